@@ -2,13 +2,19 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	_ "github.com/kozlov-ma/sesc-backend/api/docs" // This blank import is needed to serve the swagger scheme.
+	"github.com/kozlov-ma/sesc-backend/iam"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
 	"github.com/kozlov-ma/sesc-backend/sesc"
@@ -18,19 +24,72 @@ import (
 // @title SESC Management API
 // @version 1.0
 // @description API for managing SESC departments, users and permissions
+// @description The message of an error is in the language preferred by the Accept-Language header, Russian (ru) or English (en),
+// @description Russian by default. ruMessage and enMessage always hold both.
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
 // @description Enter 'Bearer ' followed by your token
 
 type API struct {
-	sesc      SESC
-	iam       IAMService
-	eventSink EventSink
+	sesc       SESC
+	iam        IAMService
+	eventSink  EventSink
+	auditLog   AuditLog
+	eventLog   EventLog
+	migrations Migrations
+	webhook    Webhook
+
+	rateLimitPerMinute int
+	requestTimeout     time.Duration
+	maxBodyBytes       int64
+	cors               CORSOptions
+	pictureHosts       []string
+	// readOnly makes the API reject writes, see ReadOnlyMiddleware.
+	readOnly atomic.Bool
+	// routes are recorded by RegisterRoutes for RoutePermissions.
+	routes []RouteAccess
 }
 
-func New(sesc SESC, iam IAMService, eventSink EventSink) *API {
-	return &API{sesc: sesc, iam: iam, eventSink: eventSink}
+// Option configures the optional behavior of the API.
+type Option func(*API)
+
+// WithRateLimit limits every client to perMinute requests per minute.
+// A non-positive perMinute disables rate limiting.
+func WithRateLimit(perMinute int) Option {
+	return func(a *API) {
+		a.rateLimitPerMinute = perMinute
+	}
+}
+
+// WithAudit records every successful mutation to the given audit log
+// and exposes it to admins under /audit.
+func WithAudit(log AuditLog) Option {
+	return func(a *API) {
+		a.auditLog = log
+	}
+}
+
+// WithEventLog exposes the last events of the given log to admins under /dev/lastEvents.
+func WithEventLog(log EventLog) Option {
+	return func(a *API) {
+		a.eventLog = log
+	}
+}
+
+// WithMigrations exposes the schema status of the database to admins under /dev/migrations.
+func WithMigrations(m Migrations) Option {
+	return func(a *API) {
+		a.migrations = m
+	}
+}
+
+func New(sesc SESC, iam IAMService, eventSink EventSink, opts ...Option) *API {
+	a := &API{sesc: sesc, iam: iam, eventSink: eventSink, maxBodyBytes: DefaultMaxBodyBytes}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // Helper functions
@@ -43,21 +102,72 @@ func (a *API) writeJSON(ctx context.Context, w http.ResponseWriter, data any, st
 	}
 }
 
+// writeCachedJSON writes data like writeJSON with status 200, setting the ETag header to etag,
+// or to a weak ETag hashing the body if etag is empty.
+// If the If-None-Match header of the request matches the ETag, it responds 304 Not Modified without a body.
+func (a *API) writeCachedJSON(ctx context.Context, w http.ResponseWriter, r *http.Request, data any, etag string) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		rec := event.Get(ctx)
+		rec.Add(events.Error, fmt.Errorf("couldn't write json: %w", err))
+		writeError(ctx, w, ErrServerError.WithStatus(http.StatusInternalServerError))
+		return
+	}
+	body = append(body, '\n')
+
+	if etag == "" {
+		sum := sha256.Sum256(body)
+		etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+	w.Header().Set("ETag", etag)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		rec := event.Get(ctx)
+		rec.Add(events.Error, fmt.Errorf("couldn't write json: %w", err))
+	}
+}
+
+// etagMatches reports whether the If-None-Match header matches the etag, using the weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 func writeError[T SpecificError](ctx context.Context, w http.ResponseWriter, apiError T) {
 	rec := event.Get(ctx)
 
-	genericError := Error(apiError)
-
 	// Set default status code if not provided
-	statusCode := http.StatusInternalServerError
-	if genericError.StatusCode != 0 {
-		statusCode = genericError.StatusCode
+	code := http.StatusInternalServerError
+	if sc := statusCode(apiError); sc != 0 {
+		code = sc
 	}
 
+	lang := GetLanguageFromContext(ctx)
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+	w.Header().Set("Content-Language", string(lang))
+	w.Header().Add("Vary", "Accept-Language")
+	w.WriteHeader(code)
 
-	rec.Sub("http").Add("error_response", apiError)
+	rec.Sub("http").Set("error_response", apiError)
+	localizeError(reflect.ValueOf(&apiError).Elem(), lang)
 
 	err := json.NewEncoder(w).Encode(apiError)
 	if err != nil {
@@ -73,55 +183,37 @@ func convertDepartment(d sesc.Department) Department {
 	}
 }
 
-const allowAllOriginsNow = true
-
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Add("Vary", "Origin")
-
-		origin := r.Header.Get("Origin")
-		if allowAllOriginsNow || isOriginAllowed(origin) {
-			w.Header().Set("Access-Control-Allow-Origin", origin)
-			w.Header().Set("Access-Control-Allow-Credentials", "true")
-		}
-
-		if r.Method == http.MethodOptions {
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			requestedHeaders := r.Header.Get("Access-Control-Request-Headers")
-			w.Header().Set("Access-Control-Allow-Headers", requestedHeaders)
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	})
-}
-
-func isOriginAllowed(origin string) bool {
-	if origin == "" {
-		return false
-	}
-	u, err := url.Parse(origin)
-	if err != nil {
-		return false
-	}
-	hostname := u.Hostname()
-	return hostname == "localhost"
-}
-
 func (a *API) RegisterRoutes(r chi.Router) {
 	r.Use(a.EventMiddleware)
+	r.Use(a.RequestIDMiddleware)
+	r.Use(a.LanguageMiddleware)
+	r.Use(a.gzipMiddleware)
 
 	// Apply global middlewares
-	r.Use(corsMiddleware)
+	r.Use(a.corsMiddleware)
 	r.Use(a.AuthMiddleware)
+	r.Use(a.ReadOnlyMiddleware)
+	if a.rateLimitPerMinute > 0 {
+		r.Use(a.RateLimitMiddleware(a.rateLimitPerMinute))
+	}
+	if a.requestTimeout > 0 {
+		r.Use(a.TimeoutMiddleware(a.requestTimeout, streamingPaths...))
+	}
+
+	a.routes = nil
 
 	// Public routes (no auth required)
 	r.Group(func(r chi.Router) {
+		r = a.guard(r, AccessPublic)
+
 		// Auth endpoints
 		r.Post("/auth/login", a.Login)
 		r.Post("/auth/admin/login", a.LoginAdmin)
 
+		// Probes
+		r.Get("/healthz", a.Health)
+		r.Get("/readyz", a.Ready)
+
 		// Public endpoints
 		r.Get("/departments", a.Departments)
 		r.Get("/roles", a.Roles)
@@ -130,41 +222,77 @@ func (a *API) RegisterRoutes(r chi.Router) {
 
 	// Protected routes (auth required)
 	r.Group(func(r chi.Router) {
-		r.Use(a.RequireAuthMiddleware)
+		r = a.guard(r, AccessAuthenticated)
 
 		// Token validation
 		r.Get("/auth/validate", a.ValidateToken)
+		r.With(a.CurrentUserMiddleware).Get("/auth/whoami", a.WhoAmI)
+
+		r.Get("/departments/{id}/head", a.DepartmentHead)
+		r.Get("/departments/{id}/users", a.DepartmentUsers)
+		r.Get("/departments/{id}/deletable", a.DepartmentDeletable)
+		r.Get("/departments/{id}/roster.pdf", a.DepartmentRosterPDF)
 
 		// User routes with current user context
 		r.Route("/users", func(r chi.Router) {
 			r.With(a.CurrentUserMiddleware).Get("/me", a.GetCurrentUser)
+			r.With(a.CurrentUserMiddleware).Post("/me/password", a.ChangePassword)
+			r.With(a.CurrentUserMiddleware).Get("/me/permissions", a.CurrentUserPermissions)
 			r.Get("/", a.GetUsers)
+			r.Post("/batch", a.GetUsersBatch)
 			r.Get("/{id}", a.GetUser)
 		})
 	})
 
 	// Admin-only routes
 	r.Group(func(r chi.Router) {
-		r.Use(a.RequireAuthMiddleware)
-		r.Use(a.RoleMiddleware("admin"))
+		r = a.guard(r, string(iam.RoleAdmin))
 
 		r.Post("/dev/fakedata", a.FakeData)
+		if a.eventLog != nil {
+			r.Get("/dev/lastEvents", a.LastEvents)
+		}
+		if a.migrations != nil {
+			r.Get("/dev/migrations", a.MigrationStatus)
+		}
+		r.Get("/dev/readonly", a.ReadOnly)
+		r.Put("/dev/readonly", a.SetReadOnly)
+		r.Get("/permissions/routes", a.RoutePermissions)
 
 		// Setting credentials for a user
 		r.Put("/users/{id}/credentials", a.RegisterUser)
+		r.Get("/users/{id}/credentials/exists", a.CredentialsExist)
+		r.Post("/users/{id}/credentials/reset", a.ResetPassword)
 
 		// Department management
 		r.Post("/departments", a.CreateDepartment)
 		r.Put("/departments/{id}", a.UpdateDepartment)
 		r.Delete("/departments/{id}", a.DeleteDepartment)
+		r.Post("/departments/{id}/transfer", a.TransferDepartmentUsers)
+		r.Get("/stats", a.Stats)
 
 		// User management
 		r.Post("/users", a.CreateUser)
+		r.Put("/users/{id}", a.ReplaceUser)
 		r.Patch("/users/{id}", a.PatchUser)
+		r.Delete("/users/{id}", a.ArchiveUser)
+		r.Post("/users/{id}/suspend", a.SuspendUser)
+		r.Post("/users/{id}/reinstate", a.ReinstateUser)
+		r.Put("/users/{id}/role", a.SetRole)
+		r.Put("/users/{id}/department", a.SetDepartment)
+		r.Post("/users/{id}/permissions", a.GrantPermissions)
+		r.Delete("/users/{id}/permissions", a.RevokePermissions)
+		r.Get("/users/{id}/history", a.EmploymentHistory)
+		r.Get("/users.csv", a.ExportUsersCSV)
 
 		// Credential management
 		r.Delete("/auth/credentials/{id}", a.DeleteCredentials)
+		r.Get("/auth/credentials", a.AllCredentials)
 		r.Get("/auth/credentials/{id}", a.GetCredentials)
+
+		if a.auditLog != nil {
+			r.Get("/audit", a.AuditEntries)
+		}
 	})
 
 	// Swagger UI
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/stretchr/testify/require"
+)
+
+// readOnlySESC is a SESC that only knows one user and one department list.
+type readOnlySESC struct {
+	SESC
+
+	user  sesc.User
+	depts []sesc.Department
+}
+
+func (s *readOnlySESC) User(_ context.Context, id sesc.UUID) (sesc.User, error) {
+	if id != s.user.ID {
+		return sesc.User{}, sesc.ErrUserNotFound
+	}
+	return s.user, nil
+}
+
+func (s *readOnlySESC) Departments(context.Context) ([]sesc.Department, error) {
+	return s.depts, nil
+}
+
+func TestConditionalGet(t *testing.T) {
+	setup := func(t *testing.T) (*readOnlySESC, func(handler http.HandlerFunc, id, ifNoneMatch string) *httptest.ResponseRecorder) {
+		t.Helper()
+		s := &readOnlySESC{
+			user: sesc.User{
+				ID:        uuid.Must(uuid.NewV7()),
+				FirstName: "Anna",
+				LastName:  "Sokolova",
+				Role:      sesc.Teacher,
+				Version:   3,
+			},
+			depts: []sesc.Department{{ID: uuid.Must(uuid.NewV7()), Name: "Math", Description: "Mathematics"}},
+		}
+
+		return s, func(handler http.HandlerFunc, id, ifNoneMatch string) *httptest.ResponseRecorder {
+			ctx, _ := event.NewRecord(t.Context(), "test")
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+			req.SetPathValue("id", id)
+			if ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", ifNoneMatch)
+			}
+			rr := httptest.NewRecorder()
+			handler(rr, req)
+			return rr
+		}
+	}
+
+	t.Run("departments", func(t *testing.T) {
+		s, get := setup(t)
+		a := New(s, nil, nil)
+
+		first := get(a.Departments, "", "")
+		require.Equal(t, http.StatusOK, first.Code)
+		etag := first.Header().Get("ETag")
+		require.Regexp(t, `^W/"[0-9a-f]+"$`, etag)
+		require.Contains(t, first.Body.String(), "Math")
+
+		second := get(a.Departments, "", etag)
+		require.Equal(t, http.StatusNotModified, second.Code)
+		require.Empty(t, second.Body.String())
+		require.Equal(t, etag, second.Header().Get("ETag"))
+
+		// Changed departments are sent again, with a new ETag
+		s.depts[0].Description = "Mathematics and statistics"
+		third := get(a.Departments, "", etag)
+		require.Equal(t, http.StatusOK, third.Code)
+		require.NotEqual(t, etag, third.Header().Get("ETag"))
+	})
+
+	t.Run("user", func(t *testing.T) {
+		s, get := setup(t)
+		a := New(s, nil, nil)
+		id := s.user.ID.String()
+
+		first := get(a.GetUser, id, "")
+		require.Equal(t, http.StatusOK, first.Code)
+		etag := first.Header().Get("ETag")
+		require.Equal(t, `"3"`, etag)
+
+		second := get(a.GetUser, id, etag)
+		require.Equal(t, http.StatusNotModified, second.Code)
+		require.Empty(t, second.Body.String())
+
+		// Weak comparison, lists and wildcards
+		require.Equal(t, http.StatusNotModified, get(a.GetUser, id, `W/"3"`).Code)
+		require.Equal(t, http.StatusNotModified, get(a.GetUser, id, `"1", "3"`).Code)
+		require.Equal(t, http.StatusNotModified, get(a.GetUser, id, "*").Code)
+
+		s.user.Version = 4
+		third := get(a.GetUser, id, etag)
+		require.Equal(t, http.StatusOK, third.Code)
+		require.Equal(t, `"4"`, third.Header().Get("ETag"))
+	})
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/audit"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// maxAuditLimit caps the number of audit entries returned in a single response.
+const maxAuditLimit = 500
+
+type AuditEntry struct {
+	ID         uuid.UUID      `json:"id"                example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
+	ActorID    uuid.UUID      `json:"actorId"           example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
+	Action     string         `json:"action"            example:"create_user"                          validate:"required"`
+	TargetType string         `json:"targetType"        example:"user"                                 validate:"required"`
+	TargetID   uuid.UUID      `json:"targetId"          example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
+	Timestamp  time.Time      `json:"timestamp"                                                        validate:"required"`
+	Details    map[string]any `json:"details,omitempty"`
+}
+
+type AuditEntriesResponse struct {
+	Entries []AuditEntry `json:"entries" validate:"required"`
+	// NextCursor is the after of the next page, omitted on the last page.
+	NextCursor uuid.UUID `json:"nextCursor,omitzero" example:"0198c3b2-7a3e-7c1a-9f2e-3b1d4c5e6f70"`
+}
+
+// AuditEntries godoc
+// @Summary List audit log entries
+// @Description Returns the most recent mutating operations in the organization of the admin, newest first,
+// @Description followed by nextCursor if there are more.
+// @Description The filters combine, from is inclusive and to is exclusive.
+// @Tags audit
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param targetId query string false "Only return entries about this user or department"
+// @Param actorId query string false "Only return entries of the operations performed by this user or admin"
+// @Param action query string false "Only return entries of this action" example(create_user)
+// @Param from query string false "Only return entries recorded at this time or later, RFC 3339" example(2025-09-01T00:00:00Z)
+// @Param to query string false "Only return entries recorded before this time, RFC 3339" example(2025-10-01T00:00:00Z)
+// @Param after query string false "Return the entries after this cursor, the nextCursor of the previous page"
+// @Param limit query int false "Maximum number of entries, 50 by default, at most 500"
+// @Success 200 {object} AuditEntriesResponse
+// @Failure 400 {object} InvalidRequestError "Invalid query parameters"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /audit [get]
+func (a *API) AuditEntries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	query := r.URL.Query()
+
+	var filter audit.Filter
+	for _, param := range []struct {
+		name string
+		dst  *uuid.UUID
+	}{
+		{"targetId", &filter.TargetID},
+		{"actorId", &filter.ActorID},
+		{"after", &filter.After},
+	} {
+		s := query.Get(param.name)
+		if s == "" {
+			continue
+		}
+		id, err := uuid.FromString(s)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails(param.name+" must be a UUID").WithStatus(http.StatusBadRequest))
+			return
+		}
+		*param.dst = id
+	}
+	filter.Action = audit.Action(query.Get("action"))
+	for _, param := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	} {
+		s := query.Get(param.name)
+		if s == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails(
+				param.name+" must be an RFC 3339 time, like 2025-09-01T00:00:00Z",
+			).WithStatus(http.StatusBadRequest))
+			return
+		}
+		*param.dst = t
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		writeError(ctx, w, ErrInvalidRequest.WithDetails("from must be before to").WithStatus(http.StatusBadRequest))
+		return
+	}
+	if s := query.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit <= 0 || limit > maxAuditLimit {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails(
+				fmt.Sprintf("limit must be a number between 1 and %d", maxAuditLimit),
+			).WithStatus(http.StatusBadRequest))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	entries, next, err := a.auditLog.Entries(ctx, filter)
+	if err != nil {
+		rec.Add(events.Error, err)
+		if errors.Is(err, audit.ErrInvalidCursor) {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails(
+				"after must be the nextCursor of a previous page",
+			).WithStatus(http.StatusBadRequest))
+			return
+		}
+		writeError(ctx, w, ErrServerError.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	response := AuditEntriesResponse{
+		Entries:    make([]AuditEntry, len(entries)),
+		NextCursor: next,
+	}
+	for i, e := range entries {
+		response.Entries[i] = AuditEntry{
+			ID:         e.ID,
+			ActorID:    e.ActorID,
+			Action:     string(e.Action),
+			TargetType: string(e.TargetType),
+			TargetID:   e.TargetID,
+			Timestamp:  e.Timestamp,
+			Details:    e.Details,
+		}
+	}
+
+	a.writeJSON(ctx, w, response, http.StatusOK)
+}
+
+// recordAudit records a successful mutation on behalf of the identity in ctx.
+// Failures are only added to the event record, so that they never fail the request itself.
+func (a *API) recordAudit(
+	ctx context.Context,
+	action audit.Action,
+	targetType audit.TargetType,
+	targetID uuid.UUID,
+	details map[string]any,
+) {
+	if a.auditLog == nil {
+		return
+	}
+
+	var actorID uuid.UUID
+	if identity, ok := GetIdentityFromContext(ctx); ok {
+		actorID = identity.ID
+	}
+
+	err := a.auditLog.Record(ctx, audit.Entry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+	})
+	if err != nil {
+		event.Get(ctx).Add(events.Error, fmt.Errorf("couldn't write audit entry: %w", err))
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/audit"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/stretchr/testify/require"
+)
+
+// departmentsSESC is a SESC that only knows how to create departments.
+type departmentsSESC struct {
+	SESC
+}
+
+func (departmentsSESC) CreateDepartment(_ context.Context, name, description string) (sesc.Department, error) {
+	return sesc.Department{ID: uuid.Must(uuid.NewV7()), Name: name, Description: description}, nil
+}
+
+// brokenAuditLog is an AuditLog that fails to record anything.
+type brokenAuditLog struct {
+	AuditLog
+}
+
+func (brokenAuditLog) Record(context.Context, audit.Entry) error {
+	return errors.New("audit log is down")
+}
+
+func TestRecordAuditFailure(t *testing.T) {
+	a := New(departmentsSESC{}, nil, nil, WithAudit(brokenAuditLog{}))
+
+	ctx := t.Context()
+	ctx, _ = event.NewRecord(ctx, "test")
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/departments", strings.NewReader(`{"name":"Math"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	a.CreateDepartment(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code, "Audit failures must not fail the request")
+}
@@ -1,13 +1,18 @@
 package api
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/audit"
 	"github.com/kozlov-ma/sesc-backend/iam"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/kozlov-ma/sesc-backend/sesc"
 )
 
 type CredentialsRequest struct {
@@ -17,11 +22,16 @@ type CredentialsRequest struct {
 
 type TokenResponse struct {
 	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..." validate:"required"`
+	// Identity is the logged in user, so that clients don't have to validate the token right away.
+	// For admins, ID is the admin ID.
+	Identity IdentityResponse `json:"identity" validate:"required"`
 }
 
 type IdentityResponse struct {
 	ID   uuid.UUID `json:"id"   example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
 	Role string    `json:"role" example:"user"                                 validate:"required"`
+	// OrgID is the organization of the identity, omitted for the default one.
+	OrgID uuid.UUID `json:"orgId,omitzero" example:"0198c3b2-7a3e-7c1a-9f2e-3b1d4c5e6f70"`
 }
 
 // RegisterUser godoc
@@ -38,6 +48,7 @@ type IdentityResponse struct {
 // @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
 // @Failure 400 {object} InvalidRequestError "Invalid request format"
 // @Failure 400 {object} InvalidCredentialsError "Invalid credentials format"
+// @Failure 400 {object} ValidationError "Username or password is missing"
 // @Failure 401 {object} UnauthorizedError "Unauthorized"
 // @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
 // @Failure 404 {object} UserNotFoundError "User does not exist"
@@ -56,8 +67,7 @@ func (a *API) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var credsReq CredentialsRequest
-	if err := json.NewDecoder(r.Body).Decode(&credsReq); err != nil {
-		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+	if !a.decodeValidJSON(w, r, &credsReq) {
 		return
 	}
 
@@ -81,6 +91,10 @@ func (a *API) RegisterUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	a.recordAudit(ctx, audit.ActionRegisterUser, audit.TargetUser, userID, map[string]any{
+		"username": creds.Username,
+	})
+
 	a.writeJSON(ctx, w, map[string]uuid.UUID{"authId": authID}, http.StatusCreated)
 }
 
@@ -93,7 +107,10 @@ func (a *API) RegisterUser(w http.ResponseWriter, r *http.Request) {
 // @Param request body CredentialsRequest true "User credentials"
 // @Success 200 {object} TokenResponse
 // @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} ValidationError "Username or password is missing"
 // @Failure 401 {object} CredentialsNotFoundError "Invalid credentials or user does not exist"
+// @Failure 401 {object} UnauthorizedError "User has been archived"
+// @Failure 429 {object} TooManyRequestsError "Too many failed login attempts"
 // @Failure 500 {object} ServerError "Internal server error"
 // @Router /auth/login [post]
 func (a *API) Login(w http.ResponseWriter, r *http.Request) {
@@ -101,8 +118,7 @@ func (a *API) Login(w http.ResponseWriter, r *http.Request) {
 	rec := event.Get(ctx)
 
 	var credsReq CredentialsRequest
-	if err := json.NewDecoder(r.Body).Decode(&credsReq); err != nil {
-		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+	if !a.decodeValidJSON(w, r, &credsReq) {
 		return
 	}
 
@@ -118,7 +134,7 @@ func (a *API) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	a.writeJSON(ctx, w, TokenResponse{Token: token}, http.StatusOK)
+	a.writeToken(ctx, w, token)
 }
 
 // LoginAdmin godoc
@@ -130,6 +146,7 @@ func (a *API) Login(w http.ResponseWriter, r *http.Request) {
 // @Param request body CredentialsRequest true "Admin credentials"
 // @Success 200 {object} TokenResponse
 // @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} ValidationError "Username or password is missing"
 // @Failure 401 {object} CredentialsNotFoundError "Invalid admin token or not recognized"
 // @Failure 500 {object} ServerError "Internal server error"
 // @Router /auth/admin/login [post]
@@ -138,8 +155,7 @@ func (a *API) LoginAdmin(w http.ResponseWriter, r *http.Request) {
 	rec := event.Get(ctx)
 
 	var req CredentialsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+	if !a.decodeValidJSON(w, r, &req) {
 		return
 	}
 
@@ -150,12 +166,34 @@ func (a *API) LoginAdmin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	a.writeJSON(ctx, w, TokenResponse{Token: token}, http.StatusOK)
+	a.writeToken(ctx, w, token)
+}
+
+// writeToken writes a TokenResponse for the just issued token, with the identity it carries.
+func (a *API) writeToken(ctx context.Context, w http.ResponseWriter, token string) {
+	rec := event.Get(ctx)
+
+	identity, err := a.iam.ImWatermelon(ctx, token)
+	if err != nil {
+		err := fmt.Errorf("couldn't parse the issued token: %w", err)
+		rec.Add(events.Error, err)
+		writeError(ctx, w, ErrServerError.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	a.writeJSON(ctx, w, TokenResponse{
+		Token: token,
+		Identity: IdentityResponse{
+			ID:    identity.ID,
+			Role:  string(identity.Role),
+			OrgID: identity.OrgID,
+		},
+	}, http.StatusOK)
 }
 
 // DeleteCredentials godoc
 // @Summary Delete user credentials
-// @Description Deletes credentials for a user
+// @Description Deletes credentials for a user. Deleting is idempotent: a user who has no credentials gets 204 too.
 // @Tags authentication
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer JWT token"
@@ -164,7 +202,7 @@ func (a *API) LoginAdmin(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
 // @Failure 401 {object} UnauthorizedError "Unauthorized"
 // @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
-// @Failure 404 {object} CredentialsNotFoundError "User credentials not found"
+// @Failure 404 {object} UserNotFoundError "User not found"
 // @Failure 500 {object} ServerError "Internal server error"
 // @Router /auth/credentials/{id} [delete]
 func (a *API) DeleteCredentials(w http.ResponseWriter, r *http.Request) {
@@ -179,12 +217,20 @@ func (a *API) DeleteCredentials(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = a.iam.DropCredentials(ctx, userID)
-	if err != nil {
+	switch {
+	case errors.Is(err, iam.ErrCredentialsNotFound):
+		// The credentials are already gone, which is what the client wants.
+		rec.Set("already_deleted", true)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case err != nil:
 		rec.Add(events.Error, err)
 		writeError(ctx, w, iamError(err))
 		return
 	}
 
+	a.recordAudit(ctx, audit.ActionDeleteCredentials, audit.TargetUser, userID, nil)
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -226,6 +272,216 @@ func (a *API) GetCredentials(w http.ResponseWriter, r *http.Request) {
 	}, http.StatusOK)
 }
 
+// AccountResponse is the username of a user. Passwords are never listed.
+type AccountResponse struct {
+	UserID   uuid.UUID `json:"userId"   example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
+	Username string    `json:"username" example:"johndoe"                              validate:"required"`
+}
+
+type AccountsResponse struct {
+	Accounts []AccountResponse `json:"accounts" validate:"required"`
+}
+
+// AllCredentials godoc
+// @Summary List the usernames of the users
+// @Description Lists the usernames of the users of the organization, except for the archived ones, ordered by username.
+// @Description Passwords are not returned.
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param departmentId query string false "Only list the users of this department"
+// @Param roleId query int false "Only list the users with this role"
+// @Success 200 {object} AccountsResponse
+// @Failure 400 {object} InvalidRequestError "Invalid query parameters"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /auth/credentials [get]
+func (a *API) AllCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	filter := iam.AccountFilter{OrgID: sesc.OrgFromContext(ctx)}
+	query := r.URL.Query()
+	if s := query.Get("departmentId"); s != "" {
+		deptID, err := uuid.FromString(s)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails("departmentId must be a UUID").WithStatus(http.StatusBadRequest))
+			return
+		}
+		filter.DepartmentID = deptID
+	}
+	if s := query.Get("roleId"); s != "" {
+		roleID, err := strconv.ParseInt(s, 10, 32)
+		if _, ok := sesc.RoleByID(int32(roleID)); err != nil || !ok {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails("roleId must be the ID of a role").WithStatus(http.StatusBadRequest))
+			return
+		}
+		filter.RoleID = int32(roleID)
+	}
+
+	accounts, err := a.iam.AllCredentials(ctx, filter)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, iamError(err))
+		return
+	}
+
+	resp := AccountsResponse{Accounts: make([]AccountResponse, len(accounts))}
+	for i, acc := range accounts {
+		resp.Accounts[i] = AccountResponse{UserID: acc.UserID, Username: acc.Username}
+	}
+	a.writeJSON(ctx, w, resp, http.StatusOK)
+}
+
+type CredentialsExistResponse struct {
+	HasCredentials bool `json:"hasCredentials" example:"true" validate:"required"`
+}
+
+// CredentialsExist godoc
+// @Summary Check whether a user has credentials
+// @Description Reports whether login credentials are set for a user
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Success 200 {object} CredentialsExistResponse
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users/{id}/credentials/exists [get]
+func (a *API) CredentialsExist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidUUID.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	exists, err := a.iam.HasCredentials(ctx, userID)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, iamError(err))
+		return
+	}
+
+	a.writeJSON(ctx, w, CredentialsExistResponse{
+		HasCredentials: exists,
+	}, http.StatusOK)
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"oldPassword" example:"secret123"    validate:"required"`
+	NewPassword string `json:"newPassword" example:"newsecret123" validate:"required"`
+}
+
+// ChangePassword godoc
+// @Summary Change own password
+// @Description Replaces the password of the current user after checking the old one
+// @Tags authentication
+// @Accept json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param request body ChangePasswordRequest true "Old and new passwords"
+// @Success 204 "No content"
+// @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} InvalidCredentialsError "Wrong old password or weak new password"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - only users can change their password"
+// @Failure 404 {object} CredentialsNotFoundError "User has no credentials"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users/me/password [post]
+func (a *API) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	user, ok := GetUserFromContext(ctx)
+	if !ok {
+		writeError(ctx, w, ErrForbidden.WithDetails("Only users can change their password").WithStatus(http.StatusForbidden))
+		return
+	}
+
+	var req ChangePasswordRequest
+	if !a.decodeJSON(w, r, &req) {
+		return
+	}
+
+	if err := a.iam.ChangePassword(ctx, user.ID, req.OldPassword, req.NewPassword); err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, iamError(err))
+		return
+	}
+
+	a.recordAudit(ctx, audit.ActionChangePassword, audit.TargetUser, user.ID, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type ResetPasswordRequest struct {
+	NewPassword string `json:"newPassword" example:"newsecret123" validate:"required"`
+}
+
+// ResetPassword godoc
+// @Summary Reset a user's password
+// @Description Replaces the password of a user without the old one, unlike the self-service /users/me/password
+// @Tags authentication
+// @Accept json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Param request body ResetPasswordRequest true "New password"
+// @Success 204 "No content"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} InvalidCredentialsError "Weak new password"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 404 {object} CredentialsNotFoundError "User has no credentials"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users/{id}/credentials/reset [post]
+func (a *API) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidUUID.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	var req ResetPasswordRequest
+	if !a.decodeJSON(w, r, &req) {
+		return
+	}
+
+	// First check that the user exists
+	_, err = a.sesc.User(ctx, userID)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	if err := a.iam.ResetPassword(ctx, userID, req.NewPassword); err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, iamError(err))
+		return
+	}
+
+	a.recordAudit(ctx, audit.ActionResetPassword, audit.TargetUser, userID, nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // ValidateToken godoc
 // @Summary Validate JWT token
 // @Description Validates a JWT token and returns the identity information
@@ -247,7 +503,50 @@ func (a *API) ValidateToken(w http.ResponseWriter, r *http.Request) {
 	}
 
 	a.writeJSON(ctx, w, IdentityResponse{
-		ID:   identity.AuthID,
-		Role: string(identity.Role),
+		ID:    identity.ID,
+		Role:  string(identity.Role),
+		OrgID: identity.OrgID,
 	}, http.StatusOK)
 }
+
+type WhoAmIResponse struct {
+	Identity IdentityResponse `json:"identity" validate:"required"`
+	// User is the record of the user, it is omitted for admins.
+	User *UserResponse `json:"user,omitzero"`
+}
+
+// WhoAmI godoc
+// @Summary Get the current identity
+// @Description Returns the identity of the token and, for non-admins, the full user record
+// @Tags authentication
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Success 200 {object} WhoAmIResponse
+// @Failure 401 {object} UnauthorizedError "Unauthorized, invalid token or suspended user"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /auth/whoami [get]
+func (a *API) WhoAmI(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	identity, ok := GetIdentityFromContext(ctx)
+	if !ok {
+		writeError(ctx, w, ErrInvalidToken.WithStatus(http.StatusUnauthorized))
+		return
+	}
+
+	response := WhoAmIResponse{
+		Identity: IdentityResponse{
+			ID:    identity.ID,
+			Role:  string(identity.Role),
+			OrgID: identity.OrgID,
+		},
+	}
+	if user, ok := GetUserFromContext(ctx); ok {
+		u := convertUser(user)
+		response.User = &u
+	}
+
+	a.writeJSON(ctx, w, response, http.StatusOK)
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	defaultCORSMethods = []string{
+		http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	}
+	defaultCORSHeaders = []string{"Authorization", "Content-Type", "If-Match", "If-None-Match", RequestIDHeader}
+)
+
+// CORSOptions describes the cross-origin requests allowed by the API.
+type CORSOptions struct {
+	// AllowedOrigins are the hosts allowed to make cross-origin requests.
+	// An entry is either an exact host, like "sesc.example.com", a wildcard like "*.example.com"
+	// that matches every subdomain of example.com, or "*" that matches any host.
+	AllowedOrigins []string
+	// AllowedMethods are returned in response to preflight requests. Defaults to all the methods used by the API.
+	AllowedMethods []string
+	// AllowedHeaders are returned in response to preflight requests. Defaults to the headers used by the API.
+	AllowedHeaders []string
+}
+
+// WithCORS allows the cross-origin requests described by opts.
+// Without it, no cross-origin requests are allowed.
+func WithCORS(opts CORSOptions) Option {
+	return func(a *API) {
+		a.cors = opts
+	}
+}
+
+func (a *API) corsMiddleware(next http.Handler) http.Handler {
+	methods := a.cors.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := a.cors.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(headers, ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+
+		origin := r.Header.Get("Origin")
+		allowed := a.isOriginAllowed(origin)
+		if allowed {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Expose-Headers", RequestIDHeader)
+		}
+
+		if r.Method == http.MethodOptions {
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isOriginAllowed reports whether the host of origin matches any of the allowed origins.
+func (a *API) isOriginAllowed(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return matchHost(strings.ToLower(u.Hostname()), a.cors.AllowedOrigins)
+}
+
+// matchHost reports whether the lowercase hostname matches any of the patterns: exact hosts,
+// "*.example.com" wildcards matching the subdomains of example.com, or "*" matching any host.
+func matchHost(hostname string, patterns []string) bool {
+	if hostname == "" {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		switch {
+		case pattern == "*":
+			return true
+		case strings.HasPrefix(pattern, "*."):
+			// The wildcard only matches subdomains, not the domain itself.
+			if strings.HasSuffix(hostname, pattern[1:]) {
+				return true
+			}
+		case hostname == pattern:
+			return true
+		}
+	}
+	return false
+}
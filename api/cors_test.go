@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	setup := func(t *testing.T, opts CORSOptions) func(method, origin string) *httptest.ResponseRecorder {
+		t.Helper()
+		handler := New(nil, nil, nil, WithCORS(opts)).corsMiddleware(
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+
+		return func(method, origin string) *httptest.ResponseRecorder {
+			req := httptest.NewRequestWithContext(t.Context(), method, "/", nil)
+			if origin != "" {
+				req.Header.Set("Origin", origin)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			return rr
+		}
+	}
+
+	t.Run("allowed origins", func(t *testing.T) {
+		serve := setup(t, CORSOptions{AllowedOrigins: []string{"sesc.example.com", "*.urfu.ru"}})
+
+		for _, origin := range []string{"https://sesc.example.com", "https://lk.urfu.ru", "http://a.b.urfu.ru:3000"} {
+			rr := serve(http.MethodGet, origin)
+			require.Equal(t, origin, rr.Header().Get("Access-Control-Allow-Origin"), origin)
+			require.Equal(t, "true", rr.Header().Get("Access-Control-Allow-Credentials"), origin)
+			require.Equal(t, "Origin", rr.Header().Get("Vary"), origin)
+		}
+	})
+
+	t.Run("disallowed origins", func(t *testing.T) {
+		serve := setup(t, CORSOptions{AllowedOrigins: []string{"sesc.example.com", "*.urfu.ru"}})
+
+		for _, origin := range []string{"https://evil.com", "https://urfu.ru", "https://sesc.example.com.evil.com", ""} {
+			rr := serve(http.MethodGet, origin)
+			require.Equal(t, http.StatusOK, rr.Code, "Disallowed origins are still served")
+			require.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"), origin)
+			require.Equal(t, "Origin", rr.Header().Get("Vary"), origin)
+		}
+	})
+
+	t.Run("any origin", func(t *testing.T) {
+		serve := setup(t, CORSOptions{AllowedOrigins: []string{"*"}})
+
+		rr := serve(http.MethodGet, "https://anything.example.org")
+		require.Equal(t, "https://anything.example.org", rr.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "X-Request-ID", rr.Header().Get("Access-Control-Expose-Headers"))
+	})
+
+	t.Run("no options", func(t *testing.T) {
+		serve := setup(t, CORSOptions{})
+
+		rr := serve(http.MethodGet, "http://localhost:3000")
+		require.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		serve := setup(t, CORSOptions{
+			AllowedOrigins: []string{"localhost"},
+			AllowedMethods: []string{http.MethodGet, http.MethodPost},
+			AllowedHeaders: []string{"Authorization"},
+		})
+
+		rr := serve(http.MethodOptions, "http://localhost:3000")
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "GET, POST", rr.Header().Get("Access-Control-Allow-Methods"))
+		require.Equal(t, "Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
+
+		rr = serve(http.MethodOptions, "https://evil.com")
+		require.Empty(t, rr.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("preflight defaults", func(t *testing.T) {
+		serve := setup(t, CORSOptions{AllowedOrigins: []string{"localhost"}})
+
+		rr := serve(http.MethodOptions, "http://localhost:3000")
+		require.Equal(t, "GET, POST, PUT, PATCH, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
+		require.Equal(t, "Authorization, Content-Type, If-Match, If-None-Match, X-Request-ID", rr.Header().Get("Access-Control-Allow-Headers"))
+	})
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxBodyBytes is the size limit of the JSON request bodies, unless set with WithMaxBodyBytes.
+const DefaultMaxBodyBytes = 1 << 20
+
+// WithMaxBodyBytes limits the size of the JSON request bodies to n bytes.
+// A non-positive n keeps the DefaultMaxBodyBytes.
+func WithMaxBodyBytes(n int64) Option {
+	return func(a *API) {
+		if n > 0 {
+			a.maxBodyBytes = n
+		}
+	}
+}
+
+// decodeJSON decodes the JSON request body into dst, rejecting the requests that don't declare
+// an application/json body, the bodies larger than the limit and the fields dst doesn't have,
+// so that a misspelled field isn't silently ignored.
+// On failure it writes an InvalidRequestError, or a ValidationError naming the unknown field, and returns false.
+func (a *API) decodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	ctx := r.Context()
+
+	if !isJSONContentType(r.Header.Get("Content-Type")) {
+		writeError(ctx, w, ErrInvalidRequest.WithDetails("expected application/json").
+			WithStatus(http.StatusUnsupportedMediaType))
+		return false
+	}
+
+	return decodeStrict(ctx, w, http.MaxBytesReader(w, r.Body, a.maxBodyBytes), dst)
+}
+
+// decodeStrict decodes the JSON in body into dst, rejecting the fields dst doesn't have.
+// On failure it writes an error like decodeJSON and returns false.
+func decodeStrict(ctx context.Context, w http.ResponseWriter, body io.Reader, dst any) bool {
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(dst)
+	if err == nil {
+		return true
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		writeError(ctx, w, ErrInvalidRequest.WithDetails(
+			fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit),
+		).WithStatus(http.StatusRequestEntityTooLarge))
+	case errors.Is(err, io.EOF):
+		writeError(ctx, w, ErrInvalidRequest.WithDetails("request body is empty").WithStatus(http.StatusBadRequest))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no dedicated error type for unknown fields.
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		writeError(ctx, w, ErrValidation.
+			WithDetails(fmt.Sprintf("unknown field %q", field)).
+			WithFields(UnknownField(field)).
+			WithStatus(http.StatusBadRequest))
+	default:
+		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+	}
+	return false
+}
+
+// decodeValidJSON decodes the JSON request body into dst like decodeJSON, and then validates it:
+// its validate tags and, if dst has one, its validate method.
+// On failure it writes an error, a ValidationError listing every invalid field if dst is invalid, and returns false.
+func (a *API) decodeValidJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if !a.decodeJSON(w, r, dst) {
+		return false
+	}
+
+	if fields := validateRequest(dst); len(fields) > 0 {
+		writeError(r.Context(), w, ErrValidation.WithFields(fields...).WithStatus(http.StatusBadRequest))
+		return false
+	}
+	return true
+}
+
+// isJSONContentType reports whether the Content-Type header declares a JSON body, with any parameters like charset.
+func isJSONContentType(header string) bool {
+	mediaType, _, err := mime.ParseMediaType(header)
+	return err == nil && mediaType == "application/json"
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	type request struct {
+		Name string `json:"name"`
+	}
+
+	setup := func(t *testing.T, opts ...Option) func(body string) (*httptest.ResponseRecorder, request, bool) {
+		t.Helper()
+		a := New(nil, nil, nil, opts...)
+
+		return func(body string) (*httptest.ResponseRecorder, request, bool) {
+			ctx, _ := event.NewRecord(t.Context(), "test")
+			req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/users", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			var dst request
+			ok := a.decodeJSON(rr, req, &dst)
+			return rr, dst, ok
+		}
+	}
+
+	t.Run("valid body", func(t *testing.T) {
+		decode := setup(t)
+
+		_, got, ok := decode(`{"name": "Math"}`)
+		require.True(t, ok)
+		require.Equal(t, "Math", got.Name)
+	})
+
+	t.Run("oversize body", func(t *testing.T) {
+		decode := setup(t, WithMaxBodyBytes(64))
+
+		rr, _, ok := decode(`{"name": "` + strings.Repeat("a", 100) + `"}`)
+		require.False(t, ok)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		require.Contains(t, rr.Body.String(), `"code":"INVALID_REQUEST"`)
+		require.Contains(t, rr.Body.String(), "larger than 64 bytes")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		decode := setup(t)
+
+		rr, _, ok := decode(`{"Name": "Math", "isAdmin": true}`)
+		require.False(t, ok)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var got ValidationError
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		require.Equal(t, "VALIDATION_ERROR", got.Code)
+		require.Equal(t, `unknown field "isAdmin"`, got.Details)
+		require.Equal(t, russianFields(UnknownField("isAdmin")), got.Fields)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		decode := setup(t)
+
+		for _, body := range []string{"", `{"name": `, `{"name": 1}`} {
+			rr, _, ok := decode(body)
+			require.False(t, ok, body)
+			require.Equal(t, http.StatusBadRequest, rr.Code, body)
+			require.Contains(t, rr.Body.String(), `"code":"INVALID_REQUEST"`, body)
+		}
+	})
+}
+
+func TestDecodeJSONContentType(t *testing.T) {
+	a := New(nil, nil, nil)
+
+	decode := func(t *testing.T, contentType string) (*httptest.ResponseRecorder, bool) {
+		t.Helper()
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/users", strings.NewReader(`{"name": "Math"}`))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rr := httptest.NewRecorder()
+
+		var dst struct {
+			Name string `json:"name"`
+		}
+		return rr, a.decodeJSON(rr, req, &dst)
+	}
+
+	t.Run("json", func(t *testing.T) {
+		for _, contentType := range []string{"application/json", "application/json; charset=utf-8", "Application/JSON"} {
+			_, ok := decode(t, contentType)
+			require.True(t, ok, contentType)
+		}
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		for _, contentType := range []string{"", "application/x-www-form-urlencoded", "multipart/form-data; boundary=x", "text/plain"} {
+			rr, ok := decode(t, contentType)
+			require.False(t, ok, contentType)
+			require.Equal(t, http.StatusUnsupportedMediaType, rr.Code, contentType)
+			require.Contains(t, rr.Body.String(), `"code":"INVALID_REQUEST"`, contentType)
+			require.Contains(t, rr.Body.String(), "expected application/json", contentType)
+		}
+	})
+}
+
+func TestDecodeValidJSON(t *testing.T) {
+	decode := func(t *testing.T, body string, dst any) (*httptest.ResponseRecorder, bool) {
+		t.Helper()
+		a := New(nil, nil, nil)
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		return rr, a.decodeValidJSON(rr, req, dst)
+	}
+
+	// fields returns the invalid fields of the ValidationError in the response, as "field: CODE".
+	fields := func(t *testing.T, rr *httptest.ResponseRecorder) []string {
+		t.Helper()
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var got ValidationError
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		require.Equal(t, "VALIDATION_ERROR", got.Code)
+
+		var res []string
+		for _, f := range got.Fields {
+			res = append(res, f.Field+": "+f.Code)
+		}
+		return res
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var req CredentialsRequest
+		_, ok := decode(t, `{"username": "john", "password": "secret"}`, &req)
+		require.True(t, ok)
+		require.Equal(t, "john", req.Username)
+	})
+
+	t.Run("missing required field", func(t *testing.T) {
+		var req CredentialsRequest
+		rr, ok := decode(t, `{"username": "john"}`, &req)
+		require.False(t, ok)
+		require.Equal(t, []string{"password: REQUIRED"}, fields(t, rr))
+	})
+
+	t.Run("nested field", func(t *testing.T) {
+		var req CreateDepartmentRequest
+		rr, ok := decode(t, `{"name": "Math", "head": {"firstName": "Anna"}}`, &req)
+		require.False(t, ok)
+		require.Equal(t, []string{"head.lastName: REQUIRED"}, fields(t, rr))
+	})
+
+	t.Run("tags and validate method", func(t *testing.T) {
+		var req CreateUserRequest
+		rr, ok := decode(t, `{"lastName": "Doe", "roleId": 999}`, &req)
+		require.False(t, ok)
+		require.Equal(t, []string{"firstName: REQUIRED", "roleId: INVALID_ROLE"}, fields(t, rr))
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		var req CredentialsRequest
+		rr, ok := decode(t, `{"username": `, &req)
+		require.False(t, ok)
+		require.Contains(t, rr.Body.String(), `"code":"INVALID_REQUEST"`)
+	})
+}
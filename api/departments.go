@@ -1,13 +1,17 @@
 package api
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/audit"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/kozlov-ma/sesc-backend/webhook"
 )
 
 type Department struct {
@@ -18,13 +22,36 @@ type Department struct {
 
 type CreateDepartmentRequest struct {
 	Name        string `json:"name"        example:"Mathematics"     validate:"required"`
-	Description string `json:"description" example:"Math department" validate:"required"`
+	Description string `json:"description" example:"Math department"`
+
+	// Head is optional. If set, the head of the department is created together with the department,
+	// and neither is created if any of them cannot be created.
+	Head *DepartmentHeadRequest `json:"head,omitzero"`
+}
+
+// DepartmentHeadRequest describes the head created together with a new department.
+// The head always gets the Dephead role and is linked to the new department.
+type DepartmentHeadRequest struct {
+	FirstName      string  `json:"firstName"               example:"Anna"                    validate:"required"`
+	LastName       string  `json:"lastName"                example:"Smirnova"                validate:"required"`
+	MiddleName     string  `json:"middleName,omitzero"     example:"Olegovna"`
+	PictureURL     string  `json:"pictureUrl,omitzero"     example:"/images/users/anna.jpg"`
+	Subdivision    string  `json:"subdivision,omitzero"    example:"Physics and mathematics"`
+	JobTitle       string  `json:"jobTitle,omitzero"       example:"Head of the department"`
+	EmploymentRate float64 `json:"employmentRate,omitzero" example:"1"`
 }
 
-type CreateDepartmentResponse = Department
+type CreateDepartmentResponse struct {
+	Department
+
+	// Head is only set if the department was created together with its head.
+	Head *UserResponse `json:"head,omitzero"`
+}
 
 type DepartmentsResponse struct {
 	Departments []Department `json:"departments" validate:"required"`
+	// Page is only returned for the pages selected by offset or limit.
+	Page *PageInfo `json:"page,omitempty"`
 }
 
 type UpdateDepartmentRequest struct {
@@ -34,10 +61,21 @@ type UpdateDepartmentRequest struct {
 
 type UpdateDepartmentResponse = Department
 
+type TransferDepartmentUsersRequest struct {
+	// TargetID is the department the users are moved to.
+	TargetID uuid.UUID `json:"targetId" example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
+}
+
+type TransferDepartmentUsersResponse struct {
+	// Moved is the number of the moved users.
+	Moved int `json:"moved" example:"12"`
+}
+
 type DepartmentNotFoundError struct {
 	Code       string `json:"code"             example:"DEPARTMENT_NOT_FOUND"`
 	Message    string `json:"message"          example:"Department not found"`
 	RuMessage  string `json:"ruMessage"        example:"Кафедра не найдена"`
+	EnMessage  string `json:"enMessage"        example:"Department not found"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -58,6 +96,7 @@ type InvalidDepartmentIDError struct {
 	Code       string `json:"code"             example:"INVALID_DEPARTMENT_ID"`
 	Message    string `json:"message"          example:"Invalid department ID"`
 	RuMessage  string `json:"ruMessage"        example:"Некорректный идентификатор кафедры"`
+	EnMessage  string `json:"enMessage"        example:"Invalid department ID"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -78,6 +117,7 @@ type InvalidDepartmentError struct {
 	Code       string `json:"code"             example:"INVALID_DEPARTMENT"`
 	Message    string `json:"message"          example:"Invalid department data"`
 	RuMessage  string `json:"ruMessage"        example:"Некорректные данные кафедры"`
+	EnMessage  string `json:"enMessage"        example:"Invalid department data"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -98,6 +138,7 @@ type DepartmentExistsError struct {
 	Code       string `json:"code"             example:"DEPARTMENT_EXISTS"`
 	Message    string `json:"message"          example:"Department with this name already exists"`
 	RuMessage  string `json:"ruMessage"        example:"Кафедра с таким названием уже существует"`
+	EnMessage  string `json:"enMessage"        example:"Department with this name already exists"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -118,6 +159,7 @@ type CannotRemoveDepartmentError struct {
 	Code       string `json:"code"             example:"CANNOT_REMOVE_DEPARTMENT"`
 	Message    string `json:"message"          example:"Cannot remove department, it still has some users"`
 	RuMessage  string `json:"ruMessage"        example:"Невозможно удалить кафедру, так как она содержит пользователей"`
+	EnMessage  string `json:"enMessage"        example:"Cannot remove department, it still has some users"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -164,15 +206,20 @@ var (
 
 // CreateDepartment godoc
 // @Summary Create a new department
-// @Description Creates a new department with the given details
+// @Description Creates a new department with the given details.
+// @Description If the head is set, the head is created with the Dephead role together with the department,
+// @Description and neither is created if any of them cannot be created.
 // @Tags departments
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer JWT token"
 // @Param request body CreateDepartmentRequest true "Department details"
-// @Success 201 {object} Department
+// @Success 201 {object} CreateDepartmentResponse
 // @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} ValidationError "One or more fields are invalid"
+// @Failure 400 {object} InvalidNameError "Invalid department or head name, or too long description"
+// @Failure 400 {object} InvalidEmploymentDataError "Invalid head employment data"
 // @Failure 401 {object} UnauthorizedError "Unauthorized"
 // @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
 // @Failure 409 {object} DepartmentExistsError "Department with this name already exists"
@@ -184,8 +231,15 @@ func (a *API) CreateDepartment(w http.ResponseWriter, r *http.Request) {
 
 	var req CreateDepartmentRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+	if !a.decodeValidJSON(w, r, &req) {
+		return
+	}
+
+	if req.Head != nil {
+		if !a.checkPictureURL(ctx, w, req.Head.PictureURL) {
+			return
+		}
+		a.createDepartmentWithHead(w, r, req)
 		return
 	}
 
@@ -196,28 +250,104 @@ func (a *API) CreateDepartment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	a.recordAudit(ctx, audit.ActionCreateDepartment, audit.TargetDepartment, dep.ID, map[string]any{
+		"name":        dep.Name,
+		"description": dep.Description,
+	})
+
+	a.writeJSON(ctx, w, CreateDepartmentResponse{
+		Department: Department{
+			ID:          dep.ID,
+			Name:        dep.Name,
+			Description: dep.Description,
+		},
+	}, http.StatusCreated)
+}
+
+// createDepartmentWithHead creates the department and its head in one go.
+func (a *API) createDepartmentWithHead(w http.ResponseWriter, r *http.Request, req CreateDepartmentRequest) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	dep, head, err := a.sesc.CreateDepartmentWithHead(ctx, req.Name, req.Description, sesc.UserUpdateOptions{
+		FirstName:      req.Head.FirstName,
+		LastName:       req.Head.LastName,
+		MiddleName:     req.Head.MiddleName,
+		PictureURL:     req.Head.PictureURL,
+		Subdivision:    req.Head.Subdivision,
+		JobTitle:       req.Head.JobTitle,
+		EmploymentRate: req.Head.EmploymentRate,
+	})
+	if err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't create department with head: %w", err))
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	a.recordAudit(ctx, audit.ActionCreateDepartment, audit.TargetDepartment, dep.ID, map[string]any{
+		"name":        dep.Name,
+		"description": dep.Description,
+		"headId":      head.ID,
+	})
+	a.recordAudit(ctx, audit.ActionCreateUser, audit.TargetUser, head.ID, map[string]any{
+		"firstName":    head.FirstName,
+		"lastName":     head.LastName,
+		"middleName":   head.MiddleName,
+		"roleId":       head.Role.ID,
+		"departmentId": head.Department.ID,
+	})
+	a.notify(ctx, webhook.UserCreated, head.ID)
+
+	resp := convertUser(head)
 	a.writeJSON(ctx, w, CreateDepartmentResponse{
-		ID:          dep.ID,
-		Name:        dep.Name,
-		Description: dep.Description,
+		Department: Department{
+			ID:          dep.ID,
+			Name:        dep.Name,
+			Description: dep.Description,
+		},
+		Head: &resp,
 	}, http.StatusCreated)
 }
 
 // Departments godoc
 // @Summary List all departments
-// @Description Retrieves list of all registered departments
+// @Description Retrieves list of all registered departments.
+// @Description With ids, only the departments with these IDs are returned in the same order, skipping the missing ones.
+// @Description Otherwise, with offset or limit, returns the page of departments ordered by name, described by page.
 // @Tags departments
 // @Produce json
+// @Param ids query string false "Comma-separated department UUIDs, at most 100"
+// @Param offset query int false "Number of departments to skip"
+// @Param limit query int false "Maximum number of departments in a page, 100 by default, at most 1000"
+// @Param If-None-Match header string false "ETag of the cached department list"
 // @Success 200 {object} DepartmentsResponse
+// @Success 304 "Departments have not changed since the ETag in If-None-Match"
+// @Header 200 {string} ETag "Weak ETag of the department list"
+// @Failure 400 {object} InvalidDepartmentIDError "Invalid UUID format or too many IDs"
 // @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
 // @Router /departments [get]
 func (a *API) Departments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	rec := event.Get(ctx)
 
+	query := r.URL.Query()
+	if query.Has("ids") {
+		a.departmentsByIDs(w, r)
+		return
+	}
+	if query.Has("offset") || query.Has("limit") {
+		a.departmentsPage(w, r)
+		return
+	}
+
 	deps, err := a.sesc.Departments(ctx)
 	if err != nil {
 		rec.Add(events.Error, fmt.Errorf("couldn't get departments: %w", err))
+		if errors.Is(err, sesc.ErrTimeout) {
+			writeError(ctx, w, sescError(err))
+			return
+		}
 		writeError(ctx, w, ErrServerError.WithStatus(http.StatusInternalServerError))
 		return
 	}
@@ -233,7 +363,221 @@ func (a *API) Departments(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	a.writeJSON(ctx, w, response, http.StatusOK)
+	a.writeCachedJSON(ctx, w, r, response, "")
+}
+
+const (
+	defaultDepartmentsPageLimit = 100
+	maxDepartmentsPageLimit     = 1000
+)
+
+// departmentsPage writes the page of departments selected by the offset and limit query parameters.
+func (a *API) departmentsPage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	offset, limit, err := parsePage(r, defaultDepartmentsPageLimit, maxDepartmentsPageLimit)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidRequest.WithDetails(err.Error()).WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	deps, total, err := a.sesc.DepartmentsPage(ctx, offset, limit)
+	if err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't get departments: %w", err))
+		if errors.Is(err, sesc.ErrTimeout) {
+			writeError(ctx, w, sescError(err))
+			return
+		}
+		writeError(ctx, w, ErrServerError.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	response := DepartmentsResponse{
+		Departments: make([]Department, len(deps)),
+		Page:        newPageInfo(offset, limit, len(deps), total),
+	}
+	for i, d := range deps {
+		response.Departments[i] = Department{
+			ID:          d.ID,
+			Name:        d.Name,
+			Description: d.Description,
+		}
+	}
+
+	a.writeCachedJSON(ctx, w, r, response, "")
+}
+
+// maxDepartmentIDs caps the number of departments fetched by ID in a single request.
+const maxDepartmentIDs = 100
+
+// departmentsByIDs writes the departments with the IDs in the ids query parameter, in the same order.
+func (a *API) departmentsByIDs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	var ids []uuid.UUID
+	for s := range strings.SplitSeq(r.URL.Query().Get("ids"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		id, err := uuid.FromString(s)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidDepartmentID.WithDetails("ids must be comma-separated UUIDs").
+				WithStatus(http.StatusBadRequest))
+			return
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) > maxDepartmentIDs {
+		writeError(ctx, w, ErrInvalidDepartmentID.WithDetails(
+			fmt.Sprintf("at most %d ids can be requested at once", maxDepartmentIDs),
+		).WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	deps, err := a.sesc.DepartmentsByIDs(ctx, ids)
+	if err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't get departments: %w", err))
+		if errors.Is(err, sesc.ErrTimeout) {
+			writeError(ctx, w, sescError(err))
+			return
+		}
+		writeError(ctx, w, ErrServerError.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	response := DepartmentsResponse{
+		Departments: make([]Department, 0, len(deps)),
+	}
+	for _, id := range ids {
+		if d, ok := deps[id]; ok {
+			response.Departments = append(response.Departments, convertDepartment(d))
+			// A repeated ID is returned once.
+			delete(deps, id)
+		}
+	}
+
+	a.writeCachedJSON(ctx, w, r, response, "")
+}
+
+// DepartmentHead godoc
+// @Summary Get the head of a department
+// @Description Retrieves the user with the department head role assigned to the department
+// @Tags departments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "Department UUID"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} InvalidDepartmentIDError "Invalid UUID format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 404 {object} DepartmentNotFoundError "Department not found"
+// @Failure 404 {object} UserNotFoundError "Department has no head"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /departments/{id}/head [get]
+func (a *API) DepartmentHead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := r.PathValue("id")
+	rec := event.Get(ctx)
+
+	var id uuid.UUID
+	if err := (&id).Parse(idStr); err != nil {
+		writeError(ctx, w, ErrInvalidDepartmentID.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	head, err := a.sesc.DepartmentHead(ctx, id)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	a.writeJSON(ctx, w, convertUser(head), http.StatusOK)
+}
+
+// DepartmentUsers godoc
+// @Summary List the users of a department
+// @Description Retrieves the users assigned to the department, except for the archived ones
+// @Tags departments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "Department UUID"
+// @Success 200 {object} UsersResponse
+// @Failure 400 {object} InvalidDepartmentIDError "Invalid UUID format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 404 {object} DepartmentNotFoundError "Department not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /departments/{id}/users [get]
+func (a *API) DepartmentUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := r.PathValue("id")
+	rec := event.Get(ctx)
+
+	var id uuid.UUID
+	if err := (&id).Parse(idStr); err != nil {
+		writeError(ctx, w, ErrInvalidDepartmentID.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	users, err := a.sesc.UsersInDepartment(ctx, id)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	a.writeJSON(ctx, w, UsersResponse{
+		Users: convertUsers(users),
+	}, http.StatusOK)
+}
+
+type DepartmentDeletableResponse struct {
+	// Deletable is true if the department has no users and can be deleted.
+	Deletable bool `json:"deletable" example:"false"`
+	// UserCount is the number of the users of the department, archived ones included.
+	UserCount int `json:"userCount" example:"3"`
+}
+
+// DepartmentDeletable godoc
+// @Summary Check whether a department can be deleted
+// @Description Reports whether the department has no users, archived ones included, and so can be deleted
+// @Tags departments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "Department UUID"
+// @Success 200 {object} DepartmentDeletableResponse
+// @Failure 400 {object} InvalidDepartmentIDError "Invalid UUID format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 404 {object} DepartmentNotFoundError "Department not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
+// @Router /departments/{id}/deletable [get]
+func (a *API) DepartmentDeletable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := r.PathValue("id")
+	rec := event.Get(ctx)
+
+	var id uuid.UUID
+	if err := (&id).Parse(idStr); err != nil {
+		writeError(ctx, w, ErrInvalidDepartmentID.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	count, err := a.sesc.DepartmentUserCount(ctx, id)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	a.writeJSON(ctx, w, DepartmentDeletableResponse{
+		Deletable: count == 0,
+		UserCount: count,
+	}, http.StatusOK)
 }
 
 // UpdateDepartment godoc
@@ -249,6 +593,7 @@ func (a *API) Departments(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} Department
 // @Failure 400 {object} InvalidDepartmentIDError "Invalid UUID format"
 // @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} InvalidNameError "Invalid department name, or too long description"
 // @Failure 401 {object} UnauthorizedError "Unauthorized"
 // @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
 // @Failure 404 {object} DepartmentNotFoundError "Department not found"
@@ -267,8 +612,7 @@ func (a *API) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req UpdateDepartmentRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+	if !a.decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -279,6 +623,11 @@ func (a *API) UpdateDepartment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	a.recordAudit(ctx, audit.ActionUpdateDepartment, audit.TargetDepartment, id, map[string]any{
+		"name":        req.Name,
+		"description": req.Description,
+	})
+
 	a.writeJSON(ctx, w, UpdateDepartmentResponse{
 		ID:          id,
 		Name:        req.Name,
@@ -319,5 +668,60 @@ func (a *API) DeleteDepartment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	a.recordAudit(ctx, audit.ActionDeleteDepartment, audit.TargetDepartment, id, nil)
+
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// TransferDepartmentUsers godoc
+// @Summary Move all the users of a department to another one
+// @Description Moves all the users of the department, archived ones included, to the target department,
+// @Description e.g. when the departments merge. The source department can be deleted afterwards.
+// @Description The users keep their roles, so the target department may end up with several heads.
+// @Tags departments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "Source department UUID"
+// @Param request body TransferDepartmentUsersRequest true "Target department"
+// @Success 200 {object} TransferDepartmentUsersResponse
+// @Failure 400 {object} InvalidDepartmentIDError "Invalid UUID format"
+// @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} ValidationError "One or more fields are invalid"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} DepartmentNotFoundError "Department not found"
+// @Failure 409 {object} InvalidDepartmentError "Target department does not exist or is the source one"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /departments/{id}/transfer [post]
+func (a *API) TransferDepartmentUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := r.PathValue("id")
+	rec := event.Get(ctx)
+
+	var id uuid.UUID
+	if err := (&id).Parse(idStr); err != nil {
+		writeError(ctx, w, ErrInvalidDepartmentID.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	var req TransferDepartmentUsersRequest
+	if !a.decodeValidJSON(w, r, &req) {
+		return
+	}
+
+	moved, err := a.sesc.TransferDepartmentUsers(ctx, id, req.TargetID)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	a.recordAudit(ctx, audit.ActionTransferUsers, audit.TargetDepartment, id, map[string]any{
+		"targetId": req.TargetID,
+		"moved":    moved,
+	})
+
+	a.writeJSON(ctx, w, TransferDepartmentUsersResponse{Moved: moved}, http.StatusOK)
+}
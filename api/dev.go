@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/kozlov-ma/sesc-backend/iam"
@@ -14,67 +17,104 @@ import (
 // FakeData godoc
 // @Summary Create a lot of fake data (for testing and development purposes)
 // @Description Creates departments, users, credentials, ...
+// @Description The same seed creates the same data, a random seed is used if it is missing or 0.
 // @Tags dev
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer JWT token"
+// @Param seed query int false "Seed of the generated data"
 // @Success 200
+// @Failure 400 {object} Error "Invalid seed"
 // @Failure 500 {object} ServerError "Internal server error"
 // @Router /dev/fakedata [post]
-func (a *API) FakeData(_ http.ResponseWriter, r *http.Request) {
+func (a *API) FakeData(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	rec := event.Get(ctx)
 
-	var fakeDepartments = []sesc.Department{
-		{
-			Name:        "Кафедра филологии",
-			Description: "Кафедра была организована в 1999 году. Первым заведующим кафедрой был В. С. Рабинович.",
-		},
-		{
-			Name:        "Кафедра гуманитарного образования",
-			Description: "Кафедра была организована в 1989 году. Первым заведующим кафедрой был В. И. Михайленко.",
-		},
-		{
-			Name:        "Кафедра иностранных языков",
-			Description: "Кафедра была организована в 1990 году. Первым заведующим кафедрой была Н. А. Столярова.",
-		},
-		{
-			Name:        "Кафедра математики",
-			Description: "Кафедра была организована в 1995 году. Первым заведующим кафедрой был В. В. Расин.",
-		},
-		{
-			Name:        "Кафедра информатики",
-			Description: "Кафедра была организована в 1995 году. Первым заведующим кафедрой был Д. Я. Шараев.",
-		},
-		{
-			Name:        "Кафедра физики и астрономии",
-			Description: "Кафедра была организована в 1993 году. Первым заведующим кафедрой был З. И. Урицкий.",
-		},
-		{
-			Name:        "Кафедра химии и биологии",
-			Description: "Кафедра была организована в 1993 году. Первым заведующим кафедрой был А. В. Гурьев.",
-		},
-		{
-			Name:        "Кафедра психофизической культуры",
-			Description: "Кафедра была организована в 1990 году. Первым заведующим кафедрой был В. Р. Малкин.",
-		},
+	var seed uint64
+	if s := r.URL.Query().Get("seed"); s != "" {
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails("seed must be a non-negative number").WithStatus(http.StatusBadRequest))
+			return
+		}
+		seed = n
 	}
+	rec.Set("seed", seed)
+	faker := gofakeit.New(seed)
 
 	depts := make([]sesc.Department, 0, len(fakeDepartments))
 	for _, d := range fakeDepartments {
-		de, err := a.sesc.CreateDepartment(ctx, d.Name, d.Description)
+		de, err := a.sesc.UpsertDepartment(ctx, d.Name, d.Description)
 		if err != nil {
 			rec.Add("error", fmt.Errorf("couldn't create department: %w", err))
+			continue
 		}
 
 		depts = append(depts, de)
 	}
 
+	for _, u := range fakeUsers(faker, depts) {
+		us, err := a.sesc.CreateUser(ctx, u)
+		if err != nil {
+			rec.Add("error", fmt.Errorf("couldn't create user: %w", err))
+			continue
+		}
+
+		_, err = a.iam.RegisterCredentials(ctx, us.ID, iam.Credentials{
+			Username: faker.Username(),
+			Password: "password",
+		})
+		if err != nil {
+			rec.Add("error", fmt.Errorf("couldn't create user: %w", err))
+			continue
+		}
+	}
+}
+
+var fakeDepartments = []sesc.Department{
+	{
+		Name:        "Кафедра филологии",
+		Description: "Кафедра была организована в 1999 году. Первым заведующим кафедрой был В. С. Рабинович.",
+	},
+	{
+		Name:        "Кафедра гуманитарного образования",
+		Description: "Кафедра была организована в 1989 году. Первым заведующим кафедрой был В. И. Михайленко.",
+	},
+	{
+		Name:        "Кафедра иностранных языков",
+		Description: "Кафедра была организована в 1990 году. Первым заведующим кафедрой была Н. А. Столярова.",
+	},
+	{
+		Name:        "Кафедра математики",
+		Description: "Кафедра была организована в 1995 году. Первым заведующим кафедрой был В. В. Расин.",
+	},
+	{
+		Name:        "Кафедра информатики",
+		Description: "Кафедра была организована в 1995 году. Первым заведующим кафедрой был Д. Я. Шараев.",
+	},
+	{
+		Name:        "Кафедра физики и астрономии",
+		Description: "Кафедра была организована в 1993 году. Первым заведующим кафедрой был З. И. Урицкий.",
+	},
+	{
+		Name:        "Кафедра химии и биологии",
+		Description: "Кафедра была организована в 1993 году. Первым заведующим кафедрой был А. В. Гурьев.",
+	},
+	{
+		Name:        "Кафедра психофизической культуры",
+		Description: "Кафедра была организована в 1990 году. Первым заведующим кафедрой был В. Р. Малкин.",
+	},
+}
+
+// fakeUsers generates the teachers and the heads of the departments, followed by the deputies,
+// drawing all the random values from faker.
+func fakeUsers(faker *gofakeit.Faker, depts []sesc.Department) []sesc.UserUpdateOptions {
 	var depheads []sesc.UserUpdateOptions
 	for _, d := range depts {
 		depheads = append(depheads, sesc.UserUpdateOptions{
-			FirstName:    gofakeit.FirstName(),
-			LastName:     gofakeit.LastName(),
-			MiddleName:   gofakeit.MiddleName(),
+			FirstName:    faker.FirstName(),
+			LastName:     faker.LastName(),
+			MiddleName:   faker.MiddleName(),
 			DepartmentID: d.ID,
 			NewRoleID:    sesc.Dephead.ID,
 		})
@@ -84,11 +124,11 @@ func (a *API) FakeData(_ http.ResponseWriter, r *http.Request) {
 	for _, d := range depts {
 		const minTeachersPerDept = 7
 		const maxTeachersPerDept = 27
-		for range gofakeit.Number(minTeachersPerDept, maxTeachersPerDept) {
+		for range faker.Number(minTeachersPerDept, maxTeachersPerDept) {
 			teachers = append(teachers, sesc.UserUpdateOptions{
-				FirstName:    gofakeit.FirstName(),
-				LastName:     gofakeit.LastName(),
-				MiddleName:   gofakeit.MiddleName(),
+				FirstName:    faker.FirstName(),
+				LastName:     faker.LastName(),
+				MiddleName:   faker.MiddleName(),
 				DepartmentID: d.ID,
 				NewRoleID:    sesc.Teacher.ID,
 			})
@@ -97,41 +137,132 @@ func (a *API) FakeData(_ http.ResponseWriter, r *http.Request) {
 
 	var deputies = []sesc.UserUpdateOptions{
 		{
-			FirstName:  gofakeit.FirstName(),
-			LastName:   gofakeit.LastName(),
-			MiddleName: gofakeit.MiddleName(),
+			FirstName:  faker.FirstName(),
+			LastName:   faker.LastName(),
+			MiddleName: faker.MiddleName(),
 			NewRoleID:  sesc.ContestDeputy.ID,
 		},
 		{
-			FirstName:  gofakeit.FirstName(),
-			LastName:   gofakeit.LastName(),
-			MiddleName: gofakeit.MiddleName(),
+			FirstName:  faker.FirstName(),
+			LastName:   faker.LastName(),
+			MiddleName: faker.MiddleName(),
 			NewRoleID:  sesc.ScientificDeputy.ID,
 		},
 		{
-			FirstName:  gofakeit.FirstName(),
-			LastName:   gofakeit.LastName(),
-			MiddleName: gofakeit.MiddleName(),
+			FirstName:  faker.FirstName(),
+			LastName:   faker.LastName(),
+			MiddleName: faker.MiddleName(),
 			NewRoleID:  sesc.DevelopmentDeputy.ID,
 		},
 	}
 
-	allUsers := slices.Concat(teachers, depheads, deputies)
+	return slices.Concat(teachers, depheads, deputies)
+}
 
-	for _, u := range allUsers {
-		us, err := a.sesc.CreateUser(ctx, u)
-		if err != nil {
-			rec.Add("error", fmt.Errorf("couldn't create user: %w", err))
+type LastEventsResponse struct {
+	// Events are the event records as nested JSON objects, with the event name under "$event".
+	Events []*event.Record `json:"events" swaggertype:"array,object" validate:"required"`
+}
+
+// LastEvents godoc
+// @Summary List the last events
+// @Description Returns the last processed event records of the admin's organization, newest first, for live debugging.
+// @Description The records of unauthenticated requests belong to the default organization.
+// @Description With the event parameter, only the records containing the event or sub-event are returned,
+// @Description pruned to it, e.g. event=sesc/create_user.
+// @Tags dev
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param event query string false "Only return this event or sub-event"
+// @Success 200 {object} LastEventsResponse
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Router /dev/lastEvents [get]
+func (a *API) LastEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := r.URL.Query().Get("event")
+	orgID := sesc.OrgFromContext(ctx)
+
+	response := LastEventsResponse{
+		Events: []*event.Record{},
+	}
+	for _, rec := range a.eventLog.LastEvents() {
+		if recordOrg(rec) != orgID {
 			continue
 		}
 
-		_, err = a.iam.RegisterCredentials(ctx, us.ID, iam.Credentials{
-			Username: gofakeit.Username(),
-			Password: "password",
-		})
-		if err != nil {
-			rec.Add("error", fmt.Errorf("couldn't create user: %w", err))
+		if name == "" || rec.EventName() == name {
+			response.Events = append(response.Events, rec)
 			continue
 		}
+
+		if filtered, ok := filterSubEvent(rec, name); ok {
+			response.Events = append(response.Events, filtered)
+		}
 	}
+
+	a.writeJSON(ctx, w, response, http.StatusOK)
+}
+
+// recordOrg returns the organization of the identity a record was made for, see AuthMiddleware,
+// or the DefaultOrg if it was not made for any.
+func recordOrg(rec *event.Record) sesc.UUID {
+	if orgID, ok := rec.Value("identity.org_id").(sesc.UUID); ok {
+		return orgID
+	}
+	return sesc.DefaultOrg
+}
+
+// filterSubEvent prunes rec to the sub-records named name, reporting whether there are any.
+func filterSubEvent(rec *event.Record, name string) (*event.Record, bool) {
+	found := false
+	filtered := rec.Filter(func(path string, _ any) bool {
+		if path == name || strings.HasSuffix(path, "."+name) {
+			found = true
+			return true
+		}
+		return false
+	})
+	return filtered, found
+}
+
+type MigrationStatusResponse struct {
+	// Hash identifies the schema of the running code.
+	Hash string `json:"hash" validate:"required"`
+	// AppliedHash identifies the last schema applied to the database, empty if none was recorded.
+	AppliedHash string `json:"appliedHash,omitzero"`
+	// AppliedAt is when the last schema was applied.
+	AppliedAt time.Time `json:"appliedAt,omitzero"`
+	// UpToDate reports whether the last applied schema is the one of the running code.
+	UpToDate bool `json:"upToDate"`
+}
+
+// MigrationStatus godoc
+// @Summary Get the database schema status
+// @Description Reports the schema of the running code, the last schema applied to the database and whether it is up to date.
+// @Tags dev
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Success 200 {object} MigrationStatusResponse
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /dev/migrations [get]
+func (a *API) MigrationStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	status, err := a.migrations.Status(ctx)
+	if err != nil {
+		writeError(ctx, w, ErrServerError.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	a.writeJSON(ctx, w, MigrationStatusResponse{
+		Hash:        status.Hash,
+		AppliedHash: status.AppliedHash,
+		AppliedAt:   status.AppliedAt,
+		UpToDate:    status.UpToDate(),
+	}, http.StatusOK)
 }
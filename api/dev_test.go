@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeUsers(t *testing.T) {
+	depts := []sesc.Department{
+		{ID: uuid.Must(uuid.NewV7()), Name: "Math"},
+		{ID: uuid.Must(uuid.NewV7()), Name: "Physics"},
+	}
+
+	// names returns the full names of the users generated with seed.
+	names := func(seed uint64) []string {
+		var names []string
+		for _, u := range fakeUsers(gofakeit.New(seed), depts) {
+			names = append(names, u.LastName+" "+u.FirstName+" "+u.MiddleName)
+		}
+		return names
+	}
+
+	t.Run("same seed", func(t *testing.T) {
+		first := names(42)
+		require.NotEmpty(t, first)
+		require.Equal(t, first, names(42))
+	})
+
+	t.Run("different seeds", func(t *testing.T) {
+		require.NotEqual(t, names(42), names(43))
+	})
+}
+
+// fixedEventLog is an EventLog keeping the given records.
+type fixedEventLog []*event.Record
+
+func (l fixedEventLog) LastEvents() []*event.Record {
+	return l
+}
+
+func TestLastEvents(t *testing.T) {
+	otherOrg := uuid.Must(uuid.NewV7())
+
+	// request returns the record of a request made for the identity of org, or for no identity if org is nil.
+	request := func(t *testing.T, path string, org *sesc.UUID) *event.Record {
+		_, rec := event.NewRecord(t.Context(), "http_request")
+		rec.Sub("http").Sub("request").Set("path", path)
+		if org != nil {
+			rec.Sub("identity").Set("org_id", *org)
+		}
+		return rec
+	}
+
+	defaultOrg := sesc.DefaultOrg
+	a := New(nil, nil, nil, WithEventLog(fixedEventLog{
+		request(t, "/default", &defaultOrg),
+		request(t, "/other", &otherOrg),
+		request(t, "/auth/login", nil),
+	}))
+
+	paths := func(t *testing.T, org sesc.UUID) []string {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		ctx = sesc.WithOrg(ctx, org)
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/dev/lastEvents", nil)
+		rr := httptest.NewRecorder()
+		a.LastEvents(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Events []struct {
+				HTTP struct {
+					Request struct {
+						Path string `json:"path"`
+					} `json:"request"`
+				} `json:"http"`
+			} `json:"events"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		var paths []string
+		for _, e := range resp.Events {
+			paths = append(paths, e.HTTP.Request.Path)
+		}
+		return paths
+	}
+
+	t.Run("default organization", func(t *testing.T) {
+		require.Equal(t, []string{"/default", "/auth/login"}, paths(t, sesc.DefaultOrg),
+			"Unauthenticated requests belong to the default organization")
+	})
+
+	t.Run("another organization", func(t *testing.T) {
+		require.Equal(t, []string{"/other"}, paths(t, otherOrg))
+	})
+}
@@ -15,6 +15,108 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the most recent mutating operations in the organization of the admin, newest first,\nfollowed by nextCursor if there are more.\nThe filters combine, from is inclusive and to is exclusive.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "List audit log entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return entries about this user or department",
+                        "name": "targetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return entries of the operations performed by this user or admin",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "create_user",
+                        "description": "Only return entries of this action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-09-01T00:00:00Z",
+                        "description": "Only return entries recorded at this time or later, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-10-01T00:00:00Z",
+                        "description": "Only return entries recorded before this time, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return the entries after this cursor, the nextCursor of the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries, 50 by default, at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.AuditEntriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/admin/login": {
             "post": {
                 "description": "Verifies admin token and returns a JWT token with admin privileges",
@@ -47,9 +149,9 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
+                        "description": "Username or password is missing",
                         "schema": {
-                            "$ref": "#/definitions/api.InvalidRequestError"
+                            "$ref": "#/definitions/api.ValidationError"
                         }
                     },
                     "401": {
@@ -67,6 +169,75 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/credentials": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the usernames of the users of the organization, except for the archived ones, ordered by username.\nPasswords are not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "List the usernames of the users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list the users of this department",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only list the users with this role",
+                        "name": "roleId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.AccountsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/credentials/{id}": {
             "get": {
                 "security": [
@@ -139,7 +310,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes credentials for a user",
+                "description": "Deletes credentials for a user. Deleting is idempotent: a user who has no credentials gets 204 too.",
                 "tags": [
                     "authentication"
                 ],
@@ -182,9 +353,9 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User credentials not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/api.CredentialsNotFoundError"
+                            "$ref": "#/definitions/api.UserNotFoundError"
                         }
                     },
                     "500": {
@@ -228,15 +399,21 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
+                        "description": "Username or password is missing",
                         "schema": {
-                            "$ref": "#/definitions/api.InvalidRequestError"
+                            "$ref": "#/definitions/api.ValidationError"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials or user does not exist",
+                        "description": "User has been archived",
                         "schema": {
-                            "$ref": "#/definitions/api.CredentialsNotFoundError"
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/api.TooManyRequestsError"
                         }
                     },
                     "500": {
@@ -293,9 +470,60 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/whoami": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the identity of the token and, for non-admins, the full user record",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Get the current identity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.WhoAmIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized, invalid token or suspended user",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
         "/departments": {
             "get": {
-                "description": "Retrieves list of all registered departments",
+                "description": "Retrieves list of all registered departments.\nWith ids, only the departments with these IDs are returned in the same order, skipping the missing ones.\nOtherwise, with offset or limit, returns the page of departments ordered by name, described by page.",
                 "produces": [
                     "application/json"
                 ],
@@ -303,11 +531,52 @@ const docTemplate = `{
                     "departments"
                 ],
                 "summary": "List all departments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated department UUIDs, at most 100",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of departments to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of departments in a page, 100 by default, at most 1000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached department list",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/api.DepartmentsResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Weak ETag of the department list"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Departments have not changed since the ETag in If-None-Match"
+                    },
+                    "400": {
+                        "description": "Invalid UUID format or too many IDs",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidDepartmentIDError"
                         }
                     },
                     "500": {
@@ -315,6 +584,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ServerError"
                         }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
                     }
                 }
             },
@@ -324,7 +599,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new department with the given details",
+                "description": "Creates a new department with the given details.\nIf the head is set, the head is created with the Dephead role together with the department,\nand neither is created if any of them cannot be created.",
                 "consumes": [
                     "application/json"
                 ],
@@ -356,13 +631,13 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/api.Department"
+                            "$ref": "#/definitions/api.CreateDepartmentResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
+                        "description": "Invalid head employment data",
                         "schema": {
-                            "$ref": "#/definitions/api.InvalidRequestError"
+                            "$ref": "#/definitions/api.InvalidEmploymentDataError"
                         }
                     },
                     "401": {
@@ -442,9 +717,9 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
+                        "description": "Invalid department name, or too long description",
                         "schema": {
-                            "$ref": "#/definitions/api.InvalidRequestError"
+                            "$ref": "#/definitions/api.InvalidNameError"
                         }
                     },
                     "401": {
@@ -548,113 +823,181 @@ const docTemplate = `{
                 }
             }
         },
-        "/dev/fakedata": {
-            "post": {
+        "/departments/{id}/deletable": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates departments, users, credentials, ...",
+                "description": "Reports whether the department has no users, archived ones included, and so can be deleted",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "dev"
+                    "departments"
                 ],
-                "summary": "Create a lot of fake data (for testing and development purposes)",
+                "summary": "Check whether a department can be deleted",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer JWT token",
                         "name": "Authorization",
                         "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.DepartmentDeletableResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidDepartmentIDError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Department not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.DepartmentNotFoundError"
+                        }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/api.ServerError"
                         }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
                     }
                 }
             }
         },
-        "/permissions": {
+        "/departments/{id}/head": {
             "get": {
-                "description": "Retrieves all available system permissions",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "permissions"
-                ],
-                "summary": "List all permissions",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.PermissionsResponse"
-                        }
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                }
-            }
-        },
-        "/roles": {
-            "get": {
-                "description": "Retrieves all system roles with their permissions",
+                ],
+                "description": "Retrieves the user with the department head role assigned to the department",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "departments"
+                ],
+                "summary": "Get the head of a department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List all roles",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.RolesResponse"
+                            "$ref": "#/definitions/api.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidDepartmentIDError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Department has no head",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/api.Error"
+                            "$ref": "#/definitions/api.ServerError"
                         }
                     }
                 }
             }
         },
-        "/users": {
+        "/departments/{id}/roster.pdf": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about all users",
+                "description": "Returns a printable list of the users of the department, except for the archived ones,\nwith their name, job title and academic title, ordered by name.\nDepartments with more than 5000 users are rejected with 422.",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "users"
+                    "departments"
                 ],
-                "summary": "Get all users registered in the system",
+                "summary": "Download the roster of a department as PDF",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer JWT token",
                         "name": "Authorization",
                         "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "PDF file with the roster",
                         "schema": {
-                            "$ref": "#/definitions/api.UsersResponse"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidDepartmentIDError"
                         }
                     },
                     "401": {
@@ -663,21 +1006,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.UnauthorizedError"
                         }
                     },
+                    "404": {
+                        "description": "Department not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.DepartmentNotFoundError"
+                        }
+                    },
+                    "422": {
+                        "description": "Department is too large for a roster",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidRequestError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/api.ServerError"
                         }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/departments/{id}/transfer": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new user with specified role (non-teacher)",
+                "description": "Moves all the users of the department, archived ones included, to the target department,\ne.g. when the departments merge. The source department can be deleted afterwards.\nThe users keep their roles, so the target department may end up with several heads.",
                 "consumes": [
                     "application/json"
                 ],
@@ -685,9 +1048,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "departments"
                 ],
-                "summary": "Create new user",
+                "summary": "Move all the users of a department to another one",
                 "parameters": [
                     {
                         "type": "string",
@@ -696,26 +1059,33 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "description": "User details",
+                        "type": "string",
+                        "description": "Source department UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target department",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.CreateUserRequest"
+                            "$ref": "#/definitions/api.TransferDepartmentUsersRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.UserResponse"
+                            "$ref": "#/definitions/api.TransferDepartmentUsersResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid name specified",
+                        "description": "One or more fields are invalid",
                         "schema": {
-                            "$ref": "#/definitions/api.InvalidNameError"
+                            "$ref": "#/definitions/api.ValidationError"
                         }
                     },
                     "401": {
@@ -730,6 +1100,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/api.ForbiddenError"
                         }
                     },
+                    "404": {
+                        "description": "Department not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.DepartmentNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Target department does not exist or is the source one",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidDepartmentError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -739,46 +1121,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me": {
+        "/departments/{id}/users": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns information about the current authenticated user",
+                "description": "Retrieves the users assigned to the department, except for the archived ones",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "departments"
                 ],
-                "summary": "Get current user information",
+                "summary": "List the users of a department",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer JWT token",
                         "name": "Authorization",
                         "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.UserResponse"
+                            "$ref": "#/definitions/api.UsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidDepartmentIDError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized or invalid token",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/api.UnauthorizedError"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Department not found",
                         "schema": {
-                            "$ref": "#/definitions/api.UserNotFoundError"
+                            "$ref": "#/definitions/api.DepartmentNotFoundError"
                         }
                     },
                     "500": {
@@ -790,21 +1185,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
+        "/dev/fakedata": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a user",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Creates departments, users, credentials, ...\nThe same seed creates the same data, a random seed is used if it is missing or 0.",
                 "tags": [
-                    "users"
+                    "dev"
                 ],
-                "summary": "Get user details",
+                "summary": "Create a lot of fake data (for testing and development purposes)",
                 "parameters": [
                     {
                         "type": "string",
@@ -813,36 +1205,20 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "User UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Seed of the generated data",
+                        "name": "seed",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/api.UserResponse"
-                        }
+                        "description": "OK"
                     },
                     "400": {
-                        "description": "Invalid UUID format",
-                        "schema": {
-                            "$ref": "#/definitions/api.InvalidUUIDError"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/api.UnauthorizedError"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Invalid seed",
                         "schema": {
-                            "$ref": "#/definitions/api.UserNotFoundError"
+                            "$ref": "#/definitions/api.Error"
                         }
                     },
                     "500": {
@@ -852,24 +1228,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/dev/lastEvents": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Applies a partial update to the user identified by {id}. Only non-nil fields in the request are applied.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the last processed event records of the admin's organization, newest first, for live debugging.\nThe records of unauthenticated requests belong to the default organization.\nWith the event parameter, only the records containing the event or sub-event are returned,\npruned to it, e.g. event=sesc/create_user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "dev"
                 ],
-                "summary": "Partially update user",
+                "summary": "List the last events",
                 "parameters": [
                     {
                         "type": "string",
@@ -879,32 +1254,16 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "User UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User fields to update",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.PatchUserRequest"
-                        }
+                        "description": "Only return this event or sub-event",
+                        "name": "event",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.UserResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid name",
-                        "schema": {
-                            "$ref": "#/definitions/api.InvalidNameError"
+                            "$ref": "#/definitions/api.LastEventsResponse"
                         }
                     },
                     "401": {
@@ -918,78 +1277,89 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ForbiddenError"
                         }
-                    },
-                    "404": {
-                        "description": "User not found",
-                        "schema": {
-                            "$ref": "#/definitions/api.UserNotFoundError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/api.ServerError"
-                        }
                     }
                 }
             }
         },
-        "/users/{id}/credentials": {
-            "put": {
+        "/dev/migrations": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Assigns username/password credentials to an existing user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Reports the schema of the running code, the last schema applied to the database and whether it is up to date.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "dev"
                 ],
-                "summary": "Register user credentials",
+                "summary": "Get the database schema status",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer JWT token",
                         "name": "Authorization",
                         "in": "header"
-                    },
-                    {
-                        "type": "string",
-                        "description": "User UUID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.CredentialsRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "AuthID",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api.MigrationStatusResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid credentials format",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/api.InvalidCredentialsError"
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/dev/readonly": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports whether the API is in read-only mode, in which all the writes are rejected with 503.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dev"
+                ],
+                "summary": "Get the read-only mode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReadOnlyResponse"
                         }
                     },
                     "401": {
@@ -1003,139 +1373,2919 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ForbiddenError"
                         }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Switches the read-only mode on or off, e.g. for the time of a migration.\nIn read-only mode all the writes, except for logins and this endpoint, are rejected with 503.\nThe mode is shared by all the organizations, so only the admins of the default one can switch it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dev"
+                ],
+                "summary": "Switch the read-only mode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Read-only mode",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ReadOnlyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReadOnlyResponse"
+                        }
                     },
-                    "404": {
-                        "description": "User does not exist",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
-                            "$ref": "#/definitions/api.UserNotFoundError"
+                            "$ref": "#/definitions/api.ValidationError"
                         }
                     },
-                    "409": {
-                        "description": "User already exists",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/api.UserExistsError"
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin of the default organization required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Always returns 200 while the process is up",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/permissions": {
+            "get": {
+                "description": "Retrieves all available system permissions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "permissions"
+                ],
+                "summary": "List all permissions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PermissionsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/permissions/routes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the method and path of every API route with the access it requires:\npublic, authenticated (any valid token) or the name of the required role, such as admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "permissions"
+                ],
+                "summary": "List who may call each route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RouteAccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks that the dependencies of the API are reachable",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Some of the dependencies are unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/api.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "description": "Retrieves all system roles with their permissions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "List all roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.RolesResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/api.ServerError"
+                            "$ref": "#/definitions/api.Error"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.CannotRemoveDepartmentError": {
+        },
+        "/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the number of the departments and of the users that are not archived, the number of the users\nwith each role, and the average employment rate of the users with a non-zero one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "departments"
+                ],
+                "summary": "Get an overview of the departments and the users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.StatsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about all users.\nWith after or limit, returns a page of users ordered by ID (roughly the order of creation), followed by nextCursor if there are more.\nWith offset, returns the page of users ordered by ID starting at offset, described by page, instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get all users registered in the system",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return suspended (true) or active (false) users",
+                        "name": "suspended",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "candidate",
+                            "doctor"
+                        ],
+                        "type": "string",
+                        "description": "Only return users with this academic degree",
+                        "name": "academicDegree",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "unspecified",
+                            "main",
+                            "internal_part_time",
+                            "external_part_time"
+                        ],
+                        "type": "string",
+                        "description": "Only return users with this employment type",
+                        "name": "employmentType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return the users after this cursor, the nextCursor of the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users to skip, cannot be combined with after",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of users in a page, 100 by default, at most 1000",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new user with specified role (non-teacher).\nIf username or password is given, the user's credentials are registered as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create new user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "User details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid credentials format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidCredentialsError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "409": {
+                        "description": "User with this username or email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserExistsError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users.csv": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams all the users registered in the system as a CSV file, suitable for opening in Excel.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export users as CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV file with users",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the users with the given IDs in one request, in the order of the IDs.\nDuplicate IDs are returned once, and the users that don't exist or are archived are omitted.\nAt most 100 IDs can be requested at once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get several users by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "IDs of the users",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UsersBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing IDs",
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns information about the current authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get current user information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the password of the current user after checking the old one",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Change own password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Old and new passwords",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Wrong old password or weak new password",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidCredentialsError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - only users can change their password",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User has no credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.CredentialsNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the permissions granted to the current user by their role or in addition to it.\nAdmins get all the permissions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "permissions"
+                ],
+                "summary": "List the permissions of the current user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.PermissionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the cached user",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "User version"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "User has not changed since the ETag in If-None-Match"
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidUUIDError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces every field of the user identified by {id} with the ones in the request.\nUnlike PATCH, omitted fields are cleared,\ne.g. the user is removed from their department if departmentId is omitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Replace user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected user version, as returned in the ETag header",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "User fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ReplaceUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "User version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employment data",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidEmploymentDataError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "User with this email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserExistsError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archives the user identified by {id}. Archived users are hidden from user listings and cannot log in.",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Archive user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidUUIDError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies a partial update to the user identified by {id}. Only non-nil fields in the request are applied.\nWith Content-Type application/merge-patch+json, the request is a JSON Merge Patch (RFC 7386) instead:\nabsent fields are left unchanged and fields set to null are cleared, e.g. a null departmentId\nremoves the user from their department. firstName, lastName, suspended and roleId can't be null,\nand a null version is the same as an absent one.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Partially update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expected user version, as returned in the ETag header",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "User fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.PatchUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "User version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid employment data",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidEmploymentDataError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "User with this email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserExistsError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/credentials": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assigns username/password credentials to an existing user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Register user credentials",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CredentialsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "AuthID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Username or password is missing",
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidationError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserExistsError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/credentials/exists": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports whether login credentials are set for a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Check whether a user has credentials",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.CredentialsExistResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidUUIDError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/credentials/reset": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the password of a user without the old one, unlike the self-service /users/me/password",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Reset a user's password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Weak new password",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidCredentialsError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User has no credentials",
+                        "schema": {
+                            "$ref": "#/definitions/api.CredentialsNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/department": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assigns the user identified by {id} to a department, or clears the department if departmentId is a nil UUID.\nOnly teachers and department heads may have a department.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Set user department",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New department",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SetDepartmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "The role of the user cannot have a department",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidRoleError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Department does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidDepartmentError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the changes of the role and the department of the user identified by {id}, oldest first.\nA missing department ID means the user had no department. The actor ID is a nil UUID\nif the change was not made on behalf of anyone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get employment history of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.EmploymentHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidUUIDError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    },
+                    "504": {
+                        "description": "Database request timed out",
+                        "schema": {
+                            "$ref": "#/definitions/api.TimeoutError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/permissions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grants permissions to the user identified by {id} in addition to the ones of their role.\nPermissions that were already granted are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Grant extra permissions to a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Permissions to grant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.GrantPermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Permission does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidPermissionError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes permissions granted to the user identified by {id}. Permissions of the user's role cannot be revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Revoke extra permissions of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Permissions to revoke",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.RevokePermissionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Permission does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidPermissionError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reinstate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lifts the suspension of the user identified by {id}",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reinstate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidUUIDError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the role of the user identified by {id}.\nOnly teachers and department heads may have a department: the role of a user with a department\ncannot be changed to any other role until the department is cleared.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Set user role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.SetRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid role or role change",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidRoleError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suspends the user identified by {id}. Suspended users cannot log in.",
+                "tags": [
+                    "users"
+                ],
+                "summary": "Suspend user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT token",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No content"
+                    },
+                    "400": {
+                        "description": "Invalid UUID format",
+                        "schema": {
+                            "$ref": "#/definitions/api.InvalidUUIDError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.UnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/api.ForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/api.UserNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ServerError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.AccountResponse": {
+            "type": "object",
+            "required": [
+                "userId",
+                "username"
+            ],
+            "properties": {
+                "userId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "api.AccountsResponse": {
+            "type": "object",
+            "required": [
+                "accounts"
+            ],
+            "properties": {
+                "accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.AccountResponse"
+                    }
+                }
+            }
+        },
+        "api.AuditEntriesResponse": {
+            "type": "object",
+            "required": [
+                "entries"
+            ],
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.AuditEntry"
+                    }
+                },
+                "nextCursor": {
+                    "description": "NextCursor is the after of the next page, omitted on the last page.",
+                    "type": "string",
+                    "example": "0198c3b2-7a3e-7c1a-9f2e-3b1d4c5e6f70"
+                }
+            }
+        },
+        "api.AuditEntry": {
+            "type": "object",
+            "required": [
+                "action",
+                "actorId",
+                "id",
+                "targetId",
+                "targetType",
+                "timestamp"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "create_user"
+                },
+                "actorId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "targetId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "targetType": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.CannotRemoveDepartmentError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "CANNOT_REMOVE_DEPARTMENT"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Cannot remove department, it still has some users"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Cannot remove department, it still has some users"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Невозможно удалить кафедру, так как она содержит пользователей"
+                }
+            }
+        },
+        "api.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "newPassword",
+                "oldPassword"
+            ],
+            "properties": {
+                "newPassword": {
+                    "type": "string",
+                    "example": "newsecret123"
+                },
+                "oldPassword": {
+                    "type": "string",
+                    "example": "secret123"
+                }
+            }
+        },
+        "api.CreateDepartmentRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Math department"
+                },
+                "head": {
+                    "description": "Head is optional. If set, the head of the department is created together with the department,\nand neither is created if any of them cannot be created.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.DepartmentHeadRequest"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mathematics"
+                }
+            }
+        },
+        "api.CreateDepartmentResponse": {
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Math department"
+                },
+                "head": {
+                    "description": "Head is only set if the department was created together with its head.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.UserResponse"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mathematics"
+                }
+            }
+        },
+        "api.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "firstName",
+                "lastName",
+                "roleId"
+            ],
+            "properties": {
+                "academicDegree": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "candidate",
+                        "doctor"
+                    ],
+                    "example": "candidate"
+                },
+                "academicTitle": {
+                    "type": "string",
+                    "example": "Docent"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Highest"
+                },
+                "dateOfEmployment": {
+                    "type": "string",
+                    "example": "2015-09-01T00:00:00Z"
+                },
+                "departmentId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "asmirnova@sesc.ru"
+                },
+                "employmentRate": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "employmentType": {
+                    "type": "string",
+                    "enum": [
+                        "unspecified",
+                        "main",
+                        "internal_part_time",
+                        "external_part_time"
+                    ],
+                    "example": "main"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "Anna"
+                },
+                "honors": {
+                    "type": "string",
+                    "example": "Honored Teacher"
+                },
+                "jobTitle": {
+                    "type": "string",
+                    "example": "Teacher of mathematics"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Smirnova"
+                },
+                "middleName": {
+                    "type": "string",
+                    "example": "Olegovna"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                },
+                "personnelCategory": {
+                    "type": "string",
+                    "enum": [
+                        "unspecified",
+                        "pedagogical",
+                        "administrative",
+                        "educational_support",
+                        "service"
+                    ],
+                    "example": "pedagogical"
+                },
+                "pictureUrl": {
+                    "type": "string",
+                    "example": "/images/users/ivan.jpg"
+                },
+                "roleId": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "subdivision": {
+                    "type": "string",
+                    "example": "Physics and mathematics"
+                },
+                "unemploymentDate": {
+                    "type": "string",
+                    "example": "2024-06-30T00:00:00Z"
+                },
+                "username": {
+                    "description": "Username and Password are optional. If either is set, the credentials are registered\ntogether with the user, and the user is not created if the registration fails.",
+                    "type": "string",
+                    "example": "asmirnova"
+                }
+            }
+        },
+        "api.CredentialsExistResponse": {
+            "type": "object",
+            "required": [
+                "hasCredentials"
+            ],
+            "properties": {
+                "hasCredentials": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "api.CredentialsNotFoundError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "CREDENTIALS_NOT_FOUND"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "User credentials not found"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User credentials not found"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Учетные данные пользователя не найдены"
+                }
+            }
+        },
+        "api.CredentialsRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "secret123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "api.Department": {
+            "type": "object",
+            "required": [
+                "description",
+                "id",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Math department"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mathematics"
+                }
+            }
+        },
+        "api.DepartmentDeletableResponse": {
+            "type": "object",
+            "properties": {
+                "deletable": {
+                    "description": "Deletable is true if the department has no users and can be deleted.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "userCount": {
+                    "description": "UserCount is the number of the users of the department, archived ones included.",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "api.DepartmentExistsError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "DEPARTMENT_EXISTS"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Department with this name already exists"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Department with this name already exists"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Кафедра с таким названием уже существует"
+                }
+            }
+        },
+        "api.DepartmentHeadRequest": {
+            "type": "object",
+            "required": [
+                "firstName",
+                "lastName"
+            ],
+            "properties": {
+                "employmentRate": {
+                    "type": "number",
+                    "example": 1
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "Anna"
+                },
+                "jobTitle": {
+                    "type": "string",
+                    "example": "Head of the department"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Smirnova"
+                },
+                "middleName": {
+                    "type": "string",
+                    "example": "Olegovna"
+                },
+                "pictureUrl": {
+                    "type": "string",
+                    "example": "/images/users/anna.jpg"
+                },
+                "subdivision": {
+                    "type": "string",
+                    "example": "Physics and mathematics"
+                }
+            }
+        },
+        "api.DepartmentNotFoundError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "DEPARTMENT_NOT_FOUND"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Department not found"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Department not found"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Кафедра не найдена"
+                }
+            }
+        },
+        "api.DepartmentsResponse": {
+            "type": "object",
+            "required": [
+                "departments"
+            ],
+            "properties": {
+                "departments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Department"
+                    }
+                },
+                "page": {
+                    "description": "Page is only returned for the pages selected by offset or limit.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.PageInfo"
+                        }
+                    ]
+                }
+            }
+        },
+        "api.EmploymentEventResponse": {
+            "type": "object",
+            "required": [
+                "actorId",
+                "id",
+                "newRoleId",
+                "oldRoleId",
+                "timestamp"
+            ],
+            "properties": {
+                "actorId": {
+                    "type": "string",
+                    "example": "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0196a1b2-7c3d-7e4f-8a9b-0c1d2e3f4a5b"
+                },
+                "newDepartmentId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "newRoleId": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "oldDepartmentId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "oldRoleId": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-01-01T12:00:00Z"
+                }
+            }
+        },
+        "api.EmploymentHistoryResponse": {
+            "type": "object",
+            "required": [
+                "events"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.EmploymentEventResponse"
+                    }
+                }
+            }
+        },
+        "api.Error": {
+            "type": "object",
+            "required": [
+                "code",
+                "enMessage",
+                "message",
+                "ruMessage"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "details": {
+                    "type": "string",
+                    "example": "field X is required"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Некорректный формат запроса"
+                }
+            }
+        },
+        "api.FieldError": {
+            "type": "object",
+            "required": [
+                "code",
+                "enMessage",
+                "field",
+                "message",
+                "ruMessage"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "REQUIRED"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Field is required"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "firstName"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Field is required"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Обязательное поле"
+                }
+            }
+        },
+        "api.ForbiddenError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "FORBIDDEN"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Forbidden - insufficient permissions"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Forbidden - insufficient permissions"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Доступ запрещен - недостаточно прав"
+                }
+            }
+        },
+        "api.GrantPermissionsRequest": {
+            "type": "object",
+            "required": [
+                "permissionIds"
+            ],
+            "properties": {
+                "permissionIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                }
+            }
+        },
+        "api.HealthResponse": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "api.IdentityResponse": {
+            "type": "object",
+            "required": [
+                "id",
+                "role"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "orgId": {
+                    "description": "OrgID is the organization of the identity, omitted for the default one.",
+                    "type": "string",
+                    "example": "0198c3b2-7a3e-7c1a-9f2e-3b1d4c5e6f70"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                }
+            }
+        },
+        "api.InvalidCredentialsError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_CREDENTIALS"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid credentials format"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid credentials format"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Неверный формат учетных данных"
+                }
+            }
+        },
+        "api.InvalidDepartmentError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_DEPARTMENT"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid department data"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid department data"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Некорректные данные кафедры"
+                }
+            }
+        },
+        "api.InvalidDepartmentIDError": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string",
-                    "example": "CANNOT_REMOVE_DEPARTMENT"
+                    "example": "INVALID_DEPARTMENT_ID"
                 },
                 "details": {
                     "type": "string"
                 },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid department ID"
+                },
                 "message": {
                     "type": "string",
-                    "example": "Cannot remove department, it still has some users"
+                    "example": "Invalid department ID"
                 },
                 "ruMessage": {
                     "type": "string",
-                    "example": "Невозможно удалить кафедру, так как она содержит пользователей"
+                    "example": "Некорректный идентификатор кафедры"
                 }
             }
         },
-        "api.CreateDepartmentRequest": {
+        "api.InvalidEmploymentDataError": {
             "type": "object",
-            "required": [
-                "description",
-                "name"
-            ],
             "properties": {
-                "description": {
+                "code": {
                     "type": "string",
-                    "example": "Math department"
+                    "example": "INVALID_EMPLOYMENT_DATA"
                 },
-                "name": {
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
                     "type": "string",
-                    "example": "Mathematics"
+                    "example": "Invalid employment data"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid employment data"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Указаны некорректные данные о трудоустройстве"
                 }
             }
         },
-        "api.CreateUserRequest": {
+        "api.InvalidNameError": {
             "type": "object",
-            "required": [
-                "firstName",
-                "lastName",
-                "roleId"
-            ],
             "properties": {
-                "departmentId": {
+                "code": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "INVALID_NAME"
                 },
-                "firstName": {
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
                     "type": "string",
-                    "example": "Anna"
+                    "example": "Invalid name specified"
                 },
-                "lastName": {
+                "message": {
                     "type": "string",
-                    "example": "Smirnova"
+                    "example": "Invalid name specified"
                 },
-                "middleName": {
+                "ruMessage": {
                     "type": "string",
-                    "example": "Olegovna"
+                    "example": "Указано некорректное имя"
+                }
+            }
+        },
+        "api.InvalidPermissionError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_PERMISSION"
                 },
-                "pictureUrl": {
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
                     "type": "string",
-                    "example": "/images/users/ivan.jpg"
+                    "example": "Invalid permission ID"
                 },
-                "roleId": {
-                    "type": "integer",
-                    "example": 2
+                "message": {
+                    "type": "string",
+                    "example": "Invalid permission ID"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Некорректный идентификатор разрешения"
                 }
             }
         },
-        "api.CredentialsNotFoundError": {
+        "api.InvalidRequestError": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string",
-                    "example": "CREDENTIALS_NOT_FOUND"
+                    "example": "INVALID_REQUEST"
+                },
+                "details": {
+                    "type": "string",
+                    "example": "field X is required"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid request body"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Некорректный формат запроса"
+                }
+            }
+        },
+        "api.InvalidRoleError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_ROLE"
                 },
                 "details": {
                     "type": "string"
                 },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid role ID specified"
+                },
                 "message": {
                     "type": "string",
-                    "example": "User credentials not found"
+                    "example": "Invalid role ID specified"
                 },
                 "ruMessage": {
                     "type": "string",
-                    "example": "Учетные данные пользователя не найдены"
+                    "example": "Указана некорректная роль"
                 }
             }
         },
-        "api.CredentialsRequest": {
+        "api.InvalidTokenError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_TOKEN"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid or expired token"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid or expired token"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Недействительный или просроченный токен"
+                }
+            }
+        },
+        "api.InvalidUUIDError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_UUID"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid UUID format"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid UUID format"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Некорректный формат UUID"
+                }
+            }
+        },
+        "api.LastEventsResponse": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "events"
             ],
             "properties": {
-                "password": {
+                "events": {
+                    "description": "Events are the event records as nested JSON objects, with the event name under \"$event\".",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                }
+            }
+        },
+        "api.MigrationStatusResponse": {
+            "type": "object",
+            "required": [
+                "hash"
+            ],
+            "properties": {
+                "appliedAt": {
+                    "description": "AppliedAt is when the last schema was applied.",
+                    "type": "string"
+                },
+                "appliedHash": {
+                    "description": "AppliedHash identifies the last schema applied to the database, empty if none was recorded.",
+                    "type": "string"
+                },
+                "hash": {
+                    "description": "Hash identifies the schema of the running code.",
+                    "type": "string"
+                },
+                "upToDate": {
+                    "description": "UpToDate reports whether the last applied schema is the one of the running code.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.PageInfo": {
+            "type": "object",
+            "properties": {
+                "hasMore": {
+                    "description": "HasMore is true unless this is the last page.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "description": "Total is the number of the items in the whole list.",
+                    "type": "integer",
+                    "example": 250
+                }
+            }
+        },
+        "api.PatchUserRequest": {
+            "type": "object",
+            "required": [
+                "firstName",
+                "lastName",
+                "roleId",
+                "suspended"
+            ],
+            "properties": {
+                "academicDegree": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "candidate",
+                        "doctor"
+                    ],
+                    "example": "candidate"
+                },
+                "academicTitle": {
+                    "type": "string",
+                    "example": "Docent"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Highest"
+                },
+                "dateOfEmployment": {
+                    "type": "string",
+                    "example": "2015-09-01T00:00:00Z"
+                },
+                "departmentId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "ipetrov@sesc.ru"
+                },
+                "employmentRate": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "employmentType": {
+                    "type": "string",
+                    "enum": [
+                        "unspecified",
+                        "main",
+                        "internal_part_time",
+                        "external_part_time"
+                    ],
+                    "example": "main"
+                },
+                "firstName": {
+                    "type": "string",
+                    "example": "Ivan"
+                },
+                "honors": {
+                    "type": "string",
+                    "example": "Honored Teacher"
+                },
+                "jobTitle": {
+                    "type": "string",
+                    "example": "Teacher of mathematics"
+                },
+                "lastName": {
+                    "type": "string",
+                    "example": "Petrov"
+                },
+                "middleName": {
+                    "type": "string",
+                    "example": "Sergeevich"
+                },
+                "personnelCategory": {
+                    "type": "string",
+                    "enum": [
+                        "unspecified",
+                        "pedagogical",
+                        "administrative",
+                        "educational_support",
+                        "service"
+                    ],
+                    "example": "pedagogical"
+                },
+                "pictureUrl": {
+                    "type": "string",
+                    "example": "/images/users/ivan.jpg"
+                },
+                "roleId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subdivision": {
                     "type": "string",
-                    "example": "secret123"
+                    "example": "Physics and mathematics"
                 },
-                "username": {
+                "suspended": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "unemploymentDate": {
                     "type": "string",
-                    "example": "johndoe"
+                    "example": "2024-06-30T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "api.Department": {
+        "api.Permission": {
             "type": "object",
             "required": [
                 "description",
@@ -1145,415 +4295,480 @@ const docTemplate = `{
             "properties": {
                 "description": {
                     "type": "string",
-                    "example": "Math department"
+                    "example": "Создание и заполнение листа достижений"
                 },
                 "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "type": "integer",
+                    "example": 1
                 },
                 "name": {
                     "type": "string",
-                    "example": "Mathematics"
+                    "example": "draft_achievement_list"
                 }
             }
         },
-        "api.DepartmentExistsError": {
+        "api.PermissionsResponse": {
             "type": "object",
+            "required": [
+                "permissions"
+            ],
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "DEPARTMENT_EXISTS"
-                },
-                "details": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Department with this name already exists"
-                },
-                "ruMessage": {
-                    "type": "string",
-                    "example": "Кафедра с таким названием уже существует"
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Permission"
+                    }
                 }
             }
         },
-        "api.DepartmentNotFoundError": {
+        "api.ReadOnlyRequest": {
             "type": "object",
+            "required": [
+                "readOnly"
+            ],
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "DEPARTMENT_NOT_FOUND"
-                },
-                "details": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Department not found"
-                },
-                "ruMessage": {
-                    "type": "string",
-                    "example": "Кафедра не найдена"
+                "readOnly": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "api.DepartmentsResponse": {
+        "api.ReadOnlyResponse": {
+            "type": "object",
+            "properties": {
+                "readOnly": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "api.ReadinessResponse": {
             "type": "object",
             "required": [
-                "departments"
+                "status"
             ],
             "properties": {
-                "departments": {
+                "failed": {
+                    "description": "Failed lists the dependencies that are not available.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/api.Department"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "postgres"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "example": "unavailable"
                 }
             }
         },
-        "api.Error": {
+        "api.ReplaceUserRequest": {
             "type": "object",
             "required": [
-                "code",
-                "message",
-                "ruMessage"
+                "firstName",
+                "lastName",
+                "roleId"
             ],
             "properties": {
-                "code": {
+                "academicDegree": {
                     "type": "string",
-                    "example": "INVALID_REQUEST"
+                    "enum": [
+                        "none",
+                        "candidate",
+                        "doctor"
+                    ],
+                    "example": "candidate"
                 },
-                "details": {
+                "academicTitle": {
                     "type": "string",
-                    "example": "field X is required"
+                    "example": "Docent"
                 },
-                "message": {
+                "category": {
                     "type": "string",
-                    "example": "Invalid request body"
+                    "example": "Highest"
                 },
-                "ruMessage": {
-                    "type": "string",
-                    "example": "Некорректный формат запроса"
-                }
-            }
-        },
-        "api.ForbiddenError": {
-            "type": "object",
-            "properties": {
-                "code": {
+                "dateOfEmployment": {
                     "type": "string",
-                    "example": "FORBIDDEN"
+                    "example": "2015-09-01T00:00:00Z"
                 },
-                "details": {
-                    "type": "string"
+                "departmentId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "message": {
+                "email": {
                     "type": "string",
-                    "example": "Forbidden - insufficient permissions"
+                    "example": "ipetrov@sesc.ru"
                 },
-                "ruMessage": {
+                "employmentRate": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "employmentType": {
                     "type": "string",
-                    "example": "Доступ запрещен - недостаточно прав"
-                }
-            }
-        },
-        "api.IdentityResponse": {
-            "type": "object",
-            "required": [
-                "id",
-                "role"
-            ],
-            "properties": {
-                "id": {
+                    "enum": [
+                        "unspecified",
+                        "main",
+                        "internal_part_time",
+                        "external_part_time"
+                    ],
+                    "example": "main"
+                },
+                "firstName": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "Ivan"
                 },
-                "role": {
+                "honors": {
                     "type": "string",
-                    "example": "user"
-                }
-            }
-        },
-        "api.InvalidCredentialsError": {
-            "type": "object",
-            "properties": {
-                "code": {
+                    "example": "Honored Teacher"
+                },
+                "jobTitle": {
                     "type": "string",
-                    "example": "INVALID_CREDENTIALS"
+                    "example": "Teacher of mathematics"
                 },
-                "details": {
-                    "type": "string"
+                "lastName": {
+                    "type": "string",
+                    "example": "Petrov"
                 },
-                "message": {
+                "middleName": {
                     "type": "string",
-                    "example": "Invalid credentials format"
+                    "example": "Sergeevich"
                 },
-                "ruMessage": {
+                "personnelCategory": {
                     "type": "string",
-                    "example": "Неверный формат учетных данных"
-                }
-            }
-        },
-        "api.InvalidDepartmentIDError": {
-            "type": "object",
-            "properties": {
-                "code": {
+                    "enum": [
+                        "unspecified",
+                        "pedagogical",
+                        "administrative",
+                        "educational_support",
+                        "service"
+                    ],
+                    "example": "pedagogical"
+                },
+                "pictureUrl": {
                     "type": "string",
-                    "example": "INVALID_DEPARTMENT_ID"
+                    "example": "/images/users/ivan.jpg"
                 },
-                "details": {
-                    "type": "string"
+                "roleId": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "message": {
+                "subdivision": {
                     "type": "string",
-                    "example": "Invalid department ID"
+                    "example": "Physics and mathematics"
                 },
-                "ruMessage": {
+                "suspended": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "unemploymentDate": {
                     "type": "string",
-                    "example": "Некорректный идентификатор кафедры"
+                    "example": "2024-06-30T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "api.InvalidNameError": {
+        "api.ResetPasswordRequest": {
             "type": "object",
+            "required": [
+                "newPassword"
+            ],
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "INVALID_NAME"
-                },
-                "details": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Invalid name specified"
-                },
-                "ruMessage": {
+                "newPassword": {
                     "type": "string",
-                    "example": "Указано некорректное имя"
+                    "example": "newsecret123"
                 }
             }
         },
-        "api.InvalidRequestError": {
+        "api.RevokePermissionsRequest": {
             "type": "object",
+            "required": [
+                "permissionIds"
+            ],
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "INVALID_REQUEST"
+                "permissionIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3
+                    ]
+                }
+            }
+        },
+        "api.Role": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "details": {
+                "name": {
                     "type": "string",
-                    "example": "field X is required"
+                    "example": "Преподаватель"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Invalid request body"
+                "parentId": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "ruMessage": {
-                    "type": "string",
-                    "example": "Некорректный формат запроса"
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Permission"
+                    }
                 }
             }
         },
-        "api.InvalidRoleError": {
+        "api.RoleCount": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "INVALID_ROLE"
-                },
-                "details": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Invalid role ID specified"
+                "count": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "ruMessage": {
-                    "type": "string",
-                    "example": "Указана некорректная роль"
+                "roleId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "api.RolesResponse": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Role"
+                    }
                 }
             }
         },
-        "api.InvalidTokenError": {
+        "api.RouteAccess": {
             "type": "object",
             "properties": {
-                "code": {
+                "access": {
                     "type": "string",
-                    "example": "INVALID_TOKEN"
-                },
-                "details": {
-                    "type": "string"
+                    "example": "admin"
                 },
-                "message": {
+                "method": {
                     "type": "string",
-                    "example": "Invalid or expired token"
+                    "example": "POST"
                 },
-                "ruMessage": {
+                "path": {
                     "type": "string",
-                    "example": "Недействительный или просроченный токен"
+                    "example": "/users"
                 }
             }
         },
-        "api.InvalidUUIDError": {
+        "api.RouteAccessResponse": {
+            "type": "object",
+            "required": [
+                "routes"
+            ],
+            "properties": {
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.RouteAccess"
+                    }
+                }
+            }
+        },
+        "api.ServerError": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string",
-                    "example": "INVALID_UUID"
+                    "example": "SERVER_ERROR"
                 },
                 "details": {
                     "type": "string"
                 },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Internal server error"
+                },
                 "message": {
                     "type": "string",
-                    "example": "Invalid UUID format"
+                    "example": "Internal server error"
                 },
                 "ruMessage": {
                     "type": "string",
-                    "example": "Некорректный формат UUID"
+                    "example": "Внутренняя ошибка сервера"
                 }
             }
         },
-        "api.PatchUserRequest": {
+        "api.SetDepartmentRequest": {
             "type": "object",
-            "required": [
-                "firstName",
-                "lastName",
-                "roleId",
-                "suspended"
-            ],
             "properties": {
                 "departmentId": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "firstName": {
-                    "type": "string",
-                    "example": "Ivan"
-                },
-                "lastName": {
-                    "type": "string",
-                    "example": "Petrov"
-                },
-                "middleName": {
-                    "type": "string",
-                    "example": "Sergeevich"
-                },
-                "pictureUrl": {
-                    "type": "string",
-                    "example": "/images/users/ivan.jpg"
-                },
-                "roleId": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "suspended": {
-                    "type": "boolean",
-                    "example": false
                 }
             }
         },
-        "api.Permission": {
+        "api.SetRoleRequest": {
             "type": "object",
             "required": [
-                "description",
-                "id",
-                "name"
+                "roleId"
             ],
             "properties": {
-                "description": {
+                "roleId": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "api.StaleUserError": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string",
-                    "example": "Создание и заполнение листа достижений"
+                    "example": "STALE_USER"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "details": {
+                    "type": "string"
                 },
-                "name": {
+                "enMessage": {
                     "type": "string",
-                    "example": "draft_achievement_list"
+                    "example": "User has been modified since it was read"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "User has been modified since it was read"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Пользователь был изменён с момента загрузки"
                 }
             }
         },
-        "api.PermissionsResponse": {
+        "api.StatsResponse": {
             "type": "object",
             "required": [
-                "permissions"
+                "usersByRole"
             ],
             "properties": {
-                "permissions": {
+                "averageEmploymentRate": {
+                    "type": "number",
+                    "example": 0.85
+                },
+                "departments": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "users": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "usersByRole": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/api.Permission"
+                        "$ref": "#/definitions/api.RoleCount"
                     }
                 }
             }
         },
-        "api.Role": {
+        "api.TimeoutError": {
             "type": "object",
-            "required": [
-                "id",
-                "name",
-                "permissions"
-            ],
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "code": {
+                    "type": "string",
+                    "example": "TIMEOUT"
                 },
-                "name": {
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
                     "type": "string",
-                    "example": "Преподаватель"
+                    "example": "Request timed out"
                 },
-                "permissions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.Permission"
-                    }
+                "message": {
+                    "type": "string",
+                    "example": "Request timed out"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Превышено время ожидания запроса"
                 }
             }
         },
-        "api.RolesResponse": {
+        "api.TokenResponse": {
             "type": "object",
+            "required": [
+                "identity",
+                "token"
+            ],
             "properties": {
-                "roles": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/api.Role"
-                    }
+                "identity": {
+                    "description": "Identity is the logged in user, so that clients don't have to validate the token right away.\nFor admins, ID is the admin ID.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.IdentityResponse"
+                        }
+                    ]
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 }
             }
         },
-        "api.ServerError": {
+        "api.TooManyRequestsError": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "string",
-                    "example": "SERVER_ERROR"
+                    "example": "TOO_MANY_REQUESTS"
                 },
                 "details": {
                     "type": "string"
                 },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Too many requests"
+                },
                 "message": {
                     "type": "string",
-                    "example": "Internal server error"
+                    "example": "Too many requests"
                 },
                 "ruMessage": {
                     "type": "string",
-                    "example": "Внутренняя ошибка сервера"
+                    "example": "Слишком много запросов"
                 }
             }
         },
-        "api.TokenResponse": {
+        "api.TransferDepartmentUsersRequest": {
             "type": "object",
             "required": [
-                "token"
+                "targetId"
             ],
             "properties": {
-                "token": {
+                "targetId": {
+                    "description": "TargetID is the department the users are moved to.",
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "api.TransferDepartmentUsersResponse": {
+            "type": "object",
+            "properties": {
+                "moved": {
+                    "description": "Moved is the number of the moved users.",
+                    "type": "integer",
+                    "example": 12
                 }
             }
         },
@@ -1567,6 +4782,10 @@ const docTemplate = `{
                 "details": {
                     "type": "string"
                 },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Unauthorized access"
+                },
                 "message": {
                     "type": "string",
                     "example": "Unauthorized access"
@@ -1604,6 +4823,10 @@ const docTemplate = `{
                 "details": {
                     "type": "string"
                 },
+                "enMessage": {
+                    "type": "string",
+                    "example": "User with this username already exists"
+                },
                 "message": {
                     "type": "string",
                     "example": "User with this username already exists"
@@ -1624,6 +4847,10 @@ const docTemplate = `{
                 "details": {
                     "type": "string"
                 },
+                "enMessage": {
+                    "type": "string",
+                    "example": "User does not exist"
+                },
                 "message": {
                     "type": "string",
                     "example": "User does not exist"
@@ -1637,25 +4864,80 @@ const docTemplate = `{
         "api.UserResponse": {
             "type": "object",
             "required": [
+                "extraPermissions",
                 "firstName",
                 "id",
                 "lastName",
                 "pictureUrl",
                 "role",
-                "suspended"
+                "suspended",
+                "version"
             ],
             "properties": {
+                "academicDegree": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "candidate",
+                        "doctor"
+                    ],
+                    "example": "candidate"
+                },
+                "academicTitle": {
+                    "type": "string",
+                    "example": "Docent"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Highest"
+                },
+                "dateOfEmployment": {
+                    "type": "string",
+                    "example": "2015-09-01T00:00:00Z"
+                },
                 "department": {
                     "$ref": "#/definitions/api.Department"
                 },
+                "email": {
+                    "type": "string",
+                    "example": "ipetrov@sesc.ru"
+                },
+                "employmentRate": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "employmentType": {
+                    "type": "string",
+                    "enum": [
+                        "unspecified",
+                        "main",
+                        "internal_part_time",
+                        "external_part_time"
+                    ],
+                    "example": "main"
+                },
+                "extraPermissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.Permission"
+                    }
+                },
                 "firstName": {
                     "type": "string",
                     "example": "Ivan"
                 },
+                "honors": {
+                    "type": "string",
+                    "example": "Honored Teacher"
+                },
                 "id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
+                "jobTitle": {
+                    "type": "string",
+                    "example": "Teacher of mathematics"
+                },
                 "lastName": {
                     "type": "string",
                     "example": "Petrov"
@@ -1664,6 +4946,17 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Sergeevich"
                 },
+                "personnelCategory": {
+                    "type": "string",
+                    "enum": [
+                        "unspecified",
+                        "pedagogical",
+                        "administrative",
+                        "educational_support",
+                        "service"
+                    ],
+                    "example": "pedagogical"
+                },
                 "pictureUrl": {
                     "type": "string",
                     "example": "/images/users/ivan.jpg"
@@ -1671,8 +4964,37 @@ const docTemplate = `{
                 "role": {
                     "$ref": "#/definitions/api.Role"
                 },
+                "subdivision": {
+                    "type": "string",
+                    "example": "Physics and mathematics"
+                },
                 "suspended": {
                     "type": "boolean"
+                },
+                "unemploymentDate": {
+                    "type": "string",
+                    "example": "2024-06-30T00:00:00Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "api.UsersBatchRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
                 }
             }
         },
@@ -1682,6 +5004,19 @@ const docTemplate = `{
                 "users"
             ],
             "properties": {
+                "nextCursor": {
+                    "description": "NextCursor is the after of the next page, omitted on the last page and if the users are not paginated.",
+                    "type": "string",
+                    "example": "0198c3b2-7a3e-7c1a-9f2e-3b1d4c5e6f70"
+                },
+                "page": {
+                    "description": "Page is only returned for the pages selected by offset.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.PageInfo"
+                        }
+                    ]
+                },
                 "users": {
                     "type": "array",
                     "items": {
@@ -1689,6 +5024,58 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "api.ValidationError": {
+            "type": "object",
+            "required": [
+                "fields"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "VALIDATION_ERROR"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "enMessage": {
+                    "type": "string",
+                    "example": "Invalid request data"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Invalid request data"
+                },
+                "ruMessage": {
+                    "type": "string",
+                    "example": "Некорректные данные запроса"
+                }
+            }
+        },
+        "api.WhoAmIResponse": {
+            "type": "object",
+            "required": [
+                "identity"
+            ],
+            "properties": {
+                "identity": {
+                    "$ref": "#/definitions/api.IdentityResponse"
+                },
+                "user": {
+                    "description": "User is the record of the user, it is omitted for admins.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.UserResponse"
+                        }
+                    ]
+                }
+            }
         }
     }
 }`
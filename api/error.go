@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/kozlov-ma/sesc-backend/iam"
 	"github.com/kozlov-ma/sesc-backend/sesc"
@@ -14,6 +15,7 @@ type Error struct {
 	Code       string `json:"code"             example:"INVALID_REQUEST"             validate:"required"`
 	Message    string `json:"message"          example:"Invalid request body"        validate:"required"`
 	RuMessage  string `json:"ruMessage"        example:"Некорректный формат запроса" validate:"required"`
+	EnMessage  string `json:"enMessage"        example:"Invalid request body"        validate:"required"`
 	Details    string `json:"details,omitzero" example:"field X is required"`
 	StatusCode int    `json:"-"`
 }
@@ -37,11 +39,23 @@ func (e Error) Error() string {
 type SpecificError interface {
 	InvalidRequestError | InvalidUUIDError | InvalidAuthHeaderError |
 		InvalidTokenError | AuthError | UnauthorizedError |
-		ForbiddenError | InvalidCredentialsError | UserNotFoundError |
-		UserExistsError | CredentialsNotFoundError | ServerError |
-		InvalidRoleError | InvalidNameError | DepartmentExistsError |
+		ForbiddenError | TooManyRequestsError | InvalidCredentialsError | UserNotFoundError |
+		UserExistsError | StaleUserError | CredentialsNotFoundError | ServerError |
+		InvalidRoleError | InvalidNameError | InvalidEmploymentDataError | DepartmentExistsError |
 		InvalidDepartmentIDError | InvalidDepartmentError | DepartmentNotFoundError |
-		CannotRemoveDepartmentError | Error
+		CannotRemoveDepartmentError | ValidationError | TimeoutError | ServiceUnavailableError | InvalidPermissionError | Error
+}
+
+// statusCode returns the HTTP status code carried by the API error, or 0 if there is none.
+func statusCode(apiError any) int {
+	switch e := apiError.(type) {
+	case Error:
+		return e.StatusCode
+	case ValidationError:
+		return e.StatusCode
+	default:
+		return 0
+	}
 }
 
 // InvalidRequestError represents an invalid request error
@@ -49,6 +63,7 @@ type InvalidRequestError struct {
 	Code       string `json:"code"             example:"INVALID_REQUEST"`
 	Message    string `json:"message"          example:"Invalid request body"`
 	RuMessage  string `json:"ruMessage"        example:"Некорректный формат запроса"`
+	EnMessage  string `json:"enMessage"        example:"Invalid request body"`
 	Details    string `json:"details,omitzero" example:"field X is required"`
 	StatusCode int    `json:"-"`
 }
@@ -70,6 +85,7 @@ type InvalidUUIDError struct {
 	Code       string `json:"code"             example:"INVALID_UUID"`
 	Message    string `json:"message"          example:"Invalid UUID format"`
 	RuMessage  string `json:"ruMessage"        example:"Некорректный формат UUID"`
+	EnMessage  string `json:"enMessage"        example:"Invalid UUID format"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -91,6 +107,7 @@ type InvalidAuthHeaderError struct {
 	Code       string `json:"code"             example:"INVALID_AUTH_HEADER"`
 	Message    string `json:"message"          example:"Invalid Authorization header format"`
 	RuMessage  string `json:"ruMessage"        example:"Неверный формат заголовка авторизации"`
+	EnMessage  string `json:"enMessage"        example:"Invalid Authorization header format"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -112,6 +129,7 @@ type InvalidTokenError struct {
 	Code       string `json:"code"             example:"INVALID_TOKEN"`
 	Message    string `json:"message"          example:"Invalid or expired token"`
 	RuMessage  string `json:"ruMessage"        example:"Недействительный или просроченный токен"`
+	EnMessage  string `json:"enMessage"        example:"Invalid or expired token"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -133,6 +151,7 @@ type AuthError struct {
 	Code       string `json:"code"             example:"AUTH_ERROR"`
 	Message    string `json:"message"          example:"Error processing authentication"`
 	RuMessage  string `json:"ruMessage"        example:"Ошибка обработки аутентификации"`
+	EnMessage  string `json:"enMessage"        example:"Error processing authentication"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -154,6 +173,7 @@ type UnauthorizedError struct {
 	Code       string `json:"code"             example:"UNAUTHORIZED"`
 	Message    string `json:"message"          example:"Unauthorized access"`
 	RuMessage  string `json:"ruMessage"        example:"Неавторизованный доступ"`
+	EnMessage  string `json:"enMessage"        example:"Unauthorized access"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -175,6 +195,7 @@ type ForbiddenError struct {
 	Code       string `json:"code"             example:"FORBIDDEN"`
 	Message    string `json:"message"          example:"Forbidden - insufficient permissions"`
 	RuMessage  string `json:"ruMessage"        example:"Доступ запрещен - недостаточно прав"`
+	EnMessage  string `json:"enMessage"        example:"Forbidden - insufficient permissions"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -191,11 +212,100 @@ func (e ForbiddenError) WithStatus(statusCode int) Error {
 	return Error(e)
 }
 
+// StaleUserError represents an update of a user that has been modified since the client read it
+type StaleUserError struct {
+	Code       string `json:"code"             example:"STALE_USER"`
+	Message    string `json:"message"          example:"User has been modified since it was read"`
+	RuMessage  string `json:"ruMessage"        example:"Пользователь был изменён с момента загрузки"`
+	EnMessage  string `json:"enMessage"        example:"User has been modified since it was read"`
+	Details    string `json:"details,omitzero"`
+	StatusCode int    `json:"-"`
+}
+
+// WithDetails adds detail information to the error
+func (e StaleUserError) WithDetails(details string) StaleUserError {
+	e.Details = details
+	return e
+}
+
+// WithStatus adds HTTP status code to the error
+func (e StaleUserError) WithStatus(statusCode int) Error {
+	e.StatusCode = statusCode
+	return Error(e)
+}
+
+// TooManyRequestsError represents a rate limit exceeded error
+type TooManyRequestsError struct {
+	Code       string `json:"code"             example:"TOO_MANY_REQUESTS"`
+	Message    string `json:"message"          example:"Too many requests"`
+	RuMessage  string `json:"ruMessage"        example:"Слишком много запросов"`
+	EnMessage  string `json:"enMessage"        example:"Too many requests"`
+	Details    string `json:"details,omitzero"`
+	StatusCode int    `json:"-"`
+}
+
+// WithDetails adds detail information to the error
+func (e TooManyRequestsError) WithDetails(details string) TooManyRequestsError {
+	e.Details = details
+	return e
+}
+
+// WithStatus adds HTTP status code to the error
+func (e TooManyRequestsError) WithStatus(statusCode int) Error {
+	e.StatusCode = statusCode
+	return Error(e)
+}
+
+// TimeoutError represents a request that was cancelled or ran out of time before it was processed
+type TimeoutError struct {
+	Code       string `json:"code"             example:"TIMEOUT"`
+	Message    string `json:"message"          example:"Request timed out"`
+	RuMessage  string `json:"ruMessage"        example:"Превышено время ожидания запроса"`
+	EnMessage  string `json:"enMessage"        example:"Request timed out"`
+	Details    string `json:"details,omitzero"`
+	StatusCode int    `json:"-"`
+}
+
+// WithDetails adds detail information to the error
+func (e TimeoutError) WithDetails(details string) TimeoutError {
+	e.Details = details
+	return e
+}
+
+// WithStatus adds HTTP status code to the error
+func (e TimeoutError) WithStatus(statusCode int) Error {
+	e.StatusCode = statusCode
+	return Error(e)
+}
+
+// ServiceUnavailableError represents a write rejected while the API is in read-only mode
+type ServiceUnavailableError struct {
+	Code       string `json:"code"             example:"READ_ONLY"`
+	Message    string `json:"message"          example:"The service is read-only during maintenance"`
+	RuMessage  string `json:"ruMessage"        example:"На время технических работ сервис доступен только для чтения"`
+	EnMessage  string `json:"enMessage"        example:"The service is read-only during maintenance"`
+	Details    string `json:"details,omitzero"`
+	StatusCode int    `json:"-"`
+}
+
+// WithDetails adds detail information to the error
+func (e ServiceUnavailableError) WithDetails(details string) ServiceUnavailableError {
+	e.Details = details
+	return e
+}
+
+// WithStatus adds HTTP status code to the error
+func (e ServiceUnavailableError) WithStatus(statusCode int) Error {
+	e.StatusCode = statusCode
+	return Error(e)
+}
+
 // InvalidCredentialsError represents invalid credentials format error
 type InvalidCredentialsError struct {
 	Code       string `json:"code"             example:"INVALID_CREDENTIALS"`
 	Message    string `json:"message"          example:"Invalid credentials format"`
 	RuMessage  string `json:"ruMessage"        example:"Неверный формат учетных данных"`
+	EnMessage  string `json:"enMessage"        example:"Invalid credentials format"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -217,6 +327,7 @@ type UserNotFoundError struct {
 	Code       string `json:"code"             example:"USER_NOT_FOUND"`
 	Message    string `json:"message"          example:"User does not exist"`
 	RuMessage  string `json:"ruMessage"        example:"Пользователь не существует"`
+	EnMessage  string `json:"enMessage"        example:"User does not exist"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -238,6 +349,7 @@ type UserExistsError struct {
 	Code       string `json:"code"             example:"USER_EXISTS"`
 	Message    string `json:"message"          example:"User with this username already exists"`
 	RuMessage  string `json:"ruMessage"        example:"Пользователь с таким именем уже существует"`
+	EnMessage  string `json:"enMessage"        example:"User with this username already exists"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -259,6 +371,7 @@ type CredentialsNotFoundError struct {
 	Code       string `json:"code"             example:"CREDENTIALS_NOT_FOUND"`
 	Message    string `json:"message"          example:"User credentials not found"`
 	RuMessage  string `json:"ruMessage"        example:"Учетные данные пользователя не найдены"`
+	EnMessage  string `json:"enMessage"        example:"User credentials not found"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -280,6 +393,7 @@ type ServerError struct {
 	Code       string `json:"code"             example:"SERVER_ERROR"`
 	Message    string `json:"message"          example:"Internal server error"`
 	RuMessage  string `json:"ruMessage"        example:"Внутренняя ошибка сервера"`
+	EnMessage  string `json:"enMessage"        example:"Internal server error"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -301,6 +415,7 @@ type InvalidRoleError struct {
 	Code       string `json:"code"             example:"INVALID_ROLE"`
 	Message    string `json:"message"          example:"Invalid role ID specified"`
 	RuMessage  string `json:"ruMessage"        example:"Указана некорректная роль"`
+	EnMessage  string `json:"enMessage"        example:"Invalid role ID specified"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -322,6 +437,7 @@ type InvalidNameError struct {
 	Code       string `json:"code"             example:"INVALID_NAME"`
 	Message    string `json:"message"          example:"Invalid name specified"`
 	RuMessage  string `json:"ruMessage"        example:"Указано некорректное имя"`
+	EnMessage  string `json:"enMessage"        example:"Invalid name specified"`
 	Details    string `json:"details,omitzero"`
 	StatusCode int    `json:"-"`
 }
@@ -338,6 +454,107 @@ func (e InvalidNameError) WithStatus(statusCode int) Error {
 	return Error(e)
 }
 
+// InvalidEmploymentDataError represents invalid employment details of a user
+type InvalidEmploymentDataError struct {
+	Code       string `json:"code"             example:"INVALID_EMPLOYMENT_DATA"`
+	Message    string `json:"message"          example:"Invalid employment data"`
+	RuMessage  string `json:"ruMessage"        example:"Указаны некорректные данные о трудоустройстве"`
+	EnMessage  string `json:"enMessage"        example:"Invalid employment data"`
+	Details    string `json:"details,omitzero"`
+	StatusCode int    `json:"-"`
+}
+
+// WithDetails adds detail information to the error
+func (e InvalidEmploymentDataError) WithDetails(details string) InvalidEmploymentDataError {
+	e.Details = details
+	return e
+}
+
+// WithStatus adds HTTP status code to the error
+func (e InvalidEmploymentDataError) WithStatus(statusCode int) Error {
+	e.StatusCode = statusCode
+	return Error(e)
+}
+
+// FieldError describes a problem with a single field of the request.
+type FieldError struct {
+	Field     string `json:"field"     example:"firstName"             validate:"required"`
+	Code      string `json:"code"      example:"REQUIRED"              validate:"required"`
+	Message   string `json:"message"   example:"Field is required"     validate:"required"`
+	RuMessage string `json:"ruMessage" example:"Обязательное поле"     validate:"required"`
+	EnMessage string `json:"enMessage" example:"Field is required"     validate:"required"`
+}
+
+// RequiredField reports that a required field is missing or empty.
+func RequiredField(field string) FieldError {
+	return FieldError{
+		Field:     field,
+		Code:      "REQUIRED",
+		Message:   "Field is required",
+		RuMessage: "Обязательное поле",
+	}
+}
+
+// InvalidRoleField reports that a field does not hold a valid role ID.
+func InvalidRoleField(field string) FieldError {
+	return FieldError{
+		Field:     field,
+		Code:      "INVALID_ROLE",
+		Message:   "Invalid role ID specified",
+		RuMessage: "Указана некорректная роль",
+	}
+}
+
+// InvalidField reports that a field has a value the endpoint doesn't accept.
+func InvalidField(field string) FieldError {
+	return FieldError{
+		Field:     field,
+		Code:      "INVALID_VALUE",
+		Message:   "Invalid value",
+		RuMessage: "Некорректное значение",
+	}
+}
+
+// UnknownField reports that the request has a field the endpoint doesn't accept.
+func UnknownField(field string) FieldError {
+	return FieldError{
+		Field:     field,
+		Code:      "UNKNOWN_FIELD",
+		Message:   "Unknown field",
+		RuMessage: "Неизвестное поле",
+	}
+}
+
+// ValidationError represents a request that has one or more invalid fields
+type ValidationError struct {
+	Code       string       `json:"code"             example:"VALIDATION_ERROR"`
+	Message    string       `json:"message"          example:"Invalid request data"`
+	RuMessage  string       `json:"ruMessage"        example:"Некорректные данные запроса"`
+	EnMessage  string       `json:"enMessage"        example:"Invalid request data"`
+	Details    string       `json:"details,omitzero"`
+	Fields     []FieldError `json:"fields"                                                  validate:"required"`
+	StatusCode int          `json:"-"`
+}
+
+// WithDetails adds detail information to the error
+func (e ValidationError) WithDetails(details string) ValidationError {
+	e.Details = details
+	return e
+}
+
+// WithFields adds invalid fields to the error
+func (e ValidationError) WithFields(fields ...FieldError) ValidationError {
+	e.Fields = append(slices.Clone(e.Fields), fields...)
+	return e
+}
+
+// WithStatus adds HTTP status code to the error.
+// Unlike other errors, ValidationError keeps its type, so that the fields are not lost.
+func (e ValidationError) WithStatus(statusCode int) ValidationError {
+	e.StatusCode = statusCode
+	return e
+}
+
 // The DepartmentExistsError is already declared in departments.go
 
 var (
@@ -383,6 +600,36 @@ var (
 		RuMessage: "Доступ запрещен - недостаточно прав",
 	}
 
+	ErrStaleUser = StaleUserError{
+		Code:      "STALE_USER",
+		Message:   "User has been modified since it was read",
+		RuMessage: "Пользователь был изменён с момента загрузки",
+	}
+
+	ErrInvalidEmploymentData = InvalidEmploymentDataError{
+		Code:      "INVALID_EMPLOYMENT_DATA",
+		Message:   "Invalid employment data",
+		RuMessage: "Указаны некорректные данные о трудоустройстве",
+	}
+
+	ErrTooManyRequests = TooManyRequestsError{
+		Code:      "TOO_MANY_REQUESTS",
+		Message:   "Too many requests",
+		RuMessage: "Слишком много запросов",
+	}
+
+	ErrTimeout = TimeoutError{
+		Code:      "TIMEOUT",
+		Message:   "Request timed out",
+		RuMessage: "Превышено время ожидания запроса",
+	}
+
+	ErrReadOnly = ServiceUnavailableError{
+		Code:      "READ_ONLY",
+		Message:   "The service is read-only during maintenance",
+		RuMessage: "На время технических работ сервис доступен только для чтения",
+	}
+
 	ErrInvalidCredentials = InvalidCredentialsError{
 		Code:      "INVALID_CREDENTIALS",
 		Message:   "Invalid credentials format",
@@ -412,6 +659,12 @@ var (
 		Message:   "Internal server error",
 		RuMessage: "Внутренняя ошибка сервера",
 	}
+
+	ErrValidation = ValidationError{
+		Code:      "VALIDATION_ERROR",
+		Message:   "Invalid request data",
+		RuMessage: "Некорректные данные запроса",
+	}
 )
 
 // Convert SESC domain errors to API errors
@@ -425,12 +678,14 @@ func sescError(err error) Error {
 		}.WithStatus(http.StatusBadRequest)
 	case errors.Is(err, sesc.ErrUserNotFound):
 		return ErrUserNotFound.WithStatus(http.StatusNotFound)
+	case errors.Is(err, sesc.ErrStaleUser):
+		return ErrStaleUser.WithStatus(http.StatusConflict)
 	case errors.Is(err, sesc.ErrCannotRemoveDepartment):
 		return ErrCannotRemoveDepartment.WithStatus(http.StatusConflict)
 	case errors.Is(err, sesc.ErrInvalidDepartment):
 		return ErrInvalidDepartment.WithStatus(http.StatusConflict)
 	case errors.Is(err, sesc.ErrInvalidPermission):
-		return ErrForbidden.WithStatus(http.StatusForbidden)
+		return ErrInvalidPermission.WithDetails(err.Error()).WithStatus(http.StatusBadRequest)
 	case errors.Is(err, sesc.ErrInvalidRoleChange):
 		return InvalidRoleError{
 			Code:      "INVALID_ROLE_CHANGE",
@@ -443,20 +698,42 @@ func sescError(err error) Error {
 			Message:   "Invalid or missing user name",
 			RuMessage: "Указано некорректное или отсутствует имя пользователя",
 		}.WithStatus(http.StatusBadRequest)
+	case errors.Is(err, sesc.ErrInvalidEmploymentData):
+		return ErrInvalidEmploymentData.WithDetails(err.Error()).WithStatus(http.StatusBadRequest)
 	case errors.Is(err, sesc.ErrInvalidDepartmentName):
 		return InvalidNameError{
 			Code:      "INVALID_NAME",
 			Message:   "Invalid or missing department name",
 			RuMessage: "Указано некорректное или отсутствует название кафедры",
+			Details:   err.Error(),
 		}.WithStatus(http.StatusBadRequest)
 	case errors.Is(err, sesc.ErrEmptyDepartment):
 		return ErrInvalidDepartment.WithDetails("Department is empty").WithStatus(http.StatusBadRequest)
 	case errors.Is(err, sesc.ErrDepartmentNotFound):
 		return ErrDepartmentNotFound.WithStatus(http.StatusNotFound)
+	case errors.Is(err, sesc.ErrDepartmentExists):
+		return ErrDepartmentExists.WithStatus(http.StatusConflict)
 	case errors.Is(err, sesc.ErrInvalidUserID):
 		return ErrInvalidUUID.WithDetails("Invalid user ID").WithStatus(http.StatusBadRequest)
 	case errors.Is(err, sesc.ErrInvalidDepartmentID):
 		return ErrInvalidDepartmentID.WithStatus(http.StatusBadRequest)
+	case errors.Is(err, sesc.ErrTimeout):
+		return ErrTimeout.WithStatus(http.StatusGatewayTimeout)
+	case errors.Is(err, sesc.ErrInvalidEmail):
+		return InvalidRequestError{
+			Code:      "INVALID_EMAIL",
+			Message:   "Invalid email",
+			RuMessage: "Некорректный адрес электронной почты",
+			Details:   err.Error(),
+		}.WithStatus(http.StatusBadRequest)
+	case errors.Is(err, sesc.ErrEmailExists):
+		return UserExistsError{
+			Code:      "EMAIL_EXISTS",
+			Message:   "User with this email already exists",
+			RuMessage: "Пользователь с таким адресом электронной почты уже существует",
+		}.WithStatus(http.StatusConflict)
+	case errors.Is(err, sesc.ErrConstraintViolation):
+		return ErrInvalidRequest.WithDetails(err.Error()).WithStatus(http.StatusBadRequest)
 	default:
 		return ErrServerError.WithDetails(err.Error()).WithStatus(http.StatusInternalServerError)
 	}
@@ -470,18 +747,39 @@ func iamError(err error) Error {
 	if errors.Is(err, iam.ErrCredentialsAlreadyExist) {
 		return ErrUserExists.WithStatus(http.StatusConflict)
 	}
+	// Specific token errors also wrap iam.ErrInvalidToken, so they must be checked first.
+	if errors.Is(err, iam.ErrTokenExpired) {
+		return ErrInvalidToken.WithDetails("Token has expired").WithStatus(http.StatusUnauthorized)
+	}
+	if errors.Is(err, iam.ErrInvalidTokenFormat) {
+		return ErrInvalidToken.WithDetails("Invalid token format").WithStatus(http.StatusUnauthorized)
+	}
+	if errors.Is(err, iam.ErrTokenSignature) {
+		return ErrInvalidToken.WithDetails("Invalid token signature").WithStatus(http.StatusUnauthorized)
+	}
 	if errors.Is(err, iam.ErrInvalidToken) {
 		return ErrInvalidToken.WithStatus(http.StatusUnauthorized)
 	}
 	if errors.Is(err, iam.ErrUserNotFound) {
 		return ErrUserNotFound.WithStatus(http.StatusNotFound)
 	}
+	if errors.Is(err, iam.ErrTooManyAttempts) {
+		return ErrTooManyRequests.WithDetails("Too many failed login attempts, try again later").WithStatus(http.StatusTooManyRequests)
+	}
 	if errors.Is(err, iam.ErrEmptyUsername) {
 		return ErrInvalidCredentials.WithDetails("Username cannot be empty").WithStatus(http.StatusBadRequest)
 	}
 	if errors.Is(err, iam.ErrEmptyPassword) {
 		return ErrInvalidCredentials.WithDetails("Password cannot be empty").WithStatus(http.StatusBadRequest)
 	}
+	if errors.Is(err, iam.ErrWeakPassword) {
+		return InvalidCredentialsError{
+			Code:      "WEAK_PASSWORD",
+			Message:   "Password does not meet the requirements",
+			RuMessage: "Пароль не соответствует требованиям",
+			Details:   err.Error(),
+		}.WithStatus(http.StatusBadRequest)
+	}
 	if errors.Is(err, iam.ErrInvalidUserID) {
 		return ErrInvalidUUID.WithDetails("Invalid user ID").WithStatus(http.StatusBadRequest)
 	}
@@ -498,15 +796,6 @@ func iamError(err error) Error {
 	if errors.Is(err, iam.ErrUnauthorized) {
 		return ErrUnauthorized.WithStatus(http.StatusUnauthorized)
 	}
-	if errors.Is(err, iam.ErrTokenExpired) {
-		return ErrInvalidToken.WithDetails("Token has expired").WithStatus(http.StatusUnauthorized)
-	}
-	if errors.Is(err, iam.ErrInvalidTokenFormat) {
-		return ErrInvalidToken.WithDetails("Invalid token format").WithStatus(http.StatusUnauthorized)
-	}
-	if errors.Is(err, iam.ErrTokenSignature) {
-		return ErrInvalidToken.WithDetails("Invalid token signature").WithStatus(http.StatusUnauthorized)
-	}
 
 	return ErrServerError.WithDetails(err.Error()).WithStatus(http.StatusInternalServerError)
 }
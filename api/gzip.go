@@ -0,0 +1,172 @@
+package api
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// gzipMinSize is the smallest response body worth compressing, smaller ones fit into a packet anyway.
+const gzipMinSize = 1024
+
+var gzipWriterPool = sync.Pool{
+	New: func() any {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// gzipMiddleware compresses the responses for the clients that accept gzip.
+// Small bodies and the content types that are already compressed, like images, are written as is.
+//
+// It should be used inside EventMiddleware, so that bytes_written is the compressed size.
+func (a *API) gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if r.Method == http.MethodHead || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gw := &gzipWriter{ResponseWriter: w}
+		defer func() {
+			if err := gw.Close(); err != nil {
+				event.Get(r.Context()).Add(events.Error, err)
+			}
+		}()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip, ignoring the preference between encodings.
+func acceptsGzip(acceptEncoding string) bool {
+	for coding := range strings.SplitSeq(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		if strings.TrimSpace(name) != "gzip" {
+			continue
+		}
+
+		q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q=")
+		if !ok {
+			return true
+		}
+		weight, err := strconv.ParseFloat(q, 64)
+		return err == nil && weight > 0
+	}
+	return false
+}
+
+// compressible reports whether the content type is worth compressing.
+func compressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case strings.HasSuffix(mediaType, "json"), strings.HasSuffix(mediaType, "xml"):
+		return true
+	case mediaType == "application/javascript":
+		return true
+	default:
+		return false
+	}
+}
+
+// gzipWriter buffers the first gzipMinSize bytes of the body to decide whether to compress it.
+type gzipWriter struct {
+	http.ResponseWriter
+
+	gz      *gzip.Writer
+	buf     []byte
+	code    int
+	decided bool
+}
+
+func (gw *gzipWriter) WriteHeader(code int) {
+	if gw.decided || gw.code != 0 {
+		return
+	}
+	gw.code = code
+
+	// These responses have no body.
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified {
+		gw.decided = true
+		gw.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (gw *gzipWriter) Write(p []byte) (int, error) {
+	if gw.decided {
+		if gw.gz != nil {
+			return gw.gz.Write(p)
+		}
+		return gw.ResponseWriter.Write(p)
+	}
+
+	if gw.code == 0 {
+		gw.code = http.StatusOK
+	}
+	gw.buf = append(gw.buf, p...)
+	if len(gw.buf) >= gzipMinSize {
+		if err := gw.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// decide writes the header, compressing the body if it is large enough and compressible,
+// and flushes the buffered part of the body.
+func (gw *gzipWriter) decide() error {
+	gw.decided = true
+
+	h := gw.Header()
+	if h.Get("Content-Type") == "" {
+		// net/http can't sniff the content type of a compressed body.
+		h.Set("Content-Type", http.DetectContentType(gw.buf))
+	}
+	if len(gw.buf) >= gzipMinSize && h.Get("Content-Encoding") == "" && compressible(h.Get("Content-Type")) {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+
+		gw.gz = gzipWriterPool.Get().(*gzip.Writer)
+		gw.gz.Reset(gw.ResponseWriter)
+	}
+
+	gw.ResponseWriter.WriteHeader(gw.code)
+
+	buf := gw.buf
+	gw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := gw.Write(buf)
+	return err
+}
+
+// Close flushes the buffered body and finishes the compressed stream.
+func (gw *gzipWriter) Close() error {
+	if !gw.decided && gw.code != 0 {
+		if err := gw.decide(); err != nil {
+			return err
+		}
+	}
+	if gw.gz == nil {
+		return nil
+	}
+
+	err := gw.gz.Close()
+	gzipWriterPool.Put(gw.gz)
+	gw.gz = nil
+	return err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (gw *gzipWriter) Unwrap() http.ResponseWriter {
+	return gw.ResponseWriter
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+// lastEventSink keeps the last processed event.
+type lastEventSink struct {
+	rec *event.Record
+}
+
+func (s *lastEventSink) ProcessEvent(rec *event.Record) {
+	s.rec = rec
+}
+
+func TestGzipMiddleware(t *testing.T) {
+	large := make([]Department, 100)
+	for i := range large {
+		large[i] = Department{Name: fmt.Sprintf("Department %d", i), Description: "A department of the school"}
+	}
+	small := []Department{{Name: "Math", Description: "Mathematics"}}
+
+	setup := func(t *testing.T, data any) (*lastEventSink, func(acceptEncoding string) *httptest.ResponseRecorder) {
+		t.Helper()
+		sink := &lastEventSink{}
+		a := New(nil, nil, sink)
+		handler := a.EventMiddleware(a.gzipMiddleware(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				a.writeJSON(r.Context(), w, data, http.StatusOK)
+			}),
+		))
+
+		return sink, func(acceptEncoding string) *httptest.ResponseRecorder {
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/departments", nil)
+			if acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", acceptEncoding)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			return rr
+		}
+	}
+
+	t.Run("large json is compressed", func(t *testing.T) {
+		sink, serve := setup(t, large)
+
+		rr := serve("deflate, gzip;q=0.8")
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "gzip", rr.Header().Get("Content-Encoding"))
+		require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		require.Contains(t, rr.Header().Values("Vary"), "Accept-Encoding")
+
+		// The compressed size is recorded.
+		require.Equal(t, int64(rr.Body.Len()), sink.rec.Value("http.response.bytes_written"))
+
+		zr, err := gzip.NewReader(rr.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		var got []Department
+		require.NoError(t, json.Unmarshal(body, &got))
+		require.Equal(t, large, got)
+	})
+
+	t.Run("gzip not accepted", func(t *testing.T) {
+		_, serve := setup(t, large)
+
+		for _, acceptEncoding := range []string{"", "deflate", "gzip;q=0"} {
+			rr := serve(acceptEncoding)
+			require.Empty(t, rr.Header().Get("Content-Encoding"), acceptEncoding)
+
+			var got []Department
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got), acceptEncoding)
+			require.Equal(t, large, got, acceptEncoding)
+		}
+	})
+
+	t.Run("small body is not compressed", func(t *testing.T) {
+		_, serve := setup(t, small)
+
+		rr := serve("gzip")
+		require.Empty(t, rr.Header().Get("Content-Encoding"))
+
+		var got []Department
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		require.Equal(t, small, got)
+	})
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// readinessTimeout limits how long the readiness probe waits for the dependencies.
+const readinessTimeout = 2 * time.Second
+
+type HealthResponse struct {
+	Status string `json:"status" example:"ok" validate:"required"`
+}
+
+type ReadinessResponse struct {
+	Status string `json:"status"           example:"unavailable" validate:"required"`
+	// Failed lists the dependencies that are not available.
+	Failed []string `json:"failed,omitzero" example:"postgres"`
+}
+
+// Health godoc
+// @Summary Liveness probe
+// @Description Always returns 200 while the process is up
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /healthz [get]
+func (a *API) Health(w http.ResponseWriter, r *http.Request) {
+	a.writeJSON(r.Context(), w, HealthResponse{Status: "ok"}, http.StatusOK)
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Checks that the dependencies of the API are reachable
+// @Tags health
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse "Some of the dependencies are unavailable"
+// @Router /readyz [get]
+func (a *API) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	checkCtx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	var failed []string
+	if err := a.sesc.Ping(checkCtx); err != nil {
+		rec.Add(events.Error, err)
+		failed = append(failed, "postgres")
+	}
+
+	if len(failed) > 0 {
+		a.writeJSON(ctx, w, ReadinessResponse{Status: "unavailable", Failed: failed}, http.StatusServiceUnavailable)
+		return
+	}
+
+	a.writeJSON(ctx, w, ReadinessResponse{Status: "ok"}, http.StatusOK)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"golang.org/x/text/language"
+)
+
+// Language is the language of the messages of the error responses.
+type Language string
+
+const (
+	LanguageRussian Language = "ru"
+	LanguageEnglish Language = "en"
+)
+
+// DefaultLanguage is used if the Accept-Language header is absent or names no supported language.
+const DefaultLanguage = LanguageRussian
+
+// supportedLanguages are matched against the Accept-Language header, the first one is the default.
+var (
+	supportedLanguages = []Language{LanguageRussian, LanguageEnglish}
+	languageMatcher    = language.NewMatcher([]language.Tag{language.Russian, language.English})
+)
+
+type languageKey struct{}
+
+// GetLanguageFromContext returns the language of the error messages set by LanguageMiddleware,
+// or DefaultLanguage if there is none.
+func GetLanguageFromContext(ctx context.Context) Language {
+	if lang, ok := ctx.Value(languageKey{}).(Language); ok {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// LanguageMiddleware picks the language of the error messages from the Accept-Language header.
+func (a *API) LanguageMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		lang := parseAcceptLanguage(r.Header.Get("Accept-Language"))
+		event.Root(ctx).Set("language", lang)
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, languageKey{}, lang)))
+	})
+}
+
+// parseAcceptLanguage returns the supported language the Accept-Language header prefers,
+// or DefaultLanguage if it names none of them.
+func parseAcceptLanguage(header string) Language {
+	if header == "" {
+		return DefaultLanguage
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+
+	_, index, confidence := languageMatcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	return supportedLanguages[index]
+}
+
+// localizeError sets the Message of the error pointed to by v, and of its Fields, to the one in lang.
+// The English message is kept in EnMessage and the Russian one in RuMessage.
+func localizeError(v reflect.Value, lang Language) {
+	msg, ruMsg, enMsg := v.FieldByName("Message"), v.FieldByName("RuMessage"), v.FieldByName("EnMessage")
+	if !msg.IsValid() || !ruMsg.IsValid() || !enMsg.IsValid() {
+		return
+	}
+
+	// The messages are written in English first.
+	if enMsg.String() == "" {
+		enMsg.SetString(msg.String())
+	}
+	if lang == LanguageRussian {
+		msg.SetString(ruMsg.String())
+	} else {
+		msg.SetString(enMsg.String())
+	}
+
+	fields := v.FieldByName("Fields")
+	if !fields.IsValid() || fields.Kind() != reflect.Slice || fields.Len() == 0 {
+		return
+	}
+	// Copy the fields, so that the ones shared with the caller aren't changed.
+	localized := reflect.MakeSlice(fields.Type(), fields.Len(), fields.Len())
+	reflect.Copy(localized, fields)
+	for i := range localized.Len() {
+		localizeError(localized.Index(i), lang)
+	}
+	fields.Set(localized)
+}
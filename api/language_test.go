@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+// russianFields returns the fields as they are written to a request without an Accept-Language header.
+func russianFields(fields ...FieldError) []FieldError {
+	for i := range fields {
+		fields[i].EnMessage = fields[i].Message
+		fields[i].Message = fields[i].RuMessage
+	}
+	return fields
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	for header, want := range map[string]Language{
+		"":                         LanguageRussian,
+		"en":                       LanguageEnglish,
+		"EN-us":                    LanguageEnglish,
+		"en-GB,en;q=0.9":           LanguageEnglish,
+		"ru":                       LanguageRussian,
+		"ru-RU, en;q=0.5":          LanguageRussian,
+		"de, en;q=0.9, ru;q=0.8":   LanguageEnglish,
+		"fr":                       LanguageRussian,
+		"*":                        LanguageRussian,
+		"en;q=0.1, ru;q=0.2":       LanguageRussian,
+		"not a language;;q=broken": LanguageRussian,
+	} {
+		require.Equal(t, want, parseAcceptLanguage(header), header)
+	}
+}
+
+func TestWriteErrorLanguage(t *testing.T) {
+	a := New(nil, nil, nil)
+
+	// write writes err through LanguageMiddleware for a request with the Accept-Language header, if not empty.
+	write := func(t *testing.T, acceptLanguage string, err ValidationError) (*httptest.ResponseRecorder, ValidationError) {
+		t.Helper()
+		handler := a.LanguageMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeError(r.Context(), w, err)
+		}))
+
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		var got ValidationError
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		return rr, got
+	}
+
+	apiErr := ErrValidation.WithFields(RequiredField("name")).WithStatus(http.StatusBadRequest)
+
+	t.Run("english", func(t *testing.T) {
+		rr, got := write(t, "en", apiErr)
+		require.Equal(t, "en", rr.Header().Get("Content-Language"))
+		require.Equal(t, "Invalid request data", got.Message)
+		require.Equal(t, "Invalid request data", got.EnMessage)
+		require.Equal(t, "Некорректные данные запроса", got.RuMessage)
+		require.Equal(t, "Field is required", got.Fields[0].Message)
+		require.Equal(t, "Обязательное поле", got.Fields[0].RuMessage)
+	})
+
+	t.Run("russian", func(t *testing.T) {
+		rr, got := write(t, "ru", apiErr)
+		require.Equal(t, "ru", rr.Header().Get("Content-Language"))
+		require.Equal(t, "Некорректные данные запроса", got.Message)
+		require.Equal(t, "Invalid request data", got.EnMessage)
+		require.Equal(t, "Некорректные данные запроса", got.RuMessage)
+		require.Equal(t, "Обязательное поле", got.Fields[0].Message)
+		require.Equal(t, "Field is required", got.Fields[0].EnMessage)
+	})
+
+	t.Run("russian by default", func(t *testing.T) {
+		rr, got := write(t, "", apiErr)
+		require.Equal(t, "ru", rr.Header().Get("Content-Language"))
+		require.Contains(t, rr.Header().Values("Vary"), "Accept-Language")
+		require.Equal(t, "Некорректные данные запроса", got.Message)
+		require.Equal(t, russianFields(RequiredField("name")), got.Fields)
+	})
+
+	t.Run("shared fields are not changed", func(t *testing.T) {
+		write(t, "ru", apiErr)
+		require.Equal(t, "Field is required", apiErr.Fields[0].Message)
+		require.Empty(t, apiErr.Fields[0].EnMessage)
+	})
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+)
+
+// MergePatchContentType is the media type of the JSON Merge Patch (RFC 7386) request bodies.
+const MergePatchContentType = "application/merge-patch+json"
+
+// isMergePatchContentType reports whether the Content-Type header declares a JSON Merge Patch body.
+func isMergePatchContentType(header string) bool {
+	mediaType, _, err := mime.ParseMediaType(header)
+	return err == nil && mediaType == MergePatchContentType
+}
+
+// userFieldClearers clear the user fields a merge patch may set to null, by their PatchUserRequest names.
+// The other fields are required and can't be cleared.
+var userFieldClearers = map[string]func(patch *sesc.UserPatch){
+	"middleName":        func(patch *sesc.UserPatch) { patch.MiddleName = new(string) },
+	"pictureUrl":        func(patch *sesc.UserPatch) { patch.PictureURL = new(string) },
+	"email":             func(patch *sesc.UserPatch) { patch.Email = new(string) },
+	"departmentId":      func(patch *sesc.UserPatch) { patch.DepartmentID = new(uuid.UUID) },
+	"subdivision":       func(patch *sesc.UserPatch) { patch.Subdivision = new(string) },
+	"jobTitle":          func(patch *sesc.UserPatch) { patch.JobTitle = new(string) },
+	"employmentRate":    func(patch *sesc.UserPatch) { patch.EmploymentRate = new(float64) },
+	"personnelCategory": func(patch *sesc.UserPatch) { patch.PersonnelCategory = new(sesc.PersonnelCategory) },
+	"employmentType":    func(patch *sesc.UserPatch) { patch.EmploymentType = new(sesc.EmploymentType) },
+	"academicDegree":    func(patch *sesc.UserPatch) { patch.AcademicDegree = new(sesc.AcademicDegree) },
+	"academicTitle":     func(patch *sesc.UserPatch) { patch.AcademicTitle = new(string) },
+	"honors":            func(patch *sesc.UserPatch) { patch.Honors = new(string) },
+	"category":          func(patch *sesc.UserPatch) { patch.Category = new(string) },
+	"dateOfEmployment":  func(patch *sesc.UserPatch) { patch.ClearDateOfEmployment = true },
+	"unemploymentDate":  func(patch *sesc.UserPatch) { patch.ClearUnemploymentDate = true },
+}
+
+// decodeUserMergePatch decodes a JSON Merge Patch of a user. The fields with a value are returned
+// in the PatchUserRequest, and the names of the fields set to null, which clear them, in cleared.
+// A null version is the same as an absent one, and the other required fields can't be null.
+// On failure it writes an error like decodeJSON and returns false.
+func (a *API) decodeUserMergePatch(w http.ResponseWriter, r *http.Request) (req PatchUserRequest, cleared []string, ok bool) {
+	ctx := r.Context()
+
+	var patch map[string]json.RawMessage
+	if !decodeStrict(ctx, w, http.MaxBytesReader(w, r.Body, a.maxBodyBytes), &patch) {
+		return PatchUserRequest{}, nil, false
+	}
+
+	// Decoding the whole patch into the request sets the fields with a value and rejects the unknown ones,
+	// nulls leave the fields absent.
+	body, err := json.Marshal(patch)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+		return PatchUserRequest{}, nil, false
+	}
+	if !decodeStrict(ctx, w, bytes.NewReader(body), &req) {
+		return PatchUserRequest{}, nil, false
+	}
+
+	var fields []FieldError
+	for _, name := range slices.Sorted(maps.Keys(patch)) {
+		if string(patch[name]) != "null" || name == "version" {
+			continue
+		}
+		if _, ok := userFieldClearers[name]; !ok {
+			fields = append(fields, RequiredField(name))
+			continue
+		}
+		cleared = append(cleared, name)
+	}
+	if len(fields) > 0 {
+		writeError(ctx, w, ErrValidation.WithFields(fields...).WithStatus(http.StatusBadRequest))
+		return PatchUserRequest{}, nil, false
+	}
+
+	return req, cleared, true
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeUserMergePatch(t *testing.T) {
+	a := New(nil, nil, nil)
+
+	decode := func(t *testing.T, body string) (*httptest.ResponseRecorder, PatchUserRequest, []string, bool) {
+		t.Helper()
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		req := httptest.NewRequestWithContext(ctx, http.MethodPatch, "/users/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", MergePatchContentType)
+		rr := httptest.NewRecorder()
+
+		patch, cleared, ok := a.decodeUserMergePatch(rr, req)
+		return rr, patch, cleared, ok
+	}
+
+	t.Run("values and nulls", func(t *testing.T) {
+		_, patch, cleared, ok := decode(t, `{"firstName": "Ivan", "middleName": null, "departmentId": null, "version": null}`)
+		require.True(t, ok)
+		require.NotNil(t, patch.FirstName)
+		require.Equal(t, "Ivan", *patch.FirstName)
+		require.Nil(t, patch.MiddleName)
+		require.Nil(t, patch.Version)
+		require.Equal(t, []string{"departmentId", "middleName"}, cleared)
+	})
+
+	t.Run("required fields can't be null", func(t *testing.T) {
+		rr, _, _, ok := decode(t, `{"firstName": null, "roleId": null, "email": null}`)
+		require.False(t, ok)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		var got ValidationError
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		require.Equal(t, russianFields(RequiredField("firstName"), RequiredField("roleId")), got.Fields)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		rr, _, _, ok := decode(t, `{"isAdmin": null}`)
+		require.False(t, ok)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), `"code":"UNKNOWN_FIELD"`)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		for _, body := range []string{"", `[]`, `{"firstName": 1}`} {
+			rr, _, _, ok := decode(t, body)
+			require.False(t, ok, body)
+			require.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/felixge/httpsnoop"
+	"github.com/go-chi/chi/v5"
 	"github.com/kozlov-ma/sesc-backend/iam"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
@@ -34,12 +35,21 @@ func GetUserFromContext(ctx context.Context) (sesc.User, bool) {
 	return user, ok
 }
 
+// rejectSuspended writes an error and returns true if the user is suspended.
+// Every middleware that looks up the current user checks it.
+func rejectSuspended(ctx context.Context, w http.ResponseWriter, u sesc.User) bool {
+	if !u.Suspended {
+		return false
+	}
+	writeError(ctx, w, ErrUnauthorized.WithDetails("you are suspended").WithStatus(http.StatusUnauthorized))
+	return true
+}
+
 func (a *API) UnauthorizeSuspendedUsersMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		u, ok := GetUserFromContext(ctx)
-		if ok && u.Suspended {
-			writeError(ctx, w, ErrUnauthorized.WithDetails("you are suspended").WithStatus(http.StatusUnauthorized))
+		if ok && rejectSuspended(ctx, w, u) {
 			return
 		}
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -82,9 +92,12 @@ func (a *API) AuthMiddleware(next http.Handler) http.Handler {
 			"auth_id", identity.AuthID,
 			"id", identity.ID,
 			"role", identity.Role,
+			"org_id", identity.OrgID,
 		)
 
 		ctx = context.WithValue(ctx, identityContextKey, identity)
+		ctx = sesc.WithActor(ctx, identity.ID)
+		ctx = sesc.WithOrg(ctx, identity.OrgID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -130,9 +143,12 @@ func (a *API) RequireAuthMiddleware(next http.Handler) http.Handler {
 			"auth_id", identity.AuthID,
 			"id", identity.ID,
 			"role", identity.Role,
+			"org_id", identity.OrgID,
 		)
 
 		ctx = context.WithValue(ctx, identityContextKey, identity)
+		ctx = sesc.WithActor(ctx, identity.ID)
+		ctx = sesc.WithOrg(ctx, identity.OrgID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -203,6 +219,58 @@ func (a *API) RoleMiddleware(role iam.Role) func(http.Handler) http.Handler {
 	}
 }
 
+// RequirePermissionMiddleware restricts access to users that have the permission with the given ID,
+// through their role or as an extra grant. Admins are always allowed, suspended users never are.
+// The current user is taken from the context if CurrentUserMiddleware has run,
+// otherwise it is looked up by the identity.
+func (a *API) RequirePermissionMiddleware(perm int32) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			rec := event.Get(ctx)
+
+			rec.Sub("http").Set("required_permission", perm)
+
+			identity, ok := GetIdentityFromContext(ctx)
+			if !ok {
+				writeError(ctx, w, UnauthorizedError{
+					Code:      "UNAUTHORIZED",
+					Message:   "Authentication required",
+					RuMessage: "Требуется аутентификация",
+					Details:   "Authentication required",
+				}.WithStatus(http.StatusUnauthorized))
+				return
+			}
+
+			if identity.Role == iam.RoleAdmin {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, ok := GetUserFromContext(ctx)
+			if !ok {
+				var err error
+				user, err = a.sesc.User(ctx, identity.ID)
+				if err != nil {
+					rec.Add(events.Error, fmt.Errorf("couldn't get user data: %w", err))
+					writeError(ctx, w, sescError(err))
+					return
+				}
+			}
+			if rejectSuspended(ctx, w, user) {
+				return
+			}
+
+			if !user.HasPermission(sesc.Permission{ID: perm}) {
+				writeError(ctx, w, ErrForbidden.WithStatus(http.StatusForbidden))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // CurrentUserMiddleware adds the current user to the request context if available
 func (a *API) CurrentUserMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -224,8 +292,7 @@ func (a *API) CurrentUserMiddleware(next http.Handler) http.Handler {
 				return
 			}
 
-			if user.Suspended {
-				writeError(ctx, w, ErrUnauthorized.WithDetails("you are suspended").WithStatus(http.StatusUnauthorized))
+			if rejectSuspended(ctx, w, user) {
 				return
 			}
 
@@ -278,6 +345,10 @@ func (a *API) EventMiddleware(next http.Handler) http.Handler {
 			"processing_time", m.Duration,
 		)
 
+		if rctx := chi.RouteContext(ctx); rctx != nil {
+			httprec.Sub("request").Set("route", rctx.RoutePattern())
+		}
+
 		httprec.Sub("response").Set(
 			"code", m.Code,
 			"bytes_written", m.Written,
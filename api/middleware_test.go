@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/iam"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/stretchr/testify/require"
+)
+
+// usersSESC is a SESC that only knows how to look up users.
+type usersSESC struct {
+	SESC
+	users map[sesc.UUID]sesc.User
+}
+
+func (s usersSESC) User(_ context.Context, id sesc.UUID) (sesc.User, error) {
+	u, ok := s.users[id]
+	if !ok {
+		return sesc.User{}, sesc.ErrUserNotFound
+	}
+	return u, nil
+}
+
+func TestRequirePermissionMiddleware(t *testing.T) {
+	teacher := sesc.User{ID: uuid.Must(uuid.NewV7()), FirstName: "Ivan", LastName: "Petrov", Role: sesc.Teacher}
+	deputy := sesc.User{ID: uuid.Must(uuid.NewV7()), FirstName: "Petr", LastName: "Ivanov", Role: sesc.ContestDeputy}
+	granted := sesc.User{
+		ID:               uuid.Must(uuid.NewV7()),
+		FirstName:        "Oleg",
+		LastName:         "Sidorov",
+		Role:             sesc.ContestDeputy,
+		ExtraPermissions: []sesc.Permission{sesc.PermissionDraftAchievementList},
+	}
+	suspended := sesc.User{ID: uuid.Must(uuid.NewV7()), FirstName: "Anna", LastName: "Smirnova", Role: sesc.Teacher, Suspended: true}
+
+	a := New(usersSESC{users: map[sesc.UUID]sesc.User{
+		teacher.ID:   teacher,
+		deputy.ID:    deputy,
+		granted.ID:   granted,
+		suspended.ID: suspended,
+	}}, nil, nil)
+
+	serve := func(t *testing.T, identity *iam.Identity) int {
+		ctx := t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		if identity != nil {
+			ctx = context.WithValue(ctx, identityContextKey, *identity)
+		}
+
+		handler := a.RequirePermissionMiddleware(sesc.PermissionDraftAchievementList.ID)(
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	t.Run("user with permission", func(t *testing.T) {
+		code := serve(t, &iam.Identity{ID: teacher.ID, Role: iam.RoleUser})
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("user without permission", func(t *testing.T) {
+		code := serve(t, &iam.Identity{ID: deputy.ID, Role: iam.RoleUser})
+		require.Equal(t, http.StatusForbidden, code)
+	})
+
+	t.Run("user with granted permission", func(t *testing.T) {
+		code := serve(t, &iam.Identity{ID: granted.ID, Role: iam.RoleUser})
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("suspended user with permission", func(t *testing.T) {
+		code := serve(t, &iam.Identity{ID: suspended.ID, Role: iam.RoleUser})
+		require.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		code := serve(t, &iam.Identity{ID: uuid.Must(uuid.NewV7()), Role: iam.RoleAdmin})
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		code := serve(t, &iam.Identity{ID: uuid.Must(uuid.NewV7()), Role: iam.RoleUser})
+		require.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		code := serve(t, nil)
+		require.Equal(t, http.StatusUnauthorized, code)
+	})
+}
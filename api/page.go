@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// PageInfo describes a page of a list selected by the offset and limit query parameters.
+type PageInfo struct {
+	Limit  int `json:"limit"  example:"100"`
+	Offset int `json:"offset" example:"0"`
+	// Total is the number of the items in the whole list.
+	Total int `json:"total" example:"250"`
+	// HasMore is true unless this is the last page.
+	HasMore bool `json:"hasMore" example:"true"`
+}
+
+func newPageInfo(offset, limit, count, total int) *PageInfo {
+	return &PageInfo{
+		Limit:   limit,
+		Offset:  offset,
+		Total:   total,
+		HasMore: offset+count < total,
+	}
+}
+
+// parsePage returns the offset and the limit query parameters of r, limit defaulting to defaultLimit.
+// The error is the details of an ErrInvalidRequest.
+func parsePage(r *http.Request, defaultLimit, maxLimit int) (offset, limit int, err error) {
+	query := r.URL.Query()
+
+	if s := query.Get("offset"); s != "" {
+		offset, err = strconv.Atoi(s)
+		if err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("offset must be a non-negative number")
+		}
+	}
+
+	limit = defaultLimit
+	if s := query.Get("limit"); s != "" {
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit <= 0 || limit > maxLimit {
+			return 0, 0, fmt.Errorf("limit must be a number between 1 and %d", maxLimit)
+		}
+	}
+
+	return offset, limit, nil
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/stretchr/testify/require"
+)
+
+// pagingSESC is a SESC that pages through the given users and departments.
+type pagingSESC struct {
+	SESC
+	users []sesc.User
+	depts []sesc.Department
+}
+
+func page[T any](items []T, offset, limit int) []T {
+	offset = min(offset, len(items))
+	return items[offset:min(offset+limit, len(items))]
+}
+
+func (s pagingSESC) UsersPage(_ context.Context, offset, limit int, _ sesc.UserFilter) ([]sesc.User, int, error) {
+	return page(s.users, offset, limit), len(s.users), nil
+}
+
+func (s pagingSESC) DepartmentsPage(_ context.Context, offset, limit int) ([]sesc.Department, int, error) {
+	return page(s.depts, offset, limit), len(s.depts), nil
+}
+
+func TestPageInfo(t *testing.T) {
+	s := pagingSESC{
+		users: []sesc.User{
+			{ID: sesc.UUID{1}, FirstName: "John", LastName: "Doe", Role: sesc.Teacher},
+			{ID: sesc.UUID{2}, FirstName: "Jane", LastName: "Doe", Role: sesc.Teacher},
+			{ID: sesc.UUID{3}, FirstName: "Anna", LastName: "Sokolova", Role: sesc.Dephead},
+		},
+		depts: []sesc.Department{
+			{ID: sesc.UUID{1}, Name: "Chemistry"},
+			{ID: sesc.UUID{2}, Name: "Math"},
+			{ID: sesc.UUID{3}, Name: "Physics"},
+		},
+	}
+	a := New(s, nil, nil)
+
+	serve := func(t *testing.T, handler http.HandlerFunc, target string) *httptest.ResponseRecorder {
+		t.Helper()
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+
+	t.Run("users", func(t *testing.T) {
+		rr := serve(t, a.GetUsers, "/users?offset=0&limit=2")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp UsersResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Users, 2)
+		require.Equal(t, &PageInfo{Limit: 2, Offset: 0, Total: 3, HasMore: true}, resp.Page)
+
+		rr = serve(t, a.GetUsers, "/users?offset=2&limit=2")
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp = UsersResponse{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Users, 1)
+		require.Equal(t, &PageInfo{Limit: 2, Offset: 2, Total: 3, HasMore: false}, resp.Page)
+	})
+
+	t.Run("departments", func(t *testing.T) {
+		rr := serve(t, a.Departments, "/departments?limit=2")
+		require.Equal(t, http.StatusOK, rr.Code)
+		var resp DepartmentsResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Len(t, resp.Departments, 2)
+		require.Equal(t, &PageInfo{Limit: 2, Offset: 0, Total: 3, HasMore: true}, resp.Page)
+
+		rr = serve(t, a.Departments, "/departments?offset=1&limit=2")
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp = DepartmentsResponse{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Equal(t, "Math", resp.Departments[0].Name)
+		require.Equal(t, &PageInfo{Limit: 2, Offset: 1, Total: 3, HasMore: false}, resp.Page)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, target := range []string{
+			"/users?offset=-1",
+			"/users?offset=x",
+			"/users?offset=0&limit=0",
+			"/users?offset=0&after=0198c3b2-7a3e-7c1a-9f2e-3b1d4c5e6f70",
+		} {
+			rr := serve(t, a.GetUsers, target)
+			require.Equal(t, http.StatusBadRequest, rr.Code, target)
+			require.Contains(t, rr.Body.String(), "INVALID_REQUEST", target)
+		}
+
+		rr := serve(t, a.Departments, "/departments?limit=1001")
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// pictureDir is the path the relative picture URLs must be under.
+const pictureDir = "/images/"
+
+// WithPictureHosts allows the absolute picture URLs of the given hosts, in addition to the paths under /images/.
+// An entry is either an exact host, a wildcard like "*.example.com" that matches every subdomain
+// of example.com, or "*" that matches any host.
+func WithPictureHosts(hosts []string) Option {
+	return func(a *API) {
+		a.pictureHosts = hosts
+	}
+}
+
+// isPictureURLAllowed reports whether pictureURL is empty, a clean path under /images/,
+// or an http(s) URL of an allowed host.
+func (a *API) isPictureURLAllowed(pictureURL string) bool {
+	if pictureURL == "" {
+		return true
+	}
+	u, err := url.Parse(pictureURL)
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "" && u.Host == "" {
+		return strings.HasPrefix(u.Path, pictureDir) && path.Clean(u.Path) == u.Path
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") &&
+		u.User == nil &&
+		matchHost(strings.ToLower(u.Hostname()), a.pictureHosts)
+}
+
+// checkPictureURL writes an InvalidRequestError and returns false if pictureURL is not allowed.
+func (a *API) checkPictureURL(ctx context.Context, w http.ResponseWriter, pictureURL string) bool {
+	if a.isPictureURLAllowed(pictureURL) {
+		return true
+	}
+	writeError(ctx, w, ErrInvalidRequest.
+		WithDetails("pictureUrl must be a path under "+pictureDir+" or a URL of an allowed host").
+		WithStatus(http.StatusBadRequest))
+	return false
+}
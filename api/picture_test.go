@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPictureURL(t *testing.T) {
+	a := New(nil, nil, nil, WithPictureHosts([]string{"cdn.sesc.ru", "*.images.sesc.ru"}))
+
+	t.Run("allowed", func(t *testing.T) {
+		for _, pictureURL := range []string{
+			"",
+			"/images/users/ivan.jpg",
+			"https://cdn.sesc.ru/users/ivan.jpg",
+			"http://CDN.sesc.ru/users/ivan.jpg",
+			"https://eu.images.sesc.ru/ivan.jpg",
+		} {
+			require.True(t, a.isPictureURLAllowed(pictureURL), pictureURL)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		for _, pictureURL := range []string{
+			"javascript:alert(1)",
+			"JavaScript://cdn.sesc.ru/%0Aalert(1)",
+			"data:image/png;base64,AAAA",
+			"/test.jpg",
+			"/images/../secret.txt",
+			"images/ivan.jpg",
+			"//evil.com/images/ivan.jpg",
+			"https://evil.com/ivan.jpg",
+			"https://images.sesc.ru/ivan.jpg",
+			"https://user@cdn.sesc.ru/ivan.jpg",
+			"ftp://cdn.sesc.ru/ivan.jpg",
+		} {
+			require.False(t, a.isPictureURLAllowed(pictureURL), pictureURL)
+		}
+	})
+
+	t.Run("no allowed hosts", func(t *testing.T) {
+		a := New(nil, nil, nil)
+		require.True(t, a.isPictureURLAllowed("/images/users/ivan.jpg"))
+		require.False(t, a.isPictureURLAllowed("https://cdn.sesc.ru/users/ivan.jpg"))
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		rr := httptest.NewRecorder()
+
+		require.False(t, a.checkPictureURL(ctx, rr, "javascript:alert(1)"))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), `"code":"INVALID_REQUEST"`)
+	})
+}
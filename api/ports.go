@@ -4,9 +4,12 @@ import (
 	"context"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/audit"
+	"github.com/kozlov-ma/sesc-backend/db/migrations"
 	"github.com/kozlov-ma/sesc-backend/iam"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/kozlov-ma/sesc-backend/webhook"
 )
 
 type (
@@ -15,7 +18,7 @@ type (
 	IAMService interface {
 		// RegisterCredentials assigns username/password to an existing userID, returns authID.
 		// Returns ErrUserDoesNotExist if user does not exist, ErrUserAlreadyExists if username exists,
-		// or ErrInvalidCredentials if credentials are invalid.
+		// ErrInvalidCredentials if credentials are invalid, or ErrWeakPassword if the password is too weak.
 		RegisterCredentials(
 			ctx context.Context,
 			userID uuid.UUID,
@@ -27,10 +30,26 @@ type (
 		LoginAdmin(ctx context.Context, creds iam.Credentials) (string, error)
 		// ImWatermelon parses tokenString, returns Identity or error
 		ImWatermelon(ctx context.Context, tokenString string) (iam.Identity, error)
-		// DropCredentials deletes credentials by userID
+		// DropCredentials deletes credentials by userID.
+		//
+		// Returns an iam.ErrCredentialsNotFound if the user has no credentials,
+		// or an iam.ErrUserNotFound if the user does not exist.
 		DropCredentials(ctx context.Context, userID uuid.UUID) error
 		// Credentials returns username/password for a userID
 		Credentials(ctx context.Context, userID uuid.UUID) (iam.Credentials, error)
+		// AllCredentials returns the usernames of the users matching the filter, without the passwords.
+		AllCredentials(ctx context.Context, filter iam.AccountFilter) ([]iam.Account, error)
+		// HasCredentials reports whether a userID has credentials.
+		// Returns ErrUserNotFound if the user does not exist.
+		HasCredentials(ctx context.Context, userID uuid.UUID) (bool, error)
+		// ChangePassword replaces the password of a userID after checking the old one.
+		// Returns ErrInvalidCredentials if the old password is wrong,
+		// or ErrWeakPassword if the new password does not meet the password policy.
+		ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) error
+		// ResetPassword replaces the password of a userID without checking the old one.
+		// Returns ErrUserNotFound if the user does not exist, ErrCredentialsNotFound if the user has no credentials,
+		// or ErrWeakPassword if the new password does not meet the password policy.
+		ResetPassword(ctx context.Context, userID uuid.UUID, newPassword string) error
 	}
 
 	SESC interface {
@@ -38,28 +57,159 @@ type (
 		//
 		// Returns an ErrInvalidRole if the new role id is invalid.
 		// Returns an ErrInvalidUserName if the first or last name is missing.
+		// Returns an ErrStaleUser if upd.Version is set and does not match the user's current version.
 		UpdateUser(ctx context.Context, id sesc.UUID, upd sesc.UserUpdateOptions) (sesc.User, error)
+		// UpdateUserFields changes only the fields set in the patch and returns the user before and after the update.
+		//
+		// Returns an ErrInvalidRoleChange if the role of the user can't have the department of the user after the update,
+		// an ErrUserNotFound if the user does not exist or is archived,
+		// or an ErrStaleUser if patch.Version is set and does not match the user's current version.
+		UpdateUserFields(ctx context.Context, id sesc.UUID, patch sesc.UserPatch) (before, after sesc.User, err error)
 		// CreateUser creates a new User with a specified role.
 		//
 		// Returns an ErrInvalidUserName if the first or last name is missing.
 		CreateUser(ctx context.Context, opt sesc.UserUpdateOptions) (sesc.User, error)
-		// Return a sesc.DepartmentAlreadyExists if the department already exists
+		// DeleteUser deletes a user together with their credentials.
+		//
+		// Returns an ErrUserNotFound if the user does not exist.
+		DeleteUser(ctx context.Context, id sesc.UUID) error
+		// ArchiveUser hides a user from listings and blocks their logins, keeping their record.
+		//
+		// Returns an ErrUserNotFound if the user does not exist or is already archived.
+		ArchiveUser(ctx context.Context, id sesc.UUID) error
+		// CreateDepartment creates a department.
+		//
+		// Returns a sesc.ErrDepartmentExists if a department with the same name, ignoring case and spaces,
+		// already exists, or a sesc.ErrInvalidDepartmentName if the name or description is invalid.
 		CreateDepartment(ctx context.Context, name, description string) (sesc.Department, error)
+		// UpsertDepartment creates a department, or updates the description of the one with the same name.
+		UpsertDepartment(ctx context.Context, name, description string) (sesc.Department, error)
+		// CreateDepartmentWithHead creates a department together with its head, who gets the Dephead role.
+		// Neither is created if any of them cannot be created.
+		CreateDepartmentWithHead(
+			ctx context.Context,
+			name, description string,
+			head sesc.UserUpdateOptions,
+		) (sesc.Department, sesc.User, error)
+		// UpdateDepartment updates the department's name and description.
+		//
+		// Returns a sesc.ErrDepartmentNotFound if the department does not exist,
+		// a sesc.ErrDepartmentExists if another department already has the new name, ignoring case and spaces,
+		// or a sesc.ErrInvalidDepartmentName if the name or description is invalid.
 		UpdateDepartment(ctx context.Context, id sesc.UUID, name, description string) error
-		// User returns a User by ID. If the user does not exist, returns a sesc.ErrUserNotFound.
+		// User returns a User by ID. If the user does not exist or is archived, returns a sesc.ErrUserNotFound.
 		User(ctx context.Context, id sesc.UUID) (sesc.User, error)
 
-		// Users returns all the users currently registered within the system.
-		Users(ctx context.Context) ([]sesc.User, error)
+		// Users returns the users matching the filter, except for the archived ones.
+		Users(ctx context.Context, filter sesc.UserFilter) ([]sesc.User, error)
+
+		// UsersAfter returns up to limit users matching the filter with IDs greater than afterID, ordered by ID,
+		// except for the archived ones, and the afterID of the next page, or uuid.Nil if there are no more users.
+		UsersAfter(ctx context.Context, afterID sesc.UUID, limit int, filter sesc.UserFilter) ([]sesc.User, sesc.UUID, error)
+		// UsersPage returns up to limit users matching the filter, except for the archived ones, ordered by ID,
+		// skipping the first offset of them, and the total number of the matching users.
+		UsersPage(ctx context.Context, offset, limit int, filter sesc.UserFilter) ([]sesc.User, int, error)
+		// UsersByIDs returns the users with the given IDs, in the order of the IDs and each once.
+		// IDs of the users that do not exist or are archived are omitted.
+		UsersByIDs(ctx context.Context, ids []sesc.UUID) ([]sesc.User, error)
+		// SetSuspended suspends or reinstates a user, and reports whether that changed the user.
+		//
+		// Returns an ErrUserNotFound if the user does not exist or is archived.
+		SetSuspended(ctx context.Context, id sesc.UUID, suspended bool) (bool, error)
+		// SetRole changes the role of a user.
+		//
+		// Returns an ErrInvalidRole if the role does not exist,
+		// an ErrInvalidRoleChange if the user has a department and the new role cannot have one,
+		// or an ErrUserNotFound if the user does not exist or is archived.
+		SetRole(ctx context.Context, id sesc.UUID, roleID int32) error
+		// SetDepartment assigns a user to a department, or clears the department if deptID is uuid.Nil.
+		//
+		// Returns an ErrInvalidDepartment if the department does not exist,
+		// an ErrInvalidRoleChange if the role of the user cannot have a department,
+		// or an ErrUserNotFound if the user does not exist or is archived.
+		SetDepartment(ctx context.Context, id, deptID sesc.UUID) error
+		// GrantPermissions grants permissions to a user in addition to the ones of their role.
+		//
+		// Returns an ErrInvalidPermission if any of the permissions does not exist,
+		// or an ErrUserNotFound if the user does not exist or is archived.
+		GrantPermissions(ctx context.Context, id sesc.UUID, permissionIDs []int32) error
+		// RevokePermissions revokes permissions granted to a user by GrantPermissions.
+		//
+		// Returns an ErrInvalidPermission if any of the permissions does not exist,
+		// or an ErrUserNotFound if the user does not exist or is archived.
+		RevokePermissions(ctx context.Context, id sesc.UUID, permissionIDs []int32) error
+		// EmploymentHistory returns the changes of the role and the department of a user, oldest first.
+		//
+		// Returns an ErrUserNotFound if the user does not exist.
+		EmploymentHistory(ctx context.Context, userID sesc.UUID) ([]sesc.EmploymentEvent, error)
 
 		// Departments returns all the departments currently registered within the system.
 		Departments(ctx context.Context) ([]sesc.Department, error)
+		// Stats computes an overview of the departments and the users, except for the archived ones.
+		Stats(ctx context.Context) (sesc.OrgStats, error)
+		// DepartmentsPage returns up to limit departments ordered by name, skipping the first offset of them,
+		// and the total number of the departments.
+		DepartmentsPage(ctx context.Context, offset, limit int) ([]sesc.Department, int, error)
+		// DepartmentsByIDs returns the departments with the given IDs.
+		// IDs of the departments that do not exist are absent from the map.
+		DepartmentsByIDs(ctx context.Context, ids []sesc.UUID) (map[sesc.UUID]sesc.Department, error)
 		DepartmentByID(ctx context.Context, id sesc.UUID) (sesc.Department, error)
+		// DepartmentHead returns the user with the Dephead role assigned to the department.
+		//
+		// Returns a sesc.ErrDepartmentNotFound if the department does not exist,
+		// or a sesc.ErrUserNotFound if the department has no head.
+		DepartmentHead(ctx context.Context, deptID sesc.UUID) (sesc.User, error)
+		// UsersInDepartment returns the users of a department, except for the archived ones.
+		//
+		// Returns a sesc.ErrDepartmentNotFound if the department does not exist.
+		UsersInDepartment(ctx context.Context, deptID sesc.UUID) ([]sesc.User, error)
+		// DepartmentUserCount returns the number of the users of a department, archived ones included.
+		// A department can only be deleted if it is zero.
+		//
+		// Returns a sesc.ErrDepartmentNotFound if the department does not exist.
+		DepartmentUserCount(ctx context.Context, deptID sesc.UUID) (int, error)
 		DeleteDepartment(ctx context.Context, id sesc.UUID) error
+		// TransferDepartmentUsers moves all the users of a department to another one, returning their number.
+		//
+		// Returns a sesc.ErrDepartmentNotFound if the source department does not exist,
+		// or a sesc.ErrInvalidDepartment if the target one does not exist or is the source one.
+		TransferDepartmentUsers(ctx context.Context, fromID, toID sesc.UUID) (int, error)
 		UpdateProfilePicture(ctx context.Context, id sesc.UUID, pictureURL string) error
+
+		// Ping returns an error if the underlying database is unreachable.
+		Ping(ctx context.Context) error
+	}
+
+	// Webhook notifies downstream systems about the user lifecycle events.
+	Webhook interface {
+		// Notify queues e for delivery. It must not block, and records its failures in the record of ctx.
+		Notify(ctx context.Context, e webhook.Event)
+	}
+
+	// AuditLog records mutating operations performed through the API.
+	AuditLog interface {
+		// Record appends an entry to the audit log.
+		Record(ctx context.Context, e audit.Entry) error
+		// Entries returns the most recent entries matching the filter, newest first,
+		// and the After of the next page, or uuid.Nil if there are no more entries.
+		//
+		// Returns an audit.ErrInvalidCursor if there is no entry with the ID f.After.
+		Entries(ctx context.Context, f audit.Filter) ([]audit.Entry, uuid.UUID, error)
 	}
 
 	EventSink interface {
 		ProcessEvent(*event.Record)
 	}
+
+	// Migrations reports the schema status of the database.
+	Migrations interface {
+		// Status returns the schema of the running code and the last one applied to the database.
+		Status(ctx context.Context) (migrations.Status, error)
+	}
+
+	// EventLog keeps the last processed event records.
+	EventLog interface {
+		// LastEvents returns the kept records, newest first.
+		LastEvents() []*event.Record
+	}
 )
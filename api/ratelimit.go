@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimitPruneInterval is how often idle buckets are looked for.
+	rateLimitPruneInterval = time.Minute
+	// rateLimitIdleTimeout is how long a bucket may stay unused before it is dropped.
+	rateLimitIdleTimeout = 10 * time.Minute
+)
+
+// rateLimiter keeps a token bucket per client.
+type rateLimiter struct {
+	mu        sync.Mutex
+	limit     rate.Limit
+	burst     int
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateLimiter(perMinute int) *rateLimiter {
+	return &rateLimiter{
+		limit:     rate.Limit(float64(perMinute) / time.Minute.Seconds()),
+		burst:     perMinute,
+		buckets:   make(map[string]*bucket),
+		lastPrune: time.Now(),
+	}
+}
+
+// allow reports whether the client with the given key may make a request now.
+func (l *rateLimiter) allow(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastPrune) >= rateLimitPruneInterval {
+		l.prune(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	return b.limiter.AllowN(now, 1)
+}
+
+// prune drops the buckets that have not been used for rateLimitIdleTimeout.
+// Should be called with l.mu held.
+func (l *rateLimiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= rateLimitIdleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+// RateLimitMiddleware limits every client to perMinute requests per minute.
+// Clients are told apart by their identity, or by their IP if they are not authenticated,
+// so it should be used after AuthMiddleware.
+func (a *API) RateLimitMiddleware(perMinute int) func(http.Handler) http.Handler {
+	limiter := newRateLimiter(perMinute)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			rec := event.Get(ctx)
+
+			key := rateLimitKey(r)
+			if !limiter.allow(key, time.Now()) {
+				rec.Sub("http").Set(
+					"rate_limited", true,
+					"rate_limit_key", key,
+				)
+				w.Header().Set("Retry-After", "60")
+				writeError(ctx, w, ErrTooManyRequests.WithStatus(http.StatusTooManyRequests))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitKey returns the identity ID for authenticated requests and the remote IP otherwise.
+func rateLimitKey(r *http.Request) string {
+	if identity, ok := GetIdentityFromContext(r.Context()); ok {
+		return "id:" + identity.ID.String()
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/iam"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	const perMinute = 5
+
+	setup := func(t *testing.T) func(identity *iam.Identity, remoteAddr string) int {
+		t.Helper()
+		handler := New(nil, nil, nil).RateLimitMiddleware(perMinute)(
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+
+		return func(identity *iam.Identity, remoteAddr string) int {
+			ctx := t.Context()
+			ctx, _ = event.NewRecord(ctx, "test")
+			if identity != nil {
+				ctx = context.WithValue(ctx, identityContextKey, *identity)
+			}
+
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+			req.RemoteAddr = remoteAddr
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			return rr.Code
+		}
+	}
+
+	t.Run("anonymous", func(t *testing.T) {
+		serve := setup(t)
+
+		for range perMinute {
+			require.Equal(t, http.StatusOK, serve(nil, "10.0.0.1:1234"))
+		}
+		require.Equal(t, http.StatusTooManyRequests, serve(nil, "10.0.0.1:4321"))
+
+		// Other clients are not affected
+		require.Equal(t, http.StatusOK, serve(nil, "10.0.0.2:1234"))
+	})
+
+	t.Run("authenticated", func(t *testing.T) {
+		serve := setup(t)
+		identity := &iam.Identity{ID: uuid.Must(uuid.NewV7()), Role: iam.RoleUser}
+
+		for range perMinute {
+			require.Equal(t, http.StatusOK, serve(identity, "10.0.0.1:1234"))
+		}
+		require.Equal(t, http.StatusTooManyRequests, serve(identity, "10.0.0.2:1234"))
+
+		// The same IP is limited separately when anonymous
+		require.Equal(t, http.StatusOK, serve(nil, "10.0.0.1:1234"))
+	})
+}
+
+func TestRateLimiterPrune(t *testing.T) {
+	l := newRateLimiter(1)
+	now := time.Now()
+
+	require.True(t, l.allow("idle", now))
+	require.True(t, l.allow("active", now))
+
+	now = now.Add(rateLimitIdleTimeout - rateLimitPruneInterval)
+	require.True(t, l.allow("active", now))
+
+	now = now.Add(rateLimitPruneInterval)
+	require.True(t, l.allow("active", now))
+
+	require.Len(t, l.buckets, 1)
+	require.Contains(t, l.buckets, "active")
+}
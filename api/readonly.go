@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+)
+
+// WithReadOnly starts the API in read-only mode, see ReadOnlyMiddleware. Admins can switch it at runtime.
+func WithReadOnly(readOnly bool) Option {
+	return func(a *API) {
+		a.readOnly.Store(readOnly)
+	}
+}
+
+// readOnlyWritablePaths accept writes in read-only mode, so that admins can log in and switch the mode off,
+// and /users/batch, which only reads despite being a POST.
+var readOnlyWritablePaths = []string{"/auth/login", "/auth/admin/login", "/dev/readonly", "/users/batch"}
+
+// ReadOnlyMiddleware responds 503 with a ServiceUnavailableError to the requests that may change data,
+// i.e. all but GET, HEAD and OPTIONS ones, while the API is in read-only mode.
+func (a *API) ReadOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case !a.readOnly.Load(),
+			r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions,
+			slices.Contains(readOnlyWritablePaths, r.URL.Path):
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		event.Get(ctx).Set("read_only", true)
+		writeError(ctx, w, ErrReadOnly.WithStatus(http.StatusServiceUnavailable))
+	})
+}
+
+type ReadOnlyRequest struct {
+	ReadOnly *bool `json:"readOnly" example:"true" validate:"required"`
+}
+
+type ReadOnlyResponse struct {
+	ReadOnly bool `json:"readOnly" example:"false"`
+}
+
+// ReadOnly godoc
+// @Summary Get the read-only mode
+// @Description Reports whether the API is in read-only mode, in which all the writes are rejected with 503.
+// @Tags dev
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Success 200 {object} ReadOnlyResponse
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Router /dev/readonly [get]
+func (a *API) ReadOnly(w http.ResponseWriter, r *http.Request) {
+	a.writeJSON(r.Context(), w, ReadOnlyResponse{ReadOnly: a.readOnly.Load()}, http.StatusOK)
+}
+
+// SetReadOnly godoc
+// @Summary Switch the read-only mode
+// @Description Switches the read-only mode on or off, e.g. for the time of a migration.
+// @Description In read-only mode all the writes, except for logins and this endpoint, are rejected with 503.
+// @Description The mode is shared by all the organizations, so only the admins of the default one can switch it.
+// @Tags dev
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param request body ReadOnlyRequest true "Read-only mode"
+// @Success 200 {object} ReadOnlyResponse
+// @Failure 400 {object} ValidationError "Invalid request"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin of the default organization required"
+// @Router /dev/readonly [put]
+func (a *API) SetReadOnly(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if sesc.OrgFromContext(ctx) != sesc.DefaultOrg {
+		writeError(ctx, w, ErrForbidden.WithDetails("Only the admins of the default organization can switch the read-only mode").
+			WithStatus(http.StatusForbidden))
+		return
+	}
+
+	var req ReadOnlyRequest
+	if !a.decodeValidJSON(w, r, &req) {
+		return
+	}
+
+	was := a.readOnly.Swap(*req.ReadOnly)
+	event.Get(ctx).Sub("read_only").Set(
+		"was", was,
+		"now", *req.ReadOnly,
+	)
+
+	a.writeJSON(ctx, w, ReadOnlyResponse{ReadOnly: *req.ReadOnly}, http.StatusOK)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	setup := func(t *testing.T, opts ...Option) (*API, func(method, path string) *httptest.ResponseRecorder) {
+		t.Helper()
+		a := New(nil, nil, nil, opts...)
+		handler := a.ReadOnlyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		return a, func(method, path string) *httptest.ResponseRecorder {
+			ctx, _ := event.NewRecord(t.Context(), "test")
+			req := httptest.NewRequestWithContext(ctx, method, path, nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			return rr
+		}
+	}
+
+	t.Run("writes are blocked", func(t *testing.T) {
+		_, serve := setup(t, WithReadOnly(true))
+
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			rr := serve(method, "/users")
+			require.Equal(t, http.StatusServiceUnavailable, rr.Code, method)
+			require.Contains(t, rr.Body.String(), `"code":"READ_ONLY"`, method)
+			require.Contains(t, rr.Body.String(), `"ruMessage"`, method)
+		}
+	})
+
+	t.Run("reads pass", func(t *testing.T) {
+		_, serve := setup(t, WithReadOnly(true))
+
+		for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+			require.Equal(t, http.StatusOK, serve(method, "/users").Code, method)
+		}
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/users/batch").Code)
+	})
+
+	t.Run("logins and the switch pass", func(t *testing.T) {
+		_, serve := setup(t, WithReadOnly(true))
+
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/auth/login").Code)
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/auth/admin/login").Code)
+		require.Equal(t, http.StatusOK, serve(http.MethodPut, "/dev/readonly").Code)
+	})
+
+	t.Run("switched at runtime", func(t *testing.T) {
+		a, serve := setup(t)
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/users").Code)
+
+		a.readOnly.Store(true)
+		require.Equal(t, http.StatusServiceUnavailable, serve(http.MethodPost, "/users").Code)
+
+		a.readOnly.Store(false)
+		require.Equal(t, http.StatusOK, serve(http.MethodPost, "/users").Code)
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+const (
+	// RequestIDHeader carries the correlation ID of a request, echoed in the response.
+	RequestIDHeader = "X-Request-ID"
+
+	requestIDContextKey contextKey = "request_id"
+	// maxRequestIDLength caps the client-provided request IDs, longer ones are replaced.
+	maxRequestIDLength = 128
+)
+
+// GetRequestIDFromContext retrieves the request ID from the request context if it exists
+func GetRequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey).(string)
+	return id, ok
+}
+
+// RequestIDMiddleware takes the request ID from the X-Request-ID header, or generates a UUIDv7 if there is
+// no valid one, adds it to the context and the root event record, and echoes it in the response.
+// It must run after EventMiddleware.
+func (a *API) RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		rec := event.Root(ctx)
+
+		id := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(id) {
+			newID, err := uuid.NewV7()
+			if err != nil {
+				rec.Add(events.Error, fmt.Errorf("couldn't generate request ID: %w", err))
+				next.ServeHTTP(w, r)
+				return
+			}
+			id = newID.String()
+		}
+
+		rec.Set("request_id", id)
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx = context.WithValue(ctx, requestIDContextKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isValidRequestID reports whether id is a non-empty string of at most maxRequestIDLength printable ASCII characters,
+// so that it is safe to log and to echo back.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := range len(id) {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	// serve returns the response, the ID seen by the handler and the one in the record.
+	serve := func(t *testing.T, header string) (rr *httptest.ResponseRecorder, seen string, recorded any) {
+		t.Helper()
+		a := New(nil, nil, nil)
+		handler := a.RequestIDMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			seen, _ = GetRequestIDFromContext(r.Context())
+		}))
+
+		ctx, rec := event.NewRecord(t.Context(), "test")
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set(RequestIDHeader, header)
+		}
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr, seen, rec.Value("request_id")
+	}
+
+	t.Run("round-trips", func(t *testing.T) {
+		rr, seen, recorded := serve(t, "client-id-42")
+		require.Equal(t, "client-id-42", rr.Header().Get(RequestIDHeader))
+		require.Equal(t, "client-id-42", seen)
+		require.Equal(t, "client-id-42", recorded)
+	})
+
+	t.Run("generated when absent", func(t *testing.T) {
+		rr, seen, recorded := serve(t, "")
+		id := rr.Header().Get(RequestIDHeader)
+		parsed, err := uuid.FromString(id)
+		require.NoError(t, err)
+		require.Equal(t, uuid.V7, parsed.Version())
+		require.Equal(t, id, seen)
+		require.Equal(t, id, recorded)
+	})
+
+	t.Run("generated when invalid", func(t *testing.T) {
+		for _, header := range []string{"with space", "ид", strings.Repeat("a", maxRequestIDLength+1)} {
+			rr, _, _ := serve(t, header)
+			id := rr.Header().Get(RequestIDHeader)
+			require.NotEqual(t, header, id)
+			_, err := uuid.FromString(id)
+			require.NoError(t, err, header)
+		}
+	})
+}
@@ -10,16 +10,46 @@ type RolesResponse struct {
 	Roles []Role `json:"roles"`
 }
 
+// Role is a predefined role. ParentID is the role it inherits the permissions from,
+// and Permissions include the inherited ones.
 type Role struct {
-	ID          int32        `json:"id"          example:"1"             validate:"required"`
-	Name        string       `json:"name"        example:"Преподаватель" validate:"required"`
-	Permissions []Permission `json:"permissions"                         validate:"required"`
+	ID          int32        `json:"id"                example:"1"             validate:"required"`
+	Name        string       `json:"name"              example:"Преподаватель" validate:"required"`
+	ParentID    int32        `json:"parentId,omitzero" example:"1"`
+	Permissions []Permission `json:"permissions"                               validate:"required"`
 }
 
 type PermissionsResponse struct {
 	Permissions []Permission `json:"permissions" validate:"required"`
 }
 
+type InvalidPermissionError struct {
+	Code       string `json:"code"             example:"INVALID_PERMISSION"`
+	Message    string `json:"message"          example:"Invalid permission ID"`
+	RuMessage  string `json:"ruMessage"        example:"Некорректный идентификатор разрешения"`
+	EnMessage  string `json:"enMessage"        example:"Invalid permission ID"`
+	Details    string `json:"details,omitzero"`
+	StatusCode int    `json:"-"`
+}
+
+// WithDetails adds detail information to the error
+func (e InvalidPermissionError) WithDetails(details string) InvalidPermissionError {
+	e.Details = details
+	return e
+}
+
+// WithStatus adds HTTP status code to the error
+func (e InvalidPermissionError) WithStatus(statusCode int) Error {
+	e.StatusCode = statusCode
+	return Error(e)
+}
+
+var ErrInvalidPermission = InvalidPermissionError{
+	Code:      "INVALID_PERMISSION",
+	Message:   "Invalid permission ID",
+	RuMessage: "Некорректный идентификатор разрешения",
+}
+
 type Permission struct {
 	ID          int32  `json:"id"          example:"1"                                      validate:"required"`
 	Name        string `json:"name"        example:"draft_achievement_list"                 validate:"required"`
@@ -72,11 +102,36 @@ func (a *API) Permissions(w http.ResponseWriter, r *http.Request) {
 	a.writeJSON(ctx, w, response, http.StatusOK)
 }
 
+// CurrentUserPermissions godoc
+// @Summary List the permissions of the current user
+// @Description Retrieves the permissions granted to the current user by their role or in addition to it.
+// @Description Admins get all the permissions.
+// @Tags permissions
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Success 200 {object} PermissionsResponse
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Router /users/me/permissions [get]
+func (a *API) CurrentUserPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	perms := sesc.Permissions
+	if user, ok := GetUserFromContext(ctx); ok {
+		perms = user.Permissions()
+	}
+
+	a.writeJSON(ctx, w, PermissionsResponse{
+		Permissions: convertPermissions(perms),
+	}, http.StatusOK)
+}
+
 func convertRole(r sesc.Role) Role {
 	return Role{
 		ID:          r.ID,
 		Name:        r.Name,
-		Permissions: convertPermissions(r.Permissions),
+		ParentID:    r.ParentID,
+		Permissions: convertPermissions(r.EffectivePermissions()),
 	}
 }
 
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"cmp"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+)
+
+// rosterFont has the Cyrillic glyphs the standard PDF fonts lack. Only the used glyphs are embedded in a roster.
+//
+//go:embed fonts/DejaVuSans.ttf
+var rosterFont []byte
+
+// maxRosterUsers bounds the memory a roster takes, since the whole PDF is built before it is written.
+const maxRosterUsers = 5000
+
+// rosterColumns are the titles and the widths in mm of the columns of a roster, filling an A4 page.
+var rosterColumns = []struct {
+	title string
+	width float64
+}{
+	{"№", 12},
+	{"ФИО", 74},
+	{"Должность", 52},
+	{"Учёное звание", 42},
+}
+
+// DepartmentRosterPDF godoc
+// @Summary Download the roster of a department as PDF
+// @Description Returns a printable list of the users of the department, except for the archived ones,
+// @Description with their name, job title and academic title, ordered by name.
+// @Description Departments with more than 5000 users are rejected with 422.
+// @Tags departments
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "Department UUID"
+// @Success 200 {file} file "PDF file with the roster"
+// @Failure 400 {object} InvalidDepartmentIDError "Invalid UUID format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 404 {object} DepartmentNotFoundError "Department not found"
+// @Failure 422 {object} InvalidRequestError "Department is too large for a roster"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
+// @Router /departments/{id}/roster.pdf [get]
+func (a *API) DepartmentRosterPDF(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := r.PathValue("id")
+	rec := event.Get(ctx)
+
+	var id uuid.UUID
+	if err := (&id).Parse(idStr); err != nil {
+		writeError(ctx, w, ErrInvalidDepartmentID.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	users, err := a.sesc.UsersInDepartment(ctx, id)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	dept, err := a.sesc.DepartmentByID(ctx, id)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	rec.Set("roster_users", len(users))
+	if len(users) > maxRosterUsers {
+		writeError(ctx, w, ErrInvalidRequest.WithDetails(
+			fmt.Sprintf("department has %d users, a roster lists at most %d", len(users), maxRosterUsers),
+		).WithStatus(http.StatusUnprocessableEntity))
+		return
+	}
+
+	slices.SortFunc(users, func(x, y sesc.User) int {
+		return cmp.Or(
+			strings.Compare(x.LastName, y.LastName),
+			strings.Compare(x.FirstName, y.FirstName),
+			strings.Compare(x.MiddleName, y.MiddleName),
+		)
+	})
+
+	pdf := rosterPDF(dept, users)
+	if err := pdf.Error(); err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't generate pdf: %w", err))
+		writeError(ctx, w, ErrServerError.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="roster.pdf"`)
+	w.WriteHeader(http.StatusOK)
+
+	if err := pdf.Output(w); err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't write pdf: %w", err))
+	}
+}
+
+// rosterPDF lays out the roster of dept listing users, a row per user, under a title on the first page
+// and the column titles on every page.
+func rosterPDF(dept sesc.Department, users []sesc.User) *fpdf.Fpdf {
+	const (
+		font       = "DejaVuSans"
+		rowHeight  = 7.0
+		cellMargin = 2.0
+	)
+
+	title := "Состав кафедры «" + dept.Name + "»"
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(font, "", rosterFont)
+	pdf.SetTitle(title, true)
+	pdf.SetAutoPageBreak(true, 15)
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont(font, "", 8)
+		pdf.CellFormat(0, 5, strconv.Itoa(pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.SetHeaderFunc(func() {
+		if pdf.PageNo() == 1 {
+			pdf.SetFont(font, "", 14)
+			pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+			pdf.Ln(2)
+		}
+
+		pdf.SetFont(font, "", 10)
+		pdf.SetFillColor(230, 230, 230)
+		for _, col := range rosterColumns {
+			pdf.CellFormat(col.width, rowHeight, col.title, "1", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+	})
+
+	pdf.AddPage()
+	pdf.SetFont(font, "", 10)
+
+	for n, u := range users {
+		name := strings.Join(strings.Fields(u.LastName+" "+u.FirstName+" "+u.MiddleName), " ")
+		row := []string{strconv.Itoa(n + 1), name, u.JobTitle, u.AcademicTitle}
+		for i, col := range rosterColumns {
+			text := fitText(pdf, row[i], col.width-cellMargin)
+			pdf.CellFormat(col.width, rowHeight, text, "1", 0, "L", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	return pdf
+}
+
+// fitText shortens s with an ellipsis to fit in width at the current font of pdf.
+func fitText(pdf *fpdf.Fpdf, s string, width float64) string {
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if text := string(runes) + "…"; pdf.GetStringWidth(text) <= width {
+			return text
+		}
+	}
+	return ""
+}
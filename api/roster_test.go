@@ -0,0 +1,44 @@
+package api
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRosterPDF(t *testing.T) {
+	t.Run("pages", func(t *testing.T) {
+		users := make([]sesc.User, 100)
+		for i := range users {
+			users[i] = sesc.User{
+				FirstName: "Иван",
+				LastName:  "Петров-" + strconv.Itoa(i),
+				JobTitle:  "Учитель " + strings.Repeat("математики и информатики ", 5),
+			}
+		}
+
+		pdf := rosterPDF(sesc.Department{Name: "Математика"}, users)
+		require.NoError(t, pdf.Error())
+		require.Greater(t, pdf.PageCount(), 1, "100 rows don't fit on a page")
+
+		var buf bytes.Buffer
+		require.NoError(t, pdf.Output(&buf))
+		require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+	})
+
+	t.Run("fit text", func(t *testing.T) {
+		pdf := rosterPDF(sesc.Department{Name: "Математика"}, nil)
+
+		require.Equal(t, "Доцент", fitText(pdf, "Доцент", 40))
+
+		long := strings.Repeat("Учитель математики ", 10)
+		fitted := fitText(pdf, long, 40)
+		require.True(t, strings.HasSuffix(fitted, "…"), fitted)
+		require.LessOrEqual(t, pdf.GetStringWidth(fitted), 40.0)
+		require.True(t, strings.HasPrefix(long, strings.TrimSuffix(fitted, "…")))
+	})
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kozlov-ma/sesc-backend/iam"
+)
+
+const (
+	// AccessPublic routes can be called without a token.
+	AccessPublic = "public"
+	// AccessAuthenticated routes require a token of any role.
+	AccessAuthenticated = "authenticated"
+)
+
+// RouteAccess is who may call a route: AccessPublic, AccessAuthenticated or the name of the iam.Role required.
+type RouteAccess struct {
+	Method string `json:"method" example:"POST"`
+	Path   string `json:"path"   example:"/users"`
+	Access string `json:"access" example:"admin"`
+}
+
+type RouteAccessResponse struct {
+	Routes []RouteAccess `json:"routes" validate:"required"`
+}
+
+// guard restricts the routes registered through the returned router to access, adding the middlewares
+// that enforce it to r, and records them for RoutePermissions.
+func (a *API) guard(r chi.Router, access string) chi.Router {
+	switch access {
+	case AccessPublic:
+	case AccessAuthenticated:
+		r.Use(a.RequireAuthMiddleware)
+	default:
+		r.Use(a.RequireAuthMiddleware)
+		r.Use(a.RoleMiddleware(iam.Role(access)))
+	}
+	return routeRecorder{Router: r, api: a, access: access}
+}
+
+// routeRecorder records the routes registered through it with the access they require.
+type routeRecorder struct {
+	chi.Router
+	api    *API
+	prefix string
+	access string
+}
+
+func (rr routeRecorder) record(method, pattern string) {
+	path := rr.prefix + pattern
+	if rr.prefix != "" && pattern == "/" {
+		path = rr.prefix
+	}
+	rr.api.routes = append(rr.api.routes, RouteAccess{
+		Method: method,
+		Path:   path,
+		Access: rr.access,
+	})
+}
+
+func (rr routeRecorder) with(r chi.Router, prefix string) routeRecorder {
+	return routeRecorder{Router: r, api: rr.api, prefix: prefix, access: rr.access}
+}
+
+func (rr routeRecorder) Get(pattern string, h http.HandlerFunc) {
+	rr.record(http.MethodGet, pattern)
+	rr.Router.Get(pattern, h)
+}
+
+func (rr routeRecorder) Post(pattern string, h http.HandlerFunc) {
+	rr.record(http.MethodPost, pattern)
+	rr.Router.Post(pattern, h)
+}
+
+func (rr routeRecorder) Put(pattern string, h http.HandlerFunc) {
+	rr.record(http.MethodPut, pattern)
+	rr.Router.Put(pattern, h)
+}
+
+func (rr routeRecorder) Patch(pattern string, h http.HandlerFunc) {
+	rr.record(http.MethodPatch, pattern)
+	rr.Router.Patch(pattern, h)
+}
+
+func (rr routeRecorder) Delete(pattern string, h http.HandlerFunc) {
+	rr.record(http.MethodDelete, pattern)
+	rr.Router.Delete(pattern, h)
+}
+
+func (rr routeRecorder) With(middlewares ...func(http.Handler) http.Handler) chi.Router {
+	return rr.with(rr.Router.With(middlewares...), rr.prefix)
+}
+
+func (rr routeRecorder) Group(fn func(r chi.Router)) chi.Router {
+	return rr.Router.Group(func(r chi.Router) {
+		fn(rr.with(r, rr.prefix))
+	})
+}
+
+func (rr routeRecorder) Route(pattern string, fn func(r chi.Router)) chi.Router {
+	return rr.Router.Route(pattern, func(r chi.Router) {
+		fn(rr.with(r, rr.prefix+pattern))
+	})
+}
+
+// RoutePermissions godoc
+// @Summary List who may call each route
+// @Description Lists the method and path of every API route with the access it requires:
+// @Description public, authenticated (any valid token) or the name of the required role, such as admin.
+// @Tags permissions
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Success 200 {object} RouteAccessResponse
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Router /permissions/routes [get]
+func (a *API) RoutePermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	routes := slices.Clone(a.routes)
+	slices.SortFunc(routes, func(x, y RouteAccess) int {
+		return cmp.Or(cmp.Compare(x.Path, y.Path), cmp.Compare(x.Method, y.Method))
+	})
+
+	a.writeJSON(ctx, w, RouteAccessResponse{
+		Routes: routes,
+	}, http.StatusOK)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutePermissions(t *testing.T) {
+	a := New(nil, nil, nil)
+	r := chi.NewRouter()
+	a.RegisterRoutes(r)
+
+	ctx, _ := event.NewRecord(t.Context(), "test")
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/permissions/routes", nil)
+	rr := httptest.NewRecorder()
+	a.RoutePermissions(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp RouteAccessResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	access := make(map[string]string, len(resp.Routes))
+	for _, route := range resp.Routes {
+		access[route.Method+" "+route.Path] = route.Access
+	}
+
+	require.Equal(t, "admin", access["POST /users"])
+	require.Equal(t, "admin", access["GET /permissions/routes"])
+	require.Equal(t, AccessAuthenticated, access["GET /users"])
+	require.Equal(t, AccessAuthenticated, access["GET /users/me"])
+	require.Equal(t, AccessAuthenticated, access["GET /users/{id}"])
+	require.Equal(t, AccessAuthenticated, access["POST /users/batch"])
+	require.Equal(t, AccessPublic, access["POST /auth/login"])
+	require.Equal(t, AccessPublic, access["GET /departments"])
+
+	// Every route of the router is listed.
+	err := chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.HasPrefix(route, "/swagger/") {
+			return nil
+		}
+		route = strings.TrimSuffix(route, "/")
+		require.Contains(t, access, method+" "+route)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Routes, len(access), "Every route is listed once")
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+)
+
+type RoleCount struct {
+	RoleID int32 `json:"roleId" example:"1"`
+	Count  int   `json:"count"  example:"12"`
+}
+
+// StatsResponse is an overview of the departments and the users. Archived users are not counted,
+// UsersByRole lists every role ordered by ID, and AverageEmploymentRate is the mean over the users
+// with a non-zero employment rate.
+type StatsResponse struct {
+	Departments           int         `json:"departments"           example:"4"`
+	Users                 int         `json:"users"                 example:"15"`
+	UsersByRole           []RoleCount `json:"usersByRole"                          validate:"required"`
+	AverageEmploymentRate float64     `json:"averageEmploymentRate" example:"0.85"`
+}
+
+// Stats godoc
+// @Summary Get an overview of the departments and the users
+// @Description Returns the number of the departments and of the users that are not archived, the number of the users
+// @Description with each role, and the average employment rate of the users with a non-zero one.
+// @Tags departments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Success 200 {object} StatsResponse
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
+// @Router /stats [get]
+func (a *API) Stats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	stats, err := a.sesc.Stats(ctx)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	a.writeJSON(ctx, w, convertStats(stats), http.StatusOK)
+}
+
+func convertStats(stats sesc.OrgStats) StatsResponse {
+	byRole := make([]RoleCount, len(sesc.Roles))
+	for i, role := range sesc.Roles {
+		byRole[i] = RoleCount{
+			RoleID: role.ID,
+			Count:  stats.UsersByRole[role.ID],
+		}
+	}
+
+	return StatsResponse{
+		Departments:           stats.Departments,
+		Users:                 stats.Users,
+		UsersByRole:           byRole,
+		AverageEmploymentRate: stats.AverageEmploymentRate,
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+)
+
+// WithRequestTimeout cuts off the requests taking longer than d, except for the streaming ones.
+// A non-positive d disables the timeout.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(a *API) {
+		a.requestTimeout = d
+	}
+}
+
+// streamingPaths are not cut off by the request timeout, as their responses are written as they are produced.
+var streamingPaths = []string{"/users.csv"}
+
+// TimeoutMiddleware responds 503 with a TimeoutError to the requests taking longer than d,
+// and cancels their context. Requests to the skipped paths are served without a timeout.
+//
+// The handler is waited for even after the timeout, as it still uses the request and its event record,
+// so handlers must return once their context is done. The response of the handler is buffered until
+// it returns, so the skipped paths should include every endpoint that streams its response.
+func (a *API) TimeoutMiddleware(d time.Duration, skip ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(skip, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{header: make(http.Header)}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+
+			switch err := ctx.Err(); {
+			case errors.Is(err, context.DeadlineExceeded):
+				// The buffered response is dropped, it may be incomplete.
+				event.Get(r.Context()).Sub("http").Set(
+					"timed_out", true,
+					"timeout", d,
+				)
+				writeError(r.Context(), w, ErrTimeout.WithStatus(http.StatusServiceUnavailable))
+			case err != nil:
+				// The client has gone away, there is no one to respond to.
+			default:
+				maps.Copy(w.Header(), tw.header)
+				if tw.code == 0 {
+					tw.code = http.StatusOK
+				}
+				w.WriteHeader(tw.code)
+				if _, err := w.Write(tw.buf.Bytes()); err != nil {
+					event.Get(r.Context()).Sub("http").Set("write_error", err)
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter buffers the response of a handler, so that it can be dropped if the handler times out.
+type timeoutWriter struct {
+	mu     sync.Mutex
+	header http.Header
+	buf    bytes.Buffer
+	code   int
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.code == 0 {
+		tw.code = http.StatusOK
+	}
+	return tw.buf.Write(p)
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.code != 0 {
+		return
+	}
+	tw.code = code
+}
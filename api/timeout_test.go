@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	const timeout = 50 * time.Millisecond
+
+	// slow blocks until its request is cancelled, and would respond 200 afterwards.
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	setup := func(t *testing.T, next http.Handler) func(path string) (*httptest.ResponseRecorder, *event.Record) {
+		t.Helper()
+		handler := New(nil, nil, nil).TimeoutMiddleware(timeout, "/users.csv")(next)
+
+		return func(path string) (*httptest.ResponseRecorder, *event.Record) {
+			ctx, rec := event.NewRecord(t.Context(), "test")
+			req := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			return rr, rec
+		}
+	}
+
+	t.Run("slow handler is cut off", func(t *testing.T) {
+		serve := setup(t, slow)
+
+		start := time.Now()
+		rr, rec := serve("/users")
+		require.Less(t, time.Since(start), 10*timeout)
+
+		require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		require.Contains(t, rr.Body.String(), `"code":"TIMEOUT"`)
+		require.Equal(t, true, rec.Value("http.timed_out"))
+	})
+
+	t.Run("handler is waited for after the timeout", func(t *testing.T) {
+		serve := setup(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+			// The handler still records to the event of the request, which must not be finished yet.
+			time.Sleep(timeout)
+			event.Get(r.Context()).Sub("handler").Set("finished", true)
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		rr, rec := serve("/users")
+		require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		require.Equal(t, true, rec.Value("handler.finished"))
+	})
+
+	t.Run("fast handler passes through", func(t *testing.T) {
+		serve := setup(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("X-Test", "yes")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("created"))
+		}))
+
+		rr, rec := serve("/users")
+		require.Equal(t, http.StatusCreated, rr.Code)
+		require.Equal(t, "yes", rr.Header().Get("X-Test"))
+		require.Equal(t, "created", rr.Body.String())
+		require.Nil(t, rec.Value("http.timed_out"))
+	})
+
+	t.Run("skipped path is not cut off", func(t *testing.T) {
+		serve := setup(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+				w.WriteHeader(http.StatusInternalServerError)
+			case <-time.After(2 * timeout):
+				w.WriteHeader(http.StatusOK)
+			}
+		}))
+
+		rr, _ := serve("/users.csv")
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+}
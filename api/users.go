@@ -1,55 +1,1042 @@
 package api
 
 import (
-	"encoding/json"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/audit"
+	"github.com/kozlov-ma/sesc-backend/iam"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
 	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/kozlov-ma/sesc-backend/webhook"
 )
 
 type UserResponse struct {
-	ID         uuid.UUID  `json:"id"                  example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
-	FirstName  string     `json:"firstName"           example:"Ivan"                                 validate:"required"`
-	LastName   string     `json:"lastName"            example:"Petrov"                               validate:"required"`
-	MiddleName string     `json:"middleName"          example:"Sergeevich"`
-	PictureURL string     `json:"pictureUrl"          example:"/images/users/ivan.jpg"               validate:"required"`
-	Role       Role       `json:"role"                                                               validate:"required"`
-	Suspended  bool       `json:"suspended"                                                          validate:"required"`
-	Department Department `json:"department,omitzero"`
+	ID               uuid.UUID    `json:"id"                  example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
+	FirstName        string       `json:"firstName"           example:"Ivan"                                 validate:"required"`
+	LastName         string       `json:"lastName"            example:"Petrov"                               validate:"required"`
+	MiddleName       string       `json:"middleName"          example:"Sergeevich"`
+	PictureURL       string       `json:"pictureUrl"          example:"/images/users/ivan.jpg"               validate:"required"`
+	Email            string       `json:"email,omitzero"      example:"ipetrov@sesc.ru"`
+	Role             Role         `json:"role"                                                               validate:"required"`
+	ExtraPermissions []Permission `json:"extraPermissions"                                                   validate:"required"`
+	Suspended        bool         `json:"suspended"                                                          validate:"required"`
+	Department       Department   `json:"department,omitzero"`
+	Version          int          `json:"version"             example:"1"                                    validate:"required"`
+
+	Subdivision       string                 `json:"subdivision"               example:"Physics and mathematics"`
+	JobTitle          string                 `json:"jobTitle"                  example:"Teacher of mathematics"`
+	EmploymentRate    float64                `json:"employmentRate"            example:"1.5"`
+	PersonnelCategory sesc.PersonnelCategory `json:"personnelCategory"         example:"pedagogical"             swaggertype:"string" enums:"unspecified,pedagogical,administrative,educational_support,service"`
+	EmploymentType    sesc.EmploymentType    `json:"employmentType"            example:"main"                    swaggertype:"string" enums:"unspecified,main,internal_part_time,external_part_time"`
+	AcademicDegree    sesc.AcademicDegree    `json:"academicDegree"            example:"candidate"               swaggertype:"string" enums:"none,candidate,doctor"`
+	AcademicTitle     string                 `json:"academicTitle"             example:"Docent"`
+	Honors            string                 `json:"honors"                    example:"Honored Teacher"`
+	Category          string                 `json:"category"                  example:"Highest"`
+	DateOfEmployment  *time.Time             `json:"dateOfEmployment,omitzero" example:"2015-09-01T00:00:00Z"`
+	UnemploymentDate  *time.Time             `json:"unemploymentDate,omitzero" example:"2024-06-30T00:00:00Z"`
+}
+
+type CreateUserRequest struct {
+	FirstName    string    `json:"firstName"             example:"Anna"                                 validate:"required"`
+	LastName     string    `json:"lastName"              example:"Smirnova"                             validate:"required"`
+	MiddleName   string    `json:"middleName"            example:"Olegovna"`
+	RoleID       int32     `json:"roleId"                example:"2"                                    validate:"required"`
+	PictureURL   string    `json:"pictureUrl,omitzero"   example:"/images/users/ivan.jpg"`
+	Email        string    `json:"email,omitzero"        example:"asmirnova@sesc.ru"`
+	DepartmentID uuid.UUID `json:"departmentId,omitzero" example:"550e8400-e29b-41d4-a716-446655440000"`
+
+	Subdivision       string                 `json:"subdivision,omitzero"       example:"Physics and mathematics"`
+	JobTitle          string                 `json:"jobTitle,omitzero"          example:"Teacher of mathematics"`
+	EmploymentRate    float64                `json:"employmentRate,omitzero"    example:"1.5"`
+	PersonnelCategory sesc.PersonnelCategory `json:"personnelCategory,omitzero" example:"pedagogical"             swaggertype:"string" enums:"unspecified,pedagogical,administrative,educational_support,service"`
+	EmploymentType    sesc.EmploymentType    `json:"employmentType,omitzero"    example:"main"                    swaggertype:"string" enums:"unspecified,main,internal_part_time,external_part_time"`
+	AcademicDegree    sesc.AcademicDegree    `json:"academicDegree,omitzero"    example:"candidate"               swaggertype:"string" enums:"none,candidate,doctor"`
+	AcademicTitle     string                 `json:"academicTitle,omitzero"     example:"Docent"`
+	Honors            string                 `json:"honors,omitzero"            example:"Honored Teacher"`
+	Category          string                 `json:"category,omitzero"          example:"Highest"`
+	DateOfEmployment  *time.Time             `json:"dateOfEmployment,omitzero"  example:"2015-09-01T00:00:00Z"`
+	UnemploymentDate  *time.Time             `json:"unemploymentDate,omitzero"  example:"2024-06-30T00:00:00Z"`
+
+	// Username and Password are optional. If either is set, the credentials are registered
+	// together with the user, and the user is not created if the registration fails.
+	Username string `json:"username,omitzero" example:"asmirnova"`
+	Password string `json:"password,omitzero" example:"secret123"`
+}
+
+// GetUser godoc
+// @Summary Get user details
+// @Description Retrieves detailed information about a user
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Param If-None-Match header string false "ETag of the cached user"
+// @Success 200 {object} UserResponse
+// @Success 304 "User has not changed since the ETag in If-None-Match"
+// @Header 200 {string} ETag "User version"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
+// @Router /users/{id} [get]
+func (a *API) GetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	idStr := r.PathValue("id")
+
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, InvalidUUIDError{
+			Code:      "INVALID_UUID",
+			Message:   "Invalid user ID format",
+			RuMessage: "Некорректный формат ID пользователя",
+		}.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	user, err := a.sesc.User(ctx, userID)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	a.writeCachedJSON(ctx, w, r, convertUser(user), userETag(user))
+}
+
+// defaultUsersPageLimit and maxUsersPageLimit bound the number of users in a page of /users.
+const (
+	defaultUsersPageLimit = 100
+	maxUsersPageLimit     = 1000
+)
+
+type UsersResponse struct {
+	Users []UserResponse `json:"users" validate:"required"`
+	// NextCursor is the after of the next page, omitted on the last page and if the users are not paginated.
+	NextCursor uuid.UUID `json:"nextCursor,omitzero" example:"0198c3b2-7a3e-7c1a-9f2e-3b1d4c5e6f70"`
+	// Page is only returned for the pages selected by offset.
+	Page *PageInfo `json:"page,omitempty"`
+}
+
+// GetUsers godoc
+// @Summary Get all users registered in the system
+// @Description Retrieves detailed information about all users.
+// @Description With after or limit, returns a page of users ordered by ID (roughly the order of creation), followed by nextCursor if there are more.
+// @Description With offset, returns the page of users ordered by ID starting at offset, described by page, instead.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param suspended query bool false "Only return suspended (true) or active (false) users"
+// @Param academicDegree query string false "Only return users with this academic degree" Enums(none, candidate, doctor)
+// @Param employmentType query string false "Only return users with this employment type" Enums(unspecified, main, internal_part_time, external_part_time)
+// @Param after query string false "Return the users after this cursor, the nextCursor of the previous page"
+// @Param offset query int false "Number of users to skip, cannot be combined with after"
+// @Param limit query int false "Maximum number of users in a page, 100 by default, at most 1000"
+// @Success 200 {object} UsersResponse
+// @Failure 400 {object} InvalidRequestError "Invalid query parameters"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
+// @Router /users [get]
+func (a *API) GetUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	var filter sesc.UserFilter
+	if s := r.URL.Query().Get("suspended"); s != "" {
+		suspended, err := strconv.ParseBool(s)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails("suspended must be true or false").WithStatus(http.StatusBadRequest))
+			return
+		}
+		filter.Suspended = &suspended
+	}
+	if s := r.URL.Query().Get("academicDegree"); s != "" {
+		degree, err := sesc.AcademicDegreeFromString(s)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails("academicDegree must be one of none, candidate, doctor").WithStatus(http.StatusBadRequest))
+			return
+		}
+		filter.AcademicDegree = &degree
+	}
+	if s := r.URL.Query().Get("employmentType"); s != "" {
+		employmentType, err := sesc.EmploymentTypeFromString(s)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails("employmentType must be one of unspecified, main, internal_part_time, external_part_time").WithStatus(http.StatusBadRequest))
+			return
+		}
+		filter.EmploymentType = &employmentType
+	}
+
+	query := r.URL.Query()
+	if query.Has("offset") {
+		if query.Has("after") {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails("after and offset cannot be combined").WithStatus(http.StatusBadRequest))
+			return
+		}
+		a.getUsersOffsetPage(w, r, filter)
+		return
+	}
+	if query.Has("after") || query.Has("limit") {
+		a.getUsersPage(w, r, filter)
+		return
+	}
+
+	users, err := a.sesc.Users(ctx, filter)
+	if err != nil {
+		rec.Add(events.Error, err)
+		if errors.Is(err, sesc.ErrTimeout) {
+			writeError(ctx, w, sescError(err))
+			return
+		}
+		writeError(ctx, w, ServerError{
+			Code:      "SERVER_ERROR",
+			Message:   "Failed to fetch users",
+			RuMessage: "Ошибка получения данных пользователей",
+		}.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	a.writeJSON(ctx, w, UsersResponse{
+		Users: convertUsers(users),
+	}, http.StatusOK)
 }
 
-type CreateUserRequest struct {
-	FirstName    string    `json:"firstName"             example:"Anna"                                 validate:"required"`
-	LastName     string    `json:"lastName"              example:"Smirnova"                             validate:"required"`
-	MiddleName   string    `json:"middleName"            example:"Olegovna"`
-	RoleID       int32     `json:"roleId"                example:"2"                                    validate:"required"`
-	PictureURL   string    `json:"pictureUrl,omitzero"   example:"/images/users/ivan.jpg"`
-	DepartmentID uuid.UUID `json:"departmentId,omitzero" example:"550e8400-e29b-41d4-a716-446655440000"`
+// getUsersPage writes a page of the users matching the filter selected by the after and limit query parameters.
+func (a *API) getUsersPage(w http.ResponseWriter, r *http.Request, filter sesc.UserFilter) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	var afterID uuid.UUID
+	if s := r.URL.Query().Get("after"); s != "" {
+		id, err := uuid.FromString(s)
+		if err != nil {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails("after must be a UUID").WithStatus(http.StatusBadRequest))
+			return
+		}
+		afterID = id
+	}
+
+	limit := defaultUsersPageLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > maxUsersPageLimit {
+			writeError(ctx, w, ErrInvalidRequest.WithDetails(
+				fmt.Sprintf("limit must be a number between 1 and %d", maxUsersPageLimit),
+			).WithStatus(http.StatusBadRequest))
+			return
+		}
+		limit = n
+	}
+
+	users, next, err := a.sesc.UsersAfter(ctx, afterID, limit, filter)
+	if err != nil {
+		rec.Add(events.Error, err)
+		if errors.Is(err, sesc.ErrTimeout) {
+			writeError(ctx, w, sescError(err))
+			return
+		}
+		writeError(ctx, w, ServerError{
+			Code:      "SERVER_ERROR",
+			Message:   "Failed to fetch users",
+			RuMessage: "Ошибка получения данных пользователей",
+		}.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	a.writeJSON(ctx, w, UsersResponse{
+		Users:      convertUsers(users),
+		NextCursor: next,
+	}, http.StatusOK)
+}
+
+// getUsersOffsetPage writes a page of the users matching the filter selected by the offset and limit query parameters.
+func (a *API) getUsersOffsetPage(w http.ResponseWriter, r *http.Request, filter sesc.UserFilter) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	offset, limit, err := parsePage(r, defaultUsersPageLimit, maxUsersPageLimit)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidRequest.WithDetails(err.Error()).WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	users, total, err := a.sesc.UsersPage(ctx, offset, limit, filter)
+	if err != nil {
+		rec.Add(events.Error, err)
+		if errors.Is(err, sesc.ErrTimeout) {
+			writeError(ctx, w, sescError(err))
+			return
+		}
+		writeError(ctx, w, ServerError{
+			Code:      "SERVER_ERROR",
+			Message:   "Failed to fetch users",
+			RuMessage: "Ошибка получения данных пользователей",
+		}.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	a.writeJSON(ctx, w, UsersResponse{
+		Users: convertUsers(users),
+		Page:  newPageInfo(offset, limit, len(users), total),
+	}, http.StatusOK)
+}
+
+// maxUsersBatch bounds the number of IDs in a request to /users/batch.
+const maxUsersBatch = 100
+
+type UsersBatchRequest struct {
+	IDs []uuid.UUID `json:"ids" example:"550e8400-e29b-41d4-a716-446655440000" validate:"required"`
+}
+
+// GetUsersBatch godoc
+// @Summary Get several users by ID
+// @Description Retrieves the users with the given IDs in one request, in the order of the IDs.
+// @Description Duplicate IDs are returned once, and the users that don't exist or are archived are omitted.
+// @Description At most 100 IDs can be requested at once.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param request body UsersBatchRequest true "IDs of the users"
+// @Success 200 {object} UsersResponse
+// @Failure 400 {object} InvalidRequestError "Invalid request format or too many IDs"
+// @Failure 400 {object} ValidationError "Missing IDs"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
+// @Router /users/batch [post]
+func (a *API) GetUsersBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	var req UsersBatchRequest
+	if !a.decodeValidJSON(w, r, &req) {
+		return
+	}
+
+	rec.Set("requested_ids", len(req.IDs))
+	if len(req.IDs) > maxUsersBatch {
+		writeError(ctx, w, ErrInvalidRequest.WithDetails(
+			fmt.Sprintf("at most %d ids can be requested at once", maxUsersBatch),
+		).WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	users, err := a.sesc.UsersByIDs(ctx, req.IDs)
+	if err != nil {
+		rec.Add(events.Error, err)
+		if errors.Is(err, sesc.ErrTimeout) {
+			writeError(ctx, w, sescError(err))
+			return
+		}
+		writeError(ctx, w, ServerError{
+			Code:      "SERVER_ERROR",
+			Message:   "Failed to fetch users",
+			RuMessage: "Ошибка получения данных пользователей",
+		}.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	a.writeJSON(ctx, w, UsersResponse{
+		Users: convertUsers(users),
+	}, http.StatusOK)
+}
+
+// usersCSVHeader is the header row of the CSV user export.
+var usersCSVHeader = []string{
+	"ID",
+	"Фамилия",
+	"Имя",
+	"Отчество",
+	"Кафедра",
+	"Роль",
+	"Должность",
+	"Ставка",
+	"Учёная степень",
+	"Дата приёма",
+	"Дата увольнения",
+	"Отстранён",
+}
+
+// academicDegreeNames are the names of the academic degrees in the CSV user export.
+var academicDegreeNames = map[sesc.AcademicDegree]string{
+	sesc.Candidate: "кандидат наук",
+	sesc.Doctor:    "доктор наук",
+}
+
+// csvDateLayout is the date format of the CSV user export.
+const csvDateLayout = "02.01.2006"
+
+// utf8BOM is written before the CSV, so that Excel detects the encoding and renders Cyrillic correctly.
+const utf8BOM = "\uFEFF"
+
+// ExportUsersCSV godoc
+// @Summary Export users as CSV
+// @Description Streams all the users registered in the system as a CSV file, suitable for opening in Excel.
+// @Tags users
+// @Produce text/csv
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Success 200 {file} file "CSV file with users"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
+// @Router /users.csv [get]
+func (a *API) ExportUsersCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	users, err := a.sesc.Users(ctx, sesc.UserFilter{})
+	if err != nil {
+		rec.Add(events.Error, err)
+		if errors.Is(err, sesc.ErrTimeout) {
+			writeError(ctx, w, sescError(err))
+			return
+		}
+		writeError(ctx, w, ServerError{
+			Code:      "SERVER_ERROR",
+			Message:   "Failed to fetch users",
+			RuMessage: "Ошибка получения данных пользователей",
+		}.WithStatus(http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="users.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't write csv: %w", err))
+		return
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(usersCSVHeader); err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't write csv: %w", err))
+		return
+	}
+
+	for _, u := range users {
+		if err := cw.Write(userCSVRow(u)); err != nil {
+			rec.Add(events.Error, fmt.Errorf("couldn't write csv: %w", err))
+			return
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't write csv: %w", err))
+	}
+
+	rec.Set("exported_users", len(users))
+}
+
+// userCSVRow converts a user to a CSV row matching usersCSVHeader.
+func userCSVRow(u sesc.User) []string {
+	suspended := "нет"
+	if u.Suspended {
+		suspended = "да"
+	}
+
+	var rate string
+	if u.EmploymentRate != 0 {
+		// Excel with the Russian locale expects a decimal comma.
+		rate = strings.Replace(strconv.FormatFloat(u.EmploymentRate, 'f', -1, 64), ".", ",", 1)
+	}
+
+	return []string{
+		u.ID.String(),
+		u.LastName,
+		u.FirstName,
+		u.MiddleName,
+		u.Department.Name,
+		u.Role.Name,
+		u.JobTitle,
+		rate,
+		academicDegreeNames[u.AcademicDegree],
+		csvDate(u.DateOfEmployment),
+		csvDate(u.UnemploymentDate),
+		suspended,
+	}
+}
+
+func csvDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(csvDateLayout)
+}
+
+// CreateUser godoc
+// @Summary Create new user
+// @Description Creates a new user with specified role (non-teacher).
+// @Description If username or password is given, the user's credentials are registered as well.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param request body CreateUserRequest true "User details"
+// @Success 201 {object} UserResponse
+// @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} ValidationError "One or more fields are invalid"
+// @Failure 400 {object} InvalidRoleError "Invalid role ID specified"
+// @Failure 400 {object} InvalidNameError "Invalid name specified"
+// @Failure 400 {object} InvalidEmploymentDataError "Invalid employment data"
+// @Failure 400 {object} InvalidCredentialsError "Invalid credentials format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 409 {object} UserExistsError "User with this username or email already exists"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users [post]
+func (a *API) CreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	var req CreateUserRequest
+
+	if !a.decodeValidJSON(w, r, &req) {
+		return
+	}
+	if !a.checkPictureURL(ctx, w, req.PictureURL) {
+		return
+	}
+
+	user, err := a.sesc.CreateUser(ctx, sesc.UserUpdateOptions{
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		MiddleName:   req.MiddleName,
+		PictureURL:   req.PictureURL,
+		Email:        req.Email,
+		DepartmentID: req.DepartmentID,
+		NewRoleID:    req.RoleID,
+
+		Subdivision:       req.Subdivision,
+		JobTitle:          req.JobTitle,
+		EmploymentRate:    req.EmploymentRate,
+		PersonnelCategory: req.PersonnelCategory,
+		EmploymentType:    req.EmploymentType,
+		AcademicDegree:    req.AcademicDegree,
+		AcademicTitle:     req.AcademicTitle,
+		Honors:            req.Honors,
+		Category:          req.Category,
+		DateOfEmployment:  req.DateOfEmployment,
+		UnemploymentDate:  req.UnemploymentDate,
+	})
+
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	if req.Username != "" || req.Password != "" {
+		_, err := a.iam.RegisterCredentials(ctx, user.ID, iam.Credentials{
+			Username: req.Username,
+			Password: req.Password,
+		})
+		if err != nil {
+			rec.Add(events.Error, err)
+
+			// Compensate for the created user, so that no user without the requested credentials is left behind.
+			if derr := a.sesc.DeleteUser(ctx, user.ID); derr != nil {
+				rec.Add(events.Error, fmt.Errorf("couldn't delete user after failed credentials registration: %w", derr))
+			}
+
+			writeError(ctx, w, iamError(err))
+			return
+		}
+	}
+
+	a.recordAudit(ctx, audit.ActionCreateUser, audit.TargetUser, user.ID, map[string]any{
+		"firstName":    user.FirstName,
+		"lastName":     user.LastName,
+		"middleName":   user.MiddleName,
+		"roleId":       user.Role.ID,
+		"departmentId": user.Department.ID,
+		"email":        user.Email,
+		"username":     req.Username,
+	})
+
+	a.notify(ctx, webhook.UserCreated, user.ID)
+
+	a.writeJSON(ctx, w, convertUser(user), http.StatusCreated)
+}
+
+// validate returns the invalid fields of the request not covered by the validate tags.
+func (req CreateUserRequest) validate() []FieldError {
+	var fields []FieldError
+	if _, ok := sesc.RoleByID(req.RoleID); !ok && req.RoleID != 0 {
+		fields = append(fields, InvalidRoleField("roleId"))
+	}
+	if sesc.ValidateEmail(req.Email) != nil {
+		fields = append(fields, InvalidField("email"))
+	}
+	return fields
+}
+
+// PatchUserRequest defines the fields that can be updated on a User.
+// Fields are pointers so that only non‑nil values are applied to the user record.
+// DepartmentID is only allowed to be set if the user's role is Teacher or Dephead.
+// Version, if set, must match the user's current version, otherwise the update is rejected.
+type PatchUserRequest struct {
+	FirstName    *string    `json:"firstName"             example:"Ivan"                                 validate:"required"`
+	LastName     *string    `json:"lastName"              example:"Petrov"                               validate:"required"`
+	MiddleName   *string    `json:"middleName,omitzero"   example:"Sergeevich"`
+	PictureURL   *string    `json:"pictureUrl,omitzero"   example:"/images/users/ivan.jpg"`
+	Email        *string    `json:"email,omitzero"        example:"ipetrov@sesc.ru"`
+	Suspended    *bool      `json:"suspended,omitzero"    example:"false"                                validate:"required"`
+	DepartmentID *uuid.UUID `json:"departmentId,omitzero" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RoleID       *int32     `json:"roleId,omitzero"       example:"1"                                    validate:"required"`
+	Version      *int       `json:"version,omitzero"      example:"1"`
+
+	Subdivision       *string                 `json:"subdivision,omitzero"       example:"Physics and mathematics"`
+	JobTitle          *string                 `json:"jobTitle,omitzero"          example:"Teacher of mathematics"`
+	EmploymentRate    *float64                `json:"employmentRate,omitzero"    example:"1.5"`
+	PersonnelCategory *sesc.PersonnelCategory `json:"personnelCategory,omitzero" example:"pedagogical"             swaggertype:"string" enums:"unspecified,pedagogical,administrative,educational_support,service"`
+	EmploymentType    *sesc.EmploymentType    `json:"employmentType,omitzero"    example:"main"                    swaggertype:"string" enums:"unspecified,main,internal_part_time,external_part_time"`
+	AcademicDegree    *sesc.AcademicDegree    `json:"academicDegree,omitzero"    example:"candidate"               swaggertype:"string" enums:"none,candidate,doctor"`
+	AcademicTitle     *string                 `json:"academicTitle,omitzero"     example:"Docent"`
+	Honors            *string                 `json:"honors,omitzero"            example:"Honored Teacher"`
+	Category          *string                 `json:"category,omitzero"          example:"Highest"`
+	DateOfEmployment  *time.Time              `json:"dateOfEmployment,omitzero"  example:"2015-09-01T00:00:00Z"`
+	UnemploymentDate  *time.Time              `json:"unemploymentDate,omitzero"  example:"2024-06-30T00:00:00Z"`
+}
+
+// validate returns all the invalid fields among the ones present in the request.
+func (req PatchUserRequest) validate() []FieldError {
+	var fields []FieldError
+	if req.FirstName != nil && *req.FirstName == "" {
+		fields = append(fields, RequiredField("firstName"))
+	}
+	if req.LastName != nil && *req.LastName == "" {
+		fields = append(fields, RequiredField("lastName"))
+	}
+	if req.RoleID != nil {
+		if _, ok := sesc.RoleByID(*req.RoleID); !ok {
+			fields = append(fields, InvalidRoleField("roleId"))
+		}
+	}
+	if req.Email != nil && sesc.ValidateEmail(*req.Email) != nil {
+		fields = append(fields, InvalidField("email"))
+	}
+	return fields
+}
+
+// patch returns the fields present in the request as a patch of the user.
+func (req PatchUserRequest) patch() sesc.UserPatch {
+	return sesc.UserPatch{
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		MiddleName:   req.MiddleName,
+		PictureURL:   req.PictureURL,
+		Email:        req.Email,
+		Suspended:    req.Suspended,
+		DepartmentID: req.DepartmentID,
+		RoleID:       req.RoleID,
+
+		Subdivision:       req.Subdivision,
+		JobTitle:          req.JobTitle,
+		EmploymentRate:    req.EmploymentRate,
+		PersonnelCategory: req.PersonnelCategory,
+		EmploymentType:    req.EmploymentType,
+		AcademicDegree:    req.AcademicDegree,
+		AcademicTitle:     req.AcademicTitle,
+		Honors:            req.Honors,
+		Category:          req.Category,
+		DateOfEmployment:  req.DateOfEmployment,
+		UnemploymentDate:  req.UnemploymentDate,
+	}
+}
+
+// auditDetails returns the fields present in the request, for the audit log.
+func (req PatchUserRequest) auditDetails() map[string]any {
+	details := make(map[string]any)
+	if req.FirstName != nil {
+		details["firstName"] = *req.FirstName
+	}
+	if req.LastName != nil {
+		details["lastName"] = *req.LastName
+	}
+	if req.MiddleName != nil {
+		details["middleName"] = *req.MiddleName
+	}
+	if req.PictureURL != nil {
+		details["pictureUrl"] = *req.PictureURL
+	}
+	if req.Email != nil {
+		details["email"] = *req.Email
+	}
+	if req.Suspended != nil {
+		details["suspended"] = *req.Suspended
+	}
+	if req.DepartmentID != nil {
+		details["departmentId"] = *req.DepartmentID
+	}
+	if req.RoleID != nil {
+		details["roleId"] = *req.RoleID
+	}
+	if req.Subdivision != nil {
+		details["subdivision"] = *req.Subdivision
+	}
+	if req.JobTitle != nil {
+		details["jobTitle"] = *req.JobTitle
+	}
+	if req.EmploymentRate != nil {
+		details["employmentRate"] = *req.EmploymentRate
+	}
+	if req.PersonnelCategory != nil {
+		details["personnelCategory"] = *req.PersonnelCategory
+	}
+	if req.EmploymentType != nil {
+		details["employmentType"] = *req.EmploymentType
+	}
+	if req.AcademicDegree != nil {
+		details["academicDegree"] = *req.AcademicDegree
+	}
+	if req.AcademicTitle != nil {
+		details["academicTitle"] = *req.AcademicTitle
+	}
+	if req.Honors != nil {
+		details["honors"] = *req.Honors
+	}
+	if req.Category != nil {
+		details["category"] = *req.Category
+	}
+	if req.DateOfEmployment != nil {
+		details["dateOfEmployment"] = *req.DateOfEmployment
+	}
+	if req.UnemploymentDate != nil {
+		details["unemploymentDate"] = *req.UnemploymentDate
+	}
+	return details
+}
+
+// ReplaceUserRequest is the full representation of a User, replacing every field of the user record.
+// Omitted optional fields are cleared, e.g. the user is removed from their department if DepartmentID is omitted.
+// DepartmentID is only allowed to be set if the role is Teacher or Dephead.
+// Version, if set, must match the user's current version, otherwise the update is rejected.
+type ReplaceUserRequest struct {
+	FirstName    string    `json:"firstName"             example:"Ivan"                                 validate:"required"`
+	LastName     string    `json:"lastName"              example:"Petrov"                               validate:"required"`
+	MiddleName   string    `json:"middleName"            example:"Sergeevich"`
+	PictureURL   string    `json:"pictureUrl"            example:"/images/users/ivan.jpg"`
+	Email        string    `json:"email,omitzero"        example:"ipetrov@sesc.ru"`
+	Suspended    bool      `json:"suspended"             example:"false"`
+	DepartmentID uuid.UUID `json:"departmentId,omitzero" example:"550e8400-e29b-41d4-a716-446655440000"`
+	RoleID       int32     `json:"roleId"                example:"1"                                    validate:"required"`
+	Version      *int      `json:"version,omitzero"      example:"1"`
+
+	Subdivision       string                 `json:"subdivision"               example:"Physics and mathematics"`
+	JobTitle          string                 `json:"jobTitle"                  example:"Teacher of mathematics"`
+	EmploymentRate    float64                `json:"employmentRate"            example:"1.5"`
+	PersonnelCategory sesc.PersonnelCategory `json:"personnelCategory"         example:"pedagogical"             swaggertype:"string" enums:"unspecified,pedagogical,administrative,educational_support,service"`
+	EmploymentType    sesc.EmploymentType    `json:"employmentType"            example:"main"                    swaggertype:"string" enums:"unspecified,main,internal_part_time,external_part_time"`
+	AcademicDegree    sesc.AcademicDegree    `json:"academicDegree"            example:"candidate"               swaggertype:"string" enums:"none,candidate,doctor"`
+	AcademicTitle     string                 `json:"academicTitle"             example:"Docent"`
+	Honors            string                 `json:"honors"                    example:"Honored Teacher"`
+	Category          string                 `json:"category"                  example:"Highest"`
+	DateOfEmployment  *time.Time             `json:"dateOfEmployment,omitzero" example:"2015-09-01T00:00:00Z"`
+	UnemploymentDate  *time.Time             `json:"unemploymentDate,omitzero" example:"2024-06-30T00:00:00Z"`
+}
+
+// validate returns all the invalid fields of the request.
+func (req ReplaceUserRequest) validate() []FieldError {
+	var fields []FieldError
+	if _, ok := sesc.RoleByID(req.RoleID); !ok && req.RoleID != 0 {
+		fields = append(fields, InvalidRoleField("roleId"))
+	}
+	if sesc.ValidateEmail(req.Email) != nil {
+		fields = append(fields, InvalidField("email"))
+	}
+	return fields
+}
+
+// updateOptions returns the options replacing every field of the user.
+func (req ReplaceUserRequest) updateOptions(version int) sesc.UserUpdateOptions {
+	return sesc.UserUpdateOptions{
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		MiddleName:   req.MiddleName,
+		PictureURL:   req.PictureURL,
+		Email:        req.Email,
+		Suspended:    req.Suspended,
+		DepartmentID: req.DepartmentID,
+		NewRoleID:    req.RoleID,
+		Version:      version,
+
+		Subdivision:       req.Subdivision,
+		JobTitle:          req.JobTitle,
+		EmploymentRate:    req.EmploymentRate,
+		PersonnelCategory: req.PersonnelCategory,
+		EmploymentType:    req.EmploymentType,
+		AcademicDegree:    req.AcademicDegree,
+		AcademicTitle:     req.AcademicTitle,
+		Honors:            req.Honors,
+		Category:          req.Category,
+		DateOfEmployment:  req.DateOfEmployment,
+		UnemploymentDate:  req.UnemploymentDate,
+	}
+}
+
+// expectedVersion returns the user version the client expects to update, or 0 if it does not expect any.
+func (req PatchUserRequest) expectedVersion(r *http.Request) (int, error) {
+	return expectedVersion(r, req.Version)
+}
+
+// expectedVersion returns the version from the If-Match header or from the request body, or 0 if there is none.
+// The If-Match header takes precedence over the version in the request body.
+func expectedVersion(r *http.Request, bodyVersion *int) (int, error) {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		if bodyVersion == nil {
+			return 0, nil
+		}
+		return *bodyVersion, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`))
+	if err != nil {
+		return 0, fmt.Errorf("invalid If-Match header: %w", err)
+	}
+	return version, nil
+}
+
+// userETag returns the ETag matching the version of the user.
+func userETag(user sesc.User) string {
+	return strconv.Quote(strconv.Itoa(user.Version))
+}
+
+// PatchUser godoc
+// @Summary Partially update user
+// @Description Applies a partial update to the user identified by {id}. Only non-nil fields in the request are applied.
+// @Description With Content-Type application/merge-patch+json, the request is a JSON Merge Patch (RFC 7386) instead:
+// @Description absent fields are left unchanged and fields set to null are cleared, e.g. a null departmentId
+// @Description removes the user from their department. firstName, lastName, suspended and roleId can't be null,
+// @Description and a null version is the same as an absent one.
+// Department can only be set for Teacher or Department-Head roles.
+// @Tags users
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Param If-Match header string false "Expected user version, as returned in the ETag header"
+// @Param request body PatchUserRequest true "User fields to update"
+// @Success 200 {object} UserResponse
+// @Header 200 {string} ETag "User version"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} ValidationError "One or more fields are invalid"
+// @Failure 400 {object} InvalidRoleError "Invalid role"
+// @Failure 400 {object} InvalidNameError "Invalid name"
+// @Failure 400 {object} InvalidEmploymentDataError "Invalid employment data"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 409 {object} StaleUserError "User has been modified since it was read"
+// @Failure 409 {object} UserExistsError "User with this email already exists"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users/{id} [patch]
+func (a *API) PatchUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, InvalidUUIDError{
+			Code:      "INVALID_UUID",
+			Message:   "Invalid user ID format",
+			RuMessage: "Некорректный формат ID пользователя",
+		}.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	var req PatchUserRequest
+	var cleared []string
+	if isMergePatchContentType(r.Header.Get("Content-Type")) {
+		var ok bool
+		if req, cleared, ok = a.decodeUserMergePatch(w, r); !ok {
+			return
+		}
+	} else if !a.decodeJSON(w, r, &req) {
+		return
+	}
+
+	if fields := req.validate(); len(fields) > 0 {
+		writeError(ctx, w, ErrValidation.WithFields(fields...).WithStatus(http.StatusBadRequest))
+		return
+	}
+	if req.PictureURL != nil && !a.checkPictureURL(ctx, w, *req.PictureURL) {
+		return
+	}
+
+	version, err := req.expectedVersion(r)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidRequest.WithDetails(err.Error()).WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	patch := req.patch()
+	patch.Version = version
+	for _, name := range cleared {
+		userFieldClearers[name](&patch)
+	}
+
+	existing, updated, err := a.sesc.UpdateUserFields(ctx, userID, patch)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	details := req.auditDetails()
+	for _, name := range cleared {
+		details[name] = nil
+	}
+	a.recordAudit(ctx, audit.ActionUpdateUser, audit.TargetUser, updated.ID, details)
+	a.notifySuspension(ctx, existing, updated)
+
+	w.Header().Set("ETag", userETag(updated))
+	a.writeJSON(ctx, w, convertUser(updated), http.StatusOK)
+}
+
+// ReplaceUser godoc
+// @Summary Replace user
+// @Description Replaces every field of the user identified by {id} with the ones in the request.
+// @Description Unlike PATCH, omitted fields are cleared,
+// @Description e.g. the user is removed from their department if departmentId is omitted.
+// Department can only be set for Teacher or Department-Head roles.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Param If-Match header string false "Expected user version, as returned in the ETag header"
+// @Param request body ReplaceUserRequest true "User fields"
+// @Success 200 {object} UserResponse
+// @Header 200 {string} ETag "User version"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} ValidationError "One or more fields are invalid"
+// @Failure 400 {object} InvalidRoleError "Invalid role"
+// @Failure 400 {object} InvalidEmploymentDataError "Invalid employment data"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 409 {object} InvalidDepartmentError "Department does not exist"
+// @Failure 409 {object} StaleUserError "User has been modified since it was read"
+// @Failure 409 {object} UserExistsError "User with this email already exists"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users/{id} [put]
+func (a *API) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, InvalidUUIDError{
+			Code:      "INVALID_UUID",
+			Message:   "Invalid user ID format",
+			RuMessage: "Некорректный формат ID пользователя",
+		}.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	var req ReplaceUserRequest
+	if !a.decodeValidJSON(w, r, &req) {
+		return
+	}
+	if !a.checkPictureURL(ctx, w, req.PictureURL) {
+		return
+	}
+
+	version, err := expectedVersion(r, req.Version)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidRequest.WithDetails(err.Error()).WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	role, _ := sesc.RoleByID(req.RoleID)
+	if req.DepartmentID != uuid.Nil && !role.CanHaveDepartment() {
+		writeError(ctx, w, InvalidRoleError{
+			Code:      "INVALID_ROLE",
+			Message:   "Unable to assign department to selected role",
+			RuMessage: "Нельзя указать департамент для выбранной роли",
+		}.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	// The user before the update tells whether the replacement suspends or reinstates them.
+	existing, err := a.sesc.User(ctx, userID)
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	updated, err := a.sesc.UpdateUser(ctx, userID, req.updateOptions(version))
+	if err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
+	}
+
+	a.recordAudit(ctx, audit.ActionUpdateUser, audit.TargetUser, updated.ID, map[string]any{
+		"firstName":    updated.FirstName,
+		"lastName":     updated.LastName,
+		"middleName":   updated.MiddleName,
+		"suspended":    updated.Suspended,
+		"roleId":       updated.Role.ID,
+		"departmentId": updated.Department.ID,
+		"email":        updated.Email,
+		"replace":      true,
+	})
+	a.notifySuspension(ctx, existing, updated)
+
+	w.Header().Set("ETag", userETag(updated))
+	a.writeJSON(ctx, w, convertUser(updated), http.StatusOK)
 }
 
-// GetUser godoc
-// @Summary Get user details
-// @Description Retrieves detailed information about a user
+// ArchiveUser godoc
+// @Summary Archive user
+// @Description Archives the user identified by {id}. Archived users are hidden from user listings and cannot log in.
 // @Tags users
-// @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer JWT token"
 // @Param id path string true "User UUID"
-// @Success 200 {object} UserResponse
+// @Success 204 "No content"
 // @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
 // @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
 // @Failure 404 {object} UserNotFoundError "User not found"
 // @Failure 500 {object} ServerError "Internal server error"
-// @Router /users/{id} [get]
-func (a *API) GetUser(w http.ResponseWriter, r *http.Request) {
+// @Router /users/{id} [delete]
+func (a *API) ArchiveUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	rec := event.Get(ctx)
 
 	idStr := r.PathValue("id")
-
 	userID, err := uuid.FromString(idStr)
 	if err != nil {
 		writeError(ctx, w, InvalidUUIDError{
@@ -60,225 +1047,357 @@ func (a *API) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := a.sesc.User(ctx, userID)
-	if err != nil {
+	if err := a.sesc.ArchiveUser(ctx, userID); err != nil {
 		rec.Add(events.Error, err)
 		writeError(ctx, w, sescError(err))
 		return
 	}
 
-	a.writeJSON(ctx, w, UserResponse{
-		ID:         user.ID,
-		FirstName:  user.FirstName,
-		LastName:   user.LastName,
-		MiddleName: user.MiddleName,
-		PictureURL: user.PictureURL,
-		Role:       convertRole(user.Role),
-		Department: convertDepartment(user.Department),
-		Suspended:  user.Suspended,
-	}, http.StatusOK)
+	a.recordAudit(ctx, audit.ActionArchiveUser, audit.TargetUser, userID, nil)
+	a.notify(ctx, webhook.UserArchived, userID)
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
-type UsersResponse struct {
-	Users []UserResponse `json:"users" validate:"required"`
+// SuspendUser godoc
+// @Summary Suspend user
+// @Description Suspends the user identified by {id}. Suspended users cannot log in.
+// @Tags users
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Success 204 "No content"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users/{id}/suspend [post]
+func (a *API) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	a.setSuspended(w, r, true)
 }
 
-// GetUsers godoc
-// @Summary Get all users registered in the system
-// @Description Retrieves detailed information about all users
+// ReinstateUser godoc
+// @Summary Reinstate user
+// @Description Lifts the suspension of the user identified by {id}
 // @Tags users
-// @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer JWT token"
-// @Success 200 {object} UsersResponse
+// @Param id path string true "User UUID"
+// @Success 204 "No content"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
 // @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
 // @Failure 500 {object} ServerError "Internal server error"
-// @Router /users [get]
-func (a *API) GetUsers(w http.ResponseWriter, r *http.Request) {
+// @Router /users/{id}/reinstate [post]
+func (a *API) ReinstateUser(w http.ResponseWriter, r *http.Request) {
+	a.setSuspended(w, r, false)
+}
+
+func (a *API) setSuspended(w http.ResponseWriter, r *http.Request, suspended bool) {
 	ctx := r.Context()
 	rec := event.Get(ctx)
-	users, err := a.sesc.Users(ctx)
+
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidUUID.WithStatus(http.StatusBadRequest))
+		return
+	}
+
+	changed, err := a.sesc.SetSuspended(ctx, userID, suspended)
 	if err != nil {
 		rec.Add(events.Error, err)
-		writeError(ctx, w, ServerError{
-			Code:      "SERVER_ERROR",
-			Message:   "Failed to fetch users",
-			RuMessage: "Ошибка получения данных пользователей",
-		}.WithStatus(http.StatusInternalServerError))
+		writeError(ctx, w, sescError(err))
 		return
 	}
 
-	a.writeJSON(ctx, w, UsersResponse{
-		Users: convertUsers(users),
-	}, http.StatusOK)
+	action := audit.ActionReinstateUser
+	if suspended {
+		action = audit.ActionSuspendUser
+	}
+	a.recordAudit(ctx, action, audit.TargetUser, userID, nil)
+
+	// Subscribers are only notified of the changes, not of the repeated requests.
+	switch {
+	case !changed:
+	case suspended:
+		a.notify(ctx, webhook.UserSuspended, userID)
+	default:
+		a.notify(ctx, webhook.UserReinstated, userID)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// CreateUser godoc
-// @Summary Create new user
-// @Description Creates a new user with specified role (non-teacher)
+type SetRoleRequest struct {
+	RoleID int32 `json:"roleId" example:"2" validate:"required"`
+}
+
+// SetRole godoc
+// @Summary Set user role
+// @Description Changes the role of the user identified by {id}.
+// @Description Only teachers and department heads may have a department: the role of a user with a department
+// @Description cannot be changed to any other role until the department is cleared.
 // @Tags users
 // @Accept json
-// @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer JWT token"
-// @Param request body CreateUserRequest true "User details"
-// @Success 201 {object} UserResponse
+// @Param id path string true "User UUID"
+// @Param request body SetRoleRequest true "New role"
+// @Success 204 "No content"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
 // @Failure 400 {object} InvalidRequestError "Invalid request format"
-// @Failure 400 {object} InvalidRoleError "Invalid role ID specified"
-// @Failure 400 {object} InvalidNameError "Invalid name specified"
+// @Failure 400 {object} InvalidRoleError "Invalid role or role change"
 // @Failure 401 {object} UnauthorizedError "Unauthorized"
 // @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
 // @Failure 500 {object} ServerError "Internal server error"
-// @Router /users [post]
-func (a *API) CreateUser(w http.ResponseWriter, r *http.Request) {
+// @Router /users/{id}/role [put]
+func (a *API) SetRole(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	rec := event.Get(ctx)
 
-	var req CreateUserRequest
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidUUID.WithStatus(http.StatusBadRequest))
 		return
 	}
 
-	user, err := a.sesc.CreateUser(ctx, sesc.UserUpdateOptions{
-		FirstName:    req.FirstName,
-		LastName:     req.LastName,
-		MiddleName:   req.MiddleName,
-		PictureURL:   req.PictureURL,
-		DepartmentID: req.DepartmentID,
-		NewRoleID:    req.RoleID,
-	})
+	var req SetRoleRequest
+	if !a.decodeJSON(w, r, &req) {
+		return
+	}
 
-	if err != nil {
+	if err := a.sesc.SetRole(ctx, userID, req.RoleID); err != nil {
 		rec.Add(events.Error, err)
 		writeError(ctx, w, sescError(err))
 		return
 	}
 
-	a.writeJSON(ctx, w, UserResponse{
-		ID:         user.ID,
-		FirstName:  user.FirstName,
-		LastName:   user.LastName,
-		MiddleName: user.MiddleName,
-		PictureURL: user.PictureURL,
-		Role:       convertRole(user.Role),
-	}, http.StatusCreated)
+	a.recordAudit(ctx, audit.ActionSetRole, audit.TargetUser, userID, map[string]any{"roleId": req.RoleID})
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// PatchUserRequest defines the fields that can be updated on a User.
-// Fields are pointers so that only non‑nil values are applied to the user record.
-// DepartmentID is only allowed to be set if the user's role is Teacher or Dephead.
-type PatchUserRequest struct {
-	FirstName    *string    `json:"firstName"             example:"Ivan"                                 validate:"required"`
-	LastName     *string    `json:"lastName"              example:"Petrov"                               validate:"required"`
-	MiddleName   *string    `json:"middleName,omitzero"   example:"Sergeevich"`
-	PictureURL   *string    `json:"pictureUrl,omitzero"   example:"/images/users/ivan.jpg"`
-	Suspended    *bool      `json:"suspended,omitzero"    example:"false"                                validate:"required"`
-	DepartmentID *uuid.UUID `json:"departmentId,omitzero" example:"550e8400-e29b-41d4-a716-446655440000"`
-	RoleID       *int32     `json:"roleId,omitzero"       example:"1"                                    validate:"required"`
+type SetDepartmentRequest struct {
+	DepartmentID uuid.UUID `json:"departmentId" example:"550e8400-e29b-41d4-a716-446655440000"`
 }
 
-// PatchUser godoc
-// @Summary Partially update user
-// @Description Applies a partial update to the user identified by {id}. Only non-nil fields in the request are applied.
-// Department can only be set for Teacher or Department-Head roles.
+// SetDepartment godoc
+// @Summary Set user department
+// @Description Assigns the user identified by {id} to a department, or clears the department if departmentId is a nil UUID.
+// @Description Only teachers and department heads may have a department.
 // @Tags users
 // @Accept json
-// @Produce json
 // @Security BearerAuth
 // @Param Authorization header string false "Bearer JWT token"
 // @Param id path string true "User UUID"
-// @Param request body PatchUserRequest true "User fields to update"
-// @Success 200 {object} UserResponse
+// @Param request body SetDepartmentRequest true "New department"
+// @Success 204 "No content"
 // @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
 // @Failure 400 {object} InvalidRequestError "Invalid request format"
-// @Failure 400 {object} InvalidRoleError "Invalid role"
-// @Failure 400 {object} InvalidNameError "Invalid name"
+// @Failure 400 {object} InvalidRoleError "The role of the user cannot have a department"
 // @Failure 401 {object} UnauthorizedError "Unauthorized"
 // @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
 // @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 409 {object} InvalidDepartmentError "Department does not exist"
 // @Failure 500 {object} ServerError "Internal server error"
-// @Router /users/{id} [patch]
-func (a *API) PatchUser(w http.ResponseWriter, r *http.Request) {
+// @Router /users/{id}/department [put]
+func (a *API) SetDepartment(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	rec := event.Get(ctx)
 
 	idStr := r.PathValue("id")
 	userID, err := uuid.FromString(idStr)
 	if err != nil {
-		writeError(ctx, w, InvalidUUIDError{
-			Code:      "INVALID_UUID",
-			Message:   "Invalid user ID format",
-			RuMessage: "Некорректный формат ID пользователя",
-		}.WithStatus(http.StatusBadRequest))
+		writeError(ctx, w, ErrInvalidUUID.WithStatus(http.StatusBadRequest))
 		return
 	}
 
-	var req PatchUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(ctx, w, ErrInvalidRequest.WithStatus(http.StatusBadRequest))
+	var req SetDepartmentRequest
+	if !a.decodeJSON(w, r, &req) {
 		return
 	}
 
-	existing, err := a.sesc.User(ctx, userID)
-	if err != nil {
+	if err := a.sesc.SetDepartment(ctx, userID, req.DepartmentID); err != nil {
 		rec.Add(events.Error, err)
 		writeError(ctx, w, sescError(err))
 		return
 	}
 
-	upd := existing.UpdateOptions()
-	if req.FirstName != nil {
-		upd.FirstName = *req.FirstName
+	a.recordAudit(ctx, audit.ActionSetDepartment, audit.TargetUser, userID, map[string]any{"departmentId": req.DepartmentID})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type GrantPermissionsRequest struct {
+	PermissionIDs []int32 `json:"permissionIds" example:"2,3" validate:"required"`
+}
+
+type RevokePermissionsRequest struct {
+	PermissionIDs []int32 `json:"permissionIds" example:"2,3" validate:"required"`
+}
+
+// GrantPermissions godoc
+// @Summary Grant extra permissions to a user
+// @Description Grants permissions to the user identified by {id} in addition to the ones of their role.
+// @Description Permissions that were already granted are skipped.
+// @Tags users
+// @Accept json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Param request body GrantPermissionsRequest true "Permissions to grant"
+// @Success 204 "No content"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} InvalidPermissionError "Permission does not exist"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users/{id}/permissions [post]
+func (a *API) GrantPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidUUID.WithStatus(http.StatusBadRequest))
+		return
 	}
-	if req.LastName != nil {
-		upd.LastName = *req.LastName
+
+	var req GrantPermissionsRequest
+	if !a.decodeJSON(w, r, &req) {
+		return
 	}
-	if req.MiddleName != nil {
-		upd.MiddleName = *req.MiddleName
+
+	if err := a.sesc.GrantPermissions(ctx, userID, req.PermissionIDs); err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
 	}
-	if req.PictureURL != nil {
-		upd.PictureURL = *req.PictureURL
+
+	a.recordAudit(ctx, audit.ActionGrantPermissions, audit.TargetUser, userID, map[string]any{"permissionIds": req.PermissionIDs})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokePermissions godoc
+// @Summary Revoke extra permissions of a user
+// @Description Revokes permissions granted to the user identified by {id}. Permissions of the user's role cannot be revoked.
+// @Tags users
+// @Accept json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Param request body RevokePermissionsRequest true "Permissions to revoke"
+// @Success 204 "No content"
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 400 {object} InvalidRequestError "Invalid request format"
+// @Failure 400 {object} InvalidPermissionError "Permission does not exist"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Router /users/{id}/permissions [delete]
+func (a *API) RevokePermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidUUID.WithStatus(http.StatusBadRequest))
+		return
 	}
-	if req.Suspended != nil {
-		upd.Suspended = *req.Suspended
+
+	var req RevokePermissionsRequest
+	if !a.decodeJSON(w, r, &req) {
+		return
 	}
-	if req.DepartmentID != nil {
-		newRoleIsBad := (req.RoleID != nil && *req.RoleID != sesc.Teacher.ID && *req.RoleID != sesc.Dephead.ID)
-		noNewRoleAndOldIsBad := (req.RoleID == nil && existing.Role.ID != sesc.Teacher.ID && existing.Role.ID != sesc.Dephead.ID)
-		if newRoleIsBad || noNewRoleAndOldIsBad {
-			writeError(ctx, w, InvalidRoleError{
-				Code:      "INVALID_ROLE",
-				Message:   "Unable to assign department to selected role",
-				RuMessage: "Нельзя указать департамент для выбранной роли",
-			}.WithStatus(http.StatusBadRequest))
-			return
-		}
 
-		upd.DepartmentID = *req.DepartmentID
+	if err := a.sesc.RevokePermissions(ctx, userID, req.PermissionIDs); err != nil {
+		rec.Add(events.Error, err)
+		writeError(ctx, w, sescError(err))
+		return
 	}
-	if req.RoleID != nil {
-		upd.NewRoleID = *req.RoleID
+
+	a.recordAudit(ctx, audit.ActionRevokePermissions, audit.TargetUser, userID, map[string]any{"permissionIds": req.PermissionIDs})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type EmploymentEventResponse struct {
+	ID              uuid.UUID `json:"id"                       example:"0196a1b2-7c3d-7e4f-8a9b-0c1d2e3f4a5b" validate:"required"`
+	ActorID         uuid.UUID `json:"actorId"                  example:"f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd" validate:"required"`
+	OldRoleID       int32     `json:"oldRoleId"                example:"2"                                    validate:"required"`
+	NewRoleID       int32     `json:"newRoleId"                example:"3"                                    validate:"required"`
+	OldDepartmentID uuid.UUID `json:"oldDepartmentId,omitzero" example:"550e8400-e29b-41d4-a716-446655440000"`
+	NewDepartmentID uuid.UUID `json:"newDepartmentId,omitzero" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Timestamp       time.Time `json:"timestamp"                example:"2025-01-01T12:00:00Z"                 validate:"required"`
+}
+
+type EmploymentHistoryResponse struct {
+	Events []EmploymentEventResponse `json:"events" validate:"required"`
+}
+
+// EmploymentHistory godoc
+// @Summary Get employment history of a user
+// @Description Returns the changes of the role and the department of the user identified by {id}, oldest first.
+// @Description A missing department ID means the user had no department. The actor ID is a nil UUID
+// @Description if the change was not made on behalf of anyone.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer JWT token"
+// @Param id path string true "User UUID"
+// @Success 200 {object} EmploymentHistoryResponse
+// @Failure 400 {object} InvalidUUIDError "Invalid UUID format"
+// @Failure 401 {object} UnauthorizedError "Unauthorized"
+// @Failure 403 {object} ForbiddenError "Forbidden - admin role required"
+// @Failure 404 {object} UserNotFoundError "User not found"
+// @Failure 500 {object} ServerError "Internal server error"
+// @Failure 504 {object} TimeoutError "Database request timed out"
+// @Router /users/{id}/history [get]
+func (a *API) EmploymentHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rec := event.Get(ctx)
+
+	idStr := r.PathValue("id")
+	userID, err := uuid.FromString(idStr)
+	if err != nil {
+		writeError(ctx, w, ErrInvalidUUID.WithStatus(http.StatusBadRequest))
+		return
 	}
 
-	updated, err := a.sesc.UpdateUser(ctx, userID, upd)
+	history, err := a.sesc.EmploymentHistory(ctx, userID)
 	if err != nil {
 		rec.Add(events.Error, err)
 		writeError(ctx, w, sescError(err))
 		return
 	}
 
-	a.writeJSON(ctx, w, UserResponse{
-		ID:         updated.ID,
-		FirstName:  updated.FirstName,
-		LastName:   updated.LastName,
-		MiddleName: updated.MiddleName,
-		PictureURL: updated.PictureURL,
-		Role:       convertRole(updated.Role),
-		Department: convertDepartment(updated.Department),
-		Suspended:  updated.Suspended,
-	}, http.StatusOK)
+	response := EmploymentHistoryResponse{
+		Events: make([]EmploymentEventResponse, len(history)),
+	}
+	for i, e := range history {
+		response.Events[i] = EmploymentEventResponse{
+			ID:              e.ID,
+			ActorID:         e.ActorID,
+			OldRoleID:       e.OldRoleID,
+			NewRoleID:       e.NewRoleID,
+			OldDepartmentID: e.OldDepartmentID,
+			NewDepartmentID: e.NewDepartmentID,
+			Timestamp:       e.Timestamp,
+		}
+	}
+
+	a.writeJSON(ctx, w, response, http.StatusOK)
 }
 
 func convertUser(user sesc.User) UserResponse {
@@ -288,9 +1407,25 @@ func convertUser(user sesc.User) UserResponse {
 		LastName:   user.LastName,
 		MiddleName: user.MiddleName,
 		PictureURL: user.PictureURL,
+		Email:      user.Email,
 		Role:       convertRole(user.Role),
 		Department: convertDepartment(user.Department),
 		Suspended:  user.Suspended,
+		Version:    user.Version,
+
+		ExtraPermissions: convertPermissions(user.ExtraPermissions),
+
+		Subdivision:       user.Subdivision,
+		JobTitle:          user.JobTitle,
+		EmploymentRate:    user.EmploymentRate,
+		PersonnelCategory: user.PersonnelCategory,
+		EmploymentType:    user.EmploymentType,
+		AcademicDegree:    user.AcademicDegree,
+		AcademicTitle:     user.AcademicTitle,
+		Honors:            user.Honors,
+		Category:          user.Category,
+		DateOfEmployment:  user.DateOfEmployment,
+		UnemploymentDate:  user.UnemploymentDate,
 	}
 }
 
@@ -320,14 +1455,5 @@ func (a *API) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	user, _ := GetUserFromContext(ctx)
 
 	// Return user data
-	a.writeJSON(ctx, w, UserResponse{
-		ID:         user.ID,
-		FirstName:  user.FirstName,
-		LastName:   user.LastName,
-		MiddleName: user.MiddleName,
-		PictureURL: user.PictureURL,
-		Role:       convertRole(user.Role),
-		Department: convertDepartment(user.Department),
-		Suspended:  user.Suspended,
-	}, http.StatusOK)
+	a.writeJSON(ctx, w, convertUser(user), http.StatusOK)
 }
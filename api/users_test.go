@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/stretchr/testify/require"
+)
+
+// timingOutSESC is a SESC whose user listing always times out.
+type timingOutSESC struct {
+	SESC
+}
+
+func (timingOutSESC) Users(ctx context.Context, _ sesc.UserFilter) ([]sesc.User, error) {
+	return nil, fmt.Errorf("couldn't query users: %w", sesc.WrapTimeout(ctx, context.DeadlineExceeded))
+}
+
+// listingSESC is a SESC that lists the given users.
+type listingSESC struct {
+	SESC
+	users []sesc.User
+}
+
+func (s listingSESC) Users(context.Context, sesc.UserFilter) ([]sesc.User, error) {
+	return s.users, nil
+}
+
+func TestGetUsers(t *testing.T) {
+	users := []sesc.User{
+		{ID: sesc.UUID{1}, FirstName: "John", LastName: "Doe", Role: sesc.Teacher},
+		{ID: sesc.UUID{2}, FirstName: "Jane", LastName: "Doe", Role: sesc.Dephead},
+	}
+	a := New(listingSESC{users: users}, nil, nil)
+
+	ctx := t.Context()
+	ctx, _ = event.NewRecord(ctx, "test")
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/users", nil)
+	rr := httptest.NewRecorder()
+	a.GetUsers(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp UsersResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp.Users, len(users))
+	require.Equal(t, users[0].ID, resp.Users[0].ID)
+	require.Equal(t, "John", resp.Users[0].FirstName)
+	require.Equal(t, users[1].ID, resp.Users[1].ID)
+}
+
+func TestGetUsersTimeout(t *testing.T) {
+	a := New(timingOutSESC{}, nil, nil)
+
+	ctx := t.Context()
+	ctx, _ = event.NewRecord(ctx, "test")
+
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/users", nil)
+	rr := httptest.NewRecorder()
+	a.GetUsers(rr, req)
+
+	require.Equal(t, http.StatusGatewayTimeout, rr.Code)
+	require.Contains(t, rr.Body.String(), `"code":"TIMEOUT"`)
+}
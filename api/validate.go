@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// requestValidator checks the validate tags of the requests, naming the fields by their JSON names.
+var requestValidator = newRequestValidator()
+
+func newRequestValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// validatedRequest is a request with the rules that the validate tags cannot express.
+type validatedRequest interface {
+	// validate returns the invalid fields of the request.
+	validate() []FieldError
+}
+
+// validateRequest returns all the invalid fields of req, a pointer to a request struct:
+// the ones failing their validate tags, followed by the ones reported by its validate method.
+func validateRequest(req any) []FieldError {
+	var fields []FieldError
+
+	var errs validator.ValidationErrors
+	if err := requestValidator.Struct(req); errors.As(err, &errs) {
+		for _, e := range errs {
+			// The namespace starts with the name of the request type.
+			_, field, _ := strings.Cut(e.Namespace(), ".")
+			if e.Tag() == "required" {
+				fields = append(fields, RequiredField(field))
+			} else {
+				fields = append(fields, InvalidField(field))
+			}
+		}
+	}
+
+	if v, ok := req.(validatedRequest); ok {
+		fields = append(fields, v.validate()...)
+	}
+	return fields
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/kozlov-ma/sesc-backend/webhook"
+)
+
+// WithWebhook notifies the given webhook about the user lifecycle events: creation, suspension,
+// reinstatement and archiving.
+func WithWebhook(w Webhook) Option {
+	return func(a *API) {
+		a.webhook = w
+	}
+}
+
+// notify queues a webhook event about a user, if a webhook is set. It never blocks the request.
+func (a *API) notify(ctx context.Context, eventType webhook.EventType, userID uuid.UUID) {
+	if a.webhook == nil {
+		return
+	}
+
+	a.webhook.Notify(ctx, webhook.Event{
+		Type:      eventType,
+		UserID:    userID,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// notifySuspension notifies about the suspension or the reinstatement of a user by an update, if it changed.
+func (a *API) notifySuspension(ctx context.Context, before, after sesc.User) {
+	switch {
+	case !before.Suspended && after.Suspended:
+		a.notify(ctx, webhook.UserSuspended, after.ID)
+	case before.Suspended && !after.Suspended:
+		a.notify(ctx, webhook.UserReinstated, after.ID)
+	}
+}
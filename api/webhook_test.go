@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/kozlov-ma/sesc-backend/webhook"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingWebhook records the events it is notified about.
+type recordingWebhook struct {
+	events []webhook.Event
+}
+
+func (w *recordingWebhook) Notify(_ context.Context, e webhook.Event) {
+	w.events = append(w.events, e)
+}
+
+// replacingSESC is a SESC that replaces a single user.
+type replacingSESC struct {
+	SESC
+	user *sesc.User
+}
+
+func (s replacingSESC) User(_ context.Context, id sesc.UUID) (sesc.User, error) {
+	if id != s.user.ID {
+		return sesc.User{}, sesc.ErrUserNotFound
+	}
+	return *s.user, nil
+}
+
+func (s replacingSESC) UpdateUser(_ context.Context, id sesc.UUID, upd sesc.UserUpdateOptions) (sesc.User, error) {
+	if id != s.user.ID {
+		return sesc.User{}, sesc.ErrUserNotFound
+	}
+	s.user.FirstName, s.user.LastName = upd.FirstName, upd.LastName
+	s.user.Suspended = upd.Suspended
+	s.user.Version++
+	return *s.user, nil
+}
+
+func (s replacingSESC) SetSuspended(_ context.Context, id sesc.UUID, suspended bool) (bool, error) {
+	if id != s.user.ID {
+		return false, sesc.ErrUserNotFound
+	}
+	changed := s.user.Suspended != suspended
+	s.user.Suspended = suspended
+	return changed, nil
+}
+
+func TestReplaceUserWebhook(t *testing.T) {
+	user := &sesc.User{ID: uuid.Must(uuid.NewV7()), FirstName: "Ivan", LastName: "Petrov", Role: sesc.Teacher}
+	hook := &recordingWebhook{}
+	a := New(replacingSESC{user: user}, nil, nil, WithWebhook(hook))
+
+	replace := func(t *testing.T, suspended bool) {
+		ctx := t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+
+		body := `{"firstName":"Ivan","lastName":"Petrov","roleId":1,"suspended":false}`
+		if suspended {
+			body = strings.Replace(body, `"suspended":false`, `"suspended":true`, 1)
+		}
+		req := httptest.NewRequestWithContext(ctx, http.MethodPut, "/users/"+user.ID.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("id", user.ID.String())
+		rr := httptest.NewRecorder()
+		a.ReplaceUser(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	// Stage 1: Suspend the user by replacing them
+	replace(t, true)
+	require.Len(t, hook.events, 1)
+	require.Equal(t, webhook.UserSuspended, hook.events[0].Type)
+	require.Equal(t, user.ID, hook.events[0].UserID)
+
+	// Stage 2: Replacing a suspended user keeping the suspension notifies nothing
+	replace(t, true)
+	require.Len(t, hook.events, 1)
+
+	// Stage 3: Reinstate the user by replacing them
+	replace(t, false)
+	require.Len(t, hook.events, 2)
+	require.Equal(t, webhook.UserReinstated, hook.events[1].Type)
+}
+
+func TestSuspendUserWebhook(t *testing.T) {
+	user := &sesc.User{ID: uuid.Must(uuid.NewV7()), FirstName: "Ivan", LastName: "Petrov", Role: sesc.Teacher}
+	hook := &recordingWebhook{}
+	a := New(replacingSESC{user: user}, nil, nil, WithWebhook(hook))
+
+	serve := func(t *testing.T, handler http.HandlerFunc, action string) {
+		ctx := t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/users/"+user.ID.String()+"/"+action, nil)
+		req.SetPathValue("id", user.ID.String())
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+	}
+
+	// Stage 1: Reinstating an active user notifies nothing
+	serve(t, a.ReinstateUser, "reinstate")
+	require.Empty(t, hook.events)
+
+	// Stage 2: Only the first of the repeated suspensions notifies
+	serve(t, a.SuspendUser, "suspend")
+	serve(t, a.SuspendUser, "suspend")
+	require.Len(t, hook.events, 1)
+	require.Equal(t, webhook.UserSuspended, hook.events[0].Type)
+
+	// Stage 3: Reinstating the suspended user notifies
+	serve(t, a.ReinstateUser, "reinstate")
+	require.Len(t, hook.events, 2)
+	require.Equal(t, webhook.UserReinstated, hook.events[1].Type)
+}
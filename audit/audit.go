@@ -0,0 +1,272 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/auditlog"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+)
+
+var (
+	ErrInvalidEntry  = errors.New("invalid audit entry")
+	ErrInvalidCursor = errors.New("invalid audit cursor")
+)
+
+type UUID = uuid.UUID
+
+// Action names a mutating operation recorded in the audit log.
+type Action string
+
+const (
+	ActionCreateUser        Action = "create_user"
+	ActionUpdateUser        Action = "update_user"
+	ActionArchiveUser       Action = "archive_user"
+	ActionSuspendUser       Action = "suspend_user"
+	ActionReinstateUser     Action = "reinstate_user"
+	ActionSetRole           Action = "set_role"
+	ActionSetDepartment     Action = "set_department"
+	ActionGrantPermissions  Action = "grant_permissions"
+	ActionRevokePermissions Action = "revoke_permissions"
+	ActionCreateDepartment  Action = "create_department"
+	ActionUpdateDepartment  Action = "update_department"
+	ActionDeleteDepartment  Action = "delete_department"
+	ActionTransferUsers     Action = "transfer_users"
+	ActionRegisterUser      Action = "register_credentials"
+	ActionDeleteCredentials Action = "delete_credentials"
+	ActionChangePassword    Action = "change_password"
+	ActionResetPassword     Action = "reset_password"
+)
+
+// TargetType names the kind of entity an audited operation was applied to.
+type TargetType string
+
+const (
+	TargetUser       TargetType = "user"
+	TargetDepartment TargetType = "department"
+)
+
+// Entry is a single record of the audit log.
+type Entry struct {
+	ID         UUID
+	ActorID    UUID
+	Action     Action
+	TargetType TargetType
+	TargetID   UUID
+	Timestamp  time.Time
+	Details    map[string]any
+}
+
+func (e Entry) validate() error {
+	switch {
+	case e.Action == "":
+		return fmt.Errorf("%w: empty action", ErrInvalidEntry)
+	case e.TargetType == "":
+		return fmt.Errorf("%w: empty target type", ErrInvalidEntry)
+	}
+	return nil
+}
+
+// Filter narrows down the entries returned by Entries. The zero values of the fields select all the entries.
+type Filter struct {
+	// TargetID, if not uuid.Nil, selects only the entries about this target.
+	TargetID UUID
+	// ActorID, if not uuid.Nil, selects only the entries of the operations performed by this actor.
+	ActorID UUID
+	// Action, if not empty, selects only the entries of this action.
+	Action Action
+	// From and To, if not zero, select only the entries recorded at From or later, and before To.
+	From time.Time
+	To   time.Time
+	// After, if not uuid.Nil, selects only the entries following the one with this ID, see Entries.
+	After UUID
+	// Limit caps the number of returned entries. A non-positive Limit means DefaultLimit.
+	Limit int
+}
+
+// predicates returns the conditions on the entries selected by f, except for After.
+func (f Filter) predicates() []predicate.AuditLog {
+	var ps []predicate.AuditLog
+	if !f.TargetID.IsNil() {
+		ps = append(ps, auditlog.TargetID(f.TargetID))
+	}
+	if !f.ActorID.IsNil() {
+		ps = append(ps, auditlog.ActorID(f.ActorID))
+	}
+	if f.Action != "" {
+		ps = append(ps, auditlog.Action(string(f.Action)))
+	}
+	if !f.From.IsZero() {
+		ps = append(ps, auditlog.TimestampGTE(f.From))
+	}
+	if !f.To.IsZero() {
+		ps = append(ps, auditlog.TimestampLT(f.To))
+	}
+	return ps
+}
+
+const DefaultLimit = 50
+
+// Audit records mutating operations using Ent for persistence.
+type Audit struct {
+	client *ent.Client
+}
+
+// New creates a new Audit with the given Ent client.
+func New(client *ent.Client) *Audit {
+	return &Audit{client: client}
+}
+
+// Record appends an entry to the audit log of the organization of ctx, see sesc.WithOrg.
+// The ID and Timestamp of the entry are assigned automatically.
+//
+// Returns an ErrInvalidEntry if the action or target type is missing.
+func (a *Audit) Record(ctx context.Context, e Entry) error {
+	rec := event.Get(ctx).Sub("audit/record")
+	statrec := event.Root(ctx).Sub("stats")
+
+	orgID := sesc.OrgFromContext(ctx)
+	rec.Sub("params").Set(
+		"org_id", orgID,
+		"actor_id", e.ActorID,
+		"action", e.Action,
+		"target_type", e.TargetType,
+		"target_id", e.TargetID,
+	)
+
+	if err := e.validate(); err != nil {
+		rec.Set("success", false)
+		return err
+	}
+
+	statrec.Add(events.PostgresQueries, 1)
+	start := time.Now()
+	err := a.client.AuditLog.Create().
+		SetOrgID(orgID).
+		SetActorID(e.ActorID).
+		SetAction(string(e.Action)).
+		SetTargetType(string(e.TargetType)).
+		SetTargetID(e.TargetID).
+		SetDetails(e.Details).
+		Exec(ctx)
+	statrec.Add(events.PostgresTime, time.Since(start))
+
+	if err != nil {
+		err = fmt.Errorf("couldn't write audit entry: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// Entries returns up to f.Limit most recent audit entries of the organization of ctx matching the filter,
+// newest first, and the After of the next page, or uuid.Nil if there are no more entries.
+//
+// Returns an ErrInvalidCursor if the organization has no entry with the ID f.After.
+func (a *Audit) Entries(ctx context.Context, f Filter) ([]Entry, UUID, error) {
+	rec := event.Get(ctx).Sub("audit/entries")
+	statrec := event.Root(ctx).Sub("stats")
+
+	if f.Limit <= 0 {
+		f.Limit = DefaultLimit
+	}
+
+	orgID := sesc.OrgFromContext(ctx)
+	rec.Sub("params").Set(
+		"org_id", orgID,
+		"target_id", f.TargetID,
+		"actor_id", f.ActorID,
+		"action", f.Action,
+		"from", f.From,
+		"to", f.To,
+		"after", f.After,
+		"limit", f.Limit,
+	)
+
+	ps := append(f.predicates(), auditlog.OrgID(orgID))
+	if !f.After.IsNil() {
+		after, err := a.afterEntry(ctx, orgID, f.After)
+		if err != nil {
+			rec.Add(events.Error, err)
+			rec.Set("success", false)
+			return nil, uuid.Nil, err
+		}
+		ps = append(ps, after)
+	}
+
+	statrec.Add(events.PostgresQueries, 1)
+	start := time.Now()
+	// One extra row tells whether there is a next page.
+	rows, err := a.client.AuditLog.Query().
+		Where(ps...).
+		Order(ent.Desc(auditlog.FieldTimestamp), ent.Desc(auditlog.FieldID)).
+		Limit(f.Limit + 1).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(start))
+
+	if err != nil {
+		err = fmt.Errorf("couldn't query audit entries: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, uuid.Nil, err
+	}
+
+	var next UUID
+	if len(rows) > f.Limit {
+		rows = rows[:f.Limit]
+		next = rows[len(rows)-1].ID
+	}
+
+	entries := make([]Entry, len(rows))
+	for i, row := range rows {
+		entries[i] = Entry{
+			ID:         row.ID,
+			ActorID:    row.ActorID,
+			Action:     Action(row.Action),
+			TargetType: TargetType(row.TargetType),
+			TargetID:   row.TargetID,
+			Timestamp:  row.Timestamp,
+			Details:    row.Details,
+		}
+	}
+
+	rec.Set(
+		"success", true,
+		"count", len(entries),
+		"next", next,
+	)
+	return entries, next, nil
+}
+
+// afterEntry returns the condition selecting the entries that follow the one with the given ID
+// in the order of Entries: the older ones, and the ones recorded at the same time with a lower ID.
+func (a *Audit) afterEntry(ctx context.Context, orgID, id UUID) (predicate.AuditLog, error) {
+	statrec := event.Root(ctx).Sub("stats")
+
+	statrec.Add(events.PostgresQueries, 1)
+	start := time.Now()
+	row, err := a.client.AuditLog.Query().Where(auditlog.ID(id), auditlog.OrgID(orgID)).Only(ctx)
+	statrec.Add(events.PostgresTime, time.Since(start))
+
+	switch {
+	case ent.IsNotFound(err):
+		return nil, fmt.Errorf("%w: no entry %s", ErrInvalidCursor, id)
+	case err != nil:
+		return nil, fmt.Errorf("couldn't query audit cursor: %w", err)
+	}
+
+	return auditlog.Or(
+		auditlog.TimestampLT(row.Timestamp),
+		auditlog.And(auditlog.Timestamp(row.Timestamp), auditlog.IDLT(id)),
+	), nil
+}
@@ -0,0 +1,212 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/enttest"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAudit(t *testing.T) (context.Context, *Audit) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	ctx := t.Context()
+	ctx, _ = event.NewRecord(ctx, "test")
+	return ctx, New(client)
+}
+
+func TestRecord(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctx, a := setupAudit(t)
+		actorID := uuid.Must(uuid.NewV7())
+		targetID := uuid.Must(uuid.NewV7())
+
+		err := a.Record(ctx, Entry{
+			ActorID:    actorID,
+			Action:     ActionCreateUser,
+			TargetType: TargetUser,
+			TargetID:   targetID,
+			Details:    map[string]any{"firstName": "Ivan"},
+		})
+		require.NoError(t, err)
+
+		entries, _, err := a.Entries(ctx, Filter{TargetID: targetID})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, actorID, entries[0].ActorID)
+		require.Equal(t, ActionCreateUser, entries[0].Action)
+		require.Equal(t, TargetUser, entries[0].TargetType)
+		require.Equal(t, "Ivan", entries[0].Details["firstName"])
+		require.False(t, entries[0].Timestamp.IsZero())
+	})
+
+	t.Run("missing action", func(t *testing.T) {
+		ctx, a := setupAudit(t)
+		err := a.Record(ctx, Entry{TargetType: TargetUser, TargetID: uuid.Must(uuid.NewV7())})
+		require.ErrorIs(t, err, ErrInvalidEntry)
+	})
+}
+
+func TestEntries(t *testing.T) {
+	ctx, a := setupAudit(t)
+	actorID := uuid.Must(uuid.NewV7())
+	userID := uuid.Must(uuid.NewV7())
+	departmentID := uuid.Must(uuid.NewV7())
+
+	for _, e := range []Entry{
+		{ActorID: actorID, Action: ActionCreateUser, TargetType: TargetUser, TargetID: userID},
+		{ActorID: actorID, Action: ActionUpdateUser, TargetType: TargetUser, TargetID: userID},
+		{ActorID: actorID, Action: ActionCreateDepartment, TargetType: TargetDepartment, TargetID: departmentID},
+	} {
+		require.NoError(t, a.Record(ctx, e))
+	}
+
+	t.Run("by target", func(t *testing.T) {
+		entries, _, err := a.Entries(ctx, Filter{TargetID: userID})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, ActionUpdateUser, entries[0].Action, "Newest entries should come first")
+		require.Equal(t, ActionCreateUser, entries[1].Action)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		entries, _, err := a.Entries(ctx, Filter{})
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		entries, _, err := a.Entries(ctx, Filter{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, ActionCreateDepartment, entries[0].Action)
+	})
+
+	t.Run("organizations", func(t *testing.T) {
+		otherCtx := sesc.WithOrg(ctx, uuid.Must(uuid.NewV7()))
+		require.NoError(t, a.Record(otherCtx, Entry{
+			ActorID:    actorID,
+			Action:     ActionUpdateUser,
+			TargetType: TargetUser,
+			TargetID:   userID,
+		}))
+
+		entries, _, err := a.Entries(otherCtx, Filter{})
+		require.NoError(t, err)
+		require.Len(t, entries, 1, "Only the entries of the organization are listed")
+
+		entries, _, err = a.Entries(ctx, Filter{TargetID: userID})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		_, _, err = a.Entries(otherCtx, Filter{After: entries[0].ID})
+		require.ErrorIs(t, err, ErrInvalidCursor, "Cursors of another organization are unknown")
+	})
+}
+
+func TestEntriesFilter(t *testing.T) {
+	ctx, a := setupAudit(t)
+	alice := uuid.Must(uuid.NewV7())
+	bob := uuid.Must(uuid.NewV7())
+	day := func(d int) time.Time { return time.Date(2025, time.September, d, 12, 0, 0, 0, time.UTC) }
+
+	// Entries of the first days of September, with the timestamps set explicitly
+	for _, e := range []Entry{
+		{ActorID: alice, Action: ActionCreateUser, Timestamp: day(1)},
+		{ActorID: bob, Action: ActionCreateUser, Timestamp: day(2)},
+		{ActorID: alice, Action: ActionUpdateUser, Timestamp: day(3)},
+		{ActorID: alice, Action: ActionArchiveUser, Timestamp: day(4)},
+		{ActorID: bob, Action: ActionUpdateUser, Timestamp: day(5)},
+	} {
+		err := a.client.AuditLog.Create().
+			SetActorID(e.ActorID).
+			SetAction(string(e.Action)).
+			SetTargetType(string(TargetUser)).
+			SetTargetID(uuid.Must(uuid.NewV7())).
+			SetTimestamp(e.Timestamp).
+			Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	actions := func(entries []Entry) []Action {
+		res := make([]Action, len(entries))
+		for i, e := range entries {
+			res[i] = e.Action
+		}
+		return res
+	}
+
+	t.Run("by actor", func(t *testing.T) {
+		entries, next, err := a.Entries(ctx, Filter{ActorID: alice})
+		require.NoError(t, err)
+		require.Equal(t, []Action{ActionArchiveUser, ActionUpdateUser, ActionCreateUser}, actions(entries))
+		require.True(t, next.IsNil())
+	})
+
+	t.Run("by action", func(t *testing.T) {
+		entries, _, err := a.Entries(ctx, Filter{Action: ActionUpdateUser})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, bob, entries[0].ActorID)
+		require.Equal(t, alice, entries[1].ActorID)
+	})
+
+	t.Run("by date range", func(t *testing.T) {
+		// From is inclusive, To is exclusive
+		entries, _, err := a.Entries(ctx, Filter{From: day(2), To: day(4)})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.True(t, day(3).Equal(entries[0].Timestamp))
+		require.True(t, day(2).Equal(entries[1].Timestamp))
+
+		entries, _, err = a.Entries(ctx, Filter{ActorID: alice, From: day(2), To: day(4)})
+		require.NoError(t, err)
+		require.Equal(t, []Action{ActionUpdateUser}, actions(entries))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		var all []Entry
+		var after UUID
+		for range 3 {
+			entries, next, err := a.Entries(ctx, Filter{After: after, Limit: 2})
+			require.NoError(t, err)
+			all = append(all, entries...)
+			if next.IsNil() {
+				break
+			}
+			after = next
+		}
+
+		require.Len(t, all, 5)
+		for i := 1; i < len(all); i++ {
+			require.True(t, all[i].Timestamp.Before(all[i-1].Timestamp), "Newest entries should come first")
+		}
+	})
+
+	t.Run("pages of a filter", func(t *testing.T) {
+		entries, next, err := a.Entries(ctx, Filter{ActorID: bob, Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, []Action{ActionUpdateUser}, actions(entries))
+		require.Equal(t, entries[0].ID, next)
+
+		entries, next, err = a.Entries(ctx, Filter{ActorID: bob, After: next, Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, []Action{ActionCreateUser}, actions(entries))
+		require.True(t, next.IsNil())
+	})
+
+	t.Run("unknown cursor", func(t *testing.T) {
+		_, _, err := a.Entries(ctx, Filter{After: uuid.Must(uuid.NewV7())})
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
@@ -0,0 +1,418 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	uuid "github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/auditlog"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+)
+
+// AuditLogUpdate is the builder for updating AuditLog entities.
+type AuditLogUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuditLogMutation
+}
+
+// Where appends a list predicates to the AuditLogUpdate builder.
+func (alu *AuditLogUpdate) Where(ps ...predicate.AuditLog) *AuditLogUpdate {
+	alu.mutation.Where(ps...)
+	return alu
+}
+
+// SetOrgID sets the "org_id" field.
+func (alu *AuditLogUpdate) SetOrgID(u uuid.UUID) *AuditLogUpdate {
+	alu.mutation.SetOrgID(u)
+	return alu
+}
+
+// SetNillableOrgID sets the "org_id" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableOrgID(u *uuid.UUID) *AuditLogUpdate {
+	if u != nil {
+		alu.SetOrgID(*u)
+	}
+	return alu
+}
+
+// SetActorID sets the "actor_id" field.
+func (alu *AuditLogUpdate) SetActorID(u uuid.UUID) *AuditLogUpdate {
+	alu.mutation.SetActorID(u)
+	return alu
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableActorID(u *uuid.UUID) *AuditLogUpdate {
+	if u != nil {
+		alu.SetActorID(*u)
+	}
+	return alu
+}
+
+// SetAction sets the "action" field.
+func (alu *AuditLogUpdate) SetAction(s string) *AuditLogUpdate {
+	alu.mutation.SetAction(s)
+	return alu
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableAction(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetAction(*s)
+	}
+	return alu
+}
+
+// SetTargetType sets the "target_type" field.
+func (alu *AuditLogUpdate) SetTargetType(s string) *AuditLogUpdate {
+	alu.mutation.SetTargetType(s)
+	return alu
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableTargetType(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetTargetType(*s)
+	}
+	return alu
+}
+
+// SetTargetID sets the "target_id" field.
+func (alu *AuditLogUpdate) SetTargetID(u uuid.UUID) *AuditLogUpdate {
+	alu.mutation.SetTargetID(u)
+	return alu
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableTargetID(u *uuid.UUID) *AuditLogUpdate {
+	if u != nil {
+		alu.SetTargetID(*u)
+	}
+	return alu
+}
+
+// SetDetails sets the "details" field.
+func (alu *AuditLogUpdate) SetDetails(m map[string]interface{}) *AuditLogUpdate {
+	alu.mutation.SetDetails(m)
+	return alu
+}
+
+// ClearDetails clears the value of the "details" field.
+func (alu *AuditLogUpdate) ClearDetails() *AuditLogUpdate {
+	alu.mutation.ClearDetails()
+	return alu
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (alu *AuditLogUpdate) Mutation() *AuditLogMutation {
+	return alu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (alu *AuditLogUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, alu.sqlSave, alu.mutation, alu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (alu *AuditLogUpdate) SaveX(ctx context.Context) int {
+	affected, err := alu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (alu *AuditLogUpdate) Exec(ctx context.Context) error {
+	_, err := alu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (alu *AuditLogUpdate) ExecX(ctx context.Context) {
+	if err := alu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (alu *AuditLogUpdate) check() error {
+	if v, ok := alu.mutation.Action(); ok {
+		if err := auditlog.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditLog.action": %w`, err)}
+		}
+	}
+	if v, ok := alu.mutation.TargetType(); ok {
+		if err := auditlog.TargetTypeValidator(v); err != nil {
+			return &ValidationError{Name: "target_type", err: fmt.Errorf(`ent: validator failed for field "AuditLog.target_type": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (alu *AuditLogUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := alu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(auditlog.Table, auditlog.Columns, sqlgraph.NewFieldSpec(auditlog.FieldID, field.TypeUUID))
+	if ps := alu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := alu.mutation.OrgID(); ok {
+		_spec.SetField(auditlog.FieldOrgID, field.TypeUUID, value)
+	}
+	if value, ok := alu.mutation.ActorID(); ok {
+		_spec.SetField(auditlog.FieldActorID, field.TypeUUID, value)
+	}
+	if value, ok := alu.mutation.Action(); ok {
+		_spec.SetField(auditlog.FieldAction, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.TargetType(); ok {
+		_spec.SetField(auditlog.FieldTargetType, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.TargetID(); ok {
+		_spec.SetField(auditlog.FieldTargetID, field.TypeUUID, value)
+	}
+	if value, ok := alu.mutation.Details(); ok {
+		_spec.SetField(auditlog.FieldDetails, field.TypeJSON, value)
+	}
+	if alu.mutation.DetailsCleared() {
+		_spec.ClearField(auditlog.FieldDetails, field.TypeJSON)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, alu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditlog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	alu.mutation.done = true
+	return n, nil
+}
+
+// AuditLogUpdateOne is the builder for updating a single AuditLog entity.
+type AuditLogUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AuditLogMutation
+}
+
+// SetOrgID sets the "org_id" field.
+func (aluo *AuditLogUpdateOne) SetOrgID(u uuid.UUID) *AuditLogUpdateOne {
+	aluo.mutation.SetOrgID(u)
+	return aluo
+}
+
+// SetNillableOrgID sets the "org_id" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableOrgID(u *uuid.UUID) *AuditLogUpdateOne {
+	if u != nil {
+		aluo.SetOrgID(*u)
+	}
+	return aluo
+}
+
+// SetActorID sets the "actor_id" field.
+func (aluo *AuditLogUpdateOne) SetActorID(u uuid.UUID) *AuditLogUpdateOne {
+	aluo.mutation.SetActorID(u)
+	return aluo
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableActorID(u *uuid.UUID) *AuditLogUpdateOne {
+	if u != nil {
+		aluo.SetActorID(*u)
+	}
+	return aluo
+}
+
+// SetAction sets the "action" field.
+func (aluo *AuditLogUpdateOne) SetAction(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetAction(s)
+	return aluo
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableAction(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetAction(*s)
+	}
+	return aluo
+}
+
+// SetTargetType sets the "target_type" field.
+func (aluo *AuditLogUpdateOne) SetTargetType(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetTargetType(s)
+	return aluo
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableTargetType(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetTargetType(*s)
+	}
+	return aluo
+}
+
+// SetTargetID sets the "target_id" field.
+func (aluo *AuditLogUpdateOne) SetTargetID(u uuid.UUID) *AuditLogUpdateOne {
+	aluo.mutation.SetTargetID(u)
+	return aluo
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableTargetID(u *uuid.UUID) *AuditLogUpdateOne {
+	if u != nil {
+		aluo.SetTargetID(*u)
+	}
+	return aluo
+}
+
+// SetDetails sets the "details" field.
+func (aluo *AuditLogUpdateOne) SetDetails(m map[string]interface{}) *AuditLogUpdateOne {
+	aluo.mutation.SetDetails(m)
+	return aluo
+}
+
+// ClearDetails clears the value of the "details" field.
+func (aluo *AuditLogUpdateOne) ClearDetails() *AuditLogUpdateOne {
+	aluo.mutation.ClearDetails()
+	return aluo
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (aluo *AuditLogUpdateOne) Mutation() *AuditLogMutation {
+	return aluo.mutation
+}
+
+// Where appends a list predicates to the AuditLogUpdate builder.
+func (aluo *AuditLogUpdateOne) Where(ps ...predicate.AuditLog) *AuditLogUpdateOne {
+	aluo.mutation.Where(ps...)
+	return aluo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (aluo *AuditLogUpdateOne) Select(field string, fields ...string) *AuditLogUpdateOne {
+	aluo.fields = append([]string{field}, fields...)
+	return aluo
+}
+
+// Save executes the query and returns the updated AuditLog entity.
+func (aluo *AuditLogUpdateOne) Save(ctx context.Context) (*AuditLog, error) {
+	return withHooks(ctx, aluo.sqlSave, aluo.mutation, aluo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aluo *AuditLogUpdateOne) SaveX(ctx context.Context) *AuditLog {
+	node, err := aluo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (aluo *AuditLogUpdateOne) Exec(ctx context.Context) error {
+	_, err := aluo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aluo *AuditLogUpdateOne) ExecX(ctx context.Context) {
+	if err := aluo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aluo *AuditLogUpdateOne) check() error {
+	if v, ok := aluo.mutation.Action(); ok {
+		if err := auditlog.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditLog.action": %w`, err)}
+		}
+	}
+	if v, ok := aluo.mutation.TargetType(); ok {
+		if err := auditlog.TargetTypeValidator(v); err != nil {
+			return &ValidationError{Name: "target_type", err: fmt.Errorf(`ent: validator failed for field "AuditLog.target_type": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (aluo *AuditLogUpdateOne) sqlSave(ctx context.Context) (_node *AuditLog, err error) {
+	if err := aluo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(auditlog.Table, auditlog.Columns, sqlgraph.NewFieldSpec(auditlog.FieldID, field.TypeUUID))
+	id, ok := aluo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuditLog.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := aluo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, auditlog.FieldID)
+		for _, f := range fields {
+			if !auditlog.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != auditlog.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := aluo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aluo.mutation.OrgID(); ok {
+		_spec.SetField(auditlog.FieldOrgID, field.TypeUUID, value)
+	}
+	if value, ok := aluo.mutation.ActorID(); ok {
+		_spec.SetField(auditlog.FieldActorID, field.TypeUUID, value)
+	}
+	if value, ok := aluo.mutation.Action(); ok {
+		_spec.SetField(auditlog.FieldAction, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.TargetType(); ok {
+		_spec.SetField(auditlog.FieldTargetType, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.TargetID(); ok {
+		_spec.SetField(auditlog.FieldTargetID, field.TypeUUID, value)
+	}
+	if value, ok := aluo.mutation.Details(); ok {
+		_spec.SetField(auditlog.FieldDetails, field.TypeJSON, value)
+	}
+	if aluo.mutation.DetailsCleared() {
+		_spec.ClearField(auditlog.FieldDetails, field.TypeJSON)
+	}
+	_node = &AuditLog{config: aluo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, aluo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditlog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	aluo.mutation.done = true
+	return _node, nil
+}
@@ -16,9 +16,13 @@ import (
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/auditlog"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/authuser"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schemaversion"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/userpermission"
 )
 
 // Client is the client that holds all ent builders.
@@ -26,12 +30,20 @@ type Client struct {
 	config
 	// Schema is the client for creating, migrating and dropping schema.
 	Schema *migrate.Schema
+	// AuditLog is the client for interacting with the AuditLog builders.
+	AuditLog *AuditLogClient
 	// AuthUser is the client for interacting with the AuthUser builders.
 	AuthUser *AuthUserClient
 	// Department is the client for interacting with the Department builders.
 	Department *DepartmentClient
+	// EmploymentEvent is the client for interacting with the EmploymentEvent builders.
+	EmploymentEvent *EmploymentEventClient
+	// SchemaVersion is the client for interacting with the SchemaVersion builders.
+	SchemaVersion *SchemaVersionClient
 	// User is the client for interacting with the User builders.
 	User *UserClient
+	// UserPermission is the client for interacting with the UserPermission builders.
+	UserPermission *UserPermissionClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -43,9 +55,13 @@ func NewClient(opts ...Option) *Client {
 
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
+	c.AuditLog = NewAuditLogClient(c.config)
 	c.AuthUser = NewAuthUserClient(c.config)
 	c.Department = NewDepartmentClient(c.config)
+	c.EmploymentEvent = NewEmploymentEventClient(c.config)
+	c.SchemaVersion = NewSchemaVersionClient(c.config)
 	c.User = NewUserClient(c.config)
+	c.UserPermission = NewUserPermissionClient(c.config)
 }
 
 type (
@@ -136,11 +152,15 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:        ctx,
-		config:     cfg,
-		AuthUser:   NewAuthUserClient(cfg),
-		Department: NewDepartmentClient(cfg),
-		User:       NewUserClient(cfg),
+		ctx:             ctx,
+		config:          cfg,
+		AuditLog:        NewAuditLogClient(cfg),
+		AuthUser:        NewAuthUserClient(cfg),
+		Department:      NewDepartmentClient(cfg),
+		EmploymentEvent: NewEmploymentEventClient(cfg),
+		SchemaVersion:   NewSchemaVersionClient(cfg),
+		User:            NewUserClient(cfg),
+		UserPermission:  NewUserPermissionClient(cfg),
 	}, nil
 }
 
@@ -158,18 +178,22 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:        ctx,
-		config:     cfg,
-		AuthUser:   NewAuthUserClient(cfg),
-		Department: NewDepartmentClient(cfg),
-		User:       NewUserClient(cfg),
+		ctx:             ctx,
+		config:          cfg,
+		AuditLog:        NewAuditLogClient(cfg),
+		AuthUser:        NewAuthUserClient(cfg),
+		Department:      NewDepartmentClient(cfg),
+		EmploymentEvent: NewEmploymentEventClient(cfg),
+		SchemaVersion:   NewSchemaVersionClient(cfg),
+		User:            NewUserClient(cfg),
+		UserPermission:  NewUserPermissionClient(cfg),
 	}, nil
 }
 
 // Debug returns a new debug-client. It's used to get verbose logging on specific operations.
 //
 //	client.Debug().
-//		AuthUser.
+//		AuditLog.
 //		Query().
 //		Count(ctx)
 func (c *Client) Debug() *Client {
@@ -191,33 +215,180 @@ func (c *Client) Close() error {
 // Use adds the mutation hooks to all the entity clients.
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
-	c.AuthUser.Use(hooks...)
-	c.Department.Use(hooks...)
-	c.User.Use(hooks...)
+	for _, n := range []interface{ Use(...Hook) }{
+		c.AuditLog, c.AuthUser, c.Department, c.EmploymentEvent, c.SchemaVersion,
+		c.User, c.UserPermission,
+	} {
+		n.Use(hooks...)
+	}
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
-	c.AuthUser.Intercept(interceptors...)
-	c.Department.Intercept(interceptors...)
-	c.User.Intercept(interceptors...)
+	for _, n := range []interface{ Intercept(...Interceptor) }{
+		c.AuditLog, c.AuthUser, c.Department, c.EmploymentEvent, c.SchemaVersion,
+		c.User, c.UserPermission,
+	} {
+		n.Intercept(interceptors...)
+	}
 }
 
 // Mutate implements the ent.Mutator interface.
 func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
+	case *AuditLogMutation:
+		return c.AuditLog.mutate(ctx, m)
 	case *AuthUserMutation:
 		return c.AuthUser.mutate(ctx, m)
 	case *DepartmentMutation:
 		return c.Department.mutate(ctx, m)
+	case *EmploymentEventMutation:
+		return c.EmploymentEvent.mutate(ctx, m)
+	case *SchemaVersionMutation:
+		return c.SchemaVersion.mutate(ctx, m)
 	case *UserMutation:
 		return c.User.mutate(ctx, m)
+	case *UserPermissionMutation:
+		return c.UserPermission.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
 	}
 }
 
+// AuditLogClient is a client for the AuditLog schema.
+type AuditLogClient struct {
+	config
+}
+
+// NewAuditLogClient returns a client for the AuditLog from the given config.
+func NewAuditLogClient(c config) *AuditLogClient {
+	return &AuditLogClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `auditlog.Hooks(f(g(h())))`.
+func (c *AuditLogClient) Use(hooks ...Hook) {
+	c.hooks.AuditLog = append(c.hooks.AuditLog, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `auditlog.Intercept(f(g(h())))`.
+func (c *AuditLogClient) Intercept(interceptors ...Interceptor) {
+	c.inters.AuditLog = append(c.inters.AuditLog, interceptors...)
+}
+
+// Create returns a builder for creating a AuditLog entity.
+func (c *AuditLogClient) Create() *AuditLogCreate {
+	mutation := newAuditLogMutation(c.config, OpCreate)
+	return &AuditLogCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of AuditLog entities.
+func (c *AuditLogClient) CreateBulk(builders ...*AuditLogCreate) *AuditLogCreateBulk {
+	return &AuditLogCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *AuditLogClient) MapCreateBulk(slice any, setFunc func(*AuditLogCreate, int)) *AuditLogCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &AuditLogCreateBulk{err: fmt.Errorf("calling to AuditLogClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*AuditLogCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &AuditLogCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for AuditLog.
+func (c *AuditLogClient) Update() *AuditLogUpdate {
+	mutation := newAuditLogMutation(c.config, OpUpdate)
+	return &AuditLogUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *AuditLogClient) UpdateOne(al *AuditLog) *AuditLogUpdateOne {
+	mutation := newAuditLogMutation(c.config, OpUpdateOne, withAuditLog(al))
+	return &AuditLogUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *AuditLogClient) UpdateOneID(id uuid.UUID) *AuditLogUpdateOne {
+	mutation := newAuditLogMutation(c.config, OpUpdateOne, withAuditLogID(id))
+	return &AuditLogUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for AuditLog.
+func (c *AuditLogClient) Delete() *AuditLogDelete {
+	mutation := newAuditLogMutation(c.config, OpDelete)
+	return &AuditLogDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *AuditLogClient) DeleteOne(al *AuditLog) *AuditLogDeleteOne {
+	return c.DeleteOneID(al.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *AuditLogClient) DeleteOneID(id uuid.UUID) *AuditLogDeleteOne {
+	builder := c.Delete().Where(auditlog.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &AuditLogDeleteOne{builder}
+}
+
+// Query returns a query builder for AuditLog.
+func (c *AuditLogClient) Query() *AuditLogQuery {
+	return &AuditLogQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeAuditLog},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a AuditLog entity by its id.
+func (c *AuditLogClient) Get(ctx context.Context, id uuid.UUID) (*AuditLog, error) {
+	return c.Query().Where(auditlog.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *AuditLogClient) GetX(ctx context.Context, id uuid.UUID) *AuditLog {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *AuditLogClient) Hooks() []Hook {
+	return c.hooks.AuditLog
+}
+
+// Interceptors returns the client interceptors.
+func (c *AuditLogClient) Interceptors() []Interceptor {
+	return c.inters.AuditLog
+}
+
+func (c *AuditLogClient) mutate(ctx context.Context, m *AuditLogMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&AuditLogCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&AuditLogUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&AuditLogUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&AuditLogDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown AuditLog mutation op: %q", m.Op())
+	}
+}
+
 // AuthUserClient is a client for the AuthUser schema.
 type AuthUserClient struct {
 	config
@@ -516,6 +687,288 @@ func (c *DepartmentClient) mutate(ctx context.Context, m *DepartmentMutation) (V
 	}
 }
 
+// EmploymentEventClient is a client for the EmploymentEvent schema.
+type EmploymentEventClient struct {
+	config
+}
+
+// NewEmploymentEventClient returns a client for the EmploymentEvent from the given config.
+func NewEmploymentEventClient(c config) *EmploymentEventClient {
+	return &EmploymentEventClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `employmentevent.Hooks(f(g(h())))`.
+func (c *EmploymentEventClient) Use(hooks ...Hook) {
+	c.hooks.EmploymentEvent = append(c.hooks.EmploymentEvent, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `employmentevent.Intercept(f(g(h())))`.
+func (c *EmploymentEventClient) Intercept(interceptors ...Interceptor) {
+	c.inters.EmploymentEvent = append(c.inters.EmploymentEvent, interceptors...)
+}
+
+// Create returns a builder for creating a EmploymentEvent entity.
+func (c *EmploymentEventClient) Create() *EmploymentEventCreate {
+	mutation := newEmploymentEventMutation(c.config, OpCreate)
+	return &EmploymentEventCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of EmploymentEvent entities.
+func (c *EmploymentEventClient) CreateBulk(builders ...*EmploymentEventCreate) *EmploymentEventCreateBulk {
+	return &EmploymentEventCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *EmploymentEventClient) MapCreateBulk(slice any, setFunc func(*EmploymentEventCreate, int)) *EmploymentEventCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &EmploymentEventCreateBulk{err: fmt.Errorf("calling to EmploymentEventClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*EmploymentEventCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &EmploymentEventCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for EmploymentEvent.
+func (c *EmploymentEventClient) Update() *EmploymentEventUpdate {
+	mutation := newEmploymentEventMutation(c.config, OpUpdate)
+	return &EmploymentEventUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *EmploymentEventClient) UpdateOne(ee *EmploymentEvent) *EmploymentEventUpdateOne {
+	mutation := newEmploymentEventMutation(c.config, OpUpdateOne, withEmploymentEvent(ee))
+	return &EmploymentEventUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *EmploymentEventClient) UpdateOneID(id uuid.UUID) *EmploymentEventUpdateOne {
+	mutation := newEmploymentEventMutation(c.config, OpUpdateOne, withEmploymentEventID(id))
+	return &EmploymentEventUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for EmploymentEvent.
+func (c *EmploymentEventClient) Delete() *EmploymentEventDelete {
+	mutation := newEmploymentEventMutation(c.config, OpDelete)
+	return &EmploymentEventDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *EmploymentEventClient) DeleteOne(ee *EmploymentEvent) *EmploymentEventDeleteOne {
+	return c.DeleteOneID(ee.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *EmploymentEventClient) DeleteOneID(id uuid.UUID) *EmploymentEventDeleteOne {
+	builder := c.Delete().Where(employmentevent.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &EmploymentEventDeleteOne{builder}
+}
+
+// Query returns a query builder for EmploymentEvent.
+func (c *EmploymentEventClient) Query() *EmploymentEventQuery {
+	return &EmploymentEventQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeEmploymentEvent},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a EmploymentEvent entity by its id.
+func (c *EmploymentEventClient) Get(ctx context.Context, id uuid.UUID) (*EmploymentEvent, error) {
+	return c.Query().Where(employmentevent.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *EmploymentEventClient) GetX(ctx context.Context, id uuid.UUID) *EmploymentEvent {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryUser queries the user edge of a EmploymentEvent.
+func (c *EmploymentEventClient) QueryUser(ee *EmploymentEvent) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := ee.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(employmentevent.Table, employmentevent.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, employmentevent.UserTable, employmentevent.UserColumn),
+		)
+		fromV = sqlgraph.Neighbors(ee.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *EmploymentEventClient) Hooks() []Hook {
+	return c.hooks.EmploymentEvent
+}
+
+// Interceptors returns the client interceptors.
+func (c *EmploymentEventClient) Interceptors() []Interceptor {
+	return c.inters.EmploymentEvent
+}
+
+func (c *EmploymentEventClient) mutate(ctx context.Context, m *EmploymentEventMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&EmploymentEventCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&EmploymentEventUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&EmploymentEventUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&EmploymentEventDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown EmploymentEvent mutation op: %q", m.Op())
+	}
+}
+
+// SchemaVersionClient is a client for the SchemaVersion schema.
+type SchemaVersionClient struct {
+	config
+}
+
+// NewSchemaVersionClient returns a client for the SchemaVersion from the given config.
+func NewSchemaVersionClient(c config) *SchemaVersionClient {
+	return &SchemaVersionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `schemaversion.Hooks(f(g(h())))`.
+func (c *SchemaVersionClient) Use(hooks ...Hook) {
+	c.hooks.SchemaVersion = append(c.hooks.SchemaVersion, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `schemaversion.Intercept(f(g(h())))`.
+func (c *SchemaVersionClient) Intercept(interceptors ...Interceptor) {
+	c.inters.SchemaVersion = append(c.inters.SchemaVersion, interceptors...)
+}
+
+// Create returns a builder for creating a SchemaVersion entity.
+func (c *SchemaVersionClient) Create() *SchemaVersionCreate {
+	mutation := newSchemaVersionMutation(c.config, OpCreate)
+	return &SchemaVersionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of SchemaVersion entities.
+func (c *SchemaVersionClient) CreateBulk(builders ...*SchemaVersionCreate) *SchemaVersionCreateBulk {
+	return &SchemaVersionCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *SchemaVersionClient) MapCreateBulk(slice any, setFunc func(*SchemaVersionCreate, int)) *SchemaVersionCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &SchemaVersionCreateBulk{err: fmt.Errorf("calling to SchemaVersionClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*SchemaVersionCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &SchemaVersionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for SchemaVersion.
+func (c *SchemaVersionClient) Update() *SchemaVersionUpdate {
+	mutation := newSchemaVersionMutation(c.config, OpUpdate)
+	return &SchemaVersionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *SchemaVersionClient) UpdateOne(sv *SchemaVersion) *SchemaVersionUpdateOne {
+	mutation := newSchemaVersionMutation(c.config, OpUpdateOne, withSchemaVersion(sv))
+	return &SchemaVersionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *SchemaVersionClient) UpdateOneID(id int) *SchemaVersionUpdateOne {
+	mutation := newSchemaVersionMutation(c.config, OpUpdateOne, withSchemaVersionID(id))
+	return &SchemaVersionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for SchemaVersion.
+func (c *SchemaVersionClient) Delete() *SchemaVersionDelete {
+	mutation := newSchemaVersionMutation(c.config, OpDelete)
+	return &SchemaVersionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *SchemaVersionClient) DeleteOne(sv *SchemaVersion) *SchemaVersionDeleteOne {
+	return c.DeleteOneID(sv.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *SchemaVersionClient) DeleteOneID(id int) *SchemaVersionDeleteOne {
+	builder := c.Delete().Where(schemaversion.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &SchemaVersionDeleteOne{builder}
+}
+
+// Query returns a query builder for SchemaVersion.
+func (c *SchemaVersionClient) Query() *SchemaVersionQuery {
+	return &SchemaVersionQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeSchemaVersion},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a SchemaVersion entity by its id.
+func (c *SchemaVersionClient) Get(ctx context.Context, id int) (*SchemaVersion, error) {
+	return c.Query().Where(schemaversion.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *SchemaVersionClient) GetX(ctx context.Context, id int) *SchemaVersion {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *SchemaVersionClient) Hooks() []Hook {
+	return c.hooks.SchemaVersion
+}
+
+// Interceptors returns the client interceptors.
+func (c *SchemaVersionClient) Interceptors() []Interceptor {
+	return c.inters.SchemaVersion
+}
+
+func (c *SchemaVersionClient) mutate(ctx context.Context, m *SchemaVersionMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&SchemaVersionCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&SchemaVersionUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&SchemaVersionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&SchemaVersionDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown SchemaVersion mutation op: %q", m.Op())
+	}
+}
+
 // UserClient is a client for the User schema.
 type UserClient struct {
 	config
@@ -656,6 +1109,38 @@ func (c *UserClient) QueryAuth(u *User) *AuthUserQuery {
 	return query
 }
 
+// QueryPermissions queries the permissions edge of a User.
+func (c *UserClient) QueryPermissions(u *User) *UserPermissionQuery {
+	query := (&UserPermissionClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := u.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, id),
+			sqlgraph.To(userpermission.Table, userpermission.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.PermissionsTable, user.PermissionsColumn),
+		)
+		fromV = sqlgraph.Neighbors(u.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryEmploymentEvents queries the employment_events edge of a User.
+func (c *UserClient) QueryEmploymentEvents(u *User) *EmploymentEventQuery {
+	query := (&EmploymentEventClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := u.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(user.Table, user.FieldID, id),
+			sqlgraph.To(employmentevent.Table, employmentevent.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, user.EmploymentEventsTable, user.EmploymentEventsColumn),
+		)
+		fromV = sqlgraph.Neighbors(u.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // Hooks returns the client hooks.
 func (c *UserClient) Hooks() []Hook {
 	return c.hooks.User
@@ -681,12 +1166,163 @@ func (c *UserClient) mutate(ctx context.Context, m *UserMutation) (Value, error)
 	}
 }
 
+// UserPermissionClient is a client for the UserPermission schema.
+type UserPermissionClient struct {
+	config
+}
+
+// NewUserPermissionClient returns a client for the UserPermission from the given config.
+func NewUserPermissionClient(c config) *UserPermissionClient {
+	return &UserPermissionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `userpermission.Hooks(f(g(h())))`.
+func (c *UserPermissionClient) Use(hooks ...Hook) {
+	c.hooks.UserPermission = append(c.hooks.UserPermission, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `userpermission.Intercept(f(g(h())))`.
+func (c *UserPermissionClient) Intercept(interceptors ...Interceptor) {
+	c.inters.UserPermission = append(c.inters.UserPermission, interceptors...)
+}
+
+// Create returns a builder for creating a UserPermission entity.
+func (c *UserPermissionClient) Create() *UserPermissionCreate {
+	mutation := newUserPermissionMutation(c.config, OpCreate)
+	return &UserPermissionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of UserPermission entities.
+func (c *UserPermissionClient) CreateBulk(builders ...*UserPermissionCreate) *UserPermissionCreateBulk {
+	return &UserPermissionCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *UserPermissionClient) MapCreateBulk(slice any, setFunc func(*UserPermissionCreate, int)) *UserPermissionCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &UserPermissionCreateBulk{err: fmt.Errorf("calling to UserPermissionClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*UserPermissionCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &UserPermissionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for UserPermission.
+func (c *UserPermissionClient) Update() *UserPermissionUpdate {
+	mutation := newUserPermissionMutation(c.config, OpUpdate)
+	return &UserPermissionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *UserPermissionClient) UpdateOne(up *UserPermission) *UserPermissionUpdateOne {
+	mutation := newUserPermissionMutation(c.config, OpUpdateOne, withUserPermission(up))
+	return &UserPermissionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *UserPermissionClient) UpdateOneID(id int) *UserPermissionUpdateOne {
+	mutation := newUserPermissionMutation(c.config, OpUpdateOne, withUserPermissionID(id))
+	return &UserPermissionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for UserPermission.
+func (c *UserPermissionClient) Delete() *UserPermissionDelete {
+	mutation := newUserPermissionMutation(c.config, OpDelete)
+	return &UserPermissionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *UserPermissionClient) DeleteOne(up *UserPermission) *UserPermissionDeleteOne {
+	return c.DeleteOneID(up.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *UserPermissionClient) DeleteOneID(id int) *UserPermissionDeleteOne {
+	builder := c.Delete().Where(userpermission.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &UserPermissionDeleteOne{builder}
+}
+
+// Query returns a query builder for UserPermission.
+func (c *UserPermissionClient) Query() *UserPermissionQuery {
+	return &UserPermissionQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeUserPermission},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a UserPermission entity by its id.
+func (c *UserPermissionClient) Get(ctx context.Context, id int) (*UserPermission, error) {
+	return c.Query().Where(userpermission.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *UserPermissionClient) GetX(ctx context.Context, id int) *UserPermission {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryUser queries the user edge of a UserPermission.
+func (c *UserPermissionClient) QueryUser(up *UserPermission) *UserQuery {
+	query := (&UserClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := up.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(userpermission.Table, userpermission.FieldID, id),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, userpermission.UserTable, userpermission.UserColumn),
+		)
+		fromV = sqlgraph.Neighbors(up.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *UserPermissionClient) Hooks() []Hook {
+	return c.hooks.UserPermission
+}
+
+// Interceptors returns the client interceptors.
+func (c *UserPermissionClient) Interceptors() []Interceptor {
+	return c.inters.UserPermission
+}
+
+func (c *UserPermissionClient) mutate(ctx context.Context, m *UserPermissionMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&UserPermissionCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&UserPermissionUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&UserPermissionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&UserPermissionDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown UserPermission mutation op: %q", m.Op())
+	}
+}
+
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		AuthUser, Department, User []ent.Hook
+		AuditLog, AuthUser, Department, EmploymentEvent, SchemaVersion, User,
+		UserPermission []ent.Hook
 	}
 	inters struct {
-		AuthUser, Department, User []ent.Interceptor
+		AuditLog, AuthUser, Department, EmploymentEvent, SchemaVersion, User,
+		UserPermission []ent.Interceptor
 	}
 )
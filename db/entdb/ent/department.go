@@ -17,8 +17,12 @@ type Department struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID uuid.UUID `json:"id,omitempty"`
+	// OrgID holds the value of the "org_id" field.
+	OrgID uuid.UUID `json:"org_id,omitempty"`
 	// Name holds the value of the "name" field.
 	Name string `json:"name,omitempty"`
+	// NormalizedName holds the value of the "normalized_name" field.
+	NormalizedName string `json:"normalized_name,omitempty"`
 	// Description holds the value of the "description" field.
 	Description string `json:"description,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -50,9 +54,9 @@ func (*Department) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case department.FieldName, department.FieldDescription:
+		case department.FieldName, department.FieldNormalizedName, department.FieldDescription:
 			values[i] = new(sql.NullString)
-		case department.FieldID:
+		case department.FieldID, department.FieldOrgID:
 			values[i] = new(uuid.UUID)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -75,12 +79,24 @@ func (d *Department) assignValues(columns []string, values []any) error {
 			} else if value != nil {
 				d.ID = *value
 			}
+		case department.FieldOrgID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field org_id", values[i])
+			} else if value != nil {
+				d.OrgID = *value
+			}
 		case department.FieldName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field name", values[i])
 			} else if value.Valid {
 				d.Name = value.String
 			}
+		case department.FieldNormalizedName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field normalized_name", values[i])
+			} else if value.Valid {
+				d.NormalizedName = value.String
+			}
 		case department.FieldDescription:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field description", values[i])
@@ -128,9 +144,15 @@ func (d *Department) String() string {
 	var builder strings.Builder
 	builder.WriteString("Department(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", d.ID))
+	builder.WriteString("org_id=")
+	builder.WriteString(fmt.Sprintf("%v", d.OrgID))
+	builder.WriteString(", ")
 	builder.WriteString("name=")
 	builder.WriteString(d.Name)
 	builder.WriteString(", ")
+	builder.WriteString("normalized_name=")
+	builder.WriteString(d.NormalizedName)
+	builder.WriteString(", ")
 	builder.WriteString("description=")
 	builder.WriteString(d.Description)
 	builder.WriteByte(')')
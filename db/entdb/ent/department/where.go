@@ -54,16 +54,66 @@ func IDLTE(id uuid.UUID) predicate.Department {
 	return predicate.Department(sql.FieldLTE(FieldID, id))
 }
 
+// OrgID applies equality check predicate on the "org_id" field. It's identical to OrgIDEQ.
+func OrgID(v uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldEQ(FieldOrgID, v))
+}
+
 // Name applies equality check predicate on the "name" field. It's identical to NameEQ.
 func Name(v string) predicate.Department {
 	return predicate.Department(sql.FieldEQ(FieldName, v))
 }
 
+// NormalizedName applies equality check predicate on the "normalized_name" field. It's identical to NormalizedNameEQ.
+func NormalizedName(v string) predicate.Department {
+	return predicate.Department(sql.FieldEQ(FieldNormalizedName, v))
+}
+
 // Description applies equality check predicate on the "description" field. It's identical to DescriptionEQ.
 func Description(v string) predicate.Department {
 	return predicate.Department(sql.FieldEQ(FieldDescription, v))
 }
 
+// OrgIDEQ applies the EQ predicate on the "org_id" field.
+func OrgIDEQ(v uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldEQ(FieldOrgID, v))
+}
+
+// OrgIDNEQ applies the NEQ predicate on the "org_id" field.
+func OrgIDNEQ(v uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldNEQ(FieldOrgID, v))
+}
+
+// OrgIDIn applies the In predicate on the "org_id" field.
+func OrgIDIn(vs ...uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldIn(FieldOrgID, vs...))
+}
+
+// OrgIDNotIn applies the NotIn predicate on the "org_id" field.
+func OrgIDNotIn(vs ...uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldNotIn(FieldOrgID, vs...))
+}
+
+// OrgIDGT applies the GT predicate on the "org_id" field.
+func OrgIDGT(v uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldGT(FieldOrgID, v))
+}
+
+// OrgIDGTE applies the GTE predicate on the "org_id" field.
+func OrgIDGTE(v uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldGTE(FieldOrgID, v))
+}
+
+// OrgIDLT applies the LT predicate on the "org_id" field.
+func OrgIDLT(v uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldLT(FieldOrgID, v))
+}
+
+// OrgIDLTE applies the LTE predicate on the "org_id" field.
+func OrgIDLTE(v uuid.UUID) predicate.Department {
+	return predicate.Department(sql.FieldLTE(FieldOrgID, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Department {
 	return predicate.Department(sql.FieldEQ(FieldName, v))
@@ -129,6 +179,81 @@ func NameContainsFold(v string) predicate.Department {
 	return predicate.Department(sql.FieldContainsFold(FieldName, v))
 }
 
+// NormalizedNameEQ applies the EQ predicate on the "normalized_name" field.
+func NormalizedNameEQ(v string) predicate.Department {
+	return predicate.Department(sql.FieldEQ(FieldNormalizedName, v))
+}
+
+// NormalizedNameNEQ applies the NEQ predicate on the "normalized_name" field.
+func NormalizedNameNEQ(v string) predicate.Department {
+	return predicate.Department(sql.FieldNEQ(FieldNormalizedName, v))
+}
+
+// NormalizedNameIn applies the In predicate on the "normalized_name" field.
+func NormalizedNameIn(vs ...string) predicate.Department {
+	return predicate.Department(sql.FieldIn(FieldNormalizedName, vs...))
+}
+
+// NormalizedNameNotIn applies the NotIn predicate on the "normalized_name" field.
+func NormalizedNameNotIn(vs ...string) predicate.Department {
+	return predicate.Department(sql.FieldNotIn(FieldNormalizedName, vs...))
+}
+
+// NormalizedNameGT applies the GT predicate on the "normalized_name" field.
+func NormalizedNameGT(v string) predicate.Department {
+	return predicate.Department(sql.FieldGT(FieldNormalizedName, v))
+}
+
+// NormalizedNameGTE applies the GTE predicate on the "normalized_name" field.
+func NormalizedNameGTE(v string) predicate.Department {
+	return predicate.Department(sql.FieldGTE(FieldNormalizedName, v))
+}
+
+// NormalizedNameLT applies the LT predicate on the "normalized_name" field.
+func NormalizedNameLT(v string) predicate.Department {
+	return predicate.Department(sql.FieldLT(FieldNormalizedName, v))
+}
+
+// NormalizedNameLTE applies the LTE predicate on the "normalized_name" field.
+func NormalizedNameLTE(v string) predicate.Department {
+	return predicate.Department(sql.FieldLTE(FieldNormalizedName, v))
+}
+
+// NormalizedNameContains applies the Contains predicate on the "normalized_name" field.
+func NormalizedNameContains(v string) predicate.Department {
+	return predicate.Department(sql.FieldContains(FieldNormalizedName, v))
+}
+
+// NormalizedNameHasPrefix applies the HasPrefix predicate on the "normalized_name" field.
+func NormalizedNameHasPrefix(v string) predicate.Department {
+	return predicate.Department(sql.FieldHasPrefix(FieldNormalizedName, v))
+}
+
+// NormalizedNameHasSuffix applies the HasSuffix predicate on the "normalized_name" field.
+func NormalizedNameHasSuffix(v string) predicate.Department {
+	return predicate.Department(sql.FieldHasSuffix(FieldNormalizedName, v))
+}
+
+// NormalizedNameIsNil applies the IsNil predicate on the "normalized_name" field.
+func NormalizedNameIsNil() predicate.Department {
+	return predicate.Department(sql.FieldIsNull(FieldNormalizedName))
+}
+
+// NormalizedNameNotNil applies the NotNil predicate on the "normalized_name" field.
+func NormalizedNameNotNil() predicate.Department {
+	return predicate.Department(sql.FieldNotNull(FieldNormalizedName))
+}
+
+// NormalizedNameEqualFold applies the EqualFold predicate on the "normalized_name" field.
+func NormalizedNameEqualFold(v string) predicate.Department {
+	return predicate.Department(sql.FieldEqualFold(FieldNormalizedName, v))
+}
+
+// NormalizedNameContainsFold applies the ContainsFold predicate on the "normalized_name" field.
+func NormalizedNameContainsFold(v string) predicate.Department {
+	return predicate.Department(sql.FieldContainsFold(FieldNormalizedName, v))
+}
+
 // DescriptionEQ applies the EQ predicate on the "description" field.
 func DescriptionEQ(v string) predicate.Department {
 	return predicate.Department(sql.FieldEQ(FieldDescription, v))
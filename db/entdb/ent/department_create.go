@@ -21,12 +21,40 @@ type DepartmentCreate struct {
 	hooks    []Hook
 }
 
+// SetOrgID sets the "org_id" field.
+func (dc *DepartmentCreate) SetOrgID(u uuid.UUID) *DepartmentCreate {
+	dc.mutation.SetOrgID(u)
+	return dc
+}
+
+// SetNillableOrgID sets the "org_id" field if the given value is not nil.
+func (dc *DepartmentCreate) SetNillableOrgID(u *uuid.UUID) *DepartmentCreate {
+	if u != nil {
+		dc.SetOrgID(*u)
+	}
+	return dc
+}
+
 // SetName sets the "name" field.
 func (dc *DepartmentCreate) SetName(s string) *DepartmentCreate {
 	dc.mutation.SetName(s)
 	return dc
 }
 
+// SetNormalizedName sets the "normalized_name" field.
+func (dc *DepartmentCreate) SetNormalizedName(s string) *DepartmentCreate {
+	dc.mutation.SetNormalizedName(s)
+	return dc
+}
+
+// SetNillableNormalizedName sets the "normalized_name" field if the given value is not nil.
+func (dc *DepartmentCreate) SetNillableNormalizedName(s *string) *DepartmentCreate {
+	if s != nil {
+		dc.SetNormalizedName(*s)
+	}
+	return dc
+}
+
 // SetDescription sets the "description" field.
 func (dc *DepartmentCreate) SetDescription(s string) *DepartmentCreate {
 	dc.mutation.SetDescription(s)
@@ -69,6 +97,7 @@ func (dc *DepartmentCreate) Mutation() *DepartmentMutation {
 
 // Save creates the Department in the database.
 func (dc *DepartmentCreate) Save(ctx context.Context) (*Department, error) {
+	dc.defaults()
 	return withHooks(ctx, dc.sqlSave, dc.mutation, dc.hooks)
 }
 
@@ -94,8 +123,19 @@ func (dc *DepartmentCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (dc *DepartmentCreate) defaults() {
+	if _, ok := dc.mutation.OrgID(); !ok {
+		v := department.DefaultOrgID()
+		dc.mutation.SetOrgID(v)
+	}
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (dc *DepartmentCreate) check() error {
+	if _, ok := dc.mutation.OrgID(); !ok {
+		return &ValidationError{Name: "org_id", err: errors.New(`ent: missing required field "Department.org_id"`)}
+	}
 	if _, ok := dc.mutation.Name(); !ok {
 		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Department.name"`)}
 	}
@@ -139,10 +179,18 @@ func (dc *DepartmentCreate) createSpec() (*Department, *sqlgraph.CreateSpec) {
 		_node.ID = id
 		_spec.ID.Value = &id
 	}
+	if value, ok := dc.mutation.OrgID(); ok {
+		_spec.SetField(department.FieldOrgID, field.TypeUUID, value)
+		_node.OrgID = value
+	}
 	if value, ok := dc.mutation.Name(); ok {
 		_spec.SetField(department.FieldName, field.TypeString, value)
 		_node.Name = value
 	}
+	if value, ok := dc.mutation.NormalizedName(); ok {
+		_spec.SetField(department.FieldNormalizedName, field.TypeString, value)
+		_node.NormalizedName = value
+	}
 	if value, ok := dc.mutation.Description(); ok {
 		_spec.SetField(department.FieldDescription, field.TypeString, value)
 		_node.Description = value
@@ -184,6 +232,7 @@ func (dcb *DepartmentCreateBulk) Save(ctx context.Context) ([]*Department, error
 	for i := range dcb.builders {
 		func(i int, root context.Context) {
 			builder := dcb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*DepartmentMutation)
 				if !ok {
@@ -29,6 +29,20 @@ func (du *DepartmentUpdate) Where(ps ...predicate.Department) *DepartmentUpdate
 	return du
 }
 
+// SetOrgID sets the "org_id" field.
+func (du *DepartmentUpdate) SetOrgID(u uuid.UUID) *DepartmentUpdate {
+	du.mutation.SetOrgID(u)
+	return du
+}
+
+// SetNillableOrgID sets the "org_id" field if the given value is not nil.
+func (du *DepartmentUpdate) SetNillableOrgID(u *uuid.UUID) *DepartmentUpdate {
+	if u != nil {
+		du.SetOrgID(*u)
+	}
+	return du
+}
+
 // SetName sets the "name" field.
 func (du *DepartmentUpdate) SetName(s string) *DepartmentUpdate {
 	du.mutation.SetName(s)
@@ -43,6 +57,26 @@ func (du *DepartmentUpdate) SetNillableName(s *string) *DepartmentUpdate {
 	return du
 }
 
+// SetNormalizedName sets the "normalized_name" field.
+func (du *DepartmentUpdate) SetNormalizedName(s string) *DepartmentUpdate {
+	du.mutation.SetNormalizedName(s)
+	return du
+}
+
+// SetNillableNormalizedName sets the "normalized_name" field if the given value is not nil.
+func (du *DepartmentUpdate) SetNillableNormalizedName(s *string) *DepartmentUpdate {
+	if s != nil {
+		du.SetNormalizedName(*s)
+	}
+	return du
+}
+
+// ClearNormalizedName clears the value of the "normalized_name" field.
+func (du *DepartmentUpdate) ClearNormalizedName() *DepartmentUpdate {
+	du.mutation.ClearNormalizedName()
+	return du
+}
+
 // SetDescription sets the "description" field.
 func (du *DepartmentUpdate) SetDescription(s string) *DepartmentUpdate {
 	du.mutation.SetDescription(s)
@@ -153,9 +187,18 @@ func (du *DepartmentUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := du.mutation.OrgID(); ok {
+		_spec.SetField(department.FieldOrgID, field.TypeUUID, value)
+	}
 	if value, ok := du.mutation.Name(); ok {
 		_spec.SetField(department.FieldName, field.TypeString, value)
 	}
+	if value, ok := du.mutation.NormalizedName(); ok {
+		_spec.SetField(department.FieldNormalizedName, field.TypeString, value)
+	}
+	if du.mutation.NormalizedNameCleared() {
+		_spec.ClearField(department.FieldNormalizedName, field.TypeString)
+	}
 	if value, ok := du.mutation.Description(); ok {
 		_spec.SetField(department.FieldDescription, field.TypeString, value)
 	}
@@ -227,6 +270,20 @@ type DepartmentUpdateOne struct {
 	mutation *DepartmentMutation
 }
 
+// SetOrgID sets the "org_id" field.
+func (duo *DepartmentUpdateOne) SetOrgID(u uuid.UUID) *DepartmentUpdateOne {
+	duo.mutation.SetOrgID(u)
+	return duo
+}
+
+// SetNillableOrgID sets the "org_id" field if the given value is not nil.
+func (duo *DepartmentUpdateOne) SetNillableOrgID(u *uuid.UUID) *DepartmentUpdateOne {
+	if u != nil {
+		duo.SetOrgID(*u)
+	}
+	return duo
+}
+
 // SetName sets the "name" field.
 func (duo *DepartmentUpdateOne) SetName(s string) *DepartmentUpdateOne {
 	duo.mutation.SetName(s)
@@ -241,6 +298,26 @@ func (duo *DepartmentUpdateOne) SetNillableName(s *string) *DepartmentUpdateOne
 	return duo
 }
 
+// SetNormalizedName sets the "normalized_name" field.
+func (duo *DepartmentUpdateOne) SetNormalizedName(s string) *DepartmentUpdateOne {
+	duo.mutation.SetNormalizedName(s)
+	return duo
+}
+
+// SetNillableNormalizedName sets the "normalized_name" field if the given value is not nil.
+func (duo *DepartmentUpdateOne) SetNillableNormalizedName(s *string) *DepartmentUpdateOne {
+	if s != nil {
+		duo.SetNormalizedName(*s)
+	}
+	return duo
+}
+
+// ClearNormalizedName clears the value of the "normalized_name" field.
+func (duo *DepartmentUpdateOne) ClearNormalizedName() *DepartmentUpdateOne {
+	duo.mutation.ClearNormalizedName()
+	return duo
+}
+
 // SetDescription sets the "description" field.
 func (duo *DepartmentUpdateOne) SetDescription(s string) *DepartmentUpdateOne {
 	duo.mutation.SetDescription(s)
@@ -381,9 +458,18 @@ func (duo *DepartmentUpdateOne) sqlSave(ctx context.Context) (_node *Department,
 			}
 		}
 	}
+	if value, ok := duo.mutation.OrgID(); ok {
+		_spec.SetField(department.FieldOrgID, field.TypeUUID, value)
+	}
 	if value, ok := duo.mutation.Name(); ok {
 		_spec.SetField(department.FieldName, field.TypeString, value)
 	}
+	if value, ok := duo.mutation.NormalizedName(); ok {
+		_spec.SetField(department.FieldNormalizedName, field.TypeString, value)
+	}
+	if duo.mutation.NormalizedNameCleared() {
+		_spec.ClearField(department.FieldNormalizedName, field.TypeString)
+	}
 	if value, ok := duo.mutation.Description(); ok {
 		_spec.SetField(department.FieldDescription, field.TypeString, value)
 	}
@@ -0,0 +1,210 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	uuid "github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+)
+
+// EmploymentEvent is the model entity for the EmploymentEvent schema.
+type EmploymentEvent struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID uuid.UUID `json:"user_id,omitempty"`
+	// ActorID holds the value of the "actor_id" field.
+	ActorID uuid.UUID `json:"actor_id,omitempty"`
+	// OldRoleID holds the value of the "old_role_id" field.
+	OldRoleID int32 `json:"old_role_id,omitempty"`
+	// NewRoleID holds the value of the "new_role_id" field.
+	NewRoleID int32 `json:"new_role_id,omitempty"`
+	// OldDepartmentID holds the value of the "old_department_id" field.
+	OldDepartmentID *uuid.UUID `json:"old_department_id,omitempty"`
+	// NewDepartmentID holds the value of the "new_department_id" field.
+	NewDepartmentID *uuid.UUID `json:"new_department_id,omitempty"`
+	// Timestamp holds the value of the "timestamp" field.
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the EmploymentEventQuery when eager-loading is set.
+	Edges        EmploymentEventEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// EmploymentEventEdges holds the relations/edges for other nodes in the graph.
+type EmploymentEventEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e EmploymentEventEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*EmploymentEvent) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case employmentevent.FieldOldDepartmentID, employmentevent.FieldNewDepartmentID:
+			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
+		case employmentevent.FieldOldRoleID, employmentevent.FieldNewRoleID:
+			values[i] = new(sql.NullInt64)
+		case employmentevent.FieldTimestamp:
+			values[i] = new(sql.NullTime)
+		case employmentevent.FieldID, employmentevent.FieldUserID, employmentevent.FieldActorID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the EmploymentEvent fields.
+func (ee *EmploymentEvent) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case employmentevent.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				ee.ID = *value
+			}
+		case employmentevent.FieldUserID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value != nil {
+				ee.UserID = *value
+			}
+		case employmentevent.FieldActorID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field actor_id", values[i])
+			} else if value != nil {
+				ee.ActorID = *value
+			}
+		case employmentevent.FieldOldRoleID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field old_role_id", values[i])
+			} else if value.Valid {
+				ee.OldRoleID = int32(value.Int64)
+			}
+		case employmentevent.FieldNewRoleID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field new_role_id", values[i])
+			} else if value.Valid {
+				ee.NewRoleID = int32(value.Int64)
+			}
+		case employmentevent.FieldOldDepartmentID:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field old_department_id", values[i])
+			} else if value.Valid {
+				ee.OldDepartmentID = new(uuid.UUID)
+				*ee.OldDepartmentID = *value.S.(*uuid.UUID)
+			}
+		case employmentevent.FieldNewDepartmentID:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field new_department_id", values[i])
+			} else if value.Valid {
+				ee.NewDepartmentID = new(uuid.UUID)
+				*ee.NewDepartmentID = *value.S.(*uuid.UUID)
+			}
+		case employmentevent.FieldTimestamp:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field timestamp", values[i])
+			} else if value.Valid {
+				ee.Timestamp = value.Time
+			}
+		default:
+			ee.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the EmploymentEvent.
+// This includes values selected through modifiers, order, etc.
+func (ee *EmploymentEvent) Value(name string) (ent.Value, error) {
+	return ee.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the EmploymentEvent entity.
+func (ee *EmploymentEvent) QueryUser() *UserQuery {
+	return NewEmploymentEventClient(ee.config).QueryUser(ee)
+}
+
+// Update returns a builder for updating this EmploymentEvent.
+// Note that you need to call EmploymentEvent.Unwrap() before calling this method if this EmploymentEvent
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ee *EmploymentEvent) Update() *EmploymentEventUpdateOne {
+	return NewEmploymentEventClient(ee.config).UpdateOne(ee)
+}
+
+// Unwrap unwraps the EmploymentEvent entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ee *EmploymentEvent) Unwrap() *EmploymentEvent {
+	_tx, ok := ee.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: EmploymentEvent is not a transactional entity")
+	}
+	ee.config.driver = _tx.drv
+	return ee
+}
+
+// String implements the fmt.Stringer.
+func (ee *EmploymentEvent) String() string {
+	var builder strings.Builder
+	builder.WriteString("EmploymentEvent(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ee.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", ee.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("actor_id=")
+	builder.WriteString(fmt.Sprintf("%v", ee.ActorID))
+	builder.WriteString(", ")
+	builder.WriteString("old_role_id=")
+	builder.WriteString(fmt.Sprintf("%v", ee.OldRoleID))
+	builder.WriteString(", ")
+	builder.WriteString("new_role_id=")
+	builder.WriteString(fmt.Sprintf("%v", ee.NewRoleID))
+	builder.WriteString(", ")
+	if v := ee.OldDepartmentID; v != nil {
+		builder.WriteString("old_department_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := ee.NewDepartmentID; v != nil {
+		builder.WriteString("new_department_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("timestamp=")
+	builder.WriteString(ee.Timestamp.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// EmploymentEvents is a parsable slice of EmploymentEvent.
+type EmploymentEvents []*EmploymentEvent
@@ -0,0 +1,129 @@
+// Code generated by ent, DO NOT EDIT.
+
+package employmentevent
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	uuid "github.com/gofrs/uuid/v5"
+)
+
+const (
+	// Label holds the string label denoting the employmentevent type in the database.
+	Label = "employment_event"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldActorID holds the string denoting the actor_id field in the database.
+	FieldActorID = "actor_id"
+	// FieldOldRoleID holds the string denoting the old_role_id field in the database.
+	FieldOldRoleID = "old_role_id"
+	// FieldNewRoleID holds the string denoting the new_role_id field in the database.
+	FieldNewRoleID = "new_role_id"
+	// FieldOldDepartmentID holds the string denoting the old_department_id field in the database.
+	FieldOldDepartmentID = "old_department_id"
+	// FieldNewDepartmentID holds the string denoting the new_department_id field in the database.
+	FieldNewDepartmentID = "new_department_id"
+	// FieldTimestamp holds the string denoting the timestamp field in the database.
+	FieldTimestamp = "timestamp"
+	// EdgeUser holds the string denoting the user edge name in mutations.
+	EdgeUser = "user"
+	// Table holds the table name of the employmentevent in the database.
+	Table = "employment_events"
+	// UserTable is the table that holds the user relation/edge.
+	UserTable = "employment_events"
+	// UserInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	UserInverseTable = "users"
+	// UserColumn is the table column denoting the user relation/edge.
+	UserColumn = "user_id"
+)
+
+// Columns holds all SQL columns for employmentevent fields.
+var Columns = []string{
+	FieldID,
+	FieldUserID,
+	FieldActorID,
+	FieldOldRoleID,
+	FieldNewRoleID,
+	FieldOldDepartmentID,
+	FieldNewDepartmentID,
+	FieldTimestamp,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultTimestamp holds the default value on creation for the "timestamp" field.
+	DefaultTimestamp func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the EmploymentEvent queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByUserID orders the results by the user_id field.
+func ByUserID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserID, opts...).ToFunc()
+}
+
+// ByActorID orders the results by the actor_id field.
+func ByActorID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldActorID, opts...).ToFunc()
+}
+
+// ByOldRoleID orders the results by the old_role_id field.
+func ByOldRoleID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOldRoleID, opts...).ToFunc()
+}
+
+// ByNewRoleID orders the results by the new_role_id field.
+func ByNewRoleID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNewRoleID, opts...).ToFunc()
+}
+
+// ByOldDepartmentID orders the results by the old_department_id field.
+func ByOldDepartmentID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOldDepartmentID, opts...).ToFunc()
+}
+
+// ByNewDepartmentID orders the results by the new_department_id field.
+func ByNewDepartmentID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNewDepartmentID, opts...).ToFunc()
+}
+
+// ByTimestamp orders the results by the timestamp field.
+func ByTimestamp(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTimestamp, opts...).ToFunc()
+}
+
+// ByUserField orders the results by user field.
+func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newUserStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newUserStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(UserInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
+	)
+}
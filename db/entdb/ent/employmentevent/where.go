@@ -0,0 +1,410 @@
+// Code generated by ent, DO NOT EDIT.
+
+package employmentevent
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	uuid "github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLTE(FieldID, id))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldUserID, v))
+}
+
+// ActorID applies equality check predicate on the "actor_id" field. It's identical to ActorIDEQ.
+func ActorID(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldActorID, v))
+}
+
+// OldRoleID applies equality check predicate on the "old_role_id" field. It's identical to OldRoleIDEQ.
+func OldRoleID(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldOldRoleID, v))
+}
+
+// NewRoleID applies equality check predicate on the "new_role_id" field. It's identical to NewRoleIDEQ.
+func NewRoleID(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldNewRoleID, v))
+}
+
+// OldDepartmentID applies equality check predicate on the "old_department_id" field. It's identical to OldDepartmentIDEQ.
+func OldDepartmentID(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldOldDepartmentID, v))
+}
+
+// NewDepartmentID applies equality check predicate on the "new_department_id" field. It's identical to NewDepartmentIDEQ.
+func NewDepartmentID(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldNewDepartmentID, v))
+}
+
+// Timestamp applies equality check predicate on the "timestamp" field. It's identical to TimestampEQ.
+func Timestamp(v time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldTimestamp, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// ActorIDEQ applies the EQ predicate on the "actor_id" field.
+func ActorIDEQ(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldActorID, v))
+}
+
+// ActorIDNEQ applies the NEQ predicate on the "actor_id" field.
+func ActorIDNEQ(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNEQ(FieldActorID, v))
+}
+
+// ActorIDIn applies the In predicate on the "actor_id" field.
+func ActorIDIn(vs ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIn(FieldActorID, vs...))
+}
+
+// ActorIDNotIn applies the NotIn predicate on the "actor_id" field.
+func ActorIDNotIn(vs ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotIn(FieldActorID, vs...))
+}
+
+// ActorIDGT applies the GT predicate on the "actor_id" field.
+func ActorIDGT(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGT(FieldActorID, v))
+}
+
+// ActorIDGTE applies the GTE predicate on the "actor_id" field.
+func ActorIDGTE(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGTE(FieldActorID, v))
+}
+
+// ActorIDLT applies the LT predicate on the "actor_id" field.
+func ActorIDLT(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLT(FieldActorID, v))
+}
+
+// ActorIDLTE applies the LTE predicate on the "actor_id" field.
+func ActorIDLTE(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLTE(FieldActorID, v))
+}
+
+// OldRoleIDEQ applies the EQ predicate on the "old_role_id" field.
+func OldRoleIDEQ(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldOldRoleID, v))
+}
+
+// OldRoleIDNEQ applies the NEQ predicate on the "old_role_id" field.
+func OldRoleIDNEQ(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNEQ(FieldOldRoleID, v))
+}
+
+// OldRoleIDIn applies the In predicate on the "old_role_id" field.
+func OldRoleIDIn(vs ...int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIn(FieldOldRoleID, vs...))
+}
+
+// OldRoleIDNotIn applies the NotIn predicate on the "old_role_id" field.
+func OldRoleIDNotIn(vs ...int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotIn(FieldOldRoleID, vs...))
+}
+
+// OldRoleIDGT applies the GT predicate on the "old_role_id" field.
+func OldRoleIDGT(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGT(FieldOldRoleID, v))
+}
+
+// OldRoleIDGTE applies the GTE predicate on the "old_role_id" field.
+func OldRoleIDGTE(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGTE(FieldOldRoleID, v))
+}
+
+// OldRoleIDLT applies the LT predicate on the "old_role_id" field.
+func OldRoleIDLT(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLT(FieldOldRoleID, v))
+}
+
+// OldRoleIDLTE applies the LTE predicate on the "old_role_id" field.
+func OldRoleIDLTE(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLTE(FieldOldRoleID, v))
+}
+
+// NewRoleIDEQ applies the EQ predicate on the "new_role_id" field.
+func NewRoleIDEQ(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldNewRoleID, v))
+}
+
+// NewRoleIDNEQ applies the NEQ predicate on the "new_role_id" field.
+func NewRoleIDNEQ(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNEQ(FieldNewRoleID, v))
+}
+
+// NewRoleIDIn applies the In predicate on the "new_role_id" field.
+func NewRoleIDIn(vs ...int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIn(FieldNewRoleID, vs...))
+}
+
+// NewRoleIDNotIn applies the NotIn predicate on the "new_role_id" field.
+func NewRoleIDNotIn(vs ...int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotIn(FieldNewRoleID, vs...))
+}
+
+// NewRoleIDGT applies the GT predicate on the "new_role_id" field.
+func NewRoleIDGT(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGT(FieldNewRoleID, v))
+}
+
+// NewRoleIDGTE applies the GTE predicate on the "new_role_id" field.
+func NewRoleIDGTE(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGTE(FieldNewRoleID, v))
+}
+
+// NewRoleIDLT applies the LT predicate on the "new_role_id" field.
+func NewRoleIDLT(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLT(FieldNewRoleID, v))
+}
+
+// NewRoleIDLTE applies the LTE predicate on the "new_role_id" field.
+func NewRoleIDLTE(v int32) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLTE(FieldNewRoleID, v))
+}
+
+// OldDepartmentIDEQ applies the EQ predicate on the "old_department_id" field.
+func OldDepartmentIDEQ(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldOldDepartmentID, v))
+}
+
+// OldDepartmentIDNEQ applies the NEQ predicate on the "old_department_id" field.
+func OldDepartmentIDNEQ(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNEQ(FieldOldDepartmentID, v))
+}
+
+// OldDepartmentIDIn applies the In predicate on the "old_department_id" field.
+func OldDepartmentIDIn(vs ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIn(FieldOldDepartmentID, vs...))
+}
+
+// OldDepartmentIDNotIn applies the NotIn predicate on the "old_department_id" field.
+func OldDepartmentIDNotIn(vs ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotIn(FieldOldDepartmentID, vs...))
+}
+
+// OldDepartmentIDGT applies the GT predicate on the "old_department_id" field.
+func OldDepartmentIDGT(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGT(FieldOldDepartmentID, v))
+}
+
+// OldDepartmentIDGTE applies the GTE predicate on the "old_department_id" field.
+func OldDepartmentIDGTE(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGTE(FieldOldDepartmentID, v))
+}
+
+// OldDepartmentIDLT applies the LT predicate on the "old_department_id" field.
+func OldDepartmentIDLT(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLT(FieldOldDepartmentID, v))
+}
+
+// OldDepartmentIDLTE applies the LTE predicate on the "old_department_id" field.
+func OldDepartmentIDLTE(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLTE(FieldOldDepartmentID, v))
+}
+
+// OldDepartmentIDIsNil applies the IsNil predicate on the "old_department_id" field.
+func OldDepartmentIDIsNil() predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIsNull(FieldOldDepartmentID))
+}
+
+// OldDepartmentIDNotNil applies the NotNil predicate on the "old_department_id" field.
+func OldDepartmentIDNotNil() predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotNull(FieldOldDepartmentID))
+}
+
+// NewDepartmentIDEQ applies the EQ predicate on the "new_department_id" field.
+func NewDepartmentIDEQ(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldNewDepartmentID, v))
+}
+
+// NewDepartmentIDNEQ applies the NEQ predicate on the "new_department_id" field.
+func NewDepartmentIDNEQ(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNEQ(FieldNewDepartmentID, v))
+}
+
+// NewDepartmentIDIn applies the In predicate on the "new_department_id" field.
+func NewDepartmentIDIn(vs ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIn(FieldNewDepartmentID, vs...))
+}
+
+// NewDepartmentIDNotIn applies the NotIn predicate on the "new_department_id" field.
+func NewDepartmentIDNotIn(vs ...uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotIn(FieldNewDepartmentID, vs...))
+}
+
+// NewDepartmentIDGT applies the GT predicate on the "new_department_id" field.
+func NewDepartmentIDGT(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGT(FieldNewDepartmentID, v))
+}
+
+// NewDepartmentIDGTE applies the GTE predicate on the "new_department_id" field.
+func NewDepartmentIDGTE(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGTE(FieldNewDepartmentID, v))
+}
+
+// NewDepartmentIDLT applies the LT predicate on the "new_department_id" field.
+func NewDepartmentIDLT(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLT(FieldNewDepartmentID, v))
+}
+
+// NewDepartmentIDLTE applies the LTE predicate on the "new_department_id" field.
+func NewDepartmentIDLTE(v uuid.UUID) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLTE(FieldNewDepartmentID, v))
+}
+
+// NewDepartmentIDIsNil applies the IsNil predicate on the "new_department_id" field.
+func NewDepartmentIDIsNil() predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIsNull(FieldNewDepartmentID))
+}
+
+// NewDepartmentIDNotNil applies the NotNil predicate on the "new_department_id" field.
+func NewDepartmentIDNotNil() predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotNull(FieldNewDepartmentID))
+}
+
+// TimestampEQ applies the EQ predicate on the "timestamp" field.
+func TimestampEQ(v time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldEQ(FieldTimestamp, v))
+}
+
+// TimestampNEQ applies the NEQ predicate on the "timestamp" field.
+func TimestampNEQ(v time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNEQ(FieldTimestamp, v))
+}
+
+// TimestampIn applies the In predicate on the "timestamp" field.
+func TimestampIn(vs ...time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldIn(FieldTimestamp, vs...))
+}
+
+// TimestampNotIn applies the NotIn predicate on the "timestamp" field.
+func TimestampNotIn(vs ...time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldNotIn(FieldTimestamp, vs...))
+}
+
+// TimestampGT applies the GT predicate on the "timestamp" field.
+func TimestampGT(v time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGT(FieldTimestamp, v))
+}
+
+// TimestampGTE applies the GTE predicate on the "timestamp" field.
+func TimestampGTE(v time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldGTE(FieldTimestamp, v))
+}
+
+// TimestampLT applies the LT predicate on the "timestamp" field.
+func TimestampLT(v time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLT(FieldTimestamp, v))
+}
+
+// TimestampLTE applies the LTE predicate on the "timestamp" field.
+func TimestampLTE(v time.Time) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.FieldLTE(FieldTimestamp, v))
+}
+
+// HasUser applies the HasEdge predicate on the "user" edge.
+func HasUser() predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, UserTable, UserColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasUserWith applies the HasEdge predicate on the "user" edge with a given conditions (other predicates).
+func HasUserWith(preds ...predicate.User) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(func(s *sql.Selector) {
+		step := newUserStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.EmploymentEvent) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.EmploymentEvent) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.EmploymentEvent) predicate.EmploymentEvent {
+	return predicate.EmploymentEvent(sql.NotPredicates(p))
+}
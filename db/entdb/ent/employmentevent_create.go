@@ -0,0 +1,336 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	uuid "github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+)
+
+// EmploymentEventCreate is the builder for creating a EmploymentEvent entity.
+type EmploymentEventCreate struct {
+	config
+	mutation *EmploymentEventMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (eec *EmploymentEventCreate) SetUserID(u uuid.UUID) *EmploymentEventCreate {
+	eec.mutation.SetUserID(u)
+	return eec
+}
+
+// SetActorID sets the "actor_id" field.
+func (eec *EmploymentEventCreate) SetActorID(u uuid.UUID) *EmploymentEventCreate {
+	eec.mutation.SetActorID(u)
+	return eec
+}
+
+// SetOldRoleID sets the "old_role_id" field.
+func (eec *EmploymentEventCreate) SetOldRoleID(i int32) *EmploymentEventCreate {
+	eec.mutation.SetOldRoleID(i)
+	return eec
+}
+
+// SetNewRoleID sets the "new_role_id" field.
+func (eec *EmploymentEventCreate) SetNewRoleID(i int32) *EmploymentEventCreate {
+	eec.mutation.SetNewRoleID(i)
+	return eec
+}
+
+// SetOldDepartmentID sets the "old_department_id" field.
+func (eec *EmploymentEventCreate) SetOldDepartmentID(u uuid.UUID) *EmploymentEventCreate {
+	eec.mutation.SetOldDepartmentID(u)
+	return eec
+}
+
+// SetNillableOldDepartmentID sets the "old_department_id" field if the given value is not nil.
+func (eec *EmploymentEventCreate) SetNillableOldDepartmentID(u *uuid.UUID) *EmploymentEventCreate {
+	if u != nil {
+		eec.SetOldDepartmentID(*u)
+	}
+	return eec
+}
+
+// SetNewDepartmentID sets the "new_department_id" field.
+func (eec *EmploymentEventCreate) SetNewDepartmentID(u uuid.UUID) *EmploymentEventCreate {
+	eec.mutation.SetNewDepartmentID(u)
+	return eec
+}
+
+// SetNillableNewDepartmentID sets the "new_department_id" field if the given value is not nil.
+func (eec *EmploymentEventCreate) SetNillableNewDepartmentID(u *uuid.UUID) *EmploymentEventCreate {
+	if u != nil {
+		eec.SetNewDepartmentID(*u)
+	}
+	return eec
+}
+
+// SetTimestamp sets the "timestamp" field.
+func (eec *EmploymentEventCreate) SetTimestamp(t time.Time) *EmploymentEventCreate {
+	eec.mutation.SetTimestamp(t)
+	return eec
+}
+
+// SetNillableTimestamp sets the "timestamp" field if the given value is not nil.
+func (eec *EmploymentEventCreate) SetNillableTimestamp(t *time.Time) *EmploymentEventCreate {
+	if t != nil {
+		eec.SetTimestamp(*t)
+	}
+	return eec
+}
+
+// SetID sets the "id" field.
+func (eec *EmploymentEventCreate) SetID(u uuid.UUID) *EmploymentEventCreate {
+	eec.mutation.SetID(u)
+	return eec
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (eec *EmploymentEventCreate) SetNillableID(u *uuid.UUID) *EmploymentEventCreate {
+	if u != nil {
+		eec.SetID(*u)
+	}
+	return eec
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (eec *EmploymentEventCreate) SetUser(u *User) *EmploymentEventCreate {
+	return eec.SetUserID(u.ID)
+}
+
+// Mutation returns the EmploymentEventMutation object of the builder.
+func (eec *EmploymentEventCreate) Mutation() *EmploymentEventMutation {
+	return eec.mutation
+}
+
+// Save creates the EmploymentEvent in the database.
+func (eec *EmploymentEventCreate) Save(ctx context.Context) (*EmploymentEvent, error) {
+	eec.defaults()
+	return withHooks(ctx, eec.sqlSave, eec.mutation, eec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (eec *EmploymentEventCreate) SaveX(ctx context.Context) *EmploymentEvent {
+	v, err := eec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (eec *EmploymentEventCreate) Exec(ctx context.Context) error {
+	_, err := eec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eec *EmploymentEventCreate) ExecX(ctx context.Context) {
+	if err := eec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (eec *EmploymentEventCreate) defaults() {
+	if _, ok := eec.mutation.Timestamp(); !ok {
+		v := employmentevent.DefaultTimestamp()
+		eec.mutation.SetTimestamp(v)
+	}
+	if _, ok := eec.mutation.ID(); !ok {
+		v := employmentevent.DefaultID()
+		eec.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (eec *EmploymentEventCreate) check() error {
+	if _, ok := eec.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "EmploymentEvent.user_id"`)}
+	}
+	if _, ok := eec.mutation.ActorID(); !ok {
+		return &ValidationError{Name: "actor_id", err: errors.New(`ent: missing required field "EmploymentEvent.actor_id"`)}
+	}
+	if _, ok := eec.mutation.OldRoleID(); !ok {
+		return &ValidationError{Name: "old_role_id", err: errors.New(`ent: missing required field "EmploymentEvent.old_role_id"`)}
+	}
+	if _, ok := eec.mutation.NewRoleID(); !ok {
+		return &ValidationError{Name: "new_role_id", err: errors.New(`ent: missing required field "EmploymentEvent.new_role_id"`)}
+	}
+	if _, ok := eec.mutation.Timestamp(); !ok {
+		return &ValidationError{Name: "timestamp", err: errors.New(`ent: missing required field "EmploymentEvent.timestamp"`)}
+	}
+	if len(eec.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "EmploymentEvent.user"`)}
+	}
+	return nil
+}
+
+func (eec *EmploymentEventCreate) sqlSave(ctx context.Context) (*EmploymentEvent, error) {
+	if err := eec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := eec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, eec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	eec.mutation.id = &_node.ID
+	eec.mutation.done = true
+	return _node, nil
+}
+
+func (eec *EmploymentEventCreate) createSpec() (*EmploymentEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &EmploymentEvent{config: eec.config}
+		_spec = sqlgraph.NewCreateSpec(employmentevent.Table, sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID))
+	)
+	if id, ok := eec.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := eec.mutation.ActorID(); ok {
+		_spec.SetField(employmentevent.FieldActorID, field.TypeUUID, value)
+		_node.ActorID = value
+	}
+	if value, ok := eec.mutation.OldRoleID(); ok {
+		_spec.SetField(employmentevent.FieldOldRoleID, field.TypeInt32, value)
+		_node.OldRoleID = value
+	}
+	if value, ok := eec.mutation.NewRoleID(); ok {
+		_spec.SetField(employmentevent.FieldNewRoleID, field.TypeInt32, value)
+		_node.NewRoleID = value
+	}
+	if value, ok := eec.mutation.OldDepartmentID(); ok {
+		_spec.SetField(employmentevent.FieldOldDepartmentID, field.TypeUUID, value)
+		_node.OldDepartmentID = &value
+	}
+	if value, ok := eec.mutation.NewDepartmentID(); ok {
+		_spec.SetField(employmentevent.FieldNewDepartmentID, field.TypeUUID, value)
+		_node.NewDepartmentID = &value
+	}
+	if value, ok := eec.mutation.Timestamp(); ok {
+		_spec.SetField(employmentevent.FieldTimestamp, field.TypeTime, value)
+		_node.Timestamp = value
+	}
+	if nodes := eec.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   employmentevent.UserTable,
+			Columns: []string{employmentevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// EmploymentEventCreateBulk is the builder for creating many EmploymentEvent entities in bulk.
+type EmploymentEventCreateBulk struct {
+	config
+	err      error
+	builders []*EmploymentEventCreate
+}
+
+// Save creates the EmploymentEvent entities in the database.
+func (eecb *EmploymentEventCreateBulk) Save(ctx context.Context) ([]*EmploymentEvent, error) {
+	if eecb.err != nil {
+		return nil, eecb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(eecb.builders))
+	nodes := make([]*EmploymentEvent, len(eecb.builders))
+	mutators := make([]Mutator, len(eecb.builders))
+	for i := range eecb.builders {
+		func(i int, root context.Context) {
+			builder := eecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*EmploymentEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, eecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, eecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, eecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (eecb *EmploymentEventCreateBulk) SaveX(ctx context.Context) []*EmploymentEvent {
+	v, err := eecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (eecb *EmploymentEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := eecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eecb *EmploymentEventCreateBulk) ExecX(ctx context.Context) {
+	if err := eecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
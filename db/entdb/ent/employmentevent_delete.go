@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+)
+
+// EmploymentEventDelete is the builder for deleting a EmploymentEvent entity.
+type EmploymentEventDelete struct {
+	config
+	hooks    []Hook
+	mutation *EmploymentEventMutation
+}
+
+// Where appends a list predicates to the EmploymentEventDelete builder.
+func (eed *EmploymentEventDelete) Where(ps ...predicate.EmploymentEvent) *EmploymentEventDelete {
+	eed.mutation.Where(ps...)
+	return eed
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (eed *EmploymentEventDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, eed.sqlExec, eed.mutation, eed.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eed *EmploymentEventDelete) ExecX(ctx context.Context) int {
+	n, err := eed.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (eed *EmploymentEventDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(employmentevent.Table, sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID))
+	if ps := eed.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, eed.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	eed.mutation.done = true
+	return affected, err
+}
+
+// EmploymentEventDeleteOne is the builder for deleting a single EmploymentEvent entity.
+type EmploymentEventDeleteOne struct {
+	eed *EmploymentEventDelete
+}
+
+// Where appends a list predicates to the EmploymentEventDelete builder.
+func (eedo *EmploymentEventDeleteOne) Where(ps ...predicate.EmploymentEvent) *EmploymentEventDeleteOne {
+	eedo.eed.mutation.Where(ps...)
+	return eedo
+}
+
+// Exec executes the deletion query.
+func (eedo *EmploymentEventDeleteOne) Exec(ctx context.Context) error {
+	n, err := eedo.eed.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{employmentevent.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eedo *EmploymentEventDeleteOne) ExecX(ctx context.Context) {
+	if err := eedo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
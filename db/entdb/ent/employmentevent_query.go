@@ -0,0 +1,644 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	uuid "github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+)
+
+// EmploymentEventQuery is the builder for querying EmploymentEvent entities.
+type EmploymentEventQuery struct {
+	config
+	ctx        *QueryContext
+	order      []employmentevent.OrderOption
+	inters     []Interceptor
+	predicates []predicate.EmploymentEvent
+	withUser   *UserQuery
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the EmploymentEventQuery builder.
+func (eeq *EmploymentEventQuery) Where(ps ...predicate.EmploymentEvent) *EmploymentEventQuery {
+	eeq.predicates = append(eeq.predicates, ps...)
+	return eeq
+}
+
+// Limit the number of records to be returned by this query.
+func (eeq *EmploymentEventQuery) Limit(limit int) *EmploymentEventQuery {
+	eeq.ctx.Limit = &limit
+	return eeq
+}
+
+// Offset to start from.
+func (eeq *EmploymentEventQuery) Offset(offset int) *EmploymentEventQuery {
+	eeq.ctx.Offset = &offset
+	return eeq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (eeq *EmploymentEventQuery) Unique(unique bool) *EmploymentEventQuery {
+	eeq.ctx.Unique = &unique
+	return eeq
+}
+
+// Order specifies how the records should be ordered.
+func (eeq *EmploymentEventQuery) Order(o ...employmentevent.OrderOption) *EmploymentEventQuery {
+	eeq.order = append(eeq.order, o...)
+	return eeq
+}
+
+// QueryUser chains the current query on the "user" edge.
+func (eeq *EmploymentEventQuery) QueryUser() *UserQuery {
+	query := (&UserClient{config: eeq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := eeq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := eeq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(employmentevent.Table, employmentevent.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, employmentevent.UserTable, employmentevent.UserColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(eeq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first EmploymentEvent entity from the query.
+// Returns a *NotFoundError when no EmploymentEvent was found.
+func (eeq *EmploymentEventQuery) First(ctx context.Context) (*EmploymentEvent, error) {
+	nodes, err := eeq.Limit(1).All(setContextOp(ctx, eeq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{employmentevent.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (eeq *EmploymentEventQuery) FirstX(ctx context.Context) *EmploymentEvent {
+	node, err := eeq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first EmploymentEvent ID from the query.
+// Returns a *NotFoundError when no EmploymentEvent ID was found.
+func (eeq *EmploymentEventQuery) FirstID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = eeq.Limit(1).IDs(setContextOp(ctx, eeq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{employmentevent.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (eeq *EmploymentEventQuery) FirstIDX(ctx context.Context) uuid.UUID {
+	id, err := eeq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single EmploymentEvent entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one EmploymentEvent entity is found.
+// Returns a *NotFoundError when no EmploymentEvent entities are found.
+func (eeq *EmploymentEventQuery) Only(ctx context.Context) (*EmploymentEvent, error) {
+	nodes, err := eeq.Limit(2).All(setContextOp(ctx, eeq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{employmentevent.Label}
+	default:
+		return nil, &NotSingularError{employmentevent.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (eeq *EmploymentEventQuery) OnlyX(ctx context.Context) *EmploymentEvent {
+	node, err := eeq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only EmploymentEvent ID in the query.
+// Returns a *NotSingularError when more than one EmploymentEvent ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (eeq *EmploymentEventQuery) OnlyID(ctx context.Context) (id uuid.UUID, err error) {
+	var ids []uuid.UUID
+	if ids, err = eeq.Limit(2).IDs(setContextOp(ctx, eeq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{employmentevent.Label}
+	default:
+		err = &NotSingularError{employmentevent.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (eeq *EmploymentEventQuery) OnlyIDX(ctx context.Context) uuid.UUID {
+	id, err := eeq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of EmploymentEvents.
+func (eeq *EmploymentEventQuery) All(ctx context.Context) ([]*EmploymentEvent, error) {
+	ctx = setContextOp(ctx, eeq.ctx, ent.OpQueryAll)
+	if err := eeq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*EmploymentEvent, *EmploymentEventQuery]()
+	return withInterceptors[[]*EmploymentEvent](ctx, eeq, qr, eeq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (eeq *EmploymentEventQuery) AllX(ctx context.Context) []*EmploymentEvent {
+	nodes, err := eeq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of EmploymentEvent IDs.
+func (eeq *EmploymentEventQuery) IDs(ctx context.Context) (ids []uuid.UUID, err error) {
+	if eeq.ctx.Unique == nil && eeq.path != nil {
+		eeq.Unique(true)
+	}
+	ctx = setContextOp(ctx, eeq.ctx, ent.OpQueryIDs)
+	if err = eeq.Select(employmentevent.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (eeq *EmploymentEventQuery) IDsX(ctx context.Context) []uuid.UUID {
+	ids, err := eeq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (eeq *EmploymentEventQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, eeq.ctx, ent.OpQueryCount)
+	if err := eeq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, eeq, querierCount[*EmploymentEventQuery](), eeq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (eeq *EmploymentEventQuery) CountX(ctx context.Context) int {
+	count, err := eeq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (eeq *EmploymentEventQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, eeq.ctx, ent.OpQueryExist)
+	switch _, err := eeq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (eeq *EmploymentEventQuery) ExistX(ctx context.Context) bool {
+	exist, err := eeq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the EmploymentEventQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (eeq *EmploymentEventQuery) Clone() *EmploymentEventQuery {
+	if eeq == nil {
+		return nil
+	}
+	return &EmploymentEventQuery{
+		config:     eeq.config,
+		ctx:        eeq.ctx.Clone(),
+		order:      append([]employmentevent.OrderOption{}, eeq.order...),
+		inters:     append([]Interceptor{}, eeq.inters...),
+		predicates: append([]predicate.EmploymentEvent{}, eeq.predicates...),
+		withUser:   eeq.withUser.Clone(),
+		// clone intermediate query.
+		sql:  eeq.sql.Clone(),
+		path: eeq.path,
+	}
+}
+
+// WithUser tells the query-builder to eager-load the nodes that are connected to
+// the "user" edge. The optional arguments are used to configure the query builder of the edge.
+func (eeq *EmploymentEventQuery) WithUser(opts ...func(*UserQuery)) *EmploymentEventQuery {
+	query := (&UserClient{config: eeq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	eeq.withUser = query
+	return eeq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID uuid.UUID `json:"user_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.EmploymentEvent.Query().
+//		GroupBy(employmentevent.FieldUserID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (eeq *EmploymentEventQuery) GroupBy(field string, fields ...string) *EmploymentEventGroupBy {
+	eeq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &EmploymentEventGroupBy{build: eeq}
+	grbuild.flds = &eeq.ctx.Fields
+	grbuild.label = employmentevent.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID uuid.UUID `json:"user_id,omitempty"`
+//	}
+//
+//	client.EmploymentEvent.Query().
+//		Select(employmentevent.FieldUserID).
+//		Scan(ctx, &v)
+func (eeq *EmploymentEventQuery) Select(fields ...string) *EmploymentEventSelect {
+	eeq.ctx.Fields = append(eeq.ctx.Fields, fields...)
+	sbuild := &EmploymentEventSelect{EmploymentEventQuery: eeq}
+	sbuild.label = employmentevent.Label
+	sbuild.flds, sbuild.scan = &eeq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a EmploymentEventSelect configured with the given aggregations.
+func (eeq *EmploymentEventQuery) Aggregate(fns ...AggregateFunc) *EmploymentEventSelect {
+	return eeq.Select().Aggregate(fns...)
+}
+
+func (eeq *EmploymentEventQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range eeq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, eeq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range eeq.ctx.Fields {
+		if !employmentevent.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if eeq.path != nil {
+		prev, err := eeq.path(ctx)
+		if err != nil {
+			return err
+		}
+		eeq.sql = prev
+	}
+	return nil
+}
+
+func (eeq *EmploymentEventQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*EmploymentEvent, error) {
+	var (
+		nodes       = []*EmploymentEvent{}
+		_spec       = eeq.querySpec()
+		loadedTypes = [1]bool{
+			eeq.withUser != nil,
+		}
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*EmploymentEvent).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &EmploymentEvent{config: eeq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	if len(eeq.modifiers) > 0 {
+		_spec.Modifiers = eeq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, eeq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := eeq.withUser; query != nil {
+		if err := eeq.loadUser(ctx, query, nodes, nil,
+			func(n *EmploymentEvent, e *User) { n.Edges.User = e }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (eeq *EmploymentEventQuery) loadUser(ctx context.Context, query *UserQuery, nodes []*EmploymentEvent, init func(*EmploymentEvent), assign func(*EmploymentEvent, *User)) error {
+	ids := make([]uuid.UUID, 0, len(nodes))
+	nodeids := make(map[uuid.UUID][]*EmploymentEvent)
+	for i := range nodes {
+		fk := nodes[i].UserID
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(user.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "user_id" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (eeq *EmploymentEventQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := eeq.querySpec()
+	if len(eeq.modifiers) > 0 {
+		_spec.Modifiers = eeq.modifiers
+	}
+	_spec.Node.Columns = eeq.ctx.Fields
+	if len(eeq.ctx.Fields) > 0 {
+		_spec.Unique = eeq.ctx.Unique != nil && *eeq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, eeq.driver, _spec)
+}
+
+func (eeq *EmploymentEventQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(employmentevent.Table, employmentevent.Columns, sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID))
+	_spec.From = eeq.sql
+	if unique := eeq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if eeq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := eeq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, employmentevent.FieldID)
+		for i := range fields {
+			if fields[i] != employmentevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+		if eeq.withUser != nil {
+			_spec.Node.AddColumnOnce(employmentevent.FieldUserID)
+		}
+	}
+	if ps := eeq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := eeq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := eeq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := eeq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (eeq *EmploymentEventQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(eeq.driver.Dialect())
+	t1 := builder.Table(employmentevent.Table)
+	columns := eeq.ctx.Fields
+	if len(columns) == 0 {
+		columns = employmentevent.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if eeq.sql != nil {
+		selector = eeq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if eeq.ctx.Unique != nil && *eeq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range eeq.modifiers {
+		m(selector)
+	}
+	for _, p := range eeq.predicates {
+		p(selector)
+	}
+	for _, p := range eeq.order {
+		p(selector)
+	}
+	if offset := eeq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := eeq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (eeq *EmploymentEventQuery) ForUpdate(opts ...sql.LockOption) *EmploymentEventQuery {
+	if eeq.driver.Dialect() == dialect.Postgres {
+		eeq.Unique(false)
+	}
+	eeq.modifiers = append(eeq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return eeq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (eeq *EmploymentEventQuery) ForShare(opts ...sql.LockOption) *EmploymentEventQuery {
+	if eeq.driver.Dialect() == dialect.Postgres {
+		eeq.Unique(false)
+	}
+	eeq.modifiers = append(eeq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return eeq
+}
+
+// EmploymentEventGroupBy is the group-by builder for EmploymentEvent entities.
+type EmploymentEventGroupBy struct {
+	selector
+	build *EmploymentEventQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (eegb *EmploymentEventGroupBy) Aggregate(fns ...AggregateFunc) *EmploymentEventGroupBy {
+	eegb.fns = append(eegb.fns, fns...)
+	return eegb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (eegb *EmploymentEventGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, eegb.build.ctx, ent.OpQueryGroupBy)
+	if err := eegb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*EmploymentEventQuery, *EmploymentEventGroupBy](ctx, eegb.build, eegb, eegb.build.inters, v)
+}
+
+func (eegb *EmploymentEventGroupBy) sqlScan(ctx context.Context, root *EmploymentEventQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(eegb.fns))
+	for _, fn := range eegb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*eegb.flds)+len(eegb.fns))
+		for _, f := range *eegb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*eegb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := eegb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// EmploymentEventSelect is the builder for selecting fields of EmploymentEvent entities.
+type EmploymentEventSelect struct {
+	*EmploymentEventQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (ees *EmploymentEventSelect) Aggregate(fns ...AggregateFunc) *EmploymentEventSelect {
+	ees.fns = append(ees.fns, fns...)
+	return ees
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ees *EmploymentEventSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ees.ctx, ent.OpQuerySelect)
+	if err := ees.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*EmploymentEventQuery, *EmploymentEventSelect](ctx, ees.EmploymentEventQuery, ees, ees.inters, v)
+}
+
+func (ees *EmploymentEventSelect) sqlScan(ctx context.Context, root *EmploymentEventQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(ees.fns))
+	for _, fn := range ees.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*ees.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ees.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,553 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	uuid "github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+)
+
+// EmploymentEventUpdate is the builder for updating EmploymentEvent entities.
+type EmploymentEventUpdate struct {
+	config
+	hooks    []Hook
+	mutation *EmploymentEventMutation
+}
+
+// Where appends a list predicates to the EmploymentEventUpdate builder.
+func (eeu *EmploymentEventUpdate) Where(ps ...predicate.EmploymentEvent) *EmploymentEventUpdate {
+	eeu.mutation.Where(ps...)
+	return eeu
+}
+
+// SetUserID sets the "user_id" field.
+func (eeu *EmploymentEventUpdate) SetUserID(u uuid.UUID) *EmploymentEventUpdate {
+	eeu.mutation.SetUserID(u)
+	return eeu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (eeu *EmploymentEventUpdate) SetNillableUserID(u *uuid.UUID) *EmploymentEventUpdate {
+	if u != nil {
+		eeu.SetUserID(*u)
+	}
+	return eeu
+}
+
+// SetActorID sets the "actor_id" field.
+func (eeu *EmploymentEventUpdate) SetActorID(u uuid.UUID) *EmploymentEventUpdate {
+	eeu.mutation.SetActorID(u)
+	return eeu
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (eeu *EmploymentEventUpdate) SetNillableActorID(u *uuid.UUID) *EmploymentEventUpdate {
+	if u != nil {
+		eeu.SetActorID(*u)
+	}
+	return eeu
+}
+
+// SetOldRoleID sets the "old_role_id" field.
+func (eeu *EmploymentEventUpdate) SetOldRoleID(i int32) *EmploymentEventUpdate {
+	eeu.mutation.ResetOldRoleID()
+	eeu.mutation.SetOldRoleID(i)
+	return eeu
+}
+
+// SetNillableOldRoleID sets the "old_role_id" field if the given value is not nil.
+func (eeu *EmploymentEventUpdate) SetNillableOldRoleID(i *int32) *EmploymentEventUpdate {
+	if i != nil {
+		eeu.SetOldRoleID(*i)
+	}
+	return eeu
+}
+
+// AddOldRoleID adds i to the "old_role_id" field.
+func (eeu *EmploymentEventUpdate) AddOldRoleID(i int32) *EmploymentEventUpdate {
+	eeu.mutation.AddOldRoleID(i)
+	return eeu
+}
+
+// SetNewRoleID sets the "new_role_id" field.
+func (eeu *EmploymentEventUpdate) SetNewRoleID(i int32) *EmploymentEventUpdate {
+	eeu.mutation.ResetNewRoleID()
+	eeu.mutation.SetNewRoleID(i)
+	return eeu
+}
+
+// SetNillableNewRoleID sets the "new_role_id" field if the given value is not nil.
+func (eeu *EmploymentEventUpdate) SetNillableNewRoleID(i *int32) *EmploymentEventUpdate {
+	if i != nil {
+		eeu.SetNewRoleID(*i)
+	}
+	return eeu
+}
+
+// AddNewRoleID adds i to the "new_role_id" field.
+func (eeu *EmploymentEventUpdate) AddNewRoleID(i int32) *EmploymentEventUpdate {
+	eeu.mutation.AddNewRoleID(i)
+	return eeu
+}
+
+// SetOldDepartmentID sets the "old_department_id" field.
+func (eeu *EmploymentEventUpdate) SetOldDepartmentID(u uuid.UUID) *EmploymentEventUpdate {
+	eeu.mutation.SetOldDepartmentID(u)
+	return eeu
+}
+
+// SetNillableOldDepartmentID sets the "old_department_id" field if the given value is not nil.
+func (eeu *EmploymentEventUpdate) SetNillableOldDepartmentID(u *uuid.UUID) *EmploymentEventUpdate {
+	if u != nil {
+		eeu.SetOldDepartmentID(*u)
+	}
+	return eeu
+}
+
+// ClearOldDepartmentID clears the value of the "old_department_id" field.
+func (eeu *EmploymentEventUpdate) ClearOldDepartmentID() *EmploymentEventUpdate {
+	eeu.mutation.ClearOldDepartmentID()
+	return eeu
+}
+
+// SetNewDepartmentID sets the "new_department_id" field.
+func (eeu *EmploymentEventUpdate) SetNewDepartmentID(u uuid.UUID) *EmploymentEventUpdate {
+	eeu.mutation.SetNewDepartmentID(u)
+	return eeu
+}
+
+// SetNillableNewDepartmentID sets the "new_department_id" field if the given value is not nil.
+func (eeu *EmploymentEventUpdate) SetNillableNewDepartmentID(u *uuid.UUID) *EmploymentEventUpdate {
+	if u != nil {
+		eeu.SetNewDepartmentID(*u)
+	}
+	return eeu
+}
+
+// ClearNewDepartmentID clears the value of the "new_department_id" field.
+func (eeu *EmploymentEventUpdate) ClearNewDepartmentID() *EmploymentEventUpdate {
+	eeu.mutation.ClearNewDepartmentID()
+	return eeu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (eeu *EmploymentEventUpdate) SetUser(u *User) *EmploymentEventUpdate {
+	return eeu.SetUserID(u.ID)
+}
+
+// Mutation returns the EmploymentEventMutation object of the builder.
+func (eeu *EmploymentEventUpdate) Mutation() *EmploymentEventMutation {
+	return eeu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (eeu *EmploymentEventUpdate) ClearUser() *EmploymentEventUpdate {
+	eeu.mutation.ClearUser()
+	return eeu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (eeu *EmploymentEventUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, eeu.sqlSave, eeu.mutation, eeu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (eeu *EmploymentEventUpdate) SaveX(ctx context.Context) int {
+	affected, err := eeu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (eeu *EmploymentEventUpdate) Exec(ctx context.Context) error {
+	_, err := eeu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eeu *EmploymentEventUpdate) ExecX(ctx context.Context) {
+	if err := eeu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (eeu *EmploymentEventUpdate) check() error {
+	if eeu.mutation.UserCleared() && len(eeu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "EmploymentEvent.user"`)
+	}
+	return nil
+}
+
+func (eeu *EmploymentEventUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := eeu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(employmentevent.Table, employmentevent.Columns, sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID))
+	if ps := eeu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := eeu.mutation.ActorID(); ok {
+		_spec.SetField(employmentevent.FieldActorID, field.TypeUUID, value)
+	}
+	if value, ok := eeu.mutation.OldRoleID(); ok {
+		_spec.SetField(employmentevent.FieldOldRoleID, field.TypeInt32, value)
+	}
+	if value, ok := eeu.mutation.AddedOldRoleID(); ok {
+		_spec.AddField(employmentevent.FieldOldRoleID, field.TypeInt32, value)
+	}
+	if value, ok := eeu.mutation.NewRoleID(); ok {
+		_spec.SetField(employmentevent.FieldNewRoleID, field.TypeInt32, value)
+	}
+	if value, ok := eeu.mutation.AddedNewRoleID(); ok {
+		_spec.AddField(employmentevent.FieldNewRoleID, field.TypeInt32, value)
+	}
+	if value, ok := eeu.mutation.OldDepartmentID(); ok {
+		_spec.SetField(employmentevent.FieldOldDepartmentID, field.TypeUUID, value)
+	}
+	if eeu.mutation.OldDepartmentIDCleared() {
+		_spec.ClearField(employmentevent.FieldOldDepartmentID, field.TypeUUID)
+	}
+	if value, ok := eeu.mutation.NewDepartmentID(); ok {
+		_spec.SetField(employmentevent.FieldNewDepartmentID, field.TypeUUID, value)
+	}
+	if eeu.mutation.NewDepartmentIDCleared() {
+		_spec.ClearField(employmentevent.FieldNewDepartmentID, field.TypeUUID)
+	}
+	if eeu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   employmentevent.UserTable,
+			Columns: []string{employmentevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := eeu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   employmentevent.UserTable,
+			Columns: []string{employmentevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, eeu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{employmentevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	eeu.mutation.done = true
+	return n, nil
+}
+
+// EmploymentEventUpdateOne is the builder for updating a single EmploymentEvent entity.
+type EmploymentEventUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *EmploymentEventMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (eeuo *EmploymentEventUpdateOne) SetUserID(u uuid.UUID) *EmploymentEventUpdateOne {
+	eeuo.mutation.SetUserID(u)
+	return eeuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (eeuo *EmploymentEventUpdateOne) SetNillableUserID(u *uuid.UUID) *EmploymentEventUpdateOne {
+	if u != nil {
+		eeuo.SetUserID(*u)
+	}
+	return eeuo
+}
+
+// SetActorID sets the "actor_id" field.
+func (eeuo *EmploymentEventUpdateOne) SetActorID(u uuid.UUID) *EmploymentEventUpdateOne {
+	eeuo.mutation.SetActorID(u)
+	return eeuo
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (eeuo *EmploymentEventUpdateOne) SetNillableActorID(u *uuid.UUID) *EmploymentEventUpdateOne {
+	if u != nil {
+		eeuo.SetActorID(*u)
+	}
+	return eeuo
+}
+
+// SetOldRoleID sets the "old_role_id" field.
+func (eeuo *EmploymentEventUpdateOne) SetOldRoleID(i int32) *EmploymentEventUpdateOne {
+	eeuo.mutation.ResetOldRoleID()
+	eeuo.mutation.SetOldRoleID(i)
+	return eeuo
+}
+
+// SetNillableOldRoleID sets the "old_role_id" field if the given value is not nil.
+func (eeuo *EmploymentEventUpdateOne) SetNillableOldRoleID(i *int32) *EmploymentEventUpdateOne {
+	if i != nil {
+		eeuo.SetOldRoleID(*i)
+	}
+	return eeuo
+}
+
+// AddOldRoleID adds i to the "old_role_id" field.
+func (eeuo *EmploymentEventUpdateOne) AddOldRoleID(i int32) *EmploymentEventUpdateOne {
+	eeuo.mutation.AddOldRoleID(i)
+	return eeuo
+}
+
+// SetNewRoleID sets the "new_role_id" field.
+func (eeuo *EmploymentEventUpdateOne) SetNewRoleID(i int32) *EmploymentEventUpdateOne {
+	eeuo.mutation.ResetNewRoleID()
+	eeuo.mutation.SetNewRoleID(i)
+	return eeuo
+}
+
+// SetNillableNewRoleID sets the "new_role_id" field if the given value is not nil.
+func (eeuo *EmploymentEventUpdateOne) SetNillableNewRoleID(i *int32) *EmploymentEventUpdateOne {
+	if i != nil {
+		eeuo.SetNewRoleID(*i)
+	}
+	return eeuo
+}
+
+// AddNewRoleID adds i to the "new_role_id" field.
+func (eeuo *EmploymentEventUpdateOne) AddNewRoleID(i int32) *EmploymentEventUpdateOne {
+	eeuo.mutation.AddNewRoleID(i)
+	return eeuo
+}
+
+// SetOldDepartmentID sets the "old_department_id" field.
+func (eeuo *EmploymentEventUpdateOne) SetOldDepartmentID(u uuid.UUID) *EmploymentEventUpdateOne {
+	eeuo.mutation.SetOldDepartmentID(u)
+	return eeuo
+}
+
+// SetNillableOldDepartmentID sets the "old_department_id" field if the given value is not nil.
+func (eeuo *EmploymentEventUpdateOne) SetNillableOldDepartmentID(u *uuid.UUID) *EmploymentEventUpdateOne {
+	if u != nil {
+		eeuo.SetOldDepartmentID(*u)
+	}
+	return eeuo
+}
+
+// ClearOldDepartmentID clears the value of the "old_department_id" field.
+func (eeuo *EmploymentEventUpdateOne) ClearOldDepartmentID() *EmploymentEventUpdateOne {
+	eeuo.mutation.ClearOldDepartmentID()
+	return eeuo
+}
+
+// SetNewDepartmentID sets the "new_department_id" field.
+func (eeuo *EmploymentEventUpdateOne) SetNewDepartmentID(u uuid.UUID) *EmploymentEventUpdateOne {
+	eeuo.mutation.SetNewDepartmentID(u)
+	return eeuo
+}
+
+// SetNillableNewDepartmentID sets the "new_department_id" field if the given value is not nil.
+func (eeuo *EmploymentEventUpdateOne) SetNillableNewDepartmentID(u *uuid.UUID) *EmploymentEventUpdateOne {
+	if u != nil {
+		eeuo.SetNewDepartmentID(*u)
+	}
+	return eeuo
+}
+
+// ClearNewDepartmentID clears the value of the "new_department_id" field.
+func (eeuo *EmploymentEventUpdateOne) ClearNewDepartmentID() *EmploymentEventUpdateOne {
+	eeuo.mutation.ClearNewDepartmentID()
+	return eeuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (eeuo *EmploymentEventUpdateOne) SetUser(u *User) *EmploymentEventUpdateOne {
+	return eeuo.SetUserID(u.ID)
+}
+
+// Mutation returns the EmploymentEventMutation object of the builder.
+func (eeuo *EmploymentEventUpdateOne) Mutation() *EmploymentEventMutation {
+	return eeuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (eeuo *EmploymentEventUpdateOne) ClearUser() *EmploymentEventUpdateOne {
+	eeuo.mutation.ClearUser()
+	return eeuo
+}
+
+// Where appends a list predicates to the EmploymentEventUpdate builder.
+func (eeuo *EmploymentEventUpdateOne) Where(ps ...predicate.EmploymentEvent) *EmploymentEventUpdateOne {
+	eeuo.mutation.Where(ps...)
+	return eeuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (eeuo *EmploymentEventUpdateOne) Select(field string, fields ...string) *EmploymentEventUpdateOne {
+	eeuo.fields = append([]string{field}, fields...)
+	return eeuo
+}
+
+// Save executes the query and returns the updated EmploymentEvent entity.
+func (eeuo *EmploymentEventUpdateOne) Save(ctx context.Context) (*EmploymentEvent, error) {
+	return withHooks(ctx, eeuo.sqlSave, eeuo.mutation, eeuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (eeuo *EmploymentEventUpdateOne) SaveX(ctx context.Context) *EmploymentEvent {
+	node, err := eeuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (eeuo *EmploymentEventUpdateOne) Exec(ctx context.Context) error {
+	_, err := eeuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eeuo *EmploymentEventUpdateOne) ExecX(ctx context.Context) {
+	if err := eeuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (eeuo *EmploymentEventUpdateOne) check() error {
+	if eeuo.mutation.UserCleared() && len(eeuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "EmploymentEvent.user"`)
+	}
+	return nil
+}
+
+func (eeuo *EmploymentEventUpdateOne) sqlSave(ctx context.Context) (_node *EmploymentEvent, err error) {
+	if err := eeuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(employmentevent.Table, employmentevent.Columns, sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID))
+	id, ok := eeuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "EmploymentEvent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := eeuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, employmentevent.FieldID)
+		for _, f := range fields {
+			if !employmentevent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != employmentevent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := eeuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := eeuo.mutation.ActorID(); ok {
+		_spec.SetField(employmentevent.FieldActorID, field.TypeUUID, value)
+	}
+	if value, ok := eeuo.mutation.OldRoleID(); ok {
+		_spec.SetField(employmentevent.FieldOldRoleID, field.TypeInt32, value)
+	}
+	if value, ok := eeuo.mutation.AddedOldRoleID(); ok {
+		_spec.AddField(employmentevent.FieldOldRoleID, field.TypeInt32, value)
+	}
+	if value, ok := eeuo.mutation.NewRoleID(); ok {
+		_spec.SetField(employmentevent.FieldNewRoleID, field.TypeInt32, value)
+	}
+	if value, ok := eeuo.mutation.AddedNewRoleID(); ok {
+		_spec.AddField(employmentevent.FieldNewRoleID, field.TypeInt32, value)
+	}
+	if value, ok := eeuo.mutation.OldDepartmentID(); ok {
+		_spec.SetField(employmentevent.FieldOldDepartmentID, field.TypeUUID, value)
+	}
+	if eeuo.mutation.OldDepartmentIDCleared() {
+		_spec.ClearField(employmentevent.FieldOldDepartmentID, field.TypeUUID)
+	}
+	if value, ok := eeuo.mutation.NewDepartmentID(); ok {
+		_spec.SetField(employmentevent.FieldNewDepartmentID, field.TypeUUID, value)
+	}
+	if eeuo.mutation.NewDepartmentIDCleared() {
+		_spec.ClearField(employmentevent.FieldNewDepartmentID, field.TypeUUID)
+	}
+	if eeuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   employmentevent.UserTable,
+			Columns: []string{employmentevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := eeuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   employmentevent.UserTable,
+			Columns: []string{employmentevent.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &EmploymentEvent{config: eeuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, eeuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{employmentevent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	eeuo.mutation.done = true
+	return _node, nil
+}
@@ -12,9 +12,13 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/auditlog"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/authuser"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schemaversion"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/userpermission"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -75,9 +79,13 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			authuser.Table:   authuser.ValidColumn,
-			department.Table: department.ValidColumn,
-			user.Table:       user.ValidColumn,
+			auditlog.Table:        auditlog.ValidColumn,
+			authuser.Table:        authuser.ValidColumn,
+			department.Table:      department.ValidColumn,
+			employmentevent.Table: employmentevent.ValidColumn,
+			schemaversion.Table:   schemaversion.ValidColumn,
+			user.Table:            user.ValidColumn,
+			userpermission.Table:  userpermission.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
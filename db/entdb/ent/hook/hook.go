@@ -9,6 +9,18 @@ import (
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
 )
 
+// The AuditLogFunc type is an adapter to allow the use of ordinary
+// function as AuditLog mutator.
+type AuditLogFunc func(context.Context, *ent.AuditLogMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f AuditLogFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.AuditLogMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.AuditLogMutation", m)
+}
+
 // The AuthUserFunc type is an adapter to allow the use of ordinary
 // function as AuthUser mutator.
 type AuthUserFunc func(context.Context, *ent.AuthUserMutation) (ent.Value, error)
@@ -33,6 +45,30 @@ func (f DepartmentFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value,
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.DepartmentMutation", m)
 }
 
+// The EmploymentEventFunc type is an adapter to allow the use of ordinary
+// function as EmploymentEvent mutator.
+type EmploymentEventFunc func(context.Context, *ent.EmploymentEventMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f EmploymentEventFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.EmploymentEventMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.EmploymentEventMutation", m)
+}
+
+// The SchemaVersionFunc type is an adapter to allow the use of ordinary
+// function as SchemaVersion mutator.
+type SchemaVersionFunc func(context.Context, *ent.SchemaVersionMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f SchemaVersionFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.SchemaVersionMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.SchemaVersionMutation", m)
+}
+
 // The UserFunc type is an adapter to allow the use of ordinary
 // function as User mutator.
 type UserFunc func(context.Context, *ent.UserMutation) (ent.Value, error)
@@ -45,6 +81,18 @@ func (f UserFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error)
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.UserMutation", m)
 }
 
+// The UserPermissionFunc type is an adapter to allow the use of ordinary
+// function as UserPermission mutator.
+type UserPermissionFunc func(context.Context, *ent.UserPermissionMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f UserPermissionFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.UserPermissionMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.UserPermissionMutation", m)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, ent.Mutation) bool
 
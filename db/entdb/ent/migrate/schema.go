@@ -3,11 +3,41 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
 
 var (
+	// AuditLogColumns holds the columns for the "audit_log" table.
+	AuditLogColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "org_id", Type: field.TypeUUID, Default: "00000000-0000-0000-0000-000000000000"},
+		{Name: "actor_id", Type: field.TypeUUID},
+		{Name: "action", Type: field.TypeString},
+		{Name: "target_type", Type: field.TypeString},
+		{Name: "target_id", Type: field.TypeUUID},
+		{Name: "timestamp", Type: field.TypeTime},
+		{Name: "details", Type: field.TypeJSON, Nullable: true},
+	}
+	// AuditLogTable holds the schema information for the "audit_log" table.
+	AuditLogTable = &schema.Table{
+		Name:       "audit_log",
+		Columns:    AuditLogColumns,
+		PrimaryKey: []*schema.Column{AuditLogColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditlog_target_id_timestamp",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogColumns[5], AuditLogColumns[6]},
+			},
+			{
+				Name:    "auditlog_org_id_timestamp",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogColumns[1], AuditLogColumns[6]},
+			},
+		},
+	}
 	// AuthUsersColumns holds the columns for the "auth_users" table.
 	AuthUsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -33,7 +63,9 @@ var (
 	// DepartmentsColumns holds the columns for the "departments" table.
 	DepartmentsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID, Unique: true},
-		{Name: "name", Type: field.TypeString, Unique: true},
+		{Name: "org_id", Type: field.TypeUUID, Default: "00000000-0000-0000-0000-000000000000"},
+		{Name: "name", Type: field.TypeString},
+		{Name: "normalized_name", Type: field.TypeString, Nullable: true},
 		{Name: "description", Type: field.TypeString, Nullable: true, Size: 2147483647},
 	}
 	// DepartmentsTable holds the schema information for the "departments" table.
@@ -41,16 +73,87 @@ var (
 		Name:       "departments",
 		Columns:    DepartmentsColumns,
 		PrimaryKey: []*schema.Column{DepartmentsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "department_org_id_name",
+				Unique:  true,
+				Columns: []*schema.Column{DepartmentsColumns[1], DepartmentsColumns[2]},
+			},
+			{
+				Name:    "department_org_id_normalized_name",
+				Unique:  true,
+				Columns: []*schema.Column{DepartmentsColumns[1], DepartmentsColumns[3]},
+			},
+		},
+	}
+	// EmploymentEventsColumns holds the columns for the "employment_events" table.
+	EmploymentEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "actor_id", Type: field.TypeUUID},
+		{Name: "old_role_id", Type: field.TypeInt32},
+		{Name: "new_role_id", Type: field.TypeInt32},
+		{Name: "old_department_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "new_department_id", Type: field.TypeUUID, Nullable: true},
+		{Name: "timestamp", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// EmploymentEventsTable holds the schema information for the "employment_events" table.
+	EmploymentEventsTable = &schema.Table{
+		Name:       "employment_events",
+		Columns:    EmploymentEventsColumns,
+		PrimaryKey: []*schema.Column{EmploymentEventsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "employment_events_users_employment_events",
+				Columns:    []*schema.Column{EmploymentEventsColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "employmentevent_user_id_timestamp",
+				Unique:  false,
+				Columns: []*schema.Column{EmploymentEventsColumns[7], EmploymentEventsColumns[6]},
+			},
+		},
+	}
+	// SchemaVersionsColumns holds the columns for the "schema_versions" table.
+	SchemaVersionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "hash", Type: field.TypeString},
+		{Name: "applied_at", Type: field.TypeTime},
+	}
+	// SchemaVersionsTable holds the schema information for the "schema_versions" table.
+	SchemaVersionsTable = &schema.Table{
+		Name:       "schema_versions",
+		Columns:    SchemaVersionsColumns,
+		PrimaryKey: []*schema.Column{SchemaVersionsColumns[0]},
 	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID, Unique: true},
+		{Name: "org_id", Type: field.TypeUUID, Default: "00000000-0000-0000-0000-000000000000"},
 		{Name: "first_name", Type: field.TypeString},
 		{Name: "last_name", Type: field.TypeString},
 		{Name: "middle_name", Type: field.TypeString, Default: ""},
 		{Name: "picture_url", Type: field.TypeString, Nullable: true},
+		{Name: "email", Type: field.TypeString, Unique: true, Nullable: true},
 		{Name: "suspended", Type: field.TypeBool, Default: false},
 		{Name: "role_id", Type: field.TypeInt32},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "version", Type: field.TypeInt, Default: 1},
+		{Name: "subdivision", Type: field.TypeString, Default: ""},
+		{Name: "job_title", Type: field.TypeString, Default: ""},
+		{Name: "employment_rate", Type: field.TypeFloat64, Default: 0},
+		{Name: "personnel_category", Type: field.TypeInt32, Default: 0},
+		{Name: "employment_type", Type: field.TypeInt32, Default: 0},
+		{Name: "academic_degree", Type: field.TypeInt32, Default: 0},
+		{Name: "academic_title", Type: field.TypeString, Default: ""},
+		{Name: "honors", Type: field.TypeString, Default: ""},
+		{Name: "category", Type: field.TypeString, Default: ""},
+		{Name: "date_of_employment", Type: field.TypeTime, Nullable: true},
+		{Name: "unemployment_date", Type: field.TypeTime, Nullable: true},
 		{Name: "department_id", Type: field.TypeUUID, Nullable: true},
 	}
 	// UsersTable holds the schema information for the "users" table.
@@ -61,21 +164,73 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "users_departments_users",
-				Columns:    []*schema.Column{UsersColumns[7]},
+				Columns:    []*schema.Column{UsersColumns[22]},
 				RefColumns: []*schema.Column{DepartmentsColumns[0]},
 				OnDelete:   schema.Restrict,
 			},
 		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "user_org_id",
+				Unique:  false,
+				Columns: []*schema.Column{UsersColumns[1]},
+			},
+		},
+	}
+	// UserPermissionsColumns holds the columns for the "user_permissions" table.
+	UserPermissionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "permission_id", Type: field.TypeInt32},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// UserPermissionsTable holds the schema information for the "user_permissions" table.
+	UserPermissionsTable = &schema.Table{
+		Name:       "user_permissions",
+		Columns:    UserPermissionsColumns,
+		PrimaryKey: []*schema.Column{UserPermissionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_permissions_users_permissions",
+				Columns:    []*schema.Column{UserPermissionsColumns[2]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "userpermission_user_id_permission_id",
+				Unique:  true,
+				Columns: []*schema.Column{UserPermissionsColumns[2], UserPermissionsColumns[1]},
+			},
+		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		AuditLogTable,
 		AuthUsersTable,
 		DepartmentsTable,
+		EmploymentEventsTable,
+		SchemaVersionsTable,
 		UsersTable,
+		UserPermissionsTable,
 	}
 )
 
 func init() {
+	AuditLogTable.Annotation = &entsql.Annotation{
+		Table: "audit_log",
+	}
 	AuthUsersTable.ForeignKeys[0].RefTable = UsersTable
+	EmploymentEventsTable.ForeignKeys[0].RefTable = UsersTable
+	EmploymentEventsTable.Annotation = &entsql.Annotation{
+		Table: "employment_events",
+	}
+	SchemaVersionsTable.Annotation = &entsql.Annotation{
+		Table: "schema_versions",
+	}
 	UsersTable.ForeignKeys[0].RefTable = DepartmentsTable
+	UserPermissionsTable.ForeignKeys[0].RefTable = UsersTable
+	UserPermissionsTable.Annotation = &entsql.Annotation{
+		Table: "user_permissions",
+	}
 }
@@ -7,14 +7,19 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	uuid "github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/auditlog"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/authuser"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schemaversion"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/userpermission"
 )
 
 const (
@@ -26,39 +31,45 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeAuthUser   = "AuthUser"
-	TypeDepartment = "Department"
-	TypeUser       = "User"
+	TypeAuditLog        = "AuditLog"
+	TypeAuthUser        = "AuthUser"
+	TypeDepartment      = "Department"
+	TypeEmploymentEvent = "EmploymentEvent"
+	TypeSchemaVersion   = "SchemaVersion"
+	TypeUser            = "User"
+	TypeUserPermission  = "UserPermission"
 )
 
-// AuthUserMutation represents an operation that mutates the AuthUser nodes in the graph.
-type AuthUserMutation struct {
+// AuditLogMutation represents an operation that mutates the AuditLog nodes in the graph.
+type AuditLogMutation struct {
 	config
 	op            Op
 	typ           string
-	id            *int
-	username      *string
-	password      *string
-	auth_id       *uuid.UUID
+	id            *uuid.UUID
+	org_id        *uuid.UUID
+	actor_id      *uuid.UUID
+	action        *string
+	target_type   *string
+	target_id     *uuid.UUID
+	timestamp     *time.Time
+	details       *map[string]interface{}
 	clearedFields map[string]struct{}
-	user          *uuid.UUID
-	cleareduser   bool
 	done          bool
-	oldValue      func(context.Context) (*AuthUser, error)
-	predicates    []predicate.AuthUser
+	oldValue      func(context.Context) (*AuditLog, error)
+	predicates    []predicate.AuditLog
 }
 
-var _ ent.Mutation = (*AuthUserMutation)(nil)
+var _ ent.Mutation = (*AuditLogMutation)(nil)
 
-// authuserOption allows management of the mutation configuration using functional options.
-type authuserOption func(*AuthUserMutation)
+// auditlogOption allows management of the mutation configuration using functional options.
+type auditlogOption func(*AuditLogMutation)
 
-// newAuthUserMutation creates new mutation for the AuthUser entity.
-func newAuthUserMutation(c config, op Op, opts ...authuserOption) *AuthUserMutation {
-	m := &AuthUserMutation{
+// newAuditLogMutation creates new mutation for the AuditLog entity.
+func newAuditLogMutation(c config, op Op, opts ...auditlogOption) *AuditLogMutation {
+	m := &AuditLogMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeAuthUser,
+		typ:           TypeAuditLog,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -67,20 +78,20 @@ func newAuthUserMutation(c config, op Op, opts ...authuserOption) *AuthUserMutat
 	return m
 }
 
-// withAuthUserID sets the ID field of the mutation.
-func withAuthUserID(id int) authuserOption {
-	return func(m *AuthUserMutation) {
+// withAuditLogID sets the ID field of the mutation.
+func withAuditLogID(id uuid.UUID) auditlogOption {
+	return func(m *AuditLogMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *AuthUser
+			value *AuditLog
 		)
-		m.oldValue = func(ctx context.Context) (*AuthUser, error) {
+		m.oldValue = func(ctx context.Context) (*AuditLog, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().AuthUser.Get(ctx, id)
+					value, err = m.Client().AuditLog.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -89,10 +100,10 @@ func withAuthUserID(id int) authuserOption {
 	}
 }
 
-// withAuthUser sets the old AuthUser of the mutation.
-func withAuthUser(node *AuthUser) authuserOption {
-	return func(m *AuthUserMutation) {
-		m.oldValue = func(context.Context) (*AuthUser, error) {
+// withAuditLog sets the old AuditLog of the mutation.
+func withAuditLog(node *AuditLog) auditlogOption {
+	return func(m *AuditLogMutation) {
+		m.oldValue = func(context.Context) (*AuditLog, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -101,7 +112,7 @@ func withAuthUser(node *AuthUser) authuserOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m AuthUserMutation) Client() *Client {
+func (m AuditLogMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -109,7 +120,7 @@ func (m AuthUserMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m AuthUserMutation) Tx() (*Tx, error) {
+func (m AuditLogMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -118,9 +129,15 @@ func (m AuthUserMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of AuditLog entities.
+func (m *AuditLogMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *AuthUserMutation) ID() (id int, exists bool) {
+func (m *AuditLogMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -131,201 +148,295 @@ func (m *AuthUserMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *AuthUserMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *AuditLogMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []int{id}, nil
+			return []uuid.UUID{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().AuthUser.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().AuditLog.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetUsername sets the "username" field.
-func (m *AuthUserMutation) SetUsername(s string) {
-	m.username = &s
+// SetOrgID sets the "org_id" field.
+func (m *AuditLogMutation) SetOrgID(u uuid.UUID) {
+	m.org_id = &u
 }
 
-// Username returns the value of the "username" field in the mutation.
-func (m *AuthUserMutation) Username() (r string, exists bool) {
-	v := m.username
+// OrgID returns the value of the "org_id" field in the mutation.
+func (m *AuditLogMutation) OrgID() (r uuid.UUID, exists bool) {
+	v := m.org_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUsername returns the old "username" field's value of the AuthUser entity.
-// If the AuthUser object wasn't provided to the builder, the object is fetched from the database.
+// OldOrgID returns the old "org_id" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthUserMutation) OldUsername(ctx context.Context) (v string, err error) {
+func (m *AuditLogMutation) OldOrgID(ctx context.Context) (v uuid.UUID, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUsername is only allowed on UpdateOne operations")
+		return v, errors.New("OldOrgID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUsername requires an ID field in the mutation")
+		return v, errors.New("OldOrgID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUsername: %w", err)
+		return v, fmt.Errorf("querying old value for OldOrgID: %w", err)
 	}
-	return oldValue.Username, nil
+	return oldValue.OrgID, nil
 }
 
-// ResetUsername resets all changes to the "username" field.
-func (m *AuthUserMutation) ResetUsername() {
-	m.username = nil
+// ResetOrgID resets all changes to the "org_id" field.
+func (m *AuditLogMutation) ResetOrgID() {
+	m.org_id = nil
 }
 
-// SetPassword sets the "password" field.
-func (m *AuthUserMutation) SetPassword(s string) {
-	m.password = &s
+// SetActorID sets the "actor_id" field.
+func (m *AuditLogMutation) SetActorID(u uuid.UUID) {
+	m.actor_id = &u
 }
 
-// Password returns the value of the "password" field in the mutation.
-func (m *AuthUserMutation) Password() (r string, exists bool) {
-	v := m.password
+// ActorID returns the value of the "actor_id" field in the mutation.
+func (m *AuditLogMutation) ActorID() (r uuid.UUID, exists bool) {
+	v := m.actor_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPassword returns the old "password" field's value of the AuthUser entity.
-// If the AuthUser object wasn't provided to the builder, the object is fetched from the database.
+// OldActorID returns the old "actor_id" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthUserMutation) OldPassword(ctx context.Context) (v string, err error) {
+func (m *AuditLogMutation) OldActorID(ctx context.Context) (v uuid.UUID, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
+		return v, errors.New("OldActorID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPassword requires an ID field in the mutation")
+		return v, errors.New("OldActorID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
+		return v, fmt.Errorf("querying old value for OldActorID: %w", err)
 	}
-	return oldValue.Password, nil
+	return oldValue.ActorID, nil
 }
 
-// ResetPassword resets all changes to the "password" field.
-func (m *AuthUserMutation) ResetPassword() {
-	m.password = nil
+// ResetActorID resets all changes to the "actor_id" field.
+func (m *AuditLogMutation) ResetActorID() {
+	m.actor_id = nil
 }
 
-// SetAuthID sets the "auth_id" field.
-func (m *AuthUserMutation) SetAuthID(u uuid.UUID) {
-	m.auth_id = &u
+// SetAction sets the "action" field.
+func (m *AuditLogMutation) SetAction(s string) {
+	m.action = &s
 }
 
-// AuthID returns the value of the "auth_id" field in the mutation.
-func (m *AuthUserMutation) AuthID() (r uuid.UUID, exists bool) {
-	v := m.auth_id
+// Action returns the value of the "action" field in the mutation.
+func (m *AuditLogMutation) Action() (r string, exists bool) {
+	v := m.action
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAuthID returns the old "auth_id" field's value of the AuthUser entity.
-// If the AuthUser object wasn't provided to the builder, the object is fetched from the database.
+// OldAction returns the old "action" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthUserMutation) OldAuthID(ctx context.Context) (v uuid.UUID, err error) {
+func (m *AuditLogMutation) OldAction(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAuthID is only allowed on UpdateOne operations")
+		return v, errors.New("OldAction is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAuthID requires an ID field in the mutation")
+		return v, errors.New("OldAction requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAuthID: %w", err)
+		return v, fmt.Errorf("querying old value for OldAction: %w", err)
 	}
-	return oldValue.AuthID, nil
+	return oldValue.Action, nil
 }
 
-// ResetAuthID resets all changes to the "auth_id" field.
-func (m *AuthUserMutation) ResetAuthID() {
-	m.auth_id = nil
+// ResetAction resets all changes to the "action" field.
+func (m *AuditLogMutation) ResetAction() {
+	m.action = nil
 }
 
-// SetUserID sets the "user_id" field.
-func (m *AuthUserMutation) SetUserID(u uuid.UUID) {
-	m.user = &u
+// SetTargetType sets the "target_type" field.
+func (m *AuditLogMutation) SetTargetType(s string) {
+	m.target_type = &s
 }
 
-// UserID returns the value of the "user_id" field in the mutation.
-func (m *AuthUserMutation) UserID() (r uuid.UUID, exists bool) {
-	v := m.user
+// TargetType returns the value of the "target_type" field in the mutation.
+func (m *AuditLogMutation) TargetType() (r string, exists bool) {
+	v := m.target_type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUserID returns the old "user_id" field's value of the AuthUser entity.
-// If the AuthUser object wasn't provided to the builder, the object is fetched from the database.
+// OldTargetType returns the old "target_type" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *AuthUserMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+func (m *AuditLogMutation) OldTargetType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+		return v, errors.New("OldTargetType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUserID requires an ID field in the mutation")
+		return v, errors.New("OldTargetType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+		return v, fmt.Errorf("querying old value for OldTargetType: %w", err)
 	}
-	return oldValue.UserID, nil
+	return oldValue.TargetType, nil
 }
 
-// ResetUserID resets all changes to the "user_id" field.
-func (m *AuthUserMutation) ResetUserID() {
-	m.user = nil
+// ResetTargetType resets all changes to the "target_type" field.
+func (m *AuditLogMutation) ResetTargetType() {
+	m.target_type = nil
 }
 
-// ClearUser clears the "user" edge to the User entity.
-func (m *AuthUserMutation) ClearUser() {
-	m.cleareduser = true
-	m.clearedFields[authuser.FieldUserID] = struct{}{}
+// SetTargetID sets the "target_id" field.
+func (m *AuditLogMutation) SetTargetID(u uuid.UUID) {
+	m.target_id = &u
 }
 
-// UserCleared reports if the "user" edge to the User entity was cleared.
-func (m *AuthUserMutation) UserCleared() bool {
-	return m.cleareduser
+// TargetID returns the value of the "target_id" field in the mutation.
+func (m *AuditLogMutation) TargetID() (r uuid.UUID, exists bool) {
+	v := m.target_id
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// UserIDs returns the "user" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// UserID instead. It exists only for internal usage by the builders.
-func (m *AuthUserMutation) UserIDs() (ids []uuid.UUID) {
-	if id := m.user; id != nil {
-		ids = append(ids, *id)
+// OldTargetID returns the old "target_id" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditLogMutation) OldTargetID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTargetID is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTargetID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTargetID: %w", err)
+	}
+	return oldValue.TargetID, nil
 }
 
-// ResetUser resets all changes to the "user" edge.
-func (m *AuthUserMutation) ResetUser() {
-	m.user = nil
-	m.cleareduser = false
+// ResetTargetID resets all changes to the "target_id" field.
+func (m *AuditLogMutation) ResetTargetID() {
+	m.target_id = nil
 }
 
-// Where appends a list predicates to the AuthUserMutation builder.
-func (m *AuthUserMutation) Where(ps ...predicate.AuthUser) {
+// SetTimestamp sets the "timestamp" field.
+func (m *AuditLogMutation) SetTimestamp(t time.Time) {
+	m.timestamp = &t
+}
+
+// Timestamp returns the value of the "timestamp" field in the mutation.
+func (m *AuditLogMutation) Timestamp() (r time.Time, exists bool) {
+	v := m.timestamp
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTimestamp returns the old "timestamp" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditLogMutation) OldTimestamp(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTimestamp is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTimestamp requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTimestamp: %w", err)
+	}
+	return oldValue.Timestamp, nil
+}
+
+// ResetTimestamp resets all changes to the "timestamp" field.
+func (m *AuditLogMutation) ResetTimestamp() {
+	m.timestamp = nil
+}
+
+// SetDetails sets the "details" field.
+func (m *AuditLogMutation) SetDetails(value map[string]interface{}) {
+	m.details = &value
+}
+
+// Details returns the value of the "details" field in the mutation.
+func (m *AuditLogMutation) Details() (r map[string]interface{}, exists bool) {
+	v := m.details
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDetails returns the old "details" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuditLogMutation) OldDetails(ctx context.Context) (v map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDetails is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDetails requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDetails: %w", err)
+	}
+	return oldValue.Details, nil
+}
+
+// ClearDetails clears the value of the "details" field.
+func (m *AuditLogMutation) ClearDetails() {
+	m.details = nil
+	m.clearedFields[auditlog.FieldDetails] = struct{}{}
+}
+
+// DetailsCleared returns if the "details" field was cleared in this mutation.
+func (m *AuditLogMutation) DetailsCleared() bool {
+	_, ok := m.clearedFields[auditlog.FieldDetails]
+	return ok
+}
+
+// ResetDetails resets all changes to the "details" field.
+func (m *AuditLogMutation) ResetDetails() {
+	m.details = nil
+	delete(m.clearedFields, auditlog.FieldDetails)
+}
+
+// Where appends a list predicates to the AuditLogMutation builder.
+func (m *AuditLogMutation) Where(ps ...predicate.AuditLog) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the AuthUserMutation builder. Using this method,
+// WhereP appends storage-level predicates to the AuditLogMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *AuthUserMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.AuthUser, len(ps))
+func (m *AuditLogMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.AuditLog, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -333,36 +444,45 @@ func (m *AuthUserMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *AuthUserMutation) Op() Op {
+func (m *AuditLogMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *AuthUserMutation) SetOp(op Op) {
+func (m *AuditLogMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (AuthUser).
-func (m *AuthUserMutation) Type() string {
+// Type returns the node type of this mutation (AuditLog).
+func (m *AuditLogMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *AuthUserMutation) Fields() []string {
-	fields := make([]string, 0, 4)
-	if m.username != nil {
-		fields = append(fields, authuser.FieldUsername)
+func (m *AuditLogMutation) Fields() []string {
+	fields := make([]string, 0, 7)
+	if m.org_id != nil {
+		fields = append(fields, auditlog.FieldOrgID)
 	}
-	if m.password != nil {
-		fields = append(fields, authuser.FieldPassword)
+	if m.actor_id != nil {
+		fields = append(fields, auditlog.FieldActorID)
 	}
-	if m.auth_id != nil {
-		fields = append(fields, authuser.FieldAuthID)
+	if m.action != nil {
+		fields = append(fields, auditlog.FieldAction)
 	}
-	if m.user != nil {
-		fields = append(fields, authuser.FieldUserID)
+	if m.target_type != nil {
+		fields = append(fields, auditlog.FieldTargetType)
+	}
+	if m.target_id != nil {
+		fields = append(fields, auditlog.FieldTargetID)
+	}
+	if m.timestamp != nil {
+		fields = append(fields, auditlog.FieldTimestamp)
+	}
+	if m.details != nil {
+		fields = append(fields, auditlog.FieldDetails)
 	}
 	return fields
 }
@@ -370,16 +490,22 @@ func (m *AuthUserMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *AuthUserMutation) Field(name string) (ent.Value, bool) {
+func (m *AuditLogMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case authuser.FieldUsername:
-		return m.Username()
-	case authuser.FieldPassword:
-		return m.Password()
-	case authuser.FieldAuthID:
-		return m.AuthID()
-	case authuser.FieldUserID:
-		return m.UserID()
+	case auditlog.FieldOrgID:
+		return m.OrgID()
+	case auditlog.FieldActorID:
+		return m.ActorID()
+	case auditlog.FieldAction:
+		return m.Action()
+	case auditlog.FieldTargetType:
+		return m.TargetType()
+	case auditlog.FieldTargetID:
+		return m.TargetID()
+	case auditlog.FieldTimestamp:
+		return m.Timestamp()
+	case auditlog.FieldDetails:
+		return m.Details()
 	}
 	return nil, false
 }
@@ -387,220 +513,239 @@ func (m *AuthUserMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *AuthUserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *AuditLogMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case authuser.FieldUsername:
-		return m.OldUsername(ctx)
-	case authuser.FieldPassword:
-		return m.OldPassword(ctx)
-	case authuser.FieldAuthID:
-		return m.OldAuthID(ctx)
-	case authuser.FieldUserID:
-		return m.OldUserID(ctx)
-	}
-	return nil, fmt.Errorf("unknown AuthUser field %s", name)
+	case auditlog.FieldOrgID:
+		return m.OldOrgID(ctx)
+	case auditlog.FieldActorID:
+		return m.OldActorID(ctx)
+	case auditlog.FieldAction:
+		return m.OldAction(ctx)
+	case auditlog.FieldTargetType:
+		return m.OldTargetType(ctx)
+	case auditlog.FieldTargetID:
+		return m.OldTargetID(ctx)
+	case auditlog.FieldTimestamp:
+		return m.OldTimestamp(ctx)
+	case auditlog.FieldDetails:
+		return m.OldDetails(ctx)
+	}
+	return nil, fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *AuthUserMutation) SetField(name string, value ent.Value) error {
+func (m *AuditLogMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case authuser.FieldUsername:
+	case auditlog.FieldOrgID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOrgID(v)
+		return nil
+	case auditlog.FieldActorID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetActorID(v)
+		return nil
+	case auditlog.FieldAction:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUsername(v)
+		m.SetAction(v)
 		return nil
-	case authuser.FieldPassword:
+	case auditlog.FieldTargetType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPassword(v)
+		m.SetTargetType(v)
 		return nil
-	case authuser.FieldAuthID:
+	case auditlog.FieldTargetID:
 		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetAuthID(v)
+		m.SetTargetID(v)
 		return nil
-	case authuser.FieldUserID:
-		v, ok := value.(uuid.UUID)
+	case auditlog.FieldTimestamp:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUserID(v)
+		m.SetTimestamp(v)
+		return nil
+	case auditlog.FieldDetails:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDetails(v)
 		return nil
 	}
-	return fmt.Errorf("unknown AuthUser field %s", name)
+	return fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *AuthUserMutation) AddedFields() []string {
+func (m *AuditLogMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *AuthUserMutation) AddedField(name string) (ent.Value, bool) {
+func (m *AuditLogMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *AuthUserMutation) AddField(name string, value ent.Value) error {
+func (m *AuditLogMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown AuthUser numeric field %s", name)
+	return fmt.Errorf("unknown AuditLog numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *AuthUserMutation) ClearedFields() []string {
-	return nil
+func (m *AuditLogMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(auditlog.FieldDetails) {
+		fields = append(fields, auditlog.FieldDetails)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *AuthUserMutation) FieldCleared(name string) bool {
+func (m *AuditLogMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *AuthUserMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown AuthUser nullable field %s", name)
+func (m *AuditLogMutation) ClearField(name string) error {
+	switch name {
+	case auditlog.FieldDetails:
+		m.ClearDetails()
+		return nil
+	}
+	return fmt.Errorf("unknown AuditLog nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *AuthUserMutation) ResetField(name string) error {
+func (m *AuditLogMutation) ResetField(name string) error {
 	switch name {
-	case authuser.FieldUsername:
-		m.ResetUsername()
+	case auditlog.FieldOrgID:
+		m.ResetOrgID()
 		return nil
-	case authuser.FieldPassword:
-		m.ResetPassword()
+	case auditlog.FieldActorID:
+		m.ResetActorID()
 		return nil
-	case authuser.FieldAuthID:
-		m.ResetAuthID()
+	case auditlog.FieldAction:
+		m.ResetAction()
 		return nil
-	case authuser.FieldUserID:
-		m.ResetUserID()
+	case auditlog.FieldTargetType:
+		m.ResetTargetType()
+		return nil
+	case auditlog.FieldTargetID:
+		m.ResetTargetID()
+		return nil
+	case auditlog.FieldTimestamp:
+		m.ResetTimestamp()
+		return nil
+	case auditlog.FieldDetails:
+		m.ResetDetails()
 		return nil
 	}
-	return fmt.Errorf("unknown AuthUser field %s", name)
+	return fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *AuthUserMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.user != nil {
-		edges = append(edges, authuser.EdgeUser)
-	}
+func (m *AuditLogMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *AuthUserMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case authuser.EdgeUser:
-		if id := m.user; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *AuditLogMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *AuthUserMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+func (m *AuditLogMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *AuthUserMutation) RemovedIDs(name string) []ent.Value {
+func (m *AuditLogMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *AuthUserMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.cleareduser {
-		edges = append(edges, authuser.EdgeUser)
-	}
+func (m *AuditLogMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *AuthUserMutation) EdgeCleared(name string) bool {
-	switch name {
-	case authuser.EdgeUser:
-		return m.cleareduser
-	}
+func (m *AuditLogMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *AuthUserMutation) ClearEdge(name string) error {
-	switch name {
-	case authuser.EdgeUser:
-		m.ClearUser()
-		return nil
-	}
-	return fmt.Errorf("unknown AuthUser unique edge %s", name)
+func (m *AuditLogMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown AuditLog unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *AuthUserMutation) ResetEdge(name string) error {
-	switch name {
-	case authuser.EdgeUser:
-		m.ResetUser()
-		return nil
-	}
-	return fmt.Errorf("unknown AuthUser edge %s", name)
+func (m *AuditLogMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown AuditLog edge %s", name)
 }
 
-// DepartmentMutation represents an operation that mutates the Department nodes in the graph.
-type DepartmentMutation struct {
+// AuthUserMutation represents an operation that mutates the AuthUser nodes in the graph.
+type AuthUserMutation struct {
 	config
 	op            Op
 	typ           string
-	id            *uuid.UUID
-	name          *string
-	description   *string
+	id            *int
+	username      *string
+	password      *string
+	auth_id       *uuid.UUID
 	clearedFields map[string]struct{}
-	users         map[uuid.UUID]struct{}
-	removedusers  map[uuid.UUID]struct{}
-	clearedusers  bool
+	user          *uuid.UUID
+	cleareduser   bool
 	done          bool
-	oldValue      func(context.Context) (*Department, error)
-	predicates    []predicate.Department
+	oldValue      func(context.Context) (*AuthUser, error)
+	predicates    []predicate.AuthUser
 }
 
-var _ ent.Mutation = (*DepartmentMutation)(nil)
+var _ ent.Mutation = (*AuthUserMutation)(nil)
 
-// departmentOption allows management of the mutation configuration using functional options.
-type departmentOption func(*DepartmentMutation)
+// authuserOption allows management of the mutation configuration using functional options.
+type authuserOption func(*AuthUserMutation)
 
-// newDepartmentMutation creates new mutation for the Department entity.
-func newDepartmentMutation(c config, op Op, opts ...departmentOption) *DepartmentMutation {
-	m := &DepartmentMutation{
+// newAuthUserMutation creates new mutation for the AuthUser entity.
+func newAuthUserMutation(c config, op Op, opts ...authuserOption) *AuthUserMutation {
+	m := &AuthUserMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeDepartment,
+		typ:           TypeAuthUser,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -609,20 +754,20 @@ func newDepartmentMutation(c config, op Op, opts ...departmentOption) *Departmen
 	return m
 }
 
-// withDepartmentID sets the ID field of the mutation.
-func withDepartmentID(id uuid.UUID) departmentOption {
-	return func(m *DepartmentMutation) {
+// withAuthUserID sets the ID field of the mutation.
+func withAuthUserID(id int) authuserOption {
+	return func(m *AuthUserMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Department
+			value *AuthUser
 		)
-		m.oldValue = func(ctx context.Context) (*Department, error) {
+		m.oldValue = func(ctx context.Context) (*AuthUser, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Department.Get(ctx, id)
+					value, err = m.Client().AuthUser.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -631,10 +776,10 @@ func withDepartmentID(id uuid.UUID) departmentOption {
 	}
 }
 
-// withDepartment sets the old Department of the mutation.
-func withDepartment(node *Department) departmentOption {
-	return func(m *DepartmentMutation) {
-		m.oldValue = func(context.Context) (*Department, error) {
+// withAuthUser sets the old AuthUser of the mutation.
+func withAuthUser(node *AuthUser) authuserOption {
+	return func(m *AuthUserMutation) {
+		m.oldValue = func(context.Context) (*AuthUser, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -643,7 +788,7 @@ func withDepartment(node *Department) departmentOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m DepartmentMutation) Client() *Client {
+func (m AuthUserMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -651,7 +796,7 @@ func (m DepartmentMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m DepartmentMutation) Tx() (*Tx, error) {
+func (m AuthUserMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -660,15 +805,9 @@ func (m DepartmentMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Department entities.
-func (m *DepartmentMutation) SetID(id uuid.UUID) {
-	m.id = &id
-}
-
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *DepartmentMutation) ID() (id uuid.UUID, exists bool) {
+func (m *AuthUserMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -679,169 +818,201 @@ func (m *DepartmentMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *DepartmentMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *AuthUserMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []uuid.UUID{id}, nil
+			return []int{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Department.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().AuthUser.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetName sets the "name" field.
-func (m *DepartmentMutation) SetName(s string) {
-	m.name = &s
+// SetUsername sets the "username" field.
+func (m *AuthUserMutation) SetUsername(s string) {
+	m.username = &s
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *DepartmentMutation) Name() (r string, exists bool) {
-	v := m.name
+// Username returns the value of the "username" field in the mutation.
+func (m *AuthUserMutation) Username() (r string, exists bool) {
+	v := m.username
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the Department entity.
-// If the Department object wasn't provided to the builder, the object is fetched from the database.
+// OldUsername returns the old "username" field's value of the AuthUser entity.
+// If the AuthUser object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DepartmentMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *AuthUserMutation) OldUsername(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+		return v, errors.New("OldUsername is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+		return v, errors.New("OldUsername requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+		return v, fmt.Errorf("querying old value for OldUsername: %w", err)
 	}
-	return oldValue.Name, nil
+	return oldValue.Username, nil
 }
 
-// ResetName resets all changes to the "name" field.
-func (m *DepartmentMutation) ResetName() {
-	m.name = nil
+// ResetUsername resets all changes to the "username" field.
+func (m *AuthUserMutation) ResetUsername() {
+	m.username = nil
 }
 
-// SetDescription sets the "description" field.
-func (m *DepartmentMutation) SetDescription(s string) {
-	m.description = &s
+// SetPassword sets the "password" field.
+func (m *AuthUserMutation) SetPassword(s string) {
+	m.password = &s
 }
 
-// Description returns the value of the "description" field in the mutation.
-func (m *DepartmentMutation) Description() (r string, exists bool) {
-	v := m.description
+// Password returns the value of the "password" field in the mutation.
+func (m *AuthUserMutation) Password() (r string, exists bool) {
+	v := m.password
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDescription returns the old "description" field's value of the Department entity.
-// If the Department object wasn't provided to the builder, the object is fetched from the database.
+// OldPassword returns the old "password" field's value of the AuthUser entity.
+// If the AuthUser object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *DepartmentMutation) OldDescription(ctx context.Context) (v string, err error) {
+func (m *AuthUserMutation) OldPassword(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
+		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDescription requires an ID field in the mutation")
+		return v, errors.New("OldPassword requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
 	}
-	return oldValue.Description, nil
+	return oldValue.Password, nil
 }
 
-// ClearDescription clears the value of the "description" field.
-func (m *DepartmentMutation) ClearDescription() {
-	m.description = nil
-	m.clearedFields[department.FieldDescription] = struct{}{}
+// ResetPassword resets all changes to the "password" field.
+func (m *AuthUserMutation) ResetPassword() {
+	m.password = nil
 }
 
-// DescriptionCleared returns if the "description" field was cleared in this mutation.
-func (m *DepartmentMutation) DescriptionCleared() bool {
-	_, ok := m.clearedFields[department.FieldDescription]
-	return ok
+// SetAuthID sets the "auth_id" field.
+func (m *AuthUserMutation) SetAuthID(u uuid.UUID) {
+	m.auth_id = &u
 }
 
-// ResetDescription resets all changes to the "description" field.
-func (m *DepartmentMutation) ResetDescription() {
-	m.description = nil
-	delete(m.clearedFields, department.FieldDescription)
+// AuthID returns the value of the "auth_id" field in the mutation.
+func (m *AuthUserMutation) AuthID() (r uuid.UUID, exists bool) {
+	v := m.auth_id
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// AddUserIDs adds the "users" edge to the User entity by ids.
-func (m *DepartmentMutation) AddUserIDs(ids ...uuid.UUID) {
-	if m.users == nil {
-		m.users = make(map[uuid.UUID]struct{})
+// OldAuthID returns the old "auth_id" field's value of the AuthUser entity.
+// If the AuthUser object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthUserMutation) OldAuthID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAuthID is only allowed on UpdateOne operations")
 	}
-	for i := range ids {
-		m.users[ids[i]] = struct{}{}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAuthID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAuthID: %w", err)
 	}
+	return oldValue.AuthID, nil
 }
 
-// ClearUsers clears the "users" edge to the User entity.
-func (m *DepartmentMutation) ClearUsers() {
-	m.clearedusers = true
+// ResetAuthID resets all changes to the "auth_id" field.
+func (m *AuthUserMutation) ResetAuthID() {
+	m.auth_id = nil
 }
 
-// UsersCleared reports if the "users" edge to the User entity was cleared.
-func (m *DepartmentMutation) UsersCleared() bool {
-	return m.clearedusers
+// SetUserID sets the "user_id" field.
+func (m *AuthUserMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
 }
 
-// RemoveUserIDs removes the "users" edge to the User entity by IDs.
-func (m *DepartmentMutation) RemoveUserIDs(ids ...uuid.UUID) {
-	if m.removedusers == nil {
-		m.removedusers = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		delete(m.users, ids[i])
-		m.removedusers[ids[i]] = struct{}{}
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *AuthUserMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// RemovedUsers returns the removed IDs of the "users" edge to the User entity.
-func (m *DepartmentMutation) RemovedUsersIDs() (ids []uuid.UUID) {
-	for id := range m.removedusers {
-		ids = append(ids, id)
-	}
-	return
-}
+// OldUserID returns the old "user_id" field's value of the AuthUser entity.
+// If the AuthUser object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *AuthUserMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
 
-// UsersIDs returns the "users" edge IDs in the mutation.
-func (m *DepartmentMutation) UsersIDs() (ids []uuid.UUID) {
-	for id := range m.users {
-		ids = append(ids, id)
+// ResetUserID resets all changes to the "user_id" field.
+func (m *AuthUserMutation) ResetUserID() {
+	m.user = nil
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *AuthUserMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[authuser.FieldUserID] = struct{}{}
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *AuthUserMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *AuthUserMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetUsers resets all changes to the "users" edge.
-func (m *DepartmentMutation) ResetUsers() {
-	m.users = nil
-	m.clearedusers = false
-	m.removedusers = nil
+// ResetUser resets all changes to the "user" edge.
+func (m *AuthUserMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the DepartmentMutation builder.
-func (m *DepartmentMutation) Where(ps ...predicate.Department) {
+// Where appends a list predicates to the AuthUserMutation builder.
+func (m *AuthUserMutation) Where(ps ...predicate.AuthUser) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the DepartmentMutation builder. Using this method,
+// WhereP appends storage-level predicates to the AuthUserMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *DepartmentMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Department, len(ps))
+func (m *AuthUserMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.AuthUser, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -849,30 +1020,36 @@ func (m *DepartmentMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *DepartmentMutation) Op() Op {
+func (m *AuthUserMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *DepartmentMutation) SetOp(op Op) {
+func (m *AuthUserMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Department).
-func (m *DepartmentMutation) Type() string {
+// Type returns the node type of this mutation (AuthUser).
+func (m *AuthUserMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *DepartmentMutation) Fields() []string {
-	fields := make([]string, 0, 2)
-	if m.name != nil {
-		fields = append(fields, department.FieldName)
+func (m *AuthUserMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.username != nil {
+		fields = append(fields, authuser.FieldUsername)
 	}
-	if m.description != nil {
-		fields = append(fields, department.FieldDescription)
+	if m.password != nil {
+		fields = append(fields, authuser.FieldPassword)
+	}
+	if m.auth_id != nil {
+		fields = append(fields, authuser.FieldAuthID)
+	}
+	if m.user != nil {
+		fields = append(fields, authuser.FieldUserID)
 	}
 	return fields
 }
@@ -880,12 +1057,16 @@ func (m *DepartmentMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *DepartmentMutation) Field(name string) (ent.Value, bool) {
+func (m *AuthUserMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case department.FieldName:
-		return m.Name()
-	case department.FieldDescription:
-		return m.Description()
+	case authuser.FieldUsername:
+		return m.Username()
+	case authuser.FieldPassword:
+		return m.Password()
+	case authuser.FieldAuthID:
+		return m.AuthID()
+	case authuser.FieldUserID:
+		return m.UserID()
 	}
 	return nil, false
 }
@@ -893,221 +1074,222 @@ func (m *DepartmentMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *DepartmentMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *AuthUserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case department.FieldName:
-		return m.OldName(ctx)
-	case department.FieldDescription:
-		return m.OldDescription(ctx)
+	case authuser.FieldUsername:
+		return m.OldUsername(ctx)
+	case authuser.FieldPassword:
+		return m.OldPassword(ctx)
+	case authuser.FieldAuthID:
+		return m.OldAuthID(ctx)
+	case authuser.FieldUserID:
+		return m.OldUserID(ctx)
 	}
-	return nil, fmt.Errorf("unknown Department field %s", name)
+	return nil, fmt.Errorf("unknown AuthUser field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *DepartmentMutation) SetField(name string, value ent.Value) error {
+func (m *AuthUserMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case department.FieldName:
+	case authuser.FieldUsername:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetName(v)
+		m.SetUsername(v)
 		return nil
-	case department.FieldDescription:
+	case authuser.FieldPassword:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDescription(v)
+		m.SetPassword(v)
+		return nil
+	case authuser.FieldAuthID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAuthID(v)
+		return nil
+	case authuser.FieldUserID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Department field %s", name)
+	return fmt.Errorf("unknown AuthUser field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *DepartmentMutation) AddedFields() []string {
+func (m *AuthUserMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *DepartmentMutation) AddedField(name string) (ent.Value, bool) {
+func (m *AuthUserMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *DepartmentMutation) AddField(name string, value ent.Value) error {
+func (m *AuthUserMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Department numeric field %s", name)
+	return fmt.Errorf("unknown AuthUser numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *DepartmentMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(department.FieldDescription) {
-		fields = append(fields, department.FieldDescription)
-	}
-	return fields
+func (m *AuthUserMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *DepartmentMutation) FieldCleared(name string) bool {
+func (m *AuthUserMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *DepartmentMutation) ClearField(name string) error {
-	switch name {
-	case department.FieldDescription:
-		m.ClearDescription()
-		return nil
-	}
-	return fmt.Errorf("unknown Department nullable field %s", name)
+func (m *AuthUserMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown AuthUser nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *DepartmentMutation) ResetField(name string) error {
+func (m *AuthUserMutation) ResetField(name string) error {
 	switch name {
-	case department.FieldName:
-		m.ResetName()
+	case authuser.FieldUsername:
+		m.ResetUsername()
 		return nil
-	case department.FieldDescription:
-		m.ResetDescription()
+	case authuser.FieldPassword:
+		m.ResetPassword()
+		return nil
+	case authuser.FieldAuthID:
+		m.ResetAuthID()
+		return nil
+	case authuser.FieldUserID:
+		m.ResetUserID()
 		return nil
 	}
-	return fmt.Errorf("unknown Department field %s", name)
+	return fmt.Errorf("unknown AuthUser field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *DepartmentMutation) AddedEdges() []string {
+func (m *AuthUserMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.users != nil {
-		edges = append(edges, department.EdgeUsers)
+	if m.user != nil {
+		edges = append(edges, authuser.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *DepartmentMutation) AddedIDs(name string) []ent.Value {
+func (m *AuthUserMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case department.EdgeUsers:
-		ids := make([]ent.Value, 0, len(m.users))
-		for id := range m.users {
-			ids = append(ids, id)
+	case authuser.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *DepartmentMutation) RemovedEdges() []string {
+func (m *AuthUserMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.removedusers != nil {
-		edges = append(edges, department.EdgeUsers)
-	}
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *DepartmentMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case department.EdgeUsers:
-		ids := make([]ent.Value, 0, len(m.removedusers))
-		for id := range m.removedusers {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *AuthUserMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *DepartmentMutation) ClearedEdges() []string {
+func (m *AuthUserMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.clearedusers {
-		edges = append(edges, department.EdgeUsers)
+	if m.cleareduser {
+		edges = append(edges, authuser.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *DepartmentMutation) EdgeCleared(name string) bool {
+func (m *AuthUserMutation) EdgeCleared(name string) bool {
 	switch name {
-	case department.EdgeUsers:
-		return m.clearedusers
+	case authuser.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *DepartmentMutation) ClearEdge(name string) error {
+func (m *AuthUserMutation) ClearEdge(name string) error {
 	switch name {
+	case authuser.EdgeUser:
+		m.ClearUser()
+		return nil
 	}
-	return fmt.Errorf("unknown Department unique edge %s", name)
+	return fmt.Errorf("unknown AuthUser unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *DepartmentMutation) ResetEdge(name string) error {
+func (m *AuthUserMutation) ResetEdge(name string) error {
 	switch name {
-	case department.EdgeUsers:
-		m.ResetUsers()
+	case authuser.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown Department edge %s", name)
+	return fmt.Errorf("unknown AuthUser edge %s", name)
 }
 
-// UserMutation represents an operation that mutates the User nodes in the graph.
-type UserMutation struct {
+// DepartmentMutation represents an operation that mutates the Department nodes in the graph.
+type DepartmentMutation struct {
 	config
-	op                Op
-	typ               string
-	id                *uuid.UUID
-	first_name        *string
-	last_name         *string
-	middle_name       *string
-	picture_url       *string
-	suspended         *bool
-	role_id           *int32
-	addrole_id        *int32
-	clearedFields     map[string]struct{}
-	department        *uuid.UUID
-	cleareddepartment bool
-	auth              *int
-	clearedauth       bool
-	done              bool
-	oldValue          func(context.Context) (*User, error)
-	predicates        []predicate.User
+	op              Op
+	typ             string
+	id              *uuid.UUID
+	org_id          *uuid.UUID
+	name            *string
+	normalized_name *string
+	description     *string
+	clearedFields   map[string]struct{}
+	users           map[uuid.UUID]struct{}
+	removedusers    map[uuid.UUID]struct{}
+	clearedusers    bool
+	done            bool
+	oldValue        func(context.Context) (*Department, error)
+	predicates      []predicate.Department
 }
 
-var _ ent.Mutation = (*UserMutation)(nil)
+var _ ent.Mutation = (*DepartmentMutation)(nil)
 
-// userOption allows management of the mutation configuration using functional options.
-type userOption func(*UserMutation)
+// departmentOption allows management of the mutation configuration using functional options.
+type departmentOption func(*DepartmentMutation)
 
-// newUserMutation creates new mutation for the User entity.
-func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
-	m := &UserMutation{
+// newDepartmentMutation creates new mutation for the Department entity.
+func newDepartmentMutation(c config, op Op, opts ...departmentOption) *DepartmentMutation {
+	m := &DepartmentMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeUser,
+		typ:           TypeDepartment,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -1116,20 +1298,20 @@ func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
 	return m
 }
 
-// withUserID sets the ID field of the mutation.
-func withUserID(id uuid.UUID) userOption {
-	return func(m *UserMutation) {
+// withDepartmentID sets the ID field of the mutation.
+func withDepartmentID(id uuid.UUID) departmentOption {
+	return func(m *DepartmentMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *User
+			value *Department
 		)
-		m.oldValue = func(ctx context.Context) (*User, error) {
+		m.oldValue = func(ctx context.Context) (*Department, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().User.Get(ctx, id)
+					value, err = m.Client().Department.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -1138,10 +1320,10 @@ func withUserID(id uuid.UUID) userOption {
 	}
 }
 
-// withUser sets the old User of the mutation.
-func withUser(node *User) userOption {
-	return func(m *UserMutation) {
-		m.oldValue = func(context.Context) (*User, error) {
+// withDepartment sets the old Department of the mutation.
+func withDepartment(node *Department) departmentOption {
+	return func(m *DepartmentMutation) {
+		m.oldValue = func(context.Context) (*Department, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -1150,7 +1332,7 @@ func withUser(node *User) userOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m UserMutation) Client() *Client {
+func (m DepartmentMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -1158,7 +1340,7 @@ func (m UserMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m UserMutation) Tx() (*Tx, error) {
+func (m DepartmentMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -1168,14 +1350,14 @@ func (m UserMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of User entities.
-func (m *UserMutation) SetID(id uuid.UUID) {
+// operation is only accepted on creation of Department entities.
+func (m *DepartmentMutation) SetID(id uuid.UUID) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *UserMutation) ID() (id uuid.UUID, exists bool) {
+func (m *DepartmentMutation) ID() (id uuid.UUID, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -1186,7 +1368,7 @@ func (m *UserMutation) ID() (id uuid.UUID, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *UserMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+func (m *DepartmentMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -1195,385 +1377,4024 @@ func (m *UserMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Department.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetFirstName sets the "first_name" field.
-func (m *UserMutation) SetFirstName(s string) {
-	m.first_name = &s
+// SetOrgID sets the "org_id" field.
+func (m *DepartmentMutation) SetOrgID(u uuid.UUID) {
+	m.org_id = &u
 }
 
-// FirstName returns the value of the "first_name" field in the mutation.
-func (m *UserMutation) FirstName() (r string, exists bool) {
-	v := m.first_name
+// OrgID returns the value of the "org_id" field in the mutation.
+func (m *DepartmentMutation) OrgID() (r uuid.UUID, exists bool) {
+	v := m.org_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldFirstName returns the old "first_name" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldOrgID returns the old "org_id" field's value of the Department entity.
+// If the Department object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldFirstName(ctx context.Context) (v string, err error) {
+func (m *DepartmentMutation) OldOrgID(ctx context.Context) (v uuid.UUID, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFirstName is only allowed on UpdateOne operations")
+		return v, errors.New("OldOrgID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFirstName requires an ID field in the mutation")
+		return v, errors.New("OldOrgID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFirstName: %w", err)
+		return v, fmt.Errorf("querying old value for OldOrgID: %w", err)
 	}
-	return oldValue.FirstName, nil
+	return oldValue.OrgID, nil
 }
 
-// ResetFirstName resets all changes to the "first_name" field.
-func (m *UserMutation) ResetFirstName() {
-	m.first_name = nil
+// ResetOrgID resets all changes to the "org_id" field.
+func (m *DepartmentMutation) ResetOrgID() {
+	m.org_id = nil
 }
 
-// SetLastName sets the "last_name" field.
-func (m *UserMutation) SetLastName(s string) {
-	m.last_name = &s
+// SetName sets the "name" field.
+func (m *DepartmentMutation) SetName(s string) {
+	m.name = &s
 }
 
-// LastName returns the value of the "last_name" field in the mutation.
-func (m *UserMutation) LastName() (r string, exists bool) {
-	v := m.last_name
+// Name returns the value of the "name" field in the mutation.
+func (m *DepartmentMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldLastName returns the old "last_name" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the Department entity.
+// If the Department object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldLastName(ctx context.Context) (v string, err error) {
+func (m *DepartmentMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLastName is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLastName requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLastName: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.LastName, nil
+	return oldValue.Name, nil
 }
 
-// ResetLastName resets all changes to the "last_name" field.
-func (m *UserMutation) ResetLastName() {
-	m.last_name = nil
+// ResetName resets all changes to the "name" field.
+func (m *DepartmentMutation) ResetName() {
+	m.name = nil
 }
 
-// SetMiddleName sets the "middle_name" field.
-func (m *UserMutation) SetMiddleName(s string) {
-	m.middle_name = &s
+// SetNormalizedName sets the "normalized_name" field.
+func (m *DepartmentMutation) SetNormalizedName(s string) {
+	m.normalized_name = &s
 }
 
-// MiddleName returns the value of the "middle_name" field in the mutation.
-func (m *UserMutation) MiddleName() (r string, exists bool) {
-	v := m.middle_name
+// NormalizedName returns the value of the "normalized_name" field in the mutation.
+func (m *DepartmentMutation) NormalizedName() (r string, exists bool) {
+	v := m.normalized_name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldMiddleName returns the old "middle_name" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldNormalizedName returns the old "normalized_name" field's value of the Department entity.
+// If the Department object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldMiddleName(ctx context.Context) (v string, err error) {
+func (m *DepartmentMutation) OldNormalizedName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldMiddleName is only allowed on UpdateOne operations")
+		return v, errors.New("OldNormalizedName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldMiddleName requires an ID field in the mutation")
+		return v, errors.New("OldNormalizedName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldMiddleName: %w", err)
+		return v, fmt.Errorf("querying old value for OldNormalizedName: %w", err)
 	}
-	return oldValue.MiddleName, nil
+	return oldValue.NormalizedName, nil
 }
 
-// ResetMiddleName resets all changes to the "middle_name" field.
-func (m *UserMutation) ResetMiddleName() {
-	m.middle_name = nil
+// ClearNormalizedName clears the value of the "normalized_name" field.
+func (m *DepartmentMutation) ClearNormalizedName() {
+	m.normalized_name = nil
+	m.clearedFields[department.FieldNormalizedName] = struct{}{}
 }
 
-// SetPictureURL sets the "picture_url" field.
-func (m *UserMutation) SetPictureURL(s string) {
-	m.picture_url = &s
+// NormalizedNameCleared returns if the "normalized_name" field was cleared in this mutation.
+func (m *DepartmentMutation) NormalizedNameCleared() bool {
+	_, ok := m.clearedFields[department.FieldNormalizedName]
+	return ok
 }
 
-// PictureURL returns the value of the "picture_url" field in the mutation.
-func (m *UserMutation) PictureURL() (r string, exists bool) {
-	v := m.picture_url
+// ResetNormalizedName resets all changes to the "normalized_name" field.
+func (m *DepartmentMutation) ResetNormalizedName() {
+	m.normalized_name = nil
+	delete(m.clearedFields, department.FieldNormalizedName)
+}
+
+// SetDescription sets the "description" field.
+func (m *DepartmentMutation) SetDescription(s string) {
+	m.description = &s
+}
+
+// Description returns the value of the "description" field in the mutation.
+func (m *DepartmentMutation) Description() (r string, exists bool) {
+	v := m.description
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPictureURL returns the old "picture_url" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldDescription returns the old "description" field's value of the Department entity.
+// If the Department object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldPictureURL(ctx context.Context) (v string, err error) {
+func (m *DepartmentMutation) OldDescription(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPictureURL is only allowed on UpdateOne operations")
+		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPictureURL requires an ID field in the mutation")
+		return v, errors.New("OldDescription requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPictureURL: %w", err)
+		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
 	}
-	return oldValue.PictureURL, nil
+	return oldValue.Description, nil
 }
 
-// ClearPictureURL clears the value of the "picture_url" field.
-func (m *UserMutation) ClearPictureURL() {
-	m.picture_url = nil
-	m.clearedFields[user.FieldPictureURL] = struct{}{}
+// ClearDescription clears the value of the "description" field.
+func (m *DepartmentMutation) ClearDescription() {
+	m.description = nil
+	m.clearedFields[department.FieldDescription] = struct{}{}
 }
 
-// PictureURLCleared returns if the "picture_url" field was cleared in this mutation.
-func (m *UserMutation) PictureURLCleared() bool {
-	_, ok := m.clearedFields[user.FieldPictureURL]
+// DescriptionCleared returns if the "description" field was cleared in this mutation.
+func (m *DepartmentMutation) DescriptionCleared() bool {
+	_, ok := m.clearedFields[department.FieldDescription]
 	return ok
 }
 
-// ResetPictureURL resets all changes to the "picture_url" field.
-func (m *UserMutation) ResetPictureURL() {
-	m.picture_url = nil
-	delete(m.clearedFields, user.FieldPictureURL)
-}
-
-// SetSuspended sets the "suspended" field.
-func (m *UserMutation) SetSuspended(b bool) {
-	m.suspended = &b
-}
-
-// Suspended returns the value of the "suspended" field in the mutation.
-func (m *UserMutation) Suspended() (r bool, exists bool) {
-	v := m.suspended
-	if v == nil {
-		return
-	}
-	return *v, true
+// ResetDescription resets all changes to the "description" field.
+func (m *DepartmentMutation) ResetDescription() {
+	m.description = nil
+	delete(m.clearedFields, department.FieldDescription)
 }
 
-// OldSuspended returns the old "suspended" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldSuspended(ctx context.Context) (v bool, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSuspended is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSuspended requires an ID field in the mutation")
+// AddUserIDs adds the "users" edge to the User entity by ids.
+func (m *DepartmentMutation) AddUserIDs(ids ...uuid.UUID) {
+	if m.users == nil {
+		m.users = make(map[uuid.UUID]struct{})
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSuspended: %w", err)
+	for i := range ids {
+		m.users[ids[i]] = struct{}{}
 	}
-	return oldValue.Suspended, nil
 }
 
-// ResetSuspended resets all changes to the "suspended" field.
-func (m *UserMutation) ResetSuspended() {
-	m.suspended = nil
+// ClearUsers clears the "users" edge to the User entity.
+func (m *DepartmentMutation) ClearUsers() {
+	m.clearedusers = true
 }
 
-// SetDepartmentID sets the "department_id" field.
-func (m *UserMutation) SetDepartmentID(u uuid.UUID) {
-	m.department = &u
+// UsersCleared reports if the "users" edge to the User entity was cleared.
+func (m *DepartmentMutation) UsersCleared() bool {
+	return m.clearedusers
 }
 
-// DepartmentID returns the value of the "department_id" field in the mutation.
-func (m *UserMutation) DepartmentID() (r uuid.UUID, exists bool) {
-	v := m.department
-	if v == nil {
-		return
+// RemoveUserIDs removes the "users" edge to the User entity by IDs.
+func (m *DepartmentMutation) RemoveUserIDs(ids ...uuid.UUID) {
+	if m.removedusers == nil {
+		m.removedusers = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.users, ids[i])
+		m.removedusers[ids[i]] = struct{}{}
 	}
-	return *v, true
 }
 
-// OldDepartmentID returns the old "department_id" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldDepartmentID(ctx context.Context) (v *uuid.UUID, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDepartmentID is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDepartmentID requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDepartmentID: %w", err)
+// RemovedUsers returns the removed IDs of the "users" edge to the User entity.
+func (m *DepartmentMutation) RemovedUsersIDs() (ids []uuid.UUID) {
+	for id := range m.removedusers {
+		ids = append(ids, id)
 	}
-	return oldValue.DepartmentID, nil
+	return
 }
 
-// ClearDepartmentID clears the value of the "department_id" field.
-func (m *UserMutation) ClearDepartmentID() {
-	m.department = nil
-	m.clearedFields[user.FieldDepartmentID] = struct{}{}
+// UsersIDs returns the "users" edge IDs in the mutation.
+func (m *DepartmentMutation) UsersIDs() (ids []uuid.UUID) {
+	for id := range m.users {
+		ids = append(ids, id)
+	}
+	return
 }
 
-// DepartmentIDCleared returns if the "department_id" field was cleared in this mutation.
-func (m *UserMutation) DepartmentIDCleared() bool {
+// ResetUsers resets all changes to the "users" edge.
+func (m *DepartmentMutation) ResetUsers() {
+	m.users = nil
+	m.clearedusers = false
+	m.removedusers = nil
+}
+
+// Where appends a list predicates to the DepartmentMutation builder.
+func (m *DepartmentMutation) Where(ps ...predicate.Department) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the DepartmentMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *DepartmentMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Department, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *DepartmentMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *DepartmentMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Department).
+func (m *DepartmentMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *DepartmentMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.org_id != nil {
+		fields = append(fields, department.FieldOrgID)
+	}
+	if m.name != nil {
+		fields = append(fields, department.FieldName)
+	}
+	if m.normalized_name != nil {
+		fields = append(fields, department.FieldNormalizedName)
+	}
+	if m.description != nil {
+		fields = append(fields, department.FieldDescription)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *DepartmentMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case department.FieldOrgID:
+		return m.OrgID()
+	case department.FieldName:
+		return m.Name()
+	case department.FieldNormalizedName:
+		return m.NormalizedName()
+	case department.FieldDescription:
+		return m.Description()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *DepartmentMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case department.FieldOrgID:
+		return m.OldOrgID(ctx)
+	case department.FieldName:
+		return m.OldName(ctx)
+	case department.FieldNormalizedName:
+		return m.OldNormalizedName(ctx)
+	case department.FieldDescription:
+		return m.OldDescription(ctx)
+	}
+	return nil, fmt.Errorf("unknown Department field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *DepartmentMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case department.FieldOrgID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOrgID(v)
+		return nil
+	case department.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case department.FieldNormalizedName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNormalizedName(v)
+		return nil
+	case department.FieldDescription:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDescription(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Department field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *DepartmentMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *DepartmentMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *DepartmentMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Department numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *DepartmentMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(department.FieldNormalizedName) {
+		fields = append(fields, department.FieldNormalizedName)
+	}
+	if m.FieldCleared(department.FieldDescription) {
+		fields = append(fields, department.FieldDescription)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *DepartmentMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *DepartmentMutation) ClearField(name string) error {
+	switch name {
+	case department.FieldNormalizedName:
+		m.ClearNormalizedName()
+		return nil
+	case department.FieldDescription:
+		m.ClearDescription()
+		return nil
+	}
+	return fmt.Errorf("unknown Department nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *DepartmentMutation) ResetField(name string) error {
+	switch name {
+	case department.FieldOrgID:
+		m.ResetOrgID()
+		return nil
+	case department.FieldName:
+		m.ResetName()
+		return nil
+	case department.FieldNormalizedName:
+		m.ResetNormalizedName()
+		return nil
+	case department.FieldDescription:
+		m.ResetDescription()
+		return nil
+	}
+	return fmt.Errorf("unknown Department field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *DepartmentMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.users != nil {
+		edges = append(edges, department.EdgeUsers)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *DepartmentMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case department.EdgeUsers:
+		ids := make([]ent.Value, 0, len(m.users))
+		for id := range m.users {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *DepartmentMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.removedusers != nil {
+		edges = append(edges, department.EdgeUsers)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *DepartmentMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case department.EdgeUsers:
+		ids := make([]ent.Value, 0, len(m.removedusers))
+		for id := range m.removedusers {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *DepartmentMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedusers {
+		edges = append(edges, department.EdgeUsers)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *DepartmentMutation) EdgeCleared(name string) bool {
+	switch name {
+	case department.EdgeUsers:
+		return m.clearedusers
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *DepartmentMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Department unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *DepartmentMutation) ResetEdge(name string) error {
+	switch name {
+	case department.EdgeUsers:
+		m.ResetUsers()
+		return nil
+	}
+	return fmt.Errorf("unknown Department edge %s", name)
+}
+
+// EmploymentEventMutation represents an operation that mutates the EmploymentEvent nodes in the graph.
+type EmploymentEventMutation struct {
+	config
+	op                Op
+	typ               string
+	id                *uuid.UUID
+	actor_id          *uuid.UUID
+	old_role_id       *int32
+	addold_role_id    *int32
+	new_role_id       *int32
+	addnew_role_id    *int32
+	old_department_id *uuid.UUID
+	new_department_id *uuid.UUID
+	timestamp         *time.Time
+	clearedFields     map[string]struct{}
+	user              *uuid.UUID
+	cleareduser       bool
+	done              bool
+	oldValue          func(context.Context) (*EmploymentEvent, error)
+	predicates        []predicate.EmploymentEvent
+}
+
+var _ ent.Mutation = (*EmploymentEventMutation)(nil)
+
+// employmenteventOption allows management of the mutation configuration using functional options.
+type employmenteventOption func(*EmploymentEventMutation)
+
+// newEmploymentEventMutation creates new mutation for the EmploymentEvent entity.
+func newEmploymentEventMutation(c config, op Op, opts ...employmenteventOption) *EmploymentEventMutation {
+	m := &EmploymentEventMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeEmploymentEvent,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withEmploymentEventID sets the ID field of the mutation.
+func withEmploymentEventID(id uuid.UUID) employmenteventOption {
+	return func(m *EmploymentEventMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *EmploymentEvent
+		)
+		m.oldValue = func(ctx context.Context) (*EmploymentEvent, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().EmploymentEvent.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withEmploymentEvent sets the old EmploymentEvent of the mutation.
+func withEmploymentEvent(node *EmploymentEvent) employmenteventOption {
+	return func(m *EmploymentEventMutation) {
+		m.oldValue = func(context.Context) (*EmploymentEvent, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m EmploymentEventMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m EmploymentEventMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of EmploymentEvent entities.
+func (m *EmploymentEventMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *EmploymentEventMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *EmploymentEventMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().EmploymentEvent.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetUserID sets the "user_id" field.
+func (m *EmploymentEventMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *EmploymentEventMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUserID returns the old "user_id" field's value of the EmploymentEvent entity.
+// If the EmploymentEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmploymentEventMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUserID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
+	}
+	return oldValue.UserID, nil
+}
+
+// ResetUserID resets all changes to the "user_id" field.
+func (m *EmploymentEventMutation) ResetUserID() {
+	m.user = nil
+}
+
+// SetActorID sets the "actor_id" field.
+func (m *EmploymentEventMutation) SetActorID(u uuid.UUID) {
+	m.actor_id = &u
+}
+
+// ActorID returns the value of the "actor_id" field in the mutation.
+func (m *EmploymentEventMutation) ActorID() (r uuid.UUID, exists bool) {
+	v := m.actor_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldActorID returns the old "actor_id" field's value of the EmploymentEvent entity.
+// If the EmploymentEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmploymentEventMutation) OldActorID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldActorID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldActorID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldActorID: %w", err)
+	}
+	return oldValue.ActorID, nil
+}
+
+// ResetActorID resets all changes to the "actor_id" field.
+func (m *EmploymentEventMutation) ResetActorID() {
+	m.actor_id = nil
+}
+
+// SetOldRoleID sets the "old_role_id" field.
+func (m *EmploymentEventMutation) SetOldRoleID(i int32) {
+	m.old_role_id = &i
+	m.addold_role_id = nil
+}
+
+// OldRoleID returns the value of the "old_role_id" field in the mutation.
+func (m *EmploymentEventMutation) OldRoleID() (r int32, exists bool) {
+	v := m.old_role_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOldRoleID returns the old "old_role_id" field's value of the EmploymentEvent entity.
+// If the EmploymentEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmploymentEventMutation) OldOldRoleID(ctx context.Context) (v int32, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOldRoleID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOldRoleID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOldRoleID: %w", err)
+	}
+	return oldValue.OldRoleID, nil
+}
+
+// AddOldRoleID adds i to the "old_role_id" field.
+func (m *EmploymentEventMutation) AddOldRoleID(i int32) {
+	if m.addold_role_id != nil {
+		*m.addold_role_id += i
+	} else {
+		m.addold_role_id = &i
+	}
+}
+
+// AddedOldRoleID returns the value that was added to the "old_role_id" field in this mutation.
+func (m *EmploymentEventMutation) AddedOldRoleID() (r int32, exists bool) {
+	v := m.addold_role_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetOldRoleID resets all changes to the "old_role_id" field.
+func (m *EmploymentEventMutation) ResetOldRoleID() {
+	m.old_role_id = nil
+	m.addold_role_id = nil
+}
+
+// SetNewRoleID sets the "new_role_id" field.
+func (m *EmploymentEventMutation) SetNewRoleID(i int32) {
+	m.new_role_id = &i
+	m.addnew_role_id = nil
+}
+
+// NewRoleID returns the value of the "new_role_id" field in the mutation.
+func (m *EmploymentEventMutation) NewRoleID() (r int32, exists bool) {
+	v := m.new_role_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNewRoleID returns the old "new_role_id" field's value of the EmploymentEvent entity.
+// If the EmploymentEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmploymentEventMutation) OldNewRoleID(ctx context.Context) (v int32, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNewRoleID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNewRoleID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNewRoleID: %w", err)
+	}
+	return oldValue.NewRoleID, nil
+}
+
+// AddNewRoleID adds i to the "new_role_id" field.
+func (m *EmploymentEventMutation) AddNewRoleID(i int32) {
+	if m.addnew_role_id != nil {
+		*m.addnew_role_id += i
+	} else {
+		m.addnew_role_id = &i
+	}
+}
+
+// AddedNewRoleID returns the value that was added to the "new_role_id" field in this mutation.
+func (m *EmploymentEventMutation) AddedNewRoleID() (r int32, exists bool) {
+	v := m.addnew_role_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetNewRoleID resets all changes to the "new_role_id" field.
+func (m *EmploymentEventMutation) ResetNewRoleID() {
+	m.new_role_id = nil
+	m.addnew_role_id = nil
+}
+
+// SetOldDepartmentID sets the "old_department_id" field.
+func (m *EmploymentEventMutation) SetOldDepartmentID(u uuid.UUID) {
+	m.old_department_id = &u
+}
+
+// OldDepartmentID returns the value of the "old_department_id" field in the mutation.
+func (m *EmploymentEventMutation) OldDepartmentID() (r uuid.UUID, exists bool) {
+	v := m.old_department_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOldDepartmentID returns the old "old_department_id" field's value of the EmploymentEvent entity.
+// If the EmploymentEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmploymentEventMutation) OldOldDepartmentID(ctx context.Context) (v *uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOldDepartmentID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOldDepartmentID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOldDepartmentID: %w", err)
+	}
+	return oldValue.OldDepartmentID, nil
+}
+
+// ClearOldDepartmentID clears the value of the "old_department_id" field.
+func (m *EmploymentEventMutation) ClearOldDepartmentID() {
+	m.old_department_id = nil
+	m.clearedFields[employmentevent.FieldOldDepartmentID] = struct{}{}
+}
+
+// OldDepartmentIDCleared returns if the "old_department_id" field was cleared in this mutation.
+func (m *EmploymentEventMutation) OldDepartmentIDCleared() bool {
+	_, ok := m.clearedFields[employmentevent.FieldOldDepartmentID]
+	return ok
+}
+
+// ResetOldDepartmentID resets all changes to the "old_department_id" field.
+func (m *EmploymentEventMutation) ResetOldDepartmentID() {
+	m.old_department_id = nil
+	delete(m.clearedFields, employmentevent.FieldOldDepartmentID)
+}
+
+// SetNewDepartmentID sets the "new_department_id" field.
+func (m *EmploymentEventMutation) SetNewDepartmentID(u uuid.UUID) {
+	m.new_department_id = &u
+}
+
+// NewDepartmentID returns the value of the "new_department_id" field in the mutation.
+func (m *EmploymentEventMutation) NewDepartmentID() (r uuid.UUID, exists bool) {
+	v := m.new_department_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNewDepartmentID returns the old "new_department_id" field's value of the EmploymentEvent entity.
+// If the EmploymentEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmploymentEventMutation) OldNewDepartmentID(ctx context.Context) (v *uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNewDepartmentID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNewDepartmentID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNewDepartmentID: %w", err)
+	}
+	return oldValue.NewDepartmentID, nil
+}
+
+// ClearNewDepartmentID clears the value of the "new_department_id" field.
+func (m *EmploymentEventMutation) ClearNewDepartmentID() {
+	m.new_department_id = nil
+	m.clearedFields[employmentevent.FieldNewDepartmentID] = struct{}{}
+}
+
+// NewDepartmentIDCleared returns if the "new_department_id" field was cleared in this mutation.
+func (m *EmploymentEventMutation) NewDepartmentIDCleared() bool {
+	_, ok := m.clearedFields[employmentevent.FieldNewDepartmentID]
+	return ok
+}
+
+// ResetNewDepartmentID resets all changes to the "new_department_id" field.
+func (m *EmploymentEventMutation) ResetNewDepartmentID() {
+	m.new_department_id = nil
+	delete(m.clearedFields, employmentevent.FieldNewDepartmentID)
+}
+
+// SetTimestamp sets the "timestamp" field.
+func (m *EmploymentEventMutation) SetTimestamp(t time.Time) {
+	m.timestamp = &t
+}
+
+// Timestamp returns the value of the "timestamp" field in the mutation.
+func (m *EmploymentEventMutation) Timestamp() (r time.Time, exists bool) {
+	v := m.timestamp
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTimestamp returns the old "timestamp" field's value of the EmploymentEvent entity.
+// If the EmploymentEvent object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EmploymentEventMutation) OldTimestamp(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTimestamp is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTimestamp requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTimestamp: %w", err)
+	}
+	return oldValue.Timestamp, nil
+}
+
+// ResetTimestamp resets all changes to the "timestamp" field.
+func (m *EmploymentEventMutation) ResetTimestamp() {
+	m.timestamp = nil
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (m *EmploymentEventMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[employmentevent.FieldUserID] = struct{}{}
+}
+
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *EmploymentEventMutation) UserCleared() bool {
+	return m.cleareduser
+}
+
+// UserIDs returns the "user" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// UserID instead. It exists only for internal usage by the builders.
+func (m *EmploymentEventMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetUser resets all changes to the "user" edge.
+func (m *EmploymentEventMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
+}
+
+// Where appends a list predicates to the EmploymentEventMutation builder.
+func (m *EmploymentEventMutation) Where(ps ...predicate.EmploymentEvent) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the EmploymentEventMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *EmploymentEventMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.EmploymentEvent, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *EmploymentEventMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *EmploymentEventMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (EmploymentEvent).
+func (m *EmploymentEventMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *EmploymentEventMutation) Fields() []string {
+	fields := make([]string, 0, 7)
+	if m.user != nil {
+		fields = append(fields, employmentevent.FieldUserID)
+	}
+	if m.actor_id != nil {
+		fields = append(fields, employmentevent.FieldActorID)
+	}
+	if m.old_role_id != nil {
+		fields = append(fields, employmentevent.FieldOldRoleID)
+	}
+	if m.new_role_id != nil {
+		fields = append(fields, employmentevent.FieldNewRoleID)
+	}
+	if m.old_department_id != nil {
+		fields = append(fields, employmentevent.FieldOldDepartmentID)
+	}
+	if m.new_department_id != nil {
+		fields = append(fields, employmentevent.FieldNewDepartmentID)
+	}
+	if m.timestamp != nil {
+		fields = append(fields, employmentevent.FieldTimestamp)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *EmploymentEventMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case employmentevent.FieldUserID:
+		return m.UserID()
+	case employmentevent.FieldActorID:
+		return m.ActorID()
+	case employmentevent.FieldOldRoleID:
+		return m.OldRoleID()
+	case employmentevent.FieldNewRoleID:
+		return m.NewRoleID()
+	case employmentevent.FieldOldDepartmentID:
+		return m.OldDepartmentID()
+	case employmentevent.FieldNewDepartmentID:
+		return m.NewDepartmentID()
+	case employmentevent.FieldTimestamp:
+		return m.Timestamp()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *EmploymentEventMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case employmentevent.FieldUserID:
+		return m.OldUserID(ctx)
+	case employmentevent.FieldActorID:
+		return m.OldActorID(ctx)
+	case employmentevent.FieldOldRoleID:
+		return m.OldOldRoleID(ctx)
+	case employmentevent.FieldNewRoleID:
+		return m.OldNewRoleID(ctx)
+	case employmentevent.FieldOldDepartmentID:
+		return m.OldOldDepartmentID(ctx)
+	case employmentevent.FieldNewDepartmentID:
+		return m.OldNewDepartmentID(ctx)
+	case employmentevent.FieldTimestamp:
+		return m.OldTimestamp(ctx)
+	}
+	return nil, fmt.Errorf("unknown EmploymentEvent field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *EmploymentEventMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case employmentevent.FieldUserID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUserID(v)
+		return nil
+	case employmentevent.FieldActorID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetActorID(v)
+		return nil
+	case employmentevent.FieldOldRoleID:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOldRoleID(v)
+		return nil
+	case employmentevent.FieldNewRoleID:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNewRoleID(v)
+		return nil
+	case employmentevent.FieldOldDepartmentID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOldDepartmentID(v)
+		return nil
+	case employmentevent.FieldNewDepartmentID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNewDepartmentID(v)
+		return nil
+	case employmentevent.FieldTimestamp:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTimestamp(v)
+		return nil
+	}
+	return fmt.Errorf("unknown EmploymentEvent field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *EmploymentEventMutation) AddedFields() []string {
+	var fields []string
+	if m.addold_role_id != nil {
+		fields = append(fields, employmentevent.FieldOldRoleID)
+	}
+	if m.addnew_role_id != nil {
+		fields = append(fields, employmentevent.FieldNewRoleID)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *EmploymentEventMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case employmentevent.FieldOldRoleID:
+		return m.AddedOldRoleID()
+	case employmentevent.FieldNewRoleID:
+		return m.AddedNewRoleID()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *EmploymentEventMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case employmentevent.FieldOldRoleID:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddOldRoleID(v)
+		return nil
+	case employmentevent.FieldNewRoleID:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddNewRoleID(v)
+		return nil
+	}
+	return fmt.Errorf("unknown EmploymentEvent numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *EmploymentEventMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(employmentevent.FieldOldDepartmentID) {
+		fields = append(fields, employmentevent.FieldOldDepartmentID)
+	}
+	if m.FieldCleared(employmentevent.FieldNewDepartmentID) {
+		fields = append(fields, employmentevent.FieldNewDepartmentID)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *EmploymentEventMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *EmploymentEventMutation) ClearField(name string) error {
+	switch name {
+	case employmentevent.FieldOldDepartmentID:
+		m.ClearOldDepartmentID()
+		return nil
+	case employmentevent.FieldNewDepartmentID:
+		m.ClearNewDepartmentID()
+		return nil
+	}
+	return fmt.Errorf("unknown EmploymentEvent nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *EmploymentEventMutation) ResetField(name string) error {
+	switch name {
+	case employmentevent.FieldUserID:
+		m.ResetUserID()
+		return nil
+	case employmentevent.FieldActorID:
+		m.ResetActorID()
+		return nil
+	case employmentevent.FieldOldRoleID:
+		m.ResetOldRoleID()
+		return nil
+	case employmentevent.FieldNewRoleID:
+		m.ResetNewRoleID()
+		return nil
+	case employmentevent.FieldOldDepartmentID:
+		m.ResetOldDepartmentID()
+		return nil
+	case employmentevent.FieldNewDepartmentID:
+		m.ResetNewDepartmentID()
+		return nil
+	case employmentevent.FieldTimestamp:
+		m.ResetTimestamp()
+		return nil
+	}
+	return fmt.Errorf("unknown EmploymentEvent field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *EmploymentEventMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, employmentevent.EdgeUser)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *EmploymentEventMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case employmentevent.EdgeUser:
+		if id := m.user; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *EmploymentEventMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *EmploymentEventMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *EmploymentEventMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, employmentevent.EdgeUser)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *EmploymentEventMutation) EdgeCleared(name string) bool {
+	switch name {
+	case employmentevent.EdgeUser:
+		return m.cleareduser
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *EmploymentEventMutation) ClearEdge(name string) error {
+	switch name {
+	case employmentevent.EdgeUser:
+		m.ClearUser()
+		return nil
+	}
+	return fmt.Errorf("unknown EmploymentEvent unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *EmploymentEventMutation) ResetEdge(name string) error {
+	switch name {
+	case employmentevent.EdgeUser:
+		m.ResetUser()
+		return nil
+	}
+	return fmt.Errorf("unknown EmploymentEvent edge %s", name)
+}
+
+// SchemaVersionMutation represents an operation that mutates the SchemaVersion nodes in the graph.
+type SchemaVersionMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	hash          *string
+	applied_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*SchemaVersion, error)
+	predicates    []predicate.SchemaVersion
+}
+
+var _ ent.Mutation = (*SchemaVersionMutation)(nil)
+
+// schemaversionOption allows management of the mutation configuration using functional options.
+type schemaversionOption func(*SchemaVersionMutation)
+
+// newSchemaVersionMutation creates new mutation for the SchemaVersion entity.
+func newSchemaVersionMutation(c config, op Op, opts ...schemaversionOption) *SchemaVersionMutation {
+	m := &SchemaVersionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeSchemaVersion,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withSchemaVersionID sets the ID field of the mutation.
+func withSchemaVersionID(id int) schemaversionOption {
+	return func(m *SchemaVersionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *SchemaVersion
+		)
+		m.oldValue = func(ctx context.Context) (*SchemaVersion, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().SchemaVersion.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withSchemaVersion sets the old SchemaVersion of the mutation.
+func withSchemaVersion(node *SchemaVersion) schemaversionOption {
+	return func(m *SchemaVersionMutation) {
+		m.oldValue = func(context.Context) (*SchemaVersion, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m SchemaVersionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m SchemaVersionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SchemaVersionMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SchemaVersionMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().SchemaVersion.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetHash sets the "hash" field.
+func (m *SchemaVersionMutation) SetHash(s string) {
+	m.hash = &s
+}
+
+// Hash returns the value of the "hash" field in the mutation.
+func (m *SchemaVersionMutation) Hash() (r string, exists bool) {
+	v := m.hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHash returns the old "hash" field's value of the SchemaVersion entity.
+// If the SchemaVersion object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SchemaVersionMutation) OldHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHash: %w", err)
+	}
+	return oldValue.Hash, nil
+}
+
+// ResetHash resets all changes to the "hash" field.
+func (m *SchemaVersionMutation) ResetHash() {
+	m.hash = nil
+}
+
+// SetAppliedAt sets the "applied_at" field.
+func (m *SchemaVersionMutation) SetAppliedAt(t time.Time) {
+	m.applied_at = &t
+}
+
+// AppliedAt returns the value of the "applied_at" field in the mutation.
+func (m *SchemaVersionMutation) AppliedAt() (r time.Time, exists bool) {
+	v := m.applied_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAppliedAt returns the old "applied_at" field's value of the SchemaVersion entity.
+// If the SchemaVersion object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SchemaVersionMutation) OldAppliedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAppliedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAppliedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAppliedAt: %w", err)
+	}
+	return oldValue.AppliedAt, nil
+}
+
+// ResetAppliedAt resets all changes to the "applied_at" field.
+func (m *SchemaVersionMutation) ResetAppliedAt() {
+	m.applied_at = nil
+}
+
+// Where appends a list predicates to the SchemaVersionMutation builder.
+func (m *SchemaVersionMutation) Where(ps ...predicate.SchemaVersion) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the SchemaVersionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *SchemaVersionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.SchemaVersion, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *SchemaVersionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *SchemaVersionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (SchemaVersion).
+func (m *SchemaVersionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *SchemaVersionMutation) Fields() []string {
+	fields := make([]string, 0, 2)
+	if m.hash != nil {
+		fields = append(fields, schemaversion.FieldHash)
+	}
+	if m.applied_at != nil {
+		fields = append(fields, schemaversion.FieldAppliedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *SchemaVersionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case schemaversion.FieldHash:
+		return m.Hash()
+	case schemaversion.FieldAppliedAt:
+		return m.AppliedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *SchemaVersionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case schemaversion.FieldHash:
+		return m.OldHash(ctx)
+	case schemaversion.FieldAppliedAt:
+		return m.OldAppliedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown SchemaVersion field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SchemaVersionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case schemaversion.FieldHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHash(v)
+		return nil
+	case schemaversion.FieldAppliedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAppliedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SchemaVersion field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *SchemaVersionMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *SchemaVersionMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SchemaVersionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown SchemaVersion numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *SchemaVersionMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *SchemaVersionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *SchemaVersionMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown SchemaVersion nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *SchemaVersionMutation) ResetField(name string) error {
+	switch name {
+	case schemaversion.FieldHash:
+		m.ResetHash()
+		return nil
+	case schemaversion.FieldAppliedAt:
+		m.ResetAppliedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown SchemaVersion field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *SchemaVersionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *SchemaVersionMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *SchemaVersionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *SchemaVersionMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *SchemaVersionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *SchemaVersionMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *SchemaVersionMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown SchemaVersion unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *SchemaVersionMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown SchemaVersion edge %s", name)
+}
+
+// UserMutation represents an operation that mutates the User nodes in the graph.
+type UserMutation struct {
+	config
+	op                       Op
+	typ                      string
+	id                       *uuid.UUID
+	org_id                   *uuid.UUID
+	first_name               *string
+	last_name                *string
+	middle_name              *string
+	picture_url              *string
+	email                    *string
+	suspended                *bool
+	role_id                  *int32
+	addrole_id               *int32
+	deleted_at               *time.Time
+	version                  *int
+	addversion               *int
+	subdivision              *string
+	job_title                *string
+	employment_rate          *float64
+	addemployment_rate       *float64
+	personnel_category       *int32
+	addpersonnel_category    *int32
+	employment_type          *int32
+	addemployment_type       *int32
+	academic_degree          *int32
+	addacademic_degree       *int32
+	academic_title           *string
+	honors                   *string
+	category                 *string
+	date_of_employment       *time.Time
+	unemployment_date        *time.Time
+	clearedFields            map[string]struct{}
+	department               *uuid.UUID
+	cleareddepartment        bool
+	auth                     *int
+	clearedauth              bool
+	permissions              map[int]struct{}
+	removedpermissions       map[int]struct{}
+	clearedpermissions       bool
+	employment_events        map[uuid.UUID]struct{}
+	removedemployment_events map[uuid.UUID]struct{}
+	clearedemployment_events bool
+	done                     bool
+	oldValue                 func(context.Context) (*User, error)
+	predicates               []predicate.User
+}
+
+var _ ent.Mutation = (*UserMutation)(nil)
+
+// userOption allows management of the mutation configuration using functional options.
+type userOption func(*UserMutation)
+
+// newUserMutation creates new mutation for the User entity.
+func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
+	m := &UserMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUser,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withUserID sets the ID field of the mutation.
+func withUserID(id uuid.UUID) userOption {
+	return func(m *UserMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *User
+		)
+		m.oldValue = func(ctx context.Context) (*User, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().User.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withUser sets the old User of the mutation.
+func withUser(node *User) userOption {
+	return func(m *UserMutation) {
+		m.oldValue = func(context.Context) (*User, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UserMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UserMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of User entities.
+func (m *UserMutation) SetID(id uuid.UUID) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UserMutation) ID() (id uuid.UUID, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UserMutation) IDs(ctx context.Context) ([]uuid.UUID, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []uuid.UUID{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetOrgID sets the "org_id" field.
+func (m *UserMutation) SetOrgID(u uuid.UUID) {
+	m.org_id = &u
+}
+
+// OrgID returns the value of the "org_id" field in the mutation.
+func (m *UserMutation) OrgID() (r uuid.UUID, exists bool) {
+	v := m.org_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOrgID returns the old "org_id" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldOrgID(ctx context.Context) (v uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOrgID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOrgID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOrgID: %w", err)
+	}
+	return oldValue.OrgID, nil
+}
+
+// ResetOrgID resets all changes to the "org_id" field.
+func (m *UserMutation) ResetOrgID() {
+	m.org_id = nil
+}
+
+// SetFirstName sets the "first_name" field.
+func (m *UserMutation) SetFirstName(s string) {
+	m.first_name = &s
+}
+
+// FirstName returns the value of the "first_name" field in the mutation.
+func (m *UserMutation) FirstName() (r string, exists bool) {
+	v := m.first_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFirstName returns the old "first_name" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldFirstName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFirstName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFirstName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFirstName: %w", err)
+	}
+	return oldValue.FirstName, nil
+}
+
+// ResetFirstName resets all changes to the "first_name" field.
+func (m *UserMutation) ResetFirstName() {
+	m.first_name = nil
+}
+
+// SetLastName sets the "last_name" field.
+func (m *UserMutation) SetLastName(s string) {
+	m.last_name = &s
+}
+
+// LastName returns the value of the "last_name" field in the mutation.
+func (m *UserMutation) LastName() (r string, exists bool) {
+	v := m.last_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastName returns the old "last_name" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldLastName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastName: %w", err)
+	}
+	return oldValue.LastName, nil
+}
+
+// ResetLastName resets all changes to the "last_name" field.
+func (m *UserMutation) ResetLastName() {
+	m.last_name = nil
+}
+
+// SetMiddleName sets the "middle_name" field.
+func (m *UserMutation) SetMiddleName(s string) {
+	m.middle_name = &s
+}
+
+// MiddleName returns the value of the "middle_name" field in the mutation.
+func (m *UserMutation) MiddleName() (r string, exists bool) {
+	v := m.middle_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMiddleName returns the old "middle_name" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldMiddleName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMiddleName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMiddleName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMiddleName: %w", err)
+	}
+	return oldValue.MiddleName, nil
+}
+
+// ResetMiddleName resets all changes to the "middle_name" field.
+func (m *UserMutation) ResetMiddleName() {
+	m.middle_name = nil
+}
+
+// SetPictureURL sets the "picture_url" field.
+func (m *UserMutation) SetPictureURL(s string) {
+	m.picture_url = &s
+}
+
+// PictureURL returns the value of the "picture_url" field in the mutation.
+func (m *UserMutation) PictureURL() (r string, exists bool) {
+	v := m.picture_url
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPictureURL returns the old "picture_url" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldPictureURL(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPictureURL is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPictureURL requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPictureURL: %w", err)
+	}
+	return oldValue.PictureURL, nil
+}
+
+// ClearPictureURL clears the value of the "picture_url" field.
+func (m *UserMutation) ClearPictureURL() {
+	m.picture_url = nil
+	m.clearedFields[user.FieldPictureURL] = struct{}{}
+}
+
+// PictureURLCleared returns if the "picture_url" field was cleared in this mutation.
+func (m *UserMutation) PictureURLCleared() bool {
+	_, ok := m.clearedFields[user.FieldPictureURL]
+	return ok
+}
+
+// ResetPictureURL resets all changes to the "picture_url" field.
+func (m *UserMutation) ResetPictureURL() {
+	m.picture_url = nil
+	delete(m.clearedFields, user.FieldPictureURL)
+}
+
+// SetEmail sets the "email" field.
+func (m *UserMutation) SetEmail(s string) {
+	m.email = &s
+}
+
+// Email returns the value of the "email" field in the mutation.
+func (m *UserMutation) Email() (r string, exists bool) {
+	v := m.email
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmail returns the old "email" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmail(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+	}
+	return oldValue.Email, nil
+}
+
+// ClearEmail clears the value of the "email" field.
+func (m *UserMutation) ClearEmail() {
+	m.email = nil
+	m.clearedFields[user.FieldEmail] = struct{}{}
+}
+
+// EmailCleared returns if the "email" field was cleared in this mutation.
+func (m *UserMutation) EmailCleared() bool {
+	_, ok := m.clearedFields[user.FieldEmail]
+	return ok
+}
+
+// ResetEmail resets all changes to the "email" field.
+func (m *UserMutation) ResetEmail() {
+	m.email = nil
+	delete(m.clearedFields, user.FieldEmail)
+}
+
+// SetSuspended sets the "suspended" field.
+func (m *UserMutation) SetSuspended(b bool) {
+	m.suspended = &b
+}
+
+// Suspended returns the value of the "suspended" field in the mutation.
+func (m *UserMutation) Suspended() (r bool, exists bool) {
+	v := m.suspended
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSuspended returns the old "suspended" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldSuspended(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSuspended is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSuspended requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSuspended: %w", err)
+	}
+	return oldValue.Suspended, nil
+}
+
+// ResetSuspended resets all changes to the "suspended" field.
+func (m *UserMutation) ResetSuspended() {
+	m.suspended = nil
+}
+
+// SetDepartmentID sets the "department_id" field.
+func (m *UserMutation) SetDepartmentID(u uuid.UUID) {
+	m.department = &u
+}
+
+// DepartmentID returns the value of the "department_id" field in the mutation.
+func (m *UserMutation) DepartmentID() (r uuid.UUID, exists bool) {
+	v := m.department
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDepartmentID returns the old "department_id" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDepartmentID(ctx context.Context) (v *uuid.UUID, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDepartmentID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDepartmentID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDepartmentID: %w", err)
+	}
+	return oldValue.DepartmentID, nil
+}
+
+// ClearDepartmentID clears the value of the "department_id" field.
+func (m *UserMutation) ClearDepartmentID() {
+	m.department = nil
+	m.clearedFields[user.FieldDepartmentID] = struct{}{}
+}
+
+// DepartmentIDCleared returns if the "department_id" field was cleared in this mutation.
+func (m *UserMutation) DepartmentIDCleared() bool {
 	_, ok := m.clearedFields[user.FieldDepartmentID]
 	return ok
 }
 
-// ResetDepartmentID resets all changes to the "department_id" field.
-func (m *UserMutation) ResetDepartmentID() {
-	m.department = nil
-	delete(m.clearedFields, user.FieldDepartmentID)
+// ResetDepartmentID resets all changes to the "department_id" field.
+func (m *UserMutation) ResetDepartmentID() {
+	m.department = nil
+	delete(m.clearedFields, user.FieldDepartmentID)
+}
+
+// SetRoleID sets the "role_id" field.
+func (m *UserMutation) SetRoleID(i int32) {
+	m.role_id = &i
+	m.addrole_id = nil
+}
+
+// RoleID returns the value of the "role_id" field in the mutation.
+func (m *UserMutation) RoleID() (r int32, exists bool) {
+	v := m.role_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRoleID returns the old "role_id" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldRoleID(ctx context.Context) (v int32, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRoleID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRoleID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRoleID: %w", err)
+	}
+	return oldValue.RoleID, nil
+}
+
+// AddRoleID adds i to the "role_id" field.
+func (m *UserMutation) AddRoleID(i int32) {
+	if m.addrole_id != nil {
+		*m.addrole_id += i
+	} else {
+		m.addrole_id = &i
+	}
+}
+
+// AddedRoleID returns the value that was added to the "role_id" field in this mutation.
+func (m *UserMutation) AddedRoleID() (r int32, exists bool) {
+	v := m.addrole_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRoleID resets all changes to the "role_id" field.
+func (m *UserMutation) ResetRoleID() {
+	m.role_id = nil
+	m.addrole_id = nil
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (m *UserMutation) SetDeletedAt(t time.Time) {
+	m.deleted_at = &t
+}
+
+// DeletedAt returns the value of the "deleted_at" field in the mutation.
+func (m *UserMutation) DeletedAt() (r time.Time, exists bool) {
+	v := m.deleted_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDeletedAt returns the old "deleted_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDeletedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDeletedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDeletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDeletedAt: %w", err)
+	}
+	return oldValue.DeletedAt, nil
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (m *UserMutation) ClearDeletedAt() {
+	m.deleted_at = nil
+	m.clearedFields[user.FieldDeletedAt] = struct{}{}
+}
+
+// DeletedAtCleared returns if the "deleted_at" field was cleared in this mutation.
+func (m *UserMutation) DeletedAtCleared() bool {
+	_, ok := m.clearedFields[user.FieldDeletedAt]
+	return ok
+}
+
+// ResetDeletedAt resets all changes to the "deleted_at" field.
+func (m *UserMutation) ResetDeletedAt() {
+	m.deleted_at = nil
+	delete(m.clearedFields, user.FieldDeletedAt)
+}
+
+// SetVersion sets the "version" field.
+func (m *UserMutation) SetVersion(i int) {
+	m.version = &i
+	m.addversion = nil
+}
+
+// Version returns the value of the "version" field in the mutation.
+func (m *UserMutation) Version() (r int, exists bool) {
+	v := m.version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVersion returns the old "version" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldVersion(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVersion: %w", err)
+	}
+	return oldValue.Version, nil
+}
+
+// AddVersion adds i to the "version" field.
+func (m *UserMutation) AddVersion(i int) {
+	if m.addversion != nil {
+		*m.addversion += i
+	} else {
+		m.addversion = &i
+	}
+}
+
+// AddedVersion returns the value that was added to the "version" field in this mutation.
+func (m *UserMutation) AddedVersion() (r int, exists bool) {
+	v := m.addversion
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetVersion resets all changes to the "version" field.
+func (m *UserMutation) ResetVersion() {
+	m.version = nil
+	m.addversion = nil
+}
+
+// SetSubdivision sets the "subdivision" field.
+func (m *UserMutation) SetSubdivision(s string) {
+	m.subdivision = &s
+}
+
+// Subdivision returns the value of the "subdivision" field in the mutation.
+func (m *UserMutation) Subdivision() (r string, exists bool) {
+	v := m.subdivision
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSubdivision returns the old "subdivision" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldSubdivision(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSubdivision is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSubdivision requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSubdivision: %w", err)
+	}
+	return oldValue.Subdivision, nil
+}
+
+// ResetSubdivision resets all changes to the "subdivision" field.
+func (m *UserMutation) ResetSubdivision() {
+	m.subdivision = nil
+}
+
+// SetJobTitle sets the "job_title" field.
+func (m *UserMutation) SetJobTitle(s string) {
+	m.job_title = &s
+}
+
+// JobTitle returns the value of the "job_title" field in the mutation.
+func (m *UserMutation) JobTitle() (r string, exists bool) {
+	v := m.job_title
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldJobTitle returns the old "job_title" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldJobTitle(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldJobTitle is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldJobTitle requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldJobTitle: %w", err)
+	}
+	return oldValue.JobTitle, nil
+}
+
+// ResetJobTitle resets all changes to the "job_title" field.
+func (m *UserMutation) ResetJobTitle() {
+	m.job_title = nil
+}
+
+// SetEmploymentRate sets the "employment_rate" field.
+func (m *UserMutation) SetEmploymentRate(f float64) {
+	m.employment_rate = &f
+	m.addemployment_rate = nil
+}
+
+// EmploymentRate returns the value of the "employment_rate" field in the mutation.
+func (m *UserMutation) EmploymentRate() (r float64, exists bool) {
+	v := m.employment_rate
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmploymentRate returns the old "employment_rate" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmploymentRate(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmploymentRate is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmploymentRate requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmploymentRate: %w", err)
+	}
+	return oldValue.EmploymentRate, nil
+}
+
+// AddEmploymentRate adds f to the "employment_rate" field.
+func (m *UserMutation) AddEmploymentRate(f float64) {
+	if m.addemployment_rate != nil {
+		*m.addemployment_rate += f
+	} else {
+		m.addemployment_rate = &f
+	}
+}
+
+// AddedEmploymentRate returns the value that was added to the "employment_rate" field in this mutation.
+func (m *UserMutation) AddedEmploymentRate() (r float64, exists bool) {
+	v := m.addemployment_rate
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetEmploymentRate resets all changes to the "employment_rate" field.
+func (m *UserMutation) ResetEmploymentRate() {
+	m.employment_rate = nil
+	m.addemployment_rate = nil
+}
+
+// SetPersonnelCategory sets the "personnel_category" field.
+func (m *UserMutation) SetPersonnelCategory(i int32) {
+	m.personnel_category = &i
+	m.addpersonnel_category = nil
+}
+
+// PersonnelCategory returns the value of the "personnel_category" field in the mutation.
+func (m *UserMutation) PersonnelCategory() (r int32, exists bool) {
+	v := m.personnel_category
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPersonnelCategory returns the old "personnel_category" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldPersonnelCategory(ctx context.Context) (v int32, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPersonnelCategory is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPersonnelCategory requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPersonnelCategory: %w", err)
+	}
+	return oldValue.PersonnelCategory, nil
+}
+
+// AddPersonnelCategory adds i to the "personnel_category" field.
+func (m *UserMutation) AddPersonnelCategory(i int32) {
+	if m.addpersonnel_category != nil {
+		*m.addpersonnel_category += i
+	} else {
+		m.addpersonnel_category = &i
+	}
+}
+
+// AddedPersonnelCategory returns the value that was added to the "personnel_category" field in this mutation.
+func (m *UserMutation) AddedPersonnelCategory() (r int32, exists bool) {
+	v := m.addpersonnel_category
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPersonnelCategory resets all changes to the "personnel_category" field.
+func (m *UserMutation) ResetPersonnelCategory() {
+	m.personnel_category = nil
+	m.addpersonnel_category = nil
+}
+
+// SetEmploymentType sets the "employment_type" field.
+func (m *UserMutation) SetEmploymentType(i int32) {
+	m.employment_type = &i
+	m.addemployment_type = nil
+}
+
+// EmploymentType returns the value of the "employment_type" field in the mutation.
+func (m *UserMutation) EmploymentType() (r int32, exists bool) {
+	v := m.employment_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmploymentType returns the old "employment_type" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmploymentType(ctx context.Context) (v int32, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmploymentType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmploymentType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmploymentType: %w", err)
+	}
+	return oldValue.EmploymentType, nil
+}
+
+// AddEmploymentType adds i to the "employment_type" field.
+func (m *UserMutation) AddEmploymentType(i int32) {
+	if m.addemployment_type != nil {
+		*m.addemployment_type += i
+	} else {
+		m.addemployment_type = &i
+	}
+}
+
+// AddedEmploymentType returns the value that was added to the "employment_type" field in this mutation.
+func (m *UserMutation) AddedEmploymentType() (r int32, exists bool) {
+	v := m.addemployment_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetEmploymentType resets all changes to the "employment_type" field.
+func (m *UserMutation) ResetEmploymentType() {
+	m.employment_type = nil
+	m.addemployment_type = nil
+}
+
+// SetAcademicDegree sets the "academic_degree" field.
+func (m *UserMutation) SetAcademicDegree(i int32) {
+	m.academic_degree = &i
+	m.addacademic_degree = nil
+}
+
+// AcademicDegree returns the value of the "academic_degree" field in the mutation.
+func (m *UserMutation) AcademicDegree() (r int32, exists bool) {
+	v := m.academic_degree
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAcademicDegree returns the old "academic_degree" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldAcademicDegree(ctx context.Context) (v int32, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAcademicDegree is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAcademicDegree requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAcademicDegree: %w", err)
+	}
+	return oldValue.AcademicDegree, nil
+}
+
+// AddAcademicDegree adds i to the "academic_degree" field.
+func (m *UserMutation) AddAcademicDegree(i int32) {
+	if m.addacademic_degree != nil {
+		*m.addacademic_degree += i
+	} else {
+		m.addacademic_degree = &i
+	}
+}
+
+// AddedAcademicDegree returns the value that was added to the "academic_degree" field in this mutation.
+func (m *UserMutation) AddedAcademicDegree() (r int32, exists bool) {
+	v := m.addacademic_degree
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetAcademicDegree resets all changes to the "academic_degree" field.
+func (m *UserMutation) ResetAcademicDegree() {
+	m.academic_degree = nil
+	m.addacademic_degree = nil
+}
+
+// SetAcademicTitle sets the "academic_title" field.
+func (m *UserMutation) SetAcademicTitle(s string) {
+	m.academic_title = &s
+}
+
+// AcademicTitle returns the value of the "academic_title" field in the mutation.
+func (m *UserMutation) AcademicTitle() (r string, exists bool) {
+	v := m.academic_title
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAcademicTitle returns the old "academic_title" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldAcademicTitle(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAcademicTitle is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAcademicTitle requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAcademicTitle: %w", err)
+	}
+	return oldValue.AcademicTitle, nil
+}
+
+// ResetAcademicTitle resets all changes to the "academic_title" field.
+func (m *UserMutation) ResetAcademicTitle() {
+	m.academic_title = nil
+}
+
+// SetHonors sets the "honors" field.
+func (m *UserMutation) SetHonors(s string) {
+	m.honors = &s
+}
+
+// Honors returns the value of the "honors" field in the mutation.
+func (m *UserMutation) Honors() (r string, exists bool) {
+	v := m.honors
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHonors returns the old "honors" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldHonors(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHonors is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHonors requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHonors: %w", err)
+	}
+	return oldValue.Honors, nil
+}
+
+// ResetHonors resets all changes to the "honors" field.
+func (m *UserMutation) ResetHonors() {
+	m.honors = nil
+}
+
+// SetCategory sets the "category" field.
+func (m *UserMutation) SetCategory(s string) {
+	m.category = &s
+}
+
+// Category returns the value of the "category" field in the mutation.
+func (m *UserMutation) Category() (r string, exists bool) {
+	v := m.category
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCategory returns the old "category" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldCategory(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCategory is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCategory requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCategory: %w", err)
+	}
+	return oldValue.Category, nil
+}
+
+// ResetCategory resets all changes to the "category" field.
+func (m *UserMutation) ResetCategory() {
+	m.category = nil
+}
+
+// SetDateOfEmployment sets the "date_of_employment" field.
+func (m *UserMutation) SetDateOfEmployment(t time.Time) {
+	m.date_of_employment = &t
+}
+
+// DateOfEmployment returns the value of the "date_of_employment" field in the mutation.
+func (m *UserMutation) DateOfEmployment() (r time.Time, exists bool) {
+	v := m.date_of_employment
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDateOfEmployment returns the old "date_of_employment" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDateOfEmployment(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDateOfEmployment is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDateOfEmployment requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDateOfEmployment: %w", err)
+	}
+	return oldValue.DateOfEmployment, nil
+}
+
+// ClearDateOfEmployment clears the value of the "date_of_employment" field.
+func (m *UserMutation) ClearDateOfEmployment() {
+	m.date_of_employment = nil
+	m.clearedFields[user.FieldDateOfEmployment] = struct{}{}
+}
+
+// DateOfEmploymentCleared returns if the "date_of_employment" field was cleared in this mutation.
+func (m *UserMutation) DateOfEmploymentCleared() bool {
+	_, ok := m.clearedFields[user.FieldDateOfEmployment]
+	return ok
+}
+
+// ResetDateOfEmployment resets all changes to the "date_of_employment" field.
+func (m *UserMutation) ResetDateOfEmployment() {
+	m.date_of_employment = nil
+	delete(m.clearedFields, user.FieldDateOfEmployment)
+}
+
+// SetUnemploymentDate sets the "unemployment_date" field.
+func (m *UserMutation) SetUnemploymentDate(t time.Time) {
+	m.unemployment_date = &t
+}
+
+// UnemploymentDate returns the value of the "unemployment_date" field in the mutation.
+func (m *UserMutation) UnemploymentDate() (r time.Time, exists bool) {
+	v := m.unemployment_date
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUnemploymentDate returns the old "unemployment_date" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldUnemploymentDate(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUnemploymentDate is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUnemploymentDate requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUnemploymentDate: %w", err)
+	}
+	return oldValue.UnemploymentDate, nil
+}
+
+// ClearUnemploymentDate clears the value of the "unemployment_date" field.
+func (m *UserMutation) ClearUnemploymentDate() {
+	m.unemployment_date = nil
+	m.clearedFields[user.FieldUnemploymentDate] = struct{}{}
+}
+
+// UnemploymentDateCleared returns if the "unemployment_date" field was cleared in this mutation.
+func (m *UserMutation) UnemploymentDateCleared() bool {
+	_, ok := m.clearedFields[user.FieldUnemploymentDate]
+	return ok
+}
+
+// ResetUnemploymentDate resets all changes to the "unemployment_date" field.
+func (m *UserMutation) ResetUnemploymentDate() {
+	m.unemployment_date = nil
+	delete(m.clearedFields, user.FieldUnemploymentDate)
+}
+
+// ClearDepartment clears the "department" edge to the Department entity.
+func (m *UserMutation) ClearDepartment() {
+	m.cleareddepartment = true
+	m.clearedFields[user.FieldDepartmentID] = struct{}{}
+}
+
+// DepartmentCleared reports if the "department" edge to the Department entity was cleared.
+func (m *UserMutation) DepartmentCleared() bool {
+	return m.DepartmentIDCleared() || m.cleareddepartment
+}
+
+// DepartmentIDs returns the "department" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// DepartmentID instead. It exists only for internal usage by the builders.
+func (m *UserMutation) DepartmentIDs() (ids []uuid.UUID) {
+	if id := m.department; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetDepartment resets all changes to the "department" edge.
+func (m *UserMutation) ResetDepartment() {
+	m.department = nil
+	m.cleareddepartment = false
+}
+
+// SetAuthID sets the "auth" edge to the AuthUser entity by id.
+func (m *UserMutation) SetAuthID(id int) {
+	m.auth = &id
+}
+
+// ClearAuth clears the "auth" edge to the AuthUser entity.
+func (m *UserMutation) ClearAuth() {
+	m.clearedauth = true
+}
+
+// AuthCleared reports if the "auth" edge to the AuthUser entity was cleared.
+func (m *UserMutation) AuthCleared() bool {
+	return m.clearedauth
+}
+
+// AuthID returns the "auth" edge ID in the mutation.
+func (m *UserMutation) AuthID() (id int, exists bool) {
+	if m.auth != nil {
+		return *m.auth, true
+	}
+	return
+}
+
+// AuthIDs returns the "auth" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// AuthID instead. It exists only for internal usage by the builders.
+func (m *UserMutation) AuthIDs() (ids []int) {
+	if id := m.auth; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetAuth resets all changes to the "auth" edge.
+func (m *UserMutation) ResetAuth() {
+	m.auth = nil
+	m.clearedauth = false
+}
+
+// AddPermissionIDs adds the "permissions" edge to the UserPermission entity by ids.
+func (m *UserMutation) AddPermissionIDs(ids ...int) {
+	if m.permissions == nil {
+		m.permissions = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.permissions[ids[i]] = struct{}{}
+	}
+}
+
+// ClearPermissions clears the "permissions" edge to the UserPermission entity.
+func (m *UserMutation) ClearPermissions() {
+	m.clearedpermissions = true
+}
+
+// PermissionsCleared reports if the "permissions" edge to the UserPermission entity was cleared.
+func (m *UserMutation) PermissionsCleared() bool {
+	return m.clearedpermissions
+}
+
+// RemovePermissionIDs removes the "permissions" edge to the UserPermission entity by IDs.
+func (m *UserMutation) RemovePermissionIDs(ids ...int) {
+	if m.removedpermissions == nil {
+		m.removedpermissions = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.permissions, ids[i])
+		m.removedpermissions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedPermissions returns the removed IDs of the "permissions" edge to the UserPermission entity.
+func (m *UserMutation) RemovedPermissionsIDs() (ids []int) {
+	for id := range m.removedpermissions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// PermissionsIDs returns the "permissions" edge IDs in the mutation.
+func (m *UserMutation) PermissionsIDs() (ids []int) {
+	for id := range m.permissions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetPermissions resets all changes to the "permissions" edge.
+func (m *UserMutation) ResetPermissions() {
+	m.permissions = nil
+	m.clearedpermissions = false
+	m.removedpermissions = nil
+}
+
+// AddEmploymentEventIDs adds the "employment_events" edge to the EmploymentEvent entity by ids.
+func (m *UserMutation) AddEmploymentEventIDs(ids ...uuid.UUID) {
+	if m.employment_events == nil {
+		m.employment_events = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.employment_events[ids[i]] = struct{}{}
+	}
+}
+
+// ClearEmploymentEvents clears the "employment_events" edge to the EmploymentEvent entity.
+func (m *UserMutation) ClearEmploymentEvents() {
+	m.clearedemployment_events = true
+}
+
+// EmploymentEventsCleared reports if the "employment_events" edge to the EmploymentEvent entity was cleared.
+func (m *UserMutation) EmploymentEventsCleared() bool {
+	return m.clearedemployment_events
+}
+
+// RemoveEmploymentEventIDs removes the "employment_events" edge to the EmploymentEvent entity by IDs.
+func (m *UserMutation) RemoveEmploymentEventIDs(ids ...uuid.UUID) {
+	if m.removedemployment_events == nil {
+		m.removedemployment_events = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.employment_events, ids[i])
+		m.removedemployment_events[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedEmploymentEvents returns the removed IDs of the "employment_events" edge to the EmploymentEvent entity.
+func (m *UserMutation) RemovedEmploymentEventsIDs() (ids []uuid.UUID) {
+	for id := range m.removedemployment_events {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// EmploymentEventsIDs returns the "employment_events" edge IDs in the mutation.
+func (m *UserMutation) EmploymentEventsIDs() (ids []uuid.UUID) {
+	for id := range m.employment_events {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetEmploymentEvents resets all changes to the "employment_events" edge.
+func (m *UserMutation) ResetEmploymentEvents() {
+	m.employment_events = nil
+	m.clearedemployment_events = false
+	m.removedemployment_events = nil
+}
+
+// Where appends a list predicates to the UserMutation builder.
+func (m *UserMutation) Where(ps ...predicate.User) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the UserMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.User, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *UserMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *UserMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (User).
+func (m *UserMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *UserMutation) Fields() []string {
+	fields := make([]string, 0, 22)
+	if m.org_id != nil {
+		fields = append(fields, user.FieldOrgID)
+	}
+	if m.first_name != nil {
+		fields = append(fields, user.FieldFirstName)
+	}
+	if m.last_name != nil {
+		fields = append(fields, user.FieldLastName)
+	}
+	if m.middle_name != nil {
+		fields = append(fields, user.FieldMiddleName)
+	}
+	if m.picture_url != nil {
+		fields = append(fields, user.FieldPictureURL)
+	}
+	if m.email != nil {
+		fields = append(fields, user.FieldEmail)
+	}
+	if m.suspended != nil {
+		fields = append(fields, user.FieldSuspended)
+	}
+	if m.department != nil {
+		fields = append(fields, user.FieldDepartmentID)
+	}
+	if m.role_id != nil {
+		fields = append(fields, user.FieldRoleID)
+	}
+	if m.deleted_at != nil {
+		fields = append(fields, user.FieldDeletedAt)
+	}
+	if m.version != nil {
+		fields = append(fields, user.FieldVersion)
+	}
+	if m.subdivision != nil {
+		fields = append(fields, user.FieldSubdivision)
+	}
+	if m.job_title != nil {
+		fields = append(fields, user.FieldJobTitle)
+	}
+	if m.employment_rate != nil {
+		fields = append(fields, user.FieldEmploymentRate)
+	}
+	if m.personnel_category != nil {
+		fields = append(fields, user.FieldPersonnelCategory)
+	}
+	if m.employment_type != nil {
+		fields = append(fields, user.FieldEmploymentType)
+	}
+	if m.academic_degree != nil {
+		fields = append(fields, user.FieldAcademicDegree)
+	}
+	if m.academic_title != nil {
+		fields = append(fields, user.FieldAcademicTitle)
+	}
+	if m.honors != nil {
+		fields = append(fields, user.FieldHonors)
+	}
+	if m.category != nil {
+		fields = append(fields, user.FieldCategory)
+	}
+	if m.date_of_employment != nil {
+		fields = append(fields, user.FieldDateOfEmployment)
+	}
+	if m.unemployment_date != nil {
+		fields = append(fields, user.FieldUnemploymentDate)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *UserMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case user.FieldOrgID:
+		return m.OrgID()
+	case user.FieldFirstName:
+		return m.FirstName()
+	case user.FieldLastName:
+		return m.LastName()
+	case user.FieldMiddleName:
+		return m.MiddleName()
+	case user.FieldPictureURL:
+		return m.PictureURL()
+	case user.FieldEmail:
+		return m.Email()
+	case user.FieldSuspended:
+		return m.Suspended()
+	case user.FieldDepartmentID:
+		return m.DepartmentID()
+	case user.FieldRoleID:
+		return m.RoleID()
+	case user.FieldDeletedAt:
+		return m.DeletedAt()
+	case user.FieldVersion:
+		return m.Version()
+	case user.FieldSubdivision:
+		return m.Subdivision()
+	case user.FieldJobTitle:
+		return m.JobTitle()
+	case user.FieldEmploymentRate:
+		return m.EmploymentRate()
+	case user.FieldPersonnelCategory:
+		return m.PersonnelCategory()
+	case user.FieldEmploymentType:
+		return m.EmploymentType()
+	case user.FieldAcademicDegree:
+		return m.AcademicDegree()
+	case user.FieldAcademicTitle:
+		return m.AcademicTitle()
+	case user.FieldHonors:
+		return m.Honors()
+	case user.FieldCategory:
+		return m.Category()
+	case user.FieldDateOfEmployment:
+		return m.DateOfEmployment()
+	case user.FieldUnemploymentDate:
+		return m.UnemploymentDate()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case user.FieldOrgID:
+		return m.OldOrgID(ctx)
+	case user.FieldFirstName:
+		return m.OldFirstName(ctx)
+	case user.FieldLastName:
+		return m.OldLastName(ctx)
+	case user.FieldMiddleName:
+		return m.OldMiddleName(ctx)
+	case user.FieldPictureURL:
+		return m.OldPictureURL(ctx)
+	case user.FieldEmail:
+		return m.OldEmail(ctx)
+	case user.FieldSuspended:
+		return m.OldSuspended(ctx)
+	case user.FieldDepartmentID:
+		return m.OldDepartmentID(ctx)
+	case user.FieldRoleID:
+		return m.OldRoleID(ctx)
+	case user.FieldDeletedAt:
+		return m.OldDeletedAt(ctx)
+	case user.FieldVersion:
+		return m.OldVersion(ctx)
+	case user.FieldSubdivision:
+		return m.OldSubdivision(ctx)
+	case user.FieldJobTitle:
+		return m.OldJobTitle(ctx)
+	case user.FieldEmploymentRate:
+		return m.OldEmploymentRate(ctx)
+	case user.FieldPersonnelCategory:
+		return m.OldPersonnelCategory(ctx)
+	case user.FieldEmploymentType:
+		return m.OldEmploymentType(ctx)
+	case user.FieldAcademicDegree:
+		return m.OldAcademicDegree(ctx)
+	case user.FieldAcademicTitle:
+		return m.OldAcademicTitle(ctx)
+	case user.FieldHonors:
+		return m.OldHonors(ctx)
+	case user.FieldCategory:
+		return m.OldCategory(ctx)
+	case user.FieldDateOfEmployment:
+		return m.OldDateOfEmployment(ctx)
+	case user.FieldUnemploymentDate:
+		return m.OldUnemploymentDate(ctx)
+	}
+	return nil, fmt.Errorf("unknown User field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case user.FieldOrgID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOrgID(v)
+		return nil
+	case user.FieldFirstName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFirstName(v)
+		return nil
+	case user.FieldLastName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastName(v)
+		return nil
+	case user.FieldMiddleName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMiddleName(v)
+		return nil
+	case user.FieldPictureURL:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPictureURL(v)
+		return nil
+	case user.FieldEmail:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmail(v)
+		return nil
+	case user.FieldSuspended:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSuspended(v)
+		return nil
+	case user.FieldDepartmentID:
+		v, ok := value.(uuid.UUID)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDepartmentID(v)
+		return nil
+	case user.FieldRoleID:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRoleID(v)
+		return nil
+	case user.FieldDeletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeletedAt(v)
+		return nil
+	case user.FieldVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVersion(v)
+		return nil
+	case user.FieldSubdivision:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSubdivision(v)
+		return nil
+	case user.FieldJobTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetJobTitle(v)
+		return nil
+	case user.FieldEmploymentRate:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmploymentRate(v)
+		return nil
+	case user.FieldPersonnelCategory:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPersonnelCategory(v)
+		return nil
+	case user.FieldEmploymentType:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmploymentType(v)
+		return nil
+	case user.FieldAcademicDegree:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAcademicDegree(v)
+		return nil
+	case user.FieldAcademicTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAcademicTitle(v)
+		return nil
+	case user.FieldHonors:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHonors(v)
+		return nil
+	case user.FieldCategory:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCategory(v)
+		return nil
+	case user.FieldDateOfEmployment:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDateOfEmployment(v)
+		return nil
+	case user.FieldUnemploymentDate:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUnemploymentDate(v)
+		return nil
+	}
+	return fmt.Errorf("unknown User field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *UserMutation) AddedFields() []string {
+	var fields []string
+	if m.addrole_id != nil {
+		fields = append(fields, user.FieldRoleID)
+	}
+	if m.addversion != nil {
+		fields = append(fields, user.FieldVersion)
+	}
+	if m.addemployment_rate != nil {
+		fields = append(fields, user.FieldEmploymentRate)
+	}
+	if m.addpersonnel_category != nil {
+		fields = append(fields, user.FieldPersonnelCategory)
+	}
+	if m.addemployment_type != nil {
+		fields = append(fields, user.FieldEmploymentType)
+	}
+	if m.addacademic_degree != nil {
+		fields = append(fields, user.FieldAcademicDegree)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case user.FieldRoleID:
+		return m.AddedRoleID()
+	case user.FieldVersion:
+		return m.AddedVersion()
+	case user.FieldEmploymentRate:
+		return m.AddedEmploymentRate()
+	case user.FieldPersonnelCategory:
+		return m.AddedPersonnelCategory()
+	case user.FieldEmploymentType:
+		return m.AddedEmploymentType()
+	case user.FieldAcademicDegree:
+		return m.AddedAcademicDegree()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case user.FieldRoleID:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRoleID(v)
+		return nil
+	case user.FieldVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddVersion(v)
+		return nil
+	case user.FieldEmploymentRate:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddEmploymentRate(v)
+		return nil
+	case user.FieldPersonnelCategory:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPersonnelCategory(v)
+		return nil
+	case user.FieldEmploymentType:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddEmploymentType(v)
+		return nil
+	case user.FieldAcademicDegree:
+		v, ok := value.(int32)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAcademicDegree(v)
+		return nil
+	}
+	return fmt.Errorf("unknown User numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *UserMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(user.FieldPictureURL) {
+		fields = append(fields, user.FieldPictureURL)
+	}
+	if m.FieldCleared(user.FieldEmail) {
+		fields = append(fields, user.FieldEmail)
+	}
+	if m.FieldCleared(user.FieldDepartmentID) {
+		fields = append(fields, user.FieldDepartmentID)
+	}
+	if m.FieldCleared(user.FieldDeletedAt) {
+		fields = append(fields, user.FieldDeletedAt)
+	}
+	if m.FieldCleared(user.FieldDateOfEmployment) {
+		fields = append(fields, user.FieldDateOfEmployment)
+	}
+	if m.FieldCleared(user.FieldUnemploymentDate) {
+		fields = append(fields, user.FieldUnemploymentDate)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *UserMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *UserMutation) ClearField(name string) error {
+	switch name {
+	case user.FieldPictureURL:
+		m.ClearPictureURL()
+		return nil
+	case user.FieldEmail:
+		m.ClearEmail()
+		return nil
+	case user.FieldDepartmentID:
+		m.ClearDepartmentID()
+		return nil
+	case user.FieldDeletedAt:
+		m.ClearDeletedAt()
+		return nil
+	case user.FieldDateOfEmployment:
+		m.ClearDateOfEmployment()
+		return nil
+	case user.FieldUnemploymentDate:
+		m.ClearUnemploymentDate()
+		return nil
+	}
+	return fmt.Errorf("unknown User nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *UserMutation) ResetField(name string) error {
+	switch name {
+	case user.FieldOrgID:
+		m.ResetOrgID()
+		return nil
+	case user.FieldFirstName:
+		m.ResetFirstName()
+		return nil
+	case user.FieldLastName:
+		m.ResetLastName()
+		return nil
+	case user.FieldMiddleName:
+		m.ResetMiddleName()
+		return nil
+	case user.FieldPictureURL:
+		m.ResetPictureURL()
+		return nil
+	case user.FieldEmail:
+		m.ResetEmail()
+		return nil
+	case user.FieldSuspended:
+		m.ResetSuspended()
+		return nil
+	case user.FieldDepartmentID:
+		m.ResetDepartmentID()
+		return nil
+	case user.FieldRoleID:
+		m.ResetRoleID()
+		return nil
+	case user.FieldDeletedAt:
+		m.ResetDeletedAt()
+		return nil
+	case user.FieldVersion:
+		m.ResetVersion()
+		return nil
+	case user.FieldSubdivision:
+		m.ResetSubdivision()
+		return nil
+	case user.FieldJobTitle:
+		m.ResetJobTitle()
+		return nil
+	case user.FieldEmploymentRate:
+		m.ResetEmploymentRate()
+		return nil
+	case user.FieldPersonnelCategory:
+		m.ResetPersonnelCategory()
+		return nil
+	case user.FieldEmploymentType:
+		m.ResetEmploymentType()
+		return nil
+	case user.FieldAcademicDegree:
+		m.ResetAcademicDegree()
+		return nil
+	case user.FieldAcademicTitle:
+		m.ResetAcademicTitle()
+		return nil
+	case user.FieldHonors:
+		m.ResetHonors()
+		return nil
+	case user.FieldCategory:
+		m.ResetCategory()
+		return nil
+	case user.FieldDateOfEmployment:
+		m.ResetDateOfEmployment()
+		return nil
+	case user.FieldUnemploymentDate:
+		m.ResetUnemploymentDate()
+		return nil
+	}
+	return fmt.Errorf("unknown User field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *UserMutation) AddedEdges() []string {
+	edges := make([]string, 0, 4)
+	if m.department != nil {
+		edges = append(edges, user.EdgeDepartment)
+	}
+	if m.auth != nil {
+		edges = append(edges, user.EdgeAuth)
+	}
+	if m.permissions != nil {
+		edges = append(edges, user.EdgePermissions)
+	}
+	if m.employment_events != nil {
+		edges = append(edges, user.EdgeEmploymentEvents)
+	}
+	return edges
 }
 
-// SetRoleID sets the "role_id" field.
-func (m *UserMutation) SetRoleID(i int32) {
-	m.role_id = &i
-	m.addrole_id = nil
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *UserMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case user.EdgeDepartment:
+		if id := m.department; id != nil {
+			return []ent.Value{*id}
+		}
+	case user.EdgeAuth:
+		if id := m.auth; id != nil {
+			return []ent.Value{*id}
+		}
+	case user.EdgePermissions:
+		ids := make([]ent.Value, 0, len(m.permissions))
+		for id := range m.permissions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeEmploymentEvents:
+		ids := make([]ent.Value, 0, len(m.employment_events))
+		for id := range m.employment_events {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
 }
 
-// RoleID returns the value of the "role_id" field in the mutation.
-func (m *UserMutation) RoleID() (r int32, exists bool) {
-	v := m.role_id
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *UserMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 4)
+	if m.removedpermissions != nil {
+		edges = append(edges, user.EdgePermissions)
+	}
+	if m.removedemployment_events != nil {
+		edges = append(edges, user.EdgeEmploymentEvents)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *UserMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case user.EdgePermissions:
+		ids := make([]ent.Value, 0, len(m.removedpermissions))
+		for id := range m.removedpermissions {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeEmploymentEvents:
+		ids := make([]ent.Value, 0, len(m.removedemployment_events))
+		for id := range m.removedemployment_events {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *UserMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 4)
+	if m.cleareddepartment {
+		edges = append(edges, user.EdgeDepartment)
+	}
+	if m.clearedauth {
+		edges = append(edges, user.EdgeAuth)
+	}
+	if m.clearedpermissions {
+		edges = append(edges, user.EdgePermissions)
+	}
+	if m.clearedemployment_events {
+		edges = append(edges, user.EdgeEmploymentEvents)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *UserMutation) EdgeCleared(name string) bool {
+	switch name {
+	case user.EdgeDepartment:
+		return m.cleareddepartment
+	case user.EdgeAuth:
+		return m.clearedauth
+	case user.EdgePermissions:
+		return m.clearedpermissions
+	case user.EdgeEmploymentEvents:
+		return m.clearedemployment_events
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *UserMutation) ClearEdge(name string) error {
+	switch name {
+	case user.EdgeDepartment:
+		m.ClearDepartment()
+		return nil
+	case user.EdgeAuth:
+		m.ClearAuth()
+		return nil
+	}
+	return fmt.Errorf("unknown User unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *UserMutation) ResetEdge(name string) error {
+	switch name {
+	case user.EdgeDepartment:
+		m.ResetDepartment()
+		return nil
+	case user.EdgeAuth:
+		m.ResetAuth()
+		return nil
+	case user.EdgePermissions:
+		m.ResetPermissions()
+		return nil
+	case user.EdgeEmploymentEvents:
+		m.ResetEmploymentEvents()
+		return nil
+	}
+	return fmt.Errorf("unknown User edge %s", name)
+}
+
+// UserPermissionMutation represents an operation that mutates the UserPermission nodes in the graph.
+type UserPermissionMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *int
+	permission_id    *int32
+	addpermission_id *int32
+	clearedFields    map[string]struct{}
+	user             *uuid.UUID
+	cleareduser      bool
+	done             bool
+	oldValue         func(context.Context) (*UserPermission, error)
+	predicates       []predicate.UserPermission
+}
+
+var _ ent.Mutation = (*UserPermissionMutation)(nil)
+
+// userpermissionOption allows management of the mutation configuration using functional options.
+type userpermissionOption func(*UserPermissionMutation)
+
+// newUserPermissionMutation creates new mutation for the UserPermission entity.
+func newUserPermissionMutation(c config, op Op, opts ...userpermissionOption) *UserPermissionMutation {
+	m := &UserPermissionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUserPermission,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withUserPermissionID sets the ID field of the mutation.
+func withUserPermissionID(id int) userpermissionOption {
+	return func(m *UserPermissionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *UserPermission
+		)
+		m.oldValue = func(ctx context.Context) (*UserPermission, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().UserPermission.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withUserPermission sets the old UserPermission of the mutation.
+func withUserPermission(node *UserPermission) userpermissionOption {
+	return func(m *UserPermissionMutation) {
+		m.oldValue = func(context.Context) (*UserPermission, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UserPermissionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UserPermissionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UserPermissionMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UserPermissionMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().UserPermission.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetUserID sets the "user_id" field.
+func (m *UserPermissionMutation) SetUserID(u uuid.UUID) {
+	m.user = &u
+}
+
+// UserID returns the value of the "user_id" field in the mutation.
+func (m *UserPermissionMutation) UserID() (r uuid.UUID, exists bool) {
+	v := m.user
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRoleID returns the old "role_id" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldUserID returns the old "user_id" field's value of the UserPermission entity.
+// If the UserPermission object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldRoleID(ctx context.Context) (v int32, err error) {
+func (m *UserPermissionMutation) OldUserID(ctx context.Context) (v uuid.UUID, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRoleID is only allowed on UpdateOne operations")
+		return v, errors.New("OldUserID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRoleID requires an ID field in the mutation")
+		return v, errors.New("OldUserID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRoleID: %w", err)
+		return v, fmt.Errorf("querying old value for OldUserID: %w", err)
 	}
-	return oldValue.RoleID, nil
+	return oldValue.UserID, nil
 }
 
-// AddRoleID adds i to the "role_id" field.
-func (m *UserMutation) AddRoleID(i int32) {
-	if m.addrole_id != nil {
-		*m.addrole_id += i
-	} else {
-		m.addrole_id = &i
-	}
+// ResetUserID resets all changes to the "user_id" field.
+func (m *UserPermissionMutation) ResetUserID() {
+	m.user = nil
 }
 
-// AddedRoleID returns the value that was added to the "role_id" field in this mutation.
-func (m *UserMutation) AddedRoleID() (r int32, exists bool) {
-	v := m.addrole_id
+// SetPermissionID sets the "permission_id" field.
+func (m *UserPermissionMutation) SetPermissionID(i int32) {
+	m.permission_id = &i
+	m.addpermission_id = nil
+}
+
+// PermissionID returns the value of the "permission_id" field in the mutation.
+func (m *UserPermissionMutation) PermissionID() (r int32, exists bool) {
+	v := m.permission_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetRoleID resets all changes to the "role_id" field.
-func (m *UserMutation) ResetRoleID() {
-	m.role_id = nil
-	m.addrole_id = nil
-}
-
-// ClearDepartment clears the "department" edge to the Department entity.
-func (m *UserMutation) ClearDepartment() {
-	m.cleareddepartment = true
-	m.clearedFields[user.FieldDepartmentID] = struct{}{}
-}
-
-// DepartmentCleared reports if the "department" edge to the Department entity was cleared.
-func (m *UserMutation) DepartmentCleared() bool {
-	return m.DepartmentIDCleared() || m.cleareddepartment
-}
-
-// DepartmentIDs returns the "department" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// DepartmentID instead. It exists only for internal usage by the builders.
-func (m *UserMutation) DepartmentIDs() (ids []uuid.UUID) {
-	if id := m.department; id != nil {
-		ids = append(ids, *id)
+// OldPermissionID returns the old "permission_id" field's value of the UserPermission entity.
+// If the UserPermission object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserPermissionMutation) OldPermissionID(ctx context.Context) (v int32, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPermissionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPermissionID requires an ID field in the mutation")
 	}
-	return
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPermissionID: %w", err)
+	}
+	return oldValue.PermissionID, nil
 }
 
-// ResetDepartment resets all changes to the "department" edge.
-func (m *UserMutation) ResetDepartment() {
-	m.department = nil
-	m.cleareddepartment = false
+// AddPermissionID adds i to the "permission_id" field.
+func (m *UserPermissionMutation) AddPermissionID(i int32) {
+	if m.addpermission_id != nil {
+		*m.addpermission_id += i
+	} else {
+		m.addpermission_id = &i
+	}
 }
 
-// SetAuthID sets the "auth" edge to the AuthUser entity by id.
-func (m *UserMutation) SetAuthID(id int) {
-	m.auth = &id
+// AddedPermissionID returns the value that was added to the "permission_id" field in this mutation.
+func (m *UserPermissionMutation) AddedPermissionID() (r int32, exists bool) {
+	v := m.addpermission_id
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ClearAuth clears the "auth" edge to the AuthUser entity.
-func (m *UserMutation) ClearAuth() {
-	m.clearedauth = true
+// ResetPermissionID resets all changes to the "permission_id" field.
+func (m *UserPermissionMutation) ResetPermissionID() {
+	m.permission_id = nil
+	m.addpermission_id = nil
 }
 
-// AuthCleared reports if the "auth" edge to the AuthUser entity was cleared.
-func (m *UserMutation) AuthCleared() bool {
-	return m.clearedauth
+// ClearUser clears the "user" edge to the User entity.
+func (m *UserPermissionMutation) ClearUser() {
+	m.cleareduser = true
+	m.clearedFields[userpermission.FieldUserID] = struct{}{}
 }
 
-// AuthID returns the "auth" edge ID in the mutation.
-func (m *UserMutation) AuthID() (id int, exists bool) {
-	if m.auth != nil {
-		return *m.auth, true
-	}
-	return
+// UserCleared reports if the "user" edge to the User entity was cleared.
+func (m *UserPermissionMutation) UserCleared() bool {
+	return m.cleareduser
 }
 
-// AuthIDs returns the "auth" edge IDs in the mutation.
+// UserIDs returns the "user" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// AuthID instead. It exists only for internal usage by the builders.
-func (m *UserMutation) AuthIDs() (ids []int) {
-	if id := m.auth; id != nil {
+// UserID instead. It exists only for internal usage by the builders.
+func (m *UserPermissionMutation) UserIDs() (ids []uuid.UUID) {
+	if id := m.user; id != nil {
 		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetAuth resets all changes to the "auth" edge.
-func (m *UserMutation) ResetAuth() {
-	m.auth = nil
-	m.clearedauth = false
+// ResetUser resets all changes to the "user" edge.
+func (m *UserPermissionMutation) ResetUser() {
+	m.user = nil
+	m.cleareduser = false
 }
 
-// Where appends a list predicates to the UserMutation builder.
-func (m *UserMutation) Where(ps ...predicate.User) {
+// Where appends a list predicates to the UserPermissionMutation builder.
+func (m *UserPermissionMutation) Where(ps ...predicate.UserPermission) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the UserMutation builder. Using this method,
+// WhereP appends storage-level predicates to the UserPermissionMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.User, len(ps))
+func (m *UserPermissionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.UserPermission, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -1581,45 +5402,30 @@ func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *UserMutation) Op() Op {
+func (m *UserPermissionMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *UserMutation) SetOp(op Op) {
+func (m *UserPermissionMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (User).
-func (m *UserMutation) Type() string {
+// Type returns the node type of this mutation (UserPermission).
+func (m *UserPermissionMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 7)
-	if m.first_name != nil {
-		fields = append(fields, user.FieldFirstName)
-	}
-	if m.last_name != nil {
-		fields = append(fields, user.FieldLastName)
-	}
-	if m.middle_name != nil {
-		fields = append(fields, user.FieldMiddleName)
-	}
-	if m.picture_url != nil {
-		fields = append(fields, user.FieldPictureURL)
-	}
-	if m.suspended != nil {
-		fields = append(fields, user.FieldSuspended)
-	}
-	if m.department != nil {
-		fields = append(fields, user.FieldDepartmentID)
+func (m *UserPermissionMutation) Fields() []string {
+	fields := make([]string, 0, 2)
+	if m.user != nil {
+		fields = append(fields, userpermission.FieldUserID)
 	}
-	if m.role_id != nil {
-		fields = append(fields, user.FieldRoleID)
+	if m.permission_id != nil {
+		fields = append(fields, userpermission.FieldPermissionID)
 	}
 	return fields
 }
@@ -1627,22 +5433,12 @@ func (m *UserMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *UserMutation) Field(name string) (ent.Value, bool) {
+func (m *UserPermissionMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case user.FieldFirstName:
-		return m.FirstName()
-	case user.FieldLastName:
-		return m.LastName()
-	case user.FieldMiddleName:
-		return m.MiddleName()
-	case user.FieldPictureURL:
-		return m.PictureURL()
-	case user.FieldSuspended:
-		return m.Suspended()
-	case user.FieldDepartmentID:
-		return m.DepartmentID()
-	case user.FieldRoleID:
-		return m.RoleID()
+	case userpermission.FieldUserID:
+		return m.UserID()
+	case userpermission.FieldPermissionID:
+		return m.PermissionID()
 	}
 	return nil, false
 }
@@ -1650,90 +5446,45 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *UserPermissionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case user.FieldFirstName:
-		return m.OldFirstName(ctx)
-	case user.FieldLastName:
-		return m.OldLastName(ctx)
-	case user.FieldMiddleName:
-		return m.OldMiddleName(ctx)
-	case user.FieldPictureURL:
-		return m.OldPictureURL(ctx)
-	case user.FieldSuspended:
-		return m.OldSuspended(ctx)
-	case user.FieldDepartmentID:
-		return m.OldDepartmentID(ctx)
-	case user.FieldRoleID:
-		return m.OldRoleID(ctx)
+	case userpermission.FieldUserID:
+		return m.OldUserID(ctx)
+	case userpermission.FieldPermissionID:
+		return m.OldPermissionID(ctx)
 	}
-	return nil, fmt.Errorf("unknown User field %s", name)
+	return nil, fmt.Errorf("unknown UserPermission field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *UserMutation) SetField(name string, value ent.Value) error {
+func (m *UserPermissionMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case user.FieldFirstName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetFirstName(v)
-		return nil
-	case user.FieldLastName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetLastName(v)
-		return nil
-	case user.FieldMiddleName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetMiddleName(v)
-		return nil
-	case user.FieldPictureURL:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPictureURL(v)
-		return nil
-	case user.FieldSuspended:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetSuspended(v)
-		return nil
-	case user.FieldDepartmentID:
+	case userpermission.FieldUserID:
 		v, ok := value.(uuid.UUID)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDepartmentID(v)
+		m.SetUserID(v)
 		return nil
-	case user.FieldRoleID:
+	case userpermission.FieldPermissionID:
 		v, ok := value.(int32)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetRoleID(v)
+		m.SetPermissionID(v)
 		return nil
 	}
-	return fmt.Errorf("unknown User field %s", name)
+	return fmt.Errorf("unknown UserPermission field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *UserMutation) AddedFields() []string {
+func (m *UserPermissionMutation) AddedFields() []string {
 	var fields []string
-	if m.addrole_id != nil {
-		fields = append(fields, user.FieldRoleID)
+	if m.addpermission_id != nil {
+		fields = append(fields, userpermission.FieldPermissionID)
 	}
 	return fields
 }
@@ -1741,10 +5492,10 @@ func (m *UserMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
+func (m *UserPermissionMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case user.FieldRoleID:
-		return m.AddedRoleID()
+	case userpermission.FieldPermissionID:
+		return m.AddedPermissionID()
 	}
 	return nil, false
 }
@@ -1752,104 +5503,67 @@ func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *UserMutation) AddField(name string, value ent.Value) error {
+func (m *UserPermissionMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case user.FieldRoleID:
+	case userpermission.FieldPermissionID:
 		v, ok := value.(int32)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddRoleID(v)
+		m.AddPermissionID(v)
 		return nil
 	}
-	return fmt.Errorf("unknown User numeric field %s", name)
+	return fmt.Errorf("unknown UserPermission numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *UserMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(user.FieldPictureURL) {
-		fields = append(fields, user.FieldPictureURL)
-	}
-	if m.FieldCleared(user.FieldDepartmentID) {
-		fields = append(fields, user.FieldDepartmentID)
-	}
-	return fields
+func (m *UserPermissionMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *UserMutation) FieldCleared(name string) bool {
+func (m *UserPermissionMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *UserMutation) ClearField(name string) error {
-	switch name {
-	case user.FieldPictureURL:
-		m.ClearPictureURL()
-		return nil
-	case user.FieldDepartmentID:
-		m.ClearDepartmentID()
-		return nil
-	}
-	return fmt.Errorf("unknown User nullable field %s", name)
+func (m *UserPermissionMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown UserPermission nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *UserMutation) ResetField(name string) error {
+func (m *UserPermissionMutation) ResetField(name string) error {
 	switch name {
-	case user.FieldFirstName:
-		m.ResetFirstName()
-		return nil
-	case user.FieldLastName:
-		m.ResetLastName()
-		return nil
-	case user.FieldMiddleName:
-		m.ResetMiddleName()
-		return nil
-	case user.FieldPictureURL:
-		m.ResetPictureURL()
-		return nil
-	case user.FieldSuspended:
-		m.ResetSuspended()
-		return nil
-	case user.FieldDepartmentID:
-		m.ResetDepartmentID()
+	case userpermission.FieldUserID:
+		m.ResetUserID()
 		return nil
-	case user.FieldRoleID:
-		m.ResetRoleID()
+	case userpermission.FieldPermissionID:
+		m.ResetPermissionID()
 		return nil
 	}
-	return fmt.Errorf("unknown User field %s", name)
+	return fmt.Errorf("unknown UserPermission field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *UserMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.department != nil {
-		edges = append(edges, user.EdgeDepartment)
-	}
-	if m.auth != nil {
-		edges = append(edges, user.EdgeAuth)
+func (m *UserPermissionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.user != nil {
+		edges = append(edges, userpermission.EdgeUser)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *UserMutation) AddedIDs(name string) []ent.Value {
+func (m *UserPermissionMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case user.EdgeDepartment:
-		if id := m.department; id != nil {
-			return []ent.Value{*id}
-		}
-	case user.EdgeAuth:
-		if id := m.auth; id != nil {
+	case userpermission.EdgeUser:
+		if id := m.user; id != nil {
 			return []ent.Value{*id}
 		}
 	}
@@ -1857,65 +5571,54 @@ func (m *UserMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *UserMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *UserPermissionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *UserMutation) RemovedIDs(name string) []ent.Value {
+func (m *UserPermissionMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *UserMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.cleareddepartment {
-		edges = append(edges, user.EdgeDepartment)
-	}
-	if m.clearedauth {
-		edges = append(edges, user.EdgeAuth)
+func (m *UserPermissionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.cleareduser {
+		edges = append(edges, userpermission.EdgeUser)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *UserMutation) EdgeCleared(name string) bool {
+func (m *UserPermissionMutation) EdgeCleared(name string) bool {
 	switch name {
-	case user.EdgeDepartment:
-		return m.cleareddepartment
-	case user.EdgeAuth:
-		return m.clearedauth
+	case userpermission.EdgeUser:
+		return m.cleareduser
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *UserMutation) ClearEdge(name string) error {
+func (m *UserPermissionMutation) ClearEdge(name string) error {
 	switch name {
-	case user.EdgeDepartment:
-		m.ClearDepartment()
-		return nil
-	case user.EdgeAuth:
-		m.ClearAuth()
+	case userpermission.EdgeUser:
+		m.ClearUser()
 		return nil
 	}
-	return fmt.Errorf("unknown User unique edge %s", name)
+	return fmt.Errorf("unknown UserPermission unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *UserMutation) ResetEdge(name string) error {
+func (m *UserPermissionMutation) ResetEdge(name string) error {
 	switch name {
-	case user.EdgeDepartment:
-		m.ResetDepartment()
-		return nil
-	case user.EdgeAuth:
-		m.ResetAuth()
+	case userpermission.EdgeUser:
+		m.ResetUser()
 		return nil
 	}
-	return fmt.Errorf("unknown User edge %s", name)
+	return fmt.Errorf("unknown UserPermission edge %s", name)
 }
@@ -6,11 +6,23 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// AuditLog is the predicate function for auditlog builders.
+type AuditLog func(*sql.Selector)
+
 // AuthUser is the predicate function for authuser builders.
 type AuthUser func(*sql.Selector)
 
 // Department is the predicate function for department builders.
 type Department func(*sql.Selector)
 
+// EmploymentEvent is the predicate function for employmentevent builders.
+type EmploymentEvent func(*sql.Selector)
+
+// SchemaVersion is the predicate function for schemaversion builders.
+type SchemaVersion func(*sql.Selector)
+
 // User is the predicate function for user builders.
 type User func(*sql.Selector)
+
+// UserPermission is the predicate function for userpermission builders.
+type UserPermission func(*sql.Selector)
@@ -3,10 +3,15 @@
 package ent
 
 import (
+	"time"
+
 	uuid "github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/auditlog"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/authuser"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schema"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schemaversion"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
 )
 
@@ -14,6 +19,28 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	auditlogFields := schema.AuditLog{}.Fields()
+	_ = auditlogFields
+	// auditlogDescOrgID is the schema descriptor for org_id field.
+	auditlogDescOrgID := auditlogFields[1].Descriptor()
+	// auditlog.DefaultOrgID holds the default value on creation for the org_id field.
+	auditlog.DefaultOrgID = auditlogDescOrgID.Default.(func() uuid.UUID)
+	// auditlogDescAction is the schema descriptor for action field.
+	auditlogDescAction := auditlogFields[3].Descriptor()
+	// auditlog.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditlog.ActionValidator = auditlogDescAction.Validators[0].(func(string) error)
+	// auditlogDescTargetType is the schema descriptor for target_type field.
+	auditlogDescTargetType := auditlogFields[4].Descriptor()
+	// auditlog.TargetTypeValidator is a validator for the "target_type" field. It is called by the builders before save.
+	auditlog.TargetTypeValidator = auditlogDescTargetType.Validators[0].(func(string) error)
+	// auditlogDescTimestamp is the schema descriptor for timestamp field.
+	auditlogDescTimestamp := auditlogFields[6].Descriptor()
+	// auditlog.DefaultTimestamp holds the default value on creation for the timestamp field.
+	auditlog.DefaultTimestamp = auditlogDescTimestamp.Default.(func() time.Time)
+	// auditlogDescID is the schema descriptor for id field.
+	auditlogDescID := auditlogFields[0].Descriptor()
+	// auditlog.DefaultID holds the default value on creation for the id field.
+	auditlog.DefaultID = auditlogDescID.Default.(func() uuid.UUID)
 	authuserFields := schema.AuthUser{}.Fields()
 	_ = authuserFields
 	// authuserDescUsername is the schema descriptor for username field.
@@ -26,20 +53,88 @@ func init() {
 	authuser.PasswordValidator = authuserDescPassword.Validators[0].(func(string) error)
 	departmentFields := schema.Department{}.Fields()
 	_ = departmentFields
+	// departmentDescOrgID is the schema descriptor for org_id field.
+	departmentDescOrgID := departmentFields[1].Descriptor()
+	// department.DefaultOrgID holds the default value on creation for the org_id field.
+	department.DefaultOrgID = departmentDescOrgID.Default.(func() uuid.UUID)
 	// departmentDescName is the schema descriptor for name field.
-	departmentDescName := departmentFields[1].Descriptor()
+	departmentDescName := departmentFields[2].Descriptor()
 	// department.NameValidator is a validator for the "name" field. It is called by the builders before save.
 	department.NameValidator = departmentDescName.Validators[0].(func(string) error)
+	employmenteventFields := schema.EmploymentEvent{}.Fields()
+	_ = employmenteventFields
+	// employmenteventDescTimestamp is the schema descriptor for timestamp field.
+	employmenteventDescTimestamp := employmenteventFields[7].Descriptor()
+	// employmentevent.DefaultTimestamp holds the default value on creation for the timestamp field.
+	employmentevent.DefaultTimestamp = employmenteventDescTimestamp.Default.(func() time.Time)
+	// employmenteventDescID is the schema descriptor for id field.
+	employmenteventDescID := employmenteventFields[0].Descriptor()
+	// employmentevent.DefaultID holds the default value on creation for the id field.
+	employmentevent.DefaultID = employmenteventDescID.Default.(func() uuid.UUID)
+	schemaversionFields := schema.SchemaVersion{}.Fields()
+	_ = schemaversionFields
+	// schemaversionDescHash is the schema descriptor for hash field.
+	schemaversionDescHash := schemaversionFields[0].Descriptor()
+	// schemaversion.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	schemaversion.HashValidator = schemaversionDescHash.Validators[0].(func(string) error)
+	// schemaversionDescAppliedAt is the schema descriptor for applied_at field.
+	schemaversionDescAppliedAt := schemaversionFields[1].Descriptor()
+	// schemaversion.DefaultAppliedAt holds the default value on creation for the applied_at field.
+	schemaversion.DefaultAppliedAt = schemaversionDescAppliedAt.Default.(func() time.Time)
 	userFields := schema.User{}.Fields()
 	_ = userFields
+	// userDescOrgID is the schema descriptor for org_id field.
+	userDescOrgID := userFields[1].Descriptor()
+	// user.DefaultOrgID holds the default value on creation for the org_id field.
+	user.DefaultOrgID = userDescOrgID.Default.(func() uuid.UUID)
 	// userDescMiddleName is the schema descriptor for middle_name field.
-	userDescMiddleName := userFields[3].Descriptor()
+	userDescMiddleName := userFields[4].Descriptor()
 	// user.DefaultMiddleName holds the default value on creation for the middle_name field.
 	user.DefaultMiddleName = userDescMiddleName.Default.(string)
 	// userDescSuspended is the schema descriptor for suspended field.
-	userDescSuspended := userFields[5].Descriptor()
+	userDescSuspended := userFields[7].Descriptor()
 	// user.DefaultSuspended holds the default value on creation for the suspended field.
 	user.DefaultSuspended = userDescSuspended.Default.(bool)
+	// userDescVersion is the schema descriptor for version field.
+	userDescVersion := userFields[11].Descriptor()
+	// user.DefaultVersion holds the default value on creation for the version field.
+	user.DefaultVersion = userDescVersion.Default.(int)
+	// userDescSubdivision is the schema descriptor for subdivision field.
+	userDescSubdivision := userFields[12].Descriptor()
+	// user.DefaultSubdivision holds the default value on creation for the subdivision field.
+	user.DefaultSubdivision = userDescSubdivision.Default.(string)
+	// userDescJobTitle is the schema descriptor for job_title field.
+	userDescJobTitle := userFields[13].Descriptor()
+	// user.DefaultJobTitle holds the default value on creation for the job_title field.
+	user.DefaultJobTitle = userDescJobTitle.Default.(string)
+	// userDescEmploymentRate is the schema descriptor for employment_rate field.
+	userDescEmploymentRate := userFields[14].Descriptor()
+	// user.DefaultEmploymentRate holds the default value on creation for the employment_rate field.
+	user.DefaultEmploymentRate = userDescEmploymentRate.Default.(float64)
+	// userDescPersonnelCategory is the schema descriptor for personnel_category field.
+	userDescPersonnelCategory := userFields[15].Descriptor()
+	// user.DefaultPersonnelCategory holds the default value on creation for the personnel_category field.
+	user.DefaultPersonnelCategory = userDescPersonnelCategory.Default.(int32)
+	// userDescEmploymentType is the schema descriptor for employment_type field.
+	userDescEmploymentType := userFields[16].Descriptor()
+	// user.DefaultEmploymentType holds the default value on creation for the employment_type field.
+	user.DefaultEmploymentType = userDescEmploymentType.Default.(int32)
+	// userDescAcademicDegree is the schema descriptor for academic_degree field.
+	userDescAcademicDegree := userFields[17].Descriptor()
+	// user.DefaultAcademicDegree holds the default value on creation for the academic_degree field.
+	user.DefaultAcademicDegree = userDescAcademicDegree.Default.(int32)
+	// userDescAcademicTitle is the schema descriptor for academic_title field.
+	userDescAcademicTitle := userFields[18].Descriptor()
+	// user.DefaultAcademicTitle holds the default value on creation for the academic_title field.
+	user.DefaultAcademicTitle = userDescAcademicTitle.Default.(string)
+	// userDescHonors is the schema descriptor for honors field.
+	userDescHonors := userFields[19].Descriptor()
+	// user.DefaultHonors holds the default value on creation for the honors field.
+	user.DefaultHonors = userDescHonors.Default.(string)
+	// userDescCategory is the schema descriptor for category field.
+	userDescCategory := userFields[20].Descriptor()
+	// user.DefaultCategory holds the default value on creation for the category field.
+	user.DefaultCategory = userDescCategory.Default.(string)
 	// userDescID is the schema descriptor for id field.
 	userDescID := userFields[0].Descriptor()
 	// user.DefaultID holds the default value on creation for the id field.
@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/gofrs/uuid/v5"
+)
+
+// AuditLog holds the schema definition for the AuditLog entity.
+type AuditLog struct {
+	ent.Schema
+}
+
+// Annotations of the AuditLog.
+func (AuditLog) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "audit_log"},
+	}
+}
+
+// Fields of the AuditLog.
+func (AuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(func() uuid.UUID { return uuid.Must(uuid.NewV7()) }).Unique(),
+		// org_id is the organization the operation was performed in, see the Department.
+		field.UUID("org_id", uuid.UUID{}).
+			Default(func() uuid.UUID { return uuid.Nil }).
+			Annotations(entsql.Default(uuid.Nil.String())),
+		field.UUID("actor_id", uuid.UUID{}),
+		field.String("action").NotEmpty(),
+		field.String("target_type").NotEmpty(),
+		field.UUID("target_id", uuid.UUID{}),
+		field.Time("timestamp").Default(time.Now).Immutable(),
+		field.JSON("details", map[string]any{}).Optional(),
+	}
+}
+
+// Indexes of the AuditLog.
+func (AuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("target_id", "timestamp"),
+		index.Fields("org_id", "timestamp"),
+	}
+}
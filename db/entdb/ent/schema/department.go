@@ -5,6 +5,7 @@ import (
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/gofrs/uuid/v5"
 )
 
@@ -16,9 +17,17 @@ type Department struct {
 func (Department) Fields() []ent.Field {
 	return []ent.Field{
 		field.UUID("id", uuid.UUID{}).Unique(),
+		// org_id is the organization of the department, the departments created before
+		// organizations were introduced belong to the default one, the nil UUID.
+		field.UUID("org_id", uuid.UUID{}).
+			Default(func() uuid.UUID { return uuid.Nil }).
+			Annotations(entsql.Default(uuid.Nil.String())),
 		field.String("name").
-			Unique().
 			NotEmpty(),
+		// normalized_name is the trimmed lower-case name, so that the names are unique regardless of case.
+		// It is optional only for the departments created before it was added, see the backfill in app.
+		field.String("normalized_name").
+			Optional(),
 		field.Text("description").
 			Optional(),
 	}
@@ -29,3 +38,11 @@ func (Department) Edges() []ent.Edge {
 		edge.To("users", User.Type).Annotations(entsql.OnDelete(entsql.Restrict)),
 	}
 }
+
+// Indexes of the Department. Names are unique within an organization.
+func (Department) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("org_id", "name").Unique(),
+		index.Fields("org_id", "normalized_name").Unique(),
+	}
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/gofrs/uuid/v5"
+)
+
+// EmploymentEvent holds the schema definition for the EmploymentEvent entity,
+// a change of the role or the department of a user.
+type EmploymentEvent struct {
+	ent.Schema
+}
+
+// Annotations of the EmploymentEvent.
+func (EmploymentEvent) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "employment_events"},
+	}
+}
+
+// Fields of the EmploymentEvent.
+func (EmploymentEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(func() uuid.UUID { return uuid.Must(uuid.NewV7()) }).Unique(),
+		field.UUID("user_id", uuid.UUID{}),
+		// The actor is uuid.Nil if the change was not made on behalf of anyone.
+		field.UUID("actor_id", uuid.UUID{}),
+		field.Int32("old_role_id"),
+		field.Int32("new_role_id"),
+		// Departments are not edges, so that the history outlives them.
+		field.UUID("old_department_id", uuid.UUID{}).Optional().Nillable(),
+		field.UUID("new_department_id", uuid.UUID{}).Optional().Nillable(),
+		field.Time("timestamp").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the EmploymentEvent.
+func (EmploymentEvent) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("employment_events").
+			Field("user_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the EmploymentEvent.
+func (EmploymentEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "timestamp"),
+	}
+}
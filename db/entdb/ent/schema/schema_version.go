@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// SchemaVersion holds the schema definition for the SchemaVersion entity,
+// a database schema applied by the migrations package.
+type SchemaVersion struct {
+	ent.Schema
+}
+
+// Annotations of the SchemaVersion.
+func (SchemaVersion) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "schema_versions"},
+	}
+}
+
+// Fields of the SchemaVersion.
+func (SchemaVersion) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("hash").NotEmpty().Immutable(),
+		field.Time("applied_at").Default(time.Now).Immutable(),
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/gofrs/uuid/v5"
 )
 
@@ -17,13 +18,32 @@ type User struct {
 func (User) Fields() []ent.Field {
 	return []ent.Field{
 		field.UUID("id", uuid.UUID{}).Default(func() uuid.UUID { return uuid.Must(uuid.NewV7()) }).Unique(),
+		// org_id is the organization of the user, see the Department.
+		field.UUID("org_id", uuid.UUID{}).
+			Default(func() uuid.UUID { return uuid.Nil }).
+			Annotations(entsql.Default(uuid.Nil.String())),
 		field.String("first_name"),
 		field.String("last_name"),
 		field.String("middle_name").Default(""),
 		field.String("picture_url").Optional(),
+		// email is stored normalized, see sesc.NormalizeEmail. Users can log in with it instead of their username.
+		field.String("email").Optional().Nillable().Unique(),
 		field.Bool("suspended").Default(false),
 		field.UUID("department_id", uuid.UUID{}).Optional().Nillable(),
 		field.Int32("role_id"),
+		field.Time("deleted_at").Optional().Nillable(),
+		field.Int("version").Default(1),
+		field.String("subdivision").Default(""),
+		field.String("job_title").Default(""),
+		field.Float("employment_rate").Default(0),
+		field.Int32("personnel_category").Default(0),
+		field.Int32("employment_type").Default(0),
+		field.Int32("academic_degree").Default(0),
+		field.String("academic_title").Default(""),
+		field.String("honors").Default(""),
+		field.String("category").Default(""),
+		field.Time("date_of_employment").Optional().Nillable(),
+		field.Time("unemployment_date").Optional().Nillable(),
 	}
 }
 
@@ -38,5 +58,18 @@ func (User) Edges() []ent.Edge {
 		edge.To("auth", AuthUser.Type).
 			Unique().
 			Annotations(entsql.OnDelete(entsql.Cascade)),
+
+		edge.To("permissions", UserPermission.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+
+		edge.To("employment_events", EmploymentEvent.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the User.
+func (User) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("org_id"),
 	}
 }
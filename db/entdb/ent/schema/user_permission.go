@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/gofrs/uuid/v5"
+)
+
+// UserPermission holds the schema definition for the UserPermission entity,
+// a permission granted to a user in addition to the ones of their role.
+type UserPermission struct {
+	ent.Schema
+}
+
+// Annotations of the UserPermission.
+func (UserPermission) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "user_permissions"},
+	}
+}
+
+// Fields of the UserPermission.
+func (UserPermission) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("user_id", uuid.UUID{}),
+		field.Int32("permission_id"),
+	}
+}
+
+// Edges of the UserPermission.
+func (UserPermission) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("permissions").
+			Field("user_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the UserPermission.
+func (UserPermission) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "permission_id").Unique(),
+	}
+}
@@ -0,0 +1,117 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schemaversion"
+)
+
+// SchemaVersion is the model entity for the SchemaVersion schema.
+type SchemaVersion struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Hash holds the value of the "hash" field.
+	Hash string `json:"hash,omitempty"`
+	// AppliedAt holds the value of the "applied_at" field.
+	AppliedAt    time.Time `json:"applied_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*SchemaVersion) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case schemaversion.FieldID:
+			values[i] = new(sql.NullInt64)
+		case schemaversion.FieldHash:
+			values[i] = new(sql.NullString)
+		case schemaversion.FieldAppliedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the SchemaVersion fields.
+func (sv *SchemaVersion) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case schemaversion.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			sv.ID = int(value.Int64)
+		case schemaversion.FieldHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field hash", values[i])
+			} else if value.Valid {
+				sv.Hash = value.String
+			}
+		case schemaversion.FieldAppliedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field applied_at", values[i])
+			} else if value.Valid {
+				sv.AppliedAt = value.Time
+			}
+		default:
+			sv.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the SchemaVersion.
+// This includes values selected through modifiers, order, etc.
+func (sv *SchemaVersion) Value(name string) (ent.Value, error) {
+	return sv.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this SchemaVersion.
+// Note that you need to call SchemaVersion.Unwrap() before calling this method if this SchemaVersion
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (sv *SchemaVersion) Update() *SchemaVersionUpdateOne {
+	return NewSchemaVersionClient(sv.config).UpdateOne(sv)
+}
+
+// Unwrap unwraps the SchemaVersion entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (sv *SchemaVersion) Unwrap() *SchemaVersion {
+	_tx, ok := sv.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: SchemaVersion is not a transactional entity")
+	}
+	sv.config.driver = _tx.drv
+	return sv
+}
+
+// String implements the fmt.Stringer.
+func (sv *SchemaVersion) String() string {
+	var builder strings.Builder
+	builder.WriteString("SchemaVersion(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", sv.ID))
+	builder.WriteString("hash=")
+	builder.WriteString(sv.Hash)
+	builder.WriteString(", ")
+	builder.WriteString("applied_at=")
+	builder.WriteString(sv.AppliedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// SchemaVersions is a parsable slice of SchemaVersion.
+type SchemaVersions []*SchemaVersion
@@ -0,0 +1,185 @@
+// Code generated by ent, DO NOT EDIT.
+
+package schemaversion
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldLTE(FieldID, id))
+}
+
+// Hash applies equality check predicate on the "hash" field. It's identical to HashEQ.
+func Hash(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldEQ(FieldHash, v))
+}
+
+// AppliedAt applies equality check predicate on the "applied_at" field. It's identical to AppliedAtEQ.
+func AppliedAt(v time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldEQ(FieldAppliedAt, v))
+}
+
+// HashEQ applies the EQ predicate on the "hash" field.
+func HashEQ(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldEQ(FieldHash, v))
+}
+
+// HashNEQ applies the NEQ predicate on the "hash" field.
+func HashNEQ(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldNEQ(FieldHash, v))
+}
+
+// HashIn applies the In predicate on the "hash" field.
+func HashIn(vs ...string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldIn(FieldHash, vs...))
+}
+
+// HashNotIn applies the NotIn predicate on the "hash" field.
+func HashNotIn(vs ...string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldNotIn(FieldHash, vs...))
+}
+
+// HashGT applies the GT predicate on the "hash" field.
+func HashGT(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldGT(FieldHash, v))
+}
+
+// HashGTE applies the GTE predicate on the "hash" field.
+func HashGTE(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldGTE(FieldHash, v))
+}
+
+// HashLT applies the LT predicate on the "hash" field.
+func HashLT(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldLT(FieldHash, v))
+}
+
+// HashLTE applies the LTE predicate on the "hash" field.
+func HashLTE(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldLTE(FieldHash, v))
+}
+
+// HashContains applies the Contains predicate on the "hash" field.
+func HashContains(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldContains(FieldHash, v))
+}
+
+// HashHasPrefix applies the HasPrefix predicate on the "hash" field.
+func HashHasPrefix(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldHasPrefix(FieldHash, v))
+}
+
+// HashHasSuffix applies the HasSuffix predicate on the "hash" field.
+func HashHasSuffix(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldHasSuffix(FieldHash, v))
+}
+
+// HashEqualFold applies the EqualFold predicate on the "hash" field.
+func HashEqualFold(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldEqualFold(FieldHash, v))
+}
+
+// HashContainsFold applies the ContainsFold predicate on the "hash" field.
+func HashContainsFold(v string) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldContainsFold(FieldHash, v))
+}
+
+// AppliedAtEQ applies the EQ predicate on the "applied_at" field.
+func AppliedAtEQ(v time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldEQ(FieldAppliedAt, v))
+}
+
+// AppliedAtNEQ applies the NEQ predicate on the "applied_at" field.
+func AppliedAtNEQ(v time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldNEQ(FieldAppliedAt, v))
+}
+
+// AppliedAtIn applies the In predicate on the "applied_at" field.
+func AppliedAtIn(vs ...time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldIn(FieldAppliedAt, vs...))
+}
+
+// AppliedAtNotIn applies the NotIn predicate on the "applied_at" field.
+func AppliedAtNotIn(vs ...time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldNotIn(FieldAppliedAt, vs...))
+}
+
+// AppliedAtGT applies the GT predicate on the "applied_at" field.
+func AppliedAtGT(v time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldGT(FieldAppliedAt, v))
+}
+
+// AppliedAtGTE applies the GTE predicate on the "applied_at" field.
+func AppliedAtGTE(v time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldGTE(FieldAppliedAt, v))
+}
+
+// AppliedAtLT applies the LT predicate on the "applied_at" field.
+func AppliedAtLT(v time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldLT(FieldAppliedAt, v))
+}
+
+// AppliedAtLTE applies the LTE predicate on the "applied_at" field.
+func AppliedAtLTE(v time.Time) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.FieldLTE(FieldAppliedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.SchemaVersion) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.SchemaVersion) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.SchemaVersion) predicate.SchemaVersion {
+	return predicate.SchemaVersion(sql.NotPredicates(p))
+}
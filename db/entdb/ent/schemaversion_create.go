@@ -0,0 +1,220 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schemaversion"
+)
+
+// SchemaVersionCreate is the builder for creating a SchemaVersion entity.
+type SchemaVersionCreate struct {
+	config
+	mutation *SchemaVersionMutation
+	hooks    []Hook
+}
+
+// SetHash sets the "hash" field.
+func (svc *SchemaVersionCreate) SetHash(s string) *SchemaVersionCreate {
+	svc.mutation.SetHash(s)
+	return svc
+}
+
+// SetAppliedAt sets the "applied_at" field.
+func (svc *SchemaVersionCreate) SetAppliedAt(t time.Time) *SchemaVersionCreate {
+	svc.mutation.SetAppliedAt(t)
+	return svc
+}
+
+// SetNillableAppliedAt sets the "applied_at" field if the given value is not nil.
+func (svc *SchemaVersionCreate) SetNillableAppliedAt(t *time.Time) *SchemaVersionCreate {
+	if t != nil {
+		svc.SetAppliedAt(*t)
+	}
+	return svc
+}
+
+// Mutation returns the SchemaVersionMutation object of the builder.
+func (svc *SchemaVersionCreate) Mutation() *SchemaVersionMutation {
+	return svc.mutation
+}
+
+// Save creates the SchemaVersion in the database.
+func (svc *SchemaVersionCreate) Save(ctx context.Context) (*SchemaVersion, error) {
+	svc.defaults()
+	return withHooks(ctx, svc.sqlSave, svc.mutation, svc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (svc *SchemaVersionCreate) SaveX(ctx context.Context) *SchemaVersion {
+	v, err := svc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (svc *SchemaVersionCreate) Exec(ctx context.Context) error {
+	_, err := svc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (svc *SchemaVersionCreate) ExecX(ctx context.Context) {
+	if err := svc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (svc *SchemaVersionCreate) defaults() {
+	if _, ok := svc.mutation.AppliedAt(); !ok {
+		v := schemaversion.DefaultAppliedAt()
+		svc.mutation.SetAppliedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (svc *SchemaVersionCreate) check() error {
+	if _, ok := svc.mutation.Hash(); !ok {
+		return &ValidationError{Name: "hash", err: errors.New(`ent: missing required field "SchemaVersion.hash"`)}
+	}
+	if v, ok := svc.mutation.Hash(); ok {
+		if err := schemaversion.HashValidator(v); err != nil {
+			return &ValidationError{Name: "hash", err: fmt.Errorf(`ent: validator failed for field "SchemaVersion.hash": %w`, err)}
+		}
+	}
+	if _, ok := svc.mutation.AppliedAt(); !ok {
+		return &ValidationError{Name: "applied_at", err: errors.New(`ent: missing required field "SchemaVersion.applied_at"`)}
+	}
+	return nil
+}
+
+func (svc *SchemaVersionCreate) sqlSave(ctx context.Context) (*SchemaVersion, error) {
+	if err := svc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := svc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, svc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	svc.mutation.id = &_node.ID
+	svc.mutation.done = true
+	return _node, nil
+}
+
+func (svc *SchemaVersionCreate) createSpec() (*SchemaVersion, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SchemaVersion{config: svc.config}
+		_spec = sqlgraph.NewCreateSpec(schemaversion.Table, sqlgraph.NewFieldSpec(schemaversion.FieldID, field.TypeInt))
+	)
+	if value, ok := svc.mutation.Hash(); ok {
+		_spec.SetField(schemaversion.FieldHash, field.TypeString, value)
+		_node.Hash = value
+	}
+	if value, ok := svc.mutation.AppliedAt(); ok {
+		_spec.SetField(schemaversion.FieldAppliedAt, field.TypeTime, value)
+		_node.AppliedAt = value
+	}
+	return _node, _spec
+}
+
+// SchemaVersionCreateBulk is the builder for creating many SchemaVersion entities in bulk.
+type SchemaVersionCreateBulk struct {
+	config
+	err      error
+	builders []*SchemaVersionCreate
+}
+
+// Save creates the SchemaVersion entities in the database.
+func (svcb *SchemaVersionCreateBulk) Save(ctx context.Context) ([]*SchemaVersion, error) {
+	if svcb.err != nil {
+		return nil, svcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(svcb.builders))
+	nodes := make([]*SchemaVersion, len(svcb.builders))
+	mutators := make([]Mutator, len(svcb.builders))
+	for i := range svcb.builders {
+		func(i int, root context.Context) {
+			builder := svcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SchemaVersionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, svcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, svcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, svcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (svcb *SchemaVersionCreateBulk) SaveX(ctx context.Context) []*SchemaVersion {
+	v, err := svcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (svcb *SchemaVersionCreateBulk) Exec(ctx context.Context) error {
+	_, err := svcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (svcb *SchemaVersionCreateBulk) ExecX(ctx context.Context) {
+	if err := svcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
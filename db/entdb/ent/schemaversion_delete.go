@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schemaversion"
+)
+
+// SchemaVersionDelete is the builder for deleting a SchemaVersion entity.
+type SchemaVersionDelete struct {
+	config
+	hooks    []Hook
+	mutation *SchemaVersionMutation
+}
+
+// Where appends a list predicates to the SchemaVersionDelete builder.
+func (svd *SchemaVersionDelete) Where(ps ...predicate.SchemaVersion) *SchemaVersionDelete {
+	svd.mutation.Where(ps...)
+	return svd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (svd *SchemaVersionDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, svd.sqlExec, svd.mutation, svd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (svd *SchemaVersionDelete) ExecX(ctx context.Context) int {
+	n, err := svd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (svd *SchemaVersionDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(schemaversion.Table, sqlgraph.NewFieldSpec(schemaversion.FieldID, field.TypeInt))
+	if ps := svd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, svd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	svd.mutation.done = true
+	return affected, err
+}
+
+// SchemaVersionDeleteOne is the builder for deleting a single SchemaVersion entity.
+type SchemaVersionDeleteOne struct {
+	svd *SchemaVersionDelete
+}
+
+// Where appends a list predicates to the SchemaVersionDelete builder.
+func (svdo *SchemaVersionDeleteOne) Where(ps ...predicate.SchemaVersion) *SchemaVersionDeleteOne {
+	svdo.svd.mutation.Where(ps...)
+	return svdo
+}
+
+// Exec executes the deletion query.
+func (svdo *SchemaVersionDeleteOne) Exec(ctx context.Context) error {
+	n, err := svdo.svd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{schemaversion.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (svdo *SchemaVersionDeleteOne) ExecX(ctx context.Context) {
+	if err := svdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
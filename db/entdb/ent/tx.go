@@ -12,12 +12,20 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// AuditLog is the client for interacting with the AuditLog builders.
+	AuditLog *AuditLogClient
 	// AuthUser is the client for interacting with the AuthUser builders.
 	AuthUser *AuthUserClient
 	// Department is the client for interacting with the Department builders.
 	Department *DepartmentClient
+	// EmploymentEvent is the client for interacting with the EmploymentEvent builders.
+	EmploymentEvent *EmploymentEventClient
+	// SchemaVersion is the client for interacting with the SchemaVersion builders.
+	SchemaVersion *SchemaVersionClient
 	// User is the client for interacting with the User builders.
 	User *UserClient
+	// UserPermission is the client for interacting with the UserPermission builders.
+	UserPermission *UserPermissionClient
 
 	// lazily loaded.
 	client     *Client
@@ -149,9 +157,13 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.AuditLog = NewAuditLogClient(tx.config)
 	tx.AuthUser = NewAuthUserClient(tx.config)
 	tx.Department = NewDepartmentClient(tx.config)
+	tx.EmploymentEvent = NewEmploymentEventClient(tx.config)
+	tx.SchemaVersion = NewSchemaVersionClient(tx.config)
 	tx.User = NewUserClient(tx.config)
+	tx.UserPermission = NewUserPermissionClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -161,7 +173,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: AuthUser.QueryXXX(), the query will be executed
+// applies a query, for example: AuditLog.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
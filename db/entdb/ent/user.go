@@ -5,6 +5,7 @@ package ent
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
@@ -19,6 +20,8 @@ type User struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID uuid.UUID `json:"id,omitempty"`
+	// OrgID holds the value of the "org_id" field.
+	OrgID uuid.UUID `json:"org_id,omitempty"`
 	// FirstName holds the value of the "first_name" field.
 	FirstName string `json:"first_name,omitempty"`
 	// LastName holds the value of the "last_name" field.
@@ -27,12 +30,40 @@ type User struct {
 	MiddleName string `json:"middle_name,omitempty"`
 	// PictureURL holds the value of the "picture_url" field.
 	PictureURL string `json:"picture_url,omitempty"`
+	// Email holds the value of the "email" field.
+	Email *string `json:"email,omitempty"`
 	// Suspended holds the value of the "suspended" field.
 	Suspended bool `json:"suspended,omitempty"`
 	// DepartmentID holds the value of the "department_id" field.
 	DepartmentID *uuid.UUID `json:"department_id,omitempty"`
 	// RoleID holds the value of the "role_id" field.
 	RoleID int32 `json:"role_id,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version,omitempty"`
+	// Subdivision holds the value of the "subdivision" field.
+	Subdivision string `json:"subdivision,omitempty"`
+	// JobTitle holds the value of the "job_title" field.
+	JobTitle string `json:"job_title,omitempty"`
+	// EmploymentRate holds the value of the "employment_rate" field.
+	EmploymentRate float64 `json:"employment_rate,omitempty"`
+	// PersonnelCategory holds the value of the "personnel_category" field.
+	PersonnelCategory int32 `json:"personnel_category,omitempty"`
+	// EmploymentType holds the value of the "employment_type" field.
+	EmploymentType int32 `json:"employment_type,omitempty"`
+	// AcademicDegree holds the value of the "academic_degree" field.
+	AcademicDegree int32 `json:"academic_degree,omitempty"`
+	// AcademicTitle holds the value of the "academic_title" field.
+	AcademicTitle string `json:"academic_title,omitempty"`
+	// Honors holds the value of the "honors" field.
+	Honors string `json:"honors,omitempty"`
+	// Category holds the value of the "category" field.
+	Category string `json:"category,omitempty"`
+	// DateOfEmployment holds the value of the "date_of_employment" field.
+	DateOfEmployment *time.Time `json:"date_of_employment,omitempty"`
+	// UnemploymentDate holds the value of the "unemployment_date" field.
+	UnemploymentDate *time.Time `json:"unemployment_date,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the UserQuery when eager-loading is set.
 	Edges        UserEdges `json:"edges"`
@@ -45,9 +76,13 @@ type UserEdges struct {
 	Department *Department `json:"department,omitempty"`
 	// Auth holds the value of the auth edge.
 	Auth *AuthUser `json:"auth,omitempty"`
+	// Permissions holds the value of the permissions edge.
+	Permissions []*UserPermission `json:"permissions,omitempty"`
+	// EmploymentEvents holds the value of the employment_events edge.
+	EmploymentEvents []*EmploymentEvent `json:"employment_events,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [4]bool
 }
 
 // DepartmentOrErr returns the Department value or an error if the edge
@@ -72,6 +107,24 @@ func (e UserEdges) AuthOrErr() (*AuthUser, error) {
 	return nil, &NotLoadedError{edge: "auth"}
 }
 
+// PermissionsOrErr returns the Permissions value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PermissionsOrErr() ([]*UserPermission, error) {
+	if e.loadedTypes[2] {
+		return e.Permissions, nil
+	}
+	return nil, &NotLoadedError{edge: "permissions"}
+}
+
+// EmploymentEventsOrErr returns the EmploymentEvents value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) EmploymentEventsOrErr() ([]*EmploymentEvent, error) {
+	if e.loadedTypes[3] {
+		return e.EmploymentEvents, nil
+	}
+	return nil, &NotLoadedError{edge: "employment_events"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -81,11 +134,15 @@ func (*User) scanValues(columns []string) ([]any, error) {
 			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
 		case user.FieldSuspended:
 			values[i] = new(sql.NullBool)
-		case user.FieldRoleID:
+		case user.FieldEmploymentRate:
+			values[i] = new(sql.NullFloat64)
+		case user.FieldRoleID, user.FieldVersion, user.FieldPersonnelCategory, user.FieldEmploymentType, user.FieldAcademicDegree:
 			values[i] = new(sql.NullInt64)
-		case user.FieldFirstName, user.FieldLastName, user.FieldMiddleName, user.FieldPictureURL:
+		case user.FieldFirstName, user.FieldLastName, user.FieldMiddleName, user.FieldPictureURL, user.FieldEmail, user.FieldSubdivision, user.FieldJobTitle, user.FieldAcademicTitle, user.FieldHonors, user.FieldCategory:
 			values[i] = new(sql.NullString)
-		case user.FieldID:
+		case user.FieldDeletedAt, user.FieldDateOfEmployment, user.FieldUnemploymentDate:
+			values[i] = new(sql.NullTime)
+		case user.FieldID, user.FieldOrgID:
 			values[i] = new(uuid.UUID)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -108,6 +165,12 @@ func (u *User) assignValues(columns []string, values []any) error {
 			} else if value != nil {
 				u.ID = *value
 			}
+		case user.FieldOrgID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field org_id", values[i])
+			} else if value != nil {
+				u.OrgID = *value
+			}
 		case user.FieldFirstName:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field first_name", values[i])
@@ -132,6 +195,13 @@ func (u *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				u.PictureURL = value.String
 			}
+		case user.FieldEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[i])
+			} else if value.Valid {
+				u.Email = new(string)
+				*u.Email = value.String
+			}
 		case user.FieldSuspended:
 			if value, ok := values[i].(*sql.NullBool); !ok {
 				return fmt.Errorf("unexpected type %T for field suspended", values[i])
@@ -151,6 +221,87 @@ func (u *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				u.RoleID = int32(value.Int64)
 			}
+		case user.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				u.DeletedAt = new(time.Time)
+				*u.DeletedAt = value.Time
+			}
+		case user.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				u.Version = int(value.Int64)
+			}
+		case user.FieldSubdivision:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field subdivision", values[i])
+			} else if value.Valid {
+				u.Subdivision = value.String
+			}
+		case user.FieldJobTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field job_title", values[i])
+			} else if value.Valid {
+				u.JobTitle = value.String
+			}
+		case user.FieldEmploymentRate:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field employment_rate", values[i])
+			} else if value.Valid {
+				u.EmploymentRate = value.Float64
+			}
+		case user.FieldPersonnelCategory:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field personnel_category", values[i])
+			} else if value.Valid {
+				u.PersonnelCategory = int32(value.Int64)
+			}
+		case user.FieldEmploymentType:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field employment_type", values[i])
+			} else if value.Valid {
+				u.EmploymentType = int32(value.Int64)
+			}
+		case user.FieldAcademicDegree:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field academic_degree", values[i])
+			} else if value.Valid {
+				u.AcademicDegree = int32(value.Int64)
+			}
+		case user.FieldAcademicTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field academic_title", values[i])
+			} else if value.Valid {
+				u.AcademicTitle = value.String
+			}
+		case user.FieldHonors:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field honors", values[i])
+			} else if value.Valid {
+				u.Honors = value.String
+			}
+		case user.FieldCategory:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field category", values[i])
+			} else if value.Valid {
+				u.Category = value.String
+			}
+		case user.FieldDateOfEmployment:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field date_of_employment", values[i])
+			} else if value.Valid {
+				u.DateOfEmployment = new(time.Time)
+				*u.DateOfEmployment = value.Time
+			}
+		case user.FieldUnemploymentDate:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field unemployment_date", values[i])
+			} else if value.Valid {
+				u.UnemploymentDate = new(time.Time)
+				*u.UnemploymentDate = value.Time
+			}
 		default:
 			u.selectValues.Set(columns[i], values[i])
 		}
@@ -174,6 +325,16 @@ func (u *User) QueryAuth() *AuthUserQuery {
 	return NewUserClient(u.config).QueryAuth(u)
 }
 
+// QueryPermissions queries the "permissions" edge of the User entity.
+func (u *User) QueryPermissions() *UserPermissionQuery {
+	return NewUserClient(u.config).QueryPermissions(u)
+}
+
+// QueryEmploymentEvents queries the "employment_events" edge of the User entity.
+func (u *User) QueryEmploymentEvents() *EmploymentEventQuery {
+	return NewUserClient(u.config).QueryEmploymentEvents(u)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -197,6 +358,9 @@ func (u *User) String() string {
 	var builder strings.Builder
 	builder.WriteString("User(")
 	builder.WriteString(fmt.Sprintf("id=%v, ", u.ID))
+	builder.WriteString("org_id=")
+	builder.WriteString(fmt.Sprintf("%v", u.OrgID))
+	builder.WriteString(", ")
 	builder.WriteString("first_name=")
 	builder.WriteString(u.FirstName)
 	builder.WriteString(", ")
@@ -209,6 +373,11 @@ func (u *User) String() string {
 	builder.WriteString("picture_url=")
 	builder.WriteString(u.PictureURL)
 	builder.WriteString(", ")
+	if v := u.Email; v != nil {
+		builder.WriteString("email=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
 	builder.WriteString("suspended=")
 	builder.WriteString(fmt.Sprintf("%v", u.Suspended))
 	builder.WriteString(", ")
@@ -219,6 +388,51 @@ func (u *User) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("role_id=")
 	builder.WriteString(fmt.Sprintf("%v", u.RoleID))
+	builder.WriteString(", ")
+	if v := u.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", u.Version))
+	builder.WriteString(", ")
+	builder.WriteString("subdivision=")
+	builder.WriteString(u.Subdivision)
+	builder.WriteString(", ")
+	builder.WriteString("job_title=")
+	builder.WriteString(u.JobTitle)
+	builder.WriteString(", ")
+	builder.WriteString("employment_rate=")
+	builder.WriteString(fmt.Sprintf("%v", u.EmploymentRate))
+	builder.WriteString(", ")
+	builder.WriteString("personnel_category=")
+	builder.WriteString(fmt.Sprintf("%v", u.PersonnelCategory))
+	builder.WriteString(", ")
+	builder.WriteString("employment_type=")
+	builder.WriteString(fmt.Sprintf("%v", u.EmploymentType))
+	builder.WriteString(", ")
+	builder.WriteString("academic_degree=")
+	builder.WriteString(fmt.Sprintf("%v", u.AcademicDegree))
+	builder.WriteString(", ")
+	builder.WriteString("academic_title=")
+	builder.WriteString(u.AcademicTitle)
+	builder.WriteString(", ")
+	builder.WriteString("honors=")
+	builder.WriteString(u.Honors)
+	builder.WriteString(", ")
+	builder.WriteString("category=")
+	builder.WriteString(u.Category)
+	builder.WriteString(", ")
+	if v := u.DateOfEmployment; v != nil {
+		builder.WriteString("date_of_employment=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := u.UnemploymentDate; v != nil {
+		builder.WriteString("unemployment_date=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
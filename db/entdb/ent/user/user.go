@@ -13,6 +13,8 @@ const (
 	Label = "user"
 	// FieldID holds the string denoting the id field in the database.
 	FieldID = "id"
+	// FieldOrgID holds the string denoting the org_id field in the database.
+	FieldOrgID = "org_id"
 	// FieldFirstName holds the string denoting the first_name field in the database.
 	FieldFirstName = "first_name"
 	// FieldLastName holds the string denoting the last_name field in the database.
@@ -21,16 +23,48 @@ const (
 	FieldMiddleName = "middle_name"
 	// FieldPictureURL holds the string denoting the picture_url field in the database.
 	FieldPictureURL = "picture_url"
+	// FieldEmail holds the string denoting the email field in the database.
+	FieldEmail = "email"
 	// FieldSuspended holds the string denoting the suspended field in the database.
 	FieldSuspended = "suspended"
 	// FieldDepartmentID holds the string denoting the department_id field in the database.
 	FieldDepartmentID = "department_id"
 	// FieldRoleID holds the string denoting the role_id field in the database.
 	FieldRoleID = "role_id"
+	// FieldDeletedAt holds the string denoting the deleted_at field in the database.
+	FieldDeletedAt = "deleted_at"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldSubdivision holds the string denoting the subdivision field in the database.
+	FieldSubdivision = "subdivision"
+	// FieldJobTitle holds the string denoting the job_title field in the database.
+	FieldJobTitle = "job_title"
+	// FieldEmploymentRate holds the string denoting the employment_rate field in the database.
+	FieldEmploymentRate = "employment_rate"
+	// FieldPersonnelCategory holds the string denoting the personnel_category field in the database.
+	FieldPersonnelCategory = "personnel_category"
+	// FieldEmploymentType holds the string denoting the employment_type field in the database.
+	FieldEmploymentType = "employment_type"
+	// FieldAcademicDegree holds the string denoting the academic_degree field in the database.
+	FieldAcademicDegree = "academic_degree"
+	// FieldAcademicTitle holds the string denoting the academic_title field in the database.
+	FieldAcademicTitle = "academic_title"
+	// FieldHonors holds the string denoting the honors field in the database.
+	FieldHonors = "honors"
+	// FieldCategory holds the string denoting the category field in the database.
+	FieldCategory = "category"
+	// FieldDateOfEmployment holds the string denoting the date_of_employment field in the database.
+	FieldDateOfEmployment = "date_of_employment"
+	// FieldUnemploymentDate holds the string denoting the unemployment_date field in the database.
+	FieldUnemploymentDate = "unemployment_date"
 	// EdgeDepartment holds the string denoting the department edge name in mutations.
 	EdgeDepartment = "department"
 	// EdgeAuth holds the string denoting the auth edge name in mutations.
 	EdgeAuth = "auth"
+	// EdgePermissions holds the string denoting the permissions edge name in mutations.
+	EdgePermissions = "permissions"
+	// EdgeEmploymentEvents holds the string denoting the employment_events edge name in mutations.
+	EdgeEmploymentEvents = "employment_events"
 	// Table holds the table name of the user in the database.
 	Table = "users"
 	// DepartmentTable is the table that holds the department relation/edge.
@@ -47,18 +81,47 @@ const (
 	AuthInverseTable = "auth_users"
 	// AuthColumn is the table column denoting the auth relation/edge.
 	AuthColumn = "user_id"
+	// PermissionsTable is the table that holds the permissions relation/edge.
+	PermissionsTable = "user_permissions"
+	// PermissionsInverseTable is the table name for the UserPermission entity.
+	// It exists in this package in order to avoid circular dependency with the "userpermission" package.
+	PermissionsInverseTable = "user_permissions"
+	// PermissionsColumn is the table column denoting the permissions relation/edge.
+	PermissionsColumn = "user_id"
+	// EmploymentEventsTable is the table that holds the employment_events relation/edge.
+	EmploymentEventsTable = "employment_events"
+	// EmploymentEventsInverseTable is the table name for the EmploymentEvent entity.
+	// It exists in this package in order to avoid circular dependency with the "employmentevent" package.
+	EmploymentEventsInverseTable = "employment_events"
+	// EmploymentEventsColumn is the table column denoting the employment_events relation/edge.
+	EmploymentEventsColumn = "user_id"
 )
 
 // Columns holds all SQL columns for user fields.
 var Columns = []string{
 	FieldID,
+	FieldOrgID,
 	FieldFirstName,
 	FieldLastName,
 	FieldMiddleName,
 	FieldPictureURL,
+	FieldEmail,
 	FieldSuspended,
 	FieldDepartmentID,
 	FieldRoleID,
+	FieldDeletedAt,
+	FieldVersion,
+	FieldSubdivision,
+	FieldJobTitle,
+	FieldEmploymentRate,
+	FieldPersonnelCategory,
+	FieldEmploymentType,
+	FieldAcademicDegree,
+	FieldAcademicTitle,
+	FieldHonors,
+	FieldCategory,
+	FieldDateOfEmployment,
+	FieldUnemploymentDate,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -72,10 +135,32 @@ func ValidColumn(column string) bool {
 }
 
 var (
+	// DefaultOrgID holds the default value on creation for the "org_id" field.
+	DefaultOrgID func() uuid.UUID
 	// DefaultMiddleName holds the default value on creation for the "middle_name" field.
 	DefaultMiddleName string
 	// DefaultSuspended holds the default value on creation for the "suspended" field.
 	DefaultSuspended bool
+	// DefaultVersion holds the default value on creation for the "version" field.
+	DefaultVersion int
+	// DefaultSubdivision holds the default value on creation for the "subdivision" field.
+	DefaultSubdivision string
+	// DefaultJobTitle holds the default value on creation for the "job_title" field.
+	DefaultJobTitle string
+	// DefaultEmploymentRate holds the default value on creation for the "employment_rate" field.
+	DefaultEmploymentRate float64
+	// DefaultPersonnelCategory holds the default value on creation for the "personnel_category" field.
+	DefaultPersonnelCategory int32
+	// DefaultEmploymentType holds the default value on creation for the "employment_type" field.
+	DefaultEmploymentType int32
+	// DefaultAcademicDegree holds the default value on creation for the "academic_degree" field.
+	DefaultAcademicDegree int32
+	// DefaultAcademicTitle holds the default value on creation for the "academic_title" field.
+	DefaultAcademicTitle string
+	// DefaultHonors holds the default value on creation for the "honors" field.
+	DefaultHonors string
+	// DefaultCategory holds the default value on creation for the "category" field.
+	DefaultCategory string
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() uuid.UUID
 )
@@ -88,6 +173,11 @@ func ByID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldID, opts...).ToFunc()
 }
 
+// ByOrgID orders the results by the org_id field.
+func ByOrgID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOrgID, opts...).ToFunc()
+}
+
 // ByFirstName orders the results by the first_name field.
 func ByFirstName(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldFirstName, opts...).ToFunc()
@@ -108,6 +198,11 @@ func ByPictureURL(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPictureURL, opts...).ToFunc()
 }
 
+// ByEmail orders the results by the email field.
+func ByEmail(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEmail, opts...).ToFunc()
+}
+
 // BySuspended orders the results by the suspended field.
 func BySuspended(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSuspended, opts...).ToFunc()
@@ -123,6 +218,71 @@ func ByRoleID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldRoleID, opts...).ToFunc()
 }
 
+// ByDeletedAt orders the results by the deleted_at field.
+func ByDeletedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeletedAt, opts...).ToFunc()
+}
+
+// ByVersion orders the results by the version field.
+func ByVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersion, opts...).ToFunc()
+}
+
+// BySubdivision orders the results by the subdivision field.
+func BySubdivision(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSubdivision, opts...).ToFunc()
+}
+
+// ByJobTitle orders the results by the job_title field.
+func ByJobTitle(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldJobTitle, opts...).ToFunc()
+}
+
+// ByEmploymentRate orders the results by the employment_rate field.
+func ByEmploymentRate(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEmploymentRate, opts...).ToFunc()
+}
+
+// ByPersonnelCategory orders the results by the personnel_category field.
+func ByPersonnelCategory(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPersonnelCategory, opts...).ToFunc()
+}
+
+// ByEmploymentType orders the results by the employment_type field.
+func ByEmploymentType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEmploymentType, opts...).ToFunc()
+}
+
+// ByAcademicDegree orders the results by the academic_degree field.
+func ByAcademicDegree(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAcademicDegree, opts...).ToFunc()
+}
+
+// ByAcademicTitle orders the results by the academic_title field.
+func ByAcademicTitle(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAcademicTitle, opts...).ToFunc()
+}
+
+// ByHonors orders the results by the honors field.
+func ByHonors(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldHonors, opts...).ToFunc()
+}
+
+// ByCategory orders the results by the category field.
+func ByCategory(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCategory, opts...).ToFunc()
+}
+
+// ByDateOfEmployment orders the results by the date_of_employment field.
+func ByDateOfEmployment(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDateOfEmployment, opts...).ToFunc()
+}
+
+// ByUnemploymentDate orders the results by the unemployment_date field.
+func ByUnemploymentDate(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUnemploymentDate, opts...).ToFunc()
+}
+
 // ByDepartmentField orders the results by department field.
 func ByDepartmentField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -136,6 +296,34 @@ func ByAuthField(field string, opts ...sql.OrderTermOption) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newAuthStep(), sql.OrderByField(field, opts...))
 	}
 }
+
+// ByPermissionsCount orders the results by permissions count.
+func ByPermissionsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newPermissionsStep(), opts...)
+	}
+}
+
+// ByPermissions orders the results by permissions terms.
+func ByPermissions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newPermissionsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByEmploymentEventsCount orders the results by employment_events count.
+func ByEmploymentEventsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newEmploymentEventsStep(), opts...)
+	}
+}
+
+// ByEmploymentEvents orders the results by employment_events terms.
+func ByEmploymentEvents(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newEmploymentEventsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newDepartmentStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -150,3 +338,17 @@ func newAuthStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2O, false, AuthTable, AuthColumn),
 	)
 }
+func newPermissionsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(PermissionsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, PermissionsTable, PermissionsColumn),
+	)
+}
+func newEmploymentEventsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(EmploymentEventsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, EmploymentEventsTable, EmploymentEventsColumn),
+	)
+}
@@ -3,6 +3,8 @@
 package user
 
 import (
+	"time"
+
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	uuid "github.com/gofrs/uuid/v5"
@@ -54,6 +56,11 @@ func IDLTE(id uuid.UUID) predicate.User {
 	return predicate.User(sql.FieldLTE(FieldID, id))
 }
 
+// OrgID applies equality check predicate on the "org_id" field. It's identical to OrgIDEQ.
+func OrgID(v uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldOrgID, v))
+}
+
 // FirstName applies equality check predicate on the "first_name" field. It's identical to FirstNameEQ.
 func FirstName(v string) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldFirstName, v))
@@ -74,6 +81,11 @@ func PictureURL(v string) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldPictureURL, v))
 }
 
+// Email applies equality check predicate on the "email" field. It's identical to EmailEQ.
+func Email(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldEmail, v))
+}
+
 // Suspended applies equality check predicate on the "suspended" field. It's identical to SuspendedEQ.
 func Suspended(v bool) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldSuspended, v))
@@ -89,6 +101,111 @@ func RoleID(v int32) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldRoleID, v))
 }
 
+// DeletedAt applies equality check predicate on the "deleted_at" field. It's identical to DeletedAtEQ.
+func DeletedAt(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDeletedAt, v))
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldVersion, v))
+}
+
+// Subdivision applies equality check predicate on the "subdivision" field. It's identical to SubdivisionEQ.
+func Subdivision(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldSubdivision, v))
+}
+
+// JobTitle applies equality check predicate on the "job_title" field. It's identical to JobTitleEQ.
+func JobTitle(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldJobTitle, v))
+}
+
+// EmploymentRate applies equality check predicate on the "employment_rate" field. It's identical to EmploymentRateEQ.
+func EmploymentRate(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldEmploymentRate, v))
+}
+
+// PersonnelCategory applies equality check predicate on the "personnel_category" field. It's identical to PersonnelCategoryEQ.
+func PersonnelCategory(v int32) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPersonnelCategory, v))
+}
+
+// EmploymentType applies equality check predicate on the "employment_type" field. It's identical to EmploymentTypeEQ.
+func EmploymentType(v int32) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldEmploymentType, v))
+}
+
+// AcademicDegree applies equality check predicate on the "academic_degree" field. It's identical to AcademicDegreeEQ.
+func AcademicDegree(v int32) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldAcademicDegree, v))
+}
+
+// AcademicTitle applies equality check predicate on the "academic_title" field. It's identical to AcademicTitleEQ.
+func AcademicTitle(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldAcademicTitle, v))
+}
+
+// Honors applies equality check predicate on the "honors" field. It's identical to HonorsEQ.
+func Honors(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldHonors, v))
+}
+
+// Category applies equality check predicate on the "category" field. It's identical to CategoryEQ.
+func Category(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldCategory, v))
+}
+
+// DateOfEmployment applies equality check predicate on the "date_of_employment" field. It's identical to DateOfEmploymentEQ.
+func DateOfEmployment(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDateOfEmployment, v))
+}
+
+// UnemploymentDate applies equality check predicate on the "unemployment_date" field. It's identical to UnemploymentDateEQ.
+func UnemploymentDate(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldUnemploymentDate, v))
+}
+
+// OrgIDEQ applies the EQ predicate on the "org_id" field.
+func OrgIDEQ(v uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldOrgID, v))
+}
+
+// OrgIDNEQ applies the NEQ predicate on the "org_id" field.
+func OrgIDNEQ(v uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldOrgID, v))
+}
+
+// OrgIDIn applies the In predicate on the "org_id" field.
+func OrgIDIn(vs ...uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldIn(FieldOrgID, vs...))
+}
+
+// OrgIDNotIn applies the NotIn predicate on the "org_id" field.
+func OrgIDNotIn(vs ...uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldOrgID, vs...))
+}
+
+// OrgIDGT applies the GT predicate on the "org_id" field.
+func OrgIDGT(v uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldGT(FieldOrgID, v))
+}
+
+// OrgIDGTE applies the GTE predicate on the "org_id" field.
+func OrgIDGTE(v uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldOrgID, v))
+}
+
+// OrgIDLT applies the LT predicate on the "org_id" field.
+func OrgIDLT(v uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldLT(FieldOrgID, v))
+}
+
+// OrgIDLTE applies the LTE predicate on the "org_id" field.
+func OrgIDLTE(v uuid.UUID) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldOrgID, v))
+}
+
 // FirstNameEQ applies the EQ predicate on the "first_name" field.
 func FirstNameEQ(v string) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldFirstName, v))
@@ -359,6 +476,81 @@ func PictureURLContainsFold(v string) predicate.User {
 	return predicate.User(sql.FieldContainsFold(FieldPictureURL, v))
 }
 
+// EmailEQ applies the EQ predicate on the "email" field.
+func EmailEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldEmail, v))
+}
+
+// EmailNEQ applies the NEQ predicate on the "email" field.
+func EmailNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldEmail, v))
+}
+
+// EmailIn applies the In predicate on the "email" field.
+func EmailIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldEmail, vs...))
+}
+
+// EmailNotIn applies the NotIn predicate on the "email" field.
+func EmailNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldEmail, vs...))
+}
+
+// EmailGT applies the GT predicate on the "email" field.
+func EmailGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldEmail, v))
+}
+
+// EmailGTE applies the GTE predicate on the "email" field.
+func EmailGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldEmail, v))
+}
+
+// EmailLT applies the LT predicate on the "email" field.
+func EmailLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldEmail, v))
+}
+
+// EmailLTE applies the LTE predicate on the "email" field.
+func EmailLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldEmail, v))
+}
+
+// EmailContains applies the Contains predicate on the "email" field.
+func EmailContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldEmail, v))
+}
+
+// EmailHasPrefix applies the HasPrefix predicate on the "email" field.
+func EmailHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldEmail, v))
+}
+
+// EmailHasSuffix applies the HasSuffix predicate on the "email" field.
+func EmailHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldEmail, v))
+}
+
+// EmailIsNil applies the IsNil predicate on the "email" field.
+func EmailIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldEmail))
+}
+
+// EmailNotNil applies the NotNil predicate on the "email" field.
+func EmailNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldEmail))
+}
+
+// EmailEqualFold applies the EqualFold predicate on the "email" field.
+func EmailEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldEmail, v))
+}
+
+// EmailContainsFold applies the ContainsFold predicate on the "email" field.
+func EmailContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldEmail, v))
+}
+
 // SuspendedEQ applies the EQ predicate on the "suspended" field.
 func SuspendedEQ(v bool) predicate.User {
 	return predicate.User(sql.FieldEQ(FieldSuspended, v))
@@ -439,6 +631,681 @@ func RoleIDLTE(v int32) predicate.User {
 	return predicate.User(sql.FieldLTE(FieldRoleID, v))
 }
 
+// DeletedAtEQ applies the EQ predicate on the "deleted_at" field.
+func DeletedAtEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDeletedAt, v))
+}
+
+// DeletedAtNEQ applies the NEQ predicate on the "deleted_at" field.
+func DeletedAtNEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldDeletedAt, v))
+}
+
+// DeletedAtIn applies the In predicate on the "deleted_at" field.
+func DeletedAtIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldIn(FieldDeletedAt, vs...))
+}
+
+// DeletedAtNotIn applies the NotIn predicate on the "deleted_at" field.
+func DeletedAtNotIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldDeletedAt, vs...))
+}
+
+// DeletedAtGT applies the GT predicate on the "deleted_at" field.
+func DeletedAtGT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGT(FieldDeletedAt, v))
+}
+
+// DeletedAtGTE applies the GTE predicate on the "deleted_at" field.
+func DeletedAtGTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldDeletedAt, v))
+}
+
+// DeletedAtLT applies the LT predicate on the "deleted_at" field.
+func DeletedAtLT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLT(FieldDeletedAt, v))
+}
+
+// DeletedAtLTE applies the LTE predicate on the "deleted_at" field.
+func DeletedAtLTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldDeletedAt, v))
+}
+
+// DeletedAtIsNil applies the IsNil predicate on the "deleted_at" field.
+func DeletedAtIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldDeletedAt))
+}
+
+// DeletedAtNotNil applies the NotNil predicate on the "deleted_at" field.
+func DeletedAtNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldDeletedAt))
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.User {
+	return predicate.User(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.User {
+	return predicate.User(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldVersion, v))
+}
+
+// SubdivisionEQ applies the EQ predicate on the "subdivision" field.
+func SubdivisionEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldSubdivision, v))
+}
+
+// SubdivisionNEQ applies the NEQ predicate on the "subdivision" field.
+func SubdivisionNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldSubdivision, v))
+}
+
+// SubdivisionIn applies the In predicate on the "subdivision" field.
+func SubdivisionIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldSubdivision, vs...))
+}
+
+// SubdivisionNotIn applies the NotIn predicate on the "subdivision" field.
+func SubdivisionNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldSubdivision, vs...))
+}
+
+// SubdivisionGT applies the GT predicate on the "subdivision" field.
+func SubdivisionGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldSubdivision, v))
+}
+
+// SubdivisionGTE applies the GTE predicate on the "subdivision" field.
+func SubdivisionGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldSubdivision, v))
+}
+
+// SubdivisionLT applies the LT predicate on the "subdivision" field.
+func SubdivisionLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldSubdivision, v))
+}
+
+// SubdivisionLTE applies the LTE predicate on the "subdivision" field.
+func SubdivisionLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldSubdivision, v))
+}
+
+// SubdivisionContains applies the Contains predicate on the "subdivision" field.
+func SubdivisionContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldSubdivision, v))
+}
+
+// SubdivisionHasPrefix applies the HasPrefix predicate on the "subdivision" field.
+func SubdivisionHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldSubdivision, v))
+}
+
+// SubdivisionHasSuffix applies the HasSuffix predicate on the "subdivision" field.
+func SubdivisionHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldSubdivision, v))
+}
+
+// SubdivisionEqualFold applies the EqualFold predicate on the "subdivision" field.
+func SubdivisionEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldSubdivision, v))
+}
+
+// SubdivisionContainsFold applies the ContainsFold predicate on the "subdivision" field.
+func SubdivisionContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldSubdivision, v))
+}
+
+// JobTitleEQ applies the EQ predicate on the "job_title" field.
+func JobTitleEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldJobTitle, v))
+}
+
+// JobTitleNEQ applies the NEQ predicate on the "job_title" field.
+func JobTitleNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldJobTitle, v))
+}
+
+// JobTitleIn applies the In predicate on the "job_title" field.
+func JobTitleIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldJobTitle, vs...))
+}
+
+// JobTitleNotIn applies the NotIn predicate on the "job_title" field.
+func JobTitleNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldJobTitle, vs...))
+}
+
+// JobTitleGT applies the GT predicate on the "job_title" field.
+func JobTitleGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldJobTitle, v))
+}
+
+// JobTitleGTE applies the GTE predicate on the "job_title" field.
+func JobTitleGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldJobTitle, v))
+}
+
+// JobTitleLT applies the LT predicate on the "job_title" field.
+func JobTitleLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldJobTitle, v))
+}
+
+// JobTitleLTE applies the LTE predicate on the "job_title" field.
+func JobTitleLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldJobTitle, v))
+}
+
+// JobTitleContains applies the Contains predicate on the "job_title" field.
+func JobTitleContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldJobTitle, v))
+}
+
+// JobTitleHasPrefix applies the HasPrefix predicate on the "job_title" field.
+func JobTitleHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldJobTitle, v))
+}
+
+// JobTitleHasSuffix applies the HasSuffix predicate on the "job_title" field.
+func JobTitleHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldJobTitle, v))
+}
+
+// JobTitleEqualFold applies the EqualFold predicate on the "job_title" field.
+func JobTitleEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldJobTitle, v))
+}
+
+// JobTitleContainsFold applies the ContainsFold predicate on the "job_title" field.
+func JobTitleContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldJobTitle, v))
+}
+
+// EmploymentRateEQ applies the EQ predicate on the "employment_rate" field.
+func EmploymentRateEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldEmploymentRate, v))
+}
+
+// EmploymentRateNEQ applies the NEQ predicate on the "employment_rate" field.
+func EmploymentRateNEQ(v float64) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldEmploymentRate, v))
+}
+
+// EmploymentRateIn applies the In predicate on the "employment_rate" field.
+func EmploymentRateIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldIn(FieldEmploymentRate, vs...))
+}
+
+// EmploymentRateNotIn applies the NotIn predicate on the "employment_rate" field.
+func EmploymentRateNotIn(vs ...float64) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldEmploymentRate, vs...))
+}
+
+// EmploymentRateGT applies the GT predicate on the "employment_rate" field.
+func EmploymentRateGT(v float64) predicate.User {
+	return predicate.User(sql.FieldGT(FieldEmploymentRate, v))
+}
+
+// EmploymentRateGTE applies the GTE predicate on the "employment_rate" field.
+func EmploymentRateGTE(v float64) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldEmploymentRate, v))
+}
+
+// EmploymentRateLT applies the LT predicate on the "employment_rate" field.
+func EmploymentRateLT(v float64) predicate.User {
+	return predicate.User(sql.FieldLT(FieldEmploymentRate, v))
+}
+
+// EmploymentRateLTE applies the LTE predicate on the "employment_rate" field.
+func EmploymentRateLTE(v float64) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldEmploymentRate, v))
+}
+
+// PersonnelCategoryEQ applies the EQ predicate on the "personnel_category" field.
+func PersonnelCategoryEQ(v int32) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldPersonnelCategory, v))
+}
+
+// PersonnelCategoryNEQ applies the NEQ predicate on the "personnel_category" field.
+func PersonnelCategoryNEQ(v int32) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldPersonnelCategory, v))
+}
+
+// PersonnelCategoryIn applies the In predicate on the "personnel_category" field.
+func PersonnelCategoryIn(vs ...int32) predicate.User {
+	return predicate.User(sql.FieldIn(FieldPersonnelCategory, vs...))
+}
+
+// PersonnelCategoryNotIn applies the NotIn predicate on the "personnel_category" field.
+func PersonnelCategoryNotIn(vs ...int32) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldPersonnelCategory, vs...))
+}
+
+// PersonnelCategoryGT applies the GT predicate on the "personnel_category" field.
+func PersonnelCategoryGT(v int32) predicate.User {
+	return predicate.User(sql.FieldGT(FieldPersonnelCategory, v))
+}
+
+// PersonnelCategoryGTE applies the GTE predicate on the "personnel_category" field.
+func PersonnelCategoryGTE(v int32) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldPersonnelCategory, v))
+}
+
+// PersonnelCategoryLT applies the LT predicate on the "personnel_category" field.
+func PersonnelCategoryLT(v int32) predicate.User {
+	return predicate.User(sql.FieldLT(FieldPersonnelCategory, v))
+}
+
+// PersonnelCategoryLTE applies the LTE predicate on the "personnel_category" field.
+func PersonnelCategoryLTE(v int32) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldPersonnelCategory, v))
+}
+
+// EmploymentTypeEQ applies the EQ predicate on the "employment_type" field.
+func EmploymentTypeEQ(v int32) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldEmploymentType, v))
+}
+
+// EmploymentTypeNEQ applies the NEQ predicate on the "employment_type" field.
+func EmploymentTypeNEQ(v int32) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldEmploymentType, v))
+}
+
+// EmploymentTypeIn applies the In predicate on the "employment_type" field.
+func EmploymentTypeIn(vs ...int32) predicate.User {
+	return predicate.User(sql.FieldIn(FieldEmploymentType, vs...))
+}
+
+// EmploymentTypeNotIn applies the NotIn predicate on the "employment_type" field.
+func EmploymentTypeNotIn(vs ...int32) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldEmploymentType, vs...))
+}
+
+// EmploymentTypeGT applies the GT predicate on the "employment_type" field.
+func EmploymentTypeGT(v int32) predicate.User {
+	return predicate.User(sql.FieldGT(FieldEmploymentType, v))
+}
+
+// EmploymentTypeGTE applies the GTE predicate on the "employment_type" field.
+func EmploymentTypeGTE(v int32) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldEmploymentType, v))
+}
+
+// EmploymentTypeLT applies the LT predicate on the "employment_type" field.
+func EmploymentTypeLT(v int32) predicate.User {
+	return predicate.User(sql.FieldLT(FieldEmploymentType, v))
+}
+
+// EmploymentTypeLTE applies the LTE predicate on the "employment_type" field.
+func EmploymentTypeLTE(v int32) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldEmploymentType, v))
+}
+
+// AcademicDegreeEQ applies the EQ predicate on the "academic_degree" field.
+func AcademicDegreeEQ(v int32) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldAcademicDegree, v))
+}
+
+// AcademicDegreeNEQ applies the NEQ predicate on the "academic_degree" field.
+func AcademicDegreeNEQ(v int32) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldAcademicDegree, v))
+}
+
+// AcademicDegreeIn applies the In predicate on the "academic_degree" field.
+func AcademicDegreeIn(vs ...int32) predicate.User {
+	return predicate.User(sql.FieldIn(FieldAcademicDegree, vs...))
+}
+
+// AcademicDegreeNotIn applies the NotIn predicate on the "academic_degree" field.
+func AcademicDegreeNotIn(vs ...int32) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldAcademicDegree, vs...))
+}
+
+// AcademicDegreeGT applies the GT predicate on the "academic_degree" field.
+func AcademicDegreeGT(v int32) predicate.User {
+	return predicate.User(sql.FieldGT(FieldAcademicDegree, v))
+}
+
+// AcademicDegreeGTE applies the GTE predicate on the "academic_degree" field.
+func AcademicDegreeGTE(v int32) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldAcademicDegree, v))
+}
+
+// AcademicDegreeLT applies the LT predicate on the "academic_degree" field.
+func AcademicDegreeLT(v int32) predicate.User {
+	return predicate.User(sql.FieldLT(FieldAcademicDegree, v))
+}
+
+// AcademicDegreeLTE applies the LTE predicate on the "academic_degree" field.
+func AcademicDegreeLTE(v int32) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldAcademicDegree, v))
+}
+
+// AcademicTitleEQ applies the EQ predicate on the "academic_title" field.
+func AcademicTitleEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldAcademicTitle, v))
+}
+
+// AcademicTitleNEQ applies the NEQ predicate on the "academic_title" field.
+func AcademicTitleNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldAcademicTitle, v))
+}
+
+// AcademicTitleIn applies the In predicate on the "academic_title" field.
+func AcademicTitleIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldAcademicTitle, vs...))
+}
+
+// AcademicTitleNotIn applies the NotIn predicate on the "academic_title" field.
+func AcademicTitleNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldAcademicTitle, vs...))
+}
+
+// AcademicTitleGT applies the GT predicate on the "academic_title" field.
+func AcademicTitleGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldAcademicTitle, v))
+}
+
+// AcademicTitleGTE applies the GTE predicate on the "academic_title" field.
+func AcademicTitleGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldAcademicTitle, v))
+}
+
+// AcademicTitleLT applies the LT predicate on the "academic_title" field.
+func AcademicTitleLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldAcademicTitle, v))
+}
+
+// AcademicTitleLTE applies the LTE predicate on the "academic_title" field.
+func AcademicTitleLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldAcademicTitle, v))
+}
+
+// AcademicTitleContains applies the Contains predicate on the "academic_title" field.
+func AcademicTitleContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldAcademicTitle, v))
+}
+
+// AcademicTitleHasPrefix applies the HasPrefix predicate on the "academic_title" field.
+func AcademicTitleHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldAcademicTitle, v))
+}
+
+// AcademicTitleHasSuffix applies the HasSuffix predicate on the "academic_title" field.
+func AcademicTitleHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldAcademicTitle, v))
+}
+
+// AcademicTitleEqualFold applies the EqualFold predicate on the "academic_title" field.
+func AcademicTitleEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldAcademicTitle, v))
+}
+
+// AcademicTitleContainsFold applies the ContainsFold predicate on the "academic_title" field.
+func AcademicTitleContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldAcademicTitle, v))
+}
+
+// HonorsEQ applies the EQ predicate on the "honors" field.
+func HonorsEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldHonors, v))
+}
+
+// HonorsNEQ applies the NEQ predicate on the "honors" field.
+func HonorsNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldHonors, v))
+}
+
+// HonorsIn applies the In predicate on the "honors" field.
+func HonorsIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldHonors, vs...))
+}
+
+// HonorsNotIn applies the NotIn predicate on the "honors" field.
+func HonorsNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldHonors, vs...))
+}
+
+// HonorsGT applies the GT predicate on the "honors" field.
+func HonorsGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldHonors, v))
+}
+
+// HonorsGTE applies the GTE predicate on the "honors" field.
+func HonorsGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldHonors, v))
+}
+
+// HonorsLT applies the LT predicate on the "honors" field.
+func HonorsLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldHonors, v))
+}
+
+// HonorsLTE applies the LTE predicate on the "honors" field.
+func HonorsLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldHonors, v))
+}
+
+// HonorsContains applies the Contains predicate on the "honors" field.
+func HonorsContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldHonors, v))
+}
+
+// HonorsHasPrefix applies the HasPrefix predicate on the "honors" field.
+func HonorsHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldHonors, v))
+}
+
+// HonorsHasSuffix applies the HasSuffix predicate on the "honors" field.
+func HonorsHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldHonors, v))
+}
+
+// HonorsEqualFold applies the EqualFold predicate on the "honors" field.
+func HonorsEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldHonors, v))
+}
+
+// HonorsContainsFold applies the ContainsFold predicate on the "honors" field.
+func HonorsContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldHonors, v))
+}
+
+// CategoryEQ applies the EQ predicate on the "category" field.
+func CategoryEQ(v string) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldCategory, v))
+}
+
+// CategoryNEQ applies the NEQ predicate on the "category" field.
+func CategoryNEQ(v string) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldCategory, v))
+}
+
+// CategoryIn applies the In predicate on the "category" field.
+func CategoryIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldIn(FieldCategory, vs...))
+}
+
+// CategoryNotIn applies the NotIn predicate on the "category" field.
+func CategoryNotIn(vs ...string) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldCategory, vs...))
+}
+
+// CategoryGT applies the GT predicate on the "category" field.
+func CategoryGT(v string) predicate.User {
+	return predicate.User(sql.FieldGT(FieldCategory, v))
+}
+
+// CategoryGTE applies the GTE predicate on the "category" field.
+func CategoryGTE(v string) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldCategory, v))
+}
+
+// CategoryLT applies the LT predicate on the "category" field.
+func CategoryLT(v string) predicate.User {
+	return predicate.User(sql.FieldLT(FieldCategory, v))
+}
+
+// CategoryLTE applies the LTE predicate on the "category" field.
+func CategoryLTE(v string) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldCategory, v))
+}
+
+// CategoryContains applies the Contains predicate on the "category" field.
+func CategoryContains(v string) predicate.User {
+	return predicate.User(sql.FieldContains(FieldCategory, v))
+}
+
+// CategoryHasPrefix applies the HasPrefix predicate on the "category" field.
+func CategoryHasPrefix(v string) predicate.User {
+	return predicate.User(sql.FieldHasPrefix(FieldCategory, v))
+}
+
+// CategoryHasSuffix applies the HasSuffix predicate on the "category" field.
+func CategoryHasSuffix(v string) predicate.User {
+	return predicate.User(sql.FieldHasSuffix(FieldCategory, v))
+}
+
+// CategoryEqualFold applies the EqualFold predicate on the "category" field.
+func CategoryEqualFold(v string) predicate.User {
+	return predicate.User(sql.FieldEqualFold(FieldCategory, v))
+}
+
+// CategoryContainsFold applies the ContainsFold predicate on the "category" field.
+func CategoryContainsFold(v string) predicate.User {
+	return predicate.User(sql.FieldContainsFold(FieldCategory, v))
+}
+
+// DateOfEmploymentEQ applies the EQ predicate on the "date_of_employment" field.
+func DateOfEmploymentEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldDateOfEmployment, v))
+}
+
+// DateOfEmploymentNEQ applies the NEQ predicate on the "date_of_employment" field.
+func DateOfEmploymentNEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldDateOfEmployment, v))
+}
+
+// DateOfEmploymentIn applies the In predicate on the "date_of_employment" field.
+func DateOfEmploymentIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldIn(FieldDateOfEmployment, vs...))
+}
+
+// DateOfEmploymentNotIn applies the NotIn predicate on the "date_of_employment" field.
+func DateOfEmploymentNotIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldDateOfEmployment, vs...))
+}
+
+// DateOfEmploymentGT applies the GT predicate on the "date_of_employment" field.
+func DateOfEmploymentGT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGT(FieldDateOfEmployment, v))
+}
+
+// DateOfEmploymentGTE applies the GTE predicate on the "date_of_employment" field.
+func DateOfEmploymentGTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldDateOfEmployment, v))
+}
+
+// DateOfEmploymentLT applies the LT predicate on the "date_of_employment" field.
+func DateOfEmploymentLT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLT(FieldDateOfEmployment, v))
+}
+
+// DateOfEmploymentLTE applies the LTE predicate on the "date_of_employment" field.
+func DateOfEmploymentLTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldDateOfEmployment, v))
+}
+
+// DateOfEmploymentIsNil applies the IsNil predicate on the "date_of_employment" field.
+func DateOfEmploymentIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldDateOfEmployment))
+}
+
+// DateOfEmploymentNotNil applies the NotNil predicate on the "date_of_employment" field.
+func DateOfEmploymentNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldDateOfEmployment))
+}
+
+// UnemploymentDateEQ applies the EQ predicate on the "unemployment_date" field.
+func UnemploymentDateEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldEQ(FieldUnemploymentDate, v))
+}
+
+// UnemploymentDateNEQ applies the NEQ predicate on the "unemployment_date" field.
+func UnemploymentDateNEQ(v time.Time) predicate.User {
+	return predicate.User(sql.FieldNEQ(FieldUnemploymentDate, v))
+}
+
+// UnemploymentDateIn applies the In predicate on the "unemployment_date" field.
+func UnemploymentDateIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldIn(FieldUnemploymentDate, vs...))
+}
+
+// UnemploymentDateNotIn applies the NotIn predicate on the "unemployment_date" field.
+func UnemploymentDateNotIn(vs ...time.Time) predicate.User {
+	return predicate.User(sql.FieldNotIn(FieldUnemploymentDate, vs...))
+}
+
+// UnemploymentDateGT applies the GT predicate on the "unemployment_date" field.
+func UnemploymentDateGT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGT(FieldUnemploymentDate, v))
+}
+
+// UnemploymentDateGTE applies the GTE predicate on the "unemployment_date" field.
+func UnemploymentDateGTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldGTE(FieldUnemploymentDate, v))
+}
+
+// UnemploymentDateLT applies the LT predicate on the "unemployment_date" field.
+func UnemploymentDateLT(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLT(FieldUnemploymentDate, v))
+}
+
+// UnemploymentDateLTE applies the LTE predicate on the "unemployment_date" field.
+func UnemploymentDateLTE(v time.Time) predicate.User {
+	return predicate.User(sql.FieldLTE(FieldUnemploymentDate, v))
+}
+
+// UnemploymentDateIsNil applies the IsNil predicate on the "unemployment_date" field.
+func UnemploymentDateIsNil() predicate.User {
+	return predicate.User(sql.FieldIsNull(FieldUnemploymentDate))
+}
+
+// UnemploymentDateNotNil applies the NotNil predicate on the "unemployment_date" field.
+func UnemploymentDateNotNil() predicate.User {
+	return predicate.User(sql.FieldNotNull(FieldUnemploymentDate))
+}
+
 // HasDepartment applies the HasEdge predicate on the "department" edge.
 func HasDepartment() predicate.User {
 	return predicate.User(func(s *sql.Selector) {
@@ -485,6 +1352,52 @@ func HasAuthWith(preds ...predicate.AuthUser) predicate.User {
 	})
 }
 
+// HasPermissions applies the HasEdge predicate on the "permissions" edge.
+func HasPermissions() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, PermissionsTable, PermissionsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasPermissionsWith applies the HasEdge predicate on the "permissions" edge with a given conditions (other predicates).
+func HasPermissionsWith(preds ...predicate.UserPermission) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newPermissionsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasEmploymentEvents applies the HasEdge predicate on the "employment_events" edge.
+func HasEmploymentEvents() predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, EmploymentEventsTable, EmploymentEventsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasEmploymentEventsWith applies the HasEdge predicate on the "employment_events" edge with a given conditions (other predicates).
+func HasEmploymentEventsWith(preds ...predicate.EmploymentEvent) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		step := newEmploymentEventsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.User) predicate.User {
 	return predicate.User(sql.AndPredicates(predicates...))
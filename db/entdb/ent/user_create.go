@@ -6,13 +6,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	uuid "github.com/gofrs/uuid/v5"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/authuser"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/userpermission"
 )
 
 // UserCreate is the builder for creating a User entity.
@@ -22,6 +25,20 @@ type UserCreate struct {
 	hooks    []Hook
 }
 
+// SetOrgID sets the "org_id" field.
+func (uc *UserCreate) SetOrgID(u uuid.UUID) *UserCreate {
+	uc.mutation.SetOrgID(u)
+	return uc
+}
+
+// SetNillableOrgID sets the "org_id" field if the given value is not nil.
+func (uc *UserCreate) SetNillableOrgID(u *uuid.UUID) *UserCreate {
+	if u != nil {
+		uc.SetOrgID(*u)
+	}
+	return uc
+}
+
 // SetFirstName sets the "first_name" field.
 func (uc *UserCreate) SetFirstName(s string) *UserCreate {
 	uc.mutation.SetFirstName(s)
@@ -62,6 +79,20 @@ func (uc *UserCreate) SetNillablePictureURL(s *string) *UserCreate {
 	return uc
 }
 
+// SetEmail sets the "email" field.
+func (uc *UserCreate) SetEmail(s string) *UserCreate {
+	uc.mutation.SetEmail(s)
+	return uc
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (uc *UserCreate) SetNillableEmail(s *string) *UserCreate {
+	if s != nil {
+		uc.SetEmail(*s)
+	}
+	return uc
+}
+
 // SetSuspended sets the "suspended" field.
 func (uc *UserCreate) SetSuspended(b bool) *UserCreate {
 	uc.mutation.SetSuspended(b)
@@ -96,6 +127,188 @@ func (uc *UserCreate) SetRoleID(i int32) *UserCreate {
 	return uc
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (uc *UserCreate) SetDeletedAt(t time.Time) *UserCreate {
+	uc.mutation.SetDeletedAt(t)
+	return uc
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDeletedAt(t *time.Time) *UserCreate {
+	if t != nil {
+		uc.SetDeletedAt(*t)
+	}
+	return uc
+}
+
+// SetVersion sets the "version" field.
+func (uc *UserCreate) SetVersion(i int) *UserCreate {
+	uc.mutation.SetVersion(i)
+	return uc
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (uc *UserCreate) SetNillableVersion(i *int) *UserCreate {
+	if i != nil {
+		uc.SetVersion(*i)
+	}
+	return uc
+}
+
+// SetSubdivision sets the "subdivision" field.
+func (uc *UserCreate) SetSubdivision(s string) *UserCreate {
+	uc.mutation.SetSubdivision(s)
+	return uc
+}
+
+// SetNillableSubdivision sets the "subdivision" field if the given value is not nil.
+func (uc *UserCreate) SetNillableSubdivision(s *string) *UserCreate {
+	if s != nil {
+		uc.SetSubdivision(*s)
+	}
+	return uc
+}
+
+// SetJobTitle sets the "job_title" field.
+func (uc *UserCreate) SetJobTitle(s string) *UserCreate {
+	uc.mutation.SetJobTitle(s)
+	return uc
+}
+
+// SetNillableJobTitle sets the "job_title" field if the given value is not nil.
+func (uc *UserCreate) SetNillableJobTitle(s *string) *UserCreate {
+	if s != nil {
+		uc.SetJobTitle(*s)
+	}
+	return uc
+}
+
+// SetEmploymentRate sets the "employment_rate" field.
+func (uc *UserCreate) SetEmploymentRate(f float64) *UserCreate {
+	uc.mutation.SetEmploymentRate(f)
+	return uc
+}
+
+// SetNillableEmploymentRate sets the "employment_rate" field if the given value is not nil.
+func (uc *UserCreate) SetNillableEmploymentRate(f *float64) *UserCreate {
+	if f != nil {
+		uc.SetEmploymentRate(*f)
+	}
+	return uc
+}
+
+// SetPersonnelCategory sets the "personnel_category" field.
+func (uc *UserCreate) SetPersonnelCategory(i int32) *UserCreate {
+	uc.mutation.SetPersonnelCategory(i)
+	return uc
+}
+
+// SetNillablePersonnelCategory sets the "personnel_category" field if the given value is not nil.
+func (uc *UserCreate) SetNillablePersonnelCategory(i *int32) *UserCreate {
+	if i != nil {
+		uc.SetPersonnelCategory(*i)
+	}
+	return uc
+}
+
+// SetEmploymentType sets the "employment_type" field.
+func (uc *UserCreate) SetEmploymentType(i int32) *UserCreate {
+	uc.mutation.SetEmploymentType(i)
+	return uc
+}
+
+// SetNillableEmploymentType sets the "employment_type" field if the given value is not nil.
+func (uc *UserCreate) SetNillableEmploymentType(i *int32) *UserCreate {
+	if i != nil {
+		uc.SetEmploymentType(*i)
+	}
+	return uc
+}
+
+// SetAcademicDegree sets the "academic_degree" field.
+func (uc *UserCreate) SetAcademicDegree(i int32) *UserCreate {
+	uc.mutation.SetAcademicDegree(i)
+	return uc
+}
+
+// SetNillableAcademicDegree sets the "academic_degree" field if the given value is not nil.
+func (uc *UserCreate) SetNillableAcademicDegree(i *int32) *UserCreate {
+	if i != nil {
+		uc.SetAcademicDegree(*i)
+	}
+	return uc
+}
+
+// SetAcademicTitle sets the "academic_title" field.
+func (uc *UserCreate) SetAcademicTitle(s string) *UserCreate {
+	uc.mutation.SetAcademicTitle(s)
+	return uc
+}
+
+// SetNillableAcademicTitle sets the "academic_title" field if the given value is not nil.
+func (uc *UserCreate) SetNillableAcademicTitle(s *string) *UserCreate {
+	if s != nil {
+		uc.SetAcademicTitle(*s)
+	}
+	return uc
+}
+
+// SetHonors sets the "honors" field.
+func (uc *UserCreate) SetHonors(s string) *UserCreate {
+	uc.mutation.SetHonors(s)
+	return uc
+}
+
+// SetNillableHonors sets the "honors" field if the given value is not nil.
+func (uc *UserCreate) SetNillableHonors(s *string) *UserCreate {
+	if s != nil {
+		uc.SetHonors(*s)
+	}
+	return uc
+}
+
+// SetCategory sets the "category" field.
+func (uc *UserCreate) SetCategory(s string) *UserCreate {
+	uc.mutation.SetCategory(s)
+	return uc
+}
+
+// SetNillableCategory sets the "category" field if the given value is not nil.
+func (uc *UserCreate) SetNillableCategory(s *string) *UserCreate {
+	if s != nil {
+		uc.SetCategory(*s)
+	}
+	return uc
+}
+
+// SetDateOfEmployment sets the "date_of_employment" field.
+func (uc *UserCreate) SetDateOfEmployment(t time.Time) *UserCreate {
+	uc.mutation.SetDateOfEmployment(t)
+	return uc
+}
+
+// SetNillableDateOfEmployment sets the "date_of_employment" field if the given value is not nil.
+func (uc *UserCreate) SetNillableDateOfEmployment(t *time.Time) *UserCreate {
+	if t != nil {
+		uc.SetDateOfEmployment(*t)
+	}
+	return uc
+}
+
+// SetUnemploymentDate sets the "unemployment_date" field.
+func (uc *UserCreate) SetUnemploymentDate(t time.Time) *UserCreate {
+	uc.mutation.SetUnemploymentDate(t)
+	return uc
+}
+
+// SetNillableUnemploymentDate sets the "unemployment_date" field if the given value is not nil.
+func (uc *UserCreate) SetNillableUnemploymentDate(t *time.Time) *UserCreate {
+	if t != nil {
+		uc.SetUnemploymentDate(*t)
+	}
+	return uc
+}
+
 // SetID sets the "id" field.
 func (uc *UserCreate) SetID(u uuid.UUID) *UserCreate {
 	uc.mutation.SetID(u)
@@ -134,6 +347,36 @@ func (uc *UserCreate) SetAuth(a *AuthUser) *UserCreate {
 	return uc.SetAuthID(a.ID)
 }
 
+// AddPermissionIDs adds the "permissions" edge to the UserPermission entity by IDs.
+func (uc *UserCreate) AddPermissionIDs(ids ...int) *UserCreate {
+	uc.mutation.AddPermissionIDs(ids...)
+	return uc
+}
+
+// AddPermissions adds the "permissions" edges to the UserPermission entity.
+func (uc *UserCreate) AddPermissions(u ...*UserPermission) *UserCreate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return uc.AddPermissionIDs(ids...)
+}
+
+// AddEmploymentEventIDs adds the "employment_events" edge to the EmploymentEvent entity by IDs.
+func (uc *UserCreate) AddEmploymentEventIDs(ids ...uuid.UUID) *UserCreate {
+	uc.mutation.AddEmploymentEventIDs(ids...)
+	return uc
+}
+
+// AddEmploymentEvents adds the "employment_events" edges to the EmploymentEvent entity.
+func (uc *UserCreate) AddEmploymentEvents(e ...*EmploymentEvent) *UserCreate {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return uc.AddEmploymentEventIDs(ids...)
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (uc *UserCreate) Mutation() *UserMutation {
 	return uc.mutation
@@ -169,6 +412,10 @@ func (uc *UserCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (uc *UserCreate) defaults() {
+	if _, ok := uc.mutation.OrgID(); !ok {
+		v := user.DefaultOrgID()
+		uc.mutation.SetOrgID(v)
+	}
 	if _, ok := uc.mutation.MiddleName(); !ok {
 		v := user.DefaultMiddleName
 		uc.mutation.SetMiddleName(v)
@@ -177,6 +424,46 @@ func (uc *UserCreate) defaults() {
 		v := user.DefaultSuspended
 		uc.mutation.SetSuspended(v)
 	}
+	if _, ok := uc.mutation.Version(); !ok {
+		v := user.DefaultVersion
+		uc.mutation.SetVersion(v)
+	}
+	if _, ok := uc.mutation.Subdivision(); !ok {
+		v := user.DefaultSubdivision
+		uc.mutation.SetSubdivision(v)
+	}
+	if _, ok := uc.mutation.JobTitle(); !ok {
+		v := user.DefaultJobTitle
+		uc.mutation.SetJobTitle(v)
+	}
+	if _, ok := uc.mutation.EmploymentRate(); !ok {
+		v := user.DefaultEmploymentRate
+		uc.mutation.SetEmploymentRate(v)
+	}
+	if _, ok := uc.mutation.PersonnelCategory(); !ok {
+		v := user.DefaultPersonnelCategory
+		uc.mutation.SetPersonnelCategory(v)
+	}
+	if _, ok := uc.mutation.EmploymentType(); !ok {
+		v := user.DefaultEmploymentType
+		uc.mutation.SetEmploymentType(v)
+	}
+	if _, ok := uc.mutation.AcademicDegree(); !ok {
+		v := user.DefaultAcademicDegree
+		uc.mutation.SetAcademicDegree(v)
+	}
+	if _, ok := uc.mutation.AcademicTitle(); !ok {
+		v := user.DefaultAcademicTitle
+		uc.mutation.SetAcademicTitle(v)
+	}
+	if _, ok := uc.mutation.Honors(); !ok {
+		v := user.DefaultHonors
+		uc.mutation.SetHonors(v)
+	}
+	if _, ok := uc.mutation.Category(); !ok {
+		v := user.DefaultCategory
+		uc.mutation.SetCategory(v)
+	}
 	if _, ok := uc.mutation.ID(); !ok {
 		v := user.DefaultID()
 		uc.mutation.SetID(v)
@@ -185,6 +472,9 @@ func (uc *UserCreate) defaults() {
 
 // check runs all checks and user-defined validators on the builder.
 func (uc *UserCreate) check() error {
+	if _, ok := uc.mutation.OrgID(); !ok {
+		return &ValidationError{Name: "org_id", err: errors.New(`ent: missing required field "User.org_id"`)}
+	}
 	if _, ok := uc.mutation.FirstName(); !ok {
 		return &ValidationError{Name: "first_name", err: errors.New(`ent: missing required field "User.first_name"`)}
 	}
@@ -200,6 +490,36 @@ func (uc *UserCreate) check() error {
 	if _, ok := uc.mutation.RoleID(); !ok {
 		return &ValidationError{Name: "role_id", err: errors.New(`ent: missing required field "User.role_id"`)}
 	}
+	if _, ok := uc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "User.version"`)}
+	}
+	if _, ok := uc.mutation.Subdivision(); !ok {
+		return &ValidationError{Name: "subdivision", err: errors.New(`ent: missing required field "User.subdivision"`)}
+	}
+	if _, ok := uc.mutation.JobTitle(); !ok {
+		return &ValidationError{Name: "job_title", err: errors.New(`ent: missing required field "User.job_title"`)}
+	}
+	if _, ok := uc.mutation.EmploymentRate(); !ok {
+		return &ValidationError{Name: "employment_rate", err: errors.New(`ent: missing required field "User.employment_rate"`)}
+	}
+	if _, ok := uc.mutation.PersonnelCategory(); !ok {
+		return &ValidationError{Name: "personnel_category", err: errors.New(`ent: missing required field "User.personnel_category"`)}
+	}
+	if _, ok := uc.mutation.EmploymentType(); !ok {
+		return &ValidationError{Name: "employment_type", err: errors.New(`ent: missing required field "User.employment_type"`)}
+	}
+	if _, ok := uc.mutation.AcademicDegree(); !ok {
+		return &ValidationError{Name: "academic_degree", err: errors.New(`ent: missing required field "User.academic_degree"`)}
+	}
+	if _, ok := uc.mutation.AcademicTitle(); !ok {
+		return &ValidationError{Name: "academic_title", err: errors.New(`ent: missing required field "User.academic_title"`)}
+	}
+	if _, ok := uc.mutation.Honors(); !ok {
+		return &ValidationError{Name: "honors", err: errors.New(`ent: missing required field "User.honors"`)}
+	}
+	if _, ok := uc.mutation.Category(); !ok {
+		return &ValidationError{Name: "category", err: errors.New(`ent: missing required field "User.category"`)}
+	}
 	return nil
 }
 
@@ -235,6 +555,10 @@ func (uc *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_node.ID = id
 		_spec.ID.Value = &id
 	}
+	if value, ok := uc.mutation.OrgID(); ok {
+		_spec.SetField(user.FieldOrgID, field.TypeUUID, value)
+		_node.OrgID = value
+	}
 	if value, ok := uc.mutation.FirstName(); ok {
 		_spec.SetField(user.FieldFirstName, field.TypeString, value)
 		_node.FirstName = value
@@ -251,6 +575,10 @@ func (uc *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_spec.SetField(user.FieldPictureURL, field.TypeString, value)
 		_node.PictureURL = value
 	}
+	if value, ok := uc.mutation.Email(); ok {
+		_spec.SetField(user.FieldEmail, field.TypeString, value)
+		_node.Email = &value
+	}
 	if value, ok := uc.mutation.Suspended(); ok {
 		_spec.SetField(user.FieldSuspended, field.TypeBool, value)
 		_node.Suspended = value
@@ -259,6 +587,58 @@ func (uc *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_spec.SetField(user.FieldRoleID, field.TypeInt32, value)
 		_node.RoleID = value
 	}
+	if value, ok := uc.mutation.DeletedAt(); ok {
+		_spec.SetField(user.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
+	if value, ok := uc.mutation.Version(); ok {
+		_spec.SetField(user.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := uc.mutation.Subdivision(); ok {
+		_spec.SetField(user.FieldSubdivision, field.TypeString, value)
+		_node.Subdivision = value
+	}
+	if value, ok := uc.mutation.JobTitle(); ok {
+		_spec.SetField(user.FieldJobTitle, field.TypeString, value)
+		_node.JobTitle = value
+	}
+	if value, ok := uc.mutation.EmploymentRate(); ok {
+		_spec.SetField(user.FieldEmploymentRate, field.TypeFloat64, value)
+		_node.EmploymentRate = value
+	}
+	if value, ok := uc.mutation.PersonnelCategory(); ok {
+		_spec.SetField(user.FieldPersonnelCategory, field.TypeInt32, value)
+		_node.PersonnelCategory = value
+	}
+	if value, ok := uc.mutation.EmploymentType(); ok {
+		_spec.SetField(user.FieldEmploymentType, field.TypeInt32, value)
+		_node.EmploymentType = value
+	}
+	if value, ok := uc.mutation.AcademicDegree(); ok {
+		_spec.SetField(user.FieldAcademicDegree, field.TypeInt32, value)
+		_node.AcademicDegree = value
+	}
+	if value, ok := uc.mutation.AcademicTitle(); ok {
+		_spec.SetField(user.FieldAcademicTitle, field.TypeString, value)
+		_node.AcademicTitle = value
+	}
+	if value, ok := uc.mutation.Honors(); ok {
+		_spec.SetField(user.FieldHonors, field.TypeString, value)
+		_node.Honors = value
+	}
+	if value, ok := uc.mutation.Category(); ok {
+		_spec.SetField(user.FieldCategory, field.TypeString, value)
+		_node.Category = value
+	}
+	if value, ok := uc.mutation.DateOfEmployment(); ok {
+		_spec.SetField(user.FieldDateOfEmployment, field.TypeTime, value)
+		_node.DateOfEmployment = &value
+	}
+	if value, ok := uc.mutation.UnemploymentDate(); ok {
+		_spec.SetField(user.FieldUnemploymentDate, field.TypeTime, value)
+		_node.UnemploymentDate = &value
+	}
 	if nodes := uc.mutation.DepartmentIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -292,6 +672,38 @@ func (uc *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := uc.mutation.PermissionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.PermissionsTable,
+			Columns: []string{user.PermissionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userpermission.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := uc.mutation.EmploymentEventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.EmploymentEventsTable,
+			Columns: []string{user.EmploymentEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -13,8 +14,10 @@ import (
 	uuid "github.com/gofrs/uuid/v5"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/authuser"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/userpermission"
 )
 
 // UserUpdate is the builder for updating User entities.
@@ -30,6 +33,20 @@ func (uu *UserUpdate) Where(ps ...predicate.User) *UserUpdate {
 	return uu
 }
 
+// SetOrgID sets the "org_id" field.
+func (uu *UserUpdate) SetOrgID(u uuid.UUID) *UserUpdate {
+	uu.mutation.SetOrgID(u)
+	return uu
+}
+
+// SetNillableOrgID sets the "org_id" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableOrgID(u *uuid.UUID) *UserUpdate {
+	if u != nil {
+		uu.SetOrgID(*u)
+	}
+	return uu
+}
+
 // SetFirstName sets the "first_name" field.
 func (uu *UserUpdate) SetFirstName(s string) *UserUpdate {
 	uu.mutation.SetFirstName(s)
@@ -92,6 +109,26 @@ func (uu *UserUpdate) ClearPictureURL() *UserUpdate {
 	return uu
 }
 
+// SetEmail sets the "email" field.
+func (uu *UserUpdate) SetEmail(s string) *UserUpdate {
+	uu.mutation.SetEmail(s)
+	return uu
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableEmail(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetEmail(*s)
+	}
+	return uu
+}
+
+// ClearEmail clears the value of the "email" field.
+func (uu *UserUpdate) ClearEmail() *UserUpdate {
+	uu.mutation.ClearEmail()
+	return uu
+}
+
 // SetSuspended sets the "suspended" field.
 func (uu *UserUpdate) SetSuspended(b bool) *UserUpdate {
 	uu.mutation.SetSuspended(b)
@@ -147,6 +184,241 @@ func (uu *UserUpdate) AddRoleID(i int32) *UserUpdate {
 	return uu
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (uu *UserUpdate) SetDeletedAt(t time.Time) *UserUpdate {
+	uu.mutation.SetDeletedAt(t)
+	return uu
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDeletedAt(t *time.Time) *UserUpdate {
+	if t != nil {
+		uu.SetDeletedAt(*t)
+	}
+	return uu
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (uu *UserUpdate) ClearDeletedAt() *UserUpdate {
+	uu.mutation.ClearDeletedAt()
+	return uu
+}
+
+// SetVersion sets the "version" field.
+func (uu *UserUpdate) SetVersion(i int) *UserUpdate {
+	uu.mutation.ResetVersion()
+	uu.mutation.SetVersion(i)
+	return uu
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableVersion(i *int) *UserUpdate {
+	if i != nil {
+		uu.SetVersion(*i)
+	}
+	return uu
+}
+
+// AddVersion adds i to the "version" field.
+func (uu *UserUpdate) AddVersion(i int) *UserUpdate {
+	uu.mutation.AddVersion(i)
+	return uu
+}
+
+// SetSubdivision sets the "subdivision" field.
+func (uu *UserUpdate) SetSubdivision(s string) *UserUpdate {
+	uu.mutation.SetSubdivision(s)
+	return uu
+}
+
+// SetNillableSubdivision sets the "subdivision" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableSubdivision(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetSubdivision(*s)
+	}
+	return uu
+}
+
+// SetJobTitle sets the "job_title" field.
+func (uu *UserUpdate) SetJobTitle(s string) *UserUpdate {
+	uu.mutation.SetJobTitle(s)
+	return uu
+}
+
+// SetNillableJobTitle sets the "job_title" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableJobTitle(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetJobTitle(*s)
+	}
+	return uu
+}
+
+// SetEmploymentRate sets the "employment_rate" field.
+func (uu *UserUpdate) SetEmploymentRate(f float64) *UserUpdate {
+	uu.mutation.ResetEmploymentRate()
+	uu.mutation.SetEmploymentRate(f)
+	return uu
+}
+
+// SetNillableEmploymentRate sets the "employment_rate" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableEmploymentRate(f *float64) *UserUpdate {
+	if f != nil {
+		uu.SetEmploymentRate(*f)
+	}
+	return uu
+}
+
+// AddEmploymentRate adds f to the "employment_rate" field.
+func (uu *UserUpdate) AddEmploymentRate(f float64) *UserUpdate {
+	uu.mutation.AddEmploymentRate(f)
+	return uu
+}
+
+// SetPersonnelCategory sets the "personnel_category" field.
+func (uu *UserUpdate) SetPersonnelCategory(i int32) *UserUpdate {
+	uu.mutation.ResetPersonnelCategory()
+	uu.mutation.SetPersonnelCategory(i)
+	return uu
+}
+
+// SetNillablePersonnelCategory sets the "personnel_category" field if the given value is not nil.
+func (uu *UserUpdate) SetNillablePersonnelCategory(i *int32) *UserUpdate {
+	if i != nil {
+		uu.SetPersonnelCategory(*i)
+	}
+	return uu
+}
+
+// AddPersonnelCategory adds i to the "personnel_category" field.
+func (uu *UserUpdate) AddPersonnelCategory(i int32) *UserUpdate {
+	uu.mutation.AddPersonnelCategory(i)
+	return uu
+}
+
+// SetEmploymentType sets the "employment_type" field.
+func (uu *UserUpdate) SetEmploymentType(i int32) *UserUpdate {
+	uu.mutation.ResetEmploymentType()
+	uu.mutation.SetEmploymentType(i)
+	return uu
+}
+
+// SetNillableEmploymentType sets the "employment_type" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableEmploymentType(i *int32) *UserUpdate {
+	if i != nil {
+		uu.SetEmploymentType(*i)
+	}
+	return uu
+}
+
+// AddEmploymentType adds i to the "employment_type" field.
+func (uu *UserUpdate) AddEmploymentType(i int32) *UserUpdate {
+	uu.mutation.AddEmploymentType(i)
+	return uu
+}
+
+// SetAcademicDegree sets the "academic_degree" field.
+func (uu *UserUpdate) SetAcademicDegree(i int32) *UserUpdate {
+	uu.mutation.ResetAcademicDegree()
+	uu.mutation.SetAcademicDegree(i)
+	return uu
+}
+
+// SetNillableAcademicDegree sets the "academic_degree" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableAcademicDegree(i *int32) *UserUpdate {
+	if i != nil {
+		uu.SetAcademicDegree(*i)
+	}
+	return uu
+}
+
+// AddAcademicDegree adds i to the "academic_degree" field.
+func (uu *UserUpdate) AddAcademicDegree(i int32) *UserUpdate {
+	uu.mutation.AddAcademicDegree(i)
+	return uu
+}
+
+// SetAcademicTitle sets the "academic_title" field.
+func (uu *UserUpdate) SetAcademicTitle(s string) *UserUpdate {
+	uu.mutation.SetAcademicTitle(s)
+	return uu
+}
+
+// SetNillableAcademicTitle sets the "academic_title" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableAcademicTitle(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetAcademicTitle(*s)
+	}
+	return uu
+}
+
+// SetHonors sets the "honors" field.
+func (uu *UserUpdate) SetHonors(s string) *UserUpdate {
+	uu.mutation.SetHonors(s)
+	return uu
+}
+
+// SetNillableHonors sets the "honors" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableHonors(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetHonors(*s)
+	}
+	return uu
+}
+
+// SetCategory sets the "category" field.
+func (uu *UserUpdate) SetCategory(s string) *UserUpdate {
+	uu.mutation.SetCategory(s)
+	return uu
+}
+
+// SetNillableCategory sets the "category" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableCategory(s *string) *UserUpdate {
+	if s != nil {
+		uu.SetCategory(*s)
+	}
+	return uu
+}
+
+// SetDateOfEmployment sets the "date_of_employment" field.
+func (uu *UserUpdate) SetDateOfEmployment(t time.Time) *UserUpdate {
+	uu.mutation.SetDateOfEmployment(t)
+	return uu
+}
+
+// SetNillableDateOfEmployment sets the "date_of_employment" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableDateOfEmployment(t *time.Time) *UserUpdate {
+	if t != nil {
+		uu.SetDateOfEmployment(*t)
+	}
+	return uu
+}
+
+// ClearDateOfEmployment clears the value of the "date_of_employment" field.
+func (uu *UserUpdate) ClearDateOfEmployment() *UserUpdate {
+	uu.mutation.ClearDateOfEmployment()
+	return uu
+}
+
+// SetUnemploymentDate sets the "unemployment_date" field.
+func (uu *UserUpdate) SetUnemploymentDate(t time.Time) *UserUpdate {
+	uu.mutation.SetUnemploymentDate(t)
+	return uu
+}
+
+// SetNillableUnemploymentDate sets the "unemployment_date" field if the given value is not nil.
+func (uu *UserUpdate) SetNillableUnemploymentDate(t *time.Time) *UserUpdate {
+	if t != nil {
+		uu.SetUnemploymentDate(*t)
+	}
+	return uu
+}
+
+// ClearUnemploymentDate clears the value of the "unemployment_date" field.
+func (uu *UserUpdate) ClearUnemploymentDate() *UserUpdate {
+	uu.mutation.ClearUnemploymentDate()
+	return uu
+}
+
 // SetDepartment sets the "department" edge to the Department entity.
 func (uu *UserUpdate) SetDepartment(d *Department) *UserUpdate {
 	return uu.SetDepartmentID(d.ID)
@@ -171,6 +443,36 @@ func (uu *UserUpdate) SetAuth(a *AuthUser) *UserUpdate {
 	return uu.SetAuthID(a.ID)
 }
 
+// AddPermissionIDs adds the "permissions" edge to the UserPermission entity by IDs.
+func (uu *UserUpdate) AddPermissionIDs(ids ...int) *UserUpdate {
+	uu.mutation.AddPermissionIDs(ids...)
+	return uu
+}
+
+// AddPermissions adds the "permissions" edges to the UserPermission entity.
+func (uu *UserUpdate) AddPermissions(u ...*UserPermission) *UserUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return uu.AddPermissionIDs(ids...)
+}
+
+// AddEmploymentEventIDs adds the "employment_events" edge to the EmploymentEvent entity by IDs.
+func (uu *UserUpdate) AddEmploymentEventIDs(ids ...uuid.UUID) *UserUpdate {
+	uu.mutation.AddEmploymentEventIDs(ids...)
+	return uu
+}
+
+// AddEmploymentEvents adds the "employment_events" edges to the EmploymentEvent entity.
+func (uu *UserUpdate) AddEmploymentEvents(e ...*EmploymentEvent) *UserUpdate {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return uu.AddEmploymentEventIDs(ids...)
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (uu *UserUpdate) Mutation() *UserMutation {
 	return uu.mutation
@@ -188,6 +490,48 @@ func (uu *UserUpdate) ClearAuth() *UserUpdate {
 	return uu
 }
 
+// ClearPermissions clears all "permissions" edges to the UserPermission entity.
+func (uu *UserUpdate) ClearPermissions() *UserUpdate {
+	uu.mutation.ClearPermissions()
+	return uu
+}
+
+// RemovePermissionIDs removes the "permissions" edge to UserPermission entities by IDs.
+func (uu *UserUpdate) RemovePermissionIDs(ids ...int) *UserUpdate {
+	uu.mutation.RemovePermissionIDs(ids...)
+	return uu
+}
+
+// RemovePermissions removes "permissions" edges to UserPermission entities.
+func (uu *UserUpdate) RemovePermissions(u ...*UserPermission) *UserUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return uu.RemovePermissionIDs(ids...)
+}
+
+// ClearEmploymentEvents clears all "employment_events" edges to the EmploymentEvent entity.
+func (uu *UserUpdate) ClearEmploymentEvents() *UserUpdate {
+	uu.mutation.ClearEmploymentEvents()
+	return uu
+}
+
+// RemoveEmploymentEventIDs removes the "employment_events" edge to EmploymentEvent entities by IDs.
+func (uu *UserUpdate) RemoveEmploymentEventIDs(ids ...uuid.UUID) *UserUpdate {
+	uu.mutation.RemoveEmploymentEventIDs(ids...)
+	return uu
+}
+
+// RemoveEmploymentEvents removes "employment_events" edges to EmploymentEvent entities.
+func (uu *UserUpdate) RemoveEmploymentEvents(e ...*EmploymentEvent) *UserUpdate {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return uu.RemoveEmploymentEventIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (uu *UserUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, uu.sqlSave, uu.mutation, uu.hooks)
@@ -224,6 +568,9 @@ func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if value, ok := uu.mutation.OrgID(); ok {
+		_spec.SetField(user.FieldOrgID, field.TypeUUID, value)
+	}
 	if value, ok := uu.mutation.FirstName(); ok {
 		_spec.SetField(user.FieldFirstName, field.TypeString, value)
 	}
@@ -239,6 +586,12 @@ func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if uu.mutation.PictureURLCleared() {
 		_spec.ClearField(user.FieldPictureURL, field.TypeString)
 	}
+	if value, ok := uu.mutation.Email(); ok {
+		_spec.SetField(user.FieldEmail, field.TypeString, value)
+	}
+	if uu.mutation.EmailCleared() {
+		_spec.ClearField(user.FieldEmail, field.TypeString)
+	}
 	if value, ok := uu.mutation.Suspended(); ok {
 		_spec.SetField(user.FieldSuspended, field.TypeBool, value)
 	}
@@ -248,6 +601,69 @@ func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := uu.mutation.AddedRoleID(); ok {
 		_spec.AddField(user.FieldRoleID, field.TypeInt32, value)
 	}
+	if value, ok := uu.mutation.DeletedAt(); ok {
+		_spec.SetField(user.FieldDeletedAt, field.TypeTime, value)
+	}
+	if uu.mutation.DeletedAtCleared() {
+		_spec.ClearField(user.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := uu.mutation.Version(); ok {
+		_spec.SetField(user.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := uu.mutation.AddedVersion(); ok {
+		_spec.AddField(user.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := uu.mutation.Subdivision(); ok {
+		_spec.SetField(user.FieldSubdivision, field.TypeString, value)
+	}
+	if value, ok := uu.mutation.JobTitle(); ok {
+		_spec.SetField(user.FieldJobTitle, field.TypeString, value)
+	}
+	if value, ok := uu.mutation.EmploymentRate(); ok {
+		_spec.SetField(user.FieldEmploymentRate, field.TypeFloat64, value)
+	}
+	if value, ok := uu.mutation.AddedEmploymentRate(); ok {
+		_spec.AddField(user.FieldEmploymentRate, field.TypeFloat64, value)
+	}
+	if value, ok := uu.mutation.PersonnelCategory(); ok {
+		_spec.SetField(user.FieldPersonnelCategory, field.TypeInt32, value)
+	}
+	if value, ok := uu.mutation.AddedPersonnelCategory(); ok {
+		_spec.AddField(user.FieldPersonnelCategory, field.TypeInt32, value)
+	}
+	if value, ok := uu.mutation.EmploymentType(); ok {
+		_spec.SetField(user.FieldEmploymentType, field.TypeInt32, value)
+	}
+	if value, ok := uu.mutation.AddedEmploymentType(); ok {
+		_spec.AddField(user.FieldEmploymentType, field.TypeInt32, value)
+	}
+	if value, ok := uu.mutation.AcademicDegree(); ok {
+		_spec.SetField(user.FieldAcademicDegree, field.TypeInt32, value)
+	}
+	if value, ok := uu.mutation.AddedAcademicDegree(); ok {
+		_spec.AddField(user.FieldAcademicDegree, field.TypeInt32, value)
+	}
+	if value, ok := uu.mutation.AcademicTitle(); ok {
+		_spec.SetField(user.FieldAcademicTitle, field.TypeString, value)
+	}
+	if value, ok := uu.mutation.Honors(); ok {
+		_spec.SetField(user.FieldHonors, field.TypeString, value)
+	}
+	if value, ok := uu.mutation.Category(); ok {
+		_spec.SetField(user.FieldCategory, field.TypeString, value)
+	}
+	if value, ok := uu.mutation.DateOfEmployment(); ok {
+		_spec.SetField(user.FieldDateOfEmployment, field.TypeTime, value)
+	}
+	if uu.mutation.DateOfEmploymentCleared() {
+		_spec.ClearField(user.FieldDateOfEmployment, field.TypeTime)
+	}
+	if value, ok := uu.mutation.UnemploymentDate(); ok {
+		_spec.SetField(user.FieldUnemploymentDate, field.TypeTime, value)
+	}
+	if uu.mutation.UnemploymentDateCleared() {
+		_spec.ClearField(user.FieldUnemploymentDate, field.TypeTime)
+	}
 	if uu.mutation.DepartmentCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -306,6 +722,96 @@ func (uu *UserUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if uu.mutation.PermissionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.PermissionsTable,
+			Columns: []string{user.PermissionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userpermission.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := uu.mutation.RemovedPermissionsIDs(); len(nodes) > 0 && !uu.mutation.PermissionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.PermissionsTable,
+			Columns: []string{user.PermissionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userpermission.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := uu.mutation.PermissionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.PermissionsTable,
+			Columns: []string{user.PermissionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userpermission.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if uu.mutation.EmploymentEventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.EmploymentEventsTable,
+			Columns: []string{user.EmploymentEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := uu.mutation.RemovedEmploymentEventsIDs(); len(nodes) > 0 && !uu.mutation.EmploymentEventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.EmploymentEventsTable,
+			Columns: []string{user.EmploymentEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := uu.mutation.EmploymentEventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.EmploymentEventsTable,
+			Columns: []string{user.EmploymentEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, uu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{user.Label}
@@ -326,6 +832,20 @@ type UserUpdateOne struct {
 	mutation *UserMutation
 }
 
+// SetOrgID sets the "org_id" field.
+func (uuo *UserUpdateOne) SetOrgID(u uuid.UUID) *UserUpdateOne {
+	uuo.mutation.SetOrgID(u)
+	return uuo
+}
+
+// SetNillableOrgID sets the "org_id" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableOrgID(u *uuid.UUID) *UserUpdateOne {
+	if u != nil {
+		uuo.SetOrgID(*u)
+	}
+	return uuo
+}
+
 // SetFirstName sets the "first_name" field.
 func (uuo *UserUpdateOne) SetFirstName(s string) *UserUpdateOne {
 	uuo.mutation.SetFirstName(s)
@@ -388,6 +908,26 @@ func (uuo *UserUpdateOne) ClearPictureURL() *UserUpdateOne {
 	return uuo
 }
 
+// SetEmail sets the "email" field.
+func (uuo *UserUpdateOne) SetEmail(s string) *UserUpdateOne {
+	uuo.mutation.SetEmail(s)
+	return uuo
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableEmail(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetEmail(*s)
+	}
+	return uuo
+}
+
+// ClearEmail clears the value of the "email" field.
+func (uuo *UserUpdateOne) ClearEmail() *UserUpdateOne {
+	uuo.mutation.ClearEmail()
+	return uuo
+}
+
 // SetSuspended sets the "suspended" field.
 func (uuo *UserUpdateOne) SetSuspended(b bool) *UserUpdateOne {
 	uuo.mutation.SetSuspended(b)
@@ -443,6 +983,241 @@ func (uuo *UserUpdateOne) AddRoleID(i int32) *UserUpdateOne {
 	return uuo
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (uuo *UserUpdateOne) SetDeletedAt(t time.Time) *UserUpdateOne {
+	uuo.mutation.SetDeletedAt(t)
+	return uuo
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDeletedAt(t *time.Time) *UserUpdateOne {
+	if t != nil {
+		uuo.SetDeletedAt(*t)
+	}
+	return uuo
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (uuo *UserUpdateOne) ClearDeletedAt() *UserUpdateOne {
+	uuo.mutation.ClearDeletedAt()
+	return uuo
+}
+
+// SetVersion sets the "version" field.
+func (uuo *UserUpdateOne) SetVersion(i int) *UserUpdateOne {
+	uuo.mutation.ResetVersion()
+	uuo.mutation.SetVersion(i)
+	return uuo
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableVersion(i *int) *UserUpdateOne {
+	if i != nil {
+		uuo.SetVersion(*i)
+	}
+	return uuo
+}
+
+// AddVersion adds i to the "version" field.
+func (uuo *UserUpdateOne) AddVersion(i int) *UserUpdateOne {
+	uuo.mutation.AddVersion(i)
+	return uuo
+}
+
+// SetSubdivision sets the "subdivision" field.
+func (uuo *UserUpdateOne) SetSubdivision(s string) *UserUpdateOne {
+	uuo.mutation.SetSubdivision(s)
+	return uuo
+}
+
+// SetNillableSubdivision sets the "subdivision" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableSubdivision(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetSubdivision(*s)
+	}
+	return uuo
+}
+
+// SetJobTitle sets the "job_title" field.
+func (uuo *UserUpdateOne) SetJobTitle(s string) *UserUpdateOne {
+	uuo.mutation.SetJobTitle(s)
+	return uuo
+}
+
+// SetNillableJobTitle sets the "job_title" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableJobTitle(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetJobTitle(*s)
+	}
+	return uuo
+}
+
+// SetEmploymentRate sets the "employment_rate" field.
+func (uuo *UserUpdateOne) SetEmploymentRate(f float64) *UserUpdateOne {
+	uuo.mutation.ResetEmploymentRate()
+	uuo.mutation.SetEmploymentRate(f)
+	return uuo
+}
+
+// SetNillableEmploymentRate sets the "employment_rate" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableEmploymentRate(f *float64) *UserUpdateOne {
+	if f != nil {
+		uuo.SetEmploymentRate(*f)
+	}
+	return uuo
+}
+
+// AddEmploymentRate adds f to the "employment_rate" field.
+func (uuo *UserUpdateOne) AddEmploymentRate(f float64) *UserUpdateOne {
+	uuo.mutation.AddEmploymentRate(f)
+	return uuo
+}
+
+// SetPersonnelCategory sets the "personnel_category" field.
+func (uuo *UserUpdateOne) SetPersonnelCategory(i int32) *UserUpdateOne {
+	uuo.mutation.ResetPersonnelCategory()
+	uuo.mutation.SetPersonnelCategory(i)
+	return uuo
+}
+
+// SetNillablePersonnelCategory sets the "personnel_category" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillablePersonnelCategory(i *int32) *UserUpdateOne {
+	if i != nil {
+		uuo.SetPersonnelCategory(*i)
+	}
+	return uuo
+}
+
+// AddPersonnelCategory adds i to the "personnel_category" field.
+func (uuo *UserUpdateOne) AddPersonnelCategory(i int32) *UserUpdateOne {
+	uuo.mutation.AddPersonnelCategory(i)
+	return uuo
+}
+
+// SetEmploymentType sets the "employment_type" field.
+func (uuo *UserUpdateOne) SetEmploymentType(i int32) *UserUpdateOne {
+	uuo.mutation.ResetEmploymentType()
+	uuo.mutation.SetEmploymentType(i)
+	return uuo
+}
+
+// SetNillableEmploymentType sets the "employment_type" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableEmploymentType(i *int32) *UserUpdateOne {
+	if i != nil {
+		uuo.SetEmploymentType(*i)
+	}
+	return uuo
+}
+
+// AddEmploymentType adds i to the "employment_type" field.
+func (uuo *UserUpdateOne) AddEmploymentType(i int32) *UserUpdateOne {
+	uuo.mutation.AddEmploymentType(i)
+	return uuo
+}
+
+// SetAcademicDegree sets the "academic_degree" field.
+func (uuo *UserUpdateOne) SetAcademicDegree(i int32) *UserUpdateOne {
+	uuo.mutation.ResetAcademicDegree()
+	uuo.mutation.SetAcademicDegree(i)
+	return uuo
+}
+
+// SetNillableAcademicDegree sets the "academic_degree" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableAcademicDegree(i *int32) *UserUpdateOne {
+	if i != nil {
+		uuo.SetAcademicDegree(*i)
+	}
+	return uuo
+}
+
+// AddAcademicDegree adds i to the "academic_degree" field.
+func (uuo *UserUpdateOne) AddAcademicDegree(i int32) *UserUpdateOne {
+	uuo.mutation.AddAcademicDegree(i)
+	return uuo
+}
+
+// SetAcademicTitle sets the "academic_title" field.
+func (uuo *UserUpdateOne) SetAcademicTitle(s string) *UserUpdateOne {
+	uuo.mutation.SetAcademicTitle(s)
+	return uuo
+}
+
+// SetNillableAcademicTitle sets the "academic_title" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableAcademicTitle(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetAcademicTitle(*s)
+	}
+	return uuo
+}
+
+// SetHonors sets the "honors" field.
+func (uuo *UserUpdateOne) SetHonors(s string) *UserUpdateOne {
+	uuo.mutation.SetHonors(s)
+	return uuo
+}
+
+// SetNillableHonors sets the "honors" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableHonors(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetHonors(*s)
+	}
+	return uuo
+}
+
+// SetCategory sets the "category" field.
+func (uuo *UserUpdateOne) SetCategory(s string) *UserUpdateOne {
+	uuo.mutation.SetCategory(s)
+	return uuo
+}
+
+// SetNillableCategory sets the "category" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableCategory(s *string) *UserUpdateOne {
+	if s != nil {
+		uuo.SetCategory(*s)
+	}
+	return uuo
+}
+
+// SetDateOfEmployment sets the "date_of_employment" field.
+func (uuo *UserUpdateOne) SetDateOfEmployment(t time.Time) *UserUpdateOne {
+	uuo.mutation.SetDateOfEmployment(t)
+	return uuo
+}
+
+// SetNillableDateOfEmployment sets the "date_of_employment" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableDateOfEmployment(t *time.Time) *UserUpdateOne {
+	if t != nil {
+		uuo.SetDateOfEmployment(*t)
+	}
+	return uuo
+}
+
+// ClearDateOfEmployment clears the value of the "date_of_employment" field.
+func (uuo *UserUpdateOne) ClearDateOfEmployment() *UserUpdateOne {
+	uuo.mutation.ClearDateOfEmployment()
+	return uuo
+}
+
+// SetUnemploymentDate sets the "unemployment_date" field.
+func (uuo *UserUpdateOne) SetUnemploymentDate(t time.Time) *UserUpdateOne {
+	uuo.mutation.SetUnemploymentDate(t)
+	return uuo
+}
+
+// SetNillableUnemploymentDate sets the "unemployment_date" field if the given value is not nil.
+func (uuo *UserUpdateOne) SetNillableUnemploymentDate(t *time.Time) *UserUpdateOne {
+	if t != nil {
+		uuo.SetUnemploymentDate(*t)
+	}
+	return uuo
+}
+
+// ClearUnemploymentDate clears the value of the "unemployment_date" field.
+func (uuo *UserUpdateOne) ClearUnemploymentDate() *UserUpdateOne {
+	uuo.mutation.ClearUnemploymentDate()
+	return uuo
+}
+
 // SetDepartment sets the "department" edge to the Department entity.
 func (uuo *UserUpdateOne) SetDepartment(d *Department) *UserUpdateOne {
 	return uuo.SetDepartmentID(d.ID)
@@ -467,6 +1242,36 @@ func (uuo *UserUpdateOne) SetAuth(a *AuthUser) *UserUpdateOne {
 	return uuo.SetAuthID(a.ID)
 }
 
+// AddPermissionIDs adds the "permissions" edge to the UserPermission entity by IDs.
+func (uuo *UserUpdateOne) AddPermissionIDs(ids ...int) *UserUpdateOne {
+	uuo.mutation.AddPermissionIDs(ids...)
+	return uuo
+}
+
+// AddPermissions adds the "permissions" edges to the UserPermission entity.
+func (uuo *UserUpdateOne) AddPermissions(u ...*UserPermission) *UserUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return uuo.AddPermissionIDs(ids...)
+}
+
+// AddEmploymentEventIDs adds the "employment_events" edge to the EmploymentEvent entity by IDs.
+func (uuo *UserUpdateOne) AddEmploymentEventIDs(ids ...uuid.UUID) *UserUpdateOne {
+	uuo.mutation.AddEmploymentEventIDs(ids...)
+	return uuo
+}
+
+// AddEmploymentEvents adds the "employment_events" edges to the EmploymentEvent entity.
+func (uuo *UserUpdateOne) AddEmploymentEvents(e ...*EmploymentEvent) *UserUpdateOne {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return uuo.AddEmploymentEventIDs(ids...)
+}
+
 // Mutation returns the UserMutation object of the builder.
 func (uuo *UserUpdateOne) Mutation() *UserMutation {
 	return uuo.mutation
@@ -484,6 +1289,48 @@ func (uuo *UserUpdateOne) ClearAuth() *UserUpdateOne {
 	return uuo
 }
 
+// ClearPermissions clears all "permissions" edges to the UserPermission entity.
+func (uuo *UserUpdateOne) ClearPermissions() *UserUpdateOne {
+	uuo.mutation.ClearPermissions()
+	return uuo
+}
+
+// RemovePermissionIDs removes the "permissions" edge to UserPermission entities by IDs.
+func (uuo *UserUpdateOne) RemovePermissionIDs(ids ...int) *UserUpdateOne {
+	uuo.mutation.RemovePermissionIDs(ids...)
+	return uuo
+}
+
+// RemovePermissions removes "permissions" edges to UserPermission entities.
+func (uuo *UserUpdateOne) RemovePermissions(u ...*UserPermission) *UserUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return uuo.RemovePermissionIDs(ids...)
+}
+
+// ClearEmploymentEvents clears all "employment_events" edges to the EmploymentEvent entity.
+func (uuo *UserUpdateOne) ClearEmploymentEvents() *UserUpdateOne {
+	uuo.mutation.ClearEmploymentEvents()
+	return uuo
+}
+
+// RemoveEmploymentEventIDs removes the "employment_events" edge to EmploymentEvent entities by IDs.
+func (uuo *UserUpdateOne) RemoveEmploymentEventIDs(ids ...uuid.UUID) *UserUpdateOne {
+	uuo.mutation.RemoveEmploymentEventIDs(ids...)
+	return uuo
+}
+
+// RemoveEmploymentEvents removes "employment_events" edges to EmploymentEvent entities.
+func (uuo *UserUpdateOne) RemoveEmploymentEvents(e ...*EmploymentEvent) *UserUpdateOne {
+	ids := make([]uuid.UUID, len(e))
+	for i := range e {
+		ids[i] = e[i].ID
+	}
+	return uuo.RemoveEmploymentEventIDs(ids...)
+}
+
 // Where appends a list predicates to the UserUpdate builder.
 func (uuo *UserUpdateOne) Where(ps ...predicate.User) *UserUpdateOne {
 	uuo.mutation.Where(ps...)
@@ -550,6 +1397,9 @@ func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error)
 			}
 		}
 	}
+	if value, ok := uuo.mutation.OrgID(); ok {
+		_spec.SetField(user.FieldOrgID, field.TypeUUID, value)
+	}
 	if value, ok := uuo.mutation.FirstName(); ok {
 		_spec.SetField(user.FieldFirstName, field.TypeString, value)
 	}
@@ -565,6 +1415,12 @@ func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error)
 	if uuo.mutation.PictureURLCleared() {
 		_spec.ClearField(user.FieldPictureURL, field.TypeString)
 	}
+	if value, ok := uuo.mutation.Email(); ok {
+		_spec.SetField(user.FieldEmail, field.TypeString, value)
+	}
+	if uuo.mutation.EmailCleared() {
+		_spec.ClearField(user.FieldEmail, field.TypeString)
+	}
 	if value, ok := uuo.mutation.Suspended(); ok {
 		_spec.SetField(user.FieldSuspended, field.TypeBool, value)
 	}
@@ -574,6 +1430,69 @@ func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error)
 	if value, ok := uuo.mutation.AddedRoleID(); ok {
 		_spec.AddField(user.FieldRoleID, field.TypeInt32, value)
 	}
+	if value, ok := uuo.mutation.DeletedAt(); ok {
+		_spec.SetField(user.FieldDeletedAt, field.TypeTime, value)
+	}
+	if uuo.mutation.DeletedAtCleared() {
+		_spec.ClearField(user.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := uuo.mutation.Version(); ok {
+		_spec.SetField(user.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := uuo.mutation.AddedVersion(); ok {
+		_spec.AddField(user.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := uuo.mutation.Subdivision(); ok {
+		_spec.SetField(user.FieldSubdivision, field.TypeString, value)
+	}
+	if value, ok := uuo.mutation.JobTitle(); ok {
+		_spec.SetField(user.FieldJobTitle, field.TypeString, value)
+	}
+	if value, ok := uuo.mutation.EmploymentRate(); ok {
+		_spec.SetField(user.FieldEmploymentRate, field.TypeFloat64, value)
+	}
+	if value, ok := uuo.mutation.AddedEmploymentRate(); ok {
+		_spec.AddField(user.FieldEmploymentRate, field.TypeFloat64, value)
+	}
+	if value, ok := uuo.mutation.PersonnelCategory(); ok {
+		_spec.SetField(user.FieldPersonnelCategory, field.TypeInt32, value)
+	}
+	if value, ok := uuo.mutation.AddedPersonnelCategory(); ok {
+		_spec.AddField(user.FieldPersonnelCategory, field.TypeInt32, value)
+	}
+	if value, ok := uuo.mutation.EmploymentType(); ok {
+		_spec.SetField(user.FieldEmploymentType, field.TypeInt32, value)
+	}
+	if value, ok := uuo.mutation.AddedEmploymentType(); ok {
+		_spec.AddField(user.FieldEmploymentType, field.TypeInt32, value)
+	}
+	if value, ok := uuo.mutation.AcademicDegree(); ok {
+		_spec.SetField(user.FieldAcademicDegree, field.TypeInt32, value)
+	}
+	if value, ok := uuo.mutation.AddedAcademicDegree(); ok {
+		_spec.AddField(user.FieldAcademicDegree, field.TypeInt32, value)
+	}
+	if value, ok := uuo.mutation.AcademicTitle(); ok {
+		_spec.SetField(user.FieldAcademicTitle, field.TypeString, value)
+	}
+	if value, ok := uuo.mutation.Honors(); ok {
+		_spec.SetField(user.FieldHonors, field.TypeString, value)
+	}
+	if value, ok := uuo.mutation.Category(); ok {
+		_spec.SetField(user.FieldCategory, field.TypeString, value)
+	}
+	if value, ok := uuo.mutation.DateOfEmployment(); ok {
+		_spec.SetField(user.FieldDateOfEmployment, field.TypeTime, value)
+	}
+	if uuo.mutation.DateOfEmploymentCleared() {
+		_spec.ClearField(user.FieldDateOfEmployment, field.TypeTime)
+	}
+	if value, ok := uuo.mutation.UnemploymentDate(); ok {
+		_spec.SetField(user.FieldUnemploymentDate, field.TypeTime, value)
+	}
+	if uuo.mutation.UnemploymentDateCleared() {
+		_spec.ClearField(user.FieldUnemploymentDate, field.TypeTime)
+	}
 	if uuo.mutation.DepartmentCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -632,6 +1551,96 @@ func (uuo *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if uuo.mutation.PermissionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.PermissionsTable,
+			Columns: []string{user.PermissionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userpermission.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := uuo.mutation.RemovedPermissionsIDs(); len(nodes) > 0 && !uuo.mutation.PermissionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.PermissionsTable,
+			Columns: []string{user.PermissionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userpermission.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := uuo.mutation.PermissionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.PermissionsTable,
+			Columns: []string{user.PermissionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(userpermission.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if uuo.mutation.EmploymentEventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.EmploymentEventsTable,
+			Columns: []string{user.EmploymentEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := uuo.mutation.RemovedEmploymentEventsIDs(); len(nodes) > 0 && !uuo.mutation.EmploymentEventsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.EmploymentEventsTable,
+			Columns: []string{user.EmploymentEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := uuo.mutation.EmploymentEventsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   user.EmploymentEventsTable,
+			Columns: []string{user.EmploymentEventsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(employmentevent.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &User{config: uuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
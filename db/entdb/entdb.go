@@ -9,7 +9,9 @@ import (
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/userpermission"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
 	"github.com/kozlov-ma/sesc-backend/sesc"
@@ -45,7 +47,9 @@ func (d *DB) CreateDepartment(
 	statrec.Add(events.PostgresQueries, 1)
 	res, err := d.c.Department.Create().
 		SetID(id).
+		SetOrgID(sesc.OrgFromContext(ctx)).
 		SetName(name).
+		SetNormalizedName(sesc.NormalizeDepartmentName(name)).
 		SetDescription(description).
 		Save(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
@@ -81,7 +85,7 @@ func (d *DB) DeleteDepartment(ctx context.Context, id sesc.UUID) error {
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	err := d.c.Department.DeleteOneID(id).Exec(ctx)
+	err := d.c.Department.DeleteOneID(id).Where(department.OrgID(sesc.OrgFromContext(ctx))).Exec(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
@@ -108,14 +112,14 @@ func (d *DB) DepartmentByID(ctx context.Context, id sesc.UUID) (sesc.Department,
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	res, err := d.c.Department.Get(ctx, id)
+	res, err := d.c.Department.Query().Where(department.ID(id), department.OrgID(sesc.OrgFromContext(ctx))).Only(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
 	case ent.IsNotFound(err):
 		return sesc.NoDepartment, sesc.ErrInvalidDepartment
 	case err != nil:
-		err := fmt.Errorf("couldn't get department: %w", err)
+		err := fmt.Errorf("couldn't get department: %w", sesc.WrapTimeout(ctx, err))
 		rec.Add(events.Error, err)
 		return sesc.NoDepartment, err
 	}
@@ -140,11 +144,11 @@ func (d *DB) Departments(ctx context.Context) ([]sesc.Department, error) {
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	res, err := d.c.Department.Query().All(ctx)
+	res, err := d.c.Department.Query().Where(department.OrgID(sesc.OrgFromContext(ctx))).All(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	if err != nil {
-		err := fmt.Errorf("couldn't get all departments: %w", err)
+		err := fmt.Errorf("couldn't get all departments: %w", sesc.WrapTimeout(ctx, err))
 		rec.Add(events.Error, err)
 		return nil, err
 	}
@@ -161,6 +165,40 @@ func (d *DB) Departments(ctx context.Context) ([]sesc.Department, error) {
 	return deps, nil
 }
 
+// DepartmentsByIDs implements sesc.DB.
+func (d *DB) DepartmentsByIDs(ctx context.Context, ids []sesc.UUID) (map[sesc.UUID]sesc.Department, error) {
+	rec := event.Get(ctx).Sub("entdb/departments_by_ids")
+	statrec := event.Get(ctx).Sub("stats")
+
+	rec.Sub("params").Set("ids", ids)
+
+	deps := make(map[sesc.UUID]sesc.Department, len(ids))
+	if len(ids) == 0 {
+		return deps, nil
+	}
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	res, err := d.c.Department.Query().Where(department.IDIn(ids...), department.OrgID(sesc.OrgFromContext(ctx))).All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't get departments: %w", sesc.WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		return nil, err
+	}
+
+	for _, r := range res {
+		deps[r.ID] = sesc.Department{
+			ID:          r.ID,
+			Name:        r.Name,
+			Description: r.Description,
+		}
+	}
+
+	return deps, nil
+}
+
 // SaveUser implements sesc.DB.
 func (d *DB) SaveUser(ctx context.Context, opt sesc.UserUpdateOptions) (sesc.User, error) {
 	rec := event.Get(ctx).Sub("entdb/save_user")
@@ -189,7 +227,9 @@ func (d *DB) SaveUser(ctx context.Context, opt sesc.UserUpdateOptions) (sesc.Use
 	statrec.Add(events.PostgresQueries, 1)
 	var dept *ent.Department
 	if opt.DepartmentID != uuid.Nil {
-		dept, err = tx.Department.Get(ctx, opt.DepartmentID)
+		dept, err = tx.Department.Query().
+			Where(department.ID(opt.DepartmentID), department.OrgID(sesc.OrgFromContext(ctx))).
+			Only(ctx)
 		switch {
 		case ent.IsNotFound(err):
 			return sesc.User{}, rollback(tx, sesc.ErrInvalidDepartment)
@@ -202,24 +242,37 @@ func (d *DB) SaveUser(ctx context.Context, opt sesc.UserUpdateOptions) (sesc.Use
 
 	statrec.Add(events.PostgresQueries, 1)
 	cr := tx.User.Create().
+		SetOrgID(sesc.OrgFromContext(ctx)).
 		SetFirstName(opt.FirstName).
 		SetLastName(opt.LastName).
 		SetMiddleName(opt.MiddleName).
 		SetPictureURL(opt.PictureURL).
-		SetRoleID(opt.NewRoleID)
+		SetNillableEmail(emailField(opt.Email)).
+		SetRoleID(opt.NewRoleID).
+		SetSubdivision(opt.Subdivision).
+		SetJobTitle(opt.JobTitle).
+		SetEmploymentRate(opt.EmploymentRate).
+		SetPersonnelCategory(int32(opt.PersonnelCategory)).
+		SetEmploymentType(int32(opt.EmploymentType)).
+		SetAcademicDegree(int32(opt.AcademicDegree)).
+		SetAcademicTitle(opt.AcademicTitle).
+		SetHonors(opt.Honors).
+		SetCategory(opt.Category).
+		SetNillableDateOfEmployment(opt.DateOfEmployment).
+		SetNillableUnemploymentDate(opt.UnemploymentDate)
 	if dept != nil {
 		cr = cr.SetDepartment(dept)
 	}
 
 	res, err := cr.Save(ctx)
 	if err != nil {
-		err := fmt.Errorf("couldn't save user: %w", err)
+		err := fmt.Errorf("couldn't save user: %w", sesc.WrapConstraint(err))
 		txrec.Add(events.Error, err)
 		return sesc.User{}, rollback(tx, err)
 	}
 
 	statrec.Add(events.PostgresQueries, 1)
-	us, err := tx.User.Query().Where(user.ID(res.ID)).WithDepartment().Only(ctx)
+	us, err := tx.User.Query().Where(user.ID(res.ID)).WithDepartment().WithPermissions(orderPermissions).Only(ctx)
 	if err != nil {
 		err := fmt.Errorf("couldn't query user after saving them: %w", err)
 		txrec.Add(events.Error, err)
@@ -263,12 +316,25 @@ func (d *DB) UpdateDepartment(
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	err := d.c.Department.UpdateOneID(id).SetName(name).SetDescription(description).Exec(ctx)
+	err := d.c.Department.UpdateOneID(id).
+		Where(department.OrgID(sesc.OrgFromContext(ctx))).
+		SetName(name).
+		SetNormalizedName(sesc.NormalizeDepartmentName(name)).
+		SetDescription(description).
+		Exec(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
 	case ent.IsNotFound(err):
-		joinedErr := errors.Join(err, sesc.ErrInvalidDepartment)
+		joinedErr := errors.Join(err, sesc.ErrDepartmentNotFound)
+		rec.Add(events.Error, joinedErr)
+		return joinedErr
+	case ent.IsConstraintError(err):
+		joinedErr := errors.Join(err, sesc.ErrDepartmentExists)
+		rec.Add(events.Error, joinedErr)
+		return joinedErr
+	case ent.IsValidationError(err):
+		joinedErr := errors.Join(err, sesc.ErrInvalidDepartmentName)
 		rec.Add(events.Error, joinedErr)
 		return joinedErr
 	case err != nil:
@@ -281,6 +347,89 @@ func (d *DB) UpdateDepartment(
 	return nil
 }
 
+// UpsertDepartment implements sesc.DB.
+func (d *DB) UpsertDepartment(
+	ctx context.Context,
+	id sesc.UUID,
+	name string,
+	description string,
+) (sesc.Department, error) {
+	rec := event.Get(ctx).Sub("entdb/upsert_department")
+	statrec := event.Get(ctx).Sub("stats")
+
+	rec.Sub("params").Set(
+		"id", id,
+		"name", name,
+		"description", description,
+	)
+
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+	tx, err := d.c.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		err := fmt.Errorf("couldn't begin transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return sesc.NoDepartment, err
+	}
+
+	statrec.Add(events.PostgresQueries, 1)
+	existing, err := tx.Department.Query().Where(
+		department.NormalizedName(sesc.NormalizeDepartmentName(name)),
+		department.OrgID(sesc.OrgFromContext(ctx)),
+	).Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		err := fmt.Errorf("couldn't query department: %w", err)
+		txrec.Add(events.Error, err)
+		return sesc.NoDepartment, rollback(tx, err)
+	}
+
+	statrec.Add(events.PostgresQueries, 1)
+	var res *ent.Department
+	if existing == nil {
+		res, err = tx.Department.Create().
+			SetID(id).
+			SetOrgID(sesc.OrgFromContext(ctx)).
+			SetName(name).
+			SetNormalizedName(sesc.NormalizeDepartmentName(name)).
+			SetDescription(description).
+			Save(ctx)
+	} else {
+		res, err = tx.Department.UpdateOne(existing).SetDescription(description).Save(ctx)
+	}
+	switch {
+	case ent.IsValidationError(err):
+		return sesc.NoDepartment, rollback(tx, sesc.ErrInvalidDepartmentName)
+	case err != nil:
+		err := fmt.Errorf("couldn't save department: %w", err)
+		txrec.Add(events.Error, err)
+		return sesc.NoDepartment, rollback(tx, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return sesc.NoDepartment, err
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+
+	rec.Set("created", existing == nil)
+	rec.Sub("department").Set(
+		"id", res.ID,
+		"name", res.Name,
+		"description", res.Description,
+	)
+
+	return sesc.Department{
+		ID:          res.ID,
+		Name:        res.Name,
+		Description: res.Description,
+	}, nil
+}
+
 // UpdateProfilePicture implements sesc.DB.
 func (d *DB) UpdateProfilePicture(ctx context.Context, id sesc.UUID, pictureURL string) error {
 	rec := event.Get(ctx).Sub("entdb/update_profile_picture")
@@ -293,7 +442,11 @@ func (d *DB) UpdateProfilePicture(ctx context.Context, id sesc.UUID, pictureURL
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	err := d.c.User.UpdateOneID(id).SetPictureURL(pictureURL).Exec(ctx)
+	err := d.c.User.UpdateOneID(id).
+		Where(user.OrgID(sesc.OrgFromContext(ctx))).
+		SetPictureURL(pictureURL).
+		AddVersion(1).
+		Exec(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
@@ -329,6 +482,7 @@ func (d *DB) UpdateUser(
 		"suspended", opt.Suspended,
 		"department_id", opt.DepartmentID,
 		"new_role_id", opt.NewRoleID,
+		"version", opt.Version,
 	)
 
 	txrec := rec.Sub("pg_transaction")
@@ -345,21 +499,26 @@ func (d *DB) UpdateUser(
 	}
 
 	statrec.Add(events.PostgresQueries, 1)
-	us, err := tx.User.Get(ctx, id)
+	us, err := tx.User.Query().Where(user.ID(id), user.OrgID(sesc.OrgFromContext(ctx))).Only(ctx)
 	switch {
 	case ent.IsNotFound(err):
 		txrec.Add(events.Error, sesc.ErrUserNotFound)
 		return sesc.User{}, rollback(tx, sesc.ErrUserNotFound)
 	case err != nil:
-		err := fmt.Errorf("couldn't query user: %w", err)
+		err := fmt.Errorf("couldn't query user: %w", sesc.WrapTimeout(ctx, err))
 		txrec.Add(events.Error, err)
 		return sesc.User{}, rollback(tx, err)
+	case opt.Version != 0 && us.Version != opt.Version:
+		txrec.Add(events.Error, sesc.ErrStaleUser)
+		return sesc.User{}, rollback(tx, sesc.ErrStaleUser)
 	}
 
 	var dept *ent.Department
 	if opt.DepartmentID != uuid.Nil {
 		statrec.Add(events.PostgresQueries, 1)
-		dept, err = tx.Department.Get(ctx, opt.DepartmentID)
+		dept, err = tx.Department.Query().
+			Where(department.ID(opt.DepartmentID), department.OrgID(sesc.OrgFromContext(ctx))).
+			Only(ctx)
 		switch {
 		case ent.IsNotFound(err):
 			txrec.Add(events.Error, sesc.ErrInvalidDepartment)
@@ -377,7 +536,35 @@ func (d *DB) UpdateUser(
 		SetMiddleName(opt.MiddleName).
 		SetPictureURL(opt.PictureURL).
 		SetSuspended(opt.Suspended).
-		SetRoleID(opt.NewRoleID)
+		SetRoleID(opt.NewRoleID).
+		SetSubdivision(opt.Subdivision).
+		SetJobTitle(opt.JobTitle).
+		SetEmploymentRate(opt.EmploymentRate).
+		SetPersonnelCategory(int32(opt.PersonnelCategory)).
+		SetEmploymentType(int32(opt.EmploymentType)).
+		SetAcademicDegree(int32(opt.AcademicDegree)).
+		SetAcademicTitle(opt.AcademicTitle).
+		SetHonors(opt.Honors).
+		SetCategory(opt.Category).
+		AddVersion(1)
+
+	if email := emailField(opt.Email); email != nil {
+		upd = upd.SetEmail(*email)
+	} else {
+		upd = upd.ClearEmail()
+	}
+
+	if opt.DateOfEmployment != nil {
+		upd = upd.SetDateOfEmployment(*opt.DateOfEmployment)
+	} else {
+		upd = upd.ClearDateOfEmployment()
+	}
+
+	if opt.UnemploymentDate != nil {
+		upd = upd.SetUnemploymentDate(*opt.UnemploymentDate)
+	} else {
+		upd = upd.ClearUnemploymentDate()
+	}
 
 	if dept != nil {
 		upd = upd.SetDepartment(dept)
@@ -389,13 +576,13 @@ func (d *DB) UpdateUser(
 	_, err = upd.Save(ctx)
 
 	if err != nil {
-		err := fmt.Errorf("couldn't update user: %w", err)
+		err := fmt.Errorf("couldn't update user: %w", sesc.WrapConstraint(err))
 		txrec.Add(events.Error, err)
 		return sesc.User{}, rollback(tx, err)
 	}
 
 	statrec.Add(events.PostgresQueries, 1)
-	us, err = tx.User.Query().Where(user.ID(id)).WithDepartment().Only(ctx)
+	us, err = tx.User.Query().Where(user.ID(id)).WithDepartment().WithPermissions(orderPermissions).Only(ctx)
 	if err != nil {
 		err := fmt.Errorf("couldn't query user after an update: %w", err)
 		txrec.Add(events.Error, err)
@@ -421,16 +608,84 @@ func (d *DB) UpdateUser(
 	return user, nil
 }
 
+// ArchiveUser implements sesc.DB.
+func (d *DB) ArchiveUser(ctx context.Context, id sesc.UUID) error {
+	rec := event.Get(ctx).Sub("entdb/archive_user")
+	statrec := event.Get(ctx).Sub("stats")
+
+	rec.Sub("params").Set("id", id)
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	n, err := d.c.User.Update().
+		Where(user.ID(id), user.OrgID(sesc.OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		SetDeletedAt(time.Now()).
+		Save(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case err != nil:
+		err := fmt.Errorf("couldn't archive user: %w", err)
+		rec.Add(events.Error, err)
+		return err
+	case n == 0:
+		rec.Add(events.Error, sesc.ErrUserNotFound)
+		return sesc.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// SetSuspended implements sesc.DB.
+func (d *DB) SetSuspended(ctx context.Context, id sesc.UUID, suspended bool) error {
+	rec := event.Get(ctx).Sub("entdb/set_suspended")
+	statrec := event.Get(ctx).Sub("stats")
+
+	rec.Sub("params").Set(
+		"id", id,
+		"suspended", suspended,
+	)
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	err := d.c.User.UpdateOneID(id).
+		Where(user.OrgID(sesc.OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		SetSuspended(suspended).
+		AddVersion(1).
+		Exec(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case ent.IsNotFound(err):
+		rec.Add(events.Error, sesc.ErrUserNotFound)
+		return sesc.ErrUserNotFound
+	case err != nil:
+		err := fmt.Errorf("couldn't set user suspended: %w", err)
+		rec.Add(events.Error, err)
+		return err
+	}
+
+	return nil
+}
+
 // UserByID implements sesc.DB.
-func (d *DB) UserByID(ctx context.Context, id sesc.UUID) (sesc.User, error) {
+func (d *DB) UserByID(ctx context.Context, id sesc.UUID, includeArchived bool) (sesc.User, error) {
 	rec := event.Get(ctx).Sub("entdb/user_by_id")
 	statrec := event.Get(ctx).Sub("stats")
 
-	rec.Sub("params").Set("id", id)
+	rec.Sub("params").Set(
+		"id", id,
+		"include_archived", includeArchived,
+	)
+
+	query := d.c.User.Query().Where(user.ID(id), user.OrgID(sesc.OrgFromContext(ctx)))
+	if !includeArchived {
+		query = query.Where(user.DeletedAtIsNil())
+	}
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	u, err := d.c.User.Query().Where(user.ID(id)).WithDepartment().Only(ctx)
+	u, err := query.WithDepartment().WithPermissions(orderPermissions).Only(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
@@ -438,7 +693,7 @@ func (d *DB) UserByID(ctx context.Context, id sesc.UUID) (sesc.User, error) {
 		rec.Add(events.Error, sesc.ErrUserNotFound)
 		return sesc.User{}, sesc.ErrUserNotFound
 	case err != nil:
-		err := fmt.Errorf("couldn't query user: %w", err)
+		err := fmt.Errorf("couldn't query user: %w", sesc.WrapTimeout(ctx, err))
 		rec.Add(events.Error, err)
 		return sesc.User{}, err
 	}
@@ -453,18 +708,48 @@ func (d *DB) UserByID(ctx context.Context, id sesc.UUID) (sesc.User, error) {
 	return user, nil
 }
 
+// UserExists implements sesc.DB.
+func (d *DB) UserExists(ctx context.Context, id sesc.UUID) (bool, error) {
+	rec := event.Get(ctx).Sub("entdb/user_exists")
+	statrec := event.Get(ctx).Sub("stats")
+
+	rec.Sub("params").Set("id", id)
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	exists, err := d.c.User.Query().
+		Where(user.ID(id), user.OrgID(sesc.OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't query user: %w", sesc.WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		return false, err
+	}
+
+	rec.Set("exists", exists)
+	return exists, nil
+}
+
 // Users implements sesc.DB.
-func (d *DB) Users(ctx context.Context) ([]sesc.User, error) {
+func (d *DB) Users(ctx context.Context, filter sesc.UserFilter) ([]sesc.User, error) {
 	rec := event.Get(ctx).Sub("entdb/users")
 	statrec := event.Get(ctx).Sub("stats")
 
+	query := d.c.User.Query().Where(user.OrgID(sesc.OrgFromContext(ctx)), user.DeletedAtIsNil())
+	if filter.Suspended != nil {
+		rec.Sub("params").Set("suspended", *filter.Suspended)
+		query = query.Where(user.Suspended(*filter.Suspended))
+	}
+
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	res, err := d.c.User.Query().WithDepartment().All(ctx)
+	res, err := query.WithDepartment().WithPermissions(orderPermissions).All(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	if err != nil {
-		err := fmt.Errorf("couldn't query users: %w", err)
+		err := fmt.Errorf("couldn't query users: %w", sesc.WrapTimeout(ctx, err))
 		rec.Add(events.Error, err)
 		return nil, err
 	}
@@ -511,8 +796,60 @@ func convertUser(u *ent.User) (sesc.User, error) {
 		LastName:   u.LastName,
 		MiddleName: u.MiddleName,
 		PictureURL: u.PictureURL,
+		Email:      emailOf(u),
 		Suspended:  u.Suspended,
 		Department: dept,
 		Role:       role,
+
+		ExtraPermissions: convertExtraPermissions(u.Edges.Permissions),
+
+		Subdivision:       u.Subdivision,
+		JobTitle:          u.JobTitle,
+		EmploymentRate:    u.EmploymentRate,
+		PersonnelCategory: sesc.PersonnelCategory(u.PersonnelCategory),
+		EmploymentType:    sesc.EmploymentType(u.EmploymentType),
+		AcademicDegree:    sesc.AcademicDegree(u.AcademicDegree),
+		AcademicTitle:     u.AcademicTitle,
+		Honors:            u.Honors,
+		Category:          u.Category,
+		DateOfEmployment:  u.DateOfEmployment,
+		UnemploymentDate:  u.UnemploymentDate,
+
+		DeletedAt: u.DeletedAt,
+		Version:   u.Version,
 	}, nil
 }
+
+// emailField returns the normalized email to store, or nil if it is empty.
+func emailField(email string) *string {
+	normalized := sesc.NormalizeEmail(email)
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+// emailOf returns the email of a user record, or an empty string if it is not set.
+func emailOf(u *ent.User) string {
+	if u.Email == nil {
+		return ""
+	}
+	return *u.Email
+}
+
+// convertExtraPermissions converts the permissions granted to a user.
+// Grants of permissions missing from the catalog are skipped.
+func convertExtraPermissions(grants []*ent.UserPermission) []sesc.Permission {
+	var perms []sesc.Permission
+	for _, g := range grants {
+		if p, ok := sesc.PermissionByID(g.PermissionID); ok {
+			perms = append(perms, p)
+		}
+	}
+	return perms
+}
+
+// orderPermissions makes the granted permissions of a user load in a stable order.
+func orderPermissions(q *ent.UserPermissionQuery) {
+	q.Order(ent.Asc(userpermission.FieldPermissionID))
+}
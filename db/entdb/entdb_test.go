@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/enttest"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
 	"github.com/kozlov-ma/sesc-backend/sesc"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
@@ -86,6 +88,52 @@ func TestCreateDepartment(t *testing.T) {
 	})
 }
 
+func TestUpsertDepartment(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, db *DB) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+
+		db = setupDB(t)
+		return ctx, db
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		ctx, db := setup(t)
+
+		id := uuid.Must(uuid.NewV7())
+		dep, err := db.UpsertDepartment(ctx, id, "HR", "Human Resources")
+		require.NoError(t, err, "UpsertDepartment failed")
+		requireDepartmentMatches(t, sesc.Department{ID: id, Name: "HR", Description: "Human Resources"}, dep)
+
+		got, err := db.DepartmentByID(ctx, id)
+		require.NoError(t, err, "DepartmentByID failed")
+		requireDepartmentMatches(t, dep, got)
+	})
+
+	t.Run("update on conflict", func(t *testing.T) {
+		ctx, db := setup(t)
+
+		existing, err := db.CreateDepartment(ctx, uuid.Must(uuid.NewV7()), "HR", "Human Resources")
+		require.NoError(t, err, "CreateDepartment failed")
+
+		dep, err := db.UpsertDepartment(ctx, uuid.Must(uuid.NewV7()), "HR", "People")
+		require.NoError(t, err, "UpsertDepartment failed")
+		requireDepartmentMatches(t, sesc.Department{ID: existing.ID, Name: "HR", Description: "People"}, dep)
+
+		deps, err := db.Departments(ctx)
+		require.NoError(t, err, "Departments failed")
+		require.Len(t, deps, 1)
+		requireDepartmentMatches(t, dep, deps[0])
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		ctx, db := setup(t)
+
+		_, err := db.UpsertDepartment(ctx, uuid.Must(uuid.NewV7()), "", "")
+		require.ErrorIs(t, err, sesc.ErrInvalidDepartmentName)
+	})
+}
+
 func TestDeleteDepartment(t *testing.T) {
 	setup := func(t *testing.T) (ctx context.Context, db *DB, id uuid.UUID) {
 		ctx = t.Context()
@@ -209,6 +257,22 @@ func TestDepartments(t *testing.T) {
 	})
 }
 
+func TestDepartmentsByIDs(t *testing.T) {
+	ctx := t.Context()
+	ctx, _ = event.NewRecord(ctx, "test")
+	db := setupDB(t)
+
+	existing, err := db.CreateDepartment(ctx, uuid.Must(uuid.NewV7()), "Math", "Mathematics")
+	require.NoError(t, err)
+	_, err = db.CreateDepartment(ctx, uuid.Must(uuid.NewV7()), "Physics", "Physics Dept")
+	require.NoError(t, err)
+
+	deps, err := db.DepartmentsByIDs(ctx, []sesc.UUID{existing.ID, uuid.Must(uuid.NewV7())})
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	requireDepartmentMatches(t, existing, deps[existing.ID])
+}
+
 func TestSaveUser(t *testing.T) {
 	setup := func(t *testing.T) (ctx context.Context, db *DB, depID uuid.UUID) {
 		ctx = t.Context()
@@ -241,11 +305,11 @@ func TestSaveUser(t *testing.T) {
 		}
 		requireUserMatches(t, expected, user)
 
-		savedUser, err := db.UserByID(ctx, user.ID)
+		savedUser, err := db.UserByID(ctx, user.ID, false)
 		require.NoError(t, err)
 		requireUserMatches(t, expected, savedUser)
 
-		us, err := db.Users(ctx)
+		us, err := db.Users(ctx, sesc.UserFilter{})
 		require.NoError(t, err)
 		require.Len(t, us, 1)
 	})
@@ -272,7 +336,7 @@ func TestSaveUser(t *testing.T) {
 		requireUserMatches(t, expected, user)
 
 		// Verify user is retrievable
-		savedUser, err := db.UserByID(ctx, user.ID)
+		savedUser, err := db.UserByID(ctx, user.ID, false)
 		require.NoError(t, err)
 		requireUserMatches(t, expected, savedUser)
 	})
@@ -321,7 +385,17 @@ func TestUpdateDepartment(t *testing.T) {
 		ctx, db, _ := setup(t)
 
 		err := db.UpdateDepartment(ctx, uuid.Must(uuid.NewV7()), "Name", "Desc")
-		require.ErrorIs(t, err, sesc.ErrInvalidDepartment)
+		require.ErrorIs(t, err, sesc.ErrDepartmentNotFound)
+	})
+
+	t.Run("name collision", func(t *testing.T) {
+		ctx, db, id := setup(t)
+
+		_, err := db.CreateDepartment(ctx, uuid.Must(uuid.NewV7()), "Taken", "Taken Desc")
+		require.NoError(t, err)
+
+		err = db.UpdateDepartment(ctx, id, "Taken", "Desc")
+		require.ErrorIs(t, err, sesc.ErrDepartmentExists)
 	})
 }
 
@@ -347,7 +421,7 @@ func TestUpdateProfilePicture(t *testing.T) {
 		err := db.UpdateProfilePicture(ctx, userID, newURL)
 		require.NoError(t, err, "UpdateProfilePicture failed")
 
-		user, err := db.UserByID(ctx, userID)
+		user, err := db.UserByID(ctx, userID, false)
 		require.NoError(t, err)
 
 		expected := sesc.User{
@@ -448,6 +522,50 @@ func TestUpdateUser(t *testing.T) {
 		_, err := db.UpdateUser(ctx, userID, opts)
 		require.ErrorIs(t, err, sesc.ErrInvalidRole)
 	})
+
+	t.Run("version", func(t *testing.T) {
+		ctx, db, _, userID := setup(t)
+		opts := sesc.UserUpdateOptions{
+			FirstName: "Updated",
+			LastName:  "User",
+			NewRoleID: 1,
+			Version:   1,
+		}
+
+		user, err := db.UpdateUser(ctx, userID, opts)
+		require.NoError(t, err, "UpdateUser failed")
+		require.Equal(t, 2, user.Version, "Version should be bumped")
+
+		_, err = db.UpdateUser(ctx, userID, opts)
+		require.ErrorIs(t, err, sesc.ErrStaleUser)
+	})
+
+	t.Run("employment data", func(t *testing.T) {
+		ctx, db, _, userID := setup(t)
+		hired := time.Date(2015, time.September, 1, 0, 0, 0, 0, time.UTC)
+		opts := sesc.UserUpdateOptions{
+			FirstName:         "Updated",
+			LastName:          "User",
+			NewRoleID:         1,
+			JobTitle:          "Teacher of mathematics",
+			EmploymentRate:    0.5,
+			PersonnelCategory: sesc.Pedagogical,
+			EmploymentType:    sesc.MainEmployment,
+			AcademicDegree:    sesc.Doctor,
+			DateOfEmployment:  &hired,
+		}
+
+		user, err := db.UpdateUser(ctx, userID, opts)
+		require.NoError(t, err, "UpdateUser failed")
+		require.Equal(t, "Teacher of mathematics", user.JobTitle)
+		require.InDelta(t, 0.5, user.EmploymentRate, 1e-9)
+		require.Equal(t, sesc.Pedagogical, user.PersonnelCategory)
+		require.Equal(t, sesc.MainEmployment, user.EmploymentType)
+		require.Equal(t, sesc.Doctor, user.AcademicDegree)
+		require.NotNil(t, user.DateOfEmployment)
+		require.True(t, hired.Equal(*user.DateOfEmployment))
+		require.Nil(t, user.UnemploymentDate)
+	})
 }
 
 func TestUserByID(t *testing.T) {
@@ -468,7 +586,7 @@ func TestUserByID(t *testing.T) {
 	t.Run("existing user", func(t *testing.T) {
 		ctx, db, userID := setup(t)
 
-		user, err := db.UserByID(ctx, userID)
+		user, err := db.UserByID(ctx, userID, false)
 		require.NoError(t, err, "UserByID failed")
 
 		expected := sesc.User{
@@ -483,8 +601,65 @@ func TestUserByID(t *testing.T) {
 	t.Run("non-existent user", func(t *testing.T) {
 		ctx, db, _ := setup(t)
 
-		_, err := db.UserByID(ctx, uuid.Must(uuid.NewV7()))
+		_, err := db.UserByID(ctx, uuid.Must(uuid.NewV7()), false)
+		require.ErrorIs(t, err, sesc.ErrUserNotFound)
+	})
+
+	t.Run("archived user", func(t *testing.T) {
+		ctx, db, userID := setup(t)
+
+		err := db.ArchiveUser(ctx, userID)
+		require.NoError(t, err, "ArchiveUser failed")
+
+		_, err = db.UserByID(ctx, userID, false)
 		require.ErrorIs(t, err, sesc.ErrUserNotFound)
+
+		user, err := db.UserByID(ctx, userID, true)
+		require.NoError(t, err, "UserByID failed")
+		require.True(t, user.Archived(), "User should be archived")
+	})
+}
+
+func TestUserExists(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, db *DB, userID uuid.UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		db = setupDB(t)
+		userID = uuid.Must(uuid.NewV7())
+		db.c.User.Create().
+			SetID(userID).
+			SetFirstName("John").
+			SetLastName("Doe").
+			SetRoleID(1).
+			ExecX(ctx)
+		return ctx, db, userID
+	}
+
+	t.Run("existing user", func(t *testing.T) {
+		ctx, db, userID := setup(t)
+
+		exists, err := db.UserExists(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, 1, event.Get(ctx).Value("stats."+events.PostgresQueries))
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, db, _ := setup(t)
+
+		exists, err := db.UserExists(ctx, uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.False(t, exists)
+		require.Equal(t, 1, event.Get(ctx).Value("stats."+events.PostgresQueries))
+	})
+
+	t.Run("archived user", func(t *testing.T) {
+		ctx, db, userID := setup(t)
+		require.NoError(t, db.ArchiveUser(ctx, userID))
+
+		exists, err := db.UserExists(ctx, userID)
+		require.NoError(t, err)
+		require.False(t, exists)
 	})
 }
 
@@ -516,7 +691,7 @@ func TestUsers(t *testing.T) {
 	t.Run("fetch all users", func(t *testing.T) {
 		ctx, db := setup(t)
 
-		users, err := db.Users(ctx)
+		users, err := db.Users(ctx, sesc.UserFilter{})
 		require.NoError(t, err, "Users failed")
 		require.Len(t, users, 2, "Expected 2 users")
 
@@ -528,4 +703,52 @@ func TestUsers(t *testing.T) {
 			require.Equal(t, int32(1), user.Role.ID, "User Role.ID should be 1")
 		}
 	})
+
+	t.Run("exclude archived users", func(t *testing.T) {
+		ctx, db := setup(t)
+
+		users, err := db.Users(ctx, sesc.UserFilter{})
+		require.NoError(t, err, "Users failed")
+		require.NoError(t, db.ArchiveUser(ctx, users[0].ID), "ArchiveUser failed")
+
+		users, err = db.Users(ctx, sesc.UserFilter{})
+		require.NoError(t, err, "Users failed")
+		require.Len(t, users, 1, "Expected 1 user")
+	})
+
+	t.Run("filter suspended users", func(t *testing.T) {
+		ctx, db := setup(t)
+
+		users, err := db.Users(ctx, sesc.UserFilter{})
+		require.NoError(t, err, "Users failed")
+		require.NoError(t, db.SetSuspended(ctx, users[0].ID, true), "SetSuspended failed")
+
+		suspended := true
+		filtered, err := db.Users(ctx, sesc.UserFilter{Suspended: &suspended})
+		require.NoError(t, err, "Users failed")
+		require.Len(t, filtered, 1, "Expected 1 suspended user")
+		require.Equal(t, users[0].ID, filtered[0].ID)
+
+		require.NoError(t, db.SetSuspended(ctx, users[0].ID, false), "SetSuspended failed")
+		filtered, err = db.Users(ctx, sesc.UserFilter{Suspended: &suspended})
+		require.NoError(t, err, "Users failed")
+		require.Empty(t, filtered, "Expected no suspended users")
+	})
+
+	t.Run("suspend non-existent user", func(t *testing.T) {
+		ctx, db := setup(t)
+
+		err := db.SetSuspended(ctx, uuid.Must(uuid.NewV7()), true)
+		require.ErrorIs(t, err, sesc.ErrUserNotFound)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, db := setup(t)
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := db.Users(ctx, sesc.UserFilter{})
+		require.ErrorIs(t, err, sesc.ErrTimeout)
+	})
 }
@@ -0,0 +1,151 @@
+// Package migrations applies the Ent schema to the database and reports whether it is up to date.
+//
+// Every applied schema is identified by a hash of the tables, columns, indexes and foreign keys
+// generated by Ent, and recorded in the schema_versions table.
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/migrate"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/schemaversion"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// Status describes the schema of the database compared to the one of the running code.
+type Status struct {
+	// Hash identifies the schema of the running code.
+	Hash string
+	// AppliedHash identifies the last schema applied to the database, or is empty if none was recorded.
+	AppliedHash string
+	// AppliedAt is when the last schema was applied, or the zero time if none was recorded.
+	AppliedAt time.Time
+}
+
+// UpToDate reports whether the last applied schema is the one of the running code.
+func (s Status) UpToDate() bool {
+	return s.AppliedHash == s.Hash
+}
+
+// Migrator applies the schema using Ent for persistence.
+type Migrator struct {
+	client *ent.Client
+}
+
+// New creates a new Migrator with the given Ent client.
+func New(client *ent.Client) *Migrator {
+	return &Migrator{client: client}
+}
+
+// Apply creates and alters the tables to match the schema of the running code,
+// dropping the columns and indexes that are no longer in it, and records the applied schema
+// unless it is already the last recorded one.
+func (m *Migrator) Apply(ctx context.Context) error {
+	if err := m.client.Schema.Create(ctx, migrate.WithDropIndex(true), migrate.WithDropColumn(true)); err != nil {
+		return fmt.Errorf("couldn't create schema: %w", err)
+	}
+
+	last, err := m.lastVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if last != nil && last.Hash == SchemaHash() {
+		return nil
+	}
+
+	if err := m.client.SchemaVersion.Create().SetHash(SchemaHash()).Exec(ctx); err != nil {
+		return fmt.Errorf("couldn't record schema version: %w", err)
+	}
+	return nil
+}
+
+// Status returns the schema of the running code and the last one applied to the database.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	rec := event.Get(ctx).Sub("migrations/status")
+	statrec := event.Root(ctx).Sub("stats")
+
+	statrec.Add(events.PostgresQueries, 1)
+	start := time.Now()
+	last, err := m.lastVersion(ctx)
+	statrec.Add(events.PostgresTime, time.Since(start))
+
+	if err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return Status{}, err
+	}
+
+	status := Status{Hash: SchemaHash()}
+	if last != nil {
+		status.AppliedHash = last.Hash
+		status.AppliedAt = last.AppliedAt
+	}
+
+	rec.Set(
+		"success", true,
+		"up_to_date", status.UpToDate(),
+	)
+	return status, nil
+}
+
+// lastVersion queries the last recorded schema version, or returns nil if there is none.
+func (m *Migrator) lastVersion(ctx context.Context) (*ent.SchemaVersion, error) {
+	v, err := m.client.SchemaVersion.Query().
+		Order(ent.Desc(schemaversion.FieldAppliedAt), ent.Desc(schemaversion.FieldID)).
+		First(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("couldn't query schema version: %w", err)
+	}
+	return v, nil
+}
+
+// SchemaHash returns the hex-encoded SHA-256 hash of the schema of the running code.
+var SchemaHash = sync.OnceValue(func() string {
+	h := sha256.New()
+	writeSchema(h)
+	return hex.EncodeToString(h.Sum(nil))
+})
+
+// writeSchema writes a description of the tables generated by Ent to w,
+// which changes whenever a migration would change the database.
+func writeSchema(w io.Writer) {
+	for _, t := range migrate.Tables {
+		fmt.Fprintf(w, "table %s\n", t.Name)
+		for _, c := range t.Columns {
+			fmt.Fprintf(w, "column %s %s nullable=%t unique=%t increment=%t default=%v size=%d\n",
+				c.Name, c.Type, c.Nullable, c.Unique, c.Increment, c.Default, c.Size)
+		}
+		for _, c := range t.PrimaryKey {
+			fmt.Fprintf(w, "primary key %s\n", c.Name)
+		}
+		for _, idx := range t.Indexes {
+			fmt.Fprintf(w, "index %s unique=%t", idx.Name, idx.Unique)
+			for _, c := range idx.Columns {
+				fmt.Fprintf(w, " %s", c.Name)
+			}
+			fmt.Fprintln(w)
+		}
+		for _, fk := range t.ForeignKeys {
+			fmt.Fprintf(w, "foreign key %s on delete %s", fk.Symbol, fk.OnDelete)
+			for _, c := range fk.Columns {
+				fmt.Fprintf(w, " %s", c.Name)
+			}
+			fmt.Fprintf(w, " -> %s", fk.RefTable.Name)
+			for _, c := range fk.RefColumns {
+				fmt.Fprintf(w, " %s", c.Name)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
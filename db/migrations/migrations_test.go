@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/enttest"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMigrator(t *testing.T) (context.Context, *ent.Client, *Migrator) {
+	t.Helper()
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	ctx := t.Context()
+	ctx, _ = event.NewRecord(ctx, "test")
+	return ctx, client, New(client)
+}
+
+func TestStatus(t *testing.T) {
+	t.Run("not applied", func(t *testing.T) {
+		ctx, _, m := setupMigrator(t)
+
+		status, err := m.Status(ctx)
+		require.NoError(t, err)
+		require.Equal(t, SchemaHash(), status.Hash)
+		require.Empty(t, status.AppliedHash)
+		require.True(t, status.AppliedAt.IsZero())
+		require.False(t, status.UpToDate())
+	})
+
+	t.Run("applied", func(t *testing.T) {
+		ctx, _, m := setupMigrator(t)
+
+		require.NoError(t, m.Apply(ctx))
+
+		status, err := m.Status(ctx)
+		require.NoError(t, err)
+		require.Equal(t, SchemaHash(), status.AppliedHash)
+		require.False(t, status.AppliedAt.IsZero())
+		require.True(t, status.UpToDate())
+	})
+
+	t.Run("outdated", func(t *testing.T) {
+		ctx, client, m := setupMigrator(t)
+
+		require.NoError(t, client.SchemaVersion.Create().SetHash("previous").Exec(ctx))
+
+		status, err := m.Status(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "previous", status.AppliedHash)
+		require.False(t, status.UpToDate())
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("records the schema once", func(t *testing.T) {
+		ctx, client, m := setupMigrator(t)
+
+		require.NoError(t, m.Apply(ctx))
+		require.NoError(t, m.Apply(ctx))
+
+		count, err := client.SchemaVersion.Query().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("records a changed schema", func(t *testing.T) {
+		ctx, client, m := setupMigrator(t)
+
+		require.NoError(t, client.SchemaVersion.Create().SetHash("previous").Exec(ctx))
+		require.NoError(t, m.Apply(ctx))
+
+		count, err := client.SchemaVersion.Query().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		status, err := m.Status(ctx)
+		require.NoError(t, err)
+		require.True(t, status.UpToDate())
+	})
+}
+
+func TestSchemaHash(t *testing.T) {
+	require.Len(t, SchemaHash(), 64)
+	require.Equal(t, SchemaHash(), SchemaHash())
+}
@@ -0,0 +1,51 @@
+// Package txretry retries serializable transactions aborted by concurrent ones.
+package txretry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DefaultAttempts is the number of attempts the services make to run a serializable transaction.
+const DefaultAttempts = 3
+
+// serializationFailure is the Postgres error code of a transaction aborted
+// because it could not be serialized with the concurrent ones.
+const serializationFailure = "40001"
+
+// baseDelay is the delay before the first retry, it doubles with every next one.
+var baseDelay = 10 * time.Millisecond
+
+// IsSerializationFailure reports whether err is caused by a Postgres serialization failure,
+// after which the whole transaction can be run again.
+func IsSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == serializationFailure
+}
+
+// Do calls fn up to attempts times, while it fails with a serialization failure,
+// waiting with an exponential backoff between the calls.
+// Fn should run the whole transaction, from begin to commit.
+//
+// Other errors are returned immediately, as well as the context error if ctx is done while waiting.
+func Do(ctx context.Context, attempts int, fn func() error) error {
+	delay := baseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= attempts || !IsSerializationFailure(err) {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
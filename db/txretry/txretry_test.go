@@ -0,0 +1,73 @@
+package txretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	baseDelay = time.Millisecond
+
+	serializationErr := fmt.Errorf("couldn't commit transaction: %w", &pq.Error{Code: serializationFailure})
+
+	// failing returns a function failing with err the first failures times, and the number of calls.
+	failing := func(failures int, err error) (func() error, *int) {
+		calls := 0
+		return func() error {
+			calls++
+			if calls <= failures {
+				return err
+			}
+			return nil
+		}, &calls
+	}
+
+	t.Run("retries serialization failures", func(t *testing.T) {
+		fn, calls := failing(2, serializationErr)
+
+		err := Do(t.Context(), 3, fn)
+		require.NoError(t, err)
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("gives up after the attempts", func(t *testing.T) {
+		fn, calls := failing(5, serializationErr)
+
+		err := Do(t.Context(), 3, fn)
+		require.ErrorIs(t, err, serializationErr)
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		other := errors.New("user not found")
+		fn, calls := failing(5, other)
+
+		err := Do(t.Context(), 3, fn)
+		require.ErrorIs(t, err, other)
+		require.Equal(t, 1, *calls)
+
+		uniqueViolation := &pq.Error{Code: "23505"}
+		fn, calls = failing(5, uniqueViolation)
+
+		err = Do(t.Context(), 3, fn)
+		require.ErrorIs(t, err, uniqueViolation)
+		require.Equal(t, 1, *calls)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		fn, calls := failing(5, serializationErr)
+
+		err := Do(ctx, 3, fn)
+		require.ErrorIs(t, err, serializationErr)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, *calls)
+	})
+}
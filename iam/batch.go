@@ -0,0 +1,174 @@
+package iam
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/txretry"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// CredentialsRegistration is a row of RegisterCredentialsBatch.
+type CredentialsRegistration struct {
+	UserID UUID
+	Creds  Credentials
+}
+
+// CredentialsRegistrationResult is the outcome of a row of RegisterCredentialsBatch:
+// the auth ID of the registered credentials, or the error the row was skipped with.
+type CredentialsRegistrationResult struct {
+	UserID UUID
+	AuthID UUID
+	Err    error
+}
+
+// RegisterCredentialsBatch assigns the credentials of every row to its user in a single transaction,
+// replacing the old ones like RegisterCredentials, and returns a result per row in the same order.
+//
+// A row that RegisterCredentials would reject is skipped with the same error in its result rather than
+// aborting the batch. So is a row whose username or user already appears earlier in the batch,
+// with an ErrCredentialsAlreadyExist. The returned error is only set if the whole batch failed,
+// in which case nothing is registered.
+func (i *IAM) RegisterCredentialsBatch(
+	ctx context.Context,
+	rows []CredentialsRegistration,
+) ([]CredentialsRegistrationResult, error) {
+	rec := event.Get(ctx).Sub("iam/register_credentials_batch")
+	statrec := event.Root(ctx).Sub("stats")
+
+	rec.Sub("params").Set("count", len(rows))
+
+	// Stage 1: Validate credentials
+	results := make([]CredentialsRegistrationResult, len(rows))
+	for n, row := range rows {
+		results[n].UserID = row.UserID
+		rowCtx := rec.Sub("validate_credentials").Sub(strconv.Itoa(n)).Wrap(ctx)
+		results[n].Err = i.validateCredentials(rowCtx, row.Creds)
+	}
+
+	// Stage 2: Register the valid credentials in a transaction, retried on serialization failures
+	var registered []CredentialsRegistrationResult
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		registered = append(registered[:0], results...)
+		return i.registerCredentialsBatchTx(ctx, rec, statrec, rows, registered)
+	})
+	if err != nil {
+		rec.Add(events.Error, err)
+		return nil, err
+	}
+
+	skipped := 0
+	for _, res := range registered {
+		if res.Err != nil {
+			skipped++
+		}
+	}
+	rec.Set(
+		"success", true,
+		"registered", len(rows)-skipped,
+		"skipped", skipped,
+	)
+	return registered, nil
+}
+
+// registerCredentialsBatchTx registers the credentials of the rows without an error in results
+// in a serializable transaction, filling in the results. The rows rejected by the checks are skipped.
+func (i *IAM) registerCredentialsBatchTx(
+	ctx context.Context,
+	rec *event.Record,
+	statrec *event.Record,
+	rows []CredentialsRegistration,
+	results []CredentialsRegistrationResult,
+) error {
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+
+	tx, err := i.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		txrec.Add(events.Error, err)
+		return fmt.Errorf("couldn't start transaction: %w", err)
+	}
+
+	rollback := func(err error) error {
+		txrec.Set("rollback", true)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			txrec.Add(events.Error, err)
+			txrec.Set("rollback_failed", true)
+			return fmt.Errorf("%w: rollback failed: %w", err, rbErr)
+		}
+		return err
+	}
+
+	usernames := make(map[string]bool, len(rows))
+	users := make(map[UUID]bool, len(rows))
+	for n, row := range rows {
+		if results[n].Err != nil {
+			continue
+		}
+
+		switch {
+		case usernames[row.Creds.Username]:
+			results[n].Err = fmt.Errorf("%w: username %q is earlier in the batch", ErrCredentialsAlreadyExist, row.Creds.Username)
+			continue
+		case users[row.UserID]:
+			results[n].Err = fmt.Errorf("%w: user %s is earlier in the batch", ErrCredentialsAlreadyExist, row.UserID)
+			continue
+		}
+
+		rowCtx := rec.Sub("rows").Sub(strconv.Itoa(n)).Wrap(ctx)
+		authID, err := i.registerBatchRow(rowCtx, tx, row)
+		switch {
+		case errors.Is(err, ErrUserNotFound), errors.Is(err, ErrCredentialsAlreadyExist):
+			results[n].Err = err
+			continue
+		case err != nil:
+			return rollback(err)
+		}
+
+		usernames[row.Creds.Username] = true
+		users[row.UserID] = true
+		results[n].AuthID = authID
+	}
+
+	if err := tx.Commit(); err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return rollback(err)
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+	return nil
+}
+
+// registerBatchRow replaces the credentials of the user of a row within tx, after the same checks as RegisterCredentials.
+func (i *IAM) registerBatchRow(ctx context.Context, tx *ent.Tx, row CredentialsRegistration) (UUID, error) {
+	rec := event.Get(ctx)
+
+	// Stage 1: Check if user exists
+	if err := i.checkUserExists(rec.Sub("check_user_exists").Wrap(ctx), tx, row.UserID); err != nil {
+		return UUID{}, err
+	}
+
+	// Stage 2: Check if username is free
+	if err := i.checkUsernameFree(rec.Sub("check_username_free").Wrap(ctx), tx, row.Creds.Username); err != nil {
+		return UUID{}, err
+	}
+
+	// Stage 3: Delete old credentials
+	if err := i.deleteOldCredentials(rec.Sub("delete_old_credentials").Wrap(ctx), tx, row.UserID); err != nil {
+		return UUID{}, err
+	}
+
+	// Stage 4: Create auth record
+	return i.createAuthRecord(rec.Sub("create_auth_record").Wrap(ctx), tx, row.UserID, row.Creds)
+}
@@ -0,0 +1,91 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCredentialsBatch(t *testing.T) {
+	t.Run("one duplicate username among valid rows", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		iam := setupIAM(t)
+
+		taken := createTestUser(ctx, t, iam.client)
+		_, err := iam.RegisterCredentials(ctx, taken, Credentials{Username: "taken", Password: "password123"})
+		require.NoError(t, err)
+
+		users := make([]UUID, 4)
+		for n := range users {
+			users[n] = createTestUser(ctx, t, iam.client)
+		}
+
+		results, err := iam.RegisterCredentialsBatch(ctx, []CredentialsRegistration{
+			{UserID: users[0], Creds: Credentials{Username: "first", Password: "password123"}},
+			{UserID: users[1], Creds: Credentials{Username: "taken", Password: "password123"}},
+			{UserID: users[2], Creds: Credentials{Username: "second", Password: "password123"}},
+			{UserID: users[3], Creds: Credentials{Username: "first", Password: "password123"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 4)
+
+		for _, n := range []int{0, 2} {
+			require.NoError(t, results[n].Err)
+			require.Equal(t, users[n], results[n].UserID)
+			require.NotEqual(t, uuid.Nil, results[n].AuthID)
+		}
+		require.ErrorIs(t, results[1].Err, ErrCredentialsAlreadyExist, "Taken before the batch")
+		require.ErrorIs(t, results[3].Err, ErrCredentialsAlreadyExist, "Taken earlier in the batch")
+		require.Equal(t, uuid.Nil, results[3].AuthID)
+
+		creds, err := iam.Credentials(ctx, users[0])
+		require.NoError(t, err)
+		require.Equal(t, "first", creds.Username)
+		creds, err = iam.Credentials(ctx, users[2])
+		require.NoError(t, err)
+		require.Equal(t, "second", creds.Username)
+
+		for _, n := range []int{1, 3} {
+			has, err := iam.HasCredentials(ctx, users[n])
+			require.NoError(t, err)
+			require.False(t, has, "Skipped rows are not registered")
+		}
+
+		creds, err = iam.Credentials(ctx, taken)
+		require.NoError(t, err)
+		require.Equal(t, "taken", creds.Username, "Existing credentials are kept")
+	})
+
+	t.Run("invalid rows are skipped", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		iam := setupIAM(t)
+		userID := createTestUser(ctx, t, iam.client)
+
+		results, err := iam.RegisterCredentialsBatch(ctx, []CredentialsRegistration{
+			{UserID: userID, Creds: Credentials{Password: "password123"}},
+			{UserID: uuid.Must(uuid.NewV7()), Creds: Credentials{Username: "ghost", Password: "password123"}},
+			{UserID: userID, Creds: Credentials{Username: "valid", Password: "password123"}},
+			{UserID: userID, Creds: Credentials{Username: "again", Password: "password123"}},
+		})
+		require.NoError(t, err)
+		require.ErrorIs(t, results[0].Err, ErrEmptyUsername)
+		require.ErrorIs(t, results[1].Err, ErrUserNotFound)
+		require.NoError(t, results[2].Err)
+		require.ErrorIs(t, results[3].Err, ErrCredentialsAlreadyExist, "The user is registered earlier in the batch")
+
+		creds, err := iam.Credentials(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, "valid", creds.Username)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		iam := setupIAM(t)
+
+		results, err := iam.RegisterCredentialsBatch(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+}
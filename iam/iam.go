@@ -2,18 +2,24 @@ package iam
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/authuser"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/predicate"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/txretry"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/kozlov-ma/sesc-backend/sesc"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var (
@@ -30,6 +36,7 @@ var (
 	ErrTokenExpired            = errors.New("token expired")
 	ErrInvalidTokenFormat      = errors.New("invalid token format")
 	ErrTokenSignature          = errors.New("invalid token signature")
+	ErrTooManyAttempts         = errors.New("too many failed login attempts")
 )
 
 type Credentials struct {
@@ -39,9 +46,28 @@ type Credentials struct {
 
 type AdminCredentials struct {
 	ID UUID
+	// OrgID is the organization the admin manages, the nil UUID for the default one.
+	OrgID UUID
+	// PasswordHash is the bcrypt hash of the password. If set, the Password is ignored,
+	// otherwise the Password is compared in plaintext.
+	PasswordHash []byte
 	Credentials
 }
 
+// matches reports whether creds match the admin credentials, in a time not depending on which of them differ.
+func (c AdminCredentials) matches(creds Credentials) bool {
+	usernameOK := subtle.ConstantTimeCompare([]byte(c.Username), []byte(creds.Username)) == 1
+
+	var passwordOK bool
+	if len(c.PasswordHash) > 0 {
+		passwordOK = bcrypt.CompareHashAndPassword(c.PasswordHash, []byte(creds.Password)) == nil
+	} else {
+		passwordOK = c.Password != "" && subtle.ConstantTimeCompare([]byte(c.Password), []byte(creds.Password)) == 1
+	}
+
+	return usernameOK && passwordOK
+}
+
 func (c Credentials) Validate() error {
 	if c.Username == "" {
 		return ErrEmptyUsername
@@ -63,28 +89,54 @@ type Identity struct {
 	AuthID uuid.UUID
 	Role   Role
 	ID     uuid.UUID
+	// OrgID is the organization of the user or the admin, the nil UUID for the default one.
+	OrgID uuid.UUID
 }
 
 // IAM handles authentication using Ent for persistence.
 type IAM struct {
 	client           *ent.Client
 	adminCredentials []AdminCredentials
-	tokenDuration    time.Duration
+	tokenDurations   map[Role]time.Duration
 	jwtkey           []byte
+	passwordPolicy   PasswordPolicy
+	loginThrottle    *loginThrottle
 }
 
+// Option configures an IAM.
+type Option func(*IAM)
+
 // New creates a new IAM with the given Ent client.
+// The tokens of every role live for tokenDuration unless WithTokenDuration overrides it.
 func New(
 	client *ent.Client,
 	tokenDuration time.Duration,
 	adminCredentials []AdminCredentials,
 	jwtkey []byte,
+	opts ...Option,
 ) *IAM {
-	return &IAM{
+	i := &IAM{
 		client:           client,
 		adminCredentials: adminCredentials,
-		tokenDuration:    tokenDuration,
-		jwtkey:           jwtkey,
+		tokenDurations: map[Role]time.Duration{
+			RoleUser:  tokenDuration,
+			RoleAdmin: tokenDuration,
+		},
+		jwtkey: jwtkey,
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// WithTokenDuration sets the lifetime of the tokens issued to the role.
+// A non-positive duration keeps the one passed to New.
+func WithTokenDuration(role Role, d time.Duration) Option {
+	return func(i *IAM) {
+		if d > 0 {
+			i.tokenDurations[role] = d
+		}
 	}
 }
 
@@ -92,7 +144,7 @@ type UUID = uuid.UUID
 
 // RegisterCredentials assigns username/password to an existing userID, returns authID.
 // Returns ErrUserDoesNotExist if user does not exist, ErrUserAlreadyExists if username exists,
-// or ErrInvalidCredentials if creds invalid.
+// ErrInvalidCredentials if creds invalid, or ErrWeakPassword if the password does not meet the password policy.
 func (i *IAM) RegisterCredentials(
 	ctx context.Context,
 	userID UUID,
@@ -112,6 +164,30 @@ func (i *IAM) RegisterCredentials(
 		return UUID{}, err
 	}
 
+	// Stages 2-5: Register credentials in a transaction, retried on serialization failures
+	var authID UUID
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		var err error
+		authID, err = i.registerCredentialsTx(ctx, rec, statrec, userID, creds)
+		return err
+	})
+	if err != nil {
+		return UUID{}, err
+	}
+
+	rec.Set("success", true)
+
+	return authID, nil
+}
+
+// registerCredentialsTx replaces the credentials of the user in a serializable transaction.
+func (i *IAM) registerCredentialsTx(
+	ctx context.Context,
+	rec *event.Record,
+	statrec *event.Record,
+	userID UUID,
+	creds Credentials,
+) (UUID, error) {
 	txrec := rec.Sub("pg_transaction")
 	txrec.Set("rollback", false)
 
@@ -169,7 +245,6 @@ func (i *IAM) RegisterCredentials(
 	}
 
 	statrec.Add(events.PostgresTime, time.Since(txStart))
-	rec.Set("success", true)
 
 	return authID, nil
 }
@@ -188,11 +263,23 @@ func (i *IAM) validateCredentials(
 		return err
 	}
 
+	if err := i.passwordPolicy.Check(creds.Password); err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("valid", false)
+		return err
+	}
+
 	rec.Set("valid", true)
 	return nil
 }
 
-// checkUserExists checks if the user exists in the database
+// inOrg matches the users of the organization of ctx, so that an admin can't reach
+// the credentials of another organization by the user ID.
+func inOrg(ctx context.Context) predicate.User {
+	return user.OrgID(sesc.OrgFromContext(ctx))
+}
+
+// checkUserExists checks if the user exists in the organization of ctx
 func (i *IAM) checkUserExists(
 	ctx context.Context,
 	tx *ent.Tx,
@@ -202,7 +289,7 @@ func (i *IAM) checkUserExists(
 	rec.Set("user_id", userID)
 
 	userExists, err := tx.User.Query().
-		Where(user.ID(userID)).
+		Where(user.ID(userID), inOrg(ctx)).
 		Exist(ctx)
 	if err != nil {
 		err := fmt.Errorf("error checking user existence: %w", err)
@@ -312,6 +399,11 @@ func (i *IAM) createAuthRecord(
 }
 
 // Login verifies credentials and returns signed JWT token string.
+// The username of the credentials may also be the email of the user,
+// which is only tried if no user has such a username.
+// Returns ErrUnauthorized if the user has been archived,
+// or ErrTooManyAttempts if the account is locked out after too many failed logins,
+// whether they were made with its username or with any spelling of its email.
 func (i *IAM) Login(ctx context.Context, creds Credentials) (string, error) {
 	rec := event.Get(ctx).Sub("iam/login")
 
@@ -325,14 +417,36 @@ func (i *IAM) Login(ctx context.Context, creds Credentials) (string, error) {
 		return "", err
 	}
 
-	// Stage 2: Find auth record
+	// Stage 2: Check that the account is not locked out
+	var throttleKey string
+	if i.loginThrottle != nil {
+		ctx = rec.Sub("resolve_throttle_key").Wrap(ctx)
+		var err error
+		if throttleKey, err = i.loginThrottleKey(ctx, creds.Username); err != nil {
+			return "", err
+		}
+		if i.loginThrottle.locked(throttleKey, time.Now()) {
+			rec.Set("locked_out", true)
+			return "", ErrTooManyAttempts
+		}
+	}
+
+	// Stage 3: Find auth record
 	ctx = rec.Sub("find_auth_record").Wrap(ctx)
 	authRec, err := i.findAuthRecord(ctx, creds)
+	if i.loginThrottle != nil {
+		switch {
+		case errors.Is(err, ErrUserNotFound):
+			i.loginThrottle.fail(throttleKey, time.Now())
+		case err == nil:
+			i.loginThrottle.reset(throttleKey)
+		}
+	}
 	if err != nil {
 		return "", err
 	}
 
-	// Stage 3: Generate token
+	// Stage 4: Generate token
 	ctx = rec.Sub("generate_token").Wrap(ctx)
 	token, err := i.generateUserToken(ctx, authRec)
 	if err != nil {
@@ -343,6 +457,57 @@ func (i *IAM) Login(ctx context.Context, creds Credentials) (string, error) {
 	return token, nil
 }
 
+// loginThrottleKey returns the key the failed logins with the username are counted by.
+// Logins with the username or the email of an account are counted together, by the ID of the user,
+// like findAuthRecord trying the username first. Unknown usernames are counted by their normalized form,
+// so that the spellings of the same name share the count.
+func (i *IAM) loginThrottleKey(ctx context.Context, username string) (string, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	statrec.Add(events.PostgresQueries, 1)
+	pgTime := time.Now()
+	authRecs, err := i.client.AuthUser.Query().
+		Where(authuser.Username(username)).
+		Select(authuser.FieldUserID).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(pgTime))
+	if err != nil {
+		err := fmt.Errorf("couldn't query account by username: %w", err)
+		rec.Add(events.Error, err)
+		return "", err
+	}
+	userIDs := make([]uuid.UUID, 0, len(authRecs))
+	for _, authRec := range authRecs {
+		userIDs = append(userIDs, authRec.UserID)
+	}
+
+	normalized := sesc.NormalizeEmail(username)
+	if len(userIDs) == 0 && strings.Contains(normalized, "@") {
+		statrec.Add(events.PostgresQueries, 1)
+		pgTime := time.Now()
+		userIDs, err = i.client.User.Query().
+			Where(user.Email(normalized), user.HasAuth()).
+			IDs(ctx)
+		statrec.Add(events.PostgresTime, time.Since(pgTime))
+		if err != nil {
+			err := fmt.Errorf("couldn't query account by email: %w", err)
+			rec.Add(events.Error, err)
+			return "", err
+		}
+	}
+
+	if len(userIDs) == 0 {
+		rec.Set("account_found", false)
+		return "username:" + normalized, nil
+	}
+	rec.Set(
+		"account_found", true,
+		"user_id", userIDs[0],
+	)
+	return "user:" + userIDs[0].String(), nil
+}
+
 // validateLoginCredentials validates the login credentials
 func (i *IAM) validateLoginCredentials(
 	ctx context.Context,
@@ -361,7 +526,7 @@ func (i *IAM) validateLoginCredentials(
 	return nil
 }
 
-// findAuthRecord finds the auth record for the given credentials
+// findAuthRecord finds the auth record for the given credentials, by the username and then by the email
 func (i *IAM) findAuthRecord(
 	ctx context.Context,
 	creds Credentials,
@@ -377,9 +542,25 @@ func (i *IAM) findAuthRecord(
 	authRec, err := i.client.AuthUser.
 		Query().
 		Where(authuser.Username(creds.Username), authuser.Password(creds.Password)).
+		WithUser().
 		Only(ctx)
 	statrec.Add(events.PostgresTime, time.Since(pgTime))
 
+	if ent.IsNotFound(err) && strings.Contains(creds.Username, "@") {
+		// Emails are stored normalized, see sesc.NormalizeEmail.
+		email := strings.ToLower(strings.TrimSpace(creds.Username))
+		rec.Set("by_email", true)
+
+		pgTime := time.Now()
+		statrec.Add(events.PostgresQueries, 1)
+		authRec, err = i.client.AuthUser.
+			Query().
+			Where(authuser.HasUserWith(user.Email(email)), authuser.Password(creds.Password)).
+			WithUser().
+			Only(ctx)
+		statrec.Add(events.PostgresTime, time.Since(pgTime))
+	}
+
 	if ent.IsNotFound(err) {
 		rec.Set("found", false)
 		return nil, ErrUserNotFound
@@ -390,6 +571,14 @@ func (i *IAM) findAuthRecord(
 		return nil, err
 	}
 
+	if u := authRec.Edges.User; u != nil && u.DeletedAt != nil {
+		rec.Set(
+			"found", true,
+			"archived", true,
+		)
+		return nil, ErrUnauthorized
+	}
+
 	rec.Set(
 		"found", true,
 		"user_id", authRec.UserID,
@@ -406,15 +595,23 @@ func (i *IAM) generateUserToken(
 	authRec *ent.AuthUser,
 ) (string, error) {
 	rec := event.Get(ctx).Sub("generate_token")
+
+	var orgID UUID
+	if u := authRec.Edges.User; u != nil {
+		orgID = u.OrgID
+	}
 	rec.Set(
 		"auth_id", authRec.AuthID,
 		"role", string(RoleUser),
+		"org_id", orgID,
 	)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id": authRec.AuthID.String(),
 		"role":    string(RoleUser),
-		"exp":     time.Now().Add(i.tokenDuration).Unix(),
+		"org_id":  orgID.String(),
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(i.tokenDurations[RoleUser]).Unix(),
 	})
 
 	signed, err := token.SignedString(i.jwtkey)
@@ -439,13 +636,13 @@ func (i *IAM) LoginAdmin(ctx context.Context, creds Credentials) (string, error)
 	ctx = rec.Wrap(ctx)
 
 	// Stage 1: Verify admin credentials
-	id, err := i.verifyAdminCredentials(ctx, creds)
+	admin, err := i.verifyAdminCredentials(ctx, creds)
 	if err != nil {
 		return "", err
 	}
 
 	// Stage 2: Generate admin token
-	token, err := i.generateAdminToken(ctx, id)
+	token, err := i.generateAdminToken(ctx, admin)
 	if err != nil {
 		return "", err
 	}
@@ -457,32 +654,40 @@ func (i *IAM) LoginAdmin(ctx context.Context, creds Credentials) (string, error)
 func (i *IAM) verifyAdminCredentials(
 	ctx context.Context,
 	creds Credentials,
-) (UUID, error) {
+) (AdminCredentials, error) {
 	rec := event.Get(ctx).Sub("verify_admin_credentials")
 	rec.Set("username", creds.Username)
 
+	// Every admin is checked, so that the time doesn't tell which username exists.
+	var admin AdminCredentials
+	found := false
 	for _, c := range i.adminCredentials {
-		if c.Credentials == creds {
-			rec.Set("valid", true)
-			return c.ID, nil
+		if c.matches(creds) && !found {
+			admin, found = c, true
 		}
 	}
 
-	rec.Set("valid", false)
-	return uuid.Nil, ErrUserNotFound
+	rec.Set("valid", found)
+	if !found {
+		return AdminCredentials{}, ErrUserNotFound
+	}
+	return admin, nil
 }
 
 // generateAdminToken generates a JWT token for an admin
 func (i *IAM) generateAdminToken(
 	ctx context.Context,
-	id UUID,
+	admin AdminCredentials,
 ) (string, error) {
 	rec := event.Get(ctx).Sub("generate_admin_token")
+	rec.Set("org_id", admin.OrgID)
 
 	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": id.String(), // Add user_id claim for admin
+		"user_id": admin.ID.String(), // Add user_id claim for admin
 		"role":    string(RoleAdmin),
-		"exp":     time.Now().Add(i.tokenDuration).Unix(),
+		"org_id":  admin.OrgID.String(),
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(i.tokenDurations[RoleAdmin]).Unix(),
 	})
 
 	// Use SignedString with jwtKey instead of SigningString
@@ -499,6 +704,8 @@ func (i *IAM) generateAdminToken(
 }
 
 // ImWatermelon parses tokenString, returns Identity or ErrInvalidToken.
+// Expired, malformed and badly signed tokens are additionally reported
+// with ErrTokenExpired, ErrInvalidTokenFormat and ErrTokenSignature.
 func (i *IAM) ImWatermelon(ctx context.Context, tokenString string) (Identity, error) {
 	rec := event.Get(ctx).Sub("iam/im_watermelon")
 
@@ -516,48 +723,59 @@ func (i *IAM) ImWatermelon(ctx context.Context, tokenString string) (Identity, e
 		return Identity{}, err
 	}
 
-	// Stage 3: handle admin role
+	// Stage 3: Extract organization
+	ctx = rec.Sub("extract_org").Wrap(ctx)
+	orgID, err := i.extractOrgClaim(ctx, claims)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	// Stage 4: handle admin role
 	if roleStr == string(RoleAdmin) {
 		ctx = rec.Sub("check_admin_role").Wrap(ctx)
-		if err := i.checkAdminRole(ctx, authIDStr); err != nil {
+		adminID, err := i.checkAdminRole(ctx, authIDStr)
+		if err != nil {
 			return Identity{}, err
 		}
 		return Identity{
 			AuthID: uuid.Nil,
 			Role:   RoleAdmin,
+			ID:     adminID,
+			OrgID:  orgID,
 		}, nil
 	}
 
-	// Stage 4: Retrieve auth user for normal user
+	// Stage 5: Retrieve auth user for normal user
 	ctx = rec.Sub("retrieve_identity").Wrap(ctx)
 	identity, err := i.retrieveUserIdentity(ctx, authIDStr, roleStr)
 	if err != nil {
 		return Identity{}, err
 	}
+	identity.OrgID = orgID
 
 	rec.Set("success", true)
 	return identity, nil
 }
 
-func (i *IAM) checkAdminRole(ctx context.Context, authIDStr string) error {
+func (i *IAM) checkAdminRole(ctx context.Context, authIDStr string) (UUID, error) {
 	rec := event.Get(ctx).Sub("check_admin_role")
 
 	var id uuid.UUID
 	if err := (&id).Parse(authIDStr); err != nil {
-		rec.Add("auth_id_valid", false)
-		return ErrInvalidToken
+		rec.Set("auth_id_valid", false)
+		return UUID{}, ErrInvalidToken
 	}
-	rec.Add("auth_id_valid", false)
+	rec.Set("auth_id_valid", true)
 
 	for _, c := range i.adminCredentials {
 		if c.ID == id {
-			rec.Add("auth_id_exists", true)
-			return nil
+			rec.Set("auth_id_exists", true)
+			return id, nil
 		}
 	}
 
-	rec.Add("auth_id_exists", false)
-	return ErrUserNotFound
+	rec.Set("auth_id_exists", false)
+	return UUID{}, ErrUserNotFound
 }
 
 // parseAndValidateToken parses and validates the JWT token
@@ -567,17 +785,24 @@ func (i *IAM) parseAndValidateToken(
 ) (jwt.MapClaims, error) {
 	rec := event.Get(ctx).Sub("parse_token")
 
-	parsed, err := jwt.Parse(tokenString, func(t *jwt.Token) (any, error) {
-		if t.Method != jwt.SigningMethodHS256 {
-			return nil, ErrInvalidToken
-		}
-		return i.jwtkey, nil
-	})
+	parsed, err := jwt.Parse(
+		tokenString,
+		func(t *jwt.Token) (any, error) {
+			if t.Method != jwt.SigningMethodHS256 {
+				return nil, ErrInvalidToken
+			}
+			return i.jwtkey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 
 	if err != nil || !parsed.Valid {
+		err := tokenError(err)
 		rec.Add(events.Error, err)
 		rec.Set("valid", false)
-		return nil, errors.Join(ErrInvalidToken, err)
+		return nil, err
 	}
 
 	claims, ok := parsed.Claims.(jwt.MapClaims)
@@ -590,6 +815,21 @@ func (i *IAM) parseAndValidateToken(
 	return claims, nil
 }
 
+// tokenError converts an error returned by the jwt parser to a specific IAM error.
+// The result always wraps ErrInvalidToken.
+func tokenError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return errors.Join(ErrTokenExpired, ErrInvalidToken, err)
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return errors.Join(ErrTokenSignature, ErrInvalidToken, err)
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return errors.Join(ErrInvalidTokenFormat, ErrInvalidToken, err)
+	default:
+		return errors.Join(ErrInvalidToken, err)
+	}
+}
+
 // extractTokenClaims extracts and validates token claims
 func (i *IAM) extractTokenClaims(
 	ctx context.Context,
@@ -617,6 +857,36 @@ func (i *IAM) extractTokenClaims(
 	return authIDStr, roleStr, nil
 }
 
+// extractOrgClaim extracts the organization from the token claims.
+// Tokens issued before organizations were introduced have none, and belong to the default one.
+func (i *IAM) extractOrgClaim(ctx context.Context, claims jwt.MapClaims) (UUID, error) {
+	rec := event.Get(ctx)
+
+	raw, ok := claims["org_id"]
+	if !ok {
+		rec.Set("present", false)
+		return uuid.Nil, nil
+	}
+	rec.Set("present", true)
+
+	orgStr, ok := raw.(string)
+	if !ok {
+		rec.Set("valid", false)
+		return uuid.Nil, ErrInvalidToken
+	}
+	orgID, err := uuid.FromString(orgStr)
+	if err != nil {
+		rec.Set("valid", false)
+		return uuid.Nil, ErrInvalidToken
+	}
+
+	rec.Set(
+		"valid", true,
+		"org_id", orgID,
+	)
+	return orgID, nil
+}
+
 // retrieveUserIdentity retrieves the user identity from the database
 func (i *IAM) retrieveUserIdentity(
 	ctx context.Context,
@@ -638,7 +908,7 @@ func (i *IAM) retrieveUserIdentity(
 	statrec.Add(events.PostgresQueries, 1)
 
 	pgTime := time.Now()
-	res, err := i.client.AuthUser.Query().Where(authuser.AuthID(aid)).Only(ctx)
+	res, err := i.client.AuthUser.Query().Where(authuser.AuthID(aid)).WithUser().Only(ctx)
 	statrec.Add(events.PostgresTime, time.Since(pgTime))
 
 	switch {
@@ -652,6 +922,14 @@ func (i *IAM) retrieveUserIdentity(
 		return Identity{}, err
 	}
 
+	if u := res.Edges.User; u != nil && u.DeletedAt != nil {
+		rec.Set(
+			"found", true,
+			"archived", true,
+		)
+		return Identity{}, ErrUnauthorized
+	}
+
 	rec.Set(
 		"found", true,
 		"user_id", res.UserID,
@@ -668,8 +946,8 @@ func (i *IAM) retrieveUserIdentity(
 	return identity, nil
 }
 
-// DropCredentials deletes credentials by userID; returns ErrUserNotFound if credentials missing,
-// or ErrUserDoesNotExist if the user doesn't exist.
+// DropCredentials deletes credentials by userID; returns ErrCredentialsNotFound if the user has no credentials,
+// or ErrUserNotFound if the user doesn't exist in the organization of ctx.
 func (i *IAM) DropCredentials(ctx context.Context, userID UUID) error {
 	rec := event.Get(ctx).Sub("iam/drop_credentials")
 	statrec := event.Get(ctx).Sub("stats")
@@ -729,7 +1007,7 @@ func (i *IAM) DropCredentials(ctx context.Context, userID UUID) error {
 	return nil
 }
 
-// checkUserExistsForDrop checks if the user exists in the database
+// checkUserExistsForDrop checks if the user exists in the organization of ctx
 func (i *IAM) checkUserExistsForDrop(
 	ctx context.Context,
 	tx *ent.Tx,
@@ -742,7 +1020,7 @@ func (i *IAM) checkUserExistsForDrop(
 	rec.Set("user_id", userID)
 
 	statrec.Add(events.PostgresQueries, 1)
-	user, err := tx.User.Get(ctx, userID)
+	user, err := tx.User.Query().Where(user.ID(userID), inOrg(ctx)).Only(ctx)
 	switch {
 	case ent.IsNotFound(err):
 		rec.Set("exists", false)
@@ -786,7 +1064,7 @@ func (i *IAM) checkCredentialsExist(
 	switch {
 	case ent.IsNotFound(err):
 		rec.Set("exists", false)
-		return nil, ErrUserNotFound
+		return nil, ErrCredentialsNotFound
 	case err != nil:
 		err := fmt.Errorf("error checking credentials existence: %w", err)
 		rec.Add(events.Error, err)
@@ -833,6 +1111,8 @@ func (i *IAM) deleteCredentials(
 	return nil
 }
 
+// Credentials returns the credentials of a user.
+// Returns ErrUserNotFound if the user has no credentials or does not exist in the organization of ctx.
 func (i *IAM) Credentials(ctx context.Context, userID UUID) (Credentials, error) {
 	rec := event.Get(ctx).Sub("iam/credentials")
 
@@ -869,7 +1149,9 @@ func (i *IAM) queryUserCredentials(
 	statrec.Add(events.PostgresQueries, 1)
 
 	startTime := time.Now()
-	res, err := i.client.AuthUser.Query().Where(authuser.UserID(userID)).Only(ctx)
+	res, err := i.client.AuthUser.Query().
+		Where(authuser.UserID(userID), authuser.HasUserWith(inOrg(ctx))).
+		Only(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
@@ -892,3 +1174,396 @@ func (i *IAM) queryUserCredentials(
 
 	return res, nil
 }
+
+// HasCredentials reports whether the user has login credentials.
+// Returns ErrUserNotFound if the user does not exist in the organization of ctx.
+func (i *IAM) HasCredentials(ctx context.Context, userID UUID) (bool, error) {
+	rec := event.Get(ctx).Sub("iam/has_credentials")
+
+	rec.Sub("params").Set("user_id", userID)
+
+	// Stage 1: Check if credentials exist
+	ctx = rec.Sub("query_credentials").Wrap(ctx)
+	exists, err := i.credentialsExist(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		rec.Set("success", true)
+		return true, nil
+	}
+
+	// Stage 2: Tell a user without credentials from a nonexistent one
+	ctx = rec.Sub("query_user").Wrap(ctx)
+	if err := i.userExists(ctx, userID); err != nil {
+		return false, err
+	}
+
+	rec.Set("success", true)
+	return false, nil
+}
+
+// credentialsExist checks if there are credentials for a user
+func (i *IAM) credentialsExist(ctx context.Context, userID UUID) (bool, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	statrec.Add(events.PostgresQueries, 1)
+
+	startTime := time.Now()
+	exists, err := i.client.AuthUser.Query().
+		Where(authuser.UserID(userID), authuser.HasUserWith(inOrg(ctx))).
+		Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+	if err != nil {
+		err := fmt.Errorf("couldn't check credentials existence: %w", err)
+		rec.Add(events.Error, err)
+		return false, err
+	}
+
+	rec.Set("exists", exists)
+	return exists, nil
+}
+
+// userExists checks if the user exists in the organization of ctx
+func (i *IAM) userExists(ctx context.Context, userID UUID) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	statrec.Add(events.PostgresQueries, 1)
+
+	startTime := time.Now()
+	exists, err := i.client.User.Query().Where(user.ID(userID), inOrg(ctx)).Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+	if err != nil {
+		err := fmt.Errorf("couldn't check user existence: %w", err)
+		rec.Add(events.Error, err)
+		return err
+	}
+
+	rec.Set("exists", exists)
+	if !exists {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// Account is the username of a user. It never holds the password.
+type Account struct {
+	UserID   UUID
+	Username string
+}
+
+// AccountFilter selects the users whose accounts are listed by AllCredentials.
+type AccountFilter struct {
+	// OrgID is the organization of the users, the nil UUID for the default one.
+	OrgID UUID
+	// DepartmentID, if not the nil UUID, only selects the users of the department.
+	DepartmentID UUID
+	// RoleID, if not zero, only selects the users with the role.
+	RoleID int32
+}
+
+// AllCredentials returns the accounts of the users matching the filter, ordered by username.
+// The passwords are not returned, and neither are the accounts of the archived users.
+func (i *IAM) AllCredentials(ctx context.Context, filter AccountFilter) ([]Account, error) {
+	rec := event.Get(ctx).Sub("iam/all_credentials")
+
+	rec.Sub("params").Set(
+		"org_id", filter.OrgID,
+		"department_id", filter.DepartmentID,
+		"role_id", filter.RoleID,
+	)
+
+	// Stage 1: Query credentials
+	ctx = rec.Sub("query_credentials").Wrap(ctx)
+	accounts, err := i.queryAccounts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", len(accounts),
+	)
+	return accounts, nil
+}
+
+// queryAccounts queries the usernames of the users matching the filter, except for the archived ones
+func (i *IAM) queryAccounts(ctx context.Context, filter AccountFilter) ([]Account, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	users := []predicate.User{user.OrgID(filter.OrgID), user.DeletedAtIsNil()}
+	if filter.DepartmentID != uuid.Nil {
+		users = append(users, user.DepartmentID(filter.DepartmentID))
+	}
+	if filter.RoleID != 0 {
+		users = append(users, user.RoleID(filter.RoleID))
+	}
+
+	statrec.Add(events.PostgresQueries, 1)
+
+	startTime := time.Now()
+	rows, err := i.client.AuthUser.Query().
+		Where(authuser.HasUserWith(users...)).
+		Order(ent.Asc(authuser.FieldUsername)).
+		Select(authuser.FieldUserID, authuser.FieldUsername).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+	if err != nil {
+		err := fmt.Errorf("couldn't query credentials: %w", err)
+		rec.Add(events.Error, err)
+		return nil, err
+	}
+
+	accounts := make([]Account, len(rows))
+	for idx, row := range rows {
+		accounts[idx] = Account{UserID: row.UserID, Username: row.Username}
+	}
+
+	rec.Set("count", len(accounts))
+	return accounts, nil
+}
+
+// ChangePassword replaces the password of a user after checking the old one.
+// Returns ErrInvalidCredentials if the old password is wrong, ErrCredentialsNotFound if the user
+// has no credentials, or ErrWeakPassword if the new password does not meet the password policy
+// or is shorter than MinPasswordLength.
+func (i *IAM) ChangePassword(ctx context.Context, userID UUID, oldPassword, newPassword string) error {
+	rec := event.Get(ctx).Sub("iam/change_password")
+	statrec := event.Root(ctx).Sub("stats")
+
+	rec.Sub("params").Set("user_id", userID)
+
+	// Stage 1: Validate the new password
+	ctx = rec.Sub("validate_password").Wrap(ctx)
+	policy := i.passwordPolicy
+	policy.MinLength = max(policy.MinLength, MinPasswordLength)
+	if err := validateNewPassword(ctx, policy, newPassword); err != nil {
+		return err
+	}
+
+	// Stages 2-3: Replace the password in a transaction, retried on serialization failures
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		return i.changePasswordTx(ctx, rec, statrec, userID, oldPassword, newPassword)
+	})
+	if err != nil {
+		return err
+	}
+
+	rec.Set("success", true)
+
+	return nil
+}
+
+// changePasswordTx checks the old password of the user and replaces it in a serializable transaction,
+// so that the password can't change between the check and the update.
+func (i *IAM) changePasswordTx(
+	ctx context.Context,
+	rec *event.Record,
+	statrec *event.Record,
+	userID UUID,
+	oldPassword string,
+	newPassword string,
+) error {
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+
+	tx, err := i.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		txrec.Add(events.Error, err)
+		return fmt.Errorf("couldn't start transaction: %w", err)
+	}
+
+	rollback := func(err error) error {
+		txrec.Set("rollback", true)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			txrec.Add(events.Error, err)
+			txrec.Set("rollback_failed", true)
+			return fmt.Errorf("%w: rollback failed: %w", err, rbErr)
+		}
+		return err
+	}
+
+	// Stage 2: Check the old password
+	ctx = rec.Sub("check_old_password").Wrap(ctx)
+	if err := i.checkPassword(ctx, tx, userID, oldPassword); err != nil {
+		return rollback(err)
+	}
+
+	// Stage 3: Update the password
+	ctx = rec.Sub("update_password").Wrap(ctx)
+	if err := i.updatePassword(ctx, tx, userID, newPassword); err != nil {
+		return rollback(err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return rollback(err)
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+
+	return nil
+}
+
+// ResetPassword replaces the password of a user without checking the old one, for admins to force a reset.
+// Returns ErrUserNotFound if the user does not exist, ErrCredentialsNotFound if the user has no credentials,
+// or ErrWeakPassword if the new password does not meet the password policy.
+func (i *IAM) ResetPassword(ctx context.Context, userID UUID, newPassword string) error {
+	rec := event.Get(ctx).Sub("iam/reset_password")
+	statrec := event.Root(ctx).Sub("stats")
+
+	rec.Sub("params").Set("user_id", userID)
+
+	// Stage 1: Validate the new password
+	ctx = rec.Sub("validate_password").Wrap(ctx)
+	if err := validateNewPassword(ctx, i.passwordPolicy, newPassword); err != nil {
+		return err
+	}
+
+	// Stages 2-3: Replace the password in a transaction, retried on serialization failures
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		return i.resetPasswordTx(ctx, rec, statrec, userID, newPassword)
+	})
+	if err != nil {
+		return err
+	}
+
+	rec.Set("success", true)
+
+	return nil
+}
+
+// resetPasswordTx replaces the password of the user in a serializable transaction.
+func (i *IAM) resetPasswordTx(
+	ctx context.Context,
+	rec *event.Record,
+	statrec *event.Record,
+	userID UUID,
+	password string,
+) error {
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+
+	tx, err := i.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		txrec.Add(events.Error, err)
+		return fmt.Errorf("couldn't start transaction: %w", err)
+	}
+
+	rollback := func(err error) error {
+		txrec.Set("rollback", true)
+		if rbErr := tx.Rollback(); rbErr != nil {
+			txrec.Add(events.Error, err)
+			txrec.Set("rollback_failed", true)
+			return fmt.Errorf("%w: rollback failed: %w", err, rbErr)
+		}
+		return err
+	}
+
+	// Stage 2: Check if user exists
+	ctx = rec.Sub("check_user_exists").Wrap(ctx)
+	if err := i.checkUserExists(ctx, tx, userID); err != nil {
+		return rollback(err)
+	}
+
+	// Stage 3: Update the password
+	ctx = rec.Sub("update_password").Wrap(ctx)
+	if err := i.updatePassword(ctx, tx, userID, password); err != nil {
+		return rollback(err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return rollback(err)
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+
+	return nil
+}
+
+// checkPassword checks that password is the current password of the user
+func (i *IAM) checkPassword(
+	ctx context.Context,
+	tx *ent.Tx,
+	userID UUID,
+	password string,
+) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	rec.Set("user_id", userID)
+
+	statrec.Add(events.PostgresQueries, 1)
+	authUser, err := tx.AuthUser.Query().
+		Where(authuser.UserID(userID)).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		rec.Set("found", false)
+		return ErrCredentialsNotFound
+	case err != nil:
+		err := fmt.Errorf("couldn't get credentials: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("found", false)
+		return err
+	}
+
+	rec.Set("found", true)
+
+	if subtle.ConstantTimeCompare([]byte(authUser.Password), []byte(password)) != 1 {
+		rec.Set("valid", false)
+		return ErrInvalidCredentials
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// updatePassword sets a new password for the user, returns ErrCredentialsNotFound if the user has no credentials
+func (i *IAM) updatePassword(
+	ctx context.Context,
+	tx *ent.Tx,
+	userID UUID,
+	password string,
+) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	rec.Set("user_id", userID)
+
+	statrec.Add(events.PostgresQueries, 1)
+	n, err := tx.AuthUser.Update().
+		Where(authuser.UserID(userID)).
+		SetPassword(password).
+		Save(ctx)
+	switch {
+	case err != nil:
+		err := fmt.Errorf("couldn't update password: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	case n == 0:
+		rec.Add(events.Error, ErrCredentialsNotFound)
+		rec.Set("success", false)
+		return ErrCredentialsNotFound
+	}
+
+	rec.Set("success", true)
+	return nil
+}
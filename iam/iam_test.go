@@ -6,14 +6,17 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/enttest"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/sesc"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
-func setupIAM(t *testing.T) *IAM {
+func setupIAM(t *testing.T, opts ...Option) *IAM {
 	t.Helper()
 	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
 	t.Cleanup(func() {
@@ -33,6 +36,7 @@ func setupIAM(t *testing.T) *IAM {
 			},
 		},
 		[]byte("testkey"),
+		opts...,
 	)
 }
 
@@ -154,6 +158,70 @@ func TestLogin(t *testing.T) {
 		})
 		require.ErrorIs(t, err, ErrUserNotFound)
 	})
+
+	t.Run("by_email", func(t *testing.T) {
+		ctx, iam, creds := setup(t)
+		require.NoError(t, iam.client.User.Update().SetEmail("login.test@sesc.ru").Exec(ctx))
+
+		byUsername, err := iam.Login(ctx, creds)
+		require.NoError(t, err)
+		byEmail, err := iam.Login(ctx, Credentials{
+			Username: " Login.Test@SESC.ru",
+			Password: creds.Password,
+		})
+		require.NoError(t, err)
+
+		usernameIdentity, err := iam.ImWatermelon(ctx, byUsername)
+		require.NoError(t, err)
+		emailIdentity, err := iam.ImWatermelon(ctx, byEmail)
+		require.NoError(t, err)
+		require.Equal(t, usernameIdentity.ID, emailIdentity.ID, "Both logins should be of the same account")
+
+		_, err = iam.Login(ctx, Credentials{
+			Username: "login.test@sesc.ru",
+			Password: "wrongpassword",
+		})
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("username_before_email", func(t *testing.T) {
+		ctx, iam, creds := setup(t)
+		require.NoError(t, iam.client.User.Update().SetEmail("shared@sesc.ru").Exec(ctx))
+
+		// The email of the first user is the username of another one.
+		otherID := createTestUser(ctx, t, iam.client)
+		_, err := iam.RegisterCredentials(ctx, otherID, Credentials{
+			Username: "shared@sesc.ru",
+			Password: creds.Password,
+		})
+		require.NoError(t, err)
+
+		token, err := iam.Login(ctx, Credentials{
+			Username: "shared@sesc.ru",
+			Password: creds.Password,
+		})
+		require.NoError(t, err)
+
+		identity, err := iam.ImWatermelon(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, otherID, identity.ID)
+	})
+
+	t.Run("archived_user", func(t *testing.T) {
+		ctx, iam, creds := setup(t)
+
+		token, err := iam.Login(ctx, creds)
+		require.NoError(t, err)
+
+		err = iam.client.User.Update().SetDeletedAt(time.Now()).Exec(ctx)
+		require.NoError(t, err)
+
+		_, err = iam.Login(ctx, creds)
+		require.ErrorIs(t, err, ErrUnauthorized)
+
+		_, err = iam.ImWatermelon(ctx, token)
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
 }
 
 func TestLoginAdmin(t *testing.T) {
@@ -182,6 +250,135 @@ func TestLoginAdmin(t *testing.T) {
 		_, err := iam.LoginAdmin(ctx, Credentials{"hell", "nah"})
 		require.ErrorIs(t, err, ErrUserNotFound)
 	})
+
+	t.Run("hashed password", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+
+		hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		adminID := uuid.Must(uuid.NewV7())
+		iam := New(nil, time.Hour, []AdminCredentials{
+			{
+				ID:           adminID,
+				PasswordHash: hash,
+				Credentials:  Credentials{Username: "root"},
+			},
+		}, []byte("testkey"))
+
+		token, err := iam.LoginAdmin(ctx, Credentials{"root", "s3cret"})
+		require.NoError(t, err)
+
+		identity, err := iam.ImWatermelon(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, adminID, identity.ID)
+
+		_, err = iam.LoginAdmin(ctx, Credentials{"root", "wrong"})
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		// The hash itself is not a valid password
+		_, err = iam.LoginAdmin(ctx, Credentials{"root", string(hash)})
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("hash takes precedence over plaintext password", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+
+		hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+		require.NoError(t, err)
+
+		iam := New(nil, time.Hour, []AdminCredentials{
+			{
+				ID:           uuid.Must(uuid.NewV7()),
+				PasswordHash: hash,
+				Credentials:  Credentials{Username: "root", Password: "plain"},
+			},
+		}, []byte("testkey"))
+
+		_, err = iam.LoginAdmin(ctx, Credentials{"root", "plain"})
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		_, err = iam.LoginAdmin(ctx, Credentials{"root", "s3cret"})
+		require.NoError(t, err)
+	})
+
+	t.Run("matching admin among many", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+
+		second := uuid.Must(uuid.NewV7())
+		iam := New(nil, time.Hour, []AdminCredentials{
+			{ID: uuid.Must(uuid.NewV7()), Credentials: Credentials{"first", "one"}},
+			{ID: second, Credentials: Credentials{"second", "two"}},
+			{ID: uuid.Must(uuid.NewV7()), Credentials: Credentials{"third", "three"}},
+		}, []byte("testkey"))
+
+		token, err := iam.LoginAdmin(ctx, Credentials{"second", "two"})
+		require.NoError(t, err)
+
+		identity, err := iam.ImWatermelon(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, second, identity.ID)
+
+		// The password of another admin doesn't match
+		_, err = iam.LoginAdmin(ctx, Credentials{"second", "one"})
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestTokenDuration(t *testing.T) {
+	// expiresIn returns how long the token is valid for.
+	expiresIn := func(t *testing.T, iam *IAM, token string) time.Duration {
+		t.Helper()
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+			return iam.jwtkey, nil
+		})
+		require.NoError(t, err)
+		exp, err := claims.GetExpirationTime()
+		require.NoError(t, err)
+		return time.Until(exp.Time)
+	}
+
+	setup := func(t *testing.T, opts ...Option) (ctx context.Context, iam *IAM, creds Credentials) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		iam = setupIAM(t, opts...)
+		userID := createTestUser(ctx, t, iam.client)
+		creds = Credentials{
+			Username: "durationtest",
+			Password: "password123",
+		}
+		_, err := iam.RegisterCredentials(ctx, userID, creds)
+		require.NoError(t, err)
+		return ctx, iam, creds
+	}
+
+	t.Run("per_role", func(t *testing.T) {
+		ctx, iam, creds := setup(t,
+			WithTokenDuration(RoleUser, 24*time.Hour),
+			WithTokenDuration(RoleAdmin, 15*time.Minute),
+		)
+
+		userToken, err := iam.Login(ctx, creds)
+		require.NoError(t, err)
+		require.InDelta(t, 24*time.Hour, expiresIn(t, iam, userToken), float64(time.Minute))
+
+		adminToken, err := iam.LoginAdmin(ctx, Credentials{"admin", "admin"})
+		require.NoError(t, err)
+		require.InDelta(t, 15*time.Minute, expiresIn(t, iam, adminToken), float64(time.Minute))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		ctx, iam, creds := setup(t, WithTokenDuration(RoleAdmin, 0))
+
+		userToken, err := iam.Login(ctx, creds)
+		require.NoError(t, err)
+		require.InDelta(t, time.Hour, expiresIn(t, iam, userToken), float64(time.Minute))
+
+		adminToken, err := iam.LoginAdmin(ctx, Credentials{"admin", "admin"})
+		require.NoError(t, err)
+		require.InDelta(t, time.Hour, expiresIn(t, iam, adminToken), float64(time.Minute))
+	})
 }
 
 func TestDropCredentials(t *testing.T) {
@@ -223,7 +420,25 @@ func TestDropCredentials(t *testing.T) {
 		userID := createTestUser(ctx, t, iam.client)
 
 		err := iam.DropCredentials(ctx, userID)
-		require.ErrorIs(t, err, ErrUserNotFound)
+		require.ErrorIs(t, err, ErrCredentialsNotFound)
+		require.NotErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("twice", func(t *testing.T) {
+		ctx, iam, userID := setup(t)
+
+		require.NoError(t, iam.DropCredentials(ctx, userID))
+		require.ErrorIs(t, iam.DropCredentials(ctx, userID), ErrCredentialsNotFound)
+	})
+
+	t.Run("another organization", func(t *testing.T) {
+		ctx, iam, userID := setup(t)
+
+		otherCtx := sesc.WithOrg(ctx, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, iam.DropCredentials(otherCtx, userID), ErrUserNotFound)
+
+		_, err := iam.Credentials(ctx, userID)
+		require.NoError(t, err, "The credentials are kept")
 	})
 }
 
@@ -251,6 +466,80 @@ func TestImWatermelon(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, userID, identity.ID)
 		require.Equal(t, RoleUser, identity.Role)
+		require.Equal(t, uuid.Nil, identity.OrgID, "Default organization")
+	})
+
+	t.Run("user_org", func(t *testing.T) {
+		ctx, iam, userID, _ := setup(t)
+		orgID := uuid.Must(uuid.NewV7())
+		require.NoError(t, iam.client.User.UpdateOneID(userID).SetOrgID(orgID).Exec(ctx))
+
+		token, err := iam.Login(ctx, Credentials{"watermelon", "password123"})
+		require.NoError(t, err)
+
+		identity, err := iam.ImWatermelon(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, orgID, identity.OrgID)
+	})
+
+	t.Run("admin_org", func(t *testing.T) {
+		ctx, iam, _, _ := setup(t)
+		iam.adminCredentials[0].OrgID = uuid.Must(uuid.NewV7())
+
+		token, err := iam.LoginAdmin(ctx, Credentials{"admin", "admin"})
+		require.NoError(t, err)
+
+		identity, err := iam.ImWatermelon(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, iam.adminCredentials[0].OrgID, identity.OrgID)
+	})
+
+	t.Run("token_without_org", func(t *testing.T) {
+		ctx, iam, _, userToken := setup(t)
+		userIdentity, err := iam.ImWatermelon(ctx, userToken)
+		require.NoError(t, err)
+
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"user_id": userIdentity.AuthID.String(),
+			"role":    string(RoleUser),
+			"iat":     time.Now().Unix(),
+			"exp":     time.Now().Add(time.Hour).Unix(),
+		}).SignedString(iam.jwtkey)
+		require.NoError(t, err)
+
+		identity, err := iam.ImWatermelon(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, uuid.Nil, identity.OrgID)
+	})
+
+	t.Run("invalid_org", func(t *testing.T) {
+		ctx, iam, _, userToken := setup(t)
+		userIdentity, err := iam.ImWatermelon(ctx, userToken)
+		require.NoError(t, err)
+
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"user_id": userIdentity.AuthID.String(),
+			"role":    string(RoleUser),
+			"org_id":  "not-a-uuid",
+			"iat":     time.Now().Unix(),
+			"exp":     time.Now().Add(time.Hour).Unix(),
+		}).SignedString(iam.jwtkey)
+		require.NoError(t, err)
+
+		_, err = iam.ImWatermelon(ctx, token)
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		ctx, iam, _, _ := setup(t)
+
+		token, err := iam.LoginAdmin(ctx, Credentials{"admin", "admin"})
+		require.NoError(t, err)
+
+		identity, err := iam.ImWatermelon(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, RoleAdmin, identity.Role)
+		require.Equal(t, iam.adminCredentials[0].ID, identity.ID)
 	})
 
 	t.Run("invalid_token", func(t *testing.T) {
@@ -258,6 +547,39 @@ func TestImWatermelon(t *testing.T) {
 
 		_, err := iam.ImWatermelon(ctx, "invalid-token")
 		require.ErrorIs(t, err, ErrInvalidToken)
+		require.ErrorIs(t, err, ErrInvalidTokenFormat)
+	})
+
+	t.Run("expired_token", func(t *testing.T) {
+		ctx, iam, _, _ := setup(t)
+
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"user_id": uuid.Must(uuid.NewV7()).String(),
+			"role":    string(RoleUser),
+			"iat":     time.Now().Add(-2 * time.Hour).Unix(),
+			"exp":     time.Now().Add(-time.Hour).Unix(),
+		}).SignedString(iam.jwtkey)
+		require.NoError(t, err)
+
+		_, err = iam.ImWatermelon(ctx, token)
+		require.ErrorIs(t, err, ErrTokenExpired)
+		require.NotErrorIs(t, err, ErrTokenSignature)
+	})
+
+	t.Run("wrong_signature", func(t *testing.T) {
+		ctx, iam, _, _ := setup(t)
+
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"user_id": uuid.Must(uuid.NewV7()).String(),
+			"role":    string(RoleUser),
+			"iat":     time.Now().Unix(),
+			"exp":     time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte("wrongkey"))
+		require.NoError(t, err)
+
+		_, err = iam.ImWatermelon(ctx, token)
+		require.ErrorIs(t, err, ErrTokenSignature)
+		require.NotErrorIs(t, err, ErrTokenExpired)
 	})
 }
 
@@ -301,4 +623,262 @@ func TestCredentials(t *testing.T) {
 		_, err := iam.Credentials(ctx, userID)
 		require.ErrorIs(t, err, ErrUserNotFound)
 	})
+
+	t.Run("another organization", func(t *testing.T) {
+		ctx, iam, userID, _ := setup(t)
+
+		_, err := iam.Credentials(sesc.WithOrg(ctx, uuid.Must(uuid.NewV7())), userID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestHasCredentials(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, iam *IAM, userID uuid.UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		iam = setupIAM(t)
+		userID = createTestUser(ctx, t, iam.client)
+		return ctx, iam, userID
+	}
+
+	t.Run("with_credentials", func(t *testing.T) {
+		ctx, iam, userID := setup(t)
+
+		_, err := iam.RegisterCredentials(ctx, userID, Credentials{
+			Username: "hascreds",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		has, err := iam.HasCredentials(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, has)
+	})
+
+	t.Run("no_credentials", func(t *testing.T) {
+		ctx, iam, userID := setup(t)
+
+		has, err := iam.HasCredentials(ctx, userID)
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("non_existent_user", func(t *testing.T) {
+		ctx, iam, _ := setup(t)
+
+		_, err := iam.HasCredentials(ctx, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("another organization", func(t *testing.T) {
+		ctx, iam, userID := setup(t)
+
+		_, err := iam.RegisterCredentials(ctx, userID, Credentials{
+			Username: "hascreds",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		_, err = iam.HasCredentials(sesc.WithOrg(ctx, uuid.Must(uuid.NewV7())), userID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, iam *IAM, userID uuid.UUID, originalCreds Credentials) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		iam = setupIAM(t)
+		userID = createTestUser(ctx, t, iam.client)
+		originalCreds = Credentials{
+			Username: "changepw",
+			Password: "password123",
+		}
+		_, err := iam.RegisterCredentials(ctx, userID, originalCreds)
+		require.NoError(t, err)
+		return ctx, iam, userID, originalCreds
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctx, iam, userID, originalCreds := setup(t)
+
+		err := iam.ChangePassword(ctx, userID, originalCreds.Password, "newpassword123")
+		require.NoError(t, err)
+
+		_, err = iam.Login(ctx, originalCreds)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		token, err := iam.Login(ctx, Credentials{Username: originalCreds.Username, Password: "newpassword123"})
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+	})
+
+	t.Run("wrong_old_password", func(t *testing.T) {
+		ctx, iam, userID, originalCreds := setup(t)
+
+		err := iam.ChangePassword(ctx, userID, "wrongpassword", "newpassword123")
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+
+		creds, err := iam.Credentials(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, originalCreds.Password, creds.Password)
+	})
+
+	t.Run("too_short", func(t *testing.T) {
+		ctx, iam, userID, originalCreds := setup(t)
+
+		err := iam.ChangePassword(ctx, userID, originalCreds.Password, "short")
+		require.ErrorIs(t, err, ErrWeakPassword)
+
+		err = iam.ChangePassword(ctx, userID, originalCreds.Password, "")
+		require.ErrorIs(t, err, ErrEmptyPassword)
+
+		creds, err := iam.Credentials(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, originalCreds.Password, creds.Password)
+	})
+
+	t.Run("no_credentials", func(t *testing.T) {
+		ctx, iam, _, _ := setup(t)
+
+		userID := createTestUser(ctx, t, iam.client)
+
+		err := iam.ChangePassword(ctx, userID, "password123", "newpassword123")
+		require.ErrorIs(t, err, ErrCredentialsNotFound)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, iam *IAM, userID uuid.UUID, originalCreds Credentials) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		iam = setupIAM(t, WithPasswordPolicy(PasswordPolicy{MinLength: 6}))
+		userID = createTestUser(ctx, t, iam.client)
+		originalCreds = Credentials{
+			Username: "resetpw",
+			Password: "password123",
+		}
+		_, err := iam.RegisterCredentials(ctx, userID, originalCreds)
+		require.NoError(t, err)
+		return ctx, iam, userID, originalCreds
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctx, iam, userID, originalCreds := setup(t)
+
+		err := iam.ResetPassword(ctx, userID, "newpassword123")
+		require.NoError(t, err)
+
+		_, err = iam.Login(ctx, originalCreds)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		token, err := iam.Login(ctx, Credentials{Username: originalCreds.Username, Password: "newpassword123"})
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+	})
+
+	t.Run("weak_password", func(t *testing.T) {
+		ctx, iam, userID, originalCreds := setup(t)
+
+		err := iam.ResetPassword(ctx, userID, "short")
+		require.ErrorIs(t, err, ErrWeakPassword)
+
+		creds, err := iam.Credentials(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, originalCreds.Password, creds.Password)
+	})
+
+	t.Run("non_existent_user", func(t *testing.T) {
+		ctx, iam, _, _ := setup(t)
+
+		err := iam.ResetPassword(ctx, uuid.Must(uuid.NewV7()), "newpassword123")
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("no_credentials", func(t *testing.T) {
+		ctx, iam, _, _ := setup(t)
+
+		userID := createTestUser(ctx, t, iam.client)
+
+		err := iam.ResetPassword(ctx, userID, "newpassword123")
+		require.ErrorIs(t, err, ErrCredentialsNotFound)
+	})
+}
+
+func TestAllCredentials(t *testing.T) {
+	ctx, _ := event.NewRecord(t.Context(), "test")
+	iam := setupIAM(t)
+
+	dept, err := iam.client.Department.Create().
+		SetID(uuid.Must(uuid.NewV7())).
+		SetName("Math").
+		SetNormalizedName("math").
+		SetDescription("").
+		Save(ctx)
+	require.NoError(t, err)
+
+	// register creates a user with the given role and department, and credentials for them if username is set.
+	register := func(t *testing.T, orgID uuid.UUID, roleID int32, deptID uuid.UUID, username string) uuid.UUID {
+		t.Helper()
+		create := iam.client.User.Create().
+			SetOrgID(orgID).
+			SetFirstName("Test").
+			SetLastName("User").
+			SetRoleID(roleID)
+		if deptID != uuid.Nil {
+			create = create.SetDepartmentID(deptID)
+		}
+		u, err := create.Save(ctx)
+		require.NoError(t, err)
+
+		if username != "" {
+			orgCtx := sesc.WithOrg(ctx, orgID)
+			_, err = iam.RegisterCredentials(orgCtx, u.ID, Credentials{Username: username, Password: "password123"})
+			require.NoError(t, err)
+		}
+		return u.ID
+	}
+
+	otherOrg := uuid.Must(uuid.NewV7())
+	bob := register(t, uuid.Nil, 1, dept.ID, "bob")
+	alice := register(t, uuid.Nil, 2, dept.ID, "alice")
+	carl := register(t, uuid.Nil, 1, uuid.Nil, "carl")
+	register(t, uuid.Nil, 1, uuid.Nil, "")
+	register(t, otherOrg, 1, uuid.Nil, "dave")
+
+	t.Run("all", func(t *testing.T) {
+		accounts, err := iam.AllCredentials(ctx, AccountFilter{})
+		require.NoError(t, err)
+		require.Equal(t, []Account{
+			{UserID: alice, Username: "alice"},
+			{UserID: bob, Username: "bob"},
+			{UserID: carl, Username: "carl"},
+		}, accounts)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		accounts, err := iam.AllCredentials(ctx, AccountFilter{DepartmentID: dept.ID})
+		require.NoError(t, err)
+		require.Equal(t, []Account{{UserID: alice, Username: "alice"}, {UserID: bob, Username: "bob"}}, accounts)
+
+		accounts, err = iam.AllCredentials(ctx, AccountFilter{DepartmentID: dept.ID, RoleID: 1})
+		require.NoError(t, err)
+		require.Equal(t, []Account{{UserID: bob, Username: "bob"}}, accounts)
+	})
+
+	t.Run("other org", func(t *testing.T) {
+		accounts, err := iam.AllCredentials(ctx, AccountFilter{OrgID: otherOrg})
+		require.NoError(t, err)
+		require.Len(t, accounts, 1)
+		require.Equal(t, "dave", accounts[0].Username)
+	})
+
+	t.Run("archived users", func(t *testing.T) {
+		erin := register(t, uuid.Nil, 1, dept.ID, "erin")
+		require.NoError(t, iam.client.User.UpdateOneID(erin).SetDeletedAt(time.Now()).Exec(ctx))
+
+		accounts, err := iam.AllCredentials(ctx, AccountFilter{DepartmentID: dept.ID})
+		require.NoError(t, err)
+		require.Equal(t, []Account{{UserID: alice, Username: "alice"}, {UserID: bob, Username: "bob"}}, accounts)
+	})
 }
@@ -0,0 +1,72 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+)
+
+var ErrWeakPassword = errors.New("password is too weak")
+
+// MinPasswordLength is the minimum length of a password set by ChangePassword,
+// whatever the configured policy is.
+const MinPasswordLength = 8
+
+// PasswordPolicy lists the requirements a new password must meet.
+// The zero value accepts any non-empty password.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireDigit  bool
+	RequireLetter bool
+}
+
+// Check returns an ErrWeakPassword describing the first requirement the password does not meet.
+func (p PasswordPolicy) Check(password string) error {
+	if n := len([]rune(password)); n < p.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters long", ErrWeakPassword, p.MinLength)
+	}
+
+	var hasDigit, hasLetter bool
+	for _, r := range password {
+		hasDigit = hasDigit || unicode.IsDigit(r)
+		hasLetter = hasLetter || unicode.IsLetter(r)
+	}
+
+	if p.RequireDigit && !hasDigit {
+		return fmt.Errorf("%w: must contain a digit", ErrWeakPassword)
+	}
+	if p.RequireLetter && !hasLetter {
+		return fmt.Errorf("%w: must contain a letter", ErrWeakPassword)
+	}
+
+	return nil
+}
+
+// WithPasswordPolicy makes IAM reject new passwords that do not meet the policy.
+func WithPasswordPolicy(p PasswordPolicy) Option {
+	return func(i *IAM) {
+		i.passwordPolicy = p
+	}
+}
+
+// validateNewPassword checks the new password against the password policy
+func validateNewPassword(ctx context.Context, policy PasswordPolicy, password string) error {
+	rec := event.Get(ctx)
+
+	if password == "" {
+		rec.Set("valid", false)
+		return ErrEmptyPassword
+	}
+
+	if err := policy.Check(password); err != nil {
+		rec.Set("valid", false)
+		rec.Set("reason", err.Error())
+		return err
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
@@ -0,0 +1,68 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:     8,
+		RequireDigit:  true,
+		RequireLetter: true,
+	}
+	require.NoError(t, policy.Check("пароль123"))
+
+	tests := []struct {
+		name     string
+		password string
+		details  string
+	}{
+		{"too short", "abc123", "at least 8 characters"},
+		{"no digit", "password", "a digit"},
+		{"no letter", "12345678", "a letter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.password)
+			require.ErrorIs(t, err, ErrWeakPassword)
+			require.ErrorContains(t, err, tt.details)
+		})
+	}
+
+	t.Run("zero value", func(t *testing.T) {
+		require.NoError(t, PasswordPolicy{}.Check("a"))
+	})
+
+	t.Run("length counts characters", func(t *testing.T) {
+		require.NoError(t, PasswordPolicy{MinLength: 4}.Check("пароль"))
+		require.ErrorIs(t, PasswordPolicy{MinLength: 7}.Check("пароль"), ErrWeakPassword)
+	})
+}
+
+func TestRegisterCredentialsPasswordPolicy(t *testing.T) {
+	ctx, _ := event.NewRecord(t.Context(), "test")
+	iam := setupIAM(t, WithPasswordPolicy(PasswordPolicy{
+		MinLength:    10,
+		RequireDigit: true,
+	}))
+	userID := createTestUser(ctx, t, iam.client)
+
+	_, err := iam.RegisterCredentials(ctx, userID, Credentials{Username: "weak", Password: "pass123"})
+	require.ErrorIs(t, err, ErrWeakPassword)
+
+	_, err = iam.RegisterCredentials(ctx, userID, Credentials{Username: "weak", Password: "passwordpassword"})
+	require.ErrorIs(t, err, ErrWeakPassword)
+
+	_, err = iam.RegisterCredentials(ctx, userID, Credentials{Username: "strong", Password: "password1234"})
+	require.NoError(t, err)
+
+	err = iam.ChangePassword(ctx, userID, "password1234", "password12")
+	require.NoError(t, err)
+
+	err = iam.ChangePassword(ctx, userID, "password12", "passwordpassword")
+	require.ErrorIs(t, err, ErrWeakPassword)
+}
@@ -0,0 +1,131 @@
+package iam
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// maxLoginThrottleEntries bounds the keys a loginThrottle tracks. Unknown usernames are keys too,
+// so without a bound logins with random usernames would grow it for the whole window.
+const maxLoginThrottleEntries = 10_000
+
+// loginThrottle counts failed logins per key and locks a key out
+// for a window after too many of them. IAM keys it by the account, see loginThrottleKey.
+//
+// At most maxEntries keys are tracked, the ones whose failures started first are forgotten to make room.
+type loginThrottle struct {
+	mu          sync.Mutex
+	maxAttempts int
+	window      time.Duration
+	maxEntries  int
+	users       map[string]*loginAttempts
+	// order lists the keys of users by their first failure, oldest first.
+	order     *list.List
+	lastPrune time.Time
+}
+
+type loginAttempts struct {
+	failures    int
+	firstFailed time.Time
+	lockedUntil time.Time
+	// elem is the element of the key in loginThrottle.order.
+	elem *list.Element
+}
+
+// WithLoginThrottle locks an account out for window after maxAttempts failed logins within window.
+// A non-positive maxAttempts disables the lockout.
+func WithLoginThrottle(maxAttempts int, window time.Duration) Option {
+	return func(i *IAM) {
+		if maxAttempts > 0 && window > 0 {
+			i.loginThrottle = newLoginThrottle(maxAttempts, window)
+		}
+	}
+}
+
+func newLoginThrottle(maxAttempts int, window time.Duration) *loginThrottle {
+	return &loginThrottle{
+		maxAttempts: maxAttempts,
+		window:      window,
+		maxEntries:  maxLoginThrottleEntries,
+		users:       make(map[string]*loginAttempts),
+		order:       list.New(),
+		lastPrune:   time.Now(),
+	}
+}
+
+// locked reports whether the username is locked out now.
+func (t *loginThrottle) locked(username string, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.Sub(t.lastPrune) >= t.window {
+		t.prune(now)
+	}
+
+	a, ok := t.users[username]
+	return ok && now.Before(a.lockedUntil)
+}
+
+// fail records a failed login, locking the username out after maxAttempts failures within the window.
+func (t *loginThrottle) fail(username string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	a, ok := t.users[username]
+	if !ok || t.expired(a, now) {
+		if ok {
+			t.remove(username, a)
+		}
+		if len(t.users) >= t.maxEntries {
+			t.prune(now)
+		}
+		for len(t.users) >= t.maxEntries {
+			oldest := t.order.Front().Value.(string)
+			t.remove(oldest, t.users[oldest])
+		}
+
+		a = &loginAttempts{firstFailed: now}
+		a.elem = t.order.PushBack(username)
+		t.users[username] = a
+	}
+
+	a.failures++
+	if a.failures >= t.maxAttempts {
+		a.lockedUntil = now.Add(t.window)
+	}
+}
+
+// reset forgets the failed logins of the username.
+func (t *loginThrottle) reset(username string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if a, ok := t.users[username]; ok {
+		t.remove(username, a)
+	}
+}
+
+// remove forgets the failed logins of the username.
+// Should be called with t.mu held.
+func (t *loginThrottle) remove(username string, a *loginAttempts) {
+	t.order.Remove(a.elem)
+	delete(t.users, username)
+}
+
+// expired reports whether the failures are outside the window and the lockout is over.
+// Should be called with t.mu held.
+func (t *loginThrottle) expired(a *loginAttempts, now time.Time) bool {
+	return now.Sub(a.firstFailed) >= t.window && !now.Before(a.lockedUntil)
+}
+
+// prune drops the expired entries.
+// Should be called with t.mu held.
+func (t *loginThrottle) prune(now time.Time) {
+	for username, a := range t.users {
+		if t.expired(a, now) {
+			t.remove(username, a)
+		}
+	}
+	t.lastPrune = now
+}
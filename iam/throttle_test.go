@@ -0,0 +1,167 @@
+package iam
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	const (
+		maxAttempts = 3
+		window      = time.Minute
+	)
+	start := time.Now()
+
+	t.Run("locks after max attempts", func(t *testing.T) {
+		throttle := newLoginThrottle(maxAttempts, window)
+
+		for i := range maxAttempts {
+			require.False(t, throttle.locked("user", start), "attempt %d", i)
+			throttle.fail("user", start)
+		}
+		require.True(t, throttle.locked("user", start))
+
+		// Other usernames are not affected
+		require.False(t, throttle.locked("other", start))
+	})
+
+	t.Run("unlocks after the window", func(t *testing.T) {
+		throttle := newLoginThrottle(maxAttempts, window)
+
+		for range maxAttempts {
+			throttle.fail("user", start)
+		}
+		require.True(t, throttle.locked("user", start.Add(window-time.Second)))
+		require.False(t, throttle.locked("user", start.Add(window)))
+
+		// The counter starts over after the lockout
+		throttle.fail("user", start.Add(window))
+		require.False(t, throttle.locked("user", start.Add(window)))
+	})
+
+	t.Run("failures outside the window are forgotten", func(t *testing.T) {
+		throttle := newLoginThrottle(maxAttempts, window)
+
+		for i := range maxAttempts - 1 {
+			throttle.fail("user", start.Add(time.Duration(i)*time.Second))
+		}
+		throttle.fail("user", start.Add(window))
+		require.False(t, throttle.locked("user", start.Add(window)))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		throttle := newLoginThrottle(maxAttempts, window)
+
+		for range maxAttempts - 1 {
+			throttle.fail("user", start)
+		}
+		throttle.reset("user")
+		throttle.fail("user", start)
+		require.False(t, throttle.locked("user", start))
+	})
+
+	t.Run("number of keys is bounded", func(t *testing.T) {
+		throttle := newLoginThrottle(maxAttempts, window)
+		throttle.maxEntries = 3
+
+		for range maxAttempts {
+			throttle.fail("user", start)
+		}
+		for i := range 2 {
+			throttle.fail(fmt.Sprintf("unknown%d", i), start.Add(time.Second))
+		}
+		require.True(t, throttle.locked("user", start.Add(time.Second)), "There is room for every key yet")
+
+		// Random usernames don't grow the throttle, the keys that failed first are forgotten
+		for i := 2; i < 100; i++ {
+			throttle.fail(fmt.Sprintf("unknown%d", i), start.Add(2*time.Second))
+		}
+		require.Len(t, throttle.users, 3)
+		require.Equal(t, 3, throttle.order.Len())
+		require.False(t, throttle.locked("user", start.Add(2*time.Second)))
+		require.Contains(t, throttle.users, "unknown99")
+		require.NotContains(t, throttle.users, "unknown0")
+	})
+
+	t.Run("concurrent failures", func(t *testing.T) {
+		throttle := newLoginThrottle(100, window)
+
+		var wg sync.WaitGroup
+		for range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				throttle.fail("user", start)
+			}()
+		}
+		wg.Wait()
+
+		require.True(t, throttle.locked("user", start))
+	})
+}
+
+func TestLoginLockout(t *testing.T) {
+	const maxAttempts = 3
+
+	ctx, _ := event.NewRecord(t.Context(), "test")
+	iam := setupIAM(t, WithLoginThrottle(maxAttempts, time.Hour))
+	userID := createTestUser(ctx, t, iam.client)
+
+	creds := Credentials{Username: "lockout", Password: "password123"}
+	_, err := iam.RegisterCredentials(ctx, userID, creds)
+	require.NoError(t, err)
+
+	wrong := Credentials{Username: creds.Username, Password: "wrong"}
+
+	// A successful login resets the counter
+	for range maxAttempts - 1 {
+		_, err := iam.Login(ctx, wrong)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	}
+	_, err = iam.Login(ctx, creds)
+	require.NoError(t, err)
+
+	for range maxAttempts {
+		_, err := iam.Login(ctx, wrong)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	}
+
+	// Locked out even with the correct password
+	_, err = iam.Login(ctx, creds)
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+}
+
+func TestLoginLockoutByEmail(t *testing.T) {
+	const maxAttempts = 5
+
+	ctx, _ := event.NewRecord(t.Context(), "test")
+	iam := setupIAM(t, WithLoginThrottle(maxAttempts, time.Hour))
+	userID := createTestUser(ctx, t, iam.client)
+	require.NoError(t, iam.client.User.UpdateOneID(userID).SetEmail("lockout@sesc.ru").Exec(ctx))
+
+	creds := Credentials{Username: "lockout", Password: "password123"}
+	_, err := iam.RegisterCredentials(ctx, userID, creds)
+	require.NoError(t, err)
+
+	// The failures with the username and any spelling of the email count towards the same account
+	for _, username := range []string{
+		"lockout@sesc.ru",
+		" Lockout@SESC.ru",
+		"LOCKOUT@sesc.ru ",
+		"lockout",
+		"LockOut@Sesc.Ru",
+	} {
+		_, err := iam.Login(ctx, Credentials{Username: username, Password: "wrong"})
+		require.ErrorIs(t, err, ErrUserNotFound)
+	}
+
+	_, err = iam.Login(ctx, creds)
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+	_, err = iam.Login(ctx, Credentials{Username: "lockout@sesc.ru", Password: creds.Password})
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+}
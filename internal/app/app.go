@@ -2,19 +2,27 @@ package app
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 
+	entsql "entgo.io/ent/dialect/sql"
 	"github.com/go-chi/chi/v5"
 	"github.com/kozlov-ma/sesc-backend/api"
+	"github.com/kozlov-ma/sesc-backend/audit"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
-	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/migrate"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/migrations"
 	"github.com/kozlov-ma/sesc-backend/iam"
 	"github.com/kozlov-ma/sesc-backend/internal/config"
+	"github.com/kozlov-ma/sesc-backend/internal/promsink"
+	"github.com/kozlov-ma/sesc-backend/internal/ringsink"
 	"github.com/kozlov-ma/sesc-backend/internal/slogsink"
 	"github.com/kozlov-ma/sesc-backend/sesc"
+	"github.com/kozlov-ma/sesc-backend/webhook"
 	// database driver
 	_ "github.com/lib/pq"
 	// database driver
@@ -28,6 +36,12 @@ type App struct {
 	API     *api.API
 	Log     *slog.Logger
 	Cleanup func()
+
+	// inflight tracks the events that are still being written by sink.
+	inflight *sync.WaitGroup
+	sink     *slogsink.SlogSink
+	// webhook delivers the user lifecycle events, nil if there is no webhook.
+	webhook *webhook.Sender
 }
 
 // DBOptions contains options for database initialization
@@ -38,6 +52,70 @@ type DBOptions struct {
 	Client *ent.Client
 }
 
+// dbPingTimeout bounds the connectivity check at startup, so that an unreachable database fails it quickly.
+const dbPingTimeout = 5 * time.Second
+
+// openDatabase connects to the database, configures the connection pool and checks that the database is reachable.
+func openDatabase(ctx context.Context, cfg config.DatabaseConfig) (*ent.Client, error) {
+	dbType := string(cfg.Type)
+	if dbType == "" {
+		dbType = string(config.DatabaseTypePostgres)
+	}
+
+	db, err := sql.Open(dbType, cfg.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	configurePool(db, cfg)
+
+	pingCtx, cancel := context.WithTimeout(ctx, dbPingTimeout)
+	defer cancel()
+	if err := db.PingContext(pingCtx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("database is unreachable: %w", err)
+	}
+
+	return ent.NewClient(ent.Driver(entsql.OpenDB(dbType, db))), nil
+}
+
+// configurePool applies the connection pool settings, leaving the zero ones at the database/sql defaults.
+func configurePool(db *sql.DB, cfg config.DatabaseConfig) {
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+	if cfg.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	}
+}
+
+// backfillDepartmentNames sets the normalized names of the departments created before they were stored.
+// A department whose name collides with another one ignoring case keeps no normalized name,
+// and is logged to be renamed by hand.
+func backfillDepartmentNames(ctx context.Context, client *ent.Client, log *slog.Logger) error {
+	depts, err := client.Department.Query().Where(department.NormalizedNameIsNil()).All(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't query departments: %w", err)
+	}
+
+	for _, d := range depts {
+		err := client.Department.UpdateOne(d).SetNormalizedName(sesc.NormalizeDepartmentName(d.Name)).Exec(ctx)
+		switch {
+		case ent.IsConstraintError(err):
+			log.WarnContext(ctx, "department name collides with another one ignoring case, rename it",
+				"id", d.ID,
+				"name", d.Name,
+			)
+		case err != nil:
+			return fmt.Errorf("couldn't update department %s: %w", d.ID, err)
+		}
+	}
+	return nil
+}
+
 // New creates a new application instance from the given config
 func New(ctx context.Context, cfg *config.Config, log *slog.Logger) (*App, error) {
 	return NewWithDBOptions(ctx, cfg, log, DBOptions{})
@@ -45,6 +123,10 @@ func New(ctx context.Context, cfg *config.Config, log *slog.Logger) (*App, error
 
 // NewWithDBOptions creates a new application instance with custom database options
 func NewWithDBOptions(ctx context.Context, cfg *config.Config, log *slog.Logger, dbOpts DBOptions) (*App, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	var client *ent.Client
 	var err error
 	var cleanup func()
@@ -56,12 +138,7 @@ func NewWithDBOptions(ctx context.Context, cfg *config.Config, log *slog.Logger,
 			// Don't close the client if it was provided externally
 		}
 	} else {
-		dbType := string(cfg.Database.Type)
-		if dbType == "" {
-			dbType = string(config.DatabaseTypePostgres)
-		}
-
-		client, err = ent.Open(dbType, cfg.Database.Address)
+		client, err = openDatabase(ctx, cfg.Database)
 		if err != nil {
 			return nil, fmt.Errorf("failed to set up database: %w", err)
 		}
@@ -76,10 +153,14 @@ func NewWithDBOptions(ctx context.Context, cfg *config.Config, log *slog.Logger,
 
 	// Run migrations if not skipped
 	if !dbOpts.SkipMigrations {
-		if err := client.Schema.Create(ctx, migrate.WithDropIndex(true), migrate.WithDropColumn(true)); err != nil {
+		if err := migrations.New(client).Apply(ctx); err != nil {
 			cleanup()
 			return nil, fmt.Errorf("couldn't apply migrations: %w", err)
 		}
+		if err := backfillDepartmentNames(ctx, client, log); err != nil {
+			cleanup()
+			return nil, fmt.Errorf("couldn't backfill department names: %w", err)
+		}
 	}
 
 	adminCredentials, err := cfg.ToIAMAdminCredentials()
@@ -88,12 +169,66 @@ func NewWithDBOptions(ctx context.Context, cfg *config.Config, log *slog.Logger,
 		return nil, fmt.Errorf("failed to convert admin credentials: %w", err)
 	}
 
-	iamService := iam.New(client, 7*24*time.Hour, adminCredentials, []byte(cfg.JWTSecret))
-	sescService := sesc.New(client)
-	apiService := api.New(sescService, iamService, slogsink.New(log))
+	iamService := iam.New(
+		client,
+		config.DefaultTokenDuration,
+		adminCredentials,
+		[]byte(cfg.JWTSecret),
+		iam.WithTokenDuration(iam.RoleUser, cfg.TokenDuration.User),
+		iam.WithTokenDuration(iam.RoleAdmin, cfg.TokenDuration.Admin),
+		iam.WithPasswordPolicy(cfg.ToIAMPasswordPolicy()),
+		iam.WithLoginThrottle(cfg.LoginThrottle.MaxAttempts, cfg.LoginThrottle.Window),
+	)
+	readIsolation, err := cfg.Database.ReadIsolationLevel()
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("invalid read isolation: %w", err)
+	}
+	sescService := sesc.New(client, sesc.WithReadIsolation(readIsolation))
+	metrics := promsink.New()
+	inflight := &sync.WaitGroup{}
+	sink := slogsink.NewAsync(log, inflight)
+	sinkMiddlewares := []slogsink.EventMiddleware{metrics}
+	apiOptions := []api.Option{
+		api.WithRateLimit(cfg.HTTP.RateLimitPerMinute),
+		api.WithRequestTimeout(cfg.HTTP.RequestTimeout),
+		api.WithMaxBodyBytes(cfg.HTTP.MaxBodyBytes),
+		api.WithCORS(api.CORSOptions{
+			AllowedOrigins: cfg.HTTP.CORS.AllowedOrigins,
+			AllowedMethods: cfg.HTTP.CORS.AllowedMethods,
+			AllowedHeaders: cfg.HTTP.CORS.AllowedHeaders,
+		}),
+		api.WithPictureHosts(cfg.HTTP.PictureHosts),
+		api.WithReadOnly(cfg.HTTP.ReadOnly),
+		api.WithAudit(audit.New(client)),
+		api.WithMigrations(migrations.New(client)),
+	}
+	if cfg.Log.LastEvents > 0 {
+		lastEvents := ringsink.New(cfg.Log.LastEvents)
+		sinkMiddlewares = append(sinkMiddlewares, lastEvents)
+		apiOptions = append(apiOptions, api.WithEventLog(lastEvents))
+	}
+	eventSink := slogsink.NewSampling(sink, cfg.Log.SampleRate, sinkMiddlewares...)
+	var webhookSender *webhook.Sender
+	if cfg.Webhook.URL != "" {
+		webhookSender = webhook.New(
+			cfg.Webhook.URL,
+			[]byte(cfg.Webhook.Secret),
+			eventSink,
+			webhook.WithAttempts(cfg.Webhook.Attempts),
+		)
+		apiOptions = append(apiOptions, api.WithWebhook(webhookSender))
+	}
+	apiService := api.New(
+		sescService,
+		iamService,
+		eventSink,
+		apiOptions...,
+	)
 
 	router := chi.NewRouter()
 	apiService.RegisterRoutes(router)
+	router.Handle("/metrics", metrics.Handler())
 
 	server := &http.Server{
 		Addr:              cfg.HTTP.ServerAddress,
@@ -110,31 +245,86 @@ func NewWithDBOptions(ctx context.Context, cfg *config.Config, log *slog.Logger,
 		API:     apiService,
 		Log:     log,
 		Cleanup: cleanup,
+
+		inflight: inflight,
+		sink:     sink,
+		webhook:  webhookSender,
 	}, nil
 }
 
 const shutdownTimeout = 15 * time.Second
 
-// Start starts the HTTP server
+// Start starts the HTTP server.
+// When ctx is done, it shuts the server down and waits for the in-flight requests and their events.
 func (a *App) Start(ctx context.Context) error {
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
+
 		<-ctx.Done()
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 		defer cancel()
 		if err := a.Server.Shutdown(shutdownCtx); err != nil {
 			a.Log.Error("couldn't shut down server", "error", err)
 		}
+		if err := a.closeWebhook(shutdownCtx); err != nil {
+			a.Log.Error("couldn't deliver webhooks", "error", err)
+		}
+		if err := a.drainEvents(shutdownCtx); err != nil {
+			a.Log.Error("couldn't drain events", "error", err)
+		}
 	}()
 
 	a.Log.InfoContext(ctx, "starting server", "address", a.Server.Addr)
 	if err := a.Server.ListenAndServe(); err != http.ErrServerClosed {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
+
+	<-shutdownDone
 	return nil
 }
 
+// closeWebhook waits for the queued webhook events to be delivered, until ctx is done.
+func (a *App) closeWebhook(ctx context.Context) error {
+	if a.webhook == nil {
+		return nil
+	}
+	return a.webhook.Close(ctx)
+}
+
+// drainEvents waits for the events that are still being written, until ctx is done.
+// Events processed after that are written synchronously.
+func (a *App) drainEvents(ctx context.Context) error {
+	if a.sink == nil {
+		return nil
+	}
+	a.sink.Close()
+
+	done := make(chan struct{})
+	go func() {
+		a.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("events are still being written: %w", ctx.Err())
+	}
+}
+
 // Close cleans up resources used by the app
 func (a *App) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := a.closeWebhook(ctx); err != nil {
+		a.Log.Error("couldn't deliver webhooks", "error", err)
+	}
+	if err := a.drainEvents(ctx); err != nil {
+		a.Log.Error("couldn't drain events", "error", err)
+	}
+
 	if a.Cleanup != nil {
 		a.Cleanup()
 	}
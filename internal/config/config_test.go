@@ -0,0 +1,115 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestToIAMAdminCredentials(t *testing.T) {
+	const id = "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd"
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	t.Run("password hash", func(t *testing.T) {
+		cfg := Config{AdminCredentials: []AdminCredentialConfig{
+			{ID: id, Username: "admin", PasswordHash: string(hash)},
+		}}
+
+		creds, err := cfg.ToIAMAdminCredentials()
+		require.NoError(t, err)
+		require.Len(t, creds, 1)
+		require.Equal(t, hash, creds[0].PasswordHash)
+	})
+
+	t.Run("invalid password hash", func(t *testing.T) {
+		cfg := Config{AdminCredentials: []AdminCredentialConfig{
+			{ID: id, Username: "admin", PasswordHash: "s3cret"},
+		}}
+
+		_, err := cfg.ToIAMAdminCredentials()
+		require.ErrorContains(t, err, "invalid bcrypt password hash")
+	})
+
+	t.Run("plaintext password", func(t *testing.T) {
+		cfg := Config{AdminCredentials: []AdminCredentialConfig{
+			{ID: id, Username: "admin", Password: "admin"},
+		}}
+
+		_, err := cfg.ToIAMAdminCredentials()
+		require.ErrorContains(t, err, "plaintext password")
+
+		cfg.AllowPlaintextAdminPasswords = true
+		creds, err := cfg.ToIAMAdminCredentials()
+		require.NoError(t, err)
+		require.Equal(t, "admin", creds[0].Password)
+		require.Empty(t, creds[0].PasswordHash)
+	})
+
+	t.Run("no password", func(t *testing.T) {
+		cfg := Config{
+			AdminCredentials:             []AdminCredentialConfig{{ID: id, Username: "admin"}},
+			AllowPlaintextAdminPasswords: true,
+		}
+
+		_, err := cfg.ToIAMAdminCredentials()
+		require.ErrorContains(t, err, "no password")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() Config {
+		return Config{
+			JWTSecret: strings.Repeat("s", MinJWTSecretLength),
+			AdminCredentials: []AdminCredentialConfig{
+				{ID: "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd", Username: "admin", Password: "admin"},
+			},
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		cfg := valid()
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("empty secret", func(t *testing.T) {
+		cfg := valid()
+		cfg.JWTSecret = ""
+		require.ErrorContains(t, cfg.Validate(), "jwt_secret must be at least 32 bytes long, got 0")
+	})
+
+	t.Run("short secret", func(t *testing.T) {
+		cfg := valid()
+		cfg.JWTSecret = strings.Repeat("s", MinJWTSecretLength-1)
+		require.ErrorContains(t, cfg.Validate(), "jwt_secret must be at least 32 bytes long")
+	})
+
+	t.Run("no admins", func(t *testing.T) {
+		cfg := valid()
+		cfg.AdminCredentials = nil
+		require.ErrorContains(t, cfg.Validate(), "no admin_credentials")
+
+		cfg.DisableAdminLogin = true
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("admins with the admin login disabled", func(t *testing.T) {
+		cfg := valid()
+		cfg.DisableAdminLogin = true
+		require.ErrorContains(t, cfg.Validate(), "disable_admin_login is on")
+	})
+
+	t.Run("read isolation", func(t *testing.T) {
+		cfg := valid()
+		for _, level := range []string{"", "read_committed", "repeatable_read", "serializable"} {
+			cfg.Database.ReadIsolation = level
+			require.NoError(t, cfg.Validate(), level)
+		}
+
+		cfg.Database.ReadIsolation = "read_uncommitted"
+		require.ErrorContains(t, cfg.Validate(), `database.read_isolation must be read_committed, repeatable_read or serializable, got "read_uncommitted"`)
+	})
+}
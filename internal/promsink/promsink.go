@@ -0,0 +1,82 @@
+// Package promsink exports the stats collected in event records as Prometheus metrics.
+package promsink
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "sesc"
+
+// unknownRoute is used as the route label for requests that did not match any route.
+const unknownRoute = "unknown"
+
+// PromSink converts http_request events into Prometheus metrics.
+//
+// It is meant to be used as a slogsink.EventMiddleware, so that it sees the event
+// before the record is finished.
+type PromSink struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	postgresQueries *prometheus.CounterVec
+	postgresTime    *prometheus.HistogramVec
+}
+
+func New() *PromSink {
+	s := &PromSink{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of processed HTTP requests.",
+		}, []string{"method", "route", "code"}),
+		postgresQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "postgres_queries_total",
+			Help:      "Number of database queries made while processing HTTP requests.",
+		}, []string{"method", "route"}),
+		postgresTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "postgres_time_seconds",
+			Help:      "Time spent in the database per HTTP request.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+	}
+
+	s.registry.MustRegister(s.requests, s.postgresQueries, s.postgresTime)
+
+	return s
+}
+
+// Handler serves the collected metrics.
+func (s *PromSink) Handler() http.Handler {
+	return promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{})
+}
+
+func (s *PromSink) ProcessEvent(rec *event.Record) {
+	if rec.EventName() != "http_request" {
+		return
+	}
+
+	method, _ := rec.Value("http.request.method").(string)
+	route, _ := rec.Value("http.request.route").(string)
+	if route == "" {
+		route = unknownRoute
+	}
+	code, _ := rec.Value("http.response.code").(int)
+
+	s.requests.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+
+	queries, _ := rec.Value("stats." + events.PostgresQueries).(int)
+	s.postgresQueries.WithLabelValues(method, route).Add(float64(queries))
+
+	pgTime, _ := rec.Value("stats." + events.PostgresTime).(time.Duration)
+	s.postgresTime.WithLabelValues(method, route).Observe(pgTime.Seconds())
+}
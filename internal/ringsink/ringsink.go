@@ -0,0 +1,59 @@
+// Package ringsink keeps the last event records in memory, for live debugging without a log backend.
+package ringsink
+
+import (
+	"sync"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+)
+
+// RingSink keeps copies of the last root records it has seen.
+//
+// It is meant to be used as a slogsink.EventMiddleware, so that it sees the event
+// before the record is finished.
+type RingSink struct {
+	mu      sync.Mutex
+	records []*event.Record
+	// next is the index in records to put the next record at.
+	next int
+	full bool
+}
+
+// New creates a RingSink keeping the last size records. Size must be positive.
+func New(size int) *RingSink {
+	if size <= 0 {
+		panic("ringsink: size must be positive")
+	}
+	return &RingSink{records: make([]*event.Record, size)}
+}
+
+func (s *RingSink) ProcessEvent(rec *event.Record) {
+	// The record is finished after the sinks are done with it, so a copy is kept.
+	rec = rec.Clone()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[s.next] = rec
+	s.next = (s.next + 1) % len(s.records)
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+// LastEvents returns the kept records, newest first.
+func (s *RingSink) LastEvents() []*event.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := s.next
+	if s.full {
+		n = len(s.records)
+	}
+
+	last := make([]*event.Record, 0, n)
+	for i := range n {
+		last = append(last, s.records[(s.next-1-i+len(s.records))%len(s.records)])
+	}
+	return last
+}
@@ -0,0 +1,49 @@
+package ringsink_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/internal/ringsink"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingSink(t *testing.T) {
+	process := func(t *testing.T, s *ringsink.RingSink, n int) {
+		t.Helper()
+		for i := range n {
+			_, rec := event.NewRecord(t.Context(), fmt.Sprintf("event%d", i))
+			rec.Sub("http").Set("i", i)
+			s.ProcessEvent(rec)
+			rec.Finish()
+		}
+	}
+
+	names := func(recs []*event.Record) []string {
+		names := make([]string, len(recs))
+		for i, rec := range recs {
+			names[i] = rec.EventName()
+		}
+		return names
+	}
+
+	t.Run("not full", func(t *testing.T) {
+		s := ringsink.New(5)
+		require.Empty(t, s.LastEvents())
+
+		process(t, s, 3)
+		require.Equal(t, []string{"event2", "event1", "event0"}, names(s.LastEvents()))
+	})
+
+	t.Run("keeps the last records", func(t *testing.T) {
+		s := ringsink.New(3)
+		process(t, s, 7)
+
+		last := s.LastEvents()
+		require.Equal(t, []string{"event6", "event5", "event4"}, names(last))
+
+		// The records are kept after the originals are finished.
+		require.Equal(t, 6, last[0].Value("http.i"))
+	})
+}
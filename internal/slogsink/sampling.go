@@ -0,0 +1,55 @@
+package slogsink
+
+import (
+	"sync/atomic"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// EventSink processes finished event records.
+type EventSink interface {
+	ProcessEvent(rec *event.Record)
+}
+
+// SamplingSink passes 1 in rate successful events to the underlying sink.
+// Failed events, i.e. the ones with an error, a panic or an error response, are always passed.
+type SamplingSink struct {
+	sink        EventSink
+	rate        uint64
+	middlewares []EventMiddleware
+
+	seen atomic.Uint64
+}
+
+// NewSampling creates a SamplingSink delegating to sink.
+// A rate of 1 or less passes every event.
+//
+// Middlewares see every event before it is sampled, so that e.g. metrics stay exact.
+func NewSampling(sink EventSink, rate int, middlewares ...EventMiddleware) *SamplingSink {
+	return &SamplingSink{
+		sink:        sink,
+		rate:        uint64(max(rate, 1)),
+		middlewares: middlewares,
+	}
+}
+
+func (s *SamplingSink) ProcessEvent(rec *event.Record) {
+	for _, mw := range s.middlewares {
+		mw.ProcessEvent(rec)
+	}
+
+	if failed(rec) || s.seen.Add(1)%s.rate == 0 {
+		s.sink.ProcessEvent(rec)
+		return
+	}
+
+	rec.Finish()
+}
+
+// failed reports whether the root record contains an error.
+func failed(rec *event.Record) bool {
+	return rec.Value(events.Error) != nil ||
+		rec.Value("panic") != nil ||
+		rec.Value("http.error_response") != nil
+}
@@ -0,0 +1,71 @@
+package slogsink
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSink counts the events it receives.
+type countingSink struct {
+	count int
+}
+
+func (s *countingSink) ProcessEvent(*event.Record) {
+	s.count++
+}
+
+func TestSamplingSink(t *testing.T) {
+	newEvent := func() *event.Record {
+		_, rec := event.NewRecord(context.Background(), "test")
+		return rec
+	}
+
+	t.Run("successful events are sampled", func(t *testing.T) {
+		inner := &countingSink{}
+		metrics := &countingSink{}
+		sink := NewSampling(inner, 10, metrics)
+
+		for range 100 {
+			sink.ProcessEvent(newEvent())
+		}
+
+		require.Equal(t, 10, inner.count)
+		require.Equal(t, 100, metrics.count, "Middlewares should see every event")
+	})
+
+	t.Run("failed events always pass", func(t *testing.T) {
+		inner := &countingSink{}
+		sink := NewSampling(inner, 10)
+
+		for i := range 100 {
+			rec := newEvent()
+			switch i % 3 {
+			case 0:
+				rec.Add(events.Error, errors.New("boom"))
+			case 1:
+				rec.Set("panic", "boom")
+			default:
+				rec.Sub("http").Set("error_response", "boom")
+			}
+			sink.ProcessEvent(rec)
+		}
+
+		require.Equal(t, 100, inner.count)
+	})
+
+	t.Run("no sampling", func(t *testing.T) {
+		inner := &countingSink{}
+		sink := NewSampling(inner, 0)
+
+		for range 100 {
+			sink.ProcessEvent(newEvent())
+		}
+
+		require.Equal(t, 100, inner.count)
+	})
+}
@@ -3,6 +3,7 @@ package slogsink
 import (
 	"context"
 	"log/slog"
+	"sync"
 
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
@@ -11,6 +12,12 @@ import (
 type SlogSink struct {
 	log         *slog.Logger
 	middlewares []EventMiddleware
+
+	// inflight tracks the events being written in background. It is nil for a synchronous sink.
+	inflight *sync.WaitGroup
+
+	mu      sync.RWMutex
+	closing bool
 }
 
 type EventMiddleware interface {
@@ -24,11 +31,59 @@ func New(log *slog.Logger, middlewares ...EventMiddleware) *SlogSink {
 	}
 }
 
+// NewAsync creates a SlogSink that writes events in background goroutines tracked by inflight,
+// so that the caller doesn't wait for the log output.
+//
+// Middlewares are still run synchronously. Once the sink is closed, events are written synchronously too.
+func NewAsync(log *slog.Logger, inflight *sync.WaitGroup, middlewares ...EventMiddleware) *SlogSink {
+	return &SlogSink{
+		log:         log,
+		middlewares: middlewares,
+		inflight:    inflight,
+	}
+}
+
 func (s *SlogSink) ProcessEvent(rec *event.Record) {
 	for _, mw := range s.middlewares {
 		mw.ProcessEvent(rec)
 	}
 
+	if s.inflight == nil {
+		s.write(rec)
+		return
+	}
+
+	s.mu.RLock()
+	if s.closing {
+		s.mu.RUnlock()
+		// Late events are flushed right away rather than dropped.
+		s.write(rec)
+		return
+	}
+	s.inflight.Add(1)
+	s.mu.RUnlock()
+
+	// The caller may still change the record, and its maps are reused once it is finished,
+	// so the background write gets a copy.
+	clone := rec.Clone()
+	rec.Finish()
+
+	go func() {
+		defer s.inflight.Done()
+		s.write(clone)
+	}()
+}
+
+// Close stops writing events in background. After Close returns, no new background writes are started,
+// so the caller may wait for the in-flight ones.
+func (s *SlogSink) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closing = true
+}
+
+func (s *SlogSink) write(rec *event.Record) {
 	level := slog.LevelInfo
 	if e := rec.Value(events.Error); e != nil {
 		level = slog.LevelError
@@ -0,0 +1,69 @@
+package slogsink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the concurrent writes of the background events.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAsyncSink(t *testing.T) {
+	var out lockedBuffer
+	var inflight sync.WaitGroup
+	sink := NewAsync(slog.New(slog.NewJSONHandler(&out, nil)), &inflight)
+
+	for i := range 20 {
+		_, rec := event.NewRecord(context.Background(), "test")
+		rec.Sub("http").Set("i", i)
+		sink.ProcessEvent(rec)
+
+		// New records reuse the maps of the finished ones while the events are being written.
+		_, other := event.NewRecord(context.Background(), "other")
+		other.Sub("http").Set("i", -1)
+	}
+
+	sink.Close()
+	inflight.Wait()
+
+	for i := range 20 {
+		require.Contains(t, out.String(), fmt.Sprintf(`"http":{"i":%d}`, i))
+	}
+	require.NotContains(t, out.String(), `"i":-1`)
+}
+
+func TestAsyncSinkClosed(t *testing.T) {
+	var out lockedBuffer
+	var inflight sync.WaitGroup
+	sink := NewAsync(slog.New(slog.NewJSONHandler(&out, nil)), &inflight)
+	sink.Close()
+
+	_, rec := event.NewRecord(context.Background(), "test")
+	rec.Sub("http").Set("path", "/late")
+	sink.ProcessEvent(rec)
+
+	// A late event is written before ProcessEvent returns, without waiting for inflight.
+	require.Contains(t, out.String(), `"http":{"path":"/late"}`)
+}
@@ -10,6 +10,13 @@ import (
 	"github.com/kozlov-ma/sesc-backend/internal/config"
 )
 
+// OtherOrgAdmin is the username of a test admin of the organization OtherOrgID, with the password "admin".
+// The other admin, "admin", manages the default organization.
+const (
+	OtherOrgAdmin = "other-admin"
+	OtherOrgID    = "7d1c6a52-93e4-4f0b-b2a8-5e6f7a8b9c0d"
+)
+
 func CreateTestConfig() *config.Config {
 	return &config.Config{
 		Database: config.DatabaseConfig{
@@ -22,14 +29,21 @@ func CreateTestConfig() *config.Config {
 			ReadTimeout:       1 * time.Second,
 			WriteTimeout:      1 * time.Second,
 		},
-		JWTSecret: "test_secret",
+		JWTSecret: "test_secret_at_least_32_bytes_long",
 		AdminCredentials: []config.AdminCredentialConfig{
 			{
 				ID:       "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd",
 				Username: "admin",
 				Password: "admin",
 			},
+			{
+				ID:       "0b5f3a8e-2c4d-4e6f-8a9b-1c2d3e4f5a6b",
+				Username: OtherOrgAdmin,
+				Password: "admin",
+				OrgID:    OtherOrgID,
+			},
 		},
+		AllowPlaintextAdminPasswords: true,
 	}
 }
 
@@ -1,13 +1,18 @@
 package event
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
-	"reflect"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unicode"
 	"unique"
 
@@ -22,10 +27,88 @@ type Record struct {
 	// Child records have eventName = "".
 	eventName ustring
 
+	// budget is shared by all the records of a tree created with NewRecord. Nil means no limits.
+	budget *budget
+	// depth is the depth of the record in its tree, the root having depth 0.
+	depth int
+	// discard is set for the records past the depth limit, that drop all the values.
+	discard bool
+
 	mu     sync.Mutex
 	values map[ustring]any
 }
 
+// Limits caps the size of a record tree, so that a runaway caller cannot exhaust the memory.
+// When a limit is exceeded, the record gets a "$truncated": true marker, which is not counted as a key,
+// and further additions are dropped.
+type Limits struct {
+	// MaxDepth is the maximum depth of the sub-records, the root record having depth 0. Zero means no limit.
+	MaxDepth int
+	// MaxKeys is the maximum total number of keys in the record and all of its sub-records. Zero means no limit.
+	MaxKeys int
+}
+
+// DefaultLimits are the limits of the records created by NewRecord, unless WithLimits is used.
+var DefaultLimits = Limits{
+	MaxDepth: 32,
+	MaxKeys:  4096,
+}
+
+// Option configures a record created by NewRecord.
+type Option func(*Record)
+
+// WithLimits sets the limits of the record tree.
+func WithLimits(limits Limits) Option {
+	return func(r *Record) {
+		r.budget = &budget{limits: limits}
+	}
+}
+
+// truncatedKey marks a record some additions to which were dropped because of the Limits.
+const truncatedKey = "$truncated"
+
+type budget struct {
+	limits Limits
+	keys   atomic.Int64
+}
+
+// reserveKey reserves a place for a new key in r. If there is no place left, r is marked as truncated.
+// The caller must hold r.mu.
+func (r *Record) reserveKey() bool {
+	if r.budget == nil || r.budget.limits.MaxKeys <= 0 {
+		return true
+	}
+
+	if r.budget.keys.Add(1) > int64(r.budget.limits.MaxKeys) {
+		r.budget.keys.Add(-1)
+		r.truncate()
+		return false
+	}
+	return true
+}
+
+// truncate marks r as truncated. The caller must hold r.mu.
+func (r *Record) truncate() {
+	r.values[unique.Make(truncatedKey)] = true
+}
+
+// newChild returns a new sub-record of r, or nil if it would be deeper than allowed.
+// The caller must hold r.mu.
+func (r *Record) newChild() *Record {
+	if r.budget != nil && r.budget.limits.MaxDepth > 0 && r.depth+1 > r.budget.limits.MaxDepth {
+		r.truncate()
+		return nil
+	}
+	if !r.reserveKey() {
+		return nil
+	}
+
+	sub := newRecord()
+	sub.budget = r.budget
+	sub.depth = r.depth + 1
+	return sub
+}
+
 func (r *Record) LogValue() slog.Value {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -67,7 +150,11 @@ type Recorder interface {
 // The list of supported types:
 // - All integer types.
 // - All float types.
+// - time.Duration.
 // - Error types.
+//
+// Values that cannot be added don't panic, so that a telemetry bug cannot fail a request:
+// the existing value is kept, and the record gets an "$add_error" marker describing the last such addition.
 func (r *Record) Add(keyValuePairs ...any) {
 	r.putValues(true, keyValuePairs)
 }
@@ -99,17 +186,35 @@ func (r *Record) putValues(add bool, keyValuePairs []any) {
 				"keys should be non-empty strings, should not contain punctuation, spaces and '$', except for '_', '/' and'@'",
 			)
 		}
-		if add {
-			r.values[unique.Make(key)] = addValues(r.values[unique.Make(key)], keyValuePairs[i+1])
+		if r.discard {
+			continue
+		}
+
+		ukey := unique.Make(key)
+		if _, exists := r.values[ukey]; !exists && !r.reserveKey() {
+			continue
+		}
+		if !add {
+			r.values[ukey] = keyValuePairs[i+1]
+			continue
+		}
+		if sum, ok := sumValues(r.values[ukey], keyValuePairs[i+1]); ok {
+			r.values[ukey] = sum
 		} else {
-			r.values[unique.Make(key)] = keyValuePairs[i+1]
+			r.values[unique.Make(addErrorKey)] = fmt.Sprintf(
+				"%s: types %T and %T cannot be added", key, r.values[ukey], keyValuePairs[i+1],
+			)
 		}
 	}
 }
 
-func addValues(to, v any) any {
+// addErrorKey marks a record to which Add couldn't add a value, like truncatedKey it is not counted as a key.
+const addErrorKey = "$add_error"
+
+// sumValues adds v to the existing value to, if their types can be added.
+func sumValues(to, v any) (any, bool) {
 	if to == nil {
-		return v
+		return v, true
 	}
 
 	if addNumbers[int8](&to, v) ||
@@ -121,16 +226,22 @@ func addValues(to, v any) any {
 		addNumbers[uint16](&to, v) ||
 		addNumbers[uint32](&to, v) ||
 		addNumbers[uint64](&to, v) ||
+		addNumbers[float32](&to, v) ||
+		addNumbers[float64](&to, v) ||
+		addNumbers[time.Duration](&to, v) ||
 		addErrors(&to, v) {
-		return to
+		return to, true
 	}
 
-	panic(fmt.Sprintf("types %s and %s cannot be added", reflect.TypeOf(to).Name(), reflect.TypeOf(v).Name()))
+	return nil, false
 }
 
 func addErrors(to *any, val any) bool {
 	s, ok1 := (*to).(error)
 	v, ok2 := val.(error)
+	if !ok1 || !ok2 {
+		return false
+	}
 
 	// s already wraps v
 	if errors.Is(s, v) {
@@ -142,11 +253,8 @@ func addErrors(to *any, val any) bool {
 		return true
 	}
 
-	if ok1 && ok2 {
-		*to = errors.Join(s, v)
-		return true
-	}
-	return false
+	*to = errors.Join(s, v)
+	return true
 }
 
 func addNumbers[T constraints.Integer | constraints.Float](to *any, val any) bool {
@@ -168,6 +276,10 @@ func (r *Record) Sub(name string) *Record {
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if r.discard {
+		return r
+	}
+
 	if sub := r.values[unique.Make(name)]; sub != nil {
 		sub, ok := sub.(*Record)
 		if !ok {
@@ -177,12 +289,151 @@ func (r *Record) Sub(name string) *Record {
 		return sub
 	}
 
-	sub := newRecord()
+	sub := r.newChild()
+	if sub == nil {
+		// The sub-record is not attached to r, so everything set on it is dropped.
+		return &Record{discard: true}
+	}
 	r.values[unique.Make(name)] = sub
 
 	return sub
 }
 
+// Merge merges the values of other into r, e.g. to collect the stats of a nested layer in the root record.
+//
+// Values that can be added, see Add, are summed, sub-records are merged recursively,
+// and all the other values of other overwrite the ones in r.
+// Other must not contain r. The new values are subject to the Limits of r.
+func (r *Record) Merge(other *Record) {
+	if other == nil || other == r || r.discard {
+		return
+	}
+
+	other.mu.Lock()
+	values := maps.Clone(other.values)
+	other.mu.Unlock()
+
+	for name, v := range values {
+		if otherSub, ok := v.(*Record); ok {
+			r.mu.Lock()
+			sub, ok := r.values[name].(*Record)
+			if !ok {
+				sub = r.newChild()
+				if sub == nil {
+					r.mu.Unlock()
+					continue
+				}
+				r.values[name] = sub
+			}
+			r.mu.Unlock()
+
+			sub.Merge(otherSub)
+			continue
+		}
+
+		r.mu.Lock()
+		if _, exists := r.values[name]; !exists && !r.reserveKey() {
+			r.mu.Unlock()
+			continue
+		}
+		if sum, ok := sumValues(r.values[name], v); ok {
+			r.values[name] = sum
+		} else {
+			r.values[name] = v
+		}
+		r.mu.Unlock()
+	}
+}
+
+// Filter returns a pruned copy of r, keeping the event name and the values for which keep returns true.
+//
+// Keep is called with the dot-separated path of every value, like in AllValues, sub-records included.
+// A sub-record for which keep returns true is copied whole, otherwise it is filtered recursively
+// and dropped if nothing is left. The copy has no Limits and stays valid after r is finished.
+func (r *Record) Filter(keep func(path string, value any) bool) *Record {
+	filtered := newRecord()
+	filtered.eventName = r.eventName
+	r.filterInto(filtered, "", keep, nil)
+	return filtered
+}
+
+func keepAll(string, any) bool { return true }
+
+// Clone returns a deep copy of r, that shares no sub-records and no pooled maps with it.
+//
+// Sinks that process a record in another goroutine should clone it first: the copy stays valid
+// while r is changed or finished. Like Filter, the copy has no Limits, and records that contain
+// themselves are replaced with "$cycle".
+func (r *Record) Clone() *Record {
+	return r.cloneRecord(nil)
+}
+
+// cloneRecord returns a deep copy of r. Path holds the records being copied, to detect cycles.
+func (r *Record) cloneRecord(path []*Record) *Record {
+	path = append(path, r)
+
+	r.mu.Lock()
+	clone := &Record{
+		eventName: r.eventName,
+		values:    maps.Clone(r.values),
+	}
+	r.mu.Unlock()
+
+	if clone.values == nil {
+		// r is finished or discards its values.
+		clone.values = make(map[ustring]any)
+	}
+
+	for name, v := range clone.values {
+		sub, ok := v.(*Record)
+		switch {
+		case !ok:
+		case slices.Contains(path, sub):
+			clone.values[name] = cycleMarker
+		default:
+			clone.values[name] = sub.cloneRecord(path)
+		}
+	}
+	return clone
+}
+
+// filterInto copies the values of r kept by keep into dst. Prefix is the path of r,
+// and path holds the records being copied, to detect cycles.
+func (r *Record) filterInto(dst *Record, prefix string, keep func(path string, value any) bool, path []*Record) {
+	path = append(path, r)
+
+	r.mu.Lock()
+	values := maps.Clone(r.values)
+	r.mu.Unlock()
+
+	for name, v := range values {
+		key := prefix + name.Value()
+		kept := keep(key, v)
+
+		sub, ok := v.(*Record)
+		switch {
+		case ok && slices.Contains(path, sub):
+			if kept {
+				dst.values[name] = cycleMarker
+			}
+		case ok:
+			subKeep := keep
+			if kept {
+				subKeep = keepAll
+			}
+			filtered := newRecord()
+			sub.filterInto(filtered, key+".", subKeep, path)
+			if kept || len(filtered.values) > 0 {
+				dst.values[name] = filtered
+			} else {
+				filtered.Finish()
+			}
+		case kept:
+			dst.values[name] = v
+		}
+	}
+}
+
 func (r *Record) EventName() string {
 	if r.eventName == (ustring{}) {
 		return ""
@@ -218,6 +469,87 @@ func (r *Record) AllValues() map[string]any {
 	return vals
 }
 
+// cycleMarker replaces a record that contains itself in the JSON output.
+const cycleMarker = "$cycle"
+
+// MarshalJSON encodes r as a nested JSON object mirroring its sub-records, with the event name under "$event".
+// Recorder values are encoded as their records, and errors as their messages. Keys are sorted.
+func (r *Record) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := r.writeJSON(&buf, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeJSON writes r to buf. Path holds the records being written, to detect cycles.
+func (r *Record) writeJSON(buf *bytes.Buffer, path []*Record) error {
+	if slices.Contains(path, r) {
+		writeJSONString(buf, cycleMarker)
+		return nil
+	}
+	path = append(path, r)
+
+	eventName := r.EventName()
+
+	r.mu.Lock()
+	names := make([]string, 0, len(r.values))
+	values := make(map[string]any, len(r.values))
+	for n, v := range r.values {
+		names = append(names, n.Value())
+		values[n.Value()] = v
+	}
+	r.mu.Unlock()
+	slices.Sort(names)
+
+	buf.WriteByte('{')
+	if eventName != "" {
+		buf.WriteString(`"$event":`)
+		writeJSONString(buf, eventName)
+		if len(names) > 0 {
+			buf.WriteByte(',')
+		}
+	}
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSONString(buf, name)
+		buf.WriteByte(':')
+		if err := writeJSONValue(buf, values[name], path); err != nil {
+			return fmt.Errorf("couldn't encode %s: %w", name, err)
+		}
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+func writeJSONValue(buf *bytes.Buffer, v any, path []*Record) error {
+	switch v := v.(type) {
+	case *Record:
+		return v.writeJSON(buf, path)
+	case Recorder:
+		return v.EventRecord().writeJSON(buf, path)
+	case error:
+		writeJSONString(buf, v.Error())
+		return nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		return nil
+	}
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	// Marshaling a string never fails.
+	data, _ := json.Marshal(s)
+	buf.Write(data)
+}
+
 func (r *Record) valueHolder(key string) (re *Record, subKey string) {
 	idx := strings.IndexByte(key, '.')
 	if idx == -1 {
@@ -271,6 +603,9 @@ var mapPool = sync.Pool{
 func (r *Record) Finish() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if r.discard {
+		return
+	}
 
 	for _, v := range r.values {
 		if re, ok := v.(*Record); ok {
@@ -294,9 +629,15 @@ func newRecord() *Record {
 	}
 }
 
-func NewRecord(parent context.Context, eventName string) (context.Context, *Record) {
+// NewRecord creates a root record for the wide event eventName and adds it to the context.
+// The record tree is limited by DefaultLimits, unless another Option is given.
+func NewRecord(parent context.Context, eventName string, opts ...Option) (context.Context, *Record) {
 	rec := newRecord()
 	rec.eventName = unique.Make(eventName)
+	rec.budget = &budget{limits: DefaultLimits}
+	for _, opt := range opts {
+		opt(rec)
+	}
 	parent = context.WithValue(parent, rootCtxKey, rec)
 	return context.WithValue(parent, eventCtxKey, rec), rec
 }
@@ -2,9 +2,13 @@ package event_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/stretchr/testify/require"
@@ -64,6 +68,105 @@ func TestRecord_Add(t *testing.T) {
 		require.ErrorIs(t, rec.Value("error").(error), e2)
 		require.ErrorIs(t, rec.Value("error").(error), e3)
 	})
+	t.Run("numbers", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+
+		rec.Add("queries", 1, "time", time.Second, "ratio", 0.25)
+		rec.Add("queries", 2, "time", time.Second, "ratio", 0.5)
+
+		require.Equal(t, 3, rec.Value("queries"))
+		require.Equal(t, 2*time.Second, rec.Value("time"))
+		require.InDelta(t, 0.75, rec.Value("ratio"), 1e-9)
+	})
+	t.Run("mismatched types", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+
+		rec.Add("value", 1)
+		require.NotPanics(t, func() { rec.Add("value", "one") })
+		require.Equal(t, 1, rec.Value("value"), "The existing value is kept")
+		require.Equal(t, "value: types int and string cannot be added", rec.Value("$add_error"))
+
+		// Other values of the same call are still added
+		rec.Add("value", int64(1), "other", 2)
+		require.Equal(t, 1, rec.Value("value"))
+		require.Equal(t, 2, rec.Value("other"))
+		require.Equal(t, "value: types int and int64 cannot be added", rec.Value("$add_error"))
+
+		rec.Add("value", nil)
+		require.Equal(t, "value: types int and <nil> cannot be added", rec.Value("$add_error"))
+	})
+}
+
+func TestRecord_Merge(t *testing.T) {
+	t.Run("numbers", func(t *testing.T) {
+		_, root := event.NewRecord(t.Context(), "test")
+		root.Add("postgres_queries", 1, "postgres_time", time.Second)
+
+		_, db := event.NewRecord(t.Context(), "db")
+		db.Add("postgres_queries", 2, "postgres_time", 2*time.Second)
+
+		root.Merge(db)
+
+		require.Equal(t, 3, root.Value("postgres_queries"))
+		require.Equal(t, 3*time.Second, root.Value("postgres_time"))
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, root := event.NewRecord(t.Context(), "test")
+		e1 := errors.New("e1")
+		root.Add("error", e1)
+
+		_, other := event.NewRecord(t.Context(), "other")
+		e2 := errors.New("e2")
+		other.Add("error", e2)
+
+		root.Merge(other)
+
+		require.ErrorIs(t, root.Value("error").(error), e1)
+		require.ErrorIs(t, root.Value("error").(error), e2)
+	})
+	t.Run("other values are overwritten", func(t *testing.T) {
+		_, root := event.NewRecord(t.Context(), "test")
+		root.Set("success", false, "name", "root", "count", 1)
+
+		_, other := event.NewRecord(t.Context(), "other")
+		other.Set("success", true, "count", "many")
+
+		root.Merge(other)
+
+		require.Equal(t, true, root.Value("success"))
+		require.Equal(t, "root", root.Value("name"))
+		require.Equal(t, "many", root.Value("count"))
+	})
+	t.Run("sub-records", func(t *testing.T) {
+		_, root := event.NewRecord(t.Context(), "test")
+		root.Sub("stats").Add("postgres_queries", 1)
+
+		_, other := event.NewRecord(t.Context(), "other")
+		other.Sub("stats").Add("postgres_queries", 2)
+		other.Sub("stats").Sub("cache").Set("hit", true)
+		other.Sub("params").Set("id", 42)
+
+		root.Merge(other)
+
+		require.Equal(t, 3, root.Value("stats.postgres_queries"))
+		require.Equal(t, true, root.Value("stats.cache.hit"))
+		require.Equal(t, 42, root.Value("params.id"))
+
+		// The merged sub-records are copies.
+		other.Sub("params").Set("id", 43)
+		require.Equal(t, 42, root.Value("params.id"))
+	})
+	t.Run("child into root", func(t *testing.T) {
+		_, root := event.NewRecord(t.Context(), "test")
+		child := root.Sub("entdb")
+		child.Add("postgres_queries", 2)
+
+		root.Merge(child)
+		root.Merge(root)
+
+		require.Equal(t, 2, root.Value("postgres_queries"))
+		require.Equal(t, 2, root.Value("entdb.postgres_queries"))
+	})
 }
 
 func TestRecord_Value(t *testing.T) {
@@ -100,6 +203,24 @@ func TestRecord_Value(t *testing.T) {
 	})
 }
 
+func TestNewRecord(t *testing.T) {
+	_, rec := event.NewRecord(t.Context(), "http_request")
+
+	require.Equal(t, "http_request", rec.EventName())
+	require.Equal(t, "http_request", rec.AllValues()["$event"])
+
+	var found bool
+	for _, attr := range rec.LogValue().Group() {
+		if attr.Key == "event" {
+			found = true
+			require.Equal(t, "http_request", attr.Value.String())
+		}
+	}
+	require.True(t, found, "LogValue should include the event name")
+
+	require.Empty(t, rec.Sub("child").EventName(), "Sub-records have no event name")
+}
+
 func TestContextOperations(t *testing.T) {
 	t.Run("simple", func(t *testing.T) {
 		ctx, rec := event.NewRecord(t.Context(), "test")
@@ -121,3 +242,307 @@ func TestContextOperations(t *testing.T) {
 		require.Subset(t, vals, expected)
 	})
 }
+
+// point is a Recorder used to test how such values are encoded.
+type point struct {
+	X, Y int
+}
+
+func (p point) EventRecord() *event.Record {
+	return event.Group("x", p.X, "y", p.Y)
+}
+
+func TestRecord_MarshalJSON(t *testing.T) {
+	t.Run("nested", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "http_request")
+		rec.Set("path", "/users", "code", 200)
+		rec.Add("error", errors.New("boom"))
+		rec.Sub("stats").Add("postgres_queries", 2)
+		rec.Sub("sesc/user").Sub("params").Set("id", "42")
+		rec.Set("point", point{X: 1, Y: 2})
+
+		data, err := json.Marshal(rec)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"$event": "http_request",
+			"path": "/users",
+			"code": 200,
+			"error": "boom",
+			"stats": {"postgres_queries": 2},
+			"sesc/user": {"params": {"id": "42"}},
+			"point": {"x": 1, "y": 2}
+		}`, string(data))
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+		rec.Set("b", 2, "a", 1, "c", 3)
+
+		data, err := json.Marshal(rec)
+		require.NoError(t, err)
+		require.Equal(t, `{"$event":"test","a":1,"b":2,"c":3}`, string(data))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+		rec.Sub("child").Set("parent", rec)
+
+		data, err := json.Marshal(rec)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"$event":"test","child":{"parent":"$cycle"}}`, string(data))
+	})
+}
+
+// countKeys counts the values in the flattened record, except for the markers.
+func countKeys(vals map[string]any) int {
+	n := 0
+	for k := range vals {
+		if !strings.HasPrefix(k, "$") && !strings.Contains(k, ".$") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRecord_Limits(t *testing.T) {
+	t.Run("max_keys", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test", event.WithLimits(event.Limits{MaxKeys: 10}))
+
+		for i := range 100 {
+			rec.Set(fmt.Sprintf("key%d", i), i)
+		}
+
+		vals := rec.AllValues()
+		require.Equal(t, 10, countKeys(vals))
+		require.Equal(t, true, vals["$truncated"])
+		require.Equal(t, 0, vals["key0"])
+		require.NotContains(t, vals, "key10")
+
+		// Existing values can still be updated
+		rec.Set("key0", 42)
+		rec.Add("key1", 1)
+		require.Equal(t, 42, rec.Value("key0"))
+		require.Equal(t, 2, rec.Value("key1"))
+	})
+	t.Run("max_keys_in_sub_records", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test", event.WithLimits(event.Limits{MaxKeys: 10}))
+
+		for i := range 100 {
+			rec.Sub(fmt.Sprintf("sub%d", i%5)).Set(fmt.Sprintf("key%d", i), i)
+		}
+
+		vals := rec.AllValues()
+		// 5 sub-records and 5 values in them
+		require.Equal(t, 5, countKeys(vals))
+		require.Equal(t, true, vals["sub0.$truncated"])
+	})
+	t.Run("max_depth", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test", event.WithLimits(event.Limits{MaxDepth: 2}))
+
+		sub := rec.Sub("a").Sub("b")
+		sub.Set("ok", true)
+
+		deep := sub.Sub("c")
+		deep.Set("dropped", true)
+		deep.Sub("d").Set("dropped", true)
+
+		vals := rec.AllValues()
+		require.Equal(t, true, vals["a.b.ok"])
+		require.Equal(t, true, vals["a.b.$truncated"])
+		require.NotContains(t, vals, "a.b.c.dropped")
+		require.Nil(t, deep.Value("dropped"))
+
+		// Dropped records can be finished safely
+		deep.Finish()
+	})
+	t.Run("merge", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test", event.WithLimits(event.Limits{MaxKeys: 3}))
+		rec.Set("a", 1)
+
+		_, other := event.NewRecord(t.Context(), "other")
+		other.Set("a", 1, "b", 2, "c", 3, "d", 4)
+
+		rec.Merge(other)
+
+		vals := rec.AllValues()
+		require.Equal(t, 3, countKeys(vals))
+		require.Equal(t, 2, vals["a"])
+		require.Equal(t, true, vals["$truncated"])
+	})
+	t.Run("default_limits", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+
+		sub := rec
+		for i := range event.DefaultLimits.MaxDepth + 10 {
+			sub = sub.Sub(fmt.Sprintf("level%d", i))
+		}
+		for i := range event.DefaultLimits.MaxKeys + 10 {
+			rec.Set(fmt.Sprintf("key%d", i), i)
+		}
+
+		vals := rec.AllValues()
+		require.LessOrEqual(t, countKeys(vals), event.DefaultLimits.MaxKeys)
+		require.Equal(t, true, vals["$truncated"])
+	})
+	t.Run("no_limits", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test", event.WithLimits(event.Limits{}))
+
+		for i := range event.DefaultLimits.MaxKeys + 10 {
+			rec.Set(fmt.Sprintf("key%d", i), i)
+		}
+
+		vals := rec.AllValues()
+		require.Equal(t, event.DefaultLimits.MaxKeys+10, countKeys(vals))
+		require.NotContains(t, vals, "$truncated")
+	})
+}
+
+func TestRecord_Filter(t *testing.T) {
+	_, rec := event.NewRecord(t.Context(), "http_request")
+	rec.Set("path", "/users")
+	rec.Sub("stats").Add("postgres_queries", 2)
+	op := rec.Sub("sesc/create_user")
+	op.Set("success", true)
+	op.Sub("pg_transaction").Set("attempts", 1)
+	rec.Sub("sesc/user").Set("success", false)
+
+	t.Run("keeps the matching sub-record whole", func(t *testing.T) {
+		filtered := rec.Filter(func(path string, _ any) bool {
+			return path == "sesc/create_user"
+		})
+
+		data, err := json.Marshal(filtered)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"$event": "http_request",
+			"sesc/create_user": {"success": true, "pg_transaction": {"attempts": 1}}
+		}`, string(data))
+	})
+
+	t.Run("prunes the empty sub-records", func(t *testing.T) {
+		filtered := rec.Filter(func(path string, _ any) bool {
+			return strings.HasSuffix(path, ".success")
+		})
+
+		data, err := json.Marshal(filtered)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"$event": "http_request",
+			"sesc/create_user": {"success": true},
+			"sesc/user": {"success": false}
+		}`, string(data))
+	})
+
+	t.Run("copy outlives the record", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+		rec.Sub("child").Set("key", "value")
+
+		filtered := rec.Filter(func(string, any) bool { return true })
+		rec.Finish()
+
+		require.Equal(t, "value", filtered.Value("child.key"))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+		rec.Sub("child").Set("parent", rec)
+
+		data, err := json.Marshal(rec.Filter(func(string, any) bool { return true }))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"$event":"test","child":{"parent":"$cycle"}}`, string(data))
+	})
+}
+
+func TestRecord_Clone(t *testing.T) {
+	t.Run("deep copy", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "http_request")
+		rec.Set("path", "/users")
+		rec.Sub("stats").Add("postgres_queries", 2)
+		rec.Sub("http").Set("header", event.Group("content_type", "application/json"))
+
+		clone := rec.Clone()
+		require.Equal(t, "http_request", clone.EventName())
+
+		want, err := json.Marshal(rec)
+		require.NoError(t, err)
+		got, err := json.Marshal(clone)
+		require.NoError(t, err)
+		require.JSONEq(t, string(want), string(got))
+
+		rec.Sub("stats").Add("postgres_queries", 1)
+		clone.Sub("http").Set("code", 200)
+		require.Equal(t, 2, clone.Value("stats.postgres_queries"))
+		require.Nil(t, rec.Value("http.code"))
+	})
+
+	t.Run("copy outlives the record", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+		rec.Sub("child").Set("key", "value")
+
+		clone := rec.Clone()
+		rec.Finish()
+
+		// The maps of the finished record are reused by the new ones.
+		for range 10 {
+			_, other := event.NewRecord(t.Context(), "other")
+			other.Sub("child").Set("key", "other")
+		}
+
+		require.Equal(t, "value", clone.Value("child.key"))
+		require.Nil(t, rec.Clone().Value("child.key"), "A finished record is cloned empty")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, rec := event.NewRecord(t.Context(), "test")
+		rec.Sub("child").Set("parent", rec)
+
+		data, err := json.Marshal(rec.Clone())
+		require.NoError(t, err)
+		require.JSONEq(t, `{"$event":"test","child":{"parent":"$cycle"}}`, string(data))
+	})
+
+	t.Run("concurrent changes", func(t *testing.T) {
+		// Run with -race: cloning must not race with the changes and the finishing of the record.
+		_, rec := event.NewRecord(t.Context(), "test", event.WithLimits(event.Limits{}))
+
+		var wg sync.WaitGroup
+		clones := make(chan *event.Record, 100)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				rec.Add("count", 1)
+				rec.Sub(fmt.Sprintf("sub%d", i%10)).Add("count", 1)
+			}
+		}()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range cap(clones) {
+				clones <- rec.Clone()
+			}
+		}()
+		wg.Wait()
+
+		finished := make(chan struct{})
+		go func() {
+			defer close(finished)
+			rec.Finish()
+		}()
+		last := rec.Clone()
+		<-finished
+
+		close(clones)
+		for clone := range clones {
+			if count, ok := clone.Value("count").(int); ok {
+				require.LessOrEqual(t, count, 100)
+			}
+			clone.Finish()
+		}
+		if count := last.Value("count"); count != nil {
+			require.Equal(t, 100, count)
+		}
+	})
+}
@@ -5,9 +5,18 @@ const (
 	// Error key should be used to add unexpected error values to the event.
 	Error = "error"
 
+	// Warning key should be used to describe inconsistencies that did not fail the event.
+	Warning = "warning"
+
 	// PostgresTime is cumulative time spent in postgres to execure the event.
 	PostgresTime = "postgres_time"
 
 	// PostgresQueries is cumulative number of postgres queries triggered by the event.
 	PostgresQueries = "postgres_queries"
+
+	// RoleCacheHits is cumulative number of role lookups served from the cache.
+	RoleCacheHits = "role_cache_hits"
+
+	// RoleCacheMisses is cumulative number of role lookups for IDs missing from the cache.
+	RoleCacheMisses = "role_cache_misses"
 )
@@ -1,6 +1,19 @@
 package sesc
 
-import "github.com/kozlov-ma/sesc-backend/pkg/event"
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+)
+
+const (
+	// MaxDepartmentNameLength is the maximum length of a department name, in characters.
+	MaxDepartmentNameLength = 100
+	// MaxDepartmentDescriptionLength is the maximum length of a department description, in characters.
+	MaxDepartmentDescriptionLength = 2000
+)
 
 // Department represents a department within an organization, like maths, physics, etc.
 // A Department can have a head, which is a user who is responsible for managing the department
@@ -24,3 +37,27 @@ func (d Department) EventRecord() *event.Record {
 var (
 	NoDepartment = Department{}
 )
+
+// NormalizeDepartmentName returns the trimmed lower-case name. Department names are unique in this form,
+// so that e.g. "IT" and "it " cannot both exist.
+func NormalizeDepartmentName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ValidateDepartment returns an ErrInvalidDepartmentName if the name is blank,
+// or if the name or the description is too long.
+func ValidateDepartment(name, description string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return ErrInvalidDepartmentName
+	case utf8.RuneCountInString(name) > MaxDepartmentNameLength:
+		return fmt.Errorf("%w: name is longer than %d characters", ErrInvalidDepartmentName, MaxDepartmentNameLength)
+	case utf8.RuneCountInString(description) > MaxDepartmentDescriptionLength:
+		return fmt.Errorf(
+			"%w: description is longer than %d characters",
+			ErrInvalidDepartmentName,
+			MaxDepartmentDescriptionLength,
+		)
+	}
+	return nil
+}
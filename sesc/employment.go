@@ -0,0 +1,185 @@
+package sesc
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// PersonnelCategory is the staff category of a User, as reported to the education authorities.
+type PersonnelCategory int32
+
+const (
+	PersonnelCategoryUnspecified PersonnelCategory = iota
+	// Pedagogical staff teach and run the educational process.
+	Pedagogical
+	// Administrative staff manage the school.
+	Administrative
+	// EducationalSupport staff assist the educational process, e.g. lab assistants.
+	EducationalSupport
+	// ServiceStaff maintain the premises.
+	ServiceStaff
+)
+
+// personnelCategoryCodes are the stable string codes of the personnel categories, indexed by value.
+var personnelCategoryCodes = []string{
+	PersonnelCategoryUnspecified: "unspecified",
+	Pedagogical:                  "pedagogical",
+	Administrative:               "administrative",
+	EducationalSupport:           "educational_support",
+	ServiceStaff:                 "service",
+}
+
+// Valid reports whether c is a known PersonnelCategory.
+func (c PersonnelCategory) Valid() bool {
+	return c >= PersonnelCategoryUnspecified && c <= ServiceStaff
+}
+
+// String returns the stable string code of c.
+func (c PersonnelCategory) String() string {
+	return enumString(personnelCategoryCodes, c, "PersonnelCategory")
+}
+
+func (c PersonnelCategory) MarshalJSON() ([]byte, error) {
+	return marshalEnum(personnelCategoryCodes, c, "personnel category")
+}
+
+func (c *PersonnelCategory) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(personnelCategoryCodes, data, c, "personnel category")
+}
+
+// PersonnelCategoryFromString parses the string code of a PersonnelCategory.
+//
+// Returns an ErrInvalidEmploymentData if the code is unknown.
+func PersonnelCategoryFromString(s string) (PersonnelCategory, error) {
+	return enumFromString[PersonnelCategory](personnelCategoryCodes, s, "personnel category")
+}
+
+// EmploymentType tells whether SESC is the main employer of a User.
+type EmploymentType int32
+
+const (
+	EmploymentTypeUnspecified EmploymentType = iota
+	// MainEmployment means SESC is the main place of work.
+	MainEmployment
+	// InternalPartTime means an additional position held by an employee of SESC.
+	InternalPartTime
+	// ExternalPartTime means the main place of work is elsewhere.
+	ExternalPartTime
+)
+
+// employmentTypeCodes are the stable string codes of the employment types, indexed by value.
+var employmentTypeCodes = []string{
+	EmploymentTypeUnspecified: "unspecified",
+	MainEmployment:            "main",
+	InternalPartTime:          "internal_part_time",
+	ExternalPartTime:          "external_part_time",
+}
+
+// Valid reports whether t is a known EmploymentType.
+func (t EmploymentType) Valid() bool {
+	return t >= EmploymentTypeUnspecified && t <= ExternalPartTime
+}
+
+// String returns the stable string code of t.
+func (t EmploymentType) String() string {
+	return enumString(employmentTypeCodes, t, "EmploymentType")
+}
+
+func (t EmploymentType) MarshalJSON() ([]byte, error) {
+	return marshalEnum(employmentTypeCodes, t, "employment type")
+}
+
+func (t *EmploymentType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(employmentTypeCodes, data, t, "employment type")
+}
+
+// EmploymentTypeFromString parses the string code of an EmploymentType.
+//
+// Returns an ErrInvalidEmploymentData if the code is unknown.
+func EmploymentTypeFromString(s string) (EmploymentType, error) {
+	return enumFromString[EmploymentType](employmentTypeCodes, s, "employment type")
+}
+
+// AcademicDegree is the highest academic degree held by a User.
+type AcademicDegree int32
+
+const (
+	NoAcademicDegree AcademicDegree = iota
+	// Candidate is a Candidate of Sciences.
+	Candidate
+	// Doctor is a Doctor of Sciences.
+	Doctor
+)
+
+// academicDegreeCodes are the stable string codes of the academic degrees, indexed by value.
+var academicDegreeCodes = []string{
+	NoAcademicDegree: "none",
+	Candidate:        "candidate",
+	Doctor:           "doctor",
+}
+
+// Valid reports whether d is a known AcademicDegree.
+func (d AcademicDegree) Valid() bool {
+	return d >= NoAcademicDegree && d <= Doctor
+}
+
+// String returns the stable string code of d.
+func (d AcademicDegree) String() string {
+	return enumString(academicDegreeCodes, d, "AcademicDegree")
+}
+
+func (d AcademicDegree) MarshalJSON() ([]byte, error) {
+	return marshalEnum(academicDegreeCodes, d, "academic degree")
+}
+
+func (d *AcademicDegree) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(academicDegreeCodes, data, d, "academic degree")
+}
+
+// AcademicDegreeFromString parses the string code of an AcademicDegree.
+//
+// Returns an ErrInvalidEmploymentData if the code is unknown.
+func AcademicDegreeFromString(s string) (AcademicDegree, error) {
+	return enumFromString[AcademicDegree](academicDegreeCodes, s, "academic degree")
+}
+
+// MaxEmploymentRate is the largest workload a single User may hold, in full-time positions.
+const MaxEmploymentRate = 2.0
+
+func enumString[T ~int32](codes []string, v T, typeName string) string {
+	if v < 0 || int(v) >= len(codes) {
+		return fmt.Sprintf("%s(%d)", typeName, int32(v))
+	}
+	return codes[v]
+}
+
+func enumFromString[T ~int32](codes []string, s, kind string) (T, error) {
+	i := slices.Index(codes, s)
+	if i < 0 {
+		return 0, fmt.Errorf("%w: unknown %s %q", ErrInvalidEmploymentData, kind, s)
+	}
+	return T(i), nil
+}
+
+func marshalEnum[T ~int32](codes []string, v T, kind string) ([]byte, error) {
+	if v < 0 || int(v) >= len(codes) {
+		return nil, fmt.Errorf("%w: unknown %s %d", ErrInvalidEmploymentData, kind, int32(v))
+	}
+	return json.Marshal(codes[v])
+}
+
+func unmarshalEnum[T ~int32](codes []string, data []byte, v *T, kind string) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: %s must be a string: %w", ErrInvalidEmploymentData, kind, err)
+	}
+
+	parsed, err := enumFromString[T](codes, s, kind)
+	if err != nil {
+		return err
+	}
+
+	*v = parsed
+	return nil
+}
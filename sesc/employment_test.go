@@ -0,0 +1,144 @@
+package sesc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmploymentEnumsJSON(t *testing.T) {
+	t.Run("personnel category", func(t *testing.T) {
+		for i, code := range personnelCategoryCodes {
+			c := PersonnelCategory(i)
+			requireEnumRoundTrip(t, c, code)
+
+			parsed, err := PersonnelCategoryFromString(code)
+			require.NoError(t, err)
+			require.Equal(t, c, parsed)
+		}
+	})
+
+	t.Run("employment type", func(t *testing.T) {
+		for i, code := range employmentTypeCodes {
+			et := EmploymentType(i)
+			requireEnumRoundTrip(t, et, code)
+
+			parsed, err := EmploymentTypeFromString(code)
+			require.NoError(t, err)
+			require.Equal(t, et, parsed)
+		}
+	})
+
+	t.Run("academic degree", func(t *testing.T) {
+		for i, code := range academicDegreeCodes {
+			d := AcademicDegree(i)
+			requireEnumRoundTrip(t, d, code)
+
+			parsed, err := AcademicDegreeFromString(code)
+			require.NoError(t, err)
+			require.Equal(t, d, parsed)
+		}
+	})
+}
+
+func requireEnumRoundTrip[T interface {
+	~int32
+	String() string
+}](t *testing.T, v T, code string) {
+	t.Helper()
+	require.Equal(t, code, v.String())
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.JSONEq(t, `"`+code+`"`, string(data))
+
+	var decoded T
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, v, decoded)
+}
+
+func TestEmploymentEnumsRejectGarbage(t *testing.T) {
+	t.Run("from string", func(t *testing.T) {
+		_, err := PersonnelCategoryFromString("garbage")
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+		_, err = EmploymentTypeFromString("Main")
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+		_, err = AcademicDegreeFromString("")
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		for _, input := range []string{`"garbage"`, `1`, `null`, `{}`} {
+			var c PersonnelCategory
+			require.ErrorIs(t, json.Unmarshal([]byte(input), &c), ErrInvalidEmploymentData, input)
+			var et EmploymentType
+			require.ErrorIs(t, json.Unmarshal([]byte(input), &et), ErrInvalidEmploymentData, input)
+			var d AcademicDegree
+			require.ErrorIs(t, json.Unmarshal([]byte(input), &d), ErrInvalidEmploymentData, input)
+		}
+	})
+
+	t.Run("marshal unknown value", func(t *testing.T) {
+		_, err := json.Marshal(ServiceStaff + 1)
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+		_, err = json.Marshal(EmploymentType(-1))
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+		_, err = json.Marshal(Doctor + 1)
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+		require.Equal(t, "AcademicDegree(3)", (Doctor + 1).String())
+	})
+}
+
+func TestUserFilterEmployment(t *testing.T) {
+	ctx, _ := event.NewRecord(t.Context(), "test")
+	svc := setupSESC(t)
+
+	ids := make(map[string]UUID)
+	for name, opts := range map[string]struct {
+		degree AcademicDegree
+		typ    EmploymentType
+	}{
+		"main doctor":       {Doctor, MainEmployment},
+		"external doctor":   {Doctor, ExternalPartTime},
+		"main candidate":    {Candidate, MainEmployment},
+		"main no degree":    {NoAcademicDegree, MainEmployment},
+		"candidate no type": {Candidate, EmploymentTypeUnspecified},
+	} {
+		u, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName:      name,
+			LastName:       "Doe",
+			NewRoleID:      Teacher.ID,
+			AcademicDegree: opts.degree,
+			EmploymentType: opts.typ,
+		})
+		require.NoError(t, err)
+		ids[name] = u.ID
+	}
+
+	filtered := func(filter UserFilter) []UUID {
+		t.Helper()
+		users, err := svc.Users(ctx, filter)
+		require.NoError(t, err)
+		got := make([]UUID, len(users))
+		for i, u := range users {
+			got[i] = u.ID
+		}
+		return got
+	}
+
+	doctor, candidate := Doctor, Candidate
+	main := MainEmployment
+
+	require.ElementsMatch(t, []UUID{ids["main doctor"], ids["external doctor"]}, filtered(UserFilter{AcademicDegree: &doctor}))
+	require.ElementsMatch(t, []UUID{ids["main doctor"], ids["main candidate"], ids["main no degree"]}, filtered(UserFilter{EmploymentType: &main}))
+	require.ElementsMatch(t, []UUID{ids["main doctor"]}, filtered(UserFilter{AcademicDegree: &doctor, EmploymentType: &main}))
+	require.ElementsMatch(t, []UUID{ids["main candidate"]}, filtered(UserFilter{AcademicDegree: &candidate, EmploymentType: &main}))
+
+	// The paginated listings use the same filter.
+	users, total, err := svc.UsersPage(ctx, 0, 10, UserFilter{AcademicDegree: &doctor})
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, users, 2)
+}
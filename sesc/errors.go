@@ -1,18 +1,85 @@
 package sesc
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
 
 var (
 	ErrInvalidRole            = errors.New("invalid role")
 	ErrUserNotFound           = errors.New("user not found")
+	ErrStaleUser              = errors.New("user has been modified since it was read")
 	ErrCannotRemoveDepartment = errors.New("cannot remove department")
 	ErrInvalidDepartment      = errors.New("invalid department")
 	ErrInvalidPermission      = errors.New("invalid permission")
 	ErrInvalidRoleChange      = errors.New("invalid role change")
 	ErrInvalidUserName        = errors.New("invalid or missing user name")
+	ErrInvalidEmploymentData  = errors.New("invalid employment data")
 	ErrInvalidDepartmentName  = errors.New("invalid or missing department name")
 	ErrEmptyDepartment        = errors.New("department is empty")
 	ErrDepartmentNotFound     = errors.New("department not found")
+	ErrDepartmentExists       = errors.New("department with this name already exists")
 	ErrInvalidUserID          = errors.New("invalid user ID")
 	ErrInvalidDepartmentID    = errors.New("invalid department ID")
+	ErrTimeout                = errors.New("operation cancelled or timed out")
+	ErrConstraintViolation    = errors.New("record violates a database constraint")
+	ErrInvalidEmail           = errors.New("invalid email")
+	ErrEmailExists            = errors.New("user with this email already exists")
+)
+
+// Postgres codes of the integrity constraint violations.
+const (
+	pgNotNullViolation    = "23502"
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
 )
+
+// WrapTimeout wraps err with an ErrTimeout if it was caused by ctx being cancelled or timing out,
+// so that callers can tell it apart from a failed query. Other errors are returned as is.
+func WrapTimeout(ctx context.Context, err error) error {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
+
+// WrapConstraint wraps err, returned by saving a user, with a sesc error if the database rejected the record
+// because it violated a constraint. A foreign key violation means the department does not exist and is wrapped
+// with an ErrInvalidDepartment, and a unique violation means another user has the email, since it is
+// the only unique column set by the callers, and is wrapped with an ErrEmailExists.
+// NOT NULL and CHECK violations are wrapped with an ErrConstraintViolation
+// naming the column or the constraint. Other errors are returned as is.
+func WrapConstraint(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pgForeignKeyViolation:
+			return fmt.Errorf("%w: %w", ErrInvalidDepartment, err)
+		case pgUniqueViolation:
+			return fmt.Errorf("%w: %w", ErrEmailExists, err)
+		case pgNotNullViolation:
+			return fmt.Errorf("%w: column %s must be set: %w", ErrConstraintViolation, pqErr.Column, err)
+		case pgCheckViolation:
+			return fmt.Errorf("%w: check %s failed: %w", ErrConstraintViolation, pqErr.Constraint, err)
+		}
+		return err
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.ExtendedCode {
+		case sqlite3.ErrConstraintForeignKey:
+			return fmt.Errorf("%w: %w", ErrInvalidDepartment, err)
+		case sqlite3.ErrConstraintUnique:
+			return fmt.Errorf("%w: %w", ErrEmailExists, err)
+		case sqlite3.ErrConstraintNotNull, sqlite3.ErrConstraintCheck:
+			return fmt.Errorf("%w: %w", ErrConstraintViolation, err)
+		}
+	}
+	return err
+}
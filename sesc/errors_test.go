@@ -0,0 +1,80 @@
+package sesc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapConstraint(t *testing.T) {
+	// saveErr wraps a driver error the way ent and the callers do.
+	saveErr := func(err error) error {
+		return fmt.Errorf("couldn't save user: %w", err)
+	}
+
+	t.Run("postgres foreign key", func(t *testing.T) {
+		err := WrapConstraint(saveErr(&pq.Error{Code: pgForeignKeyViolation, Constraint: "users_departments_users"}))
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+		require.NotErrorIs(t, err, ErrConstraintViolation)
+	})
+
+	t.Run("postgres not null", func(t *testing.T) {
+		err := WrapConstraint(saveErr(&pq.Error{Code: pgNotNullViolation, Column: "first_name"}))
+		require.ErrorIs(t, err, ErrConstraintViolation)
+		require.ErrorContains(t, err, "first_name")
+	})
+
+	t.Run("postgres check", func(t *testing.T) {
+		err := WrapConstraint(saveErr(&pq.Error{Code: pgCheckViolation, Constraint: "employment_rate_range"}))
+		require.ErrorIs(t, err, ErrConstraintViolation)
+		require.ErrorContains(t, err, "employment_rate_range")
+	})
+
+	t.Run("postgres unique", func(t *testing.T) {
+		err := WrapConstraint(saveErr(&pq.Error{Code: pgUniqueViolation, Constraint: "users_email_key"}))
+		require.ErrorIs(t, err, ErrEmailExists)
+	})
+
+	t.Run("postgres other", func(t *testing.T) {
+		orig := saveErr(&pq.Error{Code: "40001"})
+		require.Equal(t, orig, WrapConstraint(orig))
+	})
+
+	t.Run("sqlite not null", func(t *testing.T) {
+		err := WrapConstraint(saveErr(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintNotNull}))
+		require.ErrorIs(t, err, ErrConstraintViolation)
+	})
+
+	t.Run("sqlite unique", func(t *testing.T) {
+		err := WrapConstraint(saveErr(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique}))
+		require.ErrorIs(t, err, ErrEmailExists)
+	})
+
+	t.Run("sqlite foreign key", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		svc := setupSESC(t)
+
+		// Bypass the department check of CreateUser, so that the database rejects the record.
+		_, err := svc.client.User.Create().
+			SetFirstName("John").
+			SetLastName("Doe").
+			SetRoleID(Teacher.ID).
+			SetDepartmentID(uuid.Must(uuid.NewV7())).
+			Save(ctx)
+		require.Error(t, err)
+
+		err = WrapConstraint(saveErr(err))
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		orig := errors.New("connection refused")
+		require.Equal(t, orig, WrapConstraint(orig))
+	})
+}
@@ -0,0 +1,233 @@
+package sesc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/employmentevent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// EmploymentEvent is a change of the Role or the Department of a User.
+// A uuid.Nil department ID means the User had no Department.
+type EmploymentEvent struct {
+	ID     UUID
+	UserID UUID
+	// ActorID is the user who made the change, or uuid.Nil if it was not made on behalf of anyone.
+	ActorID UUID
+
+	OldRoleID       int32
+	NewRoleID       int32
+	OldDepartmentID UUID
+	NewDepartmentID UUID
+
+	Timestamp time.Time
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a copy of ctx, the changes made with which are recorded on behalf of actorID.
+func WithActor(ctx context.Context, actorID UUID) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actorID)
+}
+
+// actorFromContext returns the actor set by WithActor, or uuid.Nil if there is none.
+func actorFromContext(ctx context.Context) UUID {
+	actorID, _ := ctx.Value(actorContextKey{}).(UUID)
+	return actorID
+}
+
+// employment is the part of a user record tracked by the employment history.
+type employment struct {
+	roleID       int32
+	departmentID UUID
+}
+
+func employmentOf(u *ent.User) employment {
+	e := employment{roleID: u.RoleID}
+	if u.DepartmentID != nil {
+		e.departmentID = *u.DepartmentID
+	}
+	return e
+}
+
+// EmploymentHistory returns the changes of the role and the department of a user, oldest first.
+// The history of archived users is kept.
+//
+// Returns an ErrUserNotFound if the user does not exist.
+func (s *SESC) EmploymentHistory(ctx context.Context, userID UUID) ([]EmploymentEvent, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/employment_history")
+
+	rec.Sub("params").Set("user_id", userID)
+
+	// Stage 1: Query employment events
+	ctx = rec.Sub("query_employment_events").Wrap(ctx)
+	rows, err := s.queryEmploymentEvents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stage 2: Tell an empty history from a nonexistent user
+	if len(rows) == 0 {
+		ctx = rec.Sub("check_user_exists").Wrap(ctx)
+		if err := s.checkUserRecordExists(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	history := make([]EmploymentEvent, len(rows))
+	for i, row := range rows {
+		history[i] = convertEmploymentEvent(row)
+	}
+
+	rec.Set(
+		"success", true,
+		"count", len(history),
+	)
+	return history, nil
+}
+
+// queryEmploymentEvents queries the employment events of a user in chronological order.
+func (s *SESC) queryEmploymentEvents(ctx context.Context, userID UUID) ([]*ent.EmploymentEvent, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	rows, err := s.client.EmploymentEvent.Query().
+		Where(
+			employmentevent.UserID(userID),
+			employmentevent.HasUserWith(user.OrgID(OrgFromContext(ctx))),
+		).
+		Order(ent.Asc(employmentevent.FieldTimestamp), ent.Asc(employmentevent.FieldID)).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't query employment events: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set("success", true)
+	return rows, nil
+}
+
+// checkUserRecordExists checks that a user record exists, archived or not.
+func (s *SESC) checkUserRecordExists(ctx context.Context, id UUID) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	exists, err := s.client.User.Query().Where(user.ID(id), user.OrgID(OrgFromContext(ctx))).Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case err != nil:
+		err := fmt.Errorf("couldn't query user: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		return err
+	case !exists:
+		rec.Set("exists", false)
+		rec.Add(events.Error, ErrUserNotFound)
+		return ErrUserNotFound
+	}
+
+	rec.Set("exists", true)
+	return nil
+}
+
+func convertEmploymentEvent(row *ent.EmploymentEvent) EmploymentEvent {
+	e := EmploymentEvent{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		ActorID:   row.ActorID,
+		OldRoleID: row.OldRoleID,
+		NewRoleID: row.NewRoleID,
+		Timestamp: row.Timestamp,
+	}
+	if row.OldDepartmentID != nil {
+		e.OldDepartmentID = *row.OldDepartmentID
+	}
+	if row.NewDepartmentID != nil {
+		e.NewDepartmentID = *row.NewDepartmentID
+	}
+	return e
+}
+
+// queryEmployment queries the current role and department of a user that is not archived.
+func (s *SESC) queryEmployment(ctx context.Context, tx *ent.Tx, id UUID) (employment, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+	rec.Set("user_id", id)
+
+	statrec.Add(events.PostgresQueries, 1)
+	u, err := tx.User.Query().
+		Where(user.ID(id), user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		Select(user.FieldRoleID, user.FieldDepartmentID).
+		Only(ctx)
+
+	switch {
+	case ent.IsNotFound(err):
+		rec.Add(events.Error, ErrUserNotFound)
+		rec.Set("success", false)
+		return employment{}, ErrUserNotFound
+	case err != nil:
+		err := fmt.Errorf("couldn't query user: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return employment{}, err
+	}
+
+	e := employmentOf(u)
+	rec.Set(
+		"success", true,
+		"role_id", e.roleID,
+		"department_id", e.departmentID,
+	)
+	return e, nil
+}
+
+// recordEmploymentEvent records the change of the employment of a user on behalf of the actor in ctx.
+// Nothing is recorded if the employment has not changed.
+func (s *SESC) recordEmploymentEvent(ctx context.Context, tx *ent.Tx, id UUID, before, after employment) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	if before == after {
+		rec.Set("changed", false)
+		return nil
+	}
+	rec.Set("changed", true)
+
+	create := tx.EmploymentEvent.Create().
+		SetUserID(id).
+		SetActorID(actorFromContext(ctx)).
+		SetOldRoleID(before.roleID).
+		SetNewRoleID(after.roleID)
+	if before.departmentID != uuid.Nil {
+		create = create.SetOldDepartmentID(before.departmentID)
+	}
+	if after.departmentID != uuid.Nil {
+		create = create.SetNewDepartmentID(after.departmentID)
+	}
+
+	statrec.Add(events.PostgresQueries, 1)
+	if err := create.Exec(ctx); err != nil {
+		err := fmt.Errorf("couldn't record employment event: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
@@ -0,0 +1,132 @@
+package sesc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmploymentHistory(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID, deptID UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		dept, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.NoError(t, err)
+
+		return ctx, svc, user.ID, dept.ID
+	}
+
+	t.Run("role change", func(t *testing.T) {
+		ctx, svc, userID, _ := setup(t)
+		actorID := uuid.Must(uuid.NewV7())
+
+		require.NoError(t, svc.SetRole(WithActor(ctx, actorID), userID, ScientificDeputy.ID))
+
+		history, err := svc.EmploymentHistory(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+
+		e := history[0]
+		require.Equal(t, userID, e.UserID)
+		require.Equal(t, actorID, e.ActorID)
+		require.Equal(t, Teacher.ID, e.OldRoleID)
+		require.Equal(t, ScientificDeputy.ID, e.NewRoleID)
+		require.Equal(t, uuid.Nil, e.OldDepartmentID)
+		require.Equal(t, uuid.Nil, e.NewDepartmentID)
+		require.False(t, e.Timestamp.IsZero())
+	})
+
+	t.Run("department changes", func(t *testing.T) {
+		ctx, svc, userID, deptID := setup(t)
+
+		require.NoError(t, svc.SetDepartment(ctx, userID, deptID))
+		require.NoError(t, svc.SetDepartment(ctx, userID, uuid.Nil))
+
+		history, err := svc.EmploymentHistory(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+
+		require.Equal(t, uuid.Nil, history[0].OldDepartmentID)
+		require.Equal(t, deptID, history[0].NewDepartmentID)
+		require.Equal(t, deptID, history[1].OldDepartmentID)
+		require.Equal(t, uuid.Nil, history[1].NewDepartmentID)
+
+		require.Equal(t, uuid.Nil, history[0].ActorID, "No actor in the context")
+	})
+
+	t.Run("update user", func(t *testing.T) {
+		ctx, svc, userID, deptID := setup(t)
+
+		_, err := svc.UpdateUser(ctx, userID, UserUpdateOptions{
+			FirstName:    "John",
+			LastName:     "Doe",
+			NewRoleID:    Dephead.ID,
+			DepartmentID: deptID,
+		})
+		require.NoError(t, err)
+
+		history, err := svc.EmploymentHistory(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, Teacher.ID, history[0].OldRoleID)
+		require.Equal(t, Dephead.ID, history[0].NewRoleID)
+		require.Equal(t, deptID, history[0].NewDepartmentID)
+	})
+
+	t.Run("unchanged employment is not recorded", func(t *testing.T) {
+		ctx, svc, userID, _ := setup(t)
+
+		_, err := svc.UpdateUser(ctx, userID, UserUpdateOptions{
+			FirstName: "Johnny",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.NoError(t, err)
+		require.NoError(t, svc.SetRole(ctx, userID, Teacher.ID))
+
+		history, err := svc.EmploymentHistory(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, history)
+	})
+
+	t.Run("failed change is not recorded", func(t *testing.T) {
+		ctx, svc, userID, deptID := setup(t)
+
+		require.NoError(t, svc.SetDepartment(ctx, userID, deptID))
+		require.ErrorIs(t, svc.SetRole(ctx, userID, ContestDeputy.ID), ErrInvalidRoleChange)
+
+		history, err := svc.EmploymentHistory(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, history, 1, "Only the department assignment")
+	})
+
+	t.Run("archived user", func(t *testing.T) {
+		ctx, svc, userID, _ := setup(t)
+
+		require.NoError(t, svc.SetRole(ctx, userID, ScientificDeputy.ID))
+		require.NoError(t, svc.ArchiveUser(ctx, userID))
+
+		history, err := svc.EmploymentHistory(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _, _ := setup(t)
+
+		_, err := svc.EmploymentHistory(ctx, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
@@ -0,0 +1,28 @@
+package sesc
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// DefaultOrg is the organization of the requests that are not made on behalf of any,
+// and of the data created before organizations were introduced.
+var DefaultOrg = uuid.Nil
+
+type orgContextKey struct{}
+
+// WithOrg returns a copy of ctx, the operations with which only see and change the data of orgID.
+// Users and departments of other organizations are reported as nonexistent.
+func WithOrg(ctx context.Context, orgID UUID) context.Context {
+	return context.WithValue(ctx, orgContextKey{}, orgID)
+}
+
+// OrgFromContext returns the organization set by WithOrg, or the DefaultOrg if there is none.
+func OrgFromContext(ctx context.Context) UUID {
+	orgID, ok := ctx.Value(orgContextKey{}).(UUID)
+	if !ok {
+		return DefaultOrg
+	}
+	return orgID
+}
@@ -0,0 +1,159 @@
+package sesc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgScoping(t *testing.T) {
+	// setup creates a department with a teacher in org B, and returns the contexts of both orgs.
+	setup := func(t *testing.T) (ctxA, ctxB context.Context, svc *SESC, userB User, deptB Department) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		svc = setupSESC(t)
+
+		ctxA = WithOrg(ctx, uuid.Must(uuid.NewV7()))
+		ctxB = WithOrg(ctx, uuid.Must(uuid.NewV7()))
+
+		deptB, err := svc.CreateDepartment(ctxB, "Math", "Mathematics department")
+		require.NoError(t, err)
+
+		userB, err = svc.CreateUser(ctxB, UserUpdateOptions{
+			FirstName:    "John",
+			LastName:     "Doe",
+			NewRoleID:    Teacher.ID,
+			DepartmentID: deptB.ID,
+		})
+		require.NoError(t, err)
+
+		return ctxA, ctxB, svc, userB, deptB
+	}
+
+	t.Run("own org sees its data", func(t *testing.T) {
+		_, ctxB, svc, userB, deptB := setup(t)
+
+		got, err := svc.User(ctxB, userB.ID)
+		require.NoError(t, err)
+		require.Equal(t, deptB.ID, got.Department.ID)
+
+		users, err := svc.Users(ctxB, UserFilter{})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+	})
+
+	t.Run("users of another org are not found", func(t *testing.T) {
+		ctxA, _, svc, userB, _ := setup(t)
+
+		_, err := svc.User(ctxA, userB.ID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		exists, err := svc.UserExists(ctxA, userB.ID)
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		users, err := svc.Users(ctxA, UserFilter{})
+		require.NoError(t, err)
+		require.Empty(t, users)
+
+		_, err = svc.EmploymentHistory(ctxA, userB.ID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("users of another org cannot be changed", func(t *testing.T) {
+		ctxA, ctxB, svc, userB, _ := setup(t)
+
+		_, err := svc.UpdateUser(ctxA, userB.ID, UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.ErrorIs(t, err, ErrUserNotFound)
+		require.ErrorIs(t, svc.SetRole(ctxA, userB.ID, Dephead.ID), ErrUserNotFound)
+		_, err = svc.SetSuspended(ctxA, userB.ID, true)
+		require.ErrorIs(t, err, ErrUserNotFound)
+		require.ErrorIs(t, svc.GrantPermissions(ctxA, userB.ID, nil), ErrUserNotFound)
+		require.ErrorIs(t, svc.ArchiveUser(ctxA, userB.ID), ErrUserNotFound)
+		require.ErrorIs(t, svc.DeleteUser(ctxA, userB.ID), ErrUserNotFound)
+
+		got, err := svc.User(ctxB, userB.ID)
+		require.NoError(t, err)
+		require.Equal(t, userB.Version, got.Version, "User should not change")
+	})
+
+	t.Run("departments of another org are not found", func(t *testing.T) {
+		ctxA, _, svc, _, deptB := setup(t)
+
+		_, err := svc.DepartmentByID(ctxA, deptB.ID)
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+
+		depts, err := svc.Departments(ctxA)
+		require.NoError(t, err)
+		require.Empty(t, depts)
+
+		byIDs, err := svc.DepartmentsByIDs(ctxA, []UUID{deptB.ID})
+		require.NoError(t, err)
+		require.Empty(t, byIDs)
+
+		_, err = svc.DepartmentHead(ctxA, deptB.ID)
+		require.ErrorIs(t, err, ErrDepartmentNotFound)
+
+		require.ErrorIs(t, svc.UpdateDepartment(ctxA, deptB.ID, "Physics", ""), ErrDepartmentNotFound)
+		require.ErrorIs(t, svc.DeleteDepartment(ctxA, deptB.ID), ErrInvalidDepartment)
+	})
+
+	t.Run("departments of another org cannot be assigned", func(t *testing.T) {
+		ctxA, _, svc, _, deptB := setup(t)
+
+		_, err := svc.CreateUser(ctxA, UserUpdateOptions{
+			FirstName:    "Jane",
+			LastName:     "Doe",
+			NewRoleID:    Teacher.ID,
+			DepartmentID: deptB.ID,
+		})
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+
+		userA, err := svc.CreateUser(ctxA, UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.NoError(t, err)
+		require.ErrorIs(t, svc.SetDepartment(ctxA, userA.ID, deptB.ID), ErrInvalidDepartment)
+	})
+
+	t.Run("department names are unique within an org", func(t *testing.T) {
+		ctxA, ctxB, svc, _, deptB := setup(t)
+
+		_, err := svc.CreateDepartment(ctxB, "math", "")
+		require.ErrorIs(t, err, ErrDepartmentExists)
+
+		deptA, err := svc.CreateDepartment(ctxA, "Math", "Mathematics department")
+		require.NoError(t, err)
+		require.NotEqual(t, deptB.ID, deptA.ID)
+
+		upserted, err := svc.UpsertDepartment(ctxA, "MATH", "Upserted")
+		require.NoError(t, err)
+		require.Equal(t, deptA.ID, upserted.ID)
+	})
+
+	t.Run("no org is the default one", func(t *testing.T) {
+		_, _, svc, userB, _ := setup(t)
+		ctx, _ := event.NewRecord(t.Context(), "test")
+
+		_, err := svc.User(ctx, userB.ID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.NoError(t, err)
+
+		_, err = svc.User(WithOrg(ctx, DefaultOrg), user.ID)
+		require.NoError(t, err)
+	})
+}
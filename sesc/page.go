@@ -0,0 +1,198 @@
+package sesc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// UsersPage returns up to limit users matching the filter, except for the archived ones, ordered by ID,
+// skipping the first offset of them, and the total number of the matching users.
+// Both are read in one read-only transaction, see WithReadIsolation.
+func (s *SESC) UsersPage(ctx context.Context, offset, limit int, filter UserFilter) ([]User, int, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/users_page")
+
+	rec.Sub("params").Set(
+		"offset", offset,
+		"limit", limit,
+	)
+	filter.setParams(rec.Sub("params"))
+
+	if err := checkPage(offset, limit); err != nil {
+		rec.Add(events.Error, err)
+		return nil, 0, err
+	}
+
+	tx, err := s.beginRead(ctx)
+	if err != nil {
+		rec.Add(events.Error, err)
+		return nil, 0, err
+	}
+
+	// Stage 1: Count the users
+	ctx = rec.Sub("count_users").Wrap(ctx)
+	total, err := s.countUsers(ctx, tx, filter)
+	if err != nil {
+		return nil, 0, rollback(tx, err)
+	}
+
+	// Stage 2: Query the page of users
+	ctx = rec.Sub("query_users_page").Wrap(ctx)
+	res, err := s.queryUsersPage(ctx, tx, offset, limit, filter)
+	if err != nil {
+		return nil, 0, rollback(tx, err)
+	}
+
+	if err := commitRead(ctx, tx); err != nil {
+		rec.Add(events.Error, err)
+		return nil, 0, err
+	}
+
+	// Stage 3: Convert the users
+	ctx = rec.Sub("convert_all_users").Wrap(ctx)
+	users, err := s.convertAllUsers(ctx, res)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rec.Set(
+		"count", len(users),
+		"total", total,
+	)
+	return users, total, nil
+}
+
+// countUsers counts the users matching the filter, except for the archived ones.
+func (s *SESC) countUsers(ctx context.Context, tx *ent.Tx, filter UserFilter) (int, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	total, err := filterUsers(ctx, tx.User.Query(), filter).Count(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't count users: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return 0, err
+	}
+
+	rec.Set(
+		"success", true,
+		"total", total,
+	)
+	return total, nil
+}
+
+// queryUsersPage queries up to limit users matching the filter, ordered by ID, skipping the first offset of them.
+func (s *SESC) queryUsersPage(
+	ctx context.Context,
+	tx *ent.Tx,
+	offset, limit int,
+	filter UserFilter,
+) ([]*ent.User, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	res, err := filterUsers(ctx, tx.User.Query(), filter).
+		Order(ent.Asc(user.FieldID)).
+		Offset(offset).
+		Limit(limit).
+		WithDepartment().
+		WithPermissions(orderPermissions).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't query users: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set("success", true)
+	return res, nil
+}
+
+// DepartmentsPage returns up to limit departments ordered by name, skipping the first offset of them,
+// and the total number of the departments.
+func (s *SESC) DepartmentsPage(ctx context.Context, offset, limit int) ([]Department, int, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/departments_page")
+	statrec := event.Root(ctx).Sub("stats")
+
+	rec.Sub("params").Set(
+		"offset", offset,
+		"limit", limit,
+	)
+
+	if err := checkPage(offset, limit); err != nil {
+		rec.Add(events.Error, err)
+		return nil, 0, err
+	}
+
+	query := s.client.Department.Query().Where(department.OrgID(OrgFromContext(ctx)))
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	total, err := query.Clone().Count(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't count departments: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		return nil, 0, err
+	}
+
+	startTime = time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	res, err := query.
+		Order(ent.Asc(department.FieldName), ent.Asc(department.FieldID)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't get departments: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		return nil, 0, err
+	}
+
+	deps := make([]Department, len(res))
+	for i, r := range res {
+		deps[i] = Department{
+			ID:          r.ID,
+			Name:        r.Name,
+			Description: r.Description,
+		}
+	}
+
+	rec.Set(
+		"count", len(deps),
+		"total", total,
+	)
+	return deps, total, nil
+}
+
+// checkPage checks the offset and the limit of a page.
+func checkPage(offset, limit int) error {
+	if offset < 0 {
+		return fmt.Errorf("offset must not be negative, got %d", offset)
+	}
+	if limit <= 0 {
+		return fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	return nil
+}
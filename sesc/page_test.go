@@ -0,0 +1,105 @@
+package sesc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersPage(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, ids []UUID) {
+		ctx, _ = event.NewRecord(t.Context(), "test")
+		svc = setupSESC(t)
+
+		for i := range 5 {
+			u, err := svc.CreateUser(ctx, UserUpdateOptions{
+				FirstName: fmt.Sprintf("User%d", i+1),
+				LastName:  fmt.Sprintf("User%d", i+1),
+				NewRoleID: Teacher.ID,
+			})
+			require.NoError(t, err)
+			ids = append(ids, u.ID)
+		}
+		// UUIDv7 created within the same millisecond are not necessarily ordered.
+		slices.SortFunc(ids, func(a, b UUID) int { return bytes.Compare(a[:], b[:]) })
+
+		return ctx, svc, ids
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		ctx, svc, ids := setup(t)
+
+		users, total, err := svc.UsersPage(ctx, 0, 2, UserFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		require.Equal(t, []UUID{ids[0], ids[1]}, []UUID{users[0].ID, users[1].ID})
+
+		users, total, err = svc.UsersPage(ctx, 4, 2, UserFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		require.Len(t, users, 1)
+		require.Equal(t, ids[4], users[0].ID)
+
+		users, total, err = svc.UsersPage(ctx, 10, 2, UserFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 5, total)
+		require.Empty(t, users)
+	})
+
+	t.Run("archived and filtered users are not counted", func(t *testing.T) {
+		ctx, svc, ids := setup(t)
+
+		require.NoError(t, svc.ArchiveUser(ctx, ids[1]))
+		_, err := svc.SetSuspended(ctx, ids[2], true)
+		require.NoError(t, err)
+
+		_, total, err := svc.UsersPage(ctx, 0, 10, UserFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 4, total)
+
+		suspended := true
+		users, total, err := svc.UsersPage(ctx, 0, 10, UserFilter{Suspended: &suspended})
+		require.NoError(t, err)
+		require.Equal(t, 1, total)
+		require.Equal(t, ids[2], users[0].ID)
+	})
+
+	t.Run("invalid page", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		_, _, err := svc.UsersPage(ctx, 0, 0, UserFilter{})
+		require.Error(t, err)
+
+		_, _, err = svc.UsersPage(ctx, -1, 2, UserFilter{})
+		require.Error(t, err)
+	})
+}
+
+func TestDepartmentsPage(t *testing.T) {
+	ctx, _ := event.NewRecord(t.Context(), "test")
+	svc := setupSESC(t)
+
+	for _, name := range []string{"Physics", "Chemistry", "Math"} {
+		_, err := svc.CreateDepartment(ctx, name, "")
+		require.NoError(t, err)
+	}
+
+	deps, total, err := svc.DepartmentsPage(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, []string{"Chemistry", "Math"}, []string{deps[0].Name, deps[1].Name})
+
+	deps, total, err = svc.DepartmentsPage(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, deps, 1)
+	require.Equal(t, "Physics", deps[0].Name)
+
+	_, _, err = svc.DepartmentsPage(ctx, 0, 0)
+	require.Error(t, err)
+}
@@ -47,11 +47,8 @@ var Permissions = []Permission{
 	PermissionScientificReview,
 }
 
+// PermissionByID returns the permission with the given ID from the cache loaded by ReloadRoles.
 func PermissionByID(id int32) (Permission, bool) {
-	for _, p := range Permissions {
-		if p.ID == id {
-			return p, true
-		}
-	}
-	return Permission{}, false
+	p, ok := roles.Load().permissions[id]
+	return p, ok
 }
@@ -0,0 +1,46 @@
+package sesc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+)
+
+// Option configures the optional behavior of SESC.
+type Option func(*SESC)
+
+// WithReadIsolation sets the isolation level of the read-only transactions, sql.LevelReadCommitted by default.
+// They group the queries of a single read, like the count and the page of UsersPage or the aggregates of Stats.
+// Transactions that write are always serializable.
+//
+// With read committed, the queries of a read may see different commits, e.g. the total of UsersPage
+// may be off by the users created between the queries. Repeatable read runs them on one snapshot.
+// Serializable also checks the reads for conflicts with the concurrent writes, so they may fail
+// with serialization errors and add to the contention of the writes.
+func WithReadIsolation(level sql.IsolationLevel) Option {
+	return func(s *SESC) {
+		s.readIsolation = level
+	}
+}
+
+// beginRead starts a read-only transaction at the read isolation level.
+func (s *SESC) beginRead(ctx context.Context) (*ent.Tx, error) {
+	tx, err := s.beginTx(ctx, &sql.TxOptions{
+		Isolation: s.readIsolation,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't start read transaction: %w", WrapTimeout(ctx, err))
+	}
+	return tx, nil
+}
+
+// commitRead ends a read-only transaction started by beginRead.
+func commitRead(ctx context.Context, tx *ent.Tx) error {
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("couldn't commit read transaction: %w", WrapTimeout(ctx, err))
+	}
+	return nil
+}
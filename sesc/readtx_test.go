@@ -0,0 +1,51 @@
+package sesc
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadIsolation(t *testing.T) {
+	// setup returns a SESC recording the options of the transactions it starts.
+	setup := func(t *testing.T, opts ...Option) (context.Context, *SESC, *[]sql.TxOptions) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		svc := setupSESC(t)
+		for _, opt := range opts {
+			opt(svc)
+		}
+
+		var started []sql.TxOptions
+		begin := svc.beginTx
+		svc.beginTx = func(ctx context.Context, opts *sql.TxOptions) (*ent.Tx, error) {
+			started = append(started, *opts)
+			return begin(ctx, opts)
+		}
+		return ctx, svc, &started
+	}
+
+	t.Run("read committed by default", func(t *testing.T) {
+		ctx, svc, started := setup(t)
+
+		_, _, err := svc.UsersPage(ctx, 0, 10, UserFilter{})
+		require.NoError(t, err)
+		require.Equal(t, []sql.TxOptions{{Isolation: sql.LevelReadCommitted, ReadOnly: true}}, *started)
+	})
+
+	t.Run("configured level", func(t *testing.T) {
+		ctx, svc, started := setup(t, WithReadIsolation(sql.LevelRepeatableRead))
+
+		_, _, err := svc.UsersPage(ctx, 0, 10, UserFilter{})
+		require.NoError(t, err)
+		_, err = svc.Stats(ctx)
+		require.NoError(t, err)
+
+		want := sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+		require.Equal(t, []sql.TxOptions{want, want}, *started)
+	})
+
+}
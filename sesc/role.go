@@ -1,15 +1,24 @@
 //nolint:mnd // the only magic numbers here are ids
 package sesc
 
-import "github.com/kozlov-ma/sesc-backend/pkg/event"
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+)
 
 // Role is a standartized set of Permissions granted to a User influenced
 // by their role in the organization.
 //
-// Roles are predefined in this file.
+// Roles are predefined in this file. A Role may inherit the permissions of a parent Role,
+// which may in turn have its own parent.
 type Role struct {
-	ID          int32
-	Name        string
+	ID   int32
+	Name string
+	// ParentID is the ID of the Role whose permissions this one inherits, or 0 if there is none.
+	ParentID int32
+	// Permissions are the ones granted by the Role itself, see EffectivePermissions for the inherited ones.
 	Permissions []Permission
 }
 
@@ -20,17 +29,61 @@ func (r Role) EventRecord() *event.Record {
 	)
 }
 
+// CanHaveDepartment reports whether users with the role may belong to a department.
+// Only teachers and department heads do.
+func (r Role) CanHaveDepartment() bool {
+	return r.ID == Teacher.ID || r.ID == Dephead.ID
+}
+
 func (r Role) HasPermission(p Permission) bool {
 	return r.HasPermissionWithID(p.ID)
 }
 
+// HasPermissionWithID reports whether the Role grants the permission, itself or through its parents.
 func (r Role) HasPermissionWithID(id int32) bool {
-	for _, p := range r.Permissions {
-		if p.ID == id {
-			return true
+	return slices.ContainsFunc(r.EffectivePermissions(), func(p Permission) bool {
+		return p.ID == id
+	})
+}
+
+// EffectivePermissions returns the permissions granted by the Role followed by the ones it inherits,
+// nearest parent first, each listed once.
+func (r Role) EffectivePermissions() []Permission {
+	c := roles.Load()
+	if cached, ok := c.rolesByID[r.ID]; ok && cached.ParentID == r.ParentID && slices.Equal(cached.Permissions, r.Permissions) {
+		return slices.Clone(c.effective[r.ID])
+	}
+	// The cached roles are checked when loaded, so there are no cycles or unknown parents.
+	perms, _ := effectivePermissions(c.roles, r)
+	return perms
+}
+
+// effectivePermissions walks the parents of role among roles, collecting the permissions.
+// Returns an error if a parent does not exist or the role inherits from itself.
+func effectivePermissions(roles []Role, role Role) ([]Permission, error) {
+	var perms []Permission
+	visited := map[int32]bool{role.ID: true}
+	for {
+		for _, p := range role.Permissions {
+			if !slices.ContainsFunc(perms, func(q Permission) bool { return q.ID == p.ID }) {
+				perms = append(perms, p)
+			}
+		}
+
+		if role.ParentID == 0 {
+			return perms, nil
+		}
+		if visited[role.ParentID] {
+			return nil, fmt.Errorf("role %d inherits from itself", role.ParentID)
 		}
+		visited[role.ParentID] = true
+
+		i := slices.IndexFunc(roles, func(r Role) bool { return r.ID == role.ParentID })
+		if i < 0 {
+			return nil, fmt.Errorf("role %d inherits from unknown role %d", role.ID, role.ParentID)
+		}
+		role = roles[i]
 	}
-	return false
 }
 
 var (
@@ -42,8 +95,9 @@ var (
 		},
 	}
 	Dephead = Role{
-		ID:   2,
-		Name: "Заведующий кафедрой",
+		ID:       2,
+		Name:     "Заведующий кафедрой",
+		ParentID: Teacher.ID,
 		Permissions: []Permission{
 			PermissionDepheadReview,
 		},
@@ -79,11 +133,29 @@ var Roles = []Role{
 	DevelopmentDeputy,
 }
 
+// RoleByID returns the role with the given ID from the cache loaded by ReloadRoles.
 func RoleByID(id int32) (Role, bool) {
-	for _, r := range Roles {
-		if r.ID == id {
-			return r, true
-		}
+	r, ok := roles.Load().rolesByID[id]
+	if !ok {
+		return Role{}, false
+	}
+	r.Permissions = slices.Clone(r.Permissions)
+	return r, true
+}
+
+// PermissionsForRole returns the permissions granted by the role with the given ID, inherited ones included.
+// Returns an ErrInvalidRole if there is no such role.
+func PermissionsForRole(roleID int32) ([]Permission, error) {
+	return EffectivePermissions(roleID)
+}
+
+// EffectivePermissions returns the permissions granted by the role with the given ID
+// followed by the ones it inherits from its parents, each listed once.
+// Returns an ErrInvalidRole if there is no such role.
+func EffectivePermissions(roleID int32) ([]Permission, error) {
+	role, ok := RoleByID(roleID)
+	if !ok {
+		return nil, ErrInvalidRole
 	}
-	return Role{}, false
+	return role.EffectivePermissions(), nil
 }
@@ -0,0 +1,68 @@
+package sesc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectivePermissions(t *testing.T) {
+	t.Run("dephead inherits teacher", func(t *testing.T) {
+		perms, err := EffectivePermissions(Dephead.ID)
+		require.NoError(t, err)
+		require.Equal(t, []Permission{PermissionDepheadReview, PermissionDraftAchievementList}, perms)
+
+		require.True(t, Dephead.HasPermission(PermissionDraftAchievementList))
+		require.True(t, Dephead.HasPermission(PermissionDepheadReview))
+		require.False(t, Teacher.HasPermission(PermissionDepheadReview), "Inheritance goes one way")
+	})
+
+	t.Run("user permissions include inherited ones", func(t *testing.T) {
+		user := User{Role: Dephead, ExtraPermissions: []Permission{PermissionDraftAchievementList, PermissionContestReview}}
+		require.Equal(t, []Permission{
+			PermissionDepheadReview,
+			PermissionDraftAchievementList,
+			PermissionContestReview,
+		}, user.Permissions())
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		_, err := EffectivePermissions(0)
+		require.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("deduplicated", func(t *testing.T) {
+		roles := []Role{
+			{ID: 1, Permissions: []Permission{PermissionDraftAchievementList}},
+			{ID: 2, ParentID: 1, Permissions: []Permission{PermissionDepheadReview}},
+			{ID: 3, ParentID: 2, Permissions: []Permission{PermissionDraftAchievementList, PermissionContestReview}},
+		}
+
+		perms, err := effectivePermissions(roles, roles[2])
+		require.NoError(t, err)
+		require.Equal(t, []Permission{
+			PermissionDraftAchievementList,
+			PermissionContestReview,
+			PermissionDepheadReview,
+		}, perms)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		roles := []Role{
+			{ID: 1, ParentID: 3},
+			{ID: 2, ParentID: 1},
+			{ID: 3, ParentID: 2},
+		}
+
+		_, err := effectivePermissions(roles, roles[0])
+		require.ErrorContains(t, err, "inherits from itself")
+
+		_, err = effectivePermissions(roles[:1], Role{ID: 4, ParentID: 4})
+		require.ErrorContains(t, err, "inherits from itself")
+	})
+
+	t.Run("unknown parent", func(t *testing.T) {
+		_, err := effectivePermissions(nil, Role{ID: 1, ParentID: 2})
+		require.ErrorContains(t, err, "unknown role 2")
+	})
+}
@@ -0,0 +1,97 @@
+package sesc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// roleCache is an immutable snapshot of the roles and the permissions indexed by ID,
+// with the effective permissions of every role computed in advance.
+type roleCache struct {
+	roles       []Role
+	rolesByID   map[int32]Role
+	permissions map[int32]Permission
+	effective   map[int32][]Permission
+}
+
+// roles serves the lookups of the roles and the permissions, it is replaced as a whole by ReloadRoles.
+var roles atomic.Pointer[roleCache]
+
+// newRoleCache indexes copies of the roles and the permissions.
+// Returns an error if a role inherits from an unknown role or from itself.
+func newRoleCache(rs []Role, ps []Permission) (*roleCache, error) {
+	c := &roleCache{
+		roles:       make([]Role, len(rs)),
+		rolesByID:   make(map[int32]Role, len(rs)),
+		permissions: make(map[int32]Permission, len(ps)),
+		effective:   make(map[int32][]Permission, len(rs)),
+	}
+
+	for i, r := range rs {
+		r.Permissions = slices.Clone(r.Permissions)
+		c.roles[i] = r
+		c.rolesByID[r.ID] = r
+	}
+	for _, p := range ps {
+		c.permissions[p.ID] = p
+	}
+	for _, r := range c.roles {
+		perms, err := effectivePermissions(c.roles, r)
+		if err != nil {
+			return nil, err
+		}
+		c.effective[r.ID] = perms
+	}
+
+	return c, nil
+}
+
+// init loads the predefined roles, so that a broken definition fails at startup.
+func init() {
+	c, err := newRoleCache(Roles, Permissions)
+	if err != nil {
+		panic(fmt.Sprintf("invalid role definitions: %v", err))
+	}
+	roles.Store(c)
+}
+
+// ReloadRoles replaces the cached roles and permissions with the current Roles and Permissions.
+// Lookups like RoleByID are served from the cache, so changes to Roles and Permissions only show after a reload.
+// If the roles are invalid, the cache is kept and an error is returned.
+func ReloadRoles(ctx context.Context) error {
+	rec := event.Get(ctx).Sub("sesc/reload_roles")
+
+	c, err := newRoleCache(Roles, Permissions)
+	if err != nil {
+		err := fmt.Errorf("couldn't reload roles: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+	roles.Store(c)
+
+	rec.Set(
+		"success", true,
+		"roles", len(c.roles),
+		"permissions", len(c.permissions),
+	)
+	return nil
+}
+
+// roleByID is RoleByID, counting the lookup in the stats of the root record of ctx.
+func roleByID(ctx context.Context, id int32) (Role, bool) {
+	role, ok := RoleByID(id)
+
+	statrec := event.Root(ctx).Sub("stats")
+	if ok {
+		statrec.Add(events.RoleCacheHits, 1)
+	} else {
+		statrec.Add(events.RoleCacheMisses, 1)
+	}
+	return role, ok
+}
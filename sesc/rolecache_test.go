@@ -0,0 +1,106 @@
+package sesc
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadRoles(t *testing.T) {
+	// setup restores the predefined roles after the test changes them.
+	setup := func(t *testing.T) {
+		t.Helper()
+		saved := slices.Clone(Roles)
+		t.Cleanup(func() {
+			Roles = saved
+			ctx, _ := event.NewRecord(t.Context(), "cleanup")
+			require.NoError(t, ReloadRoles(ctx))
+		})
+	}
+
+	t.Run("lookups are served from the cache", func(t *testing.T) {
+		setup(t)
+		Roles = slices.DeleteFunc(slices.Clone(Roles), func(r Role) bool { return r.ID == Teacher.ID })
+
+		role, ok := RoleByID(Teacher.ID)
+		require.True(t, ok, "Changes show only after a reload")
+		require.Equal(t, Teacher, role)
+
+		perms, err := EffectivePermissions(Dephead.ID)
+		require.NoError(t, err)
+		require.Equal(t, []Permission{PermissionDepheadReview, PermissionDraftAchievementList}, perms)
+	})
+
+	t.Run("reload picks up changes", func(t *testing.T) {
+		setup(t)
+		renamed := Teacher
+		renamed.Name = "Преподаватель"
+		renamed.Permissions = []Permission{PermissionDraftAchievementList, PermissionContestReview}
+		Roles = slices.Clone(Roles)
+		Roles[slices.IndexFunc(Roles, func(r Role) bool { return r.ID == Teacher.ID })] = renamed
+
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		require.NoError(t, ReloadRoles(ctx))
+		require.Equal(t, true, event.Root(ctx).Value("sesc/reload_roles.success"))
+
+		role, ok := RoleByID(Teacher.ID)
+		require.True(t, ok)
+		require.Equal(t, renamed, role)
+
+		perms, err := EffectivePermissions(Dephead.ID)
+		require.NoError(t, err)
+		require.Equal(t, []Permission{
+			PermissionDepheadReview,
+			PermissionDraftAchievementList,
+			PermissionContestReview,
+		}, perms, "Inherited permissions are recomputed")
+	})
+
+	t.Run("invalid roles keep the cache", func(t *testing.T) {
+		setup(t)
+		Roles = append(slices.Clone(Roles), Role{ID: 100, ParentID: 100})
+
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		require.ErrorContains(t, ReloadRoles(ctx), "inherits from itself")
+		require.Equal(t, false, event.Root(ctx).Value("sesc/reload_roles.success"))
+
+		_, ok := RoleByID(100)
+		require.False(t, ok)
+		_, ok = RoleByID(Teacher.ID)
+		require.True(t, ok)
+	})
+
+	t.Run("returned roles can't change the cache", func(t *testing.T) {
+		role, ok := RoleByID(Dephead.ID)
+		require.True(t, ok)
+		role.Permissions[0] = PermissionContestReview
+
+		role, ok = RoleByID(Dephead.ID)
+		require.True(t, ok)
+		require.Equal(t, Dephead, role)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		svc := setupSESC(t)
+
+		for range 3 {
+			_, err := svc.CreateUser(ctx, UserUpdateOptions{
+				FirstName: "John",
+				LastName:  "Doe",
+				NewRoleID: Teacher.ID,
+			})
+			require.NoError(t, err)
+		}
+
+		ctx, _ = event.NewRecord(t.Context(), "test")
+		users, err := svc.Users(ctx, UserFilter{})
+		require.NoError(t, err)
+		require.Len(t, users, 3)
+		require.Equal(t, 3, event.Root(ctx).Value("stats."+events.RoleCacheHits))
+		require.Nil(t, event.Root(ctx).Value("stats."+events.RoleCacheMisses))
+	})
+}
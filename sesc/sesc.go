@@ -5,11 +5,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/userpermission"
+	"github.com/kozlov-ma/sesc-backend/db/txretry"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
 	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
 )
@@ -19,6 +23,11 @@ type UUID = uuid.UUID
 // SESC represents the organization's structure and provides methods to interact with it.
 type SESC struct {
 	client *ent.Client
+
+	// readIsolation is the isolation level of the read-only transactions, see WithReadIsolation.
+	readIsolation sql.IsolationLevel
+	// beginTx starts the read-only transactions. It is client.BeginTx, tests replace it to see the options.
+	beginTx func(ctx context.Context, opts *sql.TxOptions) (*ent.Tx, error)
 }
 
 // rollback calls to tx.Rollback and wraps the given error
@@ -30,7 +39,15 @@ func rollback(tx *ent.Tx, err error) error {
 	return err
 }
 
-func convertUser(u *ent.User) (User, error) {
+// emailOf returns the email of a user record, or an empty string if it is not set.
+func emailOf(u *ent.User) string {
+	if u.Email == nil {
+		return ""
+	}
+	return *u.Email
+}
+
+func convertUser(ctx context.Context, u *ent.User) (User, error) {
 	var dept Department
 	dep := u.Edges.Department
 	if dep != nil {
@@ -41,7 +58,7 @@ func convertUser(u *ent.User) (User, error) {
 		}
 	}
 
-	role, ok := RoleByID(u.RoleID)
+	role, ok := roleByID(ctx, u.RoleID)
 	if !ok {
 		return User{}, ErrInvalidRole
 	}
@@ -52,20 +69,85 @@ func convertUser(u *ent.User) (User, error) {
 		LastName:   u.LastName,
 		MiddleName: u.MiddleName,
 		PictureURL: u.PictureURL,
+		Email:      emailOf(u),
 		Suspended:  u.Suspended,
 		Department: dept,
 		Role:       role,
+
+		ExtraPermissions: convertExtraPermissions(u.Edges.Permissions),
+
+		Subdivision:       u.Subdivision,
+		JobTitle:          u.JobTitle,
+		EmploymentRate:    u.EmploymentRate,
+		PersonnelCategory: PersonnelCategory(u.PersonnelCategory),
+		EmploymentType:    EmploymentType(u.EmploymentType),
+		AcademicDegree:    AcademicDegree(u.AcademicDegree),
+		AcademicTitle:     u.AcademicTitle,
+		Honors:            u.Honors,
+		Category:          u.Category,
+		DateOfEmployment:  u.DateOfEmployment,
+		UnemploymentDate:  u.UnemploymentDate,
+
+		DeletedAt: u.DeletedAt,
+		Version:   u.Version,
 	}, nil
 }
 
-func New(client *ent.Client) *SESC {
-	return &SESC{
-		client: client,
+// convertExtraPermissions converts the permissions granted to a user.
+// Grants of permissions missing from the catalog are skipped.
+func convertExtraPermissions(grants []*ent.UserPermission) []Permission {
+	var perms []Permission
+	for _, g := range grants {
+		if p, ok := PermissionByID(g.PermissionID); ok {
+			perms = append(perms, p)
+		}
+	}
+	return perms
+}
+
+// orderPermissions makes the granted permissions of a user load in a stable order.
+func orderPermissions(q *ent.UserPermissionQuery) {
+	q.Order(ent.Asc(userpermission.FieldPermissionID))
+}
+
+func New(client *ent.Client, opts ...Option) *SESC {
+	s := &SESC{
+		client:        client,
+		readIsolation: sql.LevelReadCommitted,
+		beginTx:       client.BeginTx,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Ping checks that the database is reachable by running a lightweight query.
+func (s *SESC) Ping(ctx context.Context) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/ping")
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	_, err := s.client.Department.Query().Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't ping database: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
 	}
+
+	rec.Set("success", true)
+	return nil
 }
 
 // CreateDepartment creates a new department with auto-generated ID.
-// Returns an ErrInvalidDepartment if department already exists.
+// Returns an ErrDepartmentExists if a department with the same name, ignoring case and spaces, already exists,
+// or an ErrInvalidDepartmentName if the name is blank or the name or description is too long.
 func (s *SESC) CreateDepartment(
 	ctx context.Context,
 	name string,
@@ -81,16 +163,22 @@ func (s *SESC) CreateDepartment(
 		"description", description,
 	)
 
-	// Stage 1: Generate UUID
+	// Stage 1: Validate department input
+	ctx = rec.Sub("validate_department_input").Wrap(ctx)
+	if err := s.validateDepartmentInput(ctx, name, description); err != nil {
+		return NoDepartment, err
+	}
+
+	// Stage 2: Generate UUID
 	ctx = rec.Sub("generate_department_id").Wrap(ctx)
 	id, err := s.generateDepartmentID(ctx)
 	if err != nil {
 		return NoDepartment, err
 	}
 
-	// Stage 2: Create department record
+	// Stage 3: Create department record
 	ctx = rec.Sub("create_department_record").Wrap(ctx)
-	department, err := s.createDepartmentRecord(ctx, statrec, id, name, description)
+	department, err := s.createDepartmentRecord(ctx, statrec, s.client.Department, id, name, description)
 	if ent.IsValidationError(err) {
 		return NoDepartment, ErrInvalidDepartmentName
 	}
@@ -101,6 +189,118 @@ func (s *SESC) CreateDepartment(
 	return department, nil
 }
 
+// CreateDepartmentWithHead creates a new department together with its head in a single transaction.
+// The head is always created with the Dephead role and linked to the new department,
+// regardless of the NewRoleID and DepartmentID set in head.
+//
+// Neither the department nor the head is created if any of the steps fails.
+// Returns an ErrDepartmentExists if a department with the same name, ignoring case and spaces, already exists,
+// an ErrInvalidDepartmentName if the name or description is invalid,
+// and an ErrInvalidUserName if the head's first or last name is missing.
+func (s *SESC) CreateDepartmentWithHead(
+	ctx context.Context,
+	name string,
+	description string,
+	head UserUpdateOptions,
+) (Department, User, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/create_department_with_head")
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	head.NewRoleID = Dephead.ID
+	head.DepartmentID = uuid.Nil
+
+	rec.Sub("params").Set(
+		"name", name,
+		"description", description,
+		"head_first_name", head.FirstName,
+		"head_last_name", head.LastName,
+		"head_middle_name", head.MiddleName,
+	)
+
+	// Stage 1: Validate department input
+	ctx = rec.Sub("validate_department_input").Wrap(ctx)
+	if err := s.validateDepartmentInput(ctx, name, description); err != nil {
+		return NoDepartment, User{}, err
+	}
+
+	// Stage 2: Validate head input
+	ctx = rec.Sub("validate_create_input").Wrap(ctx)
+	if err := s.validateCreateInput(ctx, head); err != nil {
+		return NoDepartment, User{}, err
+	}
+
+	// Stage 3: Generate UUID
+	ctx = rec.Sub("generate_department_id").Wrap(ctx)
+	id, err := s.generateDepartmentID(ctx)
+	if err != nil {
+		return NoDepartment, User{}, err
+	}
+
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		err := fmt.Errorf("couldn't begin transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return NoDepartment, User{}, err
+	}
+
+	// Stage 4: Create department record
+	ctx = rec.Sub("create_department_record").Wrap(ctx)
+	department, err := s.createDepartmentRecord(ctx, statrec, tx.Department, id, name, description)
+	if ent.IsValidationError(err) {
+		return NoDepartment, User{}, rollback(tx, ErrInvalidDepartmentName)
+	}
+	if err != nil {
+		return NoDepartment, User{}, rollback(tx, err)
+	}
+
+	// Stage 5: Get created department
+	ctx = rec.Sub("check_department").Wrap(ctx)
+	dept, err := s.checkAndGetDepartment(ctx, statrec, tx, department.ID)
+	if err != nil {
+		return NoDepartment, User{}, rollback(tx, err)
+	}
+
+	// Stage 6: Create head user record
+	ctx = rec.Sub("create_user_record").Wrap(ctx)
+	userID, err := s.createUserRecord(ctx, statrec, tx, head, dept)
+	if err != nil {
+		return NoDepartment, User{}, rollback(tx, err)
+	}
+
+	// Stage 7: Query created head
+	ctx = rec.Sub("query_created_user").Wrap(ctx)
+	us, err := s.queryCreatedUser(ctx, statrec, tx, userID)
+	if err != nil {
+		return NoDepartment, User{}, rollback(tx, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return NoDepartment, User{}, err
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+
+	// Stage 8: Convert user entity to domain object
+	ctx = rec.Sub("convert_user").Wrap(ctx)
+	user, err := s.convertUserEntity(ctx, us)
+	if err != nil {
+		return NoDepartment, User{}, err
+	}
+
+	rec.Set("success", true)
+	rec.Set("user", user.EventRecord())
+	return department, user, nil
+}
+
 // generateDepartmentID generates a UUID for a new department
 func (s *SESC) generateDepartmentID(ctx context.Context) (UUID, error) {
 	rec := event.Get(ctx)
@@ -121,6 +321,7 @@ func (s *SESC) generateDepartmentID(ctx context.Context) (UUID, error) {
 func (s *SESC) createDepartmentRecord(
 	ctx context.Context,
 	statrec *event.Record,
+	departments *ent.DepartmentClient,
 	id UUID,
 	name string,
 	description string,
@@ -130,18 +331,21 @@ func (s *SESC) createDepartmentRecord(
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	res, err := s.client.Department.Create().
+	res, err := departments.Create().
 		SetID(id).
+		SetOrgID(OrgFromContext(ctx)).
 		SetName(name).
+		SetNormalizedName(NormalizeDepartmentName(name)).
 		SetDescription(description).
 		Save(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
 	case ent.IsConstraintError(err):
+		joinedErr := fmt.Errorf("%w: %w", err, ErrDepartmentExists)
 		rec.Set("success", false)
-		rec.Add(events.Error, ErrInvalidDepartment)
-		return NoDepartment, ErrInvalidDepartment
+		rec.Add(events.Error, joinedErr)
+		return NoDepartment, joinedErr
 	case err != nil:
 		err := fmt.Errorf("couldn't save department: %w", err)
 		rec.Add(events.Error, err)
@@ -175,14 +379,16 @@ func (s *SESC) DepartmentByID(ctx context.Context, id UUID) (Department, error)
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	res, err := s.client.Department.Get(ctx, id)
+	res, err := s.client.Department.Query().
+		Where(department.ID(id), department.OrgID(OrgFromContext(ctx))).
+		Only(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
 	case ent.IsNotFound(err):
 		return NoDepartment, ErrInvalidDepartment
 	case err != nil:
-		err := fmt.Errorf("couldn't get department: %w", err)
+		err := fmt.Errorf("couldn't get department: %w", WrapTimeout(ctx, err))
 		rec.Add(events.Error, err)
 		return NoDepartment, err
 	}
@@ -209,11 +415,11 @@ func (s *SESC) Departments(ctx context.Context) ([]Department, error) {
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	res, err := s.client.Department.Query().All(ctx)
+	res, err := s.client.Department.Query().Where(department.OrgID(OrgFromContext(ctx))).All(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	if err != nil {
-		err := fmt.Errorf("couldn't get all departments: %w", err)
+		err := fmt.Errorf("couldn't get all departments: %w", WrapTimeout(ctx, err))
 		rec.Add(events.Error, err)
 		return nil, err
 	}
@@ -230,8 +436,50 @@ func (s *SESC) Departments(ctx context.Context) ([]Department, error) {
 	return deps, nil
 }
 
+// DepartmentsByIDs returns the departments with the given IDs in a single query.
+// IDs of the departments that do not exist are absent from the map.
+func (s *SESC) DepartmentsByIDs(ctx context.Context, ids []UUID) (map[UUID]Department, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/departments_by_ids")
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	rec.Sub("params").Set("ids", ids)
+
+	deps := make(map[UUID]Department, len(ids))
+	if len(ids) == 0 {
+		return deps, nil
+	}
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	res, err := s.client.Department.Query().
+		Where(department.IDIn(ids...), department.OrgID(OrgFromContext(ctx))).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't get departments: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		return nil, err
+	}
+
+	for _, r := range res {
+		deps[r.ID] = Department{
+			ID:          r.ID,
+			Name:        r.Name,
+			Description: r.Description,
+		}
+	}
+
+	rec.Set("found", len(deps))
+	return deps, nil
+}
+
 // UpdateDepartment updates a department.
-// Returns an ErrInvalidDepartment if the department does not exist.
+// Returns an ErrDepartmentNotFound if the department does not exist.
+// Returns an ErrDepartmentExists if another department already has the new name, ignoring case and spaces,
+// or an ErrInvalidDepartmentName if the name is blank or the name or description is too long.
 func (s *SESC) UpdateDepartment(
 	ctx context.Context,
 	id UUID,
@@ -249,7 +497,13 @@ func (s *SESC) UpdateDepartment(
 		"description", description,
 	)
 
-	// Stage 1: Update department record
+	// Stage 1: Validate department input
+	ctx = rec.Sub("validate_department_input").Wrap(ctx)
+	if err := s.validateDepartmentInput(ctx, name, description); err != nil {
+		return err
+	}
+
+	// Stage 2: Update department record
 	ctx = rec.Sub("update_department_record").Wrap(ctx)
 	if err := s.updateDepartmentRecord(ctx, statrec, id, name, description); err != nil {
 		return err
@@ -272,12 +526,27 @@ func (s *SESC) updateDepartmentRecord(
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	err := s.client.Department.UpdateOneID(id).SetName(name).SetDescription(description).Exec(ctx)
+	err := s.client.Department.UpdateOneID(id).
+		Where(department.OrgID(OrgFromContext(ctx))).
+		SetName(name).
+		SetNormalizedName(NormalizeDepartmentName(name)).
+		SetDescription(description).
+		Exec(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
 	case ent.IsNotFound(err):
-		joinedErr := fmt.Errorf("%w: %w", err, ErrInvalidDepartment)
+		joinedErr := fmt.Errorf("%w: %w", err, ErrDepartmentNotFound)
+		rec.Add(events.Error, joinedErr)
+		rec.Set("success", false)
+		return joinedErr
+	case ent.IsConstraintError(err):
+		joinedErr := fmt.Errorf("%w: %w", err, ErrDepartmentExists)
+		rec.Add(events.Error, joinedErr)
+		rec.Set("success", false)
+		return joinedErr
+	case ent.IsValidationError(err):
+		joinedErr := fmt.Errorf("%w: %w", err, ErrInvalidDepartmentName)
 		rec.Add(events.Error, joinedErr)
 		rec.Set("success", false)
 		return joinedErr
@@ -292,130 +561,1398 @@ func (s *SESC) updateDepartmentRecord(
 	return nil
 }
 
-// DeleteDepartment deletes a department by ID.
-// Returns an ErrInvalidDepartment if the department does not exist.
-// Returns an ErrCannotRemoveDepartment if the department has users.
-func (s *SESC) DeleteDepartment(ctx context.Context, id UUID) error {
+// UpsertDepartment creates a new department, or updates the description of the department with the same name,
+// ignoring case and spaces.
+// Returns an ErrInvalidDepartmentName if the name is blank or the name or description is too long.
+func (s *SESC) UpsertDepartment(
+	ctx context.Context,
+	name string,
+	description string,
+) (Department, error) {
 	// Caller should create the record and use Wrap to add it to the context
-	rec := event.Get(ctx).Sub("sesc/delete_department")
-
-	rec.Sub("params").Set("id", id)
-
-	// Stage 1: Delete department record
-	ctx = rec.Sub("delete_department_record").Wrap(ctx)
-	if err := s.deleteDepartmentRecord(ctx, id); err != nil {
-		return err
-	}
-
-	rec.Set("success", true)
-	return nil
-}
-
-// deleteDepartmentRecord deletes a department record from the database
-func (s *SESC) deleteDepartmentRecord(ctx context.Context, id UUID) error {
-	rec := event.Get(ctx)
+	rec := event.Get(ctx).Sub("sesc/upsert_department")
 	rootRec := event.Root(ctx)
 	statrec := rootRec.Sub("stats")
 
-	rec.Set("id", id)
+	rec.Sub("params").Set(
+		"name", name,
+		"description", description,
+	)
 
-	startTime := time.Now()
-	statrec.Add(events.PostgresQueries, 1)
-	err := s.client.Department.DeleteOneID(id).Exec(ctx)
-	statrec.Add(events.PostgresTime, time.Since(startTime))
+	// Stage 1: Validate department input
+	ctx = rec.Sub("validate_department_input").Wrap(ctx)
+	if err := s.validateDepartmentInput(ctx, name, description); err != nil {
+		return NoDepartment, err
+	}
 
-	switch {
-	case ent.IsConstraintError(err):
-		rec.Add(events.Error, ErrCannotRemoveDepartment)
-		rec.Set("success", false)
-		return ErrCannotRemoveDepartment
-	case ent.IsNotFound(err):
-		rec.Add(events.Error, ErrInvalidDepartment)
-		rec.Set("success", false)
-		return ErrInvalidDepartment
-	case err != nil:
-		err := fmt.Errorf("couldn't delete department: %w", err)
-		rec.Add(events.Error, err)
-		rec.Set("success", false)
+	// Stage 2: Generate UUID, used if the department does not exist
+	ctx = rec.Sub("generate_department_id").Wrap(ctx)
+	id, err := s.generateDepartmentID(ctx)
+	if err != nil {
+		return NoDepartment, err
+	}
+
+	// Stages 3-4: Create or update the department in a transaction, retried on serialization failures
+	var department Department
+	err = txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		var err error
+		department, err = s.upsertDepartmentTx(ctx, rec, statrec, id, name, description)
 		return err
+	})
+	if ent.IsValidationError(err) {
+		return NoDepartment, ErrInvalidDepartmentName
+	}
+	if err != nil {
+		return NoDepartment, err
 	}
 
 	rec.Set("success", true)
-	return nil
+	return department, nil
 }
 
-func (s *SESC) newUUID() (UUID, error) {
-	id, err := uuid.NewV7()
+// upsertDepartmentTx creates the department with the given id,
+// or updates the description of the one with the same name, in a serializable transaction.
+func (s *SESC) upsertDepartmentTx(
+	ctx context.Context,
+	rec *event.Record,
+	statrec *event.Record,
+	id UUID,
+	name string,
+	description string,
+) (Department, error) {
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
-		return id, fmt.Errorf("couldn't create UUID: %w", err)
+		err := fmt.Errorf("couldn't begin transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return NoDepartment, err
 	}
 
-	return id, nil
-}
-
-// UserUpdateOptions represents the options for updating a user.
-type UserUpdateOptions struct {
-	FirstName    string
-	LastName     string
-	MiddleName   string
-	PictureURL   string
-	Suspended    bool
-	DepartmentID UUID
-	NewRoleID    int32
-}
+	// Stage 3: Query department by name
+	ctx = rec.Sub("query_department_by_name").Wrap(ctx)
+	existing, err := s.queryDepartmentByName(ctx, statrec, tx, name)
+	if err != nil {
+		return NoDepartment, rollback(tx, err)
+	}
 
-func (u UserUpdateOptions) Validate() error {
-	if u.FirstName == "" || u.LastName == "" {
-		return ErrInvalidUserName
+	var department Department
+	if existing == nil {
+		// Stage 4: Create department record
+		ctx = rec.Sub("create_department_record").Wrap(ctx)
+		department, err = s.createDepartmentRecord(ctx, statrec, tx.Department, id, name, description)
+	} else {
+		// Stage 4: Update department description
+		ctx = rec.Sub("update_department_description").Wrap(ctx)
+		department, err = s.updateDepartmentDescription(ctx, statrec, tx, existing, description)
+	}
+	if err != nil {
+		return NoDepartment, rollback(tx, err)
 	}
 
-	if _, ok := RoleByID(u.NewRoleID); !ok {
-		return ErrInvalidRole
+	err = tx.Commit()
+	if err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return NoDepartment, err
 	}
 
-	return nil
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+	rec.Set("created", existing == nil)
+	return department, nil
 }
 
-// UpdateUser updates user with the new fields.
-//
-// Returns an ErrInvalidRole if the new role id is invalid.
-// Returns an ErrInvalidName if the first or last name is missing.
-// Returns an ErrUserNotFound if the user does not exist.
+// queryDepartmentByName queries a department by name, returning nil if there is no such department
+func (s *SESC) queryDepartmentByName(
+	ctx context.Context,
+	statrec *event.Record,
+	tx *ent.Tx,
+	name string,
+) (*ent.Department, error) {
+	rec := event.Get(ctx)
+	rec.Set("name", name)
+
+	statrec.Add(events.PostgresQueries, 1)
+	dept, err := tx.Department.Query().
+		Where(
+			department.OrgID(OrgFromContext(ctx)),
+			department.NormalizedName(NormalizeDepartmentName(name)),
+		).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		rec.Set("exists", false)
+		//nolint:nilnil // a missing department is not an error, it is created instead.
+		return nil, nil
+	case err != nil:
+		err := fmt.Errorf("couldn't query department: %w", err)
+		rec.Add(events.Error, err)
+		return nil, err
+	}
+
+	rec.Set(
+		"exists", true,
+		"id", dept.ID,
+	)
+	return dept, nil
+}
+
+// updateDepartmentDescription updates the description of an existing department record
+func (s *SESC) updateDepartmentDescription(
+	ctx context.Context,
+	statrec *event.Record,
+	tx *ent.Tx,
+	dept *ent.Department,
+	description string,
+) (Department, error) {
+	rec := event.Get(ctx)
+	rec.Set("id", dept.ID)
+
+	statrec.Add(events.PostgresQueries, 1)
+	res, err := tx.Department.UpdateOne(dept).SetDescription(description).Save(ctx)
+	if err != nil {
+		err := fmt.Errorf("couldn't update department: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return NoDepartment, err
+	}
+
+	rec.Set("success", true)
+	return Department{
+		ID:          res.ID,
+		Name:        res.Name,
+		Description: res.Description,
+	}, nil
+}
+
+// DeleteDepartment deletes a department by ID.
+// Returns an ErrInvalidDepartment if the department does not exist.
+// Returns an ErrCannotRemoveDepartment if the department has users.
+func (s *SESC) DeleteDepartment(ctx context.Context, id UUID) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/delete_department")
+
+	rec.Sub("params").Set("id", id)
+
+	// Stage 1: Delete department record
+	ctx = rec.Sub("delete_department_record").Wrap(ctx)
+	if err := s.deleteDepartmentRecord(ctx, id); err != nil {
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// deleteDepartmentRecord deletes a department record from the database
+func (s *SESC) deleteDepartmentRecord(ctx context.Context, id UUID) error {
+	rec := event.Get(ctx)
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	rec.Set("id", id)
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	err := s.client.Department.DeleteOneID(id).Where(department.OrgID(OrgFromContext(ctx))).Exec(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case ent.IsConstraintError(err):
+		rec.Add(events.Error, ErrCannotRemoveDepartment)
+		rec.Set("success", false)
+		return ErrCannotRemoveDepartment
+	case ent.IsNotFound(err):
+		rec.Add(events.Error, ErrInvalidDepartment)
+		rec.Set("success", false)
+		return ErrInvalidDepartment
+	case err != nil:
+		err := fmt.Errorf("couldn't delete department: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// DepartmentHead returns the head of a department, the user with the Dephead role assigned to it.
+// If a department has several heads, which should not happen, the earliest created one is returned.
+//
+// Returns an ErrDepartmentNotFound if the department does not exist.
+// Returns an ErrUserNotFound if the department has no head.
+func (s *SESC) DepartmentHead(ctx context.Context, deptID UUID) (User, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/department_head")
+
+	rec.Sub("params").Set("department_id", deptID)
+
+	// Stage 1: Query department heads
+	ctx = rec.Sub("query_department_heads").Wrap(ctx)
+	heads, err := s.queryDepartmentHeads(ctx, deptID)
+	if err != nil {
+		return User{}, err
+	}
+
+	if len(heads) == 0 {
+		// Stage 2: Tell a department without a head from a nonexistent one
+		ctx = rec.Sub("check_department_exists").Wrap(ctx)
+		if err := s.checkDepartmentExists(ctx, deptID); err != nil {
+			return User{}, err
+		}
+
+		rec.Set("success", false)
+		return User{}, ErrUserNotFound
+	}
+
+	if len(heads) > 1 {
+		rec.Set(events.Warning, "department has several heads")
+	}
+
+	// Stage 3: Convert the head
+	ctx = rec.Sub("convert_user").Wrap(ctx)
+	head, err := s.convertUserFromEntity(ctx, heads[0])
+	if err != nil {
+		return User{}, err
+	}
+
+	rec.Set("success", true)
+	rec.Set("user", head.EventRecord())
+	return head, nil
+}
+
+// queryDepartmentHeads queries up to two heads of a department, enough to tell if there are several
+func (s *SESC) queryDepartmentHeads(ctx context.Context, deptID UUID) ([]*ent.User, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	heads, err := s.client.User.Query().
+		Where(
+			user.OrgID(OrgFromContext(ctx)),
+			user.DepartmentID(deptID),
+			user.RoleID(Dephead.ID),
+			user.DeletedAtIsNil(),
+		).
+		Order(ent.Asc(user.FieldID)).
+		Limit(2).
+		WithDepartment().WithPermissions(orderPermissions).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+	if err != nil {
+		err := fmt.Errorf("couldn't query department heads: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", len(heads),
+	)
+	return heads, nil
+}
+
+// UsersInDepartment returns the users assigned to a department, except for the archived ones, ordered by ID.
+//
+// Returns an ErrDepartmentNotFound if the department does not exist.
+func (s *SESC) UsersInDepartment(ctx context.Context, deptID UUID) ([]User, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/users_in_department")
+
+	rec.Sub("params").Set("department_id", deptID)
+
+	// Stage 1: Query department users
+	ctx = rec.Sub("query_department_users").Wrap(ctx)
+	res, err := s.queryUsersInDepartment(ctx, deptID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		// Stage 2: Tell an empty department from a nonexistent one
+		ctx = rec.Sub("check_department_exists").Wrap(ctx)
+		if err := s.checkDepartmentExists(ctx, deptID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Stage 3: Convert the users
+	ctx = rec.Sub("convert_all_users").Wrap(ctx)
+	users, err := s.convertAllUsers(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", len(users),
+	)
+	return users, nil
+}
+
+// queryUsersInDepartment queries the users of a department that are not archived
+func (s *SESC) queryUsersInDepartment(ctx context.Context, deptID UUID) ([]*ent.User, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	res, err := s.client.User.Query().
+		Where(
+			user.OrgID(OrgFromContext(ctx)),
+			user.DepartmentID(deptID),
+			user.DeletedAtIsNil(),
+		).
+		Order(ent.Asc(user.FieldID)).
+		WithDepartment().WithPermissions(orderPermissions).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+	if err != nil {
+		err := fmt.Errorf("couldn't query department users: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", len(res),
+	)
+	return res, nil
+}
+
+// DepartmentUserCount returns the number of the users assigned to a department, archived ones included,
+// since they keep the department from being deleted as well. A department can only be deleted if it is zero.
+//
+// Returns an ErrDepartmentNotFound if the department does not exist.
+func (s *SESC) DepartmentUserCount(ctx context.Context, deptID UUID) (int, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/department_user_count")
+
+	rec.Sub("params").Set("department_id", deptID)
+
+	// Stage 1: Check the department exists
+	ctx = rec.Sub("check_department_exists").Wrap(ctx)
+	if err := s.checkDepartmentExists(ctx, deptID); err != nil {
+		return 0, err
+	}
+
+	// Stage 2: Count the department users
+	ctx = rec.Sub("count_department_users").Wrap(ctx)
+	count, err := s.countDepartmentUsers(ctx, deptID)
+	if err != nil {
+		return 0, err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", count,
+	)
+	return count, nil
+}
+
+// countDepartmentUsers counts the users of a department, archived ones included
+func (s *SESC) countDepartmentUsers(ctx context.Context, deptID UUID) (int, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	count, err := s.client.User.Query().
+		Where(
+			user.OrgID(OrgFromContext(ctx)),
+			user.DepartmentID(deptID),
+		).
+		Count(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+	if err != nil {
+		err := fmt.Errorf("couldn't count department users: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return 0, err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", count,
+	)
+	return count, nil
+}
+
+// checkDepartmentExists returns an ErrDepartmentNotFound if the department does not exist
+func (s *SESC) checkDepartmentExists(ctx context.Context, id UUID) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	exists, err := s.client.Department.Query().
+		Where(department.ID(id), department.OrgID(OrgFromContext(ctx))).
+		Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+	if err != nil {
+		err := fmt.Errorf("couldn't query department: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		return err
+	}
+
+	rec.Set("exists", exists)
+	if !exists {
+		return ErrDepartmentNotFound
+	}
+	return nil
+}
+
+func (s *SESC) newUUID() (UUID, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return id, fmt.Errorf("couldn't create UUID: %w", err)
+	}
+
+	return id, nil
+}
+
+// UserUpdateOptions represents the options for updating a user.
+type UserUpdateOptions struct {
+	FirstName  string
+	LastName   string
+	MiddleName string
+	PictureURL string
+	// Email, if not empty, must be a valid address. It is stored normalized and must be unique.
+	Email        string
+	Suspended    bool
+	DepartmentID UUID
+	NewRoleID    int32
+
+	// Version, if non-zero, must match the current version of the user for an update to succeed.
+	// It is ignored when creating a user.
+	Version int
+
+	Subdivision string
+	JobTitle    string
+	// EmploymentRate is the workload in full-time positions, if non-zero it must not exceed MaxEmploymentRate.
+	EmploymentRate    float64
+	PersonnelCategory PersonnelCategory
+	EmploymentType    EmploymentType
+	AcademicDegree    AcademicDegree
+	AcademicTitle     string
+	Honors            string
+	Category          string
+	DateOfEmployment  *time.Time
+	// UnemploymentDate, if set together with DateOfEmployment, must not precede it.
+	UnemploymentDate *time.Time
+}
+
+// UserFilter narrows down the users returned by Users. Nil fields match any user.
+type UserFilter struct {
+	Suspended      *bool
+	AcademicDegree *AcademicDegree
+	EmploymentType *EmploymentType
+}
+
+// setParams adds the set fields of the filter to the params record.
+func (f UserFilter) setParams(rec *event.Record) {
+	if f.Suspended != nil {
+		rec.Set("suspended", *f.Suspended)
+	}
+	if f.AcademicDegree != nil {
+		rec.Set("academic_degree", *f.AcademicDegree)
+	}
+	if f.EmploymentType != nil {
+		rec.Set("employment_type", *f.EmploymentType)
+	}
+}
+
+func (u UserUpdateOptions) Validate() error {
+	if u.FirstName == "" || u.LastName == "" {
+		return ErrInvalidUserName
+	}
+
+	if _, ok := RoleByID(u.NewRoleID); !ok {
+		return ErrInvalidRole
+	}
+
+	if err := ValidateEmail(u.Email); err != nil {
+		return err
+	}
+
+	return u.validateEmployment()
+}
+
+func (u UserUpdateOptions) validateEmployment() error {
+	switch {
+	case u.EmploymentRate < 0 || u.EmploymentRate > MaxEmploymentRate:
+		return fmt.Errorf("%w: employment rate must be between 0 and %v", ErrInvalidEmploymentData, MaxEmploymentRate)
+	case u.DateOfEmployment != nil && u.UnemploymentDate != nil && u.UnemploymentDate.Before(*u.DateOfEmployment):
+		return fmt.Errorf("%w: unemployment date precedes the date of employment", ErrInvalidEmploymentData)
+	case !u.PersonnelCategory.Valid():
+		return fmt.Errorf("%w: unknown personnel category %d", ErrInvalidEmploymentData, u.PersonnelCategory)
+	case !u.EmploymentType.Valid():
+		return fmt.Errorf("%w: unknown employment type %d", ErrInvalidEmploymentData, u.EmploymentType)
+	case !u.AcademicDegree.Valid():
+		return fmt.Errorf("%w: unknown academic degree %d", ErrInvalidEmploymentData, u.AcademicDegree)
+	}
+	return nil
+}
+
+// UpdateUser updates user with the new fields.
+//
+// Returns an ErrInvalidRole if the new role id is invalid.
+// Returns an ErrInvalidName if the first or last name is missing.
+// Returns an ErrInvalidEmail if the email is invalid, or an ErrEmailExists if another user has it.
+// Returns an ErrUserNotFound if the user does not exist.
+// Returns an ErrStaleUser if upd.Version is set and does not match the user's current version.
 func (s *SESC) UpdateUser(ctx context.Context, id UUID, upd UserUpdateOptions) (User, error) {
 	// Caller should create the record and use Wrap to add it to the context
-	rec := event.Get(ctx).Sub("sesc/update_user")
+	rec := event.Get(ctx).Sub("sesc/update_user")
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	rec.Sub("params").Set(
+		"id", id,
+		"first_name", upd.FirstName,
+		"last_name", upd.LastName,
+		"middle_name", upd.MiddleName,
+		"picture_url", upd.PictureURL,
+		"suspended", upd.Suspended,
+		"department_id", upd.DepartmentID,
+		"new_role_id", upd.NewRoleID,
+		"version", upd.Version,
+	)
+
+	// Stage 1: Validate user exists
+	ctx = rec.Sub("validate_user_exists").Wrap(ctx)
+	if err := s.validateUserExists(ctx, id); err != nil {
+		return User{}, err
+	}
+
+	// Stage 2: Validate role
+	ctx = rec.Sub("validate_role").Wrap(ctx)
+	if err := s.validateRole(ctx, upd.NewRoleID); err != nil {
+		return User{}, err
+	}
+
+	// Stage 3: Validate name
+	ctx = rec.Sub("validate_name").Wrap(ctx)
+	if err := s.validateName(ctx, upd.FirstName, upd.LastName); err != nil {
+		return User{}, err
+	}
+
+	// Stage 4: Validate employment data
+	ctx = rec.Sub("validate_employment").Wrap(ctx)
+	if err := s.validateEmployment(ctx, upd); err != nil {
+		return User{}, err
+	}
+
+	// Stage 5: Validate email
+	ctx = rec.Sub("validate_email").Wrap(ctx)
+	if err := s.validateEmail(ctx, upd.Email); err != nil {
+		return User{}, err
+	}
+
+	// Stages 6-10: Update user in a transaction, retried on serialization failures
+	var us *ent.User
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		var err error
+		us, err = s.updateUserTx(ctx, rec, statrec, id, upd)
+		return err
+	})
+	if err != nil {
+		return User{}, err
+	}
+
+	// Stage 11: Convert user entity to domain object
+	ctx = rec.Sub("convert_user").Wrap(ctx)
+	updated, err := s.convertUserEntity(ctx, us)
+	if err != nil {
+		return User{}, err
+	}
+
+	rec.Set("success", true)
+	rec.Set("user", updated.EventRecord())
+	return updated, nil
+}
+
+// updateUserTx updates the user record in a serializable transaction and returns the updated one.
+// A change of the role or the department is recorded to the employment history in the same transaction.
+func (s *SESC) updateUserTx(
+	ctx context.Context,
+	rec *event.Record,
+	statrec *event.Record,
+	id UUID,
+	upd UserUpdateOptions,
+) (*ent.User, error) {
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		err := fmt.Errorf("couldn't start transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return nil, err
+	}
+
+	// Stage 6: Check and get department if needed
+	ctx = rec.Sub("check_department").Wrap(ctx)
+	dept, err := s.checkAndGetDepartment(ctx, statrec, tx, upd.DepartmentID)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	// Stage 7: Query employment before the update
+	ctx = rec.Sub("query_employment").Wrap(ctx)
+	before, err := s.queryEmployment(ctx, tx, id)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	// Stage 8: Update user
+	ctx = rec.Sub("update_user_record").Wrap(ctx)
+	if err := s.updateUserRecord(ctx, statrec, tx, id, upd, dept); err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	// Stage 9: Query updated user
+	ctx = rec.Sub("query_updated_user").Wrap(ctx)
+	us, err := s.queryUpdatedUser(ctx, statrec, tx, id)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	// Stage 10: Record employment event
+	ctx = rec.Sub("record_employment_event").Wrap(ctx)
+	if err := s.recordEmploymentEvent(ctx, tx, id, before, employmentOf(us)); err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return nil, err
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+	return us, nil
+}
+
+// validateUserExists validates that a user exists
+func (s *SESC) validateUserExists(ctx context.Context, id UUID) error {
+	rec := event.Get(ctx)
+	rec.Set("user_id", id)
+
+	exists, err := s.UserExists(ctx, id)
+	switch {
+	case err != nil:
+		rec.Add(events.Error, err)
+		return err
+	case !exists:
+		rec.Add(events.Error, ErrUserNotFound)
+		rec.Set("exists", false)
+		return ErrUserNotFound
+	}
+
+	rec.Set("exists", true)
+	return nil
+}
+
+// validateRole validates the role ID
+func (s *SESC) validateRole(ctx context.Context, roleID int32) error {
+	rec := event.Get(ctx)
+	rec.Set("role_id", roleID)
+
+	if roleID == 0 {
+		rec.Set("valid", true)
+		return nil
+	}
+
+	_, ok := RoleByID(roleID)
+	if !ok {
+		rec.Set("valid", false)
+		return ErrInvalidRole
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// validateName validates that the name is not empty
+func (s *SESC) validateName(ctx context.Context, firstName, lastName string) error {
+	rec := event.Get(ctx)
+	rec.Set(
+		"first_name", firstName,
+		"last_name", lastName,
+	)
+
+	if firstName == "" || lastName == "" {
+		rec.Set("valid", false)
+		return ErrInvalidUserName
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// validateEmail validates the email of a user
+func (s *SESC) validateEmail(ctx context.Context, email string) error {
+	rec := event.Get(ctx)
+
+	if err := ValidateEmail(email); err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("valid", false)
+		return err
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// validateEmployment validates the employment data of a user
+func (s *SESC) validateEmployment(ctx context.Context, upd UserUpdateOptions) error {
+	rec := event.Get(ctx)
+
+	if err := upd.validateEmployment(); err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("valid", false)
+		return err
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// checkAndGetDepartment checks if the department exists and returns it
+func (s *SESC) checkAndGetDepartment(
+	ctx context.Context,
+	statrec *event.Record,
+	tx *ent.Tx,
+	departmentID UUID,
+) (*ent.Department, error) {
+	rec := event.Get(ctx)
+	rec.Set("department_id", departmentID)
+
+	if departmentID == uuid.Nil {
+		rec.Set("required", false)
+		//nolint:nilnil // department should be deleted or should not be set.
+		return nil, nil
+	}
+
+	rec.Set("required", true)
+	statrec.Add(events.PostgresQueries, 1)
+
+	dept, err := tx.Department.Query().
+		Where(department.ID(departmentID), department.OrgID(OrgFromContext(ctx))).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		rec.Set("exists", false)
+		rec.Add(events.Error, ErrInvalidDepartment)
+		return nil, ErrInvalidDepartment
+	case err != nil:
+		rec.Set("exists", false)
+		err := fmt.Errorf("couldn't query department: %w", err)
+		rec.Add(events.Error, err)
+		return nil, err
+	}
+
+	rec.Set(
+		"exists", true,
+		"id", dept.ID,
+		"name", dept.Name,
+	)
+	return dept, nil
+}
+
+// updateUserRecord updates the user record in the database
+func (s *SESC) updateUserRecord(
+	ctx context.Context,
+	statrec *event.Record,
+	tx *ent.Tx,
+	id UUID,
+	upd UserUpdateOptions,
+	dept *ent.Department,
+) error {
+	rec := event.Get(ctx)
+	rec.Set("user_id", id)
+
+	statrec.Add(events.PostgresQueries, 1)
+	updater := tx.User.Update().
+		Where(user.ID(id), user.OrgID(OrgFromContext(ctx))).
+		SetFirstName(upd.FirstName).
+		SetLastName(upd.LastName).
+		SetMiddleName(upd.MiddleName).
+		SetPictureURL(upd.PictureURL).
+		SetSuspended(upd.Suspended).
+		SetRoleID(upd.NewRoleID).
+		SetSubdivision(upd.Subdivision).
+		SetJobTitle(upd.JobTitle).
+		SetEmploymentRate(upd.EmploymentRate).
+		SetPersonnelCategory(int32(upd.PersonnelCategory)).
+		SetEmploymentType(int32(upd.EmploymentType)).
+		SetAcademicDegree(int32(upd.AcademicDegree)).
+		SetAcademicTitle(upd.AcademicTitle).
+		SetHonors(upd.Honors).
+		SetCategory(upd.Category).
+		AddVersion(1)
+
+	if email := emailField(upd.Email); email != nil {
+		updater = updater.SetEmail(*email)
+	} else {
+		updater = updater.ClearEmail()
+	}
+
+	if upd.DateOfEmployment != nil {
+		updater = updater.SetDateOfEmployment(*upd.DateOfEmployment)
+	} else {
+		updater = updater.ClearDateOfEmployment()
+	}
+
+	if upd.UnemploymentDate != nil {
+		updater = updater.SetUnemploymentDate(*upd.UnemploymentDate)
+	} else {
+		updater = updater.ClearUnemploymentDate()
+	}
+
+	if upd.Version != 0 {
+		updater = updater.Where(user.Version(upd.Version))
+	}
+
+	if dept != nil {
+		updater = updater.SetDepartmentID(dept.ID)
+	} else {
+		updater = updater.ClearDepartment()
+	}
+
+	n, err := updater.Save(ctx)
+	switch {
+	case err != nil:
+		err := fmt.Errorf("couldn't update user: %w", WrapConstraint(err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	case n == 0:
+		// The user is known to exist, so the version must have changed.
+		rec.Add(events.Error, ErrStaleUser)
+		rec.Set("success", false)
+		return ErrStaleUser
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// queryUpdatedUser queries the updated user from the database
+func (s *SESC) queryUpdatedUser(
+	ctx context.Context,
+	statrec *event.Record,
+	tx *ent.Tx,
+	id UUID,
+) (*ent.User, error) {
+	rec := event.Get(ctx)
+	rec.Set("user_id", id)
+
+	statrec.Add(events.PostgresQueries, 1)
+	us, err := tx.User.Query().Where(user.ID(id)).WithDepartment().WithPermissions(orderPermissions).Only(ctx)
+	if err != nil {
+		err := fmt.Errorf("couldn't query user after an update: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set("success", true)
+	return us, nil
+}
+
+// convertUserEntity converts an ent.User to a User domain object
+func (s *SESC) convertUserEntity(
+	ctx context.Context,
+	us *ent.User,
+) (User, error) {
+	rec := event.Get(ctx)
+
+	updated, err := convertUser(ctx, us)
+	if err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return User{}, err
+	}
+
+	rec.Set("success", true)
+	return updated, nil
+}
+
+// CreateUser creates a new User with a specified role.
+//
+// Returns an ErrInvalidName if the first or last name is missing.
+// Returns an ErrInvalidEmail if the email is invalid, or an ErrEmailExists if another user has it.
+func (s *SESC) CreateUser(ctx context.Context, opt UserUpdateOptions) (User, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/create_user")
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	rec.Sub("params").Set(
+		"first_name", opt.FirstName,
+		"last_name", opt.LastName,
+		"middle_name", opt.MiddleName,
+		"picture_url", opt.PictureURL,
+		"suspended", opt.Suspended,
+		"department_id", opt.DepartmentID,
+		"new_role_id", opt.NewRoleID,
+	)
+
+	// Stage 1: Validate input
+	ctx = rec.Sub("validate_create_input").Wrap(ctx)
+	if err := s.validateCreateInput(ctx, opt); err != nil {
+		return User{}, err
+	}
+
+	// Stages 2-4: Create user in a transaction, retried on serialization failures
+	var us *ent.User
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		var err error
+		us, err = s.createUserTx(ctx, rec, statrec, opt)
+		return err
+	})
+	if err != nil {
+		return User{}, err
+	}
+
+	// Stage 5: Convert user entity to domain object
+	ctx = rec.Sub("convert_user").Wrap(ctx)
+	user, err := s.convertUserEntity(ctx, us)
+	if err != nil {
+		return User{}, err
+	}
+
+	rec.Set("success", true)
+	rec.Set("user", user.EventRecord())
+	return user, nil
+}
+
+// createUserTx creates the user record in a serializable transaction and returns it.
+func (s *SESC) createUserTx(
+	ctx context.Context,
+	rec *event.Record,
+	statrec *event.Record,
+	opt UserUpdateOptions,
+) (*ent.User, error) {
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		err := fmt.Errorf("couldn't begin transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return nil, err
+	}
+
+	// Stage 2: Check and get department if needed
+	ctx = rec.Sub("check_department").Wrap(ctx)
+	dept, err := s.checkAndGetDepartment(ctx, statrec, tx, opt.DepartmentID)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	// Stage 3: Create user record
+	ctx = rec.Sub("create_user_record").Wrap(ctx)
+	userID, err := s.createUserRecord(ctx, statrec, tx, opt, dept)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	// Stage 4: Query created user
+	ctx = rec.Sub("query_created_user").Wrap(ctx)
+	us, err := s.queryCreatedUser(ctx, statrec, tx, userID)
+	if err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return nil, err
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+	return us, nil
+}
+
+// validateDepartmentInput validates the department name and description
+func (s *SESC) validateDepartmentInput(ctx context.Context, name, description string) error {
+	rec := event.Get(ctx)
+
+	if err := ValidateDepartment(name, description); err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("valid", false)
+		return err
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// validateCreateInput validates the create user input
+func (s *SESC) validateCreateInput(ctx context.Context, opt UserUpdateOptions) error {
+	rec := event.Get(ctx)
+
+	if err := opt.Validate(); err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("valid", false)
+		return err
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// createUserRecord creates a new user record in the database
+func (s *SESC) createUserRecord(
+	ctx context.Context,
+	statrec *event.Record,
+	tx *ent.Tx,
+	opt UserUpdateOptions,
+	dept *ent.Department,
+) (UUID, error) {
+	rec := event.Get(ctx)
+
+	statrec.Add(events.PostgresQueries, 1)
+	cr := tx.User.Create().
+		SetOrgID(OrgFromContext(ctx)).
+		SetFirstName(opt.FirstName).
+		SetLastName(opt.LastName).
+		SetMiddleName(opt.MiddleName).
+		SetPictureURL(opt.PictureURL).
+		SetNillableEmail(emailField(opt.Email)).
+		SetRoleID(opt.NewRoleID).
+		SetSubdivision(opt.Subdivision).
+		SetJobTitle(opt.JobTitle).
+		SetEmploymentRate(opt.EmploymentRate).
+		SetPersonnelCategory(int32(opt.PersonnelCategory)).
+		SetEmploymentType(int32(opt.EmploymentType)).
+		SetAcademicDegree(int32(opt.AcademicDegree)).
+		SetAcademicTitle(opt.AcademicTitle).
+		SetHonors(opt.Honors).
+		SetCategory(opt.Category).
+		SetNillableDateOfEmployment(opt.DateOfEmployment).
+		SetNillableUnemploymentDate(opt.UnemploymentDate)
+	if dept != nil {
+		cr = cr.SetDepartment(dept)
+	}
+
+	res, err := cr.Save(ctx)
+	if err != nil {
+		err := fmt.Errorf("couldn't save user: %w", WrapConstraint(err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return UUID{}, err
+	}
+
+	rec.Set("success", true)
+	rec.Set("user_id", res.ID)
+	return res.ID, nil
+}
+
+// queryCreatedUser queries the newly created user from the database
+func (s *SESC) queryCreatedUser(
+	ctx context.Context,
+	statrec *event.Record,
+	tx *ent.Tx,
+	id UUID,
+) (*ent.User, error) {
+	rec := event.Get(ctx)
+	rec.Set("user_id", id)
+
+	statrec.Add(events.PostgresQueries, 1)
+	us, err := tx.User.Query().Where(user.ID(id)).WithDepartment().WithPermissions(orderPermissions).Only(ctx)
+	if err != nil {
+		err := fmt.Errorf("couldn't query user after saving them: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set("success", true)
+	return us, nil
+}
+
+// UpdateProfilePicture updates a user's profile picture.
+// Returns an ErrUserNotFound if the user does not exist.
+func (s *SESC) UpdateProfilePicture(ctx context.Context, id UUID, pictureURL string) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/update_profile_picture")
+
+	rec.Sub("params").Set(
+		"id", id,
+		"picture_url", pictureURL,
+	)
+
+	// Stage 1: Update profile picture
+	ctx = rec.Sub("update_profile_picture_record").Wrap(ctx)
+	if err := s.updateProfilePictureRecord(ctx, id, pictureURL); err != nil {
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// updateProfilePictureRecord updates a user's profile picture in the database
+func (s *SESC) updateProfilePictureRecord(ctx context.Context, id UUID, pictureURL string) error {
+	rec := event.Get(ctx)
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	rec.Set("id", id)
+	rec.Set("picture_url", pictureURL)
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	err := s.client.User.UpdateOneID(id).
+		Where(user.OrgID(OrgFromContext(ctx))).
+		SetPictureURL(pictureURL).
+		AddVersion(1).
+		Exec(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case ent.IsNotFound(err):
+		joinedErr := fmt.Errorf("%w: %w", err, ErrUserNotFound)
+		rec.Add(events.Error, joinedErr)
+		rec.Set("success", false)
+		return joinedErr
+	case err != nil:
+		err := fmt.Errorf("couldn't update user: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// DeleteUser deletes a user by ID. The user's credentials are removed with them.
+// Returns an ErrUserNotFound if the user does not exist.
+func (s *SESC) DeleteUser(ctx context.Context, id UUID) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/delete_user")
+
+	rec.Sub("params").Set("id", id)
+
+	// Stage 1: Delete user record
+	ctx = rec.Sub("delete_user_record").Wrap(ctx)
+	if err := s.deleteUserRecord(ctx, id); err != nil {
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// deleteUserRecord deletes a user record from the database
+func (s *SESC) deleteUserRecord(ctx context.Context, id UUID) error {
+	rec := event.Get(ctx)
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	rec.Set("id", id)
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	err := s.client.User.DeleteOneID(id).Where(user.OrgID(OrgFromContext(ctx))).Exec(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case ent.IsNotFound(err):
+		rec.Add(events.Error, ErrUserNotFound)
+		rec.Set("success", false)
+		return ErrUserNotFound
+	case err != nil:
+		err := fmt.Errorf("couldn't delete user: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// ArchiveUser marks a user as deleted without removing their record.
+// Archived users are hidden from Users and UserByID, unless requested explicitly.
+// Returns an ErrUserNotFound if the user does not exist or is already archived.
+func (s *SESC) ArchiveUser(ctx context.Context, id UUID) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/archive_user")
+
+	rec.Sub("params").Set("id", id)
+
+	// Stage 1: Archive user record
+	ctx = rec.Sub("archive_user_record").Wrap(ctx)
+	if err := s.archiveUserRecord(ctx, id); err != nil {
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// archiveUserRecord sets the deletion timestamp of a user record in the database
+func (s *SESC) archiveUserRecord(ctx context.Context, id UUID) error {
+	rec := event.Get(ctx)
 	rootRec := event.Root(ctx)
 	statrec := rootRec.Sub("stats")
 
+	rec.Set("id", id)
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	n, err := s.client.User.Update().
+		Where(user.ID(id), user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		SetDeletedAt(time.Now()).
+		Save(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case err != nil:
+		err := fmt.Errorf("couldn't archive user: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	case n == 0:
+		rec.Add(events.Error, ErrUserNotFound)
+		rec.Set("success", false)
+		return ErrUserNotFound
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// SetSuspended suspends or reinstates a user, and reports whether that changed the user:
+// suspending a suspended user or reinstating an active one leaves the user as is, the version included.
+// Suspended users cannot log in, but are still listed.
+// Returns an ErrUserNotFound if the user does not exist or is archived.
+func (s *SESC) SetSuspended(ctx context.Context, id UUID, suspended bool) (bool, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/set_suspended")
+
 	rec.Sub("params").Set(
 		"id", id,
-		"first_name", upd.FirstName,
-		"last_name", upd.LastName,
-		"middle_name", upd.MiddleName,
-		"picture_url", upd.PictureURL,
-		"suspended", upd.Suspended,
-		"department_id", upd.DepartmentID,
-		"new_role_id", upd.NewRoleID,
+		"suspended", suspended,
 	)
 
-	// Stage 1: Validate user exists
-	ctx = rec.Sub("validate_user_exists").Wrap(ctx)
-	if err := s.validateUserExists(ctx, id); err != nil {
-		return User{}, err
+	// Stage 1: Update user record, if the suspension differs
+	ctx = rec.Sub("set_suspended_record").Wrap(ctx)
+	changed, err := s.setSuspendedRecord(ctx, id, suspended)
+	if err != nil {
+		return false, err
 	}
 
-	// Stage 2: Validate role
-	ctx = rec.Sub("validate_role").Wrap(ctx)
-	if err := s.validateRole(ctx, upd.NewRoleID); err != nil {
-		return User{}, err
+	// Stage 2: Tell an unchanged user from a missing one
+	if !changed {
+		ctx = rec.Sub("validate_user_exists").Wrap(ctx)
+		if err := s.validateUserExists(ctx, id); err != nil {
+			return false, err
+		}
 	}
 
-	// Stage 3: Validate name
-	ctx = rec.Sub("validate_name").Wrap(ctx)
-	if err := s.validateName(ctx, upd.FirstName, upd.LastName); err != nil {
-		return User{}, err
+	rec.Set(
+		"success", true,
+		"changed", changed,
+	)
+	return changed, nil
+}
+
+// setSuspendedRecord updates the suspended flag of a user record in the database if it differs,
+// and reports whether it did
+func (s *SESC) setSuspendedRecord(ctx context.Context, id UUID, suspended bool) (bool, error) {
+	rec := event.Get(ctx)
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	n, err := s.client.User.Update().
+		Where(
+			user.ID(id),
+			user.OrgID(OrgFromContext(ctx)),
+			user.DeletedAtIsNil(),
+			user.Suspended(!suspended),
+		).
+		SetSuspended(suspended).
+		AddVersion(1).
+		Save(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't set user suspended: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return false, err
+	}
+
+	rec.Set(
+		"success", true,
+		"updated", n > 0,
+	)
+	return n > 0, nil
+}
+
+// SetRole changes the role of a user.
+//
+// A department is only kept by roles that may have one. Rather than silently clearing it,
+// moving a user with a department to any other role is rejected: the department has to be
+// cleared first.
+//
+// Returns an ErrInvalidRole if the role does not exist.
+// Returns an ErrInvalidRoleChange if the user has a department and the new role cannot have one.
+// Returns an ErrUserNotFound if the user does not exist or is archived.
+func (s *SESC) SetRole(ctx context.Context, id UUID, roleID int32) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/set_role")
+
+	rec.Sub("params").Set(
+		"id", id,
+		"role_id", roleID,
+	)
+
+	// Stage 1: Validate role
+	role, ok := RoleByID(roleID)
+	if !ok {
+		rec.Add(events.Error, ErrInvalidRole)
+		rec.Set("success", false)
+		return ErrInvalidRole
+	}
+
+	// Stages 2-4: Update user in a transaction, retried on serialization failures
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		return s.setRoleTx(ctx, rec, id, role)
+	})
+	if err != nil {
+		return err
 	}
 
+	rec.Set("success", true)
+	return nil
+}
+
+// setRoleTx updates the role of a user in a serializable transaction,
+// recording the change to the employment history.
+func (s *SESC) setRoleTx(ctx context.Context, rec *event.Record, id UUID, role Role) error {
+	statrec := event.Root(ctx).Sub("stats")
 	txrec := rec.Sub("pg_transaction")
 	txrec.Set("rollback", false)
 
@@ -426,173 +1963,253 @@ func (s *SESC) UpdateUser(ctx context.Context, id UUID, upd UserUpdateOptions) (
 	if err != nil {
 		err := fmt.Errorf("couldn't start transaction: %w", err)
 		txrec.Add(events.Error, err)
-		return User{}, err
+		return err
 	}
 
-	// Stage 4: Check and get department if needed
-	ctx = rec.Sub("check_department").Wrap(ctx)
-	dept, err := s.checkAndGetDepartment(ctx, statrec, tx, upd.DepartmentID)
+	// Stage 2: Query employment before the update
+	ctx = rec.Sub("query_employment").Wrap(ctx)
+	before, err := s.queryEmployment(ctx, tx, id)
 	if err != nil {
-		return User{}, rollback(tx, err)
+		return rollback(tx, err)
 	}
 
-	// Stage 5: Update user
-	ctx = rec.Sub("update_user_record").Wrap(ctx)
-	if err := s.updateUserRecord(ctx, statrec, tx, id, upd, dept); err != nil {
-		return User{}, rollback(tx, err)
+	// Stage 3: Update user record
+	ctx = rec.Sub("set_role_record").Wrap(ctx)
+	if err := s.setRoleRecord(ctx, tx, id, role); err != nil {
+		return rollback(tx, err)
 	}
 
-	// Stage 6: Query updated user
-	ctx = rec.Sub("query_updated_user").Wrap(ctx)
-	us, err := s.queryUpdatedUser(ctx, statrec, tx, id)
-	if err != nil {
-		return User{}, rollback(tx, err)
+	// Stage 4: Record employment event
+	ctx = rec.Sub("record_employment_event").Wrap(ctx)
+	after := before
+	after.roleID = role.ID
+	if err := s.recordEmploymentEvent(ctx, tx, id, before, after); err != nil {
+		return rollback(tx, err)
 	}
 
-	err = tx.Commit()
-	if err != nil {
+	if err := tx.Commit(); err != nil {
 		err := fmt.Errorf("couldn't commit transaction: %w", err)
 		txrec.Add(events.Error, err)
-		return User{}, err
+		return err
 	}
 
 	statrec.Add(events.PostgresTime, time.Since(txStart))
+	return nil
+}
 
-	// Stage 7: Convert user entity to domain object
-	ctx = rec.Sub("convert_user").Wrap(ctx)
-	updated, err := s.convertUserEntity(ctx, us)
-	if err != nil {
-		return User{}, err
+// setRoleRecord updates the role of a user record in the database.
+// The department condition is a part of the update, so that a concurrent
+// department assignment cannot slip in between the check and the update.
+func (s *SESC) setRoleRecord(ctx context.Context, tx *ent.Tx, id UUID, role Role) error {
+	rec := event.Get(ctx)
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	update := tx.User.UpdateOneID(id).Where(user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil())
+	if !role.CanHaveDepartment() {
+		update = update.Where(user.DepartmentIDIsNil())
+	}
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	err := update.
+		SetRoleID(role.ID).
+		AddVersion(1).
+		Exec(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case ent.IsNotFound(err):
+		err = s.roleChangeError(ctx, tx.Client(), id)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	case err != nil:
+		err := fmt.Errorf("couldn't set user role: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
 	}
 
 	rec.Set("success", true)
-	rec.Set("user", updated.EventRecord())
-	return updated, nil
+	return nil
 }
 
-// validateUserExists validates that a user exists
-func (s *SESC) validateUserExists(ctx context.Context, id UUID) error {
-	rec := event.Get(ctx)
-	rec.Set("user_id", id)
+// roleChangeError tells why an update restricted by the role or the department of a user
+// matched no users: either there is no such user, or the role and the department don't fit.
+func (s *SESC) roleChangeError(ctx context.Context, client *ent.Client, id UUID) error {
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	exists, err := client.User.Query().
+		Where(user.ID(id), user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("couldn't query user: %w", err)
+	case exists:
+		return ErrInvalidRoleChange
+	default:
+		return ErrUserNotFound
+	}
+}
+
+// SetDepartment assigns a user to a department, or clears the department if deptID is uuid.Nil.
+//
+// Returns an ErrInvalidDepartment if the department does not exist.
+// Returns an ErrInvalidRoleChange if the role of the user cannot have a department.
+// Returns an ErrUserNotFound if the user does not exist or is archived.
+func (s *SESC) SetDepartment(ctx context.Context, id, deptID UUID) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/set_department")
+
+	rec.Sub("params").Set(
+		"id", id,
+		"department_id", deptID,
+	)
 
-	_, err := s.UserByID(ctx, id)
+	// Stage 1: Validate department exists
+	ctx = rec.Sub("validate_department_exists").Wrap(ctx)
+	if err := s.validateDepartmentExists(ctx, deptID); err != nil {
+		return err
+	}
+
+	// Stages 2-4: Update user in a transaction, retried on serialization failures
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		return s.setDepartmentTx(ctx, rec, id, deptID)
+	})
 	if err != nil {
-		rec.Add(events.Error, err)
-		rec.Set("exists", false)
 		return err
 	}
 
-	rec.Set("exists", true)
+	rec.Set("success", true)
 	return nil
 }
 
-// validateRole validates the role ID
-func (s *SESC) validateRole(ctx context.Context, roleID int32) error {
-	rec := event.Get(ctx)
-	rec.Set("role_id", roleID)
+// setDepartmentTx updates the department of a user in a serializable transaction,
+// recording the change to the employment history.
+func (s *SESC) setDepartmentTx(ctx context.Context, rec *event.Record, id, deptID UUID) error {
+	statrec := event.Root(ctx).Sub("stats")
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
 
-	if roleID == 0 {
-		rec.Set("valid", true)
-		return nil
+	txStart := time.Now()
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		err := fmt.Errorf("couldn't start transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return err
 	}
 
-	_, ok := RoleByID(roleID)
-	if !ok {
-		rec.Set("valid", false)
-		return ErrInvalidRole
+	// Stage 2: Query employment before the update
+	ctx = rec.Sub("query_employment").Wrap(ctx)
+	before, err := s.queryEmployment(ctx, tx, id)
+	if err != nil {
+		return rollback(tx, err)
 	}
 
-	rec.Set("valid", true)
-	return nil
-}
+	// Stage 3: Update user record
+	ctx = rec.Sub("set_department_record").Wrap(ctx)
+	if err := s.setDepartmentRecord(ctx, tx, id, deptID); err != nil {
+		return rollback(tx, err)
+	}
 
-// validateName validates that the name is not empty
-func (s *SESC) validateName(ctx context.Context, firstName, lastName string) error {
-	rec := event.Get(ctx)
-	rec.Set(
-		"first_name", firstName,
-		"last_name", lastName,
-	)
+	// Stage 4: Record employment event
+	ctx = rec.Sub("record_employment_event").Wrap(ctx)
+	after := before
+	after.departmentID = deptID
+	if err := s.recordEmploymentEvent(ctx, tx, id, before, after); err != nil {
+		return rollback(tx, err)
+	}
 
-	if firstName == "" || lastName == "" {
-		rec.Set("valid", false)
-		return ErrInvalidUserName
+	if err := tx.Commit(); err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return err
 	}
 
-	rec.Set("valid", true)
+	statrec.Add(events.PostgresTime, time.Since(txStart))
 	return nil
 }
 
-// checkAndGetDepartment checks if the department exists and returns it
-func (s *SESC) checkAndGetDepartment(
-	ctx context.Context,
-	statrec *event.Record,
-	tx *ent.Tx,
-	departmentID UUID,
-) (*ent.Department, error) {
+// validateDepartmentExists validates that a department exists, unless no department is given
+func (s *SESC) validateDepartmentExists(ctx context.Context, id UUID) error {
 	rec := event.Get(ctx)
-	rec.Set("department_id", departmentID)
+	statrec := event.Root(ctx).Sub("stats")
+	rec.Set("department_id", id)
 
-	if departmentID == uuid.Nil {
+	if id == uuid.Nil {
 		rec.Set("required", false)
-		//nolint:nilnil // department should be deleted or should not be set.
-		return nil, nil
+		return nil
 	}
 
 	rec.Set("required", true)
+
+	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
+	exists, err := s.client.Department.Query().
+		Where(department.ID(id), department.OrgID(OrgFromContext(ctx))).
+		Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
 
-	dept, err := tx.Department.Get(ctx, departmentID)
 	switch {
-	case ent.IsNotFound(err):
-		rec.Set("exists", false)
-		rec.Add(events.Error, ErrInvalidDepartment)
-		return nil, ErrInvalidDepartment
 	case err != nil:
-		rec.Set("exists", false)
 		err := fmt.Errorf("couldn't query department: %w", err)
 		rec.Add(events.Error, err)
-		return nil, err
+		return err
+	case !exists:
+		rec.Set("exists", false)
+		rec.Add(events.Error, ErrInvalidDepartment)
+		return ErrInvalidDepartment
 	}
 
-	rec.Set(
-		"exists", true,
-		"id", dept.ID,
-		"name", dept.Name,
-	)
-	return dept, nil
+	rec.Set("exists", true)
+	return nil
 }
 
-// updateUserRecord updates the user record in the database
-func (s *SESC) updateUserRecord(
-	ctx context.Context,
-	statrec *event.Record,
-	tx *ent.Tx,
-	id UUID,
-	upd UserUpdateOptions,
-	dept *ent.Department,
-) error {
+// setDepartmentRecord updates the department of a user record in the database.
+// As with roles, the role condition is a part of the update.
+func (s *SESC) setDepartmentRecord(ctx context.Context, tx *ent.Tx, id, deptID UUID) error {
 	rec := event.Get(ctx)
-	rec.Set("user_id", id)
-
-	statrec.Add(events.PostgresQueries, 1)
-	updater := tx.User.UpdateOneID(id).
-		SetFirstName(upd.FirstName).
-		SetLastName(upd.LastName).
-		SetMiddleName(upd.MiddleName).
-		SetPictureURL(upd.PictureURL).
-		SetSuspended(upd.Suspended).
-		SetRoleID(upd.NewRoleID)
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
 
-	if dept != nil {
-		updater = updater.SetDepartmentID(dept.ID)
+	update := tx.User.UpdateOneID(id).Where(user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil())
+	if deptID == uuid.Nil {
+		update = update.ClearDepartment()
 	} else {
-		updater = updater.ClearDepartment()
+		var roleIDs []int32
+		for _, r := range roles.Load().roles {
+			if r.CanHaveDepartment() {
+				roleIDs = append(roleIDs, r.ID)
+			}
+		}
+		update = update.Where(user.RoleIDIn(roleIDs...)).SetDepartmentID(deptID)
 	}
 
-	_, err := updater.Save(ctx)
-	if err != nil {
-		err := fmt.Errorf("couldn't update user: %w", err)
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	err := update.AddVersion(1).Exec(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	switch {
+	case ent.IsNotFound(err):
+		err = s.roleChangeError(ctx, tx.Client(), id)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	case ent.IsConstraintError(err):
+		// The department was deleted after it had been validated.
+		rec.Add(events.Error, ErrInvalidDepartment)
+		rec.Set("success", false)
+		return ErrInvalidDepartment
+	case err != nil:
+		err := fmt.Errorf("couldn't set user department: %w", err)
 		rec.Add(events.Error, err)
 		rec.Set("success", false)
 		return err
@@ -602,259 +2219,251 @@ func (s *SESC) updateUserRecord(
 	return nil
 }
 
-// queryUpdatedUser queries the updated user from the database
-func (s *SESC) queryUpdatedUser(
-	ctx context.Context,
-	statrec *event.Record,
-	tx *ent.Tx,
-	id UUID,
-) (*ent.User, error) {
-	rec := event.Get(ctx)
-	rec.Set("user_id", id)
+// GrantPermissions grants permissions to a user in addition to the ones of their role.
+// Permissions the user has already been granted are skipped.
+//
+// Returns an ErrInvalidPermission if any of the permissions does not exist.
+// Returns an ErrUserNotFound if the user does not exist or is archived.
+func (s *SESC) GrantPermissions(ctx context.Context, id UUID, permissionIDs []int32) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/grant_permissions")
+	statrec := event.Root(ctx).Sub("stats")
 
-	statrec.Add(events.PostgresQueries, 1)
-	us, err := tx.User.Query().Where(user.ID(id)).WithDepartment().Only(ctx)
+	rec.Sub("params").Set(
+		"id", id,
+		"permission_ids", permissionIDs,
+	)
+
+	// Stage 1: Validate permissions
+	permissionIDs, err := validatePermissions(permissionIDs)
 	if err != nil {
-		err := fmt.Errorf("couldn't query user after an update: %w", err)
 		rec.Add(events.Error, err)
 		rec.Set("success", false)
-		return nil, err
+		return err
 	}
 
-	rec.Set("success", true)
-	return us, nil
-}
-
-// convertUserEntity converts an ent.User to a User domain object
-func (s *SESC) convertUserEntity(
-	ctx context.Context,
-	us *ent.User,
-) (User, error) {
-	rec := event.Get(ctx)
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
 
-	updated, err := convertUser(us)
+	txStart := time.Now()
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
 	if err != nil {
-		rec.Add(events.Error, err)
-		rec.Set("success", false)
-		return User{}, err
+		err := fmt.Errorf("couldn't start transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return err
+	}
+
+	// Stage 2: Bump user version
+	ctx = rec.Sub("bump_user_version").Wrap(ctx)
+	if err := s.bumpUserVersion(ctx, tx, id); err != nil {
+		return rollback(tx, err)
+	}
+
+	// Stage 3: Create grants
+	ctx = rec.Sub("create_grants").Wrap(ctx)
+	if err := s.createGrants(ctx, tx, id, permissionIDs); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return err
 	}
 
+	statrec.Add(events.PostgresTime, time.Since(txStart))
 	rec.Set("success", true)
-	return updated, nil
+	return nil
 }
 
-// CreateUser creates a new User with a specified role.
-//
-// Returns an ErrInvalidName if the first or last name is missing.
-func (s *SESC) CreateUser(ctx context.Context, opt UserUpdateOptions) (User, error) {
-	// Caller should create the record and use Wrap to add it to the context
-	rec := event.Get(ctx).Sub("sesc/create_user")
-	rootRec := event.Root(ctx)
-	statrec := rootRec.Sub("stats")
-
-	rec.Sub("params").Set(
-		"first_name", opt.FirstName,
-		"last_name", opt.LastName,
-		"middle_name", opt.MiddleName,
-		"picture_url", opt.PictureURL,
-		"suspended", opt.Suspended,
-		"department_id", opt.DepartmentID,
-		"new_role_id", opt.NewRoleID,
+// RevokePermissions revokes permissions granted to a user by GrantPermissions.
+// Permissions of the user's role cannot be revoked; permissions that were not granted are skipped.
+//
+// Returns an ErrInvalidPermission if any of the permissions does not exist.
+// Returns an ErrUserNotFound if the user does not exist or is archived.
+func (s *SESC) RevokePermissions(ctx context.Context, id UUID, permissionIDs []int32) error {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/revoke_permissions")
+	statrec := event.Root(ctx).Sub("stats")
+
+	rec.Sub("params").Set(
+		"id", id,
+		"permission_ids", permissionIDs,
 	)
 
-	// Stage 1: Validate input
-	ctx = rec.Sub("validate_create_input").Wrap(ctx)
-	if err := s.validateCreateInput(ctx, opt); err != nil {
-		return User{}, err
+	// Stage 1: Validate permissions
+	permissionIDs, err := validatePermissions(permissionIDs)
+	if err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
 	}
 
 	txrec := rec.Sub("pg_transaction")
 	txrec.Set("rollback", false)
 
 	txStart := time.Now()
-	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
 	if err != nil {
-		err := fmt.Errorf("couldn't begin transaction: %w", err)
+		err := fmt.Errorf("couldn't start transaction: %w", err)
 		txrec.Add(events.Error, err)
-		return User{}, err
-	}
-
-	// Stage 2: Check and get department if needed
-	ctx = rec.Sub("check_department").Wrap(ctx)
-	dept, err := s.checkAndGetDepartment(ctx, statrec, tx, opt.DepartmentID)
-	if err != nil {
-		return User{}, rollback(tx, err)
+		return err
 	}
 
-	// Stage 3: Create user record
-	ctx = rec.Sub("create_user_record").Wrap(ctx)
-	userID, err := s.createUserRecord(ctx, statrec, tx, opt, dept)
-	if err != nil {
-		return User{}, rollback(tx, err)
+	// Stage 2: Bump user version
+	ctx = rec.Sub("bump_user_version").Wrap(ctx)
+	if err := s.bumpUserVersion(ctx, tx, id); err != nil {
+		return rollback(tx, err)
 	}
 
-	// Stage 4: Query created user
-	ctx = rec.Sub("query_created_user").Wrap(ctx)
-	us, err := s.queryCreatedUser(ctx, statrec, tx, userID)
-	if err != nil {
-		return User{}, rollback(tx, err)
+	// Stage 3: Delete grants
+	ctx = rec.Sub("delete_grants").Wrap(ctx)
+	if err := s.deleteGrants(ctx, tx, id, permissionIDs); err != nil {
+		return rollback(tx, err)
 	}
 
-	err = tx.Commit()
-	if err != nil {
+	if err := tx.Commit(); err != nil {
 		err := fmt.Errorf("couldn't commit transaction: %w", err)
 		txrec.Add(events.Error, err)
-		return User{}, err
+		return err
 	}
 
 	statrec.Add(events.PostgresTime, time.Since(txStart))
-
-	// Stage 5: Convert user entity to domain object
-	ctx = rec.Sub("convert_user").Wrap(ctx)
-	user, err := s.convertUserEntity(ctx, us)
-	if err != nil {
-		return User{}, err
-	}
-
 	rec.Set("success", true)
-	rec.Set("user", user.EventRecord())
-	return user, nil
+	return nil
 }
 
-// validateCreateInput validates the create user input
-func (s *SESC) validateCreateInput(ctx context.Context, opt UserUpdateOptions) error {
-	rec := event.Get(ctx)
-
-	if err := opt.Validate(); err != nil {
-		rec.Add(events.Error, err)
-		rec.Set("valid", false)
-		return err
+// validatePermissions checks that all the permissions exist in the catalog
+// and returns their sorted IDs without duplicates.
+func validatePermissions(permissionIDs []int32) ([]int32, error) {
+	for _, id := range permissionIDs {
+		if _, ok := PermissionByID(id); !ok {
+			return nil, fmt.Errorf("%w: no permission with id %d", ErrInvalidPermission, id)
+		}
 	}
 
-	rec.Set("valid", true)
-	return nil
+	ids := slices.Clone(permissionIDs)
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
 }
 
-// createUserRecord creates a new user record in the database
-func (s *SESC) createUserRecord(
-	ctx context.Context,
-	statrec *event.Record,
-	tx *ent.Tx,
-	opt UserUpdateOptions,
-	dept *ent.Department,
-) (UUID, error) {
+// bumpUserVersion increments the version of a user record, so that changes
+// of related records invalidate the user's ETag.
+func (s *SESC) bumpUserVersion(ctx context.Context, tx *ent.Tx, id UUID) error {
 	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
 
 	statrec.Add(events.PostgresQueries, 1)
-	cr := tx.User.Create().
-		SetFirstName(opt.FirstName).
-		SetLastName(opt.LastName).
-		SetMiddleName(opt.MiddleName).
-		SetPictureURL(opt.PictureURL).
-		SetRoleID(opt.NewRoleID)
-	if dept != nil {
-		cr = cr.SetDepartment(dept)
-	}
+	err := tx.User.UpdateOneID(id).
+		Where(user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		AddVersion(1).
+		Exec(ctx)
 
-	res, err := cr.Save(ctx)
-	if err != nil {
-		err := fmt.Errorf("couldn't save user: %w", err)
+	switch {
+	case ent.IsNotFound(err):
+		rec.Add(events.Error, ErrUserNotFound)
+		rec.Set("success", false)
+		return ErrUserNotFound
+	case err != nil:
+		err := fmt.Errorf("couldn't update user version: %w", err)
 		rec.Add(events.Error, err)
 		rec.Set("success", false)
-		return UUID{}, err
+		return err
 	}
 
 	rec.Set("success", true)
-	rec.Set("user_id", res.ID)
-	return res.ID, nil
+	return nil
 }
 
-// queryCreatedUser queries the newly created user from the database
-func (s *SESC) queryCreatedUser(
-	ctx context.Context,
-	statrec *event.Record,
-	tx *ent.Tx,
-	id UUID,
-) (*ent.User, error) {
+// createGrants creates the grants of a user that do not exist yet
+func (s *SESC) createGrants(ctx context.Context, tx *ent.Tx, id UUID, permissionIDs []int32) error {
 	rec := event.Get(ctx)
-	rec.Set("user_id", id)
+	statrec := event.Root(ctx).Sub("stats")
 
 	statrec.Add(events.PostgresQueries, 1)
-	us, err := tx.User.Query().Where(user.ID(id)).WithDepartment().Only(ctx)
+	existing, err := tx.UserPermission.Query().
+		Where(
+			userpermission.UserID(id),
+			userpermission.PermissionIDIn(permissionIDs...),
+		).
+		All(ctx)
 	if err != nil {
-		err := fmt.Errorf("couldn't query user after saving them: %w", err)
+		err := fmt.Errorf("couldn't query user permissions: %w", err)
 		rec.Add(events.Error, err)
 		rec.Set("success", false)
-		return nil, err
+		return err
 	}
 
-	rec.Set("success", true)
-	return us, nil
-}
-
-// UpdateProfilePicture updates a user's profile picture.
-// Returns an ErrUserNotFound if the user does not exist.
-func (s *SESC) UpdateProfilePicture(ctx context.Context, id UUID, pictureURL string) error {
-	// Caller should create the record and use Wrap to add it to the context
-	rec := event.Get(ctx).Sub("sesc/update_profile_picture")
-
-	rec.Sub("params").Set(
-		"id", id,
-		"picture_url", pictureURL,
-	)
+	var creates []*ent.UserPermissionCreate
+	for _, pid := range permissionIDs {
+		if slices.ContainsFunc(existing, func(g *ent.UserPermission) bool { return g.PermissionID == pid }) {
+			continue
+		}
+		creates = append(creates, tx.UserPermission.Create().SetUserID(id).SetPermissionID(pid))
+	}
 
-	// Stage 1: Update profile picture
-	ctx = rec.Sub("update_profile_picture_record").Wrap(ctx)
-	if err := s.updateProfilePictureRecord(ctx, id, pictureURL); err != nil {
-		return err
+	if len(creates) > 0 {
+		statrec.Add(events.PostgresQueries, 1)
+		if err := tx.UserPermission.CreateBulk(creates...).Exec(ctx); err != nil {
+			err := fmt.Errorf("couldn't create user permissions: %w", err)
+			rec.Add(events.Error, err)
+			rec.Set("success", false)
+			return err
+		}
 	}
 
-	rec.Set("success", true)
+	rec.Set(
+		"success", true,
+		"created_count", len(creates),
+	)
 	return nil
 }
 
-// updateProfilePictureRecord updates a user's profile picture in the database
-func (s *SESC) updateProfilePictureRecord(ctx context.Context, id UUID, pictureURL string) error {
+// deleteGrants deletes the grants of a user
+func (s *SESC) deleteGrants(ctx context.Context, tx *ent.Tx, id UUID, permissionIDs []int32) error {
 	rec := event.Get(ctx)
-	rootRec := event.Root(ctx)
-	statrec := rootRec.Sub("stats")
-
-	rec.Set("id", id)
-	rec.Set("picture_url", pictureURL)
+	statrec := event.Root(ctx).Sub("stats")
 
-	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	err := s.client.User.UpdateOneID(id).SetPictureURL(pictureURL).Exec(ctx)
-	statrec.Add(events.PostgresTime, time.Since(startTime))
-
-	switch {
-	case ent.IsNotFound(err):
-		joinedErr := fmt.Errorf("%w: %w", err, ErrUserNotFound)
-		rec.Add(events.Error, joinedErr)
-		rec.Set("success", false)
-		return joinedErr
-	case err != nil:
-		err := fmt.Errorf("couldn't update user: %w", err)
+	deleted, err := tx.UserPermission.Delete().
+		Where(
+			userpermission.UserID(id),
+			userpermission.PermissionIDIn(permissionIDs...),
+		).
+		Exec(ctx)
+	if err != nil {
+		err := fmt.Errorf("couldn't delete user permissions: %w", err)
 		rec.Add(events.Error, err)
 		rec.Set("success", false)
 		return err
 	}
 
-	rec.Set("success", true)
+	rec.Set(
+		"success", true,
+		"deleted_count", deleted,
+	)
 	return nil
 }
 
 // UserByID gets a user by their ID.
+// Archived users are only returned if includeArchived is true.
 // Returns an ErrUserNotFound if the user does not exist.
-func (s *SESC) UserByID(ctx context.Context, id UUID) (User, error) {
+func (s *SESC) UserByID(ctx context.Context, id UUID, includeArchived bool) (User, error) {
 	// Caller should create the record and use Wrap to add it to the context
 	rec := event.Get(ctx).Sub("sesc/user_by_id")
 
-	rec.Sub("params").Set("id", id)
+	rec.Sub("params").Set(
+		"id", id,
+		"include_archived", includeArchived,
+	)
 
 	// Stage 1: Query user by ID
 	ctx = rec.Sub("query_user_by_id").Wrap(ctx)
-	u, err := s.getUserByID(ctx, id)
+	u, err := s.getUserByID(ctx, id, includeArchived)
 	if err != nil {
 		return User{}, err
 	}
@@ -869,17 +2478,50 @@ func (s *SESC) UserByID(ctx context.Context, id UUID) (User, error) {
 	return userObj, nil
 }
 
+// UserExists reports whether a user exists and is not archived.
+// Unlike UserByID, it neither loads the department of the user nor converts them.
+func (s *SESC) UserExists(ctx context.Context, id UUID) (bool, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/user_exists")
+	statrec := event.Root(ctx).Sub("stats")
+
+	rec.Sub("params").Set("id", id)
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	exists, err := s.client.User.Query().
+		Where(user.ID(id), user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		Exist(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't query user: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return false, err
+	}
+
+	rec.Set("success", true)
+	rec.Set("exists", exists)
+	return exists, nil
+}
+
 // getUserByID queries a user by ID from the database
-func (s *SESC) getUserByID(ctx context.Context, id UUID) (*ent.User, error) {
+func (s *SESC) getUserByID(ctx context.Context, id UUID, includeArchived bool) (*ent.User, error) {
 	rec := event.Get(ctx)
 	rootRec := event.Root(ctx)
 	statrec := rootRec.Sub("stats")
 
 	rec.Set("id", id)
 
+	query := s.client.User.Query().Where(user.ID(id), user.OrgID(OrgFromContext(ctx)))
+	if !includeArchived {
+		query = query.Where(user.DeletedAtIsNil())
+	}
+
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	u, err := s.client.User.Query().Where(user.ID(id)).WithDepartment().Only(ctx)
+	u, err := query.WithDepartment().WithPermissions(orderPermissions).Only(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	switch {
@@ -888,7 +2530,7 @@ func (s *SESC) getUserByID(ctx context.Context, id UUID) (*ent.User, error) {
 		rec.Set("success", false)
 		return nil, ErrUserNotFound
 	case err != nil:
-		err := fmt.Errorf("couldn't query user: %w", err)
+		err := fmt.Errorf("couldn't query user: %w", WrapTimeout(ctx, err))
 		rec.Add(events.Error, err)
 		rec.Set("success", false)
 		return nil, err
@@ -902,7 +2544,7 @@ func (s *SESC) getUserByID(ctx context.Context, id UUID) (*ent.User, error) {
 func (s *SESC) convertUserFromEntity(ctx context.Context, u *ent.User) (User, error) {
 	rec := event.Get(ctx)
 
-	userObj, err := convertUser(u)
+	userObj, err := convertUser(ctx, u)
 	if err != nil {
 		rec.Add(events.Error, err)
 		rec.Set("success", false)
@@ -913,14 +2555,16 @@ func (s *SESC) convertUserFromEntity(ctx context.Context, u *ent.User) (User, er
 	return userObj, nil
 }
 
-// Users gets all users.
-func (s *SESC) Users(ctx context.Context) ([]User, error) {
+// Users gets the users matching the filter, except for the archived ones.
+func (s *SESC) Users(ctx context.Context, filter UserFilter) ([]User, error) {
 	// Caller should create the record and use Wrap to add it to the context
 	rec := event.Get(ctx).Sub("sesc/users")
 
+	filter.setParams(rec.Sub("params"))
+
 	// Stage 1: Query all users
 	ctx = rec.Sub("query_all_users").Wrap(ctx)
-	res, err := s.queryAllUsers(ctx)
+	res, err := s.queryAllUsers(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -935,19 +2579,184 @@ func (s *SESC) Users(ctx context.Context) ([]User, error) {
 	return users, nil
 }
 
+// UsersByIDs returns the users with the given IDs in a single query, in the order of the IDs and each once.
+// IDs of the users that do not exist or are archived are omitted.
+func (s *SESC) UsersByIDs(ctx context.Context, ids []UUID) ([]User, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/users_by_ids")
+
+	rec.Sub("params").Set("ids", ids)
+
+	if len(ids) == 0 {
+		return []User{}, nil
+	}
+
+	// Stage 1: Query the users
+	ctx = rec.Sub("query_users_by_ids").Wrap(ctx)
+	res, err := s.queryUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stage 2: Convert the users
+	ctx = rec.Sub("convert_all_users").Wrap(ctx)
+	converted, err := s.convertAllUsers(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[UUID]User, len(converted))
+	for _, u := range converted {
+		byID[u.ID] = u
+	}
+	users := make([]User, 0, len(byID))
+	for _, id := range ids {
+		if u, ok := byID[id]; ok {
+			users = append(users, u)
+			delete(byID, id)
+		}
+	}
+
+	rec.Set("found", len(users))
+	return users, nil
+}
+
+// queryUsersByIDs queries the users with the given IDs from the database, except for the archived ones
+func (s *SESC) queryUsersByIDs(ctx context.Context, ids []UUID) ([]*ent.User, error) {
+	rec := event.Get(ctx)
+	rootRec := event.Root(ctx)
+	statrec := rootRec.Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	res, err := s.client.User.Query().
+		Where(user.IDIn(ids...), user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		WithDepartment().
+		WithPermissions(orderPermissions).
+		All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't query users: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set("success", true)
+	return res, nil
+}
+
+// filteredUsers returns the query of the users of the org in ctx matching the filter, except for the archived ones.
+func (s *SESC) filteredUsers(ctx context.Context, filter UserFilter) *ent.UserQuery {
+	return filterUsers(ctx, s.client.User.Query(), filter)
+}
+
+// filterUsers narrows query to the users of the org in ctx matching the filter, except for the archived ones.
+func filterUsers(ctx context.Context, query *ent.UserQuery, filter UserFilter) *ent.UserQuery {
+	query = query.Where(user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil())
+	if filter.Suspended != nil {
+		query = query.Where(user.Suspended(*filter.Suspended))
+	}
+	if filter.AcademicDegree != nil {
+		query = query.Where(user.AcademicDegree(int32(*filter.AcademicDegree)))
+	}
+	if filter.EmploymentType != nil {
+		query = query.Where(user.EmploymentType(int32(*filter.EmploymentType)))
+	}
+	return query
+}
+
 // queryAllUsers queries all users from the database
-func (s *SESC) queryAllUsers(ctx context.Context) ([]*ent.User, error) {
+func (s *SESC) queryAllUsers(ctx context.Context, filter UserFilter) ([]*ent.User, error) {
 	rec := event.Get(ctx)
 	rootRec := event.Root(ctx)
 	statrec := rootRec.Sub("stats")
 
 	startTime := time.Now()
 	statrec.Add(events.PostgresQueries, 1)
-	res, err := s.client.User.Query().WithDepartment().All(ctx)
+	res, err := s.filteredUsers(ctx, filter).WithDepartment().WithPermissions(orderPermissions).All(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't query users: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set("success", true)
+	return res, nil
+}
+
+// UsersAfter returns up to limit users matching the filter with IDs greater than afterID, ordered by ID,
+// except for the archived ones. Since the IDs are UUIDv7, this is the order in which the users were created,
+// up to the users created within the same millisecond.
+// A uuid.Nil afterID starts from the first user.
+//
+// The returned cursor is the afterID of the next page, or uuid.Nil if there are no more users.
+func (s *SESC) UsersAfter(ctx context.Context, afterID UUID, limit int, filter UserFilter) ([]User, UUID, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/users_after")
+
+	rec.Sub("params").Set(
+		"after_id", afterID,
+		"limit", limit,
+	)
+	filter.setParams(rec.Sub("params"))
+
+	if limit <= 0 {
+		err := fmt.Errorf("limit must be positive, got %d", limit)
+		rec.Add(events.Error, err)
+		return nil, uuid.Nil, err
+	}
+
+	// Stage 1: Query the page of users
+	ctx = rec.Sub("query_users_page").Wrap(ctx)
+	res, err := s.queryUsersAfter(ctx, afterID, limit, filter)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	// One extra row tells whether there is a next page.
+	var next UUID
+	if len(res) > limit {
+		res = res[:limit]
+		next = res[limit-1].ID
+	}
+
+	// Stage 2: Convert the users
+	ctx = rec.Sub("convert_all_users").Wrap(ctx)
+	users, err := s.convertAllUsers(ctx, res)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	rec.Set(
+		"count", len(users),
+		"next", next,
+	)
+	return users, next, nil
+}
+
+// queryUsersAfter queries up to limit+1 users with IDs greater than afterID, ordered by ID.
+func (s *SESC) queryUsersAfter(ctx context.Context, afterID UUID, limit int, filter UserFilter) ([]*ent.User, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	res, err := s.filteredUsers(ctx, filter).
+		Where(user.IDGT(afterID)).
+		Order(ent.Asc(user.FieldID)).
+		Limit(limit + 1).
+		WithDepartment().
+		WithPermissions(orderPermissions).
+		All(ctx)
 	statrec.Add(events.PostgresTime, time.Since(startTime))
 
 	if err != nil {
-		err := fmt.Errorf("couldn't query users: %w", err)
+		err := fmt.Errorf("couldn't query users: %w", WrapTimeout(ctx, err))
 		rec.Add(events.Error, err)
 		rec.Set("success", false)
 		return nil, err
@@ -964,7 +2773,7 @@ func (s *SESC) convertAllUsers(ctx context.Context, entUsers []*ent.User) ([]Use
 	users := make([]User, len(entUsers))
 	for i, r := range entUsers {
 		var err error
-		users[i], err = convertUser(r)
+		users[i], err = convertUser(ctx, r)
 		if err != nil {
 			rec.Add(events.Error, err)
 			rec.Set("success", false)
@@ -976,12 +2785,12 @@ func (s *SESC) convertAllUsers(ctx context.Context, entUsers []*ent.User) ([]Use
 	return users, nil
 }
 
-// User returns a User by ID. Alias for UserByID.
+// User returns a User by ID. Alias for UserByID that excludes archived users.
 // Returns ErrUserNotFound if the user does not exist.
 func (s *SESC) User(ctx context.Context, id UUID) (User, error) {
 	rec := event.Get(ctx).Sub("sesc/user")
 
 	// Create a wrapped context for UserByID
 	ctx = rec.Sub("user_by_id").Wrap(ctx)
-	return s.UserByID(ctx, id)
+	return s.UserByID(ctx, id, false)
 }
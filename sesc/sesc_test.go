@@ -1,13 +1,18 @@
 package sesc
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/enttest"
 	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
 )
@@ -80,7 +85,101 @@ func TestCreateDepartment(t *testing.T) {
 		_, _ = svc.CreateDepartment(ctx, "IT", "IT Dept")
 		// Trying to create another department with the same name
 		_, err := svc.CreateDepartment(ctx, "IT", "Duplicate Dept")
-		require.ErrorIs(t, err, ErrInvalidDepartment)
+		require.ErrorIs(t, err, ErrDepartmentExists)
+	})
+
+	t.Run("case-insensitive collision", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		_, err := svc.CreateDepartment(ctx, "IT", "IT Dept")
+		require.NoError(t, err)
+
+		for _, name := range []string{"it", " It ", "iT\t"} {
+			_, err = svc.CreateDepartment(ctx, name, "Duplicate Dept")
+			require.ErrorIs(t, err, ErrDepartmentExists, name)
+		}
+
+		deps, err := svc.Departments(ctx)
+		require.NoError(t, err)
+		require.Len(t, deps, 1)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		for name, input := range map[string][2]string{
+			"blank name":       {"   ", "Desc"},
+			"long name":        {strings.Repeat("я", MaxDepartmentNameLength+1), "Desc"},
+			"long description": {"IT", strings.Repeat("a", MaxDepartmentDescriptionLength+1)},
+		} {
+			_, err := svc.CreateDepartment(ctx, input[0], input[1])
+			require.ErrorIs(t, err, ErrInvalidDepartmentName, name)
+		}
+
+		// The limits are inclusive, and count characters rather than bytes.
+		_, err := svc.CreateDepartment(
+			ctx,
+			strings.Repeat("я", MaxDepartmentNameLength),
+			strings.Repeat("я", MaxDepartmentDescriptionLength),
+		)
+		require.NoError(t, err)
+	})
+}
+
+func TestUpsertDepartment(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+
+		svc = setupSESC(t)
+		return ctx, svc
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		dep, err := svc.UpsertDepartment(ctx, "HR", "Human Resources")
+		require.NoError(t, err, "UpsertDepartment failed")
+		require.NotEqual(t, uuid.Nil, dep.ID)
+		requireDepartmentMatches(t, Department{ID: dep.ID, Name: "HR", Description: "Human Resources"}, dep)
+
+		got, err := svc.DepartmentByID(ctx, dep.ID)
+		require.NoError(t, err, "DepartmentByID failed")
+		requireDepartmentMatches(t, dep, got)
+	})
+
+	t.Run("update on conflict", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		existing, err := svc.CreateDepartment(ctx, "HR", "Human Resources")
+		require.NoError(t, err, "CreateDepartment failed")
+
+		dep, err := svc.UpsertDepartment(ctx, "HR", "People")
+		require.NoError(t, err, "UpsertDepartment failed")
+		requireDepartmentMatches(t, Department{ID: existing.ID, Name: "HR", Description: "People"}, dep)
+
+		deps, err := svc.Departments(ctx)
+		require.NoError(t, err, "Departments failed")
+		require.Len(t, deps, 1)
+		requireDepartmentMatches(t, dep, deps[0])
+	})
+
+	t.Run("update on case-insensitive conflict", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		existing, err := svc.CreateDepartment(ctx, "HR", "Human Resources")
+		require.NoError(t, err, "CreateDepartment failed")
+
+		dep, err := svc.UpsertDepartment(ctx, "hr ", "People")
+		require.NoError(t, err, "UpsertDepartment failed")
+		requireDepartmentMatches(t, Department{ID: existing.ID, Name: "HR", Description: "People"}, dep)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		_, err := svc.UpsertDepartment(ctx, "", "")
+		require.ErrorIs(t, err, ErrInvalidDepartmentName)
 	})
 }
 
@@ -157,6 +256,44 @@ func TestDepartmentByID(t *testing.T) {
 	})
 }
 
+func TestDepartmentsByIDs(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, math, physics Department) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		var err error
+		math, err = svc.CreateDepartment(ctx, "Math", "Mathematics")
+		require.NoError(t, err)
+		physics, err = svc.CreateDepartment(ctx, "Physics", "Physics Dept")
+		require.NoError(t, err)
+		_, err = svc.CreateDepartment(ctx, "Chemistry", "Chemistry Dept")
+		require.NoError(t, err)
+		return ctx, svc, math, physics
+	}
+
+	t.Run("existing and non-existent departments", func(t *testing.T) {
+		ctx, svc, math, physics := setup(t)
+		missing := uuid.Must(uuid.NewV7())
+
+		ctx, _ = event.NewRecord(ctx, "by_ids")
+		deps, err := svc.DepartmentsByIDs(ctx, []UUID{math.ID, missing, physics.ID})
+		require.NoError(t, err)
+		require.Equal(t, map[UUID]Department{math.ID: math, physics.ID: physics}, deps)
+		require.Equal(t, 1, event.Root(ctx).Value("stats."+events.PostgresQueries))
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		ctx, svc, _, _ := setup(t)
+
+		ctx, _ = event.NewRecord(ctx, "by_ids")
+		deps, err := svc.DepartmentsByIDs(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, deps)
+		require.Nil(t, event.Root(ctx).Value("stats."+events.PostgresQueries))
+	})
+}
+
 func TestGetAllDepartments(t *testing.T) {
 	setup := func(t *testing.T) (ctx context.Context, svc *SESC) {
 		ctx = t.Context()
@@ -237,11 +374,11 @@ func TestCreateUser(t *testing.T) {
 		}
 		requireUserMatches(t, expected, user)
 
-		savedUser, err := svc.UserByID(ctx, user.ID)
+		savedUser, err := svc.UserByID(ctx, user.ID, false)
 		require.NoError(t, err)
 		requireUserMatches(t, expected, savedUser)
 
-		us, err := svc.Users(ctx)
+		us, err := svc.Users(ctx, UserFilter{})
 		require.NoError(t, err)
 		require.Len(t, us, 1)
 	})
@@ -268,7 +405,7 @@ func TestCreateUser(t *testing.T) {
 		requireUserMatches(t, expected, user)
 
 		// Verify user is retrievable
-		savedUser, err := svc.UserByID(ctx, user.ID)
+		savedUser, err := svc.UserByID(ctx, user.ID, false)
 		require.NoError(t, err)
 		requireUserMatches(t, expected, savedUser)
 	})
@@ -317,7 +454,38 @@ func TestUpdateDepartment(t *testing.T) {
 		ctx, svc, _ := setup(t)
 
 		err := svc.UpdateDepartment(ctx, uuid.Must(uuid.NewV7()), "Name", "Desc")
-		require.ErrorIs(t, err, ErrInvalidDepartment)
+		require.ErrorIs(t, err, ErrDepartmentNotFound)
+	})
+
+	t.Run("name collision", func(t *testing.T) {
+		ctx, svc, id := setup(t)
+
+		_, err := svc.CreateDepartment(ctx, "Taken", "Taken Desc")
+		require.NoError(t, err)
+
+		err = svc.UpdateDepartment(ctx, id, "Taken", "Desc")
+		require.ErrorIs(t, err, ErrDepartmentExists)
+
+		err = svc.UpdateDepartment(ctx, id, " TAKEN", "Desc")
+		require.ErrorIs(t, err, ErrDepartmentExists)
+
+		dep, err := svc.DepartmentByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "Old", dep.Name)
+	})
+
+	t.Run("changing the case of its own name", func(t *testing.T) {
+		ctx, svc, id := setup(t)
+
+		err := svc.UpdateDepartment(ctx, id, "OLD", "Old Desc")
+		require.NoError(t, err)
+	})
+
+	t.Run("too long name", func(t *testing.T) {
+		ctx, svc, id := setup(t)
+
+		err := svc.UpdateDepartment(ctx, id, strings.Repeat("a", MaxDepartmentNameLength+1), "Desc")
+		require.ErrorIs(t, err, ErrInvalidDepartmentName)
 	})
 }
 
@@ -347,7 +515,7 @@ func TestUpdateProfilePicture(t *testing.T) {
 		err := svc.UpdateProfilePicture(ctx, userID, newURL)
 		require.NoError(t, err, "UpdateProfilePicture failed")
 
-		user, err := svc.UserByID(ctx, userID)
+		user, err := svc.UserByID(ctx, userID, false)
 		require.NoError(t, err)
 
 		expected := User{
@@ -368,177 +536,1337 @@ func TestUpdateProfilePicture(t *testing.T) {
 	})
 }
 
-func TestUpdateUser(t *testing.T) {
-	setup := func(t *testing.T) (ctx context.Context, svc *SESC, depID UUID, userID UUID) {
+func TestDeleteUser(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID UUID) {
 		ctx = t.Context()
 		ctx, _ = event.NewRecord(ctx, "test")
 		svc = setupSESC(t)
 
-		// Create department
-		dep, _ := svc.CreateDepartment(ctx, "Dep", "Dep")
-		depID = dep.ID
-
-		// Create user
-		opts := UserUpdateOptions{
-			FirstName:    "Original",
-			LastName:     "User",
-			DepartmentID: depID,
-			NewRoleID:    1,
-		}
-
-		user, err := svc.CreateUser(ctx, opts)
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: 1,
+		})
 		require.NoError(t, err)
 
-		return ctx, svc, depID, user.ID
+		return ctx, svc, user.ID
 	}
 
 	t.Run("success", func(t *testing.T) {
-		ctx, svc, depID, userID := setup(t)
-		opts := UserUpdateOptions{
-			FirstName:    "Updated",
-			LastName:     "User",
-			DepartmentID: depID,
-			NewRoleID:    2,
-		}
-
-		user, err := svc.UpdateUser(ctx, userID, opts)
-		require.NoError(t, err, "UpdateUser failed")
+		ctx, svc, userID := setup(t)
 
-		expected := User{
-			ID:         userID,
-			FirstName:  opts.FirstName,
-			LastName:   opts.LastName,
-			Department: Department{ID: depID},
-			Role:       Role{ID: opts.NewRoleID},
-		}
-		requireUserMatches(t, expected, user)
-	})
+		err := svc.DeleteUser(ctx, userID)
+		require.NoError(t, err, "DeleteUser failed")
 
-	t.Run("non-existent user", func(t *testing.T) {
-		ctx, svc, _, _ := setup(t)
-		_, err := svc.UpdateUser(ctx, uuid.Must(uuid.NewV7()), UserUpdateOptions{})
+		_, err = svc.UserByID(ctx, userID, false)
 		require.ErrorIs(t, err, ErrUserNotFound)
 	})
 
-	t.Run("invalid department", func(t *testing.T) {
-		ctx, svc, _, userID := setup(t)
-		opts := UserUpdateOptions{
-			FirstName:    "Updated",
-			LastName:     "User",
-			DepartmentID: uuid.Must(uuid.NewV7()),
-			NewRoleID:    1,
-		}
-		_, err := svc.UpdateUser(ctx, userID, opts)
-		require.ErrorIs(t, err, ErrInvalidDepartment)
-	})
-
-	t.Run("remove department", func(t *testing.T) {
-		ctx, svc, _, userID := setup(t)
-		opts := UserUpdateOptions{
-			FirstName: "Updated",
-			LastName:  "User",
-			NewRoleID: 2,
-		}
-		res, err := svc.UpdateUser(ctx, userID, opts)
-		require.NoError(t, err)
-
-		expected := User{
-			ID:         userID,
-			FirstName:  opts.FirstName,
-			LastName:   opts.LastName,
-			Department: NoDepartment,
-			Role:       Role{ID: opts.NewRoleID},
-		}
-		requireUserMatches(t, expected, res)
-	})
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
 
-	t.Run("invalid role", func(t *testing.T) {
-		ctx, svc, _, userID := setup(t)
-		opts := UserUpdateOptions{
-			FirstName: "Updated",
-			LastName:  "User",
-			NewRoleID: 999,
-		}
-		_, err := svc.UpdateUser(ctx, userID, opts)
-		require.ErrorIs(t, err, ErrInvalidRole)
+		err := svc.DeleteUser(ctx, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, ErrUserNotFound)
 	})
 }
 
-func TestUserByID(t *testing.T) {
+func TestArchiveUser(t *testing.T) {
 	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID UUID) {
 		ctx = t.Context()
 		ctx, _ = event.NewRecord(ctx, "test")
 		svc = setupSESC(t)
 
-		// Create user
-		opts := UserUpdateOptions{
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
 			FirstName: "John",
 			LastName:  "Doe",
 			NewRoleID: 1,
-		}
-
-		user, err := svc.CreateUser(ctx, opts)
+		})
 		require.NoError(t, err)
 
 		return ctx, svc, user.ID
 	}
 
-	t.Run("existing user", func(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
 		ctx, svc, userID := setup(t)
 
-		user, err := svc.UserByID(ctx, userID)
-		require.NoError(t, err, "UserByID failed")
+		err := svc.ArchiveUser(ctx, userID)
+		require.NoError(t, err, "ArchiveUser failed")
 
-		expected := User{
-			ID:        userID,
-			FirstName: "John",
+		_, err = svc.UserByID(ctx, userID, false)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		_, err = svc.User(ctx, userID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		user, err := svc.UserByID(ctx, userID, true)
+		require.NoError(t, err)
+		require.True(t, user.Archived())
+		require.Equal(t, "John", user.FirstName)
+	})
+
+	t.Run("excluded from listing", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		other, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "Jane",
 			LastName:  "Doe",
-			Role:      Role{ID: 1},
-		}
-		requireUserMatches(t, expected, user)
+			NewRoleID: 1,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.ArchiveUser(ctx, userID))
+
+		users, err := svc.Users(ctx, UserFilter{})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, other.ID, users[0].ID)
+	})
+
+	t.Run("already archived", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		require.NoError(t, svc.ArchiveUser(ctx, userID))
+
+		err := svc.ArchiveUser(ctx, userID)
+		require.ErrorIs(t, err, ErrUserNotFound)
 	})
 
 	t.Run("non-existent user", func(t *testing.T) {
 		ctx, svc, _ := setup(t)
 
-		_, err := svc.UserByID(ctx, uuid.Must(uuid.NewV7()))
+		err := svc.ArchiveUser(ctx, uuid.Must(uuid.NewV7()))
 		require.ErrorIs(t, err, ErrUserNotFound)
 	})
 }
 
-func TestGetAllUsers(t *testing.T) {
-	setup := func(t *testing.T) (ctx context.Context, svc *SESC) {
+func TestSetSuspended(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID UUID) {
 		ctx = t.Context()
 		ctx, _ = event.NewRecord(ctx, "test")
 		svc = setupSESC(t)
 
-		// Create some users
-		for i := range 2 {
-			opts := UserUpdateOptions{
-				FirstName: fmt.Sprintf("User%d", i+1),
-				LastName:  fmt.Sprintf("User%d", i+1),
-				NewRoleID: 1,
-			}
-			_, err := svc.CreateUser(ctx, opts)
-			require.NoError(t, err)
-		}
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: 1,
+		})
+		require.NoError(t, err)
 
-		return ctx, svc
+		return ctx, svc, user.ID
 	}
 
-	t.Run("fetch all users", func(t *testing.T) {
-		ctx, svc := setup(t)
+	t.Run("suspend and reinstate", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
 
-		users, err := svc.Users(ctx)
-		require.NoError(t, err, "Users failed")
-		require.Len(t, users, 2, "Expected 2 users")
+		changed, err := svc.SetSuspended(ctx, userID, true)
+		require.NoError(t, err)
+		require.True(t, changed)
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, user.Suspended)
+		require.Equal(t, 2, user.Version, "Version should be bumped")
 
-		// Verify user fields
-		for _, user := range users {
-			require.NotEqual(t, uuid.Nil, user.ID, "User ID should not be nil")
-			require.NotEmpty(t, user.FirstName, "User FirstName should not be empty")
-			require.NotEmpty(t, user.LastName, "User LastName should not be empty")
-			require.Equal(t, int32(1), user.Role.ID, "User Role.ID should be 1")
-		}
+		changed, err = svc.SetSuspended(ctx, userID, false)
+		require.NoError(t, err)
+		require.True(t, changed)
+		user, err = svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.False(t, user.Suspended)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		changed, err := svc.SetSuspended(ctx, userID, false)
+		require.NoError(t, err)
+		require.False(t, changed, "The user is not suspended yet")
+
+		_, err = svc.SetSuspended(ctx, userID, true)
+		require.NoError(t, err)
+		changed, err = svc.SetSuspended(ctx, userID, true)
+		require.NoError(t, err)
+		require.False(t, changed)
+
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, user.Suspended)
+		require.Equal(t, 2, user.Version, "Version should only be bumped by the change")
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		active, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			NewRoleID: 1,
+		})
+		require.NoError(t, err)
+		_, err = svc.SetSuspended(ctx, userID, true)
+		require.NoError(t, err)
+
+		suspended := true
+		users, err := svc.Users(ctx, UserFilter{Suspended: &suspended})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, userID, users[0].ID)
+
+		suspended = false
+		users, err = svc.Users(ctx, UserFilter{Suspended: &suspended})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, active.ID, users[0].ID)
+
+		users, err = svc.Users(ctx, UserFilter{})
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		_, err := svc.SetSuspended(ctx, uuid.Must(uuid.NewV7()), true)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("archived user", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		require.NoError(t, svc.ArchiveUser(ctx, userID))
+
+		_, err := svc.SetSuspended(ctx, userID, true)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestSetRole(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		dept, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName:    "John",
+			LastName:     "Doe",
+			NewRoleID:    Teacher.ID,
+			DepartmentID: dept.ID,
+		})
+		require.NoError(t, err)
+
+		return ctx, svc, user.ID
+	}
+
+	t.Run("legal transition keeps the department", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		require.NoError(t, svc.SetRole(ctx, userID, Dephead.ID))
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, Dephead.ID, user.Role.ID)
+		require.NotEqual(t, NoDepartment, user.Department)
+		require.Equal(t, 2, user.Version, "Version should be bumped")
+	})
+
+	t.Run("role that cannot have the department", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		err := svc.SetRole(ctx, userID, ContestDeputy.ID)
+		require.ErrorIs(t, err, ErrInvalidRoleChange)
+
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, Teacher.ID, user.Role.ID, "Role should not change")
+		require.Equal(t, 1, user.Version)
+	})
+
+	t.Run("user without a department", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.SetRole(ctx, user.ID, ScientificDeputy.ID))
+		user, err = svc.User(ctx, user.ID)
+		require.NoError(t, err)
+		require.Equal(t, ScientificDeputy.ID, user.Role.ID)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		err := svc.SetRole(ctx, userID, 999)
+		require.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		err := svc.SetRole(ctx, uuid.Must(uuid.NewV7()), Teacher.ID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestSetDepartment(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID, deptID UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		dept, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.NoError(t, err)
+
+		return ctx, svc, user.ID, dept.ID
+	}
+
+	t.Run("assign and clear", func(t *testing.T) {
+		ctx, svc, userID, deptID := setup(t)
+
+		require.NoError(t, svc.SetDepartment(ctx, userID, deptID))
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, deptID, user.Department.ID)
+		require.Equal(t, 2, user.Version, "Version should be bumped")
+
+		require.NoError(t, svc.SetDepartment(ctx, userID, uuid.Nil))
+		user, err = svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, NoDepartment, user.Department)
+	})
+
+	t.Run("non-existent department", func(t *testing.T) {
+		ctx, svc, userID, _ := setup(t)
+
+		err := svc.SetDepartment(ctx, userID, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+	})
+
+	t.Run("role that cannot have a department", func(t *testing.T) {
+		ctx, svc, _, deptID := setup(t)
+
+		deputy, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			NewRoleID: ContestDeputy.ID,
+		})
+		require.NoError(t, err)
+
+		err = svc.SetDepartment(ctx, deputy.ID, deptID)
+		require.ErrorIs(t, err, ErrInvalidRoleChange)
+
+		require.NoError(t, svc.SetDepartment(ctx, deputy.ID, uuid.Nil), "Clearing is always allowed")
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _, deptID := setup(t)
+
+		err := svc.SetDepartment(ctx, uuid.Must(uuid.NewV7()), deptID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, depID UUID, userID UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		// Create department
+		dep, _ := svc.CreateDepartment(ctx, "Dep", "Dep")
+		depID = dep.ID
+
+		// Create user
+		opts := UserUpdateOptions{
+			FirstName:    "Original",
+			LastName:     "User",
+			DepartmentID: depID,
+			NewRoleID:    1,
+		}
+
+		user, err := svc.CreateUser(ctx, opts)
+		require.NoError(t, err)
+
+		return ctx, svc, depID, user.ID
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctx, svc, depID, userID := setup(t)
+		opts := UserUpdateOptions{
+			FirstName:    "Updated",
+			LastName:     "User",
+			DepartmentID: depID,
+			NewRoleID:    2,
+		}
+
+		user, err := svc.UpdateUser(ctx, userID, opts)
+		require.NoError(t, err, "UpdateUser failed")
+
+		expected := User{
+			ID:         userID,
+			FirstName:  opts.FirstName,
+			LastName:   opts.LastName,
+			Department: Department{ID: depID},
+			Role:       Role{ID: opts.NewRoleID},
+		}
+		requireUserMatches(t, expected, user)
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _, _ := setup(t)
+		_, err := svc.UpdateUser(ctx, uuid.Must(uuid.NewV7()), UserUpdateOptions{})
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("invalid department", func(t *testing.T) {
+		ctx, svc, _, userID := setup(t)
+		opts := UserUpdateOptions{
+			FirstName:    "Updated",
+			LastName:     "User",
+			DepartmentID: uuid.Must(uuid.NewV7()),
+			NewRoleID:    1,
+		}
+		_, err := svc.UpdateUser(ctx, userID, opts)
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+	})
+
+	t.Run("remove department", func(t *testing.T) {
+		ctx, svc, _, userID := setup(t)
+		opts := UserUpdateOptions{
+			FirstName: "Updated",
+			LastName:  "User",
+			NewRoleID: 2,
+		}
+		res, err := svc.UpdateUser(ctx, userID, opts)
+		require.NoError(t, err)
+
+		expected := User{
+			ID:         userID,
+			FirstName:  opts.FirstName,
+			LastName:   opts.LastName,
+			Department: NoDepartment,
+			Role:       Role{ID: opts.NewRoleID},
+		}
+		requireUserMatches(t, expected, res)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		ctx, svc, _, userID := setup(t)
+		opts := UserUpdateOptions{
+			FirstName: "Updated",
+			LastName:  "User",
+			NewRoleID: 999,
+		}
+		_, err := svc.UpdateUser(ctx, userID, opts)
+		require.ErrorIs(t, err, ErrInvalidRole)
+	})
+
+	t.Run("fresh version", func(t *testing.T) {
+		ctx, svc, _, userID := setup(t)
+
+		user, err := svc.UserByID(ctx, userID, false)
+		require.NoError(t, err)
+		require.Equal(t, 1, user.Version)
+
+		opts := user.UpdateOptions()
+		opts.FirstName = "Updated"
+		updated, err := svc.UpdateUser(ctx, userID, opts)
+		require.NoError(t, err)
+		require.Equal(t, "Updated", updated.FirstName)
+		require.Equal(t, 2, updated.Version)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		ctx, svc, _, userID := setup(t)
+
+		user, err := svc.UserByID(ctx, userID, false)
+		require.NoError(t, err)
+
+		// Someone else updates the user first
+		first := user.UpdateOptions()
+		first.FirstName = "First"
+		_, err = svc.UpdateUser(ctx, userID, first)
+		require.NoError(t, err)
+
+		second := user.UpdateOptions()
+		second.FirstName = "Second"
+		_, err = svc.UpdateUser(ctx, userID, second)
+		require.ErrorIs(t, err, ErrStaleUser)
+
+		current, err := svc.UserByID(ctx, userID, false)
+		require.NoError(t, err)
+		require.Equal(t, "First", current.FirstName)
+		require.Equal(t, 2, current.Version)
+	})
+
+	t.Run("employment data", func(t *testing.T) {
+		ctx, svc, depID, userID := setup(t)
+		hired := time.Date(2015, time.September, 1, 0, 0, 0, 0, time.UTC)
+		opts := UserUpdateOptions{
+			FirstName:         "Updated",
+			LastName:          "User",
+			DepartmentID:      depID,
+			NewRoleID:         1,
+			Subdivision:       "Physics and mathematics",
+			JobTitle:          "Teacher of mathematics",
+			EmploymentRate:    1.5,
+			PersonnelCategory: Pedagogical,
+			EmploymentType:    InternalPartTime,
+			AcademicDegree:    Candidate,
+			AcademicTitle:     "Docent",
+			Honors:            "Honored Teacher",
+			Category:          "Highest",
+			DateOfEmployment:  &hired,
+		}
+
+		user, err := svc.UpdateUser(ctx, userID, opts)
+		require.NoError(t, err, "UpdateUser failed")
+		require.Equal(t, opts, user.UpdateOptions().withoutVersion())
+
+		opts.EmploymentRate = 3
+		_, err = svc.UpdateUser(ctx, userID, opts)
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+	})
+}
+
+// withoutVersion returns the options without the expected user version.
+func (u UserUpdateOptions) withoutVersion() UserUpdateOptions {
+	u.Version = 0
+	return u
+}
+
+func TestUserUpdateOptionsValidate(t *testing.T) {
+	hired := time.Date(2015, time.September, 1, 0, 0, 0, 0, time.UTC)
+	fired := time.Date(2014, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	valid := UserUpdateOptions{
+		FirstName:         "John",
+		LastName:          "Doe",
+		NewRoleID:         1,
+		EmploymentRate:    MaxEmploymentRate,
+		PersonnelCategory: ServiceStaff,
+		EmploymentType:    ExternalPartTime,
+		AcademicDegree:    Doctor,
+		DateOfEmployment:  &hired,
+		UnemploymentDate:  &hired,
+	}
+	require.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(u *UserUpdateOptions)
+	}{
+		{"negative employment rate", func(u *UserUpdateOptions) { u.EmploymentRate = -0.5 }},
+		{"employment rate above maximum", func(u *UserUpdateOptions) { u.EmploymentRate = 2.25 }},
+		{"unemployed before employment", func(u *UserUpdateOptions) { u.UnemploymentDate = &fired }},
+		{"unknown personnel category", func(u *UserUpdateOptions) { u.PersonnelCategory = ServiceStaff + 1 }},
+		{"unknown employment type", func(u *UserUpdateOptions) { u.EmploymentType = -1 }},
+		{"unknown academic degree", func(u *UserUpdateOptions) { u.AcademicDegree = Doctor + 1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.modify(&opts)
+			require.ErrorIs(t, opts.Validate(), ErrInvalidEmploymentData)
+		})
+	}
+}
+
+func TestUserByID(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		// Create user
+		opts := UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: 1,
+		}
+
+		user, err := svc.CreateUser(ctx, opts)
+		require.NoError(t, err)
+
+		return ctx, svc, user.ID
+	}
+
+	t.Run("existing user", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		user, err := svc.UserByID(ctx, userID, false)
+		require.NoError(t, err, "UserByID failed")
+
+		expected := User{
+			ID:        userID,
+			FirstName: "John",
+			LastName:  "Doe",
+			Role:      Role{ID: 1},
+		}
+		requireUserMatches(t, expected, user)
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		_, err := svc.UserByID(ctx, uuid.Must(uuid.NewV7()), false)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestUserExists(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.NoError(t, err)
+
+		// A fresh record, so that only the queries of UserExists are counted
+		ctx, _ = event.NewRecord(t.Context(), "test")
+		return ctx, svc, user.ID
+	}
+
+	t.Run("existing user", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		exists, err := svc.UserExists(ctx, userID)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Equal(t, 1, event.Root(ctx).Value("stats."+events.PostgresQueries))
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		exists, err := svc.UserExists(ctx, uuid.Must(uuid.NewV7()))
+		require.NoError(t, err)
+		require.False(t, exists)
+		require.Equal(t, 1, event.Root(ctx).Value("stats."+events.PostgresQueries))
+	})
+
+	t.Run("archived user", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+		require.NoError(t, svc.ArchiveUser(ctx, userID))
+
+		exists, err := svc.UserExists(ctx, userID)
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+
+	t.Run("update of a non-existent user", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		_, err := svc.UpdateUser(ctx, uuid.Must(uuid.NewV7()), UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.ErrorIs(t, err, ErrUserNotFound)
+		require.Equal(t, 1, event.Root(ctx).Value("stats."+events.PostgresQueries))
+	})
+}
+
+func TestUsersByIDs(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, ids []UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		dept, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+
+		for _, name := range []string{"Alice", "Bob", "Carol"} {
+			user, err := svc.CreateUser(ctx, UserUpdateOptions{
+				FirstName:    name,
+				LastName:     "Doe",
+				NewRoleID:    Teacher.ID,
+				DepartmentID: dept.ID,
+			})
+			require.NoError(t, err)
+			ids = append(ids, user.ID)
+		}
+
+		// A fresh record, so that only the queries of UsersByIDs are counted
+		ctx, _ = event.NewRecord(t.Context(), "test")
+		return ctx, svc, ids
+	}
+
+	t.Run("existing and missing users", func(t *testing.T) {
+		ctx, svc, ids := setup(t)
+		missing := uuid.Must(uuid.NewV7())
+
+		users, err := svc.UsersByIDs(ctx, []UUID{ids[2], missing, ids[0], ids[2]})
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.Equal(t, "Carol", users[0].FirstName)
+		require.Equal(t, "Alice", users[1].FirstName)
+		require.Equal(t, "Math", users[0].Department.Name, "Departments are loaded")
+		require.Equal(t, 1, event.Root(ctx).Value("stats."+events.PostgresQueries))
+	})
+
+	t.Run("archived user", func(t *testing.T) {
+		ctx, svc, ids := setup(t)
+		require.NoError(t, svc.ArchiveUser(ctx, ids[1]))
+
+		users, err := svc.UsersByIDs(ctx, ids)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.Equal(t, ids[0], users[0].ID)
+		require.Equal(t, ids[2], users[1].ID)
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		users, err := svc.UsersByIDs(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, users)
+		require.Nil(t, event.Root(ctx).Value("stats."+events.PostgresQueries))
+	})
+}
+
+func TestGetAllUsers(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		// Create some users
+		for i := range 2 {
+			opts := UserUpdateOptions{
+				FirstName: fmt.Sprintf("User%d", i+1),
+				LastName:  fmt.Sprintf("User%d", i+1),
+				NewRoleID: 1,
+			}
+			_, err := svc.CreateUser(ctx, opts)
+			require.NoError(t, err)
+		}
+
+		return ctx, svc
+	}
+
+	t.Run("fetch all users", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		users, err := svc.Users(ctx, UserFilter{})
+		require.NoError(t, err, "Users failed")
+		require.Len(t, users, 2, "Expected 2 users")
+
+		// Verify user fields
+		for _, user := range users {
+			require.NotEqual(t, uuid.Nil, user.ID, "User ID should not be nil")
+			require.NotEmpty(t, user.FirstName, "User FirstName should not be empty")
+			require.NotEmpty(t, user.LastName, "User LastName should not be empty")
+			require.Equal(t, int32(1), user.Role.ID, "User Role.ID should be 1")
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := svc.Users(ctx, UserFilter{})
+		require.ErrorIs(t, err, ErrTimeout)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestUsersAfter(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, ids []UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		for i := range 7 {
+			u, err := svc.CreateUser(ctx, UserUpdateOptions{
+				FirstName: fmt.Sprintf("User%d", i+1),
+				LastName:  fmt.Sprintf("User%d", i+1),
+				NewRoleID: Teacher.ID,
+			})
+			require.NoError(t, err)
+			ids = append(ids, u.ID)
+		}
+		// UUIDv7 created within the same millisecond are not necessarily ordered.
+		slices.SortFunc(ids, func(a, b UUID) int { return bytes.Compare(a[:], b[:]) })
+
+		return ctx, svc, ids
+	}
+
+	t.Run("iterate all pages", func(t *testing.T) {
+		ctx, svc, ids := setup(t)
+
+		var got []UUID
+		var pages int
+		cursor := uuid.Nil
+		for {
+			users, next, err := svc.UsersAfter(ctx, cursor, 3, UserFilter{})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(users), 3)
+			for _, u := range users {
+				got = append(got, u.ID)
+			}
+			pages++
+			if next == uuid.Nil {
+				break
+			}
+			require.Equal(t, users[len(users)-1].ID, next)
+			cursor = next
+		}
+
+		require.Equal(t, 3, pages)
+		require.Equal(t, ids, got, "Every user exactly once, ordered by ID")
+	})
+
+	t.Run("exact last page", func(t *testing.T) {
+		ctx, svc, ids := setup(t)
+
+		users, next, err := svc.UsersAfter(ctx, ids[3], 3, UserFilter{})
+		require.NoError(t, err)
+		require.Len(t, users, 3)
+		require.Equal(t, uuid.Nil, next, "No more users")
+	})
+
+	t.Run("archived and filtered users are skipped", func(t *testing.T) {
+		ctx, svc, ids := setup(t)
+
+		require.NoError(t, svc.ArchiveUser(ctx, ids[1]))
+		_, err := svc.SetSuspended(ctx, ids[2], true)
+		require.NoError(t, err)
+
+		users, _, err := svc.UsersAfter(ctx, uuid.Nil, 3, UserFilter{})
+		require.NoError(t, err)
+		require.Equal(t, []UUID{ids[0], ids[2], ids[3]}, []UUID{users[0].ID, users[1].ID, users[2].ID})
+
+		suspended := true
+		users, next, err := svc.UsersAfter(ctx, uuid.Nil, 3, UserFilter{Suspended: &suspended})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, ids[2], users[0].ID)
+		require.Equal(t, uuid.Nil, next)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		_, _, err := svc.UsersAfter(ctx, uuid.Nil, 0, UserFilter{})
+		require.Error(t, err)
+	})
+}
+
+func TestPermissionsForRole(t *testing.T) {
+	for _, role := range Roles {
+		perms, err := PermissionsForRole(role.ID)
+		require.NoError(t, err)
+		require.Equal(t, role.EffectivePermissions(), perms, role.Name)
+		require.Equal(t, role.Permissions, perms[:len(role.Permissions)], "Own permissions go first")
+	}
+
+	perms, err := PermissionsForRole(Teacher.ID)
+	require.NoError(t, err)
+	perms[0] = PermissionScientificReview
+	require.Equal(t, PermissionDraftAchievementList, Teacher.Permissions[0], "Role definitions must not be modified")
+
+	_, err = PermissionsForRole(0)
+	require.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestGrantPermissions(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, userID UUID) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		user, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+		})
+		require.NoError(t, err)
+
+		return ctx, svc, user.ID
+	}
+
+	t.Run("grant and revoke", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		err := svc.GrantPermissions(ctx, userID, []int32{
+			PermissionScientificReview.ID,
+			PermissionDepheadReview.ID,
+			PermissionDepheadReview.ID,
+		})
+		require.NoError(t, err)
+
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, []Permission{PermissionDepheadReview, PermissionScientificReview}, user.ExtraPermissions)
+		require.Equal(t, []Permission{
+			PermissionDraftAchievementList,
+			PermissionDepheadReview,
+			PermissionScientificReview,
+		}, user.Permissions())
+		require.True(t, user.HasPermission(PermissionDepheadReview))
+		require.False(t, user.HasPermission(PermissionContestReview))
+		require.Equal(t, 2, user.Version, "Version should be bumped")
+
+		// Granting again is a no-op
+		require.NoError(t, svc.GrantPermissions(ctx, userID, []int32{PermissionDepheadReview.ID}))
+		user, err = svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, user.ExtraPermissions, 2)
+
+		require.NoError(t, svc.RevokePermissions(ctx, userID, []int32{PermissionDepheadReview.ID}))
+		user, err = svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, []Permission{PermissionScientificReview}, user.ExtraPermissions)
+		require.False(t, user.HasPermission(PermissionDepheadReview))
+	})
+
+	t.Run("role permissions cannot be revoked", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		require.NoError(t, svc.RevokePermissions(ctx, userID, []int32{PermissionDraftAchievementList.ID}))
+
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, Teacher.Permissions, user.Permissions())
+	})
+
+	t.Run("granted role permission is listed once", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		require.NoError(t, svc.GrantPermissions(ctx, userID, []int32{PermissionDraftAchievementList.ID}))
+
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Equal(t, Teacher.Permissions, user.Permissions())
+	})
+
+	t.Run("invalid permission", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		err := svc.GrantPermissions(ctx, userID, []int32{PermissionDepheadReview.ID, 100})
+		require.ErrorIs(t, err, ErrInvalidPermission)
+
+		err = svc.RevokePermissions(ctx, userID, []int32{100})
+		require.ErrorIs(t, err, ErrInvalidPermission)
+
+		user, err := svc.User(ctx, userID)
+		require.NoError(t, err)
+		require.Empty(t, user.ExtraPermissions)
+		require.Equal(t, 1, user.Version)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		err := svc.GrantPermissions(ctx, uuid.Must(uuid.NewV7()), []int32{PermissionDepheadReview.ID})
+		require.ErrorIs(t, err, ErrUserNotFound)
+
+		err = svc.RevokePermissions(ctx, uuid.Must(uuid.NewV7()), []int32{PermissionDepheadReview.ID})
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("archived user", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		require.NoError(t, svc.ArchiveUser(ctx, userID))
+
+		err := svc.GrantPermissions(ctx, userID, []int32{PermissionDepheadReview.ID})
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("deleting the user deletes the grants", func(t *testing.T) {
+		ctx, svc, userID := setup(t)
+
+		require.NoError(t, svc.GrantPermissions(ctx, userID, []int32{PermissionDepheadReview.ID}))
+		require.NoError(t, svc.DeleteUser(ctx, userID))
+
+		count, err := svc.client.UserPermission.Query().Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+	})
+}
+
+func TestUsersInDepartment(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, math, physics Department) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		math, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+		physics, err = svc.CreateDepartment(ctx, "Physics", "Physics department")
+		require.NoError(t, err)
+
+		return ctx, svc, math, physics
+	}
+
+	createUser := func(ctx context.Context, t *testing.T, svc *SESC, deptID UUID) User {
+		t.Helper()
+		u, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName:    "John",
+			LastName:     "Teacher",
+			NewRoleID:    Teacher.ID,
+			DepartmentID: deptID,
+		})
+		require.NoError(t, err)
+		return u
+	}
+
+	t.Run("populated department", func(t *testing.T) {
+		ctx, svc, math, physics := setup(t)
+		first := createUser(ctx, t, svc, math.ID)
+		second := createUser(ctx, t, svc, math.ID)
+		archived := createUser(ctx, t, svc, math.ID)
+		require.NoError(t, svc.ArchiveUser(ctx, archived.ID))
+		createUser(ctx, t, svc, physics.ID)
+		createUser(ctx, t, svc, uuid.Nil)
+
+		users, err := svc.UsersInDepartment(ctx, math.ID)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.ElementsMatch(t, []UUID{first.ID, second.ID}, []UUID{users[0].ID, users[1].ID})
+		for _, u := range users {
+			require.Equal(t, math.ID, u.Department.ID)
+		}
+	})
+
+	t.Run("empty department", func(t *testing.T) {
+		ctx, svc, math, physics := setup(t)
+		createUser(ctx, t, svc, physics.ID)
+
+		users, err := svc.UsersInDepartment(ctx, math.ID)
+		require.NoError(t, err)
+		require.Empty(t, users)
+	})
+
+	t.Run("nonexistent department", func(t *testing.T) {
+		ctx, svc, _, _ := setup(t)
+
+		_, err := svc.UsersInDepartment(ctx, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, ErrDepartmentNotFound)
+	})
+}
+
+func TestDepartmentUserCount(t *testing.T) {
+	ctx, _ := event.NewRecord(t.Context(), "test")
+	svc := setupSESC(t)
+
+	math, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+	require.NoError(t, err)
+	physics, err := svc.CreateDepartment(ctx, "Physics", "Physics department")
+	require.NoError(t, err)
+
+	count, err := svc.DepartmentUserCount(ctx, math.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	for range 2 {
+		_, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName:    "John",
+			LastName:     "Teacher",
+			NewRoleID:    Teacher.ID,
+			DepartmentID: math.ID,
+		})
+		require.NoError(t, err)
+	}
+	archived, err := svc.CreateUser(ctx, UserUpdateOptions{
+		FirstName:    "Jane",
+		LastName:     "Teacher",
+		NewRoleID:    Teacher.ID,
+		DepartmentID: physics.ID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.ArchiveUser(ctx, archived.ID))
+
+	count, err = svc.DepartmentUserCount(ctx, math.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	count, err = svc.DepartmentUserCount(ctx, physics.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, count, "Archived users are counted")
+	require.ErrorIs(t, svc.DeleteDepartment(ctx, physics.ID), ErrCannotRemoveDepartment)
+
+	_, err = svc.DepartmentUserCount(ctx, uuid.Must(uuid.NewV7()))
+	require.ErrorIs(t, err, ErrDepartmentNotFound)
+}
+
+func TestDepartmentHead(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, dept Department) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		dept, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+
+		_, err = svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName:    "John",
+			LastName:     "Teacher",
+			NewRoleID:    Teacher.ID,
+			DepartmentID: dept.ID,
+		})
+		require.NoError(t, err)
+
+		return ctx, svc, dept
+	}
+
+	createHead := func(ctx context.Context, t *testing.T, svc *SESC, dept Department, name string) User {
+		t.Helper()
+		head, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName:    name,
+			LastName:     "Head",
+			NewRoleID:    Dephead.ID,
+			DepartmentID: dept.ID,
+		})
+		require.NoError(t, err)
+		return head
+	}
+
+	t.Run("department with a head", func(t *testing.T) {
+		ctx, svc, dept := setup(t)
+		head := createHead(ctx, t, svc, dept, "Jane")
+
+		// Heads of other departments are not returned
+		other, err := svc.CreateDepartment(ctx, "Physics", "Physics department")
+		require.NoError(t, err)
+		createHead(ctx, t, svc, other, "Jim")
+
+		got, err := svc.DepartmentHead(ctx, dept.ID)
+		require.NoError(t, err)
+		require.Equal(t, head.ID, got.ID)
+		require.Equal(t, dept.ID, got.Department.ID)
+	})
+
+	t.Run("department without a head", func(t *testing.T) {
+		ctx, svc, dept := setup(t)
+
+		_, err := svc.DepartmentHead(ctx, dept.ID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("archived head", func(t *testing.T) {
+		ctx, svc, dept := setup(t)
+		head := createHead(ctx, t, svc, dept, "Jane")
+		require.NoError(t, svc.ArchiveUser(ctx, head.ID))
+
+		_, err := svc.DepartmentHead(ctx, dept.ID)
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("nonexistent department", func(t *testing.T) {
+		ctx, svc, _ := setup(t)
+
+		_, err := svc.DepartmentHead(ctx, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, ErrDepartmentNotFound)
+	})
+
+	t.Run("several heads", func(t *testing.T) {
+		ctx, svc, dept := setup(t)
+		first := createHead(ctx, t, svc, dept, "Jane")
+		createHead(ctx, t, svc, dept, "Jim")
+
+		ctx, rec := event.NewRecord(ctx, "test")
+		for range 3 {
+			got, err := svc.DepartmentHead(ctx, dept.ID)
+			require.NoError(t, err)
+			require.Equal(t, first.ID, got.ID)
+		}
+		require.NotNil(t, rec.Value("sesc/department_head."+events.Warning))
+	})
+}
+
+func TestCreateDepartmentWithHead(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+		return ctx, svc
+	}
+
+	requireNothingCreated := func(ctx context.Context, t *testing.T, svc *SESC, wantDepartments int) {
+		t.Helper()
+		depts, err := svc.Departments(ctx)
+		require.NoError(t, err)
+		require.Len(t, depts, wantDepartments)
+
+		users, err := svc.Users(ctx, UserFilter{})
+		require.NoError(t, err)
+		require.Empty(t, users)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		dept, head, err := svc.CreateDepartmentWithHead(ctx, "Math", "Mathematics department", UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Head",
+			JobTitle:  "Head of the department",
+			// Role and department are always overridden
+			NewRoleID:    Teacher.ID,
+			DepartmentID: uuid.Must(uuid.NewV7()),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Math", dept.Name)
+		require.Equal(t, Dephead, head.Role)
+		require.Equal(t, dept.ID, head.Department.ID)
+		require.Equal(t, "Head of the department", head.JobTitle)
+
+		got, err := svc.DepartmentHead(ctx, dept.ID)
+		require.NoError(t, err)
+		require.Equal(t, head.ID, got.ID)
+	})
+
+	t.Run("invalid head name", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		_, _, err := svc.CreateDepartmentWithHead(ctx, "Math", "Mathematics department", UserUpdateOptions{
+			FirstName: "Jane",
+		})
+		require.ErrorIs(t, err, ErrInvalidUserName)
+		requireNothingCreated(ctx, t, svc, 0)
+	})
+
+	t.Run("invalid head employment data", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		_, _, err := svc.CreateDepartmentWithHead(ctx, "Math", "Mathematics department", UserUpdateOptions{
+			FirstName:      "Jane",
+			LastName:       "Head",
+			EmploymentRate: MaxEmploymentRate + 1,
+		})
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+		requireNothingCreated(ctx, t, svc, 0)
+	})
+
+	t.Run("existing department", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		_, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+
+		_, _, err = svc.CreateDepartmentWithHead(ctx, "Math", "Another mathematics department", UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Head",
+		})
+		require.ErrorIs(t, err, ErrDepartmentExists)
+		requireNothingCreated(ctx, t, svc, 1)
+	})
+
+	t.Run("empty department name", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		_, _, err := svc.CreateDepartmentWithHead(ctx, "", "", UserUpdateOptions{
+			FirstName: "Jane",
+			LastName:  "Head",
+		})
+		require.ErrorIs(t, err, ErrInvalidDepartmentName)
+		requireNothingCreated(ctx, t, svc, 0)
+	})
+}
+
+func TestUserEmail(t *testing.T) {
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		return ctx, setupSESC(t)
+	}
+
+	withEmail := func(email string) UserUpdateOptions {
+		return UserUpdateOptions{
+			FirstName: "John",
+			LastName:  "Doe",
+			NewRoleID: Teacher.ID,
+			Email:     email,
+		}
+	}
+
+	t.Run("normalized", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		created, err := svc.CreateUser(ctx, withEmail(" John.Doe@SESC.ru "))
+		require.NoError(t, err)
+		require.Equal(t, "john.doe@sesc.ru", created.Email)
+
+		got, err := svc.User(ctx, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, "john.doe@sesc.ru", got.Email)
+	})
+
+	t.Run("no email", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		// Users without an email do not collide with each other.
+		for range 2 {
+			created, err := svc.CreateUser(ctx, withEmail(""))
+			require.NoError(t, err)
+			require.Empty(t, created.Email)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		for _, email := range []string{"john", "john@", "John Doe <john@sesc.ru>"} {
+			_, err := svc.CreateUser(ctx, withEmail(email))
+			require.ErrorIs(t, err, ErrInvalidEmail, email)
+		}
+
+		created, err := svc.CreateUser(ctx, withEmail(""))
+		require.NoError(t, err)
+		_, err = svc.UpdateUser(ctx, created.ID, withEmail("john@"))
+		require.ErrorIs(t, err, ErrInvalidEmail)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		_, err := svc.CreateUser(ctx, withEmail("john@sesc.ru"))
+		require.NoError(t, err)
+
+		_, err = svc.CreateUser(ctx, withEmail("JOHN@sesc.ru"))
+		require.ErrorIs(t, err, ErrEmailExists)
+
+		other, err := svc.CreateUser(ctx, withEmail("jane@sesc.ru"))
+		require.NoError(t, err)
+		_, err = svc.UpdateUser(ctx, other.ID, withEmail("john@sesc.ru"))
+		require.ErrorIs(t, err, ErrEmailExists)
+	})
+
+	t.Run("update and clear", func(t *testing.T) {
+		ctx, svc := setup(t)
+
+		created, err := svc.CreateUser(ctx, withEmail("john@sesc.ru"))
+		require.NoError(t, err)
+
+		updated, err := svc.UpdateUser(ctx, created.ID, withEmail("john.doe@sesc.ru"))
+		require.NoError(t, err)
+		require.Equal(t, "john.doe@sesc.ru", updated.Email)
+
+		updated, err = svc.UpdateUser(ctx, created.ID, withEmail(""))
+		require.NoError(t, err)
+		require.Empty(t, updated.Email)
 	})
 }
@@ -0,0 +1,169 @@
+package sesc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// OrgStats is an overview of the departments and the users of an org. Archived users are not counted.
+type OrgStats struct {
+	Departments int
+	Users       int
+	// UsersByRole maps the role IDs to the number of the users with the role, roles without users are absent.
+	UsersByRole map[int32]int
+	// AverageEmploymentRate is the mean over the users with a non-zero employment rate, or 0 if there are none.
+	AverageEmploymentRate float64
+}
+
+// Stats computes the OrgStats of the org in ctx, each aggregate with a single query,
+// all in one read-only transaction, see WithReadIsolation.
+func (s *SESC) Stats(ctx context.Context) (OrgStats, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/stats")
+
+	var stats OrgStats
+	tx, err := s.beginRead(ctx)
+	if err != nil {
+		rec.Add(events.Error, err)
+		return OrgStats{}, err
+	}
+
+	// Stage 1: Count the departments
+	ctx = rec.Sub("count_departments").Wrap(ctx)
+	stats.Departments, err = s.countDepartments(ctx, tx)
+	if err != nil {
+		return OrgStats{}, rollback(tx, err)
+	}
+
+	// Stage 2: Count the users by role
+	ctx = rec.Sub("count_users_by_role").Wrap(ctx)
+	stats.UsersByRole, err = s.countUsersByRole(ctx, tx)
+	if err != nil {
+		return OrgStats{}, rollback(tx, err)
+	}
+	for _, n := range stats.UsersByRole {
+		stats.Users += n
+	}
+
+	// Stage 3: Average the employment rates
+	ctx = rec.Sub("average_employment_rate").Wrap(ctx)
+	stats.AverageEmploymentRate, err = s.averageEmploymentRate(ctx, tx)
+	if err != nil {
+		return OrgStats{}, rollback(tx, err)
+	}
+
+	if err := commitRead(ctx, tx); err != nil {
+		rec.Add(events.Error, err)
+		return OrgStats{}, err
+	}
+
+	rec.Set(
+		"success", true,
+		"departments", stats.Departments,
+		"users", stats.Users,
+	)
+	return stats, nil
+}
+
+// countDepartments counts the departments of the org.
+func (s *SESC) countDepartments(ctx context.Context, tx *ent.Tx) (int, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	count, err := tx.Department.Query().Where(department.OrgID(OrgFromContext(ctx))).Count(ctx)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't count departments: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return 0, err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", count,
+	)
+	return count, nil
+}
+
+// countUsersByRole counts the users of the org grouped by role, except for the archived ones.
+func (s *SESC) countUsersByRole(ctx context.Context, tx *ent.Tx) (map[int32]int, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	var rows []struct {
+		RoleID int32 `json:"role_id"`
+		Count  int   `json:"count"`
+	}
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	err := filterUsers(ctx, tx.User.Query(), UserFilter{}).
+		GroupBy(user.FieldRoleID).
+		Aggregate(ent.Count()).
+		Scan(ctx, &rows)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't count users by role: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	counts := make(map[int32]int, len(rows))
+	for _, row := range rows {
+		counts[row.RoleID] = row.Count
+	}
+
+	rec.Set("success", true)
+	return counts, nil
+}
+
+// averageEmploymentRate averages the non-zero employment rates of the users of the org,
+// except for the archived ones.
+func (s *SESC) averageEmploymentRate(ctx context.Context, tx *ent.Tx) (float64, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	var rows []struct {
+		Mean sql.NullFloat64 `json:"mean"`
+	}
+
+	startTime := time.Now()
+	statrec.Add(events.PostgresQueries, 1)
+	err := filterUsers(ctx, tx.User.Query(), UserFilter{}).
+		Where(user.EmploymentRateGT(0)).
+		Aggregate(ent.As(ent.Mean(user.FieldEmploymentRate), "mean")).
+		Scan(ctx, &rows)
+	statrec.Add(events.PostgresTime, time.Since(startTime))
+
+	if err != nil {
+		err := fmt.Errorf("couldn't average employment rates: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return 0, err
+	}
+
+	var mean float64
+	if len(rows) > 0 && rows[0].Mean.Valid {
+		mean = rows[0].Mean.Float64
+	}
+
+	rec.Set(
+		"success", true,
+		"mean", mean,
+	)
+	return mean, nil
+}
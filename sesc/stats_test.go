@@ -0,0 +1,83 @@
+package sesc
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("known dataset", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		svc := setupSESC(t)
+
+		math, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+		_, err = svc.CreateDepartment(ctx, "Physics", "Physics department")
+		require.NoError(t, err)
+
+		for _, u := range []struct {
+			role   int32
+			rate   float64
+			deptID UUID
+		}{
+			{Teacher.ID, 1, math.ID},
+			{Teacher.ID, 0.5, math.ID},
+			{Teacher.ID, 0, math.ID},
+			{Dephead.ID, 0.75, math.ID},
+			{ScientificDeputy.ID, 0, uuid.Nil},
+		} {
+			_, err := svc.CreateUser(ctx, UserUpdateOptions{
+				FirstName:      "John",
+				LastName:       "Doe",
+				NewRoleID:      u.role,
+				DepartmentID:   u.deptID,
+				EmploymentRate: u.rate,
+			})
+			require.NoError(t, err)
+		}
+
+		archived, err := svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName:      "Jane",
+			LastName:       "Doe",
+			NewRoleID:      Teacher.ID,
+			EmploymentRate: 0.1,
+		})
+		require.NoError(t, err)
+		require.NoError(t, svc.ArchiveUser(ctx, archived.ID))
+
+		// Another org is not counted
+		_, err = svc.CreateUser(WithOrg(ctx, uuid.Must(uuid.NewV7())), UserUpdateOptions{
+			FirstName:      "Other",
+			LastName:       "Org",
+			NewRoleID:      Teacher.ID,
+			EmploymentRate: 0.1,
+		})
+		require.NoError(t, err)
+
+		stats, err := svc.Stats(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, stats.Departments)
+		require.Equal(t, 5, stats.Users)
+		require.Equal(t, map[int32]int{
+			Teacher.ID:          3,
+			Dephead.ID:          1,
+			ScientificDeputy.ID: 1,
+		}, stats.UsersByRole)
+		require.InDelta(t, 0.75, stats.AverageEmploymentRate, 1e-9, "Mean of 1, 0.5 and 0.75")
+	})
+
+	t.Run("empty org", func(t *testing.T) {
+		ctx, _ := event.NewRecord(t.Context(), "test")
+		svc := setupSESC(t)
+
+		stats, err := svc.Stats(ctx)
+		require.NoError(t, err)
+		require.Zero(t, stats.Departments)
+		require.Zero(t, stats.Users)
+		require.Empty(t, stats.UsersByRole)
+		require.Zero(t, stats.AverageEmploymentRate)
+	})
+}
@@ -0,0 +1,240 @@
+package sesc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/department"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/txretry"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// TransferDepartmentUsers moves all the users of a department, archived ones included, to another department,
+// so that the source department can be deleted. The users keep their roles: moving the head of the source
+// to a department that already has one leaves it with several heads.
+// Every move is recorded to the employment history on behalf of the actor in ctx.
+//
+// Returns the number of moved users.
+// Returns an ErrDepartmentNotFound if the source department does not exist.
+// Returns an ErrInvalidDepartment if the target department does not exist or is the source one.
+func (s *SESC) TransferDepartmentUsers(ctx context.Context, fromID, toID UUID) (int, error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/transfer_department_users")
+
+	rec.Sub("params").Set(
+		"from_id", fromID,
+		"to_id", toID,
+	)
+
+	if fromID == toID {
+		err := fmt.Errorf("%w: cannot transfer users to the same department", ErrInvalidDepartment)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return 0, err
+	}
+
+	// Stages 1-4: Move users in a transaction, retried on serialization failures
+	var moved int
+	err := txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		var err error
+		moved, err = s.transferDepartmentUsersTx(ctx, rec, fromID, toID)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	rec.Set(
+		"success", true,
+		"moved", moved,
+	)
+	return moved, nil
+}
+
+// transferDepartmentUsersTx moves the users between the departments in a serializable transaction,
+// recording the moves to the employment history.
+func (s *SESC) transferDepartmentUsersTx(ctx context.Context, rec *event.Record, fromID, toID UUID) (int, error) {
+	statrec := event.Root(ctx).Sub("stats")
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		err := fmt.Errorf("couldn't start transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return 0, err
+	}
+
+	// Stage 1: Check that both departments exist
+	ctx = rec.Sub("check_departments_exist").Wrap(ctx)
+	if err := s.checkTransferDepartments(ctx, tx, fromID, toID); err != nil {
+		return 0, rollback(tx, err)
+	}
+
+	// Stage 2: Query the users of the source department
+	ctx = rec.Sub("query_department_users").Wrap(ctx)
+	users, err := s.queryDepartmentUsers(ctx, tx, fromID)
+	if err != nil {
+		return 0, rollback(tx, err)
+	}
+
+	// Stage 3: Move the users
+	ctx = rec.Sub("move_department_users").Wrap(ctx)
+	moved, err := s.moveDepartmentUsers(ctx, tx, fromID, toID)
+	if err != nil {
+		return 0, rollback(tx, err)
+	}
+
+	// Stage 4: Record employment events
+	ctx = rec.Sub("record_employment_events").Wrap(ctx)
+	if err := s.recordTransferEvents(ctx, tx, users, fromID, toID); err != nil {
+		return 0, rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return 0, err
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+	return moved, nil
+}
+
+// checkTransferDepartments returns an ErrDepartmentNotFound if the source department does not exist,
+// or an ErrInvalidDepartment if the target one does not.
+func (s *SESC) checkTransferDepartments(ctx context.Context, tx *ent.Tx, fromID, toID UUID) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	statrec.Add(events.PostgresQueries, 1)
+	ids, err := tx.Department.Query().
+		Where(department.IDIn(fromID, toID), department.OrgID(OrgFromContext(ctx))).
+		IDs(ctx)
+	if err != nil {
+		err := fmt.Errorf("couldn't query departments: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+
+	var fromExists, toExists bool
+	for _, id := range ids {
+		fromExists = fromExists || id == fromID
+		toExists = toExists || id == toID
+	}
+	rec.Set(
+		"from_exists", fromExists,
+		"to_exists", toExists,
+	)
+
+	switch {
+	case !fromExists:
+		rec.Add(events.Error, ErrDepartmentNotFound)
+		rec.Set("success", false)
+		return ErrDepartmentNotFound
+	case !toExists:
+		rec.Add(events.Error, ErrInvalidDepartment)
+		rec.Set("success", false)
+		return ErrInvalidDepartment
+	}
+
+	rec.Set("success", true)
+	return nil
+}
+
+// queryDepartmentUsers queries the IDs and roles of all the users of a department, archived ones included.
+func (s *SESC) queryDepartmentUsers(ctx context.Context, tx *ent.Tx, deptID UUID) ([]*ent.User, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	statrec.Add(events.PostgresQueries, 1)
+	users, err := tx.User.Query().
+		Where(user.DepartmentID(deptID), user.OrgID(OrgFromContext(ctx))).
+		Select(user.FieldID, user.FieldRoleID).
+		All(ctx)
+	if err != nil {
+		err := fmt.Errorf("couldn't query department users: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", len(users),
+	)
+	return users, nil
+}
+
+// moveDepartmentUsers reassigns all the users of a department to another one with a single update,
+// returning the number of moved users.
+func (s *SESC) moveDepartmentUsers(ctx context.Context, tx *ent.Tx, fromID, toID UUID) (int, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	statrec.Add(events.PostgresQueries, 1)
+	moved, err := tx.User.Update().
+		Where(user.DepartmentID(fromID), user.OrgID(OrgFromContext(ctx))).
+		SetDepartmentID(toID).
+		AddVersion(1).
+		Save(ctx)
+	if err != nil {
+		err := fmt.Errorf("couldn't move department users: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return 0, err
+	}
+
+	rec.Set(
+		"success", true,
+		"moved", moved,
+	)
+	return moved, nil
+}
+
+// recordTransferEvents records the moves of the users from the department fromID to toID
+// on behalf of the actor in ctx.
+func (s *SESC) recordTransferEvents(ctx context.Context, tx *ent.Tx, users []*ent.User, fromID, toID UUID) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+
+	if len(users) == 0 {
+		rec.Set("count", 0)
+		return nil
+	}
+
+	actorID := actorFromContext(ctx)
+	creates := make([]*ent.EmploymentEventCreate, len(users))
+	for i, u := range users {
+		creates[i] = tx.EmploymentEvent.Create().
+			SetUserID(u.ID).
+			SetActorID(actorID).
+			SetOldRoleID(u.RoleID).
+			SetNewRoleID(u.RoleID).
+			SetOldDepartmentID(fromID).
+			SetNewDepartmentID(toID)
+	}
+
+	statrec.Add(events.PostgresQueries, 1)
+	if err := tx.EmploymentEvent.CreateBulk(creates...).Exec(ctx); err != nil {
+		err := fmt.Errorf("couldn't record employment events: %w", err)
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+
+	rec.Set(
+		"success", true,
+		"count", len(users),
+	)
+	return nil
+}
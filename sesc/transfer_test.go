@@ -0,0 +1,101 @@
+package sesc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferDepartmentUsers(t *testing.T) {
+	// setup creates two departments, with two teachers in the first one, one of them archived,
+	// and a teacher without a department.
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, from, to Department, users []User) {
+		ctx, _ = event.NewRecord(t.Context(), "test")
+		svc = setupSESC(t)
+
+		from, err := svc.CreateDepartment(ctx, "Math", "Mathematics department")
+		require.NoError(t, err)
+		to, err = svc.CreateDepartment(ctx, "Physics", "Physics department")
+		require.NoError(t, err)
+
+		for _, deptID := range []UUID{from.ID, from.ID, uuid.Nil} {
+			u, err := svc.CreateUser(ctx, UserUpdateOptions{
+				FirstName:    "John",
+				LastName:     "Doe",
+				NewRoleID:    Teacher.ID,
+				DepartmentID: deptID,
+			})
+			require.NoError(t, err)
+			users = append(users, u)
+		}
+		require.NoError(t, svc.ArchiveUser(ctx, users[1].ID))
+
+		return ctx, svc, from, to, users
+	}
+
+	t.Run("moves all users", func(t *testing.T) {
+		ctx, svc, from, to, users := setup(t)
+		actorID := uuid.Must(uuid.NewV7())
+
+		moved, err := svc.TransferDepartmentUsers(WithActor(ctx, actorID), from.ID, to.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, moved)
+
+		for _, u := range users[:2] {
+			got, err := svc.UserByID(ctx, u.ID, true)
+			require.NoError(t, err)
+			require.Equal(t, to.ID, got.Department.ID)
+			require.Equal(t, u.Version+1, got.Version)
+
+			history, err := svc.EmploymentHistory(ctx, u.ID)
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			require.Equal(t, from.ID, history[0].OldDepartmentID)
+			require.Equal(t, to.ID, history[0].NewDepartmentID)
+			require.Equal(t, Teacher.ID, history[0].NewRoleID)
+			require.Equal(t, actorID, history[0].ActorID)
+		}
+
+		got, err := svc.User(ctx, users[2].ID)
+		require.NoError(t, err)
+		require.Equal(t, uuid.Nil, got.Department.ID, "User without a department should not move")
+
+		require.NoError(t, svc.DeleteDepartment(ctx, from.ID), "Source department should be empty")
+	})
+
+	t.Run("empty department", func(t *testing.T) {
+		ctx, svc, from, to, _ := setup(t)
+
+		moved, err := svc.TransferDepartmentUsers(ctx, to.ID, from.ID)
+		require.NoError(t, err)
+		require.Zero(t, moved)
+	})
+
+	t.Run("non-existent source", func(t *testing.T) {
+		ctx, svc, _, to, _ := setup(t)
+
+		_, err := svc.TransferDepartmentUsers(ctx, uuid.Must(uuid.NewV7()), to.ID)
+		require.ErrorIs(t, err, ErrDepartmentNotFound)
+	})
+
+	t.Run("non-existent target", func(t *testing.T) {
+		ctx, svc, from, _, users := setup(t)
+
+		_, err := svc.TransferDepartmentUsers(ctx, from.ID, uuid.Must(uuid.NewV7()))
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+
+		got, err := svc.User(ctx, users[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, from.ID, got.Department.ID, "User should not move")
+	})
+
+	t.Run("same department", func(t *testing.T) {
+		ctx, svc, from, _, _ := setup(t)
+
+		_, err := svc.TransferDepartmentUsers(ctx, from.ID, from.ID)
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+	})
+}
@@ -1,6 +1,14 @@
 package sesc
 
-import "github.com/kozlov-ma/sesc-backend/pkg/event"
+import (
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+)
 
 // User represents a SESC employee that participates in the achievement list
 // filling and review processes.
@@ -11,6 +19,11 @@ import "github.com/kozlov-ma/sesc-backend/pkg/event"
 //
 // Use ExtraPermissions to grant additional permissions to the user, i.e.,
 // the ability to fill out achievement lists as a department head.
+//
+// Employment fields describe the User's position, as kept by the HR department.
+// Zero values mean the data is not filled in.
+//
+// DeletedAt is set once the User is archived.
 type User struct {
 	ID UUID
 
@@ -20,11 +33,40 @@ type User struct {
 
 	PictureURL string
 
+	// Email is the normalized work email of the User, empty if it is not set.
+	// The User can log in with it instead of their username.
+	Email string
+
 	Suspended bool
 
 	Department Department
 
 	Role Role
+
+	// ExtraPermissions are granted to the User in addition to the ones of their Role.
+	ExtraPermissions []Permission
+
+	Subdivision       string
+	JobTitle          string
+	EmploymentRate    float64
+	PersonnelCategory PersonnelCategory
+	EmploymentType    EmploymentType
+	AcademicDegree    AcademicDegree
+	AcademicTitle     string
+	Honors            string
+	Category          string
+	DateOfEmployment  *time.Time
+	UnemploymentDate  *time.Time
+
+	DeletedAt *time.Time
+
+	// Version is incremented on every update of the User.
+	Version int
+}
+
+// Archived reports whether the User has been archived.
+func (u User) Archived() bool {
+	return u.DeletedAt != nil
 }
 
 func (u User) EventRecord() *event.Record {
@@ -36,11 +78,27 @@ func (u User) EventRecord() *event.Record {
 		"department", u.Department,
 		"role_id", u.Role.ID,
 		"role", u.Role,
+		"archived", u.Archived(),
+		"version", u.Version,
 	)
 }
 
 func (u User) HasPermission(permission Permission) bool {
-	return u.Role.HasPermission(permission)
+	return u.Role.HasPermission(permission) || slices.ContainsFunc(u.ExtraPermissions, func(p Permission) bool {
+		return p.ID == permission.ID
+	})
+}
+
+// Permissions returns the effective permissions of the User:
+// the effective ones of their Role followed by the extra ones the Role does not grant.
+func (u User) Permissions() []Permission {
+	perms := u.Role.EffectivePermissions()
+	for _, p := range u.ExtraPermissions {
+		if !u.Role.HasPermission(p) {
+			perms = append(perms, p)
+		}
+	}
+	return perms
 }
 
 func (u User) UpdateOptions() UserUpdateOptions {
@@ -49,8 +107,50 @@ func (u User) UpdateOptions() UserUpdateOptions {
 		LastName:     u.LastName,
 		MiddleName:   u.MiddleName,
 		PictureURL:   u.PictureURL,
+		Email:        u.Email,
 		Suspended:    u.Suspended,
 		DepartmentID: u.Department.ID,
 		NewRoleID:    u.Role.ID,
+		Version:      u.Version,
+
+		Subdivision:       u.Subdivision,
+		JobTitle:          u.JobTitle,
+		EmploymentRate:    u.EmploymentRate,
+		PersonnelCategory: u.PersonnelCategory,
+		EmploymentType:    u.EmploymentType,
+		AcademicDegree:    u.AcademicDegree,
+		AcademicTitle:     u.AcademicTitle,
+		Honors:            u.Honors,
+		Category:          u.Category,
+		DateOfEmployment:  u.DateOfEmployment,
+		UnemploymentDate:  u.UnemploymentDate,
+	}
+}
+
+// NormalizeEmail returns the trimmed lower-case email. Emails are stored and looked up in this form.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateEmail returns an ErrInvalidEmail if email is not blank and is not a plain address like "name@example.com".
+func ValidateEmail(email string) error {
+	email = NormalizeEmail(email)
+	if email == "" {
+		return nil
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("%w: %q", ErrInvalidEmail, email)
+	}
+	return nil
+}
+
+// emailField returns the normalized email to store, or nil if it is empty.
+func emailField(email string) *string {
+	normalized := NormalizeEmail(email)
+	if normalized == "" {
+		return nil
 	}
+	return &normalized
 }
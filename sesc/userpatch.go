@@ -0,0 +1,430 @@
+package sesc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent"
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/user"
+	"github.com/kozlov-ma/sesc-backend/db/txretry"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// UserPatch holds the fields of a user changed by UpdateUserFields, nil fields are left unchanged.
+// The optional fields are cleared by their zero values: an empty string, uuid.Nil for the department
+// and the unspecified values of the enums.
+type UserPatch struct {
+	FirstName    *string
+	LastName     *string
+	MiddleName   *string
+	PictureURL   *string
+	Email        *string
+	Suspended    *bool
+	DepartmentID *UUID
+	RoleID       *int32
+	// Version, if non-zero, must match the current version of the user for the update to succeed.
+	Version int
+
+	Subdivision       *string
+	JobTitle          *string
+	EmploymentRate    *float64
+	PersonnelCategory *PersonnelCategory
+	EmploymentType    *EmploymentType
+	AcademicDegree    *AcademicDegree
+	AcademicTitle     *string
+	Honors            *string
+	Category          *string
+	DateOfEmployment  *time.Time
+	UnemploymentDate  *time.Time
+	// ClearDateOfEmployment and ClearUnemploymentDate clear the dates, even if they are set in the patch.
+	ClearDateOfEmployment bool
+	ClearUnemploymentDate bool
+}
+
+// validate checks the fields of the patch that don't depend on the current user.
+func (p UserPatch) validate() error {
+	switch {
+	case p.FirstName != nil && *p.FirstName == "", p.LastName != nil && *p.LastName == "":
+		return ErrInvalidUserName
+	case p.EmploymentRate != nil && (*p.EmploymentRate < 0 || *p.EmploymentRate > MaxEmploymentRate):
+		return fmt.Errorf("%w: employment rate must be between 0 and %v", ErrInvalidEmploymentData, MaxEmploymentRate)
+	case p.PersonnelCategory != nil && !p.PersonnelCategory.Valid():
+		return fmt.Errorf("%w: unknown personnel category %d", ErrInvalidEmploymentData, *p.PersonnelCategory)
+	case p.EmploymentType != nil && !p.EmploymentType.Valid():
+		return fmt.Errorf("%w: unknown employment type %d", ErrInvalidEmploymentData, *p.EmploymentType)
+	case p.AcademicDegree != nil && !p.AcademicDegree.Valid():
+		return fmt.Errorf("%w: unknown academic degree %d", ErrInvalidEmploymentData, *p.AcademicDegree)
+	}
+
+	if p.RoleID != nil {
+		if _, ok := RoleByID(*p.RoleID); !ok {
+			return ErrInvalidRole
+		}
+	}
+	if p.Email != nil {
+		return ValidateEmail(*p.Email)
+	}
+	return nil
+}
+
+// setParams adds the set fields of the patch to the params record.
+func (p UserPatch) setParams(rec *event.Record) {
+	if p.FirstName != nil {
+		rec.Set("first_name", *p.FirstName)
+	}
+	if p.LastName != nil {
+		rec.Set("last_name", *p.LastName)
+	}
+	if p.Suspended != nil {
+		rec.Set("suspended", *p.Suspended)
+	}
+	if p.DepartmentID != nil {
+		rec.Set("department_id", *p.DepartmentID)
+	}
+	if p.RoleID != nil {
+		rec.Set("role_id", *p.RoleID)
+	}
+	rec.Set("version", p.Version)
+}
+
+// employment returns the role and the department of the user u after the patch.
+func (p UserPatch) employment(u *ent.User) employment {
+	e := employmentOf(u)
+	if p.RoleID != nil {
+		e.roleID = *p.RoleID
+	}
+	if p.DepartmentID != nil {
+		e.departmentID = *p.DepartmentID
+	}
+	return e
+}
+
+// employmentDates returns the date of employment and the unemployment date of the user u after the patch.
+func (p UserPatch) employmentDates(u *ent.User) (employed, unemployed *time.Time) {
+	employed, unemployed = u.DateOfEmployment, u.UnemploymentDate
+	switch {
+	case p.ClearDateOfEmployment:
+		employed = nil
+	case p.DateOfEmployment != nil:
+		employed = p.DateOfEmployment
+	}
+	switch {
+	case p.ClearUnemploymentDate:
+		unemployed = nil
+	case p.UnemploymentDate != nil:
+		unemployed = p.UnemploymentDate
+	}
+	return employed, unemployed
+}
+
+// apply sets the fields of the patch on the update.
+func (p UserPatch) apply(update *ent.UserUpdateOne) *ent.UserUpdateOne {
+	if p.FirstName != nil {
+		update = update.SetFirstName(*p.FirstName)
+	}
+	if p.LastName != nil {
+		update = update.SetLastName(*p.LastName)
+	}
+	if p.MiddleName != nil {
+		update = update.SetMiddleName(*p.MiddleName)
+	}
+	if p.PictureURL != nil {
+		update = update.SetPictureURL(*p.PictureURL)
+	}
+	if p.Email != nil {
+		if email := emailField(*p.Email); email != nil {
+			update = update.SetEmail(*email)
+		} else {
+			update = update.ClearEmail()
+		}
+	}
+	if p.Suspended != nil {
+		update = update.SetSuspended(*p.Suspended)
+	}
+	if p.DepartmentID != nil {
+		if *p.DepartmentID == uuid.Nil {
+			update = update.ClearDepartment()
+		} else {
+			update = update.SetDepartmentID(*p.DepartmentID)
+		}
+	}
+	if p.RoleID != nil {
+		update = update.SetRoleID(*p.RoleID)
+	}
+
+	if p.Subdivision != nil {
+		update = update.SetSubdivision(*p.Subdivision)
+	}
+	if p.JobTitle != nil {
+		update = update.SetJobTitle(*p.JobTitle)
+	}
+	if p.EmploymentRate != nil {
+		update = update.SetEmploymentRate(*p.EmploymentRate)
+	}
+	if p.PersonnelCategory != nil {
+		update = update.SetPersonnelCategory(int32(*p.PersonnelCategory))
+	}
+	if p.EmploymentType != nil {
+		update = update.SetEmploymentType(int32(*p.EmploymentType))
+	}
+	if p.AcademicDegree != nil {
+		update = update.SetAcademicDegree(int32(*p.AcademicDegree))
+	}
+	if p.AcademicTitle != nil {
+		update = update.SetAcademicTitle(*p.AcademicTitle)
+	}
+	if p.Honors != nil {
+		update = update.SetHonors(*p.Honors)
+	}
+	if p.Category != nil {
+		update = update.SetCategory(*p.Category)
+	}
+	switch {
+	case p.ClearDateOfEmployment:
+		update = update.ClearDateOfEmployment()
+	case p.DateOfEmployment != nil:
+		update = update.SetDateOfEmployment(*p.DateOfEmployment)
+	}
+	switch {
+	case p.ClearUnemploymentDate:
+		update = update.ClearUnemploymentDate()
+	case p.UnemploymentDate != nil:
+		update = update.SetUnemploymentDate(*p.UnemploymentDate)
+	}
+	return update
+}
+
+// UpdateUserFields changes only the fields set in the patch, so that concurrent updates of the other fields
+// are not lost. Returns the user before and after the update.
+//
+// A department can only be set for a role that can have one, whether the role or the department is patched.
+//
+// Returns an ErrInvalidRole if the new role id is invalid.
+// Returns an ErrInvalidName if the first or last name is set to an empty one.
+// Returns an ErrInvalidEmail if the email is invalid, or an ErrEmailExists if another user has it.
+// Returns an ErrInvalidEmploymentData if the employment data is invalid after the update.
+// Returns an ErrInvalidDepartment if the department does not exist.
+// Returns an ErrInvalidRoleChange if the role of the user can't have the department of the user after the update.
+// Returns an ErrUserNotFound if the user does not exist or is archived.
+// Returns an ErrStaleUser if patch.Version is set and does not match the user's current version.
+func (s *SESC) UpdateUserFields(ctx context.Context, id UUID, patch UserPatch) (before, after User, err error) {
+	// Caller should create the record and use Wrap to add it to the context
+	rec := event.Get(ctx).Sub("sesc/update_user_fields")
+
+	params := rec.Sub("params")
+	params.Set("id", id)
+	patch.setParams(params)
+
+	// Stage 1: Validate patch
+	ctx = rec.Sub("validate_patch").Wrap(ctx)
+	if err := s.validatePatch(ctx, patch); err != nil {
+		return User{}, User{}, err
+	}
+
+	// Stages 2-7: Update user in a transaction, retried on serialization failures
+	var old, us *ent.User
+	err = txretry.Do(ctx, txretry.DefaultAttempts, func() error {
+		var err error
+		old, us, err = s.updateUserFieldsTx(ctx, rec, id, patch)
+		return err
+	})
+	if err != nil {
+		return User{}, User{}, err
+	}
+
+	// Stage 8: Convert user entities to domain objects
+	ctx = rec.Sub("convert_user").Wrap(ctx)
+	if before, err = s.convertUserEntity(ctx, old); err != nil {
+		return User{}, User{}, err
+	}
+	if after, err = s.convertUserEntity(ctx, us); err != nil {
+		return User{}, User{}, err
+	}
+
+	rec.Set("success", true)
+	rec.Set("user", after.EventRecord())
+	return before, after, nil
+}
+
+// updateUserFieldsTx applies the patch in a serializable transaction and returns the user before and after it.
+// A change of the role or the department is recorded to the employment history in the same transaction.
+func (s *SESC) updateUserFieldsTx(
+	ctx context.Context,
+	rec *event.Record,
+	id UUID,
+	patch UserPatch,
+) (before, after *ent.User, err error) {
+	statrec := event.Root(ctx).Sub("stats")
+	txrec := rec.Sub("pg_transaction")
+	txrec.Set("rollback", false)
+
+	txStart := time.Now()
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		err := fmt.Errorf("couldn't start transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return nil, nil, err
+	}
+
+	// Stage 2: Query the user before the update
+	ctx = rec.Sub("query_user").Wrap(ctx)
+	before, err = s.queryPatchedUser(ctx, tx, id)
+	if err != nil {
+		return nil, nil, rollback(tx, err)
+	}
+
+	// Stage 3: Check department if it is patched
+	if patch.DepartmentID != nil {
+		ctx = rec.Sub("check_department").Wrap(ctx)
+		if _, err := s.checkAndGetDepartment(ctx, statrec, tx, *patch.DepartmentID); err != nil {
+			return nil, nil, rollback(tx, err)
+		}
+	}
+
+	// Stage 4: Check the user after the patch
+	ctx = rec.Sub("check_patched_user").Wrap(ctx)
+	if err := s.checkPatchedUser(ctx, before, patch); err != nil {
+		return nil, nil, rollback(tx, err)
+	}
+
+	// Stage 5: Update the patched fields
+	ctx = rec.Sub("update_user_fields").Wrap(ctx)
+	if err := s.updateUserFields(ctx, tx, id, patch); err != nil {
+		return nil, nil, rollback(tx, err)
+	}
+
+	// Stage 6: Query updated user
+	ctx = rec.Sub("query_updated_user").Wrap(ctx)
+	after, err = s.queryUpdatedUser(ctx, statrec, tx, id)
+	if err != nil {
+		return nil, nil, rollback(tx, err)
+	}
+
+	// Stage 7: Record employment event
+	ctx = rec.Sub("record_employment_event").Wrap(ctx)
+	if err := s.recordEmploymentEvent(ctx, tx, id, employmentOf(before), employmentOf(after)); err != nil {
+		return nil, nil, rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		err := fmt.Errorf("couldn't commit transaction: %w", err)
+		txrec.Add(events.Error, err)
+		return nil, nil, err
+	}
+
+	statrec.Add(events.PostgresTime, time.Since(txStart))
+	return before, after, nil
+}
+
+// validatePatch validates the fields set in a patch of a user
+func (s *SESC) validatePatch(ctx context.Context, patch UserPatch) error {
+	rec := event.Get(ctx)
+
+	if err := patch.validate(); err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("valid", false)
+		return err
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// queryPatchedUser queries a user that is not archived, with the edges needed to convert it.
+func (s *SESC) queryPatchedUser(ctx context.Context, tx *ent.Tx, id UUID) (*ent.User, error) {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+	rec.Set("user_id", id)
+
+	statrec.Add(events.PostgresQueries, 1)
+	u, err := tx.User.Query().
+		Where(user.ID(id), user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil()).
+		WithDepartment().
+		WithPermissions(orderPermissions).
+		Only(ctx)
+
+	switch {
+	case ent.IsNotFound(err):
+		rec.Add(events.Error, ErrUserNotFound)
+		rec.Set("success", false)
+		return nil, ErrUserNotFound
+	case err != nil:
+		err := fmt.Errorf("couldn't query user: %w", WrapTimeout(ctx, err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return nil, err
+	}
+
+	rec.Set(
+		"success", true,
+		"version", u.Version,
+	)
+	return u, nil
+}
+
+// checkPatchedUser checks the rules involving both the patched fields and the current ones of the user u:
+// the role must be able to have the department, and the unemployment date must not precede the date of employment.
+func (s *SESC) checkPatchedUser(ctx context.Context, u *ent.User, patch UserPatch) error {
+	rec := event.Get(ctx)
+
+	e := patch.employment(u)
+	rec.Set(
+		"role_id", e.roleID,
+		"department_id", e.departmentID,
+	)
+
+	role, _ := RoleByID(e.roleID)
+	employed, unemployed := patch.employmentDates(u)
+
+	var err error
+	switch {
+	case e.departmentID != uuid.Nil && !role.CanHaveDepartment():
+		err = fmt.Errorf("%w: role %d can't have a department", ErrInvalidRoleChange, e.roleID)
+	case employed != nil && unemployed != nil && unemployed.Before(*employed):
+		err = fmt.Errorf("%w: unemployment date precedes the date of employment", ErrInvalidEmploymentData)
+	}
+	if err != nil {
+		rec.Add(events.Error, err)
+		rec.Set("valid", false)
+		return err
+	}
+
+	rec.Set("valid", true)
+	return nil
+}
+
+// updateUserFields updates only the patched fields of the user record with a single statement
+func (s *SESC) updateUserFields(ctx context.Context, tx *ent.Tx, id UUID, patch UserPatch) error {
+	rec := event.Get(ctx)
+	statrec := event.Root(ctx).Sub("stats")
+	rec.Set("user_id", id)
+
+	update := tx.User.UpdateOneID(id).Where(user.OrgID(OrgFromContext(ctx)), user.DeletedAtIsNil())
+	if patch.Version != 0 {
+		update = update.Where(user.Version(patch.Version))
+	}
+
+	statrec.Add(events.PostgresQueries, 1)
+	err := patch.apply(update).AddVersion(1).Exec(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		// The user has been read in the same transaction, so the version must have changed.
+		rec.Add(events.Error, ErrStaleUser)
+		rec.Set("success", false)
+		return ErrStaleUser
+	case err != nil:
+		err := fmt.Errorf("couldn't update user: %w", WrapConstraint(err))
+		rec.Add(events.Error, err)
+		rec.Set("success", false)
+		return err
+	}
+
+	rec.Set("success", true)
+	return nil
+}
@@ -0,0 +1,175 @@
+package sesc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateUserFields(t *testing.T) {
+	hired := time.Date(2015, time.September, 1, 0, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (ctx context.Context, svc *SESC, depID UUID, created User) {
+		ctx = t.Context()
+		ctx, _ = event.NewRecord(ctx, "test")
+		svc = setupSESC(t)
+
+		dep, err := svc.CreateDepartment(ctx, "Dep", "Dep")
+		require.NoError(t, err)
+		depID = dep.ID
+
+		created, err = svc.CreateUser(ctx, UserUpdateOptions{
+			FirstName:        "Ivan",
+			LastName:         "Petrov",
+			MiddleName:       "Sergeevich",
+			Email:            "ipetrov@sesc.ru",
+			DepartmentID:     depID,
+			NewRoleID:        Teacher.ID,
+			JobTitle:         "Teacher of physics",
+			AcademicDegree:   Candidate,
+			DateOfEmployment: &hired,
+		})
+		require.NoError(t, err)
+		return ctx, svc, depID, created
+	}
+
+	t.Run("only the patched fields change", func(t *testing.T) {
+		ctx, svc, depID, created := setup(t)
+
+		lastName, rate := "Sidorov", 0.5
+		before, after, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{
+			LastName:       &lastName,
+			EmploymentRate: &rate,
+		})
+		require.NoError(t, err)
+		require.Equal(t, created, before)
+
+		require.Equal(t, "Sidorov", after.LastName)
+		require.InDelta(t, 0.5, after.EmploymentRate, 1e-9)
+		require.Equal(t, "Ivan", after.FirstName)
+		require.Equal(t, "Sergeevich", after.MiddleName)
+		require.Equal(t, "ipetrov@sesc.ru", after.Email)
+		require.Equal(t, depID, after.Department.ID)
+		require.Equal(t, Teacher.ID, after.Role.ID)
+		require.Equal(t, "Teacher of physics", after.JobTitle)
+		require.Equal(t, Candidate, after.AcademicDegree)
+		require.NotNil(t, after.DateOfEmployment)
+		require.True(t, hired.Equal(*after.DateOfEmployment))
+		require.Equal(t, created.Version+1, after.Version)
+	})
+
+	t.Run("patches of different fields don't overwrite each other", func(t *testing.T) {
+		ctx, svc, _, created := setup(t)
+
+		// Unlike updates of the whole user built from a stale read, neither patch carries the other field.
+		firstName, jobTitle := "Oleg", "Head of physics"
+		_, _, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{FirstName: &firstName})
+		require.NoError(t, err)
+		_, after, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{JobTitle: &jobTitle})
+		require.NoError(t, err)
+
+		require.Equal(t, "Oleg", after.FirstName)
+		require.Equal(t, "Head of physics", after.JobTitle)
+	})
+
+	t.Run("clearing fields", func(t *testing.T) {
+		ctx, svc, _, created := setup(t)
+
+		_, after, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{
+			MiddleName:            new(string),
+			Email:                 new(string),
+			DepartmentID:          new(UUID),
+			ClearDateOfEmployment: true,
+		})
+		require.NoError(t, err)
+		require.Empty(t, after.MiddleName)
+		require.Empty(t, after.Email)
+		require.Equal(t, uuid.Nil, after.Department.ID)
+		require.Nil(t, after.DateOfEmployment)
+		require.Equal(t, "Teacher of physics", after.JobTitle)
+	})
+
+	t.Run("role can't have the department", func(t *testing.T) {
+		ctx, svc, depID, created := setup(t)
+
+		deputy := ContestDeputy.ID
+		_, _, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{RoleID: &deputy})
+		require.ErrorIs(t, err, ErrInvalidRoleChange, "The user keeps their department")
+
+		_, after, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{RoleID: &deputy, DepartmentID: new(UUID)})
+		require.NoError(t, err)
+		require.Equal(t, deputy, after.Role.ID)
+
+		_, _, err = svc.UpdateUserFields(ctx, created.ID, UserPatch{DepartmentID: &depID})
+		require.ErrorIs(t, err, ErrInvalidRoleChange)
+	})
+
+	t.Run("employment dates are checked against the current ones", func(t *testing.T) {
+		ctx, svc, _, created := setup(t)
+
+		fired := hired.AddDate(0, -1, 0)
+		_, _, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{UnemploymentDate: &fired})
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+
+		_, after, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{
+			UnemploymentDate:      &fired,
+			ClearDateOfEmployment: true,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, after.UnemploymentDate)
+		require.True(t, fired.Equal(*after.UnemploymentDate))
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		ctx, svc, _, created := setup(t)
+
+		role, rate, email, dept := int32(999), 2.5, "not an email", uuid.Must(uuid.NewV7())
+		_, _, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{FirstName: new(string)})
+		require.ErrorIs(t, err, ErrInvalidUserName)
+		_, _, err = svc.UpdateUserFields(ctx, created.ID, UserPatch{RoleID: &role})
+		require.ErrorIs(t, err, ErrInvalidRole)
+		_, _, err = svc.UpdateUserFields(ctx, created.ID, UserPatch{EmploymentRate: &rate})
+		require.ErrorIs(t, err, ErrInvalidEmploymentData)
+		_, _, err = svc.UpdateUserFields(ctx, created.ID, UserPatch{Email: &email})
+		require.ErrorIs(t, err, ErrInvalidEmail)
+		_, _, err = svc.UpdateUserFields(ctx, created.ID, UserPatch{DepartmentID: &dept})
+		require.ErrorIs(t, err, ErrInvalidDepartment)
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		ctx, svc, _, created := setup(t)
+
+		firstName := "Oleg"
+		_, _, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{FirstName: &firstName, Version: created.Version + 1})
+		require.ErrorIs(t, err, ErrStaleUser)
+
+		_, after, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{FirstName: &firstName, Version: created.Version})
+		require.NoError(t, err)
+		require.Equal(t, "Oleg", after.FirstName)
+	})
+
+	t.Run("employment history", func(t *testing.T) {
+		ctx, svc, depID, created := setup(t)
+
+		dephead := Dephead.ID
+		_, _, err := svc.UpdateUserFields(ctx, created.ID, UserPatch{RoleID: &dephead})
+		require.NoError(t, err)
+
+		history, err := svc.EmploymentHistory(ctx, created.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, Teacher.ID, history[0].OldRoleID)
+		require.Equal(t, Dephead.ID, history[0].NewRoleID)
+		require.Equal(t, depID, history[0].NewDepartmentID)
+	})
+
+	t.Run("non-existent user", func(t *testing.T) {
+		ctx, svc, _, _ := setup(t)
+		_, _, err := svc.UpdateUserFields(ctx, uuid.Must(uuid.NewV7()), UserPatch{})
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
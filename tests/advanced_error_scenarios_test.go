@@ -53,7 +53,7 @@ func TestDepartmentErrors(t *testing.T) {
 	// Try to create another department with the same name
 	_, err = adminClient.CreateDepartment(ctx, deptReq)
 	require.Error(t, err)
-	assert.Contains(t, strings.ToLower(err.Error()), "invalid_department")
+	assert.Contains(t, strings.ToLower(err.Error()), "department_exists")
 
 	// Test regular user trying to create a department (should be forbidden)
 	_, err = regularClient.CreateDepartment(ctx, CreateDepartmentRequest{
@@ -0,0 +1,204 @@
+package tests
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	// 1. Creating a user writes an audit entry on behalf of the admin
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Ivan",
+		LastName:  "Petrov",
+		RoleID:    2,
+	})
+	require.NoError(t, err)
+
+	entries, err := client.GetAuditEntries(ctx, user.ID.String(), 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "create_user", entries[0].Action)
+	assert.Equal(t, "user", entries[0].TargetType)
+	assert.Equal(t, user.ID, entries[0].TargetID)
+	assert.Equal(t, "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd", entries[0].ActorID.String())
+	assert.Equal(t, "Ivan", entries[0].Details["firstName"])
+	assert.False(t, entries[0].Timestamp.IsZero())
+
+	// 2. Further mutations are listed newest first
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		FirstName: stringPtr("Petr"),
+	})
+	require.NoError(t, err)
+
+	err = client.RegisterUser(ctx, user.ID.String(), RegisterUserRequest{
+		Username: "ipetrov",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	entries, err = client.GetAuditEntries(ctx, user.ID.String(), 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "register_credentials", entries[0].Action)
+	assert.Equal(t, "update_user", entries[1].Action)
+	assert.Equal(t, "create_user", entries[2].Action)
+	assert.NotContains(t, entries[0].Details, "password", "Passwords must not be audited")
+
+	// 3. Failed mutations are not audited
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{Name: ""})
+	require.Error(t, err)
+
+	entries, err = client.GetAuditEntries(ctx, "", 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "register_credentials", entries[0].Action)
+
+	// 4. Invalid query parameters are rejected
+	_, err = client.GetAuditEntries(ctx, "not-a-uuid", 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// 5. Only admins can read the audit log
+	userClient := NewClient(app.URL)
+	_, err = userClient.Login(ctx, "ipetrov", "password123")
+	require.NoError(t, err)
+
+	_, err = userClient.GetAuditEntries(ctx, "", 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 403")
+}
+
+func TestAuditLogFilters(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+	const adminID = "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd"
+
+	var userIDs []uuid.UUID
+	for _, name := range []string{"Ivan", "Petr"} {
+		user, err := client.CreateUser(ctx, CreateUserRequest{FirstName: name, LastName: "Orlov", RoleID: 2})
+		require.NoError(t, err)
+		userIDs = append(userIDs, user.ID)
+	}
+
+	// The server and the tests share the clock
+	time.Sleep(time.Millisecond)
+	mid := time.Now()
+	time.Sleep(time.Millisecond)
+
+	_, err = client.PatchUser(ctx, userIDs[0].String(), PatchUserRequest{FirstName: stringPtr("Oleg")})
+	require.NoError(t, err)
+
+	actions := func(entries []AuditEntry) []string {
+		res := make([]string, len(entries))
+		for i, e := range entries {
+			res[i] = e.Action
+		}
+		return res
+	}
+
+	// 1. Filter by actor and date range
+	entries, next, err := client.QueryAuditEntries(ctx, url.Values{
+		"actorId": {adminID},
+		"from":    {mid.Format(time.RFC3339Nano)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"update_user"}, actions(entries))
+	assert.Equal(t, uuid.Nil, next)
+
+	entries, _, err = client.QueryAuditEntries(ctx, url.Values{"to": {mid.Format(time.RFC3339Nano)}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create_user", "create_user"}, actions(entries))
+
+	entries, _, err = client.QueryAuditEntries(ctx, url.Values{"actorId": {uuid.Must(uuid.NewV7()).String()}})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// 2. Pages of a filter follow the cursor
+	entries, next, err = client.QueryAuditEntries(ctx, url.Values{"action": {"create_user"}, "limit": {"1"}})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, userIDs[1], entries[0].TargetID)
+	require.NotEqual(t, uuid.Nil, next)
+
+	entries, next, err = client.QueryAuditEntries(ctx, url.Values{
+		"action": {"create_user"},
+		"limit":  {"1"},
+		"after":  {next.String()},
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, userIDs[0], entries[0].TargetID)
+	assert.Equal(t, uuid.Nil, next)
+
+	// 3. Invalid queries are rejected
+	for _, query := range []url.Values{
+		{"from": {mid.Format(time.RFC3339Nano)}, "to": {mid.Format(time.RFC3339Nano)}},
+		{"from": {"yesterday"}},
+		{"actorId": {"not-a-uuid"}},
+		{"after": {uuid.Must(uuid.NewV7()).String()}},
+		{"limit": {strconv.Itoa(501)}},
+	} {
+		_, _, err := client.QueryAuditEntries(ctx, query)
+		require.Error(t, err, query)
+		assert.Contains(t, err.Error(), "status: 400", query)
+	}
+}
+
+func TestAuditLogOrgScoping(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	other := NewClient(app.URL)
+	otherToken, err := other.LoginAdmin(ctx, testutil.OtherOrgAdmin, "admin")
+	require.NoError(t, err)
+	other.SetToken(otherToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{FirstName: "Ivan", LastName: "Orlov", RoleID: 2})
+	require.NoError(t, err)
+	otherUser, err := other.CreateUser(ctx, CreateUserRequest{FirstName: "Petr", LastName: "Orlov", RoleID: 2})
+	require.NoError(t, err)
+
+	// 1. Each organization only sees its own entries
+	entries, _, err := client.QueryAuditEntries(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, user.ID, entries[0].TargetID)
+
+	entries, _, err = other.QueryAuditEntries(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, otherUser.ID, entries[0].TargetID)
+
+	// 2. Filtering by a target of another organization finds nothing
+	entries, err = other.GetAuditEntries(ctx, user.ID.String(), 0)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
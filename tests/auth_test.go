@@ -1,8 +1,10 @@
 package tests
 
 import (
+	"net/url"
 	"testing"
 
+	"github.com/gofrs/uuid/v5"
 	"github.com/kozlov-ma/sesc-backend/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,9 +23,14 @@ func TestAdminLogin(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 
+	// The login response carries the admin identity
+	assert.Equal(t, "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd", client.Identity().ID.String())
+	assert.Equal(t, "admin", client.Identity().Role)
+
 	// Verify token is valid
-	err = client.ValidateToken(ctx)
+	identity, err := client.ValidateToken(ctx)
 	require.NoError(t, err)
+	assert.Equal(t, client.Identity(), *identity)
 
 	// Test admin login with incorrect credentials
 	_, err = client.LoginAdmin(ctx, "wrong", "wrong")
@@ -49,7 +56,7 @@ func TestLoginFlow(t *testing.T) {
 		FirstName:  "Test",
 		LastName:   "User",
 		RoleID:     2,
-		PictureURL: "/test.jpg",
+		PictureURL: "/images/test.jpg",
 	}
 
 	user, err := client.CreateUser(ctx, userData)
@@ -71,10 +78,15 @@ func TestLoginFlow(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, userToken)
 
+	// The login response carries the identity of the user
+	assert.Equal(t, user.ID, userClient.Identity().ID)
+	assert.Equal(t, "user", userClient.Identity().Role)
+
 	// Verify the token
 	userClient.SetToken(userToken)
-	err = userClient.ValidateToken(ctx)
+	identity, err := userClient.ValidateToken(ctx)
 	require.NoError(t, err)
+	assert.Equal(t, user.ID, identity.ID)
 
 	// Get current user and verify details
 	currentUser, err := userClient.GetCurrentUser(ctx)
@@ -83,3 +95,399 @@ func TestLoginFlow(t *testing.T) {
 	assert.Equal(t, userData.FirstName, currentUser.FirstName)
 	assert.Equal(t, userData.LastName, currentUser.LastName)
 }
+
+func TestWhoAmI(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	_, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+
+	// An admin has no user record
+	me, err := client.WhoAmI(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", me.Identity.Role)
+	assert.Equal(t, "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd", me.Identity.ID.String())
+	assert.Nil(t, me.User)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Test",
+		LastName:   "User",
+		RoleID:     2,
+		PictureURL: "/images/test.jpg",
+	})
+	require.NoError(t, err)
+	err = client.RegisterUser(ctx, user.ID.String(), RegisterUserRequest{
+		Username: "testuser",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	// A user gets their own record
+	userClient := NewClient(app.URL)
+	_, err = userClient.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+
+	me, err = userClient.WhoAmI(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user", me.Identity.Role)
+	assert.Equal(t, user.ID, me.Identity.ID)
+	require.NotNil(t, me.User)
+	assert.Equal(t, user.ID, me.User.ID)
+	assert.Equal(t, "Test", me.User.FirstName)
+
+	// Without a token
+	_, err = NewClient(app.URL).WhoAmI(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+}
+
+func TestHasCredentials(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Test",
+		LastName:   "User",
+		RoleID:     2,
+		PictureURL: "/images/test.jpg",
+	})
+	require.NoError(t, err)
+
+	// A user without credentials
+	has, err := client.HasCredentials(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// A user with credentials
+	err = client.RegisterUser(ctx, user.ID.String(), RegisterUserRequest{
+		Username: "testuser",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	has, err = client.HasCredentials(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// A nonexistent user
+	_, err = client.HasCredentials(ctx, "00000000-0000-0000-0000-000000000001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// An invalid user ID
+	_, err = client.HasCredentials(ctx, "not-a-uuid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestDeleteCredentials(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Test",
+		LastName:   "User",
+		RoleID:     2,
+		PictureURL: "/images/test.jpg",
+	})
+	require.NoError(t, err)
+
+	err = client.RegisterUser(ctx, user.ID.String(), RegisterUserRequest{
+		Username: "testuser",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	// Deleting the credentials
+	err = client.DeleteCredentials(ctx, user.ID.String())
+	require.NoError(t, err)
+
+	has, err := client.HasCredentials(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// Deleting them again is a no-op
+	err = client.DeleteCredentials(ctx, user.ID.String())
+	require.NoError(t, err)
+
+	// A nonexistent user is still not found
+	err = client.DeleteCredentials(ctx, "00000000-0000-0000-0000-000000000001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+	assert.Contains(t, err.Error(), "USER_NOT_FOUND")
+}
+
+func TestCredentialsOrgScoping(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Test",
+		LastName:  "User",
+		RoleID:    2,
+	})
+	require.NoError(t, err)
+
+	err = client.RegisterUser(ctx, user.ID.String(), RegisterUserRequest{
+		Username: "testuser",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	other := NewClient(app.URL)
+	otherToken, err := other.LoginAdmin(ctx, testutil.OtherOrgAdmin, "admin")
+	require.NoError(t, err)
+	other.SetToken(otherToken)
+
+	// 1. The credentials can't be read from another organization
+	_, err = other.GetCredentials(ctx, user.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// 2. Nor checked
+	_, err = other.HasCredentials(ctx, user.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+	assert.Contains(t, err.Error(), "USER_NOT_FOUND")
+
+	// 3. Nor deleted
+	err = other.DeleteCredentials(ctx, user.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+	assert.Contains(t, err.Error(), "USER_NOT_FOUND")
+
+	// 4. They are intact in the organization of the user
+	creds, err := client.GetCredentials(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "testuser", creds.Username)
+
+	has, err := client.HasCredentials(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestChangePassword(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	adminClient := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := adminClient.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	adminClient.SetToken(adminToken)
+
+	user, err := adminClient.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Test",
+		LastName:   "User",
+		RoleID:     2,
+		PictureURL: "/images/test.jpg",
+	})
+	require.NoError(t, err)
+
+	err = adminClient.RegisterUser(ctx, user.ID.String(), RegisterUserRequest{
+		Username: "testuser",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	userClient := NewClient(app.URL)
+	userToken, err := userClient.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+	userClient.SetToken(userToken)
+
+	// Wrong old password
+	err = userClient.ChangePassword(ctx, ChangePasswordRequest{
+		OldPassword: "wrongpassword",
+		NewPassword: "newpassword123",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_CREDENTIALS")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// Too short new password
+	err = userClient.ChangePassword(ctx, ChangePasswordRequest{
+		OldPassword: "password123",
+		NewPassword: "short",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WEAK_PASSWORD")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// Admins have no password to change
+	err = adminClient.ChangePassword(ctx, ChangePasswordRequest{
+		OldPassword: "admin",
+		NewPassword: "newpassword123",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 403")
+
+	// Success
+	err = userClient.ChangePassword(ctx, ChangePasswordRequest{
+		OldPassword: "password123",
+		NewPassword: "newpassword123",
+	})
+	require.NoError(t, err)
+
+	_, err = NewClient(app.URL).Login(ctx, "testuser", "password123")
+	require.Error(t, err)
+
+	_, err = NewClient(app.URL).Login(ctx, "testuser", "newpassword123")
+	require.NoError(t, err)
+}
+
+func TestResetPassword(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	adminClient := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := adminClient.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	adminClient.SetToken(adminToken)
+
+	user, err := adminClient.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Test",
+		LastName:   "User",
+		RoleID:     2,
+		PictureURL: "/images/test.jpg",
+	})
+	require.NoError(t, err)
+
+	err = adminClient.RegisterUser(ctx, user.ID.String(), RegisterUserRequest{
+		Username: "testuser",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	userClient := NewClient(app.URL)
+	userToken, err := userClient.Login(ctx, "testuser", "password123")
+	require.NoError(t, err)
+	userClient.SetToken(userToken)
+
+	// Users cannot reset passwords
+	err = userClient.ResetPassword(ctx, user.ID.String(), ResetPasswordRequest{NewPassword: "newpassword123"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 403")
+
+	// Non-existent user
+	err = adminClient.ResetPassword(ctx, uuid.Must(uuid.NewV7()).String(), ResetPasswordRequest{NewPassword: "newpassword123"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// Success
+	err = adminClient.ResetPassword(ctx, user.ID.String(), ResetPasswordRequest{NewPassword: "newpassword123"})
+	require.NoError(t, err)
+
+	_, err = NewClient(app.URL).Login(ctx, "testuser", "password123")
+	require.Error(t, err)
+
+	_, err = NewClient(app.URL).Login(ctx, "testuser", "newpassword123")
+	require.NoError(t, err)
+}
+
+func TestAllCredentials(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{Name: "Math"})
+	require.NoError(t, err)
+
+	teacher, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:    "Ivan",
+		LastName:     "Petrov",
+		RoleID:       1,
+		DepartmentID: dept.ID,
+		Username:     "ipetrov",
+		Password:     "password123",
+	})
+	require.NoError(t, err)
+	dephead, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Anna",
+		LastName:  "Smirnova",
+		RoleID:    2,
+		Username:  "asmirnova",
+		Password:  "password123",
+	})
+	require.NoError(t, err)
+	_, err = client.CreateUser(ctx, CreateUserRequest{FirstName: "No", LastName: "Credentials", RoleID: 1})
+	require.NoError(t, err)
+
+	// 1. All the usernames are listed, without the passwords
+	accounts, err := client.GetAllCredentials(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Account{
+		{UserID: dephead.ID, Username: "asmirnova"},
+		{UserID: teacher.ID, Username: "ipetrov"},
+	}, accounts)
+
+	// 2. Filters
+	accounts, err = client.GetAllCredentials(ctx, url.Values{"departmentId": {dept.ID.String()}})
+	require.NoError(t, err)
+	assert.Equal(t, []Account{{UserID: teacher.ID, Username: "ipetrov"}}, accounts)
+
+	accounts, err = client.GetAllCredentials(ctx, url.Values{"roleId": {"2"}})
+	require.NoError(t, err)
+	assert.Equal(t, []Account{{UserID: dephead.ID, Username: "asmirnova"}}, accounts)
+
+	_, err = client.GetAllCredentials(ctx, url.Values{"roleId": {"999"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// 3. Only admins may list the usernames
+	userClient := NewClient(app.URL)
+	userToken, err := userClient.Login(ctx, "ipetrov", "password123")
+	require.NoError(t, err)
+	userClient.SetToken(userToken)
+
+	_, err = userClient.GetAllCredentials(ctx, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 403")
+}
+
+func TestRoutePermissions(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	ctx := t.Context()
+
+	client := NewClient(app.URL)
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	routes, err := client.GetRoutePermissions(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, routes, RouteAccess{Method: "POST", Path: "/users", Access: "admin"})
+	assert.Contains(t, routes, RouteAccess{Method: "GET", Path: "/users/me", Access: "authenticated"})
+	assert.Contains(t, routes, RouteAccess{Method: "POST", Path: "/auth/login", Access: "public"})
+
+	// Only admins can list the routes
+	_, err = NewClient(app.URL).GetRoutePermissions(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+}
@@ -9,6 +9,10 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
 )
 
 // Client is the HTTP client for API testing
@@ -16,16 +20,29 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	token      string
+	identity   Identity
+	language   string
 }
 
-// NewClient creates a new API client
+// NewClient creates a new API client, asking for the error messages in English
 func NewClient(baseURL string) *Client {
 	return &Client{
 		baseURL:    baseURL,
 		httpClient: &http.Client{},
+		language:   "en",
 	}
 }
 
+// SetLanguage sets the Accept-Language header of subsequent requests, an empty one omits the header
+func (c *Client) SetLanguage(language string) {
+	c.language = language
+}
+
+// Identity returns the identity returned by the last login
+func (c *Client) Identity() Identity {
+	return c.identity
+}
+
 // SetToken sets the authorization token for subsequent requests
 func (c *Client) SetToken(token string) {
 	c.token = token
@@ -36,7 +53,6 @@ func (c *Client) makeRequest(
 	ctx context.Context,
 	method, endpoint string,
 	body any,
-	//nolint:unparam // this can be needed in the future.
 	query url.Values,
 ) (*http.Response, error) {
 	u, err := url.Parse(c.baseURL)
@@ -70,6 +86,9 @@ func (c *Client) makeRequest(
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
 
 	return c.httpClient.Do(req)
 }
@@ -88,6 +107,16 @@ func parseResponse(resp *http.Response, out any) error {
 		if err := json.Unmarshal(body, &apiError); err != nil {
 			return fmt.Errorf("http error %d: %s", resp.StatusCode, string(body))
 		}
+		if len(apiError.Fields) > 0 {
+			fields := make([]string, len(apiError.Fields))
+			for i, f := range apiError.Fields {
+				fields[i] = f.Field + ": " + f.Code
+			}
+			return fmt.Errorf(
+				"api error: %s (code: %s, status: %d, fields: %s)",
+				apiError.Message, apiError.Code, resp.StatusCode, strings.Join(fields, ", "),
+			)
+		}
 		return fmt.Errorf("api error: %s (code: %s, status: %d)", apiError.Message, apiError.Code, resp.StatusCode)
 	}
 
@@ -100,6 +129,36 @@ func parseResponse(resp *http.Response, out any) error {
 	return nil
 }
 
+// Ready calls the readiness probe and returns its status code
+func (c *Client) Ready(ctx context.Context) (int, *ReadinessResponse, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/readyz", nil, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	var ready ReadinessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ready); err != nil {
+		return 0, nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return resp.StatusCode, &ready, nil
+}
+
+// Metrics scrapes the Prometheus metrics
+func (c *Client) Metrics(ctx context.Context) (string, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/metrics", nil, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	return string(body), nil
+}
+
 // Login performs a login request and returns the token
 func (c *Client) Login(ctx context.Context, username, password string) (string, error) {
 	resp, err := c.makeRequest(ctx, http.MethodPost, "/auth/login", LoginRequest{
@@ -116,6 +175,7 @@ func (c *Client) Login(ctx context.Context, username, password string) (string,
 	}
 
 	c.token = loginResp.Token
+	c.identity = loginResp.Identity
 	return loginResp.Token, nil
 }
 
@@ -135,16 +195,36 @@ func (c *Client) LoginAdmin(ctx context.Context, username, password string) (str
 	}
 
 	c.token = loginResp.Token
+	c.identity = loginResp.Identity
 	return loginResp.Token, nil
 }
 
-// ValidateToken validates the current token
-func (c *Client) ValidateToken(ctx context.Context) error {
+// ValidateToken validates the current token and returns its identity
+func (c *Client) ValidateToken(ctx context.Context) (*Identity, error) {
 	resp, err := c.makeRequest(ctx, http.MethodGet, "/auth/validate", nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return parseResponse(resp, nil)
+
+	var identity Identity
+	if err := parseResponse(resp, &identity); err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// WhoAmI gets the identity of the current token along with the user record
+func (c *Client) WhoAmI(ctx context.Context) (*WhoAmIResponse, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/auth/whoami", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result WhoAmIResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // GetCurrentUser gets the current user
@@ -161,6 +241,22 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
 	return &user, nil
 }
 
+// GetCurrentUserPermissions gets the permissions of the current user
+func (c *Client) GetCurrentUserPermissions(ctx context.Context) ([]Permission, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/users/me/permissions", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissionsResp struct {
+		Permissions []Permission `json:"permissions"`
+	}
+	if err := parseResponse(resp, &permissionsResp); err != nil {
+		return nil, err
+	}
+	return permissionsResp.Permissions, nil
+}
+
 // GetUsers gets all users
 func (c *Client) GetUsers(ctx context.Context) ([]User, error) {
 	resp, err := c.makeRequest(ctx, http.MethodGet, "/users", nil, nil)
@@ -177,6 +273,110 @@ func (c *Client) GetUsers(ctx context.Context) ([]User, error) {
 	return usersResp.Users, nil
 }
 
+// FilterUsers gets the users matching the given query parameters
+func (c *Client) FilterUsers(ctx context.Context, query url.Values) ([]User, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/users", nil, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var usersResp struct {
+		Users []User `json:"users"`
+	}
+	if err := parseResponse(resp, &usersResp); err != nil {
+		return nil, err
+	}
+	return usersResp.Users, nil
+}
+
+// GetUsersBatch gets the users with the given IDs
+func (c *Client) GetUsersBatch(ctx context.Context, ids []uuid.UUID) ([]User, error) {
+	resp, err := c.makeRequest(ctx, http.MethodPost, "/users/batch", UsersBatchRequest{IDs: ids}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var usersResp struct {
+		Users []User `json:"users"`
+	}
+	if err := parseResponse(resp, &usersResp); err != nil {
+		return nil, err
+	}
+	return usersResp.Users, nil
+}
+
+// GetUsersPage gets a page of users matching the given query parameters and the cursor of the next page
+func (c *Client) GetUsersPage(ctx context.Context, query url.Values) ([]User, uuid.UUID, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/users", nil, query)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	var usersResp struct {
+		Users      []User    `json:"users"`
+		NextCursor uuid.UUID `json:"nextCursor"`
+	}
+	if err := parseResponse(resp, &usersResp); err != nil {
+		return nil, uuid.Nil, err
+	}
+	return usersResp.Users, usersResp.NextCursor, nil
+}
+
+// GetUsersOffsetPage gets a page of users selected by the offset and limit query parameters and its description
+func (c *Client) GetUsersOffsetPage(ctx context.Context, query url.Values) ([]User, *PageInfo, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/users", nil, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var usersResp struct {
+		Users []User    `json:"users"`
+		Page  *PageInfo `json:"page"`
+	}
+	if err := parseResponse(resp, &usersResp); err != nil {
+		return nil, nil, err
+	}
+	return usersResp.Users, usersResp.Page, nil
+}
+
+// ExportUsersCSV downloads the CSV user export, returning the response headers and body
+func (c *Client) ExportUsersCSV(ctx context.Context) (http.Header, []byte, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/users.csv", nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, nil, parseResponse(resp, nil)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp.Header, body, nil
+}
+
+// GetDepartmentRoster downloads the PDF roster of a department
+func (c *Client) GetDepartmentRoster(ctx context.Context, id string) (http.Header, []byte, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/departments/"+id+"/roster.pdf", nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, nil, parseResponse(resp, nil)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp.Header, body, nil
+}
+
 // GetUser gets a user by ID
 func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
 	resp, err := c.makeRequest(ctx, http.MethodGet, "/users/"+id, nil, nil)
@@ -219,6 +419,51 @@ func (c *Client) PatchUser(ctx context.Context, id string, req PatchUserRequest)
 	return &user, nil
 }
 
+// MergePatchUser applies a JSON Merge Patch to a user, where a nil value clears the field
+func (c *Client) MergePatchUser(ctx context.Context, id string, patch map[string]any) (*User, error) {
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, c.baseURL+"/users/"+id, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := parseResponse(resp, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ReplaceUser replaces every field of a user
+func (c *Client) ReplaceUser(ctx context.Context, id string, req ReplaceUserRequest) (*User, error) {
+	resp, err := c.makeRequest(ctx, http.MethodPut, "/users/"+id, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := parseResponse(resp, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // RegisterUser sets credentials for a user
 func (c *Client) RegisterUser(ctx context.Context, userID string, req RegisterUserRequest) error {
 	resp, err := c.makeRequest(ctx, http.MethodPut, "/users/"+userID+"/credentials", req, nil)
@@ -228,6 +473,77 @@ func (c *Client) RegisterUser(ctx context.Context, userID string, req RegisterUs
 	return parseResponse(resp, nil)
 }
 
+// ChangePassword changes the password of the current user
+func (c *Client) ChangePassword(ctx context.Context, req ChangePasswordRequest) error {
+	resp, err := c.makeRequest(ctx, http.MethodPost, "/users/me/password", req, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// ResetPassword replaces the password of a user without the old one
+func (c *Client) ResetPassword(ctx context.Context, userID string, req ResetPasswordRequest) error {
+	resp, err := c.makeRequest(ctx, http.MethodPost, "/users/"+userID+"/credentials/reset", req, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// HasCredentials checks whether a user has credentials
+func (c *Client) HasCredentials(ctx context.Context, userID string) (bool, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/users/"+userID+"/credentials/exists", nil, nil)
+	if err != nil {
+		return false, err
+	}
+
+	var result CredentialsExistResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return false, err
+	}
+	return result.HasCredentials, nil
+}
+
+// GetAllCredentials lists the usernames of the users matching the query, e.g. departmentId and roleId
+func (c *Client) GetAllCredentials(ctx context.Context, query url.Values) ([]Account, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/auth/credentials", nil, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Accounts []Account `json:"accounts"`
+	}
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Accounts, nil
+}
+
+// GetCredentials gets the credentials of a user
+func (c *Client) GetCredentials(ctx context.Context, userID string) (*RegisterUserRequest, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/auth/credentials/"+userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds RegisterUserRequest
+	if err := parseResponse(resp, &creds); err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
+
+// DeleteCredentials deletes the credentials of a user
+func (c *Client) DeleteCredentials(ctx context.Context, userID string) error {
+	resp, err := c.makeRequest(ctx, http.MethodDelete, "/auth/credentials/"+userID, nil, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
 // GetDepartments gets all departments
 func (c *Client) GetDepartments(ctx context.Context) ([]Department, error) {
 	resp, err := c.makeRequest(ctx, http.MethodGet, "/departments", nil, nil)
@@ -244,6 +560,23 @@ func (c *Client) GetDepartments(ctx context.Context) ([]Department, error) {
 	return departmentsResp.Departments, nil
 }
 
+// GetDepartmentsByIDs gets the departments with the given IDs
+func (c *Client) GetDepartmentsByIDs(ctx context.Context, ids ...string) ([]Department, error) {
+	query := url.Values{"ids": {strings.Join(ids, ",")}}
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/departments", nil, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var departmentsResp struct {
+		Departments []Department `json:"departments"`
+	}
+	if err := parseResponse(resp, &departmentsResp); err != nil {
+		return nil, err
+	}
+	return departmentsResp.Departments, nil
+}
+
 // CreateDepartment creates a new department
 func (c *Client) CreateDepartment(ctx context.Context, req CreateDepartmentRequest) (*Department, error) {
 	resp, err := c.makeRequest(ctx, http.MethodPost, "/departments", req, nil)
@@ -258,6 +591,40 @@ func (c *Client) CreateDepartment(ctx context.Context, req CreateDepartmentReque
 	return &department, nil
 }
 
+// CreateDepartmentWithHead creates a new department together with its head
+func (c *Client) CreateDepartmentWithHead(
+	ctx context.Context,
+	req CreateDepartmentRequest,
+) (*CreateDepartmentResponse, error) {
+	resp, err := c.makeRequest(ctx, http.MethodPost, "/departments", req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var created CreateDepartmentResponse
+	if err := parseResponse(resp, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetDepartmentsPage gets a page of departments selected by the offset and limit query parameters and its description
+func (c *Client) GetDepartmentsPage(ctx context.Context, query url.Values) ([]Department, *PageInfo, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/departments", nil, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var deptsResp struct {
+		Departments []Department `json:"departments"`
+		Page        *PageInfo    `json:"page"`
+	}
+	if err := parseResponse(resp, &deptsResp); err != nil {
+		return nil, nil, err
+	}
+	return deptsResp.Departments, deptsResp.Page, nil
+}
+
 // UpdateDepartment updates a department
 func (c *Client) UpdateDepartment(ctx context.Context, id string, req UpdateDepartmentRequest) (*Department, error) {
 	resp, err := c.makeRequest(ctx, http.MethodPut, "/departments/"+id, req, nil)
@@ -272,6 +639,50 @@ func (c *Client) UpdateDepartment(ctx context.Context, id string, req UpdateDepa
 	return &department, nil
 }
 
+// GetDepartmentHead gets the head of a department
+func (c *Client) GetDepartmentHead(ctx context.Context, id string) (*User, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/departments/"+id+"/head", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := parseResponse(resp, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetDepartmentUsers gets the users of a department
+func (c *Client) GetDepartmentUsers(ctx context.Context, id string) ([]User, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/departments/"+id+"/users", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var usersResp struct {
+		Users []User `json:"users"`
+	}
+	if err := parseResponse(resp, &usersResp); err != nil {
+		return nil, err
+	}
+	return usersResp.Users, nil
+}
+
+// GetDepartmentDeletable gets whether a department can be deleted
+func (c *Client) GetDepartmentDeletable(ctx context.Context, id string) (*DepartmentDeletable, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/departments/"+id+"/deletable", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletable DepartmentDeletable
+	if err := parseResponse(resp, &deletable); err != nil {
+		return nil, err
+	}
+	return &deletable, nil
+}
+
 // DeleteDepartment deletes a department
 func (c *Client) DeleteDepartment(ctx context.Context, id string) error {
 	resp, err := c.makeRequest(ctx, http.MethodDelete, "/departments/"+id, nil, nil)
@@ -281,6 +692,213 @@ func (c *Client) DeleteDepartment(ctx context.Context, id string) error {
 	return parseResponse(resp, nil)
 }
 
+// TransferDepartmentUsers moves all the users of a department to another one
+func (c *Client) TransferDepartmentUsers(ctx context.Context, id string, req TransferDepartmentUsersRequest) (int, error) {
+	resp, err := c.makeRequest(ctx, http.MethodPost, "/departments/"+id+"/transfer", req, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var result TransferDepartmentUsersResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return 0, err
+	}
+	return result.Moved, nil
+}
+
+// ArchiveUser archives a user
+func (c *Client) ArchiveUser(ctx context.Context, id string) error {
+	resp, err := c.makeRequest(ctx, http.MethodDelete, "/users/"+id, nil, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// SuspendUser suspends a user
+func (c *Client) SuspendUser(ctx context.Context, id string) error {
+	resp, err := c.makeRequest(ctx, http.MethodPost, "/users/"+id+"/suspend", nil, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// ReinstateUser lifts the suspension of a user
+func (c *Client) ReinstateUser(ctx context.Context, id string) error {
+	resp, err := c.makeRequest(ctx, http.MethodPost, "/users/"+id+"/reinstate", nil, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// SetRole changes the role of a user
+func (c *Client) SetRole(ctx context.Context, id string, roleID int32) error {
+	resp, err := c.makeRequest(ctx, http.MethodPut, "/users/"+id+"/role", SetRoleRequest{RoleID: roleID}, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// SetDepartment changes the department of a user, clearing it if deptID is uuid.Nil
+func (c *Client) SetDepartment(ctx context.Context, id string, deptID uuid.UUID) error {
+	resp, err := c.makeRequest(ctx, http.MethodPut, "/users/"+id+"/department", SetDepartmentRequest{DepartmentID: deptID}, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// GetEmploymentHistory returns the changes of the role and the department of a user, oldest first
+func (c *Client) GetEmploymentHistory(ctx context.Context, id string) ([]EmploymentEvent, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/users/"+id+"/history", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var history struct {
+		Events []EmploymentEvent `json:"events"`
+	}
+	if err := parseResponse(resp, &history); err != nil {
+		return nil, err
+	}
+	return history.Events, nil
+}
+
+// GrantPermissions grants extra permissions to a user
+func (c *Client) GrantPermissions(ctx context.Context, id string, permissionIDs ...int32) error {
+	resp, err := c.makeRequest(ctx, http.MethodPost, "/users/"+id+"/permissions", PermissionsRequest{PermissionIDs: permissionIDs}, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// RevokePermissions revokes extra permissions of a user
+func (c *Client) RevokePermissions(ctx context.Context, id string, permissionIDs ...int32) error {
+	resp, err := c.makeRequest(ctx, http.MethodDelete, "/users/"+id+"/permissions", PermissionsRequest{PermissionIDs: permissionIDs}, nil)
+	if err != nil {
+		return err
+	}
+	return parseResponse(resp, nil)
+}
+
+// GetAuditEntries lists audit log entries, optionally only the ones about targetID
+func (c *Client) GetAuditEntries(ctx context.Context, targetID string, limit int) ([]AuditEntry, error) {
+	query := url.Values{}
+	if targetID != "" {
+		query.Set("targetId", targetID)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/audit", nil, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var auditResp struct {
+		Entries []AuditEntry `json:"entries"`
+	}
+	if err := parseResponse(resp, &auditResp); err != nil {
+		return nil, err
+	}
+	return auditResp.Entries, nil
+}
+
+// QueryAuditEntries lists audit log entries matching the given query parameters
+// and returns the cursor of the next page
+func (c *Client) QueryAuditEntries(ctx context.Context, query url.Values) ([]AuditEntry, uuid.UUID, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/audit", nil, query)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	var auditResp struct {
+		Entries    []AuditEntry `json:"entries"`
+		NextCursor uuid.UUID    `json:"nextCursor"`
+	}
+	if err := parseResponse(resp, &auditResp); err != nil {
+		return nil, uuid.Nil, err
+	}
+	return auditResp.Entries, auditResp.NextCursor, nil
+}
+
+// GetMigrationStatus gets the database schema status
+func (c *Client) GetMigrationStatus(ctx context.Context) (*MigrationStatus, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/dev/migrations", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var status MigrationStatus
+	if err := parseResponse(resp, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetReadOnly gets whether the service is read-only
+func (c *Client) GetReadOnly(ctx context.Context) (*ReadOnly, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/dev/readonly", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var state ReadOnly
+	if err := parseResponse(resp, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// SetReadOnly turns the read-only mode of the service on or off
+func (c *Client) SetReadOnly(ctx context.Context, readOnly bool) (*ReadOnly, error) {
+	resp, err := c.makeRequest(ctx, http.MethodPut, "/dev/readonly", ReadOnly{ReadOnly: readOnly}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var state ReadOnly
+	if err := parseResponse(resp, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// GetRoutePermissions gets the access required by each route
+func (c *Client) GetRoutePermissions(ctx context.Context) ([]RouteAccess, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/permissions/routes", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var routesResp struct {
+		Routes []RouteAccess `json:"routes"`
+	}
+	if err := parseResponse(resp, &routesResp); err != nil {
+		return nil, err
+	}
+	return routesResp.Routes, nil
+}
+
+// GetStats gets the overview of the departments and the users
+func (c *Client) GetStats(ctx context.Context) (*Stats, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/stats", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats Stats
+	if err := parseResponse(resp, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
 // GetRoles gets all roles
 func (c *Client) GetRoles(ctx context.Context) ([]Role, error) {
 	resp, err := c.makeRequest(ctx, http.MethodGet, "/roles", nil, nil)
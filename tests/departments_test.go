@@ -1,6 +1,9 @@
 package tests
 
 import (
+	"bytes"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/gofrs/uuid/v5"
@@ -87,3 +90,593 @@ func TestDepartmentCRUD(t *testing.T) {
 		assert.NotEqual(t, createdDept.ID, dept.ID, "Department should have been deleted")
 	}
 }
+
+func TestUpdateDepartmentErrors(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	math, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Mathematics",
+		Description: "Math department",
+	})
+	require.NoError(t, err)
+
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Physics",
+		Description: "Physics department",
+	})
+	require.NoError(t, err)
+
+	// 1. Renaming to an existing name is a conflict
+	_, err = client.UpdateDepartment(ctx, math.ID.String(), UpdateDepartmentRequest{
+		Name:        "Physics",
+		Description: "Math department",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEPARTMENT_EXISTS")
+	assert.Contains(t, err.Error(), "status: 409")
+
+	// 2. Updating a nonexistent department is not found
+	_, err = client.UpdateDepartment(ctx, uuid.Must(uuid.NewV7()).String(), UpdateDepartmentRequest{
+		Name:        "Chemistry",
+		Description: "Chemistry department",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEPARTMENT_NOT_FOUND")
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// 3. Renaming to an existing name in another case is a conflict too
+	_, err = client.UpdateDepartment(ctx, math.ID.String(), UpdateDepartmentRequest{
+		Name:        " physics",
+		Description: "Math department",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEPARTMENT_EXISTS")
+}
+
+func TestGetDepartmentsByIDs(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	math, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Mathematics",
+		Description: "Math department",
+	})
+	require.NoError(t, err)
+	physics, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Physics",
+		Description: "Physics department",
+	})
+	require.NoError(t, err)
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Chemistry",
+		Description: "Chemistry department",
+	})
+	require.NoError(t, err)
+
+	// The departments come in the requested order, the missing ones are skipped
+	missing := uuid.Must(uuid.NewV7()).String()
+	depts, err := client.GetDepartmentsByIDs(ctx, physics.ID.String(), missing, math.ID.String())
+	require.NoError(t, err)
+	require.Len(t, depts, 2)
+	assert.Equal(t, physics.ID, depts[0].ID)
+	assert.Equal(t, "Physics", depts[0].Name)
+	assert.Equal(t, math.ID, depts[1].ID)
+
+	// Only missing departments
+	depts, err = client.GetDepartmentsByIDs(ctx, missing)
+	require.NoError(t, err)
+	assert.Empty(t, depts)
+
+	// An invalid ID
+	_, err = client.GetDepartmentsByIDs(ctx, math.ID.String(), "not-a-uuid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestDepartmentNameValidation(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "IT",
+		Description: "Information technology",
+	})
+	require.NoError(t, err)
+
+	// Names are unique ignoring case
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "it",
+		Description: "Another IT",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEPARTMENT_EXISTS")
+	assert.Contains(t, err.Error(), "status: 409")
+
+	// Missing and blank names
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Description: "No name",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name: REQUIRED")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "   ",
+		Description: "Blank name",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_NAME")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// Too long name
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        strings.Repeat("a", 101),
+		Description: "Long name",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_NAME")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// Too long description
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Physics",
+		Description: strings.Repeat("a", 2001),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_NAME")
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestDepartmentHead(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	token, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(token)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Mathematics",
+		Description: "Math department",
+	})
+	require.NoError(t, err)
+
+	// Department without a head
+	_, err = client.GetDepartmentHead(ctx, dept.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "USER_NOT_FOUND")
+	assert.Contains(t, err.Error(), "status: 404")
+
+	head, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:    "Jane",
+		LastName:     "Head",
+		RoleID:       2,
+		DepartmentID: dept.ID,
+		PictureURL:   "/images/test.jpg",
+	})
+	require.NoError(t, err)
+
+	// Department with a head
+	got, err := client.GetDepartmentHead(ctx, dept.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, head.ID, got.ID)
+
+	// Nonexistent department
+	_, err = client.GetDepartmentHead(ctx, "00000000-0000-0000-0000-000000000001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEPARTMENT_NOT_FOUND")
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// Invalid department ID
+	_, err = client.GetDepartmentHead(ctx, "not-a-uuid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestDepartmentUsers(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	token, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(token)
+
+	math, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Mathematics",
+		Description: "Math department",
+	})
+	require.NoError(t, err)
+	physics, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Physics",
+		Description: "Physics department",
+	})
+	require.NoError(t, err)
+
+	teacher, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:    "Ivan",
+		LastName:     "Petrov",
+		RoleID:       1,
+		DepartmentID: math.ID,
+	})
+	require.NoError(t, err)
+
+	// Populated department
+	users, err := client.GetDepartmentUsers(ctx, math.ID.String())
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, teacher.ID, users[0].ID)
+
+	// Empty department
+	users, err = client.GetDepartmentUsers(ctx, physics.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	// Nonexistent department
+	_, err = client.GetDepartmentUsers(ctx, "00000000-0000-0000-0000-000000000001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEPARTMENT_NOT_FOUND")
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// Invalid department ID
+	_, err = client.GetDepartmentUsers(ctx, "not-a-uuid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestDepartmentRoster(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	token, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(token)
+
+	chemistry, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Химия",
+		Description: "Chemistry department",
+	})
+	require.NoError(t, err)
+
+	for _, req := range []CreateUserRequest{
+		{FirstName: "Анна", LastName: "Смирнова", MiddleName: "Петровна", JobTitle: "Учитель химии", AcademicTitle: "Доцент"},
+		{FirstName: "Ivan", LastName: "Petrov", JobTitle: "Teacher of chemistry"},
+	} {
+		req.RoleID = 1
+		req.DepartmentID = chemistry.ID
+		_, err := client.CreateUser(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// 1. The roster is a PDF
+	header, body, err := client.GetDepartmentRoster(ctx, chemistry.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", header.Get("Content-Type"))
+	assert.Contains(t, header.Get("Content-Disposition"), "roster.pdf")
+	require.NotEmpty(t, body)
+	assert.True(t, bytes.HasPrefix(body, []byte("%PDF")), "starts with the PDF magic")
+
+	// 2. An empty department has an empty roster
+	empty, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Empty",
+		Description: "No users yet",
+	})
+	require.NoError(t, err)
+	_, body, err = client.GetDepartmentRoster(ctx, empty.ID.String())
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(body, []byte("%PDF")))
+
+	// 3. Nonexistent department
+	_, _, err = client.GetDepartmentRoster(ctx, "00000000-0000-0000-0000-000000000001")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// 4. Invalid department ID
+	_, _, err = client.GetDepartmentRoster(ctx, "not-a-uuid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestDepartmentDeletable(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	token, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(token)
+
+	math, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Mathematics",
+		Description: "Math department",
+	})
+	require.NoError(t, err)
+	physics, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Physics",
+		Description: "Physics department",
+	})
+	require.NoError(t, err)
+
+	_, err = client.CreateUser(ctx, CreateUserRequest{
+		FirstName:    "Ivan",
+		LastName:     "Petrov",
+		RoleID:       1,
+		DepartmentID: math.ID,
+	})
+	require.NoError(t, err)
+
+	// Populated department
+	deletable, err := client.GetDepartmentDeletable(ctx, math.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, DepartmentDeletable{Deletable: false, UserCount: 1}, *deletable)
+
+	err = client.DeleteDepartment(ctx, math.ID.String())
+	require.Error(t, err, "A non-deletable department should not be deleted")
+
+	// Empty department
+	deletable, err = client.GetDepartmentDeletable(ctx, physics.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, DepartmentDeletable{Deletable: true, UserCount: 0}, *deletable)
+
+	require.NoError(t, client.DeleteDepartment(ctx, physics.ID.String()))
+
+	// Nonexistent department
+	_, err = client.GetDepartmentDeletable(ctx, physics.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEPARTMENT_NOT_FOUND")
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// Invalid department ID
+	_, err = client.GetDepartmentDeletable(ctx, "not-a-uuid")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestCreateDepartmentWithHead(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	token, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(token)
+
+	created, err := client.CreateDepartmentWithHead(ctx, CreateDepartmentRequest{
+		Name:        "Mathematics",
+		Description: "Math department",
+		Head: &DepartmentHeadRequest{
+			FirstName: "Jane",
+			LastName:  "Head",
+			JobTitle:  "Head of the department",
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.Head)
+	assert.Equal(t, "Mathematics", created.Name)
+	assert.Equal(t, int32(2), created.Head.Role.ID)
+	assert.Equal(t, created.ID, created.Head.Department.ID)
+
+	head, err := client.GetDepartmentHead(ctx, created.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, created.Head.ID, head.ID)
+
+	requireUnchanged := func(t *testing.T) {
+		t.Helper()
+		depts, err := client.GetDepartments(ctx)
+		require.NoError(t, err)
+		assert.Len(t, depts, 1)
+
+		users, err := client.GetUsers(ctx)
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+	}
+
+	// Invalid head data, nothing is created
+	_, err = client.CreateDepartmentWithHead(ctx, CreateDepartmentRequest{
+		Name: "Physics",
+		Head: &DepartmentHeadRequest{
+			FirstName:      "Jim",
+			LastName:       "Head",
+			EmploymentRate: 100,
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_EMPLOYMENT_DATA")
+	assert.Contains(t, err.Error(), "status: 400")
+	requireUnchanged(t)
+
+	// Missing head name
+	_, err = client.CreateDepartmentWithHead(ctx, CreateDepartmentRequest{
+		Name: "Physics",
+		Head: &DepartmentHeadRequest{FirstName: "Jim"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+	requireUnchanged(t)
+
+	// Existing department, the head is not created either
+	_, err = client.CreateDepartmentWithHead(ctx, CreateDepartmentRequest{
+		Name: "Mathematics",
+		Head: &DepartmentHeadRequest{
+			FirstName: "Jim",
+			LastName:  "Head",
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 409")
+	requireUnchanged(t)
+}
+
+func TestTransferDepartmentUsers(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	token, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(token)
+
+	from, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Mathematics",
+		Description: "Math department",
+	})
+	require.NoError(t, err)
+	to, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Physics",
+		Description: "Physics department",
+	})
+	require.NoError(t, err)
+
+	var users []*User
+	for range 3 {
+		user, err := client.CreateUser(ctx, CreateUserRequest{
+			FirstName:    "Ivan",
+			LastName:     "Petrov",
+			RoleID:       1,
+			DepartmentID: from.ID,
+		})
+		require.NoError(t, err)
+		users = append(users, user)
+	}
+
+	// 1. All the users are moved to the target department
+	moved, err := client.TransferDepartmentUsers(ctx, from.ID.String(), TransferDepartmentUsersRequest{TargetID: to.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 3, moved)
+
+	for _, u := range users {
+		got, err := client.GetUser(ctx, u.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, to.ID, got.Department.ID)
+	}
+
+	// 2. The now-empty source department can be deleted
+	require.NoError(t, client.DeleteDepartment(ctx, from.ID.String()))
+
+	// 3. Nonexistent target department
+	_, err = client.TransferDepartmentUsers(ctx, to.ID.String(), TransferDepartmentUsersRequest{
+		TargetID: uuid.Must(uuid.NewV7()),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_DEPARTMENT")
+	assert.Contains(t, err.Error(), "status: 409")
+
+	// 4. Nonexistent source department
+	_, err = client.TransferDepartmentUsers(ctx, from.ID.String(), TransferDepartmentUsersRequest{TargetID: to.ID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DEPARTMENT_NOT_FOUND")
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// 5. Missing target department
+	_, err = client.TransferDepartmentUsers(ctx, to.ID.String(), TransferDepartmentUsersRequest{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "targetId: REQUIRED")
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestDepartmentsPagination(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	ctx := t.Context()
+
+	client := NewClient(app.URL)
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	for _, name := range []string{"Physics", "Chemistry", "Math"} {
+		_, err := client.CreateDepartment(ctx, CreateDepartmentRequest{Name: name, Description: name})
+		require.NoError(t, err)
+	}
+
+	// A page that is not the last one, ordered by name
+	depts, page, err := client.GetDepartmentsPage(ctx, url.Values{"limit": {"2"}})
+	require.NoError(t, err)
+	require.Len(t, depts, 2)
+	assert.Equal(t, "Chemistry", depts[0].Name)
+	assert.Equal(t, "Math", depts[1].Name)
+	require.NotNil(t, page)
+	assert.Equal(t, PageInfo{Limit: 2, Offset: 0, Total: 3, HasMore: true}, *page)
+
+	// The last page
+	depts, page, err = client.GetDepartmentsPage(ctx, url.Values{"offset": {"2"}, "limit": {"2"}})
+	require.NoError(t, err)
+	require.Len(t, depts, 1)
+	assert.Equal(t, "Physics", depts[0].Name)
+	require.NotNil(t, page)
+	assert.Equal(t, PageInfo{Limit: 2, Offset: 2, Total: 3, HasMore: false}, *page)
+
+	// Without offset and limit, all the departments are returned without a page description
+	depts, page, err = client.GetDepartmentsPage(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, depts, 3)
+	assert.Nil(t, page)
+}
+
+func TestStats(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	ctx := t.Context()
+
+	client := NewClient(app.URL)
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	math, err := client.CreateDepartment(ctx, CreateDepartmentRequest{Name: "Math", Description: "Math department"})
+	require.NoError(t, err)
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{Name: "Physics", Description: "Physics department"})
+	require.NoError(t, err)
+
+	for _, u := range []CreateUserRequest{
+		{FirstName: "Ivan", LastName: "Petrov", RoleID: 1, DepartmentID: math.ID, EmploymentRate: 1},
+		{FirstName: "Anna", LastName: "Sokolova", RoleID: 1, DepartmentID: math.ID, EmploymentRate: 0.5},
+		{FirstName: "Oleg", LastName: "Ivanov", RoleID: 2, DepartmentID: math.ID},
+	} {
+		_, err := client.CreateUser(ctx, u)
+		require.NoError(t, err)
+	}
+
+	stats, err := client.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Departments)
+	assert.Equal(t, 3, stats.Users)
+	assert.Equal(t, []RoleCount{
+		{RoleID: 1, Count: 2},
+		{RoleID: 2, Count: 1},
+		{RoleID: 3, Count: 0},
+		{RoleID: 4, Count: 0},
+		{RoleID: 5, Count: 0},
+	}, stats.UsersByRole)
+	assert.InDelta(t, 0.75, stats.AverageEmploymentRate, 1e-9)
+
+	// Only admins can get the stats
+	_, err = NewClient(app.URL).GetStats(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"net/http"
 	"strings"
 	"testing"
 
@@ -30,6 +31,31 @@ func TestAuthenticationErrors(t *testing.T) {
 	assert.Contains(t, strings.ToLower(err.Error()), "unauthorized")
 }
 
+func TestErrorLanguage(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	for _, tc := range []struct {
+		language string
+		message  string
+	}{
+		{"en", "Unauthorized access"},
+		{"en-US,en;q=0.9", "Unauthorized access"},
+		{"ru", "Требуется аутентификация"},
+		{"de, en;q=0.5, ru;q=0.8", "Требуется аутентификация"},
+		{"", "Требуется аутентификация"},
+		{"fr", "Требуется аутентификация"},
+	} {
+		client.SetLanguage(tc.language)
+
+		_, err := client.GetUsers(ctx)
+		require.Error(t, err, tc.language)
+		assert.Contains(t, err.Error(), tc.message, tc.language)
+		assert.Contains(t, err.Error(), "UNAUTHORIZED", tc.language)
+	}
+}
+
 func TestAuthorizationErrors(t *testing.T) {
 	app := testutil.StartTestApp(t)
 	adminClient := NewClient(app.URL)
@@ -125,6 +151,107 @@ func TestValidationErrors(t *testing.T) {
 	}
 }
 
+func TestFieldValidationErrors(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	client := NewClient(app.URL)
+	ctx := t.Context()
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	// All invalid fields should be reported at once
+	_, err = client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "",
+		LastName:  "User",
+		RoleID:    999,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VALIDATION_ERROR")
+	assert.Contains(t, err.Error(), "status: 400")
+	assert.Contains(t, err.Error(), "firstName: REQUIRED")
+	assert.Contains(t, err.Error(), "roleId: INVALID_ROLE")
+	assert.NotContains(t, err.Error(), "lastName")
+
+	users, err := client.GetUsers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	// Only the fields present in a patch are validated
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Valid",
+		LastName:  "User",
+		RoleID:    2,
+	})
+	require.NoError(t, err)
+
+	invalidRole := int32(999)
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		LastName: stringPtr(""),
+		RoleID:   &invalidRole,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lastName: REQUIRED")
+	assert.Contains(t, err.Error(), "roleId: INVALID_ROLE")
+	assert.NotContains(t, err.Error(), "firstName")
+
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{Name: ""})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name: REQUIRED")
+
+	// Nested fields are named by their path
+	_, err = client.CreateDepartmentWithHead(ctx, CreateDepartmentRequest{
+		Name: "Physics",
+		Head: &DepartmentHeadRequest{FirstName: "Anna"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "head.lastName: REQUIRED")
+	assert.NotContains(t, err.Error(), "firstName")
+
+	// Credentials are validated before they reach IAM
+	err = client.RegisterUser(ctx, user.ID.String(), RegisterUserRequest{Username: "valid"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+	assert.Contains(t, err.Error(), "password: REQUIRED")
+	assert.NotContains(t, err.Error(), "username")
+
+	_, err = NewClient(app.URL).Login(ctx, "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "username: REQUIRED")
+	assert.Contains(t, err.Error(), "password: REQUIRED")
+}
+
+func TestUnknownFieldErrors(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	client := NewClient(app.URL)
+	ctx := t.Context()
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Valid",
+		LastName:  "User",
+		RoleID:    2,
+	})
+	require.NoError(t, err)
+
+	// A misspelled field is reported instead of being silently ignored
+	resp, err := client.makeRequest(ctx, http.MethodPatch, "/users/"+user.ID.String(), map[string]any{
+		"lastName":  "Patched",
+		"firstNmae": "Typo",
+	}, nil)
+	require.NoError(t, err)
+	err = parseResponse(resp, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VALIDATION_ERROR")
+	assert.Contains(t, err.Error(), "status: 400")
+	assert.Contains(t, err.Error(), "firstNmae: UNKNOWN_FIELD")
+
+	got, err := client.GetUser(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "User", got.LastName)
+}
+
 func TestResourceNotFoundErrors(t *testing.T) {
 	app := testutil.StartTestApp(t)
 	client := NewClient(app.URL)
@@ -145,7 +272,7 @@ func TestResourceNotFoundErrors(t *testing.T) {
 		Name: "Updated Department",
 	})
 	require.Error(t, err)
-	assert.Contains(t, strings.ToLower(err.Error()), "invalid_department")
+	assert.Contains(t, strings.ToLower(err.Error()), "department_not_found")
 
 	// Test deleting non-existent department
 	err = client.DeleteDepartment(ctx, randomID)
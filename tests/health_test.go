@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	resp, err := client.makeRequest(ctx, http.MethodGet, "/healthz", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, parseResponse(resp, nil))
+}
+
+func TestReadiness(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	// 1. Healthy database
+	status, ready, err := client.Ready(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok", ready.Status)
+	assert.Empty(t, ready.Failed)
+
+	// 2. Broken database connection
+	require.NoError(t, app.Client.Close())
+
+	status, ready, err = client.Ready(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "unavailable", ready.Status)
+	assert.Equal(t, []string{"postgres"}, ready.Failed)
+}
+
+func TestRequestID(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	ctx := t.Context()
+
+	get := func(requestID string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, app.URL+"/healthz", nil)
+		require.NoError(t, err)
+		if requestID != "" {
+			req.Header.Set("X-Request-ID", requestID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	// 1. A client request ID is echoed back
+	resp := get("trace-0123")
+	assert.Equal(t, "trace-0123", resp.Header.Get("X-Request-ID"))
+
+	// 2. A request ID is generated when there is none
+	first := get("").Header.Get("X-Request-ID")
+	second := get("").Header.Get("X-Request-ID")
+	assert.NotEmpty(t, first)
+	assert.NotEqual(t, first, second, "Every request gets its own ID")
+}
@@ -0,0 +1,30 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	metrics, err := client.Metrics(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, metrics, `route="/departments"`)
+
+	_, err = client.GetDepartments(ctx)
+	require.NoError(t, err)
+	_, err = client.GetDepartments(ctx)
+	require.NoError(t, err)
+
+	metrics, err = client.Metrics(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, metrics, `sesc_http_requests_total{code="200",method="GET",route="/departments"} 2`)
+	assert.Contains(t, metrics, `sesc_postgres_queries_total{method="GET",route="/departments"} 2`)
+	assert.Contains(t, metrics, `sesc_postgres_time_seconds_count{method="GET",route="/departments"} 2`)
+}
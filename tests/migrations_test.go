@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/db/migrations"
+	"github.com/kozlov-ma/sesc-backend/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationStatus(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	ctx := t.Context()
+
+	client := NewClient(app.URL)
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	// 1. No schema was applied through the migrations yet
+	status, err := client.GetMigrationStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrations.SchemaHash(), status.Hash)
+	assert.Empty(t, status.AppliedHash)
+	assert.False(t, status.UpToDate)
+
+	// 2. A migrated database reports the applied schema
+	require.NoError(t, migrations.New(app.Client).Apply(ctx))
+
+	status, err = client.GetMigrationStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrations.SchemaHash(), status.AppliedHash)
+	assert.False(t, status.AppliedAt.IsZero())
+	assert.True(t, status.UpToDate)
+
+	// 3. Only admins can read the schema status
+	_, err = NewClient(app.URL).GetMigrationStatus(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+}
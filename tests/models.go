@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid/v5"
 )
 
@@ -14,19 +16,47 @@ type LoginRequest struct {
 
 // LoginResponse contains the JWT token from a successful login
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token    string   `json:"token"`
+	Identity Identity `json:"identity"`
+}
+
+// Identity is the user a token was issued to
+type Identity struct {
+	ID   uuid.UUID `json:"id"`
+	Role string    `json:"role"`
+}
+
+// WhoAmIResponse is the identity of a token along with the user record, which is nil for admins
+type WhoAmIResponse struct {
+	Identity Identity `json:"identity"`
+	User     *User    `json:"user"`
 }
 
 // User represents a user in the system
 type User struct {
-	ID         uuid.UUID  `json:"id"`
-	FirstName  string     `json:"firstName"`
-	LastName   string     `json:"lastName"`
-	MiddleName string     `json:"middleName,omitempty"`
-	PictureURL string     `json:"pictureUrl"`
-	Role       Role       `json:"role"`
-	Suspended  bool       `json:"suspended"`
-	Department Department `json:"department,omitempty"`
+	ID               uuid.UUID    `json:"id"`
+	FirstName        string       `json:"firstName"`
+	LastName         string       `json:"lastName"`
+	MiddleName       string       `json:"middleName,omitempty"`
+	Email            string       `json:"email,omitempty"`
+	PictureURL       string       `json:"pictureUrl"`
+	Role             Role         `json:"role"`
+	ExtraPermissions []Permission `json:"extraPermissions"`
+	Suspended        bool         `json:"suspended"`
+	Department       Department   `json:"department,omitempty"`
+	Version          int          `json:"version"`
+
+	Subdivision       string     `json:"subdivision"`
+	JobTitle          string     `json:"jobTitle"`
+	EmploymentRate    float64    `json:"employmentRate"`
+	PersonnelCategory string     `json:"personnelCategory"`
+	EmploymentType    string     `json:"employmentType"`
+	AcademicDegree    string     `json:"academicDegree"`
+	AcademicTitle     string     `json:"academicTitle"`
+	Honors            string     `json:"honors"`
+	Category          string     `json:"category"`
+	DateOfEmployment  *time.Time `json:"dateOfEmployment,omitempty"`
+	UnemploymentDate  *time.Time `json:"unemploymentDate,omitempty"`
 }
 
 // CreateUserRequest is used to create a new user
@@ -34,9 +64,36 @@ type CreateUserRequest struct {
 	FirstName    string    `json:"firstName"`
 	LastName     string    `json:"lastName"`
 	MiddleName   string    `json:"middleName,omitempty"`
+	Email        string    `json:"email,omitempty"`
 	RoleID       int32     `json:"roleId"`
 	PictureURL   string    `json:"pictureUrl,omitempty"`
 	DepartmentID uuid.UUID `json:"departmentId,omitempty"`
+	Username     string    `json:"username,omitempty"`
+	Password     string    `json:"password,omitempty"`
+
+	Subdivision       string     `json:"subdivision,omitempty"`
+	JobTitle          string     `json:"jobTitle,omitempty"`
+	EmploymentRate    float64    `json:"employmentRate,omitempty"`
+	PersonnelCategory string     `json:"personnelCategory,omitempty"`
+	EmploymentType    string     `json:"employmentType,omitempty"`
+	AcademicDegree    string     `json:"academicDegree,omitempty"`
+	AcademicTitle     string     `json:"academicTitle,omitempty"`
+	Honors            string     `json:"honors,omitempty"`
+	Category          string     `json:"category,omitempty"`
+	DateOfEmployment  *time.Time `json:"dateOfEmployment,omitempty"`
+	UnemploymentDate  *time.Time `json:"unemploymentDate,omitempty"`
+}
+
+// ReplaceUserRequest is used to replace every field of a user
+type ReplaceUserRequest struct {
+	FirstName    string    `json:"firstName"`
+	LastName     string    `json:"lastName"`
+	MiddleName   string    `json:"middleName"`
+	PictureURL   string    `json:"pictureUrl"`
+	Suspended    bool      `json:"suspended"`
+	DepartmentID uuid.UUID `json:"departmentId,omitzero"`
+	RoleID       int32     `json:"roleId"`
+	JobTitle     string    `json:"jobTitle"`
 }
 
 // PatchUserRequest is used to update a user
@@ -44,10 +101,34 @@ type PatchUserRequest struct {
 	FirstName    *string    `json:"firstName,omitempty"`
 	LastName     *string    `json:"lastName,omitempty"`
 	MiddleName   *string    `json:"middleName,omitempty"`
+	Email        *string    `json:"email,omitempty"`
 	PictureURL   *string    `json:"pictureUrl,omitempty"`
 	Suspended    *bool      `json:"suspended,omitempty"`
 	DepartmentID *uuid.UUID `json:"departmentId,omitempty"`
 	RoleID       *int32     `json:"roleId,omitempty"`
+	Version      *int       `json:"version,omitempty"`
+
+	Subdivision       *string    `json:"subdivision,omitempty"`
+	JobTitle          *string    `json:"jobTitle,omitempty"`
+	EmploymentRate    *float64   `json:"employmentRate,omitempty"`
+	PersonnelCategory *string    `json:"personnelCategory,omitempty"`
+	EmploymentType    *string    `json:"employmentType,omitempty"`
+	AcademicDegree    *string    `json:"academicDegree,omitempty"`
+	AcademicTitle     *string    `json:"academicTitle,omitempty"`
+	Honors            *string    `json:"honors,omitempty"`
+	Category          *string    `json:"category,omitempty"`
+	DateOfEmployment  *time.Time `json:"dateOfEmployment,omitempty"`
+	UnemploymentDate  *time.Time `json:"unemploymentDate,omitempty"`
+}
+
+// SetRoleRequest is used to change the role of a user
+type SetRoleRequest struct {
+	RoleID int32 `json:"roleId"`
+}
+
+// SetDepartmentRequest is used to change the department of a user
+type SetDepartmentRequest struct {
+	DepartmentID uuid.UUID `json:"departmentId"`
 }
 
 // RegisterUserRequest is used to set credentials for a user
@@ -56,6 +137,35 @@ type RegisterUserRequest struct {
 	Password string `json:"password"`
 }
 
+type CredentialsExistResponse struct {
+	HasCredentials bool `json:"hasCredentials"`
+}
+
+// Account is the username of a user, as listed under /auth/credentials
+type Account struct {
+	UserID   uuid.UUID `json:"userId"`
+	Username string    `json:"username"`
+	// Password is never sent by the server, it is decoded to check that.
+	Password string `json:"password"`
+}
+
+type PermissionsRequest struct {
+	PermissionIDs []int32 `json:"permissionIds"`
+}
+
+type UsersBatchRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"oldPassword"`
+	NewPassword string `json:"newPassword"`
+}
+
+type ResetPasswordRequest struct {
+	NewPassword string `json:"newPassword"`
+}
+
 // Department represents a department in the system
 type Department struct {
 	ID          uuid.UUID `json:"id"`
@@ -65,8 +175,24 @@ type Department struct {
 
 // CreateDepartmentRequest is used to create a new department
 type CreateDepartmentRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Head        *DepartmentHeadRequest `json:"head,omitempty"`
+}
+
+// DepartmentHeadRequest describes the head created together with a new department
+type DepartmentHeadRequest struct {
+	FirstName      string  `json:"firstName"`
+	LastName       string  `json:"lastName"`
+	MiddleName     string  `json:"middleName,omitempty"`
+	JobTitle       string  `json:"jobTitle,omitempty"`
+	EmploymentRate float64 `json:"employmentRate,omitempty"`
+}
+
+// CreateDepartmentResponse is returned when a department is created together with its head
+type CreateDepartmentResponse struct {
+	Department
+	Head *User `json:"head"`
 }
 
 // UpdateDepartmentRequest is used to update a department
@@ -75,10 +201,21 @@ type UpdateDepartmentRequest struct {
 	Description string `json:"description"`
 }
 
+// TransferDepartmentUsersRequest represents the request to move the users of a department
+type TransferDepartmentUsersRequest struct {
+	TargetID uuid.UUID `json:"targetId"`
+}
+
+// TransferDepartmentUsersResponse represents the number of the moved users
+type TransferDepartmentUsersResponse struct {
+	Moved int `json:"moved"`
+}
+
 // Role represents a role in the system
 type Role struct {
 	ID          int32        `json:"id"`
 	Name        string       `json:"name"`
+	ParentID    int32        `json:"parentId"`
 	Permissions []Permission `json:"permissions"`
 }
 
@@ -91,8 +228,95 @@ type Permission struct {
 
 // Error represents an API error
 type Error struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	RuMessage string       `json:"ruMessage,omitempty"`
+	EnMessage string       `json:"enMessage,omitempty"`
+	Details   string       `json:"details,omitempty"`
+	Fields    []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes a problem with a single field of the request
+type FieldError struct {
+	Field     string `json:"field"`
 	Code      string `json:"code"`
 	Message   string `json:"message"`
-	RuMessage string `json:"ruMessage,omitempty"`
-	Details   string `json:"details,omitempty"`
+	RuMessage string `json:"ruMessage"`
+	EnMessage string `json:"enMessage"`
+}
+
+// ReadinessResponse represents the readiness probe response
+type ReadinessResponse struct {
+	Status string   `json:"status"`
+	Failed []string `json:"failed,omitempty"`
+}
+
+// EmploymentEvent represents a change of the role or the department of a user
+type EmploymentEvent struct {
+	ID              uuid.UUID `json:"id"`
+	ActorID         uuid.UUID `json:"actorId"`
+	OldRoleID       int32     `json:"oldRoleId"`
+	NewRoleID       int32     `json:"newRoleId"`
+	OldDepartmentID uuid.UUID `json:"oldDepartmentId"`
+	NewDepartmentID uuid.UUID `json:"newDepartmentId"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// AuditEntry represents a single record of the audit log
+type AuditEntry struct {
+	ID         uuid.UUID      `json:"id"`
+	ActorID    uuid.UUID      `json:"actorId"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"targetType"`
+	TargetID   uuid.UUID      `json:"targetId"`
+	Timestamp  time.Time      `json:"timestamp"`
+	Details    map[string]any `json:"details,omitempty"`
+}
+
+// RoleCount represents the number of the users with a role
+type RoleCount struct {
+	RoleID int32 `json:"roleId"`
+	Count  int   `json:"count"`
+}
+
+// Stats represents the overview of the departments and the users
+type Stats struct {
+	Departments           int         `json:"departments"`
+	Users                 int         `json:"users"`
+	UsersByRole           []RoleCount `json:"usersByRole"`
+	AverageEmploymentRate float64     `json:"averageEmploymentRate"`
+}
+
+// RouteAccess represents the access required by a route
+type RouteAccess struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Access string `json:"access"`
+}
+
+// DepartmentDeletable represents whether a department can be deleted
+type DepartmentDeletable struct {
+	Deletable bool `json:"deletable"`
+	UserCount int  `json:"userCount"`
+}
+
+// PageInfo represents the description of a page of a list
+type PageInfo struct {
+	Limit   int  `json:"limit"`
+	Offset  int  `json:"offset"`
+	Total   int  `json:"total"`
+	HasMore bool `json:"hasMore"`
+}
+
+// ReadOnly represents the read-only mode of the service
+type ReadOnly struct {
+	ReadOnly bool `json:"readOnly"`
+}
+
+// MigrationStatus represents the database schema status
+type MigrationStatus struct {
+	Hash        string    `json:"hash"`
+	AppliedHash string    `json:"appliedHash,omitempty"`
+	AppliedAt   time.Time `json:"appliedAt,omitzero"`
+	UpToDate    bool      `json:"upToDate"`
 }
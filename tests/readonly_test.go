@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/kozlov-ma/sesc-backend/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	ctx := t.Context()
+
+	client := NewClient(app.URL)
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	// 1. The service starts writable
+	state, err := client.GetReadOnly(ctx)
+	require.NoError(t, err)
+	assert.False(t, state.ReadOnly)
+
+	// 2. Writes are rejected in the read-only mode
+	state, err = client.SetReadOnly(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, state.ReadOnly)
+
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{Name: "Math"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 503")
+	assert.Contains(t, err.Error(), "READ_ONLY")
+
+	// 3. Reads and logins still work
+	_, err = client.GetDepartments(ctx)
+	require.NoError(t, err)
+
+	_, err = NewClient(app.URL).LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+
+	// 4. Writes work again once the mode is off
+	state, err = client.SetReadOnly(ctx, false)
+	require.NoError(t, err)
+	assert.False(t, state.ReadOnly)
+
+	_, err = client.CreateDepartment(ctx, CreateDepartmentRequest{Name: "Math"})
+	require.NoError(t, err)
+
+	// 5. Only admins can switch the mode
+	_, err = NewClient(app.URL).SetReadOnly(ctx, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+
+	// 6. The mode is shared by all the organizations, so the admins of other ones can't switch it
+	other := NewClient(app.URL)
+	otherToken, err := other.LoginAdmin(ctx, testutil.OtherOrgAdmin, "admin")
+	require.NoError(t, err)
+	other.SetToken(otherToken)
+
+	_, err = other.SetReadOnly(ctx, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 403")
+
+	state, err = other.GetReadOnly(ctx)
+	require.NoError(t, err)
+	assert.False(t, state.ReadOnly)
+}
@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kozlov-ma/sesc-backend/db/entdb/ent/enttest"
+	"github.com/kozlov-ma/sesc-backend/internal/app"
+	"github.com/kozlov-ma/sesc-backend/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes from the log handler.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestShutdownDrainsEvents(t *testing.T) {
+	cfg := testutil.CreateTestConfig()
+	client := enttest.Open(t, string(cfg.Database.Type), cfg.Database.Address)
+	t.Cleanup(func() { _ = client.Close() })
+
+	var logs syncBuffer
+	log := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	application, err := app.NewWithDBOptions(t.Context(), cfg, log, app.DBOptions{
+		Client:         client,
+		SkipMigrations: true,
+	})
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	application.Server.Addr = listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	application.Router.Get("/slow", func(w http.ResponseWriter, _ *http.Request) {
+		close(entered)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	started := make(chan error, 1)
+	go func() {
+		started <- application.Start(ctx)
+	}()
+
+	url := "http://" + application.Server.Addr
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url + "/healthz")
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// 1. A request is in flight when the shutdown begins
+	responded := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			responded <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		responded <- resp.StatusCode
+	}()
+	<-entered
+	cancel()
+
+	// 2. The request completes during the shutdown
+	close(release)
+	assert.Equal(t, http.StatusOK, <-responded)
+
+	// 3. Once Start returns, the event of the request has been written
+	select {
+	case err := <-started:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return after shutdown")
+	}
+	assert.Contains(t, logs.String(), `"path":"/slow"`)
+
+	application.Close()
+}
+
+func TestCloseWritesLateEvents(t *testing.T) {
+	cfg := testutil.CreateTestConfig()
+	client := enttest.Open(t, string(cfg.Database.Type), cfg.Database.Address)
+	t.Cleanup(func() { _ = client.Close() })
+
+	var logs syncBuffer
+	log := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	application, err := app.NewWithDBOptions(t.Context(), cfg, log, app.DBOptions{
+		Client:         client,
+		SkipMigrations: true,
+	})
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	application.Server.Addr = listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	application.Router.Get("/slow", func(w http.ResponseWriter, _ *http.Request) {
+		close(entered)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	started := make(chan error, 1)
+	go func() {
+		started <- application.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-started
+	})
+
+	url := "http://" + application.Server.Addr
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url + "/healthz")
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// 1. A request is in flight when the app is closed, without shutting the server down first
+	responded := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			responded <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		responded <- resp.StatusCode
+	}()
+	<-entered
+	application.Close()
+
+	// 2. The request completes after the events have been drained
+	close(release)
+	assert.Equal(t, http.StatusOK, <-responded)
+
+	// 3. Its event is still written, synchronously
+	assert.Eventually(t, func() bool {
+		return strings.Contains(logs.String(), `"path":"/slow"`)
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -1,7 +1,11 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/csv"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/kozlov-ma/sesc-backend/internal/testutil"
@@ -120,3 +124,1276 @@ func TestUserCRUD(t *testing.T) {
 	}
 	assert.True(t, found, "Newly created user not found in users list")
 }
+
+func TestCreateUserWithCredentials(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	// 1. Create a user together with their credentials
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Anna",
+		LastName:  "Smirnova",
+		RoleID:    2,
+		Username:  "asmirnova",
+		Password:  "password123",
+	})
+	require.NoError(t, err)
+
+	userClient := NewClient(app.URL)
+	_, err = userClient.Login(ctx, "asmirnova", "password123")
+	require.NoError(t, err)
+
+	currentUser, err := userClient.GetCurrentUser(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, currentUser.ID)
+
+	// 2. Try to create another user with the same username
+	usersBefore, err := client.GetUsers(ctx)
+	require.NoError(t, err)
+
+	_, err = client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Anna",
+		LastName:  "Ivanova",
+		RoleID:    2,
+		Username:  "asmirnova",
+		Password:  "another_password",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "USER_EXISTS")
+	assert.Contains(t, err.Error(), "status: 409")
+
+	// 3. The user must not be left behind without credentials
+	usersAfter, err := client.GetUsers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, usersAfter, len(usersBefore))
+	for _, u := range usersAfter {
+		assert.NotEqual(t, "Ivanova", u.LastName, "Orphaned user found")
+	}
+}
+
+func TestLoginByEmail(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	// 1. Create a user with an email and credentials
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Anna",
+		LastName:  "Smirnova",
+		Email:     "Anna.Smirnova@sesc.ru",
+		RoleID:    2,
+		Username:  "asmirnova",
+		Password:  "password123",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "anna.smirnova@sesc.ru", user.Email)
+
+	// 2. Log in by the username and by the email into the same account
+	for _, login := range []string{"asmirnova", "anna.smirnova@sesc.ru", "ANNA.SMIRNOVA@sesc.ru"} {
+		userClient := NewClient(app.URL)
+		_, err = userClient.Login(ctx, login, "password123")
+		require.NoError(t, err, login)
+
+		currentUser, err := userClient.GetCurrentUser(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, currentUser.ID, login)
+	}
+
+	_, err = NewClient(app.URL).Login(ctx, "anna.smirnova@sesc.ru", "wrong_password")
+	require.Error(t, err)
+
+	// 3. The email is unique
+	_, err = client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Anna",
+		LastName:  "Ivanova",
+		Email:     "anna.smirnova@sesc.ru",
+		RoleID:    2,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "EMAIL_EXISTS")
+	assert.Contains(t, err.Error(), "status: 409")
+
+	// 4. The email is validated
+	invalid := "anna.smirnova"
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{Email: &invalid})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "email: INVALID_VALUE")
+
+	// 5. The email can be removed
+	empty := ""
+	patched, err := client.PatchUser(ctx, user.ID.String(), PatchUserRequest{Email: &empty})
+	require.NoError(t, err)
+	assert.Empty(t, patched.Email)
+
+	_, err = NewClient(app.URL).Login(ctx, "anna.smirnova@sesc.ru", "password123")
+	require.Error(t, err)
+}
+
+func TestUserPictureURL(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Anna",
+		LastName:   "Smirnova",
+		RoleID:     2,
+		PictureURL: "/images/users/anna.jpg",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/images/users/anna.jpg", user.PictureURL)
+
+	_, err = client.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Anna",
+		LastName:   "Ivanova",
+		RoleID:     2,
+		PictureURL: "javascript:alert(1)",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_REQUEST")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	pictureURL := "https://evil.com/anna.jpg"
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{PictureURL: &pictureURL})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestCreateUserResponse(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{Name: "Math", Description: "Mathematics"})
+	require.NoError(t, err)
+
+	dateOfEmployment := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+	created, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:        "Ivan",
+		LastName:         "Petrov",
+		MiddleName:       "Sergeevich",
+		Email:            "ipetrov@sesc.ru",
+		RoleID:           1,
+		PictureURL:       "/images/users/ivan.jpg",
+		DepartmentID:     dept.ID,
+		JobTitle:         "Teacher",
+		EmploymentRate:   1,
+		DateOfEmployment: &dateOfEmployment,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, *dept, created.Department)
+
+	// The created user is returned the same way as it is read
+	got, err := client.GetUser(ctx, created.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, got, created)
+}
+
+func TestArchiveUser(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Oleg",
+		LastName:  "Sidorov",
+		RoleID:    2,
+		Username:  "osidorov",
+		Password:  "password123",
+	})
+	require.NoError(t, err)
+
+	userClient := NewClient(app.URL)
+	_, err = userClient.Login(ctx, "osidorov", "password123")
+	require.NoError(t, err)
+
+	// 1. Archive the user
+	err = client.ArchiveUser(ctx, user.ID.String())
+	require.NoError(t, err)
+
+	// 2. The user is no longer listed or fetchable
+	users, err := client.GetUsers(ctx)
+	require.NoError(t, err)
+	for _, u := range users {
+		assert.NotEqual(t, user.ID, u.ID, "Archived user found in users list")
+	}
+
+	_, err = client.GetUser(ctx, user.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// 3. The user can no longer log in, and their old token is rejected
+	_, err = userClient.GetCurrentUser(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+
+	_, err = NewClient(app.URL).Login(ctx, "osidorov", "password123")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+
+	// 4. Archiving twice is reported as not found
+	err = client.ArchiveUser(ctx, user.ID.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+}
+
+func TestExportUsersCSV(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	hired := time.Date(2015, time.September, 1, 0, 0, 0, 0, time.UTC)
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Математика",
+		Description: "Кафедра математики",
+	})
+	require.NoError(t, err)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:        "Иван",
+		LastName:         "Петров",
+		MiddleName:       "Сергеевич",
+		RoleID:           1,
+		DepartmentID:     dept.ID,
+		JobTitle:         "Учитель математики",
+		EmploymentRate:   1.5,
+		AcademicDegree:   "candidate",
+		DateOfEmployment: &hired,
+	})
+	require.NoError(t, err)
+
+	header, body, err := client.ExportUsersCSV(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, header.Get("Content-Type"), "text/csv")
+	assert.Contains(t, header.Get("Content-Disposition"), "attachment")
+
+	// Excel needs the BOM to detect UTF-8
+	require.True(t, bytes.HasPrefix(body, []byte("\uFEFF")), "CSV must start with a UTF-8 BOM")
+
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\uFEFF")))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{
+		"ID", "Фамилия", "Имя", "Отчество", "Кафедра", "Роль",
+		"Должность", "Ставка", "Учёная степень", "Дата приёма", "Дата увольнения", "Отстранён",
+	}, records[0])
+	assert.Equal(t, []string{
+		user.ID.String(), "Петров", "Иван", "Сергеевич", "Математика", "Преподаватель",
+		"Учитель математики", "1,5", "кандидат наук", "01.09.2015", "", "нет",
+	}, records[1])
+
+	// Only admins can export users
+	_, err = client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Петр",
+		LastName:  "Иванов",
+		RoleID:    2,
+		Username:  "pivanov",
+		Password:  "password123",
+	})
+	require.NoError(t, err)
+
+	userClient := NewClient(app.URL)
+	_, err = userClient.Login(ctx, "pivanov", "password123")
+	require.NoError(t, err)
+
+	_, _, err = userClient.ExportUsersCSV(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 403")
+}
+
+func TestPatchUserVersion(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Anna",
+		LastName:  "Smirnova",
+		RoleID:    2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, user.Version)
+
+	// 1. An update based on the current version succeeds and bumps it
+	version := user.Version
+	updated, err := client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		FirstName: stringPtr("Anya"),
+		Version:   &version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+
+	// 2. A second update based on the same version is rejected
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		LastName: stringPtr("Ivanova"),
+		Version:  &version,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "STALE_USER")
+	assert.Contains(t, err.Error(), "status: 409")
+
+	fetched, err := client.GetUser(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Smirnova", fetched.LastName)
+	assert.Equal(t, 2, fetched.Version)
+
+	// 3. Updates without a version keep last-write-wins semantics
+	updated, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		LastName: stringPtr("Ivanova"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated.Version)
+}
+
+func TestMergePatchUser(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Chemistry",
+		Description: "Chemistry department",
+	})
+	require.NoError(t, err)
+
+	hired := time.Date(2015, time.September, 1, 0, 0, 0, 0, time.UTC)
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:        "Olga",
+		LastName:         "Sokolova",
+		MiddleName:       "Petrovna",
+		Email:            "osokolova@sesc.ru",
+		RoleID:           2,
+		DepartmentID:     dept.ID,
+		JobTitle:         "Teacher of chemistry",
+		AcademicDegree:   "candidate",
+		DateOfEmployment: &hired,
+	})
+	require.NoError(t, err)
+
+	// 1. Omitted fields are left unchanged
+	patched, err := client.MergePatchUser(ctx, user.ID.String(), map[string]any{
+		"lastName": "Sokolova-Ivanova",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Sokolova-Ivanova", patched.LastName)
+	assert.Equal(t, "Petrovna", patched.MiddleName)
+	assert.Equal(t, "osokolova@sesc.ru", patched.Email)
+	assert.Equal(t, dept.ID, patched.Department.ID)
+	assert.Equal(t, "candidate", patched.AcademicDegree)
+	require.NotNil(t, patched.DateOfEmployment)
+
+	// 2. Null clears a field
+	patched, err = client.MergePatchUser(ctx, user.ID.String(), map[string]any{
+		"middleName":       nil,
+		"departmentId":     nil,
+		"academicDegree":   nil,
+		"dateOfEmployment": nil,
+		"jobTitle":         "Teacher of biology",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, patched.MiddleName)
+	assert.Equal(t, uuid.Nil, patched.Department.ID)
+	assert.Equal(t, "none", patched.AcademicDegree)
+	assert.Nil(t, patched.DateOfEmployment)
+	assert.Equal(t, "Teacher of biology", patched.JobTitle)
+	assert.Equal(t, "osokolova@sesc.ru", patched.Email)
+
+	fetched, err := client.GetUser(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, fetched.MiddleName)
+	assert.Equal(t, uuid.Nil, fetched.Department.ID)
+
+	// 3. Required fields can't be cleared
+	_, err = client.MergePatchUser(ctx, user.ID.String(), map[string]any{
+		"firstName": nil,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VALIDATION_ERROR")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// 4. Plain JSON patches are unaffected
+	patched, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		MiddleName: stringPtr("Ivanovna"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Ivanovna", patched.MiddleName)
+	assert.Equal(t, "Teacher of biology", patched.JobTitle)
+}
+
+func TestPatchUserRoleAndDepartment(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Biology",
+		Description: "Biology department",
+	})
+	require.NoError(t, err)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:    "Pavel",
+		LastName:     "Orlov",
+		RoleID:       1,
+		DepartmentID: dept.ID,
+	})
+	require.NoError(t, err)
+
+	// 1. A user with a department cannot become a deputy
+	deputy := int32(3)
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{RoleID: &deputy})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_ROLE_CHANGE")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// 2. Unless the department is cleared in the same patch
+	patched, err := client.MergePatchUser(ctx, user.ID.String(), map[string]any{
+		"roleId":       deputy,
+		"departmentId": nil,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, deputy, patched.Role.ID)
+	assert.Equal(t, uuid.Nil, patched.Department.ID)
+
+	// 3. A deputy cannot be assigned to a department
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{DepartmentID: &dept.ID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_ROLE_CHANGE")
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestSuspendUser(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Olga",
+		LastName:  "Kuznetsova",
+		RoleID:    2,
+		Username:  "okuznetsova",
+		Password:  "password123",
+	})
+	require.NoError(t, err)
+
+	active, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Pavel",
+		LastName:  "Popov",
+		RoleID:    2,
+	})
+	require.NoError(t, err)
+
+	userClient := NewClient(app.URL)
+	_, err = userClient.Login(ctx, "okuznetsova", "password123")
+	require.NoError(t, err)
+
+	// 1. Suspend the user
+	err = client.SuspendUser(ctx, user.ID.String())
+	require.NoError(t, err)
+
+	fetched, err := client.GetUser(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.True(t, fetched.Suspended)
+
+	// 2. Suspended users are not authorized
+	_, err = userClient.GetCurrentUser(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+
+	// 3. Users can be filtered by suspension
+	users, err := client.FilterUsers(ctx, url.Values{"suspended": {"true"}})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, user.ID, users[0].ID)
+
+	users, err = client.FilterUsers(ctx, url.Values{"suspended": {"false"}})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, active.ID, users[0].ID)
+
+	_, err = client.FilterUsers(ctx, url.Values{"suspended": {"maybe"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// 4. Reinstate the user
+	err = client.ReinstateUser(ctx, user.ID.String())
+	require.NoError(t, err)
+
+	fetched, err = client.GetUser(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.False(t, fetched.Suspended)
+
+	_, err = userClient.GetCurrentUser(ctx)
+	require.NoError(t, err)
+
+	// 5. Unknown users are reported as not found
+	randomID := uuid.Must(uuid.NewV7()).String()
+	err = client.SuspendUser(ctx, randomID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+
+	err = client.ReinstateUser(ctx, randomID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+}
+
+func TestSetRole(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Физика",
+		Description: "Кафедра физики",
+	})
+	require.NoError(t, err)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:    "Sergey",
+		LastName:     "Orlov",
+		RoleID:       1,
+		DepartmentID: dept.ID,
+	})
+	require.NoError(t, err)
+
+	// 1. A teacher can become a department head and keep the department
+	err = client.SetRole(ctx, user.ID.String(), 2)
+	require.NoError(t, err)
+
+	fetched, err := client.GetUser(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetched.Role.ID)
+	assert.Equal(t, dept.ID, fetched.Department.ID)
+
+	// 2. A user with a department cannot become a deputy
+	err = client.SetRole(ctx, user.ID.String(), 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_ROLE_CHANGE")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	fetched, err = client.GetUser(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fetched.Role.ID)
+
+	// 3. Unknown roles are rejected
+	err = client.SetRole(ctx, user.ID.String(), 999)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_ROLE")
+}
+
+func TestEmploymentHistory(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Химия",
+		Description: "Кафедра химии",
+	})
+	require.NoError(t, err)
+
+	user, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Olga",
+		LastName:  "Belova",
+		RoleID:    1,
+	})
+	require.NoError(t, err)
+
+	// 1. A new user has no history
+	history, err := client.GetEmploymentHistory(ctx, user.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	// 2. Role and department changes are recorded on behalf of the admin
+	require.NoError(t, client.SetDepartment(ctx, user.ID.String(), dept.ID))
+	require.NoError(t, client.SetRole(ctx, user.ID.String(), 2))
+
+	history, err = client.GetEmploymentHistory(ctx, user.ID.String())
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	assert.Equal(t, uuid.Nil, history[0].OldDepartmentID)
+	assert.Equal(t, dept.ID, history[0].NewDepartmentID)
+	assert.Equal(t, int32(1), history[1].OldRoleID)
+	assert.Equal(t, int32(2), history[1].NewRoleID)
+	assert.Equal(t, dept.ID, history[1].NewDepartmentID)
+	for _, e := range history {
+		assert.Equal(t, "f1157f63-65dc-4c3d-bcb2-4d6d55d2e3fd", e.ActorID.String())
+	}
+
+	// 3. Unknown users have no history
+	_, err = client.GetEmploymentHistory(ctx, uuid.Must(uuid.NewV7()).String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+}
+
+func TestSetDepartment(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Химия",
+		Description: "Кафедра химии",
+	})
+	require.NoError(t, err)
+
+	teacher, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Anna",
+		LastName:  "Sokolova",
+		RoleID:    1,
+	})
+	require.NoError(t, err)
+
+	deputy, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName: "Igor",
+		LastName:  "Lebedev",
+		RoleID:    4,
+	})
+	require.NoError(t, err)
+
+	// 1. Assign a department
+	err = client.SetDepartment(ctx, teacher.ID.String(), dept.ID)
+	require.NoError(t, err)
+
+	fetched, err := client.GetUser(ctx, teacher.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, dept.ID, fetched.Department.ID)
+
+	// 2. Clear the department
+	err = client.SetDepartment(ctx, teacher.ID.String(), uuid.Nil)
+	require.NoError(t, err)
+
+	fetched, err = client.GetUser(ctx, teacher.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, fetched.Department.ID)
+
+	// 3. Nonexistent departments are rejected
+	err = client.SetDepartment(ctx, teacher.ID.String(), uuid.Must(uuid.NewV7()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_DEPARTMENT")
+
+	// 4. Deputies cannot have a department
+	err = client.SetDepartment(ctx, deputy.ID.String(), dept.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_ROLE_CHANGE")
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestReplaceUser(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Физика",
+		Description: "Кафедра физики",
+	})
+	require.NoError(t, err)
+
+	createTeacher := func(t *testing.T) *User {
+		t.Helper()
+		user, err := client.CreateUser(ctx, CreateUserRequest{
+			FirstName:    "Anna",
+			LastName:     "Sokolova",
+			MiddleName:   "Petrovna",
+			RoleID:       1,
+			DepartmentID: dept.ID,
+		})
+		require.NoError(t, err)
+		return user
+	}
+
+	// 1. PATCH leaves the omitted department and middle name
+	patched := createTeacher(t)
+	lastName := "Morozova"
+	updated, err := client.PatchUser(ctx, patched.ID.String(), PatchUserRequest{LastName: &lastName})
+	require.NoError(t, err)
+	assert.Equal(t, "Morozova", updated.LastName)
+	assert.Equal(t, "Petrovna", updated.MiddleName)
+	assert.Equal(t, dept.ID, updated.Department.ID)
+
+	// 2. PUT clears them
+	replaced := createTeacher(t)
+	updated, err = client.ReplaceUser(ctx, replaced.ID.String(), ReplaceUserRequest{
+		FirstName: "Anna",
+		LastName:  "Morozova",
+		RoleID:    1,
+		JobTitle:  "Teacher of physics",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Morozova", updated.LastName)
+	assert.Empty(t, updated.MiddleName)
+	assert.Equal(t, "Teacher of physics", updated.JobTitle)
+	assert.Equal(t, uuid.Nil, updated.Department.ID)
+
+	fetched, err := client.GetUser(ctx, replaced.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, fetched.Department.ID)
+
+	// 3. PUT with a department keeps it
+	updated, err = client.ReplaceUser(ctx, replaced.ID.String(), ReplaceUserRequest{
+		FirstName:    "Anna",
+		LastName:     "Morozova",
+		RoleID:       1,
+		DepartmentID: dept.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dept.ID, updated.Department.ID)
+
+	// 4. The full body is required
+	_, err = client.ReplaceUser(ctx, replaced.ID.String(), ReplaceUserRequest{FirstName: "Anna"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VALIDATION_ERROR")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// 5. Deputies cannot have a department
+	_, err = client.ReplaceUser(ctx, replaced.ID.String(), ReplaceUserRequest{
+		FirstName:    "Anna",
+		LastName:     "Morozova",
+		RoleID:       4,
+		DepartmentID: dept.ID,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_ROLE")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// 6. Nonexistent users
+	_, err = client.ReplaceUser(ctx, uuid.Must(uuid.NewV7()).String(), ReplaceUserRequest{
+		FirstName: "Anna",
+		LastName:  "Morozova",
+		RoleID:    1,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+}
+
+func TestUserEmploymentFields(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	hired := time.Date(2015, time.September, 1, 0, 0, 0, 0, time.UTC)
+	fired := time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	// 1. All the employment fields round-trip through creation and retrieval
+	created, err := client.CreateUser(ctx, CreateUserRequest{
+		FirstName:         "Maria",
+		LastName:          "Volkova",
+		RoleID:            1,
+		Subdivision:       "Physics and mathematics",
+		JobTitle:          "Teacher of physics",
+		EmploymentRate:    1.25,
+		PersonnelCategory: "pedagogical",
+		EmploymentType:    "internal_part_time",
+		AcademicDegree:    "doctor",
+		AcademicTitle:     "Professor",
+		Honors:            "Honored Teacher",
+		Category:          "Highest",
+		DateOfEmployment:  &hired,
+		UnemploymentDate:  &fired,
+	})
+	require.NoError(t, err)
+
+	user, err := client.GetUser(ctx, created.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Physics and mathematics", user.Subdivision)
+	assert.Equal(t, "Teacher of physics", user.JobTitle)
+	assert.InDelta(t, 1.25, user.EmploymentRate, 1e-9)
+	assert.Equal(t, "pedagogical", user.PersonnelCategory)
+	assert.Equal(t, "internal_part_time", user.EmploymentType)
+	assert.Equal(t, "doctor", user.AcademicDegree)
+	assert.Equal(t, "Professor", user.AcademicTitle)
+	assert.Equal(t, "Honored Teacher", user.Honors)
+	assert.Equal(t, "Highest", user.Category)
+	require.NotNil(t, user.DateOfEmployment)
+	assert.True(t, hired.Equal(*user.DateOfEmployment))
+	require.NotNil(t, user.UnemploymentDate)
+	assert.True(t, fired.Equal(*user.UnemploymentDate))
+
+	// 2. A patch only changes the fields present in it
+	rate := 0.5
+	patched, err := client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		EmploymentRate: &rate,
+		JobTitle:       stringPtr("Head of physics"),
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, patched.EmploymentRate, 1e-9)
+	assert.Equal(t, "Head of physics", patched.JobTitle)
+	assert.Equal(t, "Professor", patched.AcademicTitle)
+	assert.Equal(t, "doctor", patched.AcademicDegree)
+	require.NotNil(t, patched.DateOfEmployment)
+	assert.True(t, hired.Equal(*patched.DateOfEmployment))
+
+	// 3. Invalid employment data is rejected
+	rate = 2.5
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		EmploymentRate: &rate,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_EMPLOYMENT_DATA")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	_, err = client.CreateUser(ctx, CreateUserRequest{
+		FirstName:        "Egor",
+		LastName:         "Volkov",
+		RoleID:           1,
+		DateOfEmployment: &fired,
+		UnemploymentDate: &hired,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_EMPLOYMENT_DATA")
+
+	// 4. Unknown enum codes are rejected
+	_, err = client.PatchUser(ctx, user.ID.String(), PatchUserRequest{
+		AcademicDegree: stringPtr("professor"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestFilterUsersByEmployment(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	create := func(name, degree, employmentType string) uuid.UUID {
+		t.Helper()
+		user, err := client.CreateUser(ctx, CreateUserRequest{
+			FirstName:      name,
+			LastName:       "Orlov",
+			RoleID:         1,
+			AcademicDegree: degree,
+			EmploymentType: employmentType,
+		})
+		require.NoError(t, err)
+		return user.ID
+	}
+	mainDoctor := create("Ivan", "doctor", "main")
+	externalDoctor := create("Petr", "doctor", "external_part_time")
+	mainCandidate := create("Oleg", "candidate", "main")
+
+	ids := func(users []User) []uuid.UUID {
+		res := make([]uuid.UUID, len(users))
+		for i, u := range users {
+			res[i] = u.ID
+		}
+		return res
+	}
+
+	// 1. Each filter returns the matching users
+	users, err := client.FilterUsers(ctx, url.Values{"academicDegree": {"doctor"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{mainDoctor, externalDoctor}, ids(users))
+
+	users, err = client.FilterUsers(ctx, url.Values{"employmentType": {"main"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{mainDoctor, mainCandidate}, ids(users))
+
+	// 2. The filters combine
+	users, err = client.FilterUsers(ctx, url.Values{"academicDegree": {"doctor"}, "employmentType": {"main"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{mainDoctor}, ids(users))
+
+	// 3. Unknown codes are rejected
+	_, err = client.FilterUsers(ctx, url.Values{"academicDegree": {"professor"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+
+	_, err = client.FilterUsers(ctx, url.Values{"employmentType": {"seasonal"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestGetUsersBatch(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(adminToken)
+
+	dept, err := client.CreateDepartment(ctx, CreateDepartmentRequest{
+		Name:        "Batch Department",
+		Description: "Department for the batch test",
+	})
+	require.NoError(t, err)
+
+	var ids []uuid.UUID
+	for _, name := range []string{"Ivan", "Petr"} {
+		user, err := client.CreateUser(ctx, CreateUserRequest{
+			FirstName:    name,
+			LastName:     "Orlov",
+			RoleID:       1,
+			DepartmentID: dept.ID,
+		})
+		require.NoError(t, err)
+		ids = append(ids, user.ID)
+	}
+
+	// 1. Existing users are returned in the requested order, missing ones are omitted
+	users, err := client.GetUsersBatch(ctx, []uuid.UUID{ids[1], uuid.Must(uuid.NewV7()), ids[0]})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, ids[1], users[0].ID)
+	assert.Equal(t, ids[0], users[1].ID)
+	assert.Equal(t, "Batch Department", users[0].Department.Name)
+
+	// 2. No IDs, no users
+	users, err = client.GetUsersBatch(ctx, []uuid.UUID{})
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	// 3. The number of IDs is capped
+	tooMany := make([]uuid.UUID, 101)
+	for i := range tooMany {
+		tooMany[i] = uuid.Must(uuid.NewV7())
+	}
+	_, err = client.GetUsersBatch(ctx, tooMany)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// 4. The IDs are required
+	_, err = client.GetUsersBatch(ctx, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ids: REQUIRED")
+}
+
+func TestCurrentUserPermissions(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	adminClient := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := adminClient.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	adminClient.SetToken(adminToken)
+
+	// Admins get all the permissions
+	catalog, err := adminClient.GetPermissions(ctx)
+	require.NoError(t, err)
+	perms, err := adminClient.GetCurrentUserPermissions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, catalog, perms)
+
+	teacher, err := adminClient.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Test",
+		LastName:   "Teacher",
+		RoleID:     1,
+		PictureURL: "/images/test.jpg",
+	})
+	require.NoError(t, err)
+
+	err = adminClient.RegisterUser(ctx, teacher.ID.String(), RegisterUserRequest{
+		Username: "teacher",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	teacherClient := NewClient(app.URL)
+	teacherToken, err := teacherClient.Login(ctx, "teacher", "password123")
+	require.NoError(t, err)
+	teacherClient.SetToken(teacherToken)
+
+	roles, err := adminClient.GetRoles(ctx)
+	require.NoError(t, err)
+	var teacherRole Role
+	for _, r := range roles {
+		if r.ID == 1 {
+			teacherRole = r
+		}
+	}
+	require.NotEmpty(t, teacherRole.Permissions)
+
+	perms, err = teacherClient.GetCurrentUserPermissions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, teacherRole.Permissions, perms)
+
+	// Unauthenticated requests are rejected
+	_, err = NewClient(app.URL).GetCurrentUserPermissions(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 401")
+}
+
+func TestRoleInheritance(t *testing.T) {
+	app := testutil.StartTestApp(t)
+	ctx := t.Context()
+
+	roles, err := NewClient(app.URL).GetRoles(ctx)
+	require.NoError(t, err)
+
+	byID := make(map[int32]Role, len(roles))
+	for _, r := range roles {
+		byID[r.ID] = r
+	}
+	teacher, dephead := byID[1], byID[2]
+
+	// The department head inherits the permissions of the teacher
+	assert.Equal(t, int32(1), dephead.ParentID)
+	assert.Zero(t, teacher.ParentID)
+	require.NotEmpty(t, teacher.Permissions)
+	for _, p := range teacher.Permissions {
+		assert.Contains(t, dephead.Permissions, p)
+	}
+	assert.Greater(t, len(dephead.Permissions), len(teacher.Permissions), "The department head has its own permissions too")
+}
+
+func TestGrantPermissions(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	adminClient := NewClient(app.URL)
+	ctx := t.Context()
+
+	adminToken, err := adminClient.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	adminClient.SetToken(adminToken)
+
+	teacher, err := adminClient.CreateUser(ctx, CreateUserRequest{
+		FirstName:  "Test",
+		LastName:   "Teacher",
+		RoleID:     1,
+		PictureURL: "/images/test.jpg",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, teacher.ExtraPermissions)
+
+	err = adminClient.RegisterUser(ctx, teacher.ID.String(), RegisterUserRequest{
+		Username: "teacher",
+		Password: "password123",
+	})
+	require.NoError(t, err)
+
+	teacherClient := NewClient(app.URL)
+	teacherToken, err := teacherClient.Login(ctx, "teacher", "password123")
+	require.NoError(t, err)
+	teacherClient.SetToken(teacherToken)
+
+	permissionIDs := func(perms []Permission) []int32 {
+		ids := make([]int32, len(perms))
+		for i, p := range perms {
+			ids[i] = p.ID
+		}
+		return ids
+	}
+
+	// Grant
+	err = adminClient.GrantPermissions(ctx, teacher.ID.String(), 3, 2)
+	require.NoError(t, err)
+
+	user, err := adminClient.GetUser(ctx, teacher.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, []int32{2, 3}, permissionIDs(user.ExtraPermissions))
+
+	perms, err := teacherClient.GetCurrentUserPermissions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1, 2, 3}, permissionIDs(perms))
+
+	// Revoke
+	err = adminClient.RevokePermissions(ctx, teacher.ID.String(), 2)
+	require.NoError(t, err)
+
+	perms, err = teacherClient.GetCurrentUserPermissions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1, 3}, permissionIDs(perms))
+
+	// Unknown permission
+	err = adminClient.GrantPermissions(ctx, teacher.ID.String(), 100)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_PERMISSION")
+	assert.Contains(t, err.Error(), "status: 400")
+
+	// Unknown user
+	err = adminClient.GrantPermissions(ctx, uuid.Must(uuid.NewV7()).String(), 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 404")
+
+	// Only admins may grant permissions
+	err = teacherClient.GrantPermissions(ctx, teacher.ID.String(), 4)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 403")
+}
+
+func TestUsersPagination(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	token, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(token)
+
+	created := make(map[uuid.UUID]bool)
+	for range 5 {
+		user, err := client.CreateUser(ctx, CreateUserRequest{
+			FirstName: "Page",
+			LastName:  "User",
+			RoleID:    2,
+		})
+		require.NoError(t, err)
+		created[user.ID] = true
+	}
+
+	// Walk all the pages through the cursors
+	seen := make(map[uuid.UUID]bool)
+	var previous uuid.UUID
+	query := url.Values{"limit": {"2"}}
+	for pages := 1; ; pages++ {
+		require.LessOrEqual(t, pages, 3, "5 users fit in 3 pages of 2")
+
+		users, next, err := client.GetUsersPage(ctx, query)
+		require.NoError(t, err)
+		for _, u := range users {
+			assert.False(t, seen[u.ID], "User %s returned twice", u.ID)
+			assert.Positive(t, bytes.Compare(u.ID.Bytes(), previous.Bytes()), "Users should be ordered by ID")
+			seen[u.ID] = true
+			previous = u.ID
+		}
+
+		if next == uuid.Nil {
+			break
+		}
+		query.Set("after", next.String())
+	}
+	assert.Equal(t, created, seen, "Every user should be returned")
+
+	// Without after and limit, all the users are returned at once
+	users, next, err := client.GetUsersPage(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, users, 5)
+	assert.Equal(t, uuid.Nil, next)
+
+	_, _, err = client.GetUsersPage(ctx, url.Values{"after": {"not-a-uuid"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+
+	_, _, err = client.GetUsersPage(ctx, url.Values{"limit": {"0"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
+
+func TestUsersOffsetPagination(t *testing.T) {
+	app := testutil.StartTestApp(t)
+
+	client := NewClient(app.URL)
+	ctx := t.Context()
+
+	token, err := client.LoginAdmin(ctx, "admin", "admin")
+	require.NoError(t, err)
+	client.SetToken(token)
+
+	for range 5 {
+		_, err := client.CreateUser(ctx, CreateUserRequest{
+			FirstName: "Page",
+			LastName:  "User",
+			RoleID:    2,
+		})
+		require.NoError(t, err)
+	}
+
+	// A page that is not the last one
+	users, page, err := client.GetUsersOffsetPage(ctx, url.Values{"offset": {"0"}, "limit": {"2"}})
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	require.NotNil(t, page)
+	assert.Equal(t, PageInfo{Limit: 2, Offset: 0, Total: 5, HasMore: true}, *page)
+
+	// The last page
+	users, page, err = client.GetUsersOffsetPage(ctx, url.Values{"offset": {"4"}, "limit": {"2"}})
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	require.NotNil(t, page)
+	assert.Equal(t, PageInfo{Limit: 2, Offset: 4, Total: 5, HasMore: false}, *page)
+
+	// Without offset, there is no page description
+	_, page, err = client.GetUsersOffsetPage(ctx, nil)
+	require.NoError(t, err)
+	assert.Nil(t, page)
+
+	_, _, err = client.GetUsersOffsetPage(ctx, url.Values{"offset": {"-1"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status: 400")
+}
@@ -0,0 +1,266 @@
+// Package webhook notifies downstream systems about the user lifecycle events with signed HTTP POST requests.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+)
+
+// EventType names a user lifecycle event.
+type EventType string
+
+const (
+	UserCreated    EventType = "user.created"
+	UserSuspended  EventType = "user.suspended"
+	UserReinstated EventType = "user.reinstated"
+	UserArchived   EventType = "user.archived"
+)
+
+// Event is the JSON payload of a webhook request.
+type Event struct {
+	Type      EventType `json:"type"`
+	UserID    uuid.UUID `json:"userId"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+const (
+	// SignatureHeader holds "sha256=" followed by the hex HMAC-SHA256 of the request body, see Sign.
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader holds the type of the event.
+	EventHeader = "X-Webhook-Event"
+)
+
+// Sign returns the value of the SignatureHeader for body signed with secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+const (
+	DefaultAttempts  = 5
+	DefaultBackoff   = 500 * time.Millisecond
+	DefaultQueueSize = 1000
+	DefaultTimeout   = 10 * time.Second
+)
+
+// EventSink processes the records of the deliveries.
+type EventSink interface {
+	ProcessEvent(rec *event.Record)
+}
+
+// Sender delivers the events to a URL in background, one at a time, in the order they were queued.
+// Each delivery is recorded as a "webhook_delivery" event.
+type Sender struct {
+	url      string
+	secret   []byte
+	sink     EventSink
+	client   *http.Client
+	attempts int
+	backoff  time.Duration
+
+	queue chan Event
+	// ctx is canceled when Close gives up waiting, to stop the delivery in progress.
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// Option configures a Sender created by New.
+type Option func(*Sender)
+
+// WithAttempts sets the number of attempts to deliver an event. A non-positive n keeps the DefaultAttempts.
+func WithAttempts(n int) Option {
+	return func(s *Sender) {
+		if n > 0 {
+			s.attempts = n
+		}
+	}
+}
+
+// WithBackoff sets the delay before the second attempt, doubled before every next one.
+func WithBackoff(d time.Duration) Option {
+	return func(s *Sender) {
+		s.backoff = d
+	}
+}
+
+// WithHTTPClient sets the client the requests are sent with.
+func WithHTTPClient(c *http.Client) Option {
+	return func(s *Sender) {
+		s.client = c
+	}
+}
+
+// WithQueueSize sets the number of the events waiting for delivery, past which new events are dropped.
+func WithQueueSize(n int) Option {
+	return func(s *Sender) {
+		s.queue = make(chan Event, n)
+	}
+}
+
+// New creates a Sender delivering the events to url, signed with secret, and starts delivering.
+// Close must be called to stop it.
+func New(url string, secret []byte, sink EventSink, opts ...Option) *Sender {
+	s := &Sender{
+		url:      url,
+		secret:   secret,
+		sink:     sink,
+		client:   &http.Client{Timeout: DefaultTimeout},
+		attempts: DefaultAttempts,
+		backoff:  DefaultBackoff,
+		queue:    make(chan Event, DefaultQueueSize),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	go s.run()
+	return s
+}
+
+// Notify queues e for delivery without waiting for it. If the queue is full or the Sender is closed,
+// the event is dropped and an error is added to the record in ctx.
+func (s *Sender) Notify(ctx context.Context, e Event) {
+	rec := event.Get(ctx).Sub("webhook")
+	rec.Set("type", e.Type)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		rec.Add(events.Error, fmt.Errorf("couldn't queue webhook %s: sender is closed", e.Type))
+		rec.Set("queued", false)
+		return
+	}
+
+	select {
+	case s.queue <- e:
+		rec.Set("queued", true)
+	default:
+		rec.Add(events.Error, fmt.Errorf("couldn't queue webhook %s: queue is full", e.Type))
+		rec.Set("queued", false)
+	}
+}
+
+// Close stops accepting new events and waits for the queued ones to be delivered, until ctx is done.
+// Then the delivery in progress is canceled and the rest of the queue is dropped.
+func (s *Sender) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return fmt.Errorf("webhooks are still being delivered: %w", ctx.Err())
+	}
+}
+
+func (s *Sender) run() {
+	defer close(s.done)
+	defer s.cancel()
+
+	for e := range s.queue {
+		if s.ctx.Err() != nil {
+			continue
+		}
+		s.deliver(e)
+	}
+}
+
+// deliver posts e, retrying with an exponential backoff, and records the outcome.
+func (s *Sender) deliver(e Event) {
+	ctx, rec := event.NewRecord(s.ctx, "webhook_delivery")
+	defer s.sink.ProcessEvent(rec)
+
+	rec.Set(
+		"time", time.Now(),
+		"type", e.Type,
+		"user_id", e.UserID,
+	)
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't encode webhook: %w", err))
+		rec.Set("success", false)
+		return
+	}
+
+	delay := s.backoff
+	attempt := 0
+	for {
+		attempt++
+		err = s.post(ctx, e.Type, body)
+		if err == nil || attempt == s.attempts || !sleep(ctx, delay) {
+			break
+		}
+		delay *= 2
+	}
+
+	rec.Set("attempts", attempt)
+	if err != nil {
+		rec.Add(events.Error, fmt.Errorf("couldn't deliver webhook: %w", err))
+		rec.Set("success", false)
+		return
+	}
+	rec.Set("success", true)
+}
+
+// sleep waits for d, and returns false if ctx is done before that.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *Sender) post(ctx context.Context, eventType EventType, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(eventType))
+	req.Header.Set(SignatureHeader, Sign(s.secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
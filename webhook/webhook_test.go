@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/kozlov-ma/sesc-backend/pkg/event"
+	"github.com/kozlov-ma/sesc-backend/pkg/event/events"
+	"github.com/stretchr/testify/require"
+)
+
+// chanSink passes the records of the deliveries to a channel.
+type chanSink chan *event.Record
+
+func (s chanSink) ProcessEvent(rec *event.Record) {
+	s <- rec
+}
+
+// delivery is a request received by the stub receiver.
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+func TestSender(t *testing.T) {
+	secret := []byte("webhook_secret")
+
+	// setup starts a receiver responding with the given statuses, then with 204, and a Sender delivering to it.
+	setup := func(t *testing.T, statuses ...int) (*Sender, chan delivery, chanSink) {
+		t.Helper()
+		deliveries := make(chan delivery, 10)
+		var calls atomic.Int32
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			deliveries <- delivery{header: r.Header, body: body}
+
+			if i := int(calls.Add(1)) - 1; i < len(statuses) {
+				w.WriteHeader(statuses[i])
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(receiver.Close)
+
+		sink := make(chanSink, 10)
+		s := New(receiver.URL, secret, sink, WithAttempts(3), WithBackoff(time.Millisecond))
+		t.Cleanup(func() { _ = s.Close(context.Background()) })
+		return s, deliveries, sink
+	}
+
+	notify := func(t *testing.T, s *Sender, e Event) *event.Record {
+		t.Helper()
+		ctx, rec := event.NewRecord(t.Context(), "test")
+		s.Notify(ctx, e)
+		return rec
+	}
+
+	userID := uuid.Must(uuid.NewV7())
+	created := Event{
+		Type:      UserCreated,
+		UserID:    userID,
+		Timestamp: time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("signed payload", func(t *testing.T) {
+		s, deliveries, sink := setup(t)
+
+		rec := notify(t, s, created)
+		require.Equal(t, true, rec.Value("webhook.queued"))
+
+		d := <-deliveries
+		require.Equal(t, "application/json", d.header.Get("Content-Type"))
+		require.Equal(t, string(UserCreated), d.header.Get(EventHeader))
+		require.Equal(t, Sign(secret, d.body), d.header.Get(SignatureHeader))
+		require.NotEqual(t, Sign([]byte("another_secret"), d.body), d.header.Get(SignatureHeader))
+
+		var got Event
+		require.NoError(t, json.Unmarshal(d.body, &got))
+		require.Equal(t, created, got)
+
+		delivered := <-sink
+		require.Equal(t, "webhook_delivery", delivered.EventName())
+		require.Equal(t, true, delivered.Value("success"))
+		require.Equal(t, 1, delivered.Value("attempts"))
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		s, deliveries, sink := setup(t, http.StatusInternalServerError, http.StatusBadGateway)
+
+		notify(t, s, created)
+
+		for range 3 {
+			d := <-deliveries
+			require.Equal(t, Sign(secret, d.body), d.header.Get(SignatureHeader))
+		}
+
+		delivered := <-sink
+		require.Equal(t, true, delivered.Value("success"))
+		require.Equal(t, 3, delivered.Value("attempts"))
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		s, _, sink := setup(t, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError)
+
+		notify(t, s, created)
+
+		delivered := <-sink
+		require.Equal(t, false, delivered.Value("success"))
+		require.Equal(t, 3, delivered.Value("attempts"))
+		err, ok := delivered.Value(events.Error).(error)
+		require.True(t, ok)
+		require.ErrorContains(t, err, "500")
+	})
+
+	t.Run("in order", func(t *testing.T) {
+		s, deliveries, _ := setup(t)
+
+		for _, eventType := range []EventType{UserCreated, UserSuspended, UserReinstated, UserArchived} {
+			notify(t, s, Event{Type: eventType, UserID: userID})
+		}
+		require.NoError(t, s.Close(t.Context()))
+
+		for _, eventType := range []EventType{UserCreated, UserSuspended, UserReinstated, UserArchived} {
+			d := <-deliveries
+			require.Equal(t, string(eventType), d.header.Get(EventHeader))
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		s, _, _ := setup(t)
+		require.NoError(t, s.Close(t.Context()))
+
+		rec := notify(t, s, created)
+		require.Equal(t, false, rec.Value("webhook.queued"))
+		require.NotNil(t, rec.Value("webhook."+events.Error))
+	})
+}
+
+func TestSenderDoesNotBlock(t *testing.T) {
+	unblock := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer receiver.Close()
+	defer close(unblock)
+
+	sink := make(chanSink, 10)
+	s := New(receiver.URL, nil, sink, WithQueueSize(1))
+
+	var recs []*event.Record
+	start := time.Now()
+	for range 5 {
+		ctx, rec := event.NewRecord(t.Context(), "test")
+		s.Notify(ctx, Event{Type: UserCreated})
+		recs = append(recs, rec)
+	}
+	require.Less(t, time.Since(start), time.Second, "Notify must not wait for the delivery")
+	require.Equal(t, false, recs[4].Value("webhook.queued"), "The queue is full")
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, s.Close(ctx), context.DeadlineExceeded)
+
+	delivered := <-sink
+	require.Equal(t, false, delivered.Value("success"))
+	err, _ := delivered.Value(events.Error).(error)
+	require.True(t, errors.Is(err, context.Canceled), "The delivery in progress is canceled: %v", err)
+}